@@ -32,6 +32,11 @@ func getVersion() string {
 }
 
 func main() {
+	// --offline is checked ahead of flag parsing so no telemetry is sent
+	if cli.OfflineRequested(os.Args[1:]) {
+		os.Setenv("SHIP_TELEMETRY", "false")
+	}
+
 	// Initialize telemetry
 	if err := telemetry.Init(); err != nil {
 		// Don't fail the CLI if telemetry fails
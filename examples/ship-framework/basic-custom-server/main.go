@@ -42,8 +42,8 @@ func (t *EchoTool) Execute(ctx context.Context, params map[string]interface{}, e
 	message, ok := params["message"].(string)
 	if !ok {
 		return &ship.ToolResult{
-			Error: fmt.Errorf("message parameter is required"),
-		}, fmt.Errorf("message parameter is required")
+			Error: fmt.Errorf("%w: message is required", ship.ErrInvalidParameter),
+		}, fmt.Errorf("%w: message is required", ship.ErrInvalidParameter)
 	}
 
 	uppercase, _ := params["uppercase"].(bool)
@@ -105,8 +105,8 @@ func (t *FileListTool) Execute(ctx context.Context, params map[string]interface{
 
 	if engine == nil {
 		return &ship.ToolResult{
-			Error: fmt.Errorf("dagger engine not available"),
-		}, fmt.Errorf("dagger engine not available")
+			Error: ship.ErrDaggerEngineNotAvailable,
+		}, ship.ErrDaggerEngineNotAvailable
 	}
 
 	// Mount current directory and run ls using Ship's Dagger wrapper
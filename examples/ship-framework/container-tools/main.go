@@ -48,8 +48,8 @@ func (t *TerraformValidateTool) Execute(ctx context.Context, params map[string]i
 
 	if engine == nil {
 		return &ship.ToolResult{
-			Error: fmt.Errorf("dagger engine not available"),
-		}, fmt.Errorf("dagger engine not available")
+			Error: ship.ErrDaggerEngineNotAvailable,
+		}, ship.ErrDaggerEngineNotAvailable
 	}
 
 	// Build command arguments
@@ -129,8 +129,8 @@ func (t *DockerLintTool) Execute(ctx context.Context, params map[string]interfac
 
 	if engine == nil {
 		return &ship.ToolResult{
-			Error: fmt.Errorf("dagger engine not available"),
-		}, fmt.Errorf("dagger engine not available")
+			Error: ship.ErrDaggerEngineNotAvailable,
+		}, ship.ErrDaggerEngineNotAvailable
 	}
 
 	// Build hadolint command
@@ -204,8 +204,8 @@ func (t *YAMLLintTool) Execute(ctx context.Context, params map[string]interface{
 
 	if engine == nil {
 		return &ship.ToolResult{
-			Error: fmt.Errorf("dagger engine not available"),
-		}, fmt.Errorf("dagger engine not available")
+			Error: ship.ErrDaggerEngineNotAvailable,
+		}, ship.ErrDaggerEngineNotAvailable
 	}
 
 	// Build yamllint command
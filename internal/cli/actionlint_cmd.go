@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var actionlintCmd = &cobra.Command{
+	Use:   "actionlint [directory|workflow-file]",
+	Short: "Lint GitHub Actions workflows with actionlint",
+	Long: `Lint GitHub Actions workflow files using actionlint.
+
+The target defaults to the current directory. Pass a single workflow file to lint
+only that file, e.g. from a pre-commit hook. Shell scripts in run: steps are
+checked with shellcheck unless --no-shellcheck is set; Python scripts are checked
+with pyflakes when --pyflakes is set.
+
+Examples:
+  # Lint workflows in the current repository
+  ship security actionlint
+
+  # Lint a single workflow and emit SARIF
+  ship security actionlint .github/workflows/ci.yml --format sarif
+
+  # Lint without shellcheck but with pyflakes
+  ship security actionlint --no-shellcheck --pyflakes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("actionlint", runActionlint),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(actionlintCmd)
+
+	actionlintCmd.Flags().String("format", "json", "Output format (json, sarif, junit, or default for actionlint's text output)")
+	actionlintCmd.Flags().Bool("shellcheck", true, "Check run: shell scripts with shellcheck")
+	actionlintCmd.Flags().Bool("no-shellcheck", false, "Disable the shellcheck integration")
+	actionlintCmd.Flags().Bool("pyflakes", false, "Check Python run: scripts with pyflakes")
+
+	registerEnumFlag(actionlintCmd, "format", "json", "sarif", formatJUnit, "default")
+}
+
+func runActionlint(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	target := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "actionlint", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "actionlint", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	actionlintModule := modules.NewActionlintModule(engine.GetClient())
+	result, err := actionlintModule.Scan(ctx, target, actionlintOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("actionlint", "scan", err.Error())
+		return "", fmt.Errorf("actionlint scan failed: %w", err)
+	}
+
+	if format, _ := cmd.Flags().GetString("format"); format == formatJUnit {
+		if result, err = sarifToJUnit("actionlint", result); err != nil {
+			return "", err
+		}
+	}
+
+	telemetry.TrackDaggerOperation("actionlint_scan", "actionlint", true, time.Since(start))
+	return result, nil
+}
+
+// actionlintOptionsFromFlags maps the command's flags onto module options
+func actionlintOptionsFromFlags(cmd *cobra.Command) []modules.ActionlintOption {
+	format, _ := cmd.Flags().GetString("format")
+	shellcheck, _ := cmd.Flags().GetBool("shellcheck")
+	noShellcheck, _ := cmd.Flags().GetBool("no-shellcheck")
+	pyflakes, _ := cmd.Flags().GetBool("pyflakes")
+
+	return []modules.ActionlintOption{
+		modules.WithActionlintFormat(toolReportFormat(format)),
+		modules.WithActionlintShellcheck(shellcheck && !noShellcheck),
+		modules.WithActionlintPyflakes(pyflakes),
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+)
+
+func actionlintConfigFromFlags() *modules.ActionlintConfig {
+	config := &modules.ActionlintConfig{}
+	for _, opt := range actionlintOptionsFromFlags(actionlintCmd) {
+		opt(config)
+	}
+	return config
+}
+
+func TestActionlintOptionsFromFlags_Defaults(t *testing.T) {
+	config := actionlintConfigFromFlags()
+	assert.True(t, config.Shellcheck)
+	assert.False(t, config.Pyflakes)
+}
+
+func TestActionlintOptionsFromFlags_NoShellcheck(t *testing.T) {
+	setFlagsForTest(t, actionlintCmd, map[string]string{"no-shellcheck": "true"})
+
+	assert.False(t, actionlintConfigFromFlags().Shellcheck)
+}
+
+func TestActionlintOptionsFromFlags_ShellcheckFalse(t *testing.T) {
+	setFlagsForTest(t, actionlintCmd, map[string]string{"shellcheck": "false"})
+
+	assert.False(t, actionlintConfigFromFlags().Shellcheck)
+}
+
+func TestActionlintOptionsFromFlags_Pyflakes(t *testing.T) {
+	setFlagsForTest(t, actionlintCmd, map[string]string{"pyflakes": "true"})
+
+	config := actionlintConfigFromFlags()
+	assert.True(t, config.Pyflakes)
+	assert.True(t, config.Shellcheck)
+}
+
+func TestActionlintOptionsFromFlags_Format(t *testing.T) {
+	assert.Equal(t, "json", actionlintConfigFromFlags().Format, "the CLI defaults to the module's json format")
+
+	setFlagsForTest(t, actionlintCmd, map[string]string{"format": "sarif"})
+	assert.Equal(t, "sarif", actionlintConfigFromFlags().Format)
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("artifact-dir", "", "Directory artifact files such as SBOMs and saved reports are written to (default: current directory)")
+}
+
+// configureArtifactDir exports --artifact-dir so MCP tools, including those in
+// ship subprocesses started by the MCP server, write artifacts into it
+func configureArtifactDir(cmd *cobra.Command) {
+	if dir, _ := cmd.Flags().GetString("artifact-dir"); dir != "" {
+		os.Setenv(shipMcp.ArtifactDirEnv, dir)
+	}
+}
+
+// cliArtifactPath resolves an output path given on the command line. Relative
+// paths go through the same --artifact-dir resolution as MCP artifacts;
+// absolute paths are kept, since the user chose them. An empty path stays
+// empty so callers can tell the output was not requested.
+func cliArtifactPath(path string) (string, error) {
+	if path == "" || filepath.IsAbs(path) {
+		return path, nil
+	}
+	return shipMcp.ArtifactPath(path)
+}
@@ -50,7 +50,7 @@ Examples:
 
   # Build with custom Dockerfile location
   ship buildx build --src-dir ./myapp --tag myapp:latest --dockerfile-path docker/Dockerfile`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: runTool("buildx", func(cmd *cobra.Command, args []string) (string, error) {
 		start := time.Now()
 		srcDir, _ := cmd.Flags().GetString("src-dir")
 		tag, _ := cmd.Flags().GetString("tag")
@@ -62,18 +62,18 @@ Examples:
 
 		if srcDir == "" {
 			telemetry.TrackError("validation", "buildx_build", "src-dir is required")
-			return fmt.Errorf("--src-dir is required")
+			return "", fmt.Errorf("--src-dir is required")
 		}
 		if tag == "" {
 			telemetry.TrackError("validation", "buildx_build", "tag is required")
-			return fmt.Errorf("--tag is required")
+			return "", fmt.Errorf("--tag is required")
 		}
 
 		ctx := context.Background()
 		engine, err := dagger.NewEngine(ctx)
 		if err != nil {
 			telemetry.TrackError("dagger", "buildx_build", err.Error())
-			return fmt.Errorf("failed to create dagger engine: %w", err)
+			return "", fmt.Errorf("failed to create dagger engine: %w", err)
 		}
 		defer engine.Close()
 
@@ -88,13 +88,12 @@ Examples:
 		
 		if err != nil {
 			telemetry.TrackError("buildx", "build", err.Error())
-			return fmt.Errorf("buildx build failed: %w", err)
+			return "", fmt.Errorf("buildx build failed: %w", err)
 		}
 
-		fmt.Println(result)
 		telemetry.TrackDaggerOperation("buildx_build", "buildx", true, duration)
-		return nil
-	},
+		return result, nil
+	}),
 }
 
 var buildxPublishCmd = &cobra.Command{
@@ -114,7 +113,7 @@ Examples:
 
   # Multi-platform publish
   ship buildx publish --src-dir ./myapp --tag myapp:latest --platform linux/amd64,linux/arm64 --username user --password pass`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: runTool("buildx", func(cmd *cobra.Command, args []string) (string, error) {
 		srcDir, _ := cmd.Flags().GetString("src-dir")
 		tag, _ := cmd.Flags().GetString("tag")
 		platform, _ := cmd.Flags().GetString("platform")
@@ -124,34 +123,33 @@ Examples:
 		dockerfilePath, _ := cmd.Flags().GetString("dockerfile-path")
 
 		if srcDir == "" {
-			return fmt.Errorf("--src-dir is required")
+			return "", fmt.Errorf("--src-dir is required")
 		}
 		if tag == "" {
-			return fmt.Errorf("--tag is required")
+			return "", fmt.Errorf("--tag is required")
 		}
 		if username == "" {
-			return fmt.Errorf("--username is required")
+			return "", fmt.Errorf("--username is required")
 		}
 		if password == "" {
-			return fmt.Errorf("--password is required")
+			return "", fmt.Errorf("--password is required")
 		}
 
 		ctx := context.Background()
 		engine, err := dagger.NewEngine(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create dagger engine: %w", err)
+			return "", fmt.Errorf("failed to create dagger engine: %w", err)
 		}
 		defer engine.Close()
 
 		buildxModule := modules.NewBuildXModule(engine.GetClient())
 		result, err := buildxModule.Publish(ctx, srcDir, tag, platform, username, password, registry, dockerfilePath)
 		if err != nil {
-			return fmt.Errorf("buildx publish failed: %w", err)
+			return "", fmt.Errorf("buildx publish failed: %w", err)
 		}
 
-		fmt.Println(result)
-		return nil
-	},
+		return result, nil
+	}),
 }
 
 var buildxDevCmd = &cobra.Command{
@@ -168,47 +166,45 @@ Examples:
 
   # Dev environment with source code mounted
   ship buildx dev --src-dir ./myapp`,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: runTool("buildx", func(cmd *cobra.Command, args []string) (string, error) {
 		srcDir, _ := cmd.Flags().GetString("src-dir")
 
 		ctx := context.Background()
 		engine, err := dagger.NewEngine(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create dagger engine: %w", err)
+			return "", fmt.Errorf("failed to create dagger engine: %w", err)
 		}
 		defer engine.Close()
 
 		buildxModule := modules.NewBuildXModule(engine.GetClient())
 		result, err := buildxModule.Dev(ctx, srcDir)
 		if err != nil {
-			return fmt.Errorf("buildx dev setup failed: %w", err)
+			return "", fmt.Errorf("buildx dev setup failed: %w", err)
 		}
 
-		fmt.Println(result)
-		return nil
-	},
+		return result, nil
+	}),
 }
 
 var buildxVersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Get Docker BuildX version information",
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: runTool("buildx", func(cmd *cobra.Command, args []string) (string, error) {
 		ctx := context.Background()
 		engine, err := dagger.NewEngine(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create dagger engine: %w", err)
+			return "", fmt.Errorf("failed to create dagger engine: %w", err)
 		}
 		defer engine.Close()
 
 		buildxModule := modules.NewBuildXModule(engine.GetClient())
 		result, err := buildxModule.GetVersion(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get buildx version: %w", err)
+			return "", fmt.Errorf("failed to get buildx version: %w", err)
 		}
 
-		fmt.Println(result)
-		return nil
-	},
+		return result, nil
+	}),
 }
 
 func init() {
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var cfnNagCmd = &cobra.Command{
+	Use:   "cfn-nag [template|directory]",
+	Short: "Scan CloudFormation templates with cfn-nag",
+	Long: `Scan CloudFormation templates for insecure patterns using cfn-nag.
+
+The target defaults to the current directory. Use --profile-path to run only the
+rule IDs listed in a profile file, and --deny-list-path to skip rule IDs.
+
+Examples:
+  # Scan all templates in the current directory
+  ship security cfn-nag
+
+  # Scan one template with a curated rule set
+  ship security cfn-nag ./template.yaml --profile-path ./cfn-nag-profile.txt
+
+  # Scan with custom rules, excluding noisy rules
+  ship security cfn-nag ./templates --rules ./custom-rules --deny-list-path ./deny-list.txt`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("cfn-nag", runCfnNag),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(cfnNagCmd)
+
+	cfnNagCmd.Flags().String("output", "json", "Output format (json, txt)")
+	cfnNagCmd.Flags().String("rules", "", "Directory containing custom rules")
+	cfnNagCmd.Flags().String("profile-path", "", "Profile file listing the only rule IDs to apply")
+	cfnNagCmd.Flags().String("deny-list-path", "", "Deny list file of rule IDs to skip")
+
+	registerEnumFlag(cfnNagCmd, "output", "json", "txt")
+}
+
+func runCfnNag(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	target := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "cfn-nag", args)
+
+	isFile, err := isRegularFile(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid scan target: %w", err)
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "cfn-nag", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	cfnNagModule := modules.NewCfnNagModule(engine.GetClient())
+	result, err := cfnNagModule.ScanWithOptions(ctx, target, !isFile, cfnNagOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("cfn-nag", "scan", err.Error())
+		return "", fmt.Errorf("cfn-nag scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("cfn_nag_scan", "cfn-nag", true, time.Since(start))
+	return result, nil
+}
+
+// cfnNagOptionsFromFlags maps the command's flags onto module options
+func cfnNagOptionsFromFlags(cmd *cobra.Command) []modules.CfnNagOption {
+	output, _ := cmd.Flags().GetString("output")
+	rules, _ := cmd.Flags().GetString("rules")
+	profilePath, _ := cmd.Flags().GetString("profile-path")
+	denyListPath, _ := cmd.Flags().GetString("deny-list-path")
+
+	return []modules.CfnNagOption{
+		modules.WithCfnNagOutputFormat(output),
+		modules.WithCfnNagRulesDir(rules),
+		modules.WithCfnNagProfile(profilePath),
+		modules.WithCfnNagDenyList(denyListPath),
+	}
+}
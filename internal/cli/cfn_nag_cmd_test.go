@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCfnNagOptionsFromFlags(t *testing.T) {
+	setFlagsForTest(t, cfnNagCmd, map[string]string{
+		"rules":          "./rules",
+		"profile-path":   "./profile.txt",
+		"deny-list-path": "./deny.txt",
+	})
+
+	config := &modules.CfnNagConfig{}
+	for _, opt := range cfnNagOptionsFromFlags(cfnNagCmd) {
+		opt(config)
+	}
+
+	assert.Equal(t, "json", config.OutputFormat)
+	assert.Equal(t, "./rules", config.RulesDir)
+	assert.Equal(t, "./profile.txt", config.ProfilePath)
+	assert.Equal(t, "./deny.txt", config.DenyListPath)
+}
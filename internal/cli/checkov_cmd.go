@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var checkovCmd = &cobra.Command{
+	Use:   "checkov [directory]",
+	Short: "Scan Infrastructure as Code with Checkov",
+	Long: `Scan Infrastructure as Code for misconfigurations using Checkov.
+
+The directory defaults to the current directory. The command exits non-zero when
+checks fail unless --soft-fail is set.
+
+Examples:
+  # Scan the current directory with all frameworks
+  ship security checkov
+
+  # Scan Terraform and Kubernetes files and emit SARIF
+  ship security checkov ./infra --framework terraform,kubernetes --output sarif
+
+  # Run only specific checks without failing the build
+  ship security checkov --check CKV_AWS_20,CKV_AWS_57 --soft-fail`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("checkov", runCheckov),
+}
+
+// checkovOutputFormats are the report formats supported by the checkov command
+var checkovOutputFormats = []string{"cli", "json", "sarif"}
+
+func init() {
+	securityToolsCmd.AddCommand(checkovCmd)
+
+	checkovCmd.Flags().String("framework", "", "Comma-separated frameworks to scan (e.g. terraform,kubernetes,dockerfile)")
+	checkovCmd.Flags().String("check", "", "Comma-separated list of check IDs to run")
+	checkovCmd.Flags().String("skip-check", "", "Comma-separated list of check IDs to skip")
+	checkovCmd.Flags().String("output", "cli", "Output format (cli, json, sarif)")
+	checkovCmd.Flags().Bool("soft-fail", false, "Report failed checks without a non-zero exit code")
+
+	registerEnumFlag(checkovCmd, "output", "cli", "json", "sarif")
+}
+
+func runCheckov(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	dir := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "checkov", args)
+
+	opts, err := checkovOptionsFromFlags(cmd)
+	if err != nil {
+		telemetry.TrackError("validation", "checkov", err.Error())
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "checkov", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	checkovModule := modules.NewCheckovModule(engine.GetClient())
+	result, err := checkovModule.Scan(ctx, dir, opts...)
+	if errors.Is(err, modules.ErrCheckovFailedChecks) {
+		// The report is still printed when checks fail
+		return result, err
+	}
+	if err != nil {
+		telemetry.TrackError("checkov", "scan", err.Error())
+		return result, fmt.Errorf("checkov scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("checkov_scan", "checkov", true, time.Since(start))
+	return result, nil
+}
+
+// checkovOptionsFromFlags maps the command's flags onto module options
+func checkovOptionsFromFlags(cmd *cobra.Command) ([]modules.CheckovOption, error) {
+	framework, _ := cmd.Flags().GetString("framework")
+	check, _ := cmd.Flags().GetString("check")
+	skipCheck, _ := cmd.Flags().GetString("skip-check")
+	output, _ := cmd.Flags().GetString("output")
+	softFail, _ := cmd.Flags().GetBool("soft-fail")
+
+	if !contains(checkovOutputFormats, output) {
+		return nil, fmt.Errorf("invalid --output %q: must be one of %v", output, checkovOutputFormats)
+	}
+
+	return []modules.CheckovOption{
+		modules.WithCheckovFrameworks(splitCommaList(framework)),
+		modules.WithCheckovChecks(splitCommaList(check)),
+		modules.WithCheckovSkipChecks(splitCommaList(skipCheck)),
+		modules.WithCheckovOutput(output),
+		modules.WithCheckovSoftFail(softFail),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkovConfigFromFlags(t *testing.T) *modules.CheckovConfig {
+	t.Helper()
+	opts, err := checkovOptionsFromFlags(checkovCmd)
+	require.NoError(t, err)
+
+	config := &modules.CheckovConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+func TestCheckovOptionsFromFlags_FrameworkSelection(t *testing.T) {
+	setFlagsForTest(t, checkovCmd, map[string]string{
+		"framework":  "terraform,kubernetes",
+		"check":      "CKV_AWS_20",
+		"skip-check": "CKV_AWS_57,CKV_AWS_18",
+		"output":     "sarif",
+	})
+
+	config := checkovConfigFromFlags(t)
+	assert.Equal(t, []string{"terraform", "kubernetes"}, config.Frameworks)
+	assert.Equal(t, []string{"CKV_AWS_20"}, config.Checks)
+	assert.Equal(t, []string{"CKV_AWS_57", "CKV_AWS_18"}, config.SkipChecks)
+	assert.Equal(t, "sarif", config.Output)
+	assert.False(t, config.SoftFail)
+}
+
+func TestCheckovOptionsFromFlags_SoftFail(t *testing.T) {
+	setFlagsForTest(t, checkovCmd, map[string]string{"soft-fail": "true"})
+
+	config := checkovConfigFromFlags(t)
+	assert.True(t, config.SoftFail)
+	assert.Empty(t, config.Frameworks)
+	assert.Equal(t, "cli", config.Output)
+}
+
+func TestCheckovOptionsFromFlags_InvalidOutput(t *testing.T) {
+	setFlagsForTest(t, checkovCmd, map[string]string{"output": "junitxml"})
+
+	_, err := checkovOptionsFromFlags(checkovCmd)
+	assert.Error(t, err)
+}
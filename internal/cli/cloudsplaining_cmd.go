@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var cloudsplainingCmd = &cobra.Command{
+	Use:   "cloudsplaining",
+	Short: "Assess AWS IAM least privilege with Cloudsplaining",
+	Long: `Download the account authorization details and flag IAM policies that violate
+least privilege using Cloudsplaining.
+
+Use --exclusions to suppress expected findings with a Cloudsplaining exclusions
+YAML file.
+
+Examples:
+  # Scan the account behind the default profile
+  ship security cloudsplaining
+
+  # Scan with an exclusions file
+  ship security cloudsplaining --profile prod --exclusions ./exclusions.yml`,
+	Args: cobra.NoArgs,
+	RunE: runTool("cloudsplaining", runCloudsplaining),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(cloudsplainingCmd)
+
+	cloudsplainingCmd.Flags().String("profile", "default", "AWS profile to use")
+	cloudsplainingCmd.Flags().String("exclusions", "", "Exclusions YAML file to suppress expected findings")
+}
+
+func runCloudsplaining(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	profile, _ := cmd.Flags().GetString("profile")
+
+	telemetry.TrackCLICommand("security", "cloudsplaining", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "cloudsplaining", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	cloudsplainingModule := modules.NewCloudsplainingModule(engine.GetClient())
+	result, err := cloudsplainingModule.ScanAccountAuthorization(ctx, profile, cloudsplainingOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("cloudsplaining", "scan", err.Error())
+		return "", fmt.Errorf("cloudsplaining scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("cloudsplaining_scan", "cloudsplaining", true, time.Since(start))
+	return result, nil
+}
+
+// cloudsplainingOptionsFromFlags maps the command's flags onto module options
+func cloudsplainingOptionsFromFlags(cmd *cobra.Command) []modules.CloudsplainingOption {
+	exclusions, _ := cmd.Flags().GetString("exclusions")
+
+	return []modules.CloudsplainingOption{
+		modules.WithCloudsplainingExclusions(exclusions),
+	}
+}
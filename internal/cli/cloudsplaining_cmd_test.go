@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudsplainingOptionsFromFlags(t *testing.T) {
+	config := &modules.CloudsplainingConfig{}
+	for _, opt := range cloudsplainingOptionsFromFlags(cloudsplainingCmd) {
+		opt(config)
+	}
+	assert.Empty(t, config.ExclusionsFile, "no exclusions by default")
+
+	setFlagsForTest(t, cloudsplainingCmd, map[string]string{"exclusions": "./exclusions.yml"})
+
+	config = &modules.CloudsplainingConfig{}
+	for _, opt := range cloudsplainingOptionsFromFlags(cloudsplainingCmd) {
+		opt(config)
+	}
+	assert.Equal(t, "./exclusions.yml", config.ExclusionsFile)
+}
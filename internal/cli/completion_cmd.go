@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/spf13/cobra"
+)
+
+// completionShells are the shells ship generates completion scripts for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the autocompletion script for a shell",
+	Long: `Generate the autocompletion script for ship for the given shell.
+
+Besides commands and flags, completion suggests tool names for ship mcp and
+the accepted values of enum flags such as --format.
+
+Examples:
+  # Load completions in the current bash session
+  source <(ship completion bash)
+
+  # Install zsh completions
+  ship completion zsh > "${fpath[1]}/_ship"
+
+  # Install fish completions
+  ship completion fish > ~/.config/fish/completions/ship.fish
+
+  # Load completions in the current PowerShell session
+  ship completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             completionShells,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.Root(), args[0], cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// writeCompletion writes root's completion script for shell to w
+func writeCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q: expected one of %v", shell, completionShells)
+	}
+}
+
+// completeMCPTools completes the tool argument of ship mcp with "all", the
+// tool categories, the registry tools and the external MCP servers
+func completeMCPTools(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return mcpToolCompletions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// mcpToolCompletions lists the values ship mcp accepts, with descriptions
+func mcpToolCompletions() []cobra.Completion {
+	completions := []cobra.Completion{cobra.CompletionWithDesc("all", "Every tool")}
+	for _, category := range mcpCategories {
+		completions = append(completions, cobra.CompletionWithDesc(category, "Tools in the "+category+" category"))
+	}
+
+	var tools []cobra.Completion
+	for _, registered := range shipMcp.ToolRegistry {
+		for _, tool := range registered {
+			tools = append(tools, cobra.CompletionWithDesc(tool.Name, tool.Description))
+		}
+	}
+	for _, name := range shipMcp.ListExternalMCPServers() {
+		tools = append(tools, cobra.CompletionWithDesc(name, "External MCP server"))
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i] < tools[j] })
+
+	return append(completions, tools...)
+}
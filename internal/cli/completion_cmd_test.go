@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"completion"})
+	require.NoError(t, err)
+	assert.Equal(t, completionCmd, cmd)
+}
+
+func TestCompletionCmdOutputsScriptForEachShell(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			completionCmd.SetOut(&out)
+			defer completionCmd.SetOut(nil)
+
+			require.NoError(t, completionCmd.RunE(completionCmd, []string{shell}))
+			assert.NotEmpty(t, out.String())
+			assert.Contains(t, out.String(), "ship")
+		})
+	}
+}
+
+func TestCompletionCmdRejectsUnknownShell(t *testing.T) {
+	assert.Error(t, completionCmd.Args(completionCmd, []string{"tcsh"}))
+	assert.Error(t, writeCompletion(rootCmd, "tcsh", &bytes.Buffer{}))
+}
+
+func TestCompleteMCPTools(t *testing.T) {
+	completions, directive := completeMCPTools(mcpCmd, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	names := make([]string, len(completions))
+	for i, completion := range completions {
+		names[i], _, _ = strings.Cut(completion, "\t")
+	}
+	assert.Equal(t, "all", names[0])
+	assert.Contains(t, names, "security")
+	assert.Contains(t, names, "trivy")
+	assert.Contains(t, names, "gitleaks")
+
+	completions, _ = completeMCPTools(mcpCmd, []string{"trivy"}, "")
+	assert.Empty(t, completions, "ship mcp takes a single tool")
+}
+
+func TestEnumFlagCompletion(t *testing.T) {
+	tests := []struct {
+		cmd  *cobra.Command
+		flag string
+		want []string
+	}{
+		{trivyCmd, "format", []string{"json", "table", "cosign-vuln", "github"}},
+		{trivyCmd, "compliance", []string{
+			"docker-cis", "docker-cis-1.6.0", "k8s-cis", "k8s-cis-1.23", "k8s-nsa", "k8s-nsa-1.0",
+			"k8s-pss-baseline", "k8s-pss-baseline-0.1", "k8s-pss-restricted", "k8s-pss-restricted-0.1",
+		}},
+		{grypeCmd, "format", grypeOutputFormats},
+		{trufflehogCmd, "type", trufflehogScanTypes},
+		{rootCmd, "output-format", []string{"text", "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cmd.Name()+" --"+tt.flag, func(t *testing.T) {
+			complete, ok := tt.cmd.GetFlagCompletionFunc(tt.flag)
+			require.True(t, ok)
+
+			completions, directive := complete(tt.cmd, nil, "")
+			assert.Equal(t, tt.want, completions)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var conftestCmd = &cobra.Command{
+	Use:   "conftest [path]",
+	Short: "Test configuration files against OPA policies with Conftest",
+	Long: `Test configuration files such as Kubernetes manifests, Terraform or
+Dockerfiles against Rego policies using Conftest.
+
+The path defaults to the current directory and may be a single file. By default
+each file is evaluated on its own against the policies in one namespace.
+--combine evaluates all files as one document, so a policy can relate
+resources declared in different files, and --all-namespaces evaluates the
+policies of every namespace.
+
+Examples:
+  # Test the current directory against ./policy
+  ship security conftest
+
+  # Test manifests against policies that span several files
+  ship security conftest ./k8s --policy ./policies --combine
+
+  # Evaluate every policy namespace
+  ship security conftest deployment.yaml --all-namespaces`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("conftest", runConftest),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(conftestCmd)
+
+	conftestCmd.Flags().String("policy", "policy", "Directory containing the Rego policies")
+	conftestCmd.Flags().String("namespace", "", "Policy namespace to evaluate (default main)")
+	conftestCmd.Flags().Bool("all-namespaces", false, "Evaluate the policies in every namespace")
+	conftestCmd.Flags().Bool("combine", false, "Combine all input files into one document before evaluating")
+	conftestCmd.Flags().String("output", "json", "Output format (json, table, tap, junit, github, sarif)")
+	conftestCmd.Flags().String("parser", "", "Parser to use for the input files (e.g. yaml, hcl2, dockerfile)")
+
+	registerEnumFlag(conftestCmd, "output", "json", "table", "tap", "junit", "github", "sarif")
+}
+
+func runConftest(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	path := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "conftest", args)
+
+	namespace, _ := cmd.Flags().GetString("namespace")
+	allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+	if namespace != "" && allNamespaces {
+		return "", fmt.Errorf("--namespace and --all-namespaces cannot be used together")
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "conftest", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	conftestModule := modules.NewConftestModule(engine.GetClient())
+	result, err := conftestModule.Test(ctx, path, conftestOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("conftest", "test", err.Error())
+		return "", fmt.Errorf("conftest test failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("conftest_test", "conftest", true, time.Since(start))
+	return result, nil
+}
+
+// conftestOptionsFromFlags maps the command's flags onto module options
+func conftestOptionsFromFlags(cmd *cobra.Command) []modules.ConftestOption {
+	policy, _ := cmd.Flags().GetString("policy")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+	combine, _ := cmd.Flags().GetBool("combine")
+	output, _ := cmd.Flags().GetString("output")
+	parser, _ := cmd.Flags().GetString("parser")
+
+	return []modules.ConftestOption{
+		modules.WithConftestPolicyPath(policy),
+		modules.WithConftestNamespace(namespace),
+		modules.WithConftestAllNamespaces(allNamespaces),
+		modules.WithConftestCombine(combine),
+		modules.WithConftestOutput(output),
+		modules.WithConftestParser(parser),
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConftestCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "conftest"})
+	require.NoError(t, err)
+	assert.Equal(t, conftestCmd, cmd)
+}
+
+func TestConftestOptionsFromFlags_Combine(t *testing.T) {
+	setFlagsForTest(t, conftestCmd, map[string]string{
+		"policy":  "./policies",
+		"combine": "true",
+	})
+
+	config := &modules.ConftestConfig{}
+	for _, opt := range conftestOptionsFromFlags(conftestCmd) {
+		opt(config)
+	}
+
+	assert.True(t, config.Combine)
+	assert.False(t, config.AllNamespaces)
+	assert.Equal(t, "./policies", config.PolicyPath)
+}
+
+func TestConftestOptionsFromFlags_AllNamespaces(t *testing.T) {
+	setFlagsForTest(t, conftestCmd, map[string]string{
+		"all-namespaces": "true",
+	})
+
+	config := &modules.ConftestConfig{}
+	for _, opt := range conftestOptionsFromFlags(conftestCmd) {
+		opt(config)
+	}
+
+	assert.True(t, config.AllNamespaces)
+	assert.False(t, config.Combine)
+	assert.Empty(t, config.Namespace)
+}
+
+func TestConftestOptionsFromFlags_Defaults(t *testing.T) {
+	config := &modules.ConftestConfig{}
+	for _, opt := range conftestOptionsFromFlags(conftestCmd) {
+		opt(config)
+	}
+
+	assert.Equal(t, "policy", config.PolicyPath)
+	assert.Equal(t, "json", config.Output)
+	assert.False(t, config.Combine)
+	assert.False(t, config.AllNamespaces)
+}
+
+func TestRunConftest_NamespaceConflictsWithAllNamespaces(t *testing.T) {
+	setFlagsForTest(t, conftestCmd, map[string]string{
+		"namespace":      "kubernetes",
+		"all-namespaces": "true",
+	})
+
+	_, err := runConftest(conftestCmd, nil)
+	assert.ErrorContains(t, err, "--namespace and --all-namespaces cannot be used together")
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var cosignCmd = &cobra.Command{
+	Use:   "cosign",
+	Short: "Manage container image artifacts with Cosign",
+	Long:  `Attach and download supply chain artifacts stored alongside container images using Cosign.`,
+}
+
+var cosignAttachSBOMCmd = &cobra.Command{
+	Use:   "attach-sbom <image>",
+	Short: "Attach an SBOM to an image in its registry",
+	Long: `Attach an SBOM file to a container image with 'cosign attach sbom'. The SBOM
+is stored in the image's registry next to the image. Its type (spdx, cyclonedx
+or syft) is detected from the file unless --type is given.
+
+Registry credentials are read from the Docker configuration ($DOCKER_CONFIG or
+~/.docker/config.json), which is mounted into the cosign container. Credentials
+kept in a credential helper are not available there; run 'docker login' with
+a plain credential store, or log in with cosign login, first.
+
+Examples:
+  ship security cosign attach-sbom ghcr.io/org/app:1.0 --sbom ./sbom.spdx.json
+
+  # Attach a CycloneDX SBOM
+  ship security cosign attach-sbom ghcr.io/org/app:1.0 --sbom ./sbom.cdx.json --type cyclonedx`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTool("cosign", runCosignAttachSBOM),
+}
+
+var cosignDownloadSBOMCmd = &cobra.Command{
+	Use:   "download-sbom <image>",
+	Short: "Download the SBOM attached to an image",
+	Long: `Download the SBOM attached to a container image with 'cosign download sbom'.
+The SBOM is printed unless --output is given. Registry credentials are read
+from the Docker configuration, as for attach-sbom.
+
+Examples:
+  # Print the SBOM
+  ship security cosign download-sbom ghcr.io/org/app:1.0
+
+  # Save the SBOM to a file
+  ship security cosign download-sbom ghcr.io/org/app:1.0 --output ./sbom.spdx.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTool("cosign", runCosignDownloadSBOM),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(cosignCmd)
+	cosignCmd.AddCommand(cosignAttachSBOMCmd)
+	cosignCmd.AddCommand(cosignDownloadSBOMCmd)
+
+	cosignAttachSBOMCmd.Flags().String("sbom", "", "Path to the SBOM file to attach (required)")
+	cosignAttachSBOMCmd.Flags().String("type", "", "SBOM type: spdx, cyclonedx or syft (default: detected from the file)")
+	registerEnumFlag(cosignAttachSBOMCmd, "type", modules.CosignSBOMTypes...)
+	cosignDownloadSBOMCmd.Flags().String("output", "", "File to write the SBOM to (default: print it)")
+}
+
+func runCosignAttachSBOM(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	image := args[0]
+	sbom, _ := cmd.Flags().GetString("sbom")
+	sbomType, _ := cmd.Flags().GetString("type")
+
+	telemetry.TrackCLICommand("security", "cosign_attach-sbom", args)
+
+	if sbom == "" {
+		err := fmt.Errorf("--sbom is required")
+		telemetry.TrackError("validation", "cosign_attach-sbom", err.Error())
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "cosign", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	cosignModule := modules.NewCosignModule(engine.GetClient())
+	result, err := cosignModule.AttachSBOM(ctx, image, sbom, sbomType)
+	if err != nil {
+		telemetry.TrackError("cosign", "attach-sbom", err.Error())
+		return "", err
+	}
+
+	telemetry.TrackDaggerOperation("cosign_attach_sbom", "cosign", true, time.Since(start))
+	return result, nil
+}
+
+func runCosignDownloadSBOM(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	image := args[0]
+	output, _ := cmd.Flags().GetString("output")
+
+	telemetry.TrackCLICommand("security", "cosign_download-sbom", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "cosign", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	cosignModule := modules.NewCosignModule(engine.GetClient())
+	result, err := cosignModule.DownloadSBOM(ctx, image, output)
+	if err != nil {
+		telemetry.TrackError("cosign", "download-sbom", err.Error())
+		return "", err
+	}
+
+	telemetry.TrackDaggerOperation("cosign_download_sbom", "cosign", true, time.Since(start))
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosignSBOMRouting(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "cosign", "attach-sbom"})
+	require.NoError(t, err)
+	assert.Equal(t, cosignAttachSBOMCmd, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("sbom"))
+
+	cmd, _, err = rootCmd.Find([]string{"security", "cosign", "download-sbom"})
+	require.NoError(t, err)
+	assert.Equal(t, cosignDownloadSBOMCmd, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+}
+
+func TestCosignAttachSBOMRequiresSBOM(t *testing.T) {
+	cmd := &cobra.Command{Use: "attach-sbom"}
+	cmd.Flags().String("sbom", "", "")
+
+	_, err := runCosignAttachSBOM(cmd, []string{"ghcr.io/org/app:1.0"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--sbom")
+}
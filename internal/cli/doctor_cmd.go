@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that Ship's runtime dependencies are available",
+	Long: `Run diagnostics for the environment Ship needs to execute tools:
+
+  - Docker daemon availability
+  - Dagger engine connectivity
+  - Network access to common container registries
+
+Each check is reported individually and the command fails if any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Duration("timeout", 30*time.Second, "Timeout for each check")
+}
+
+// doctorRegistries are probed for network reachability. Any HTTP response,
+// including 401 from the registry API, counts as reachable.
+var doctorRegistries = []string{
+	"https://registry-1.docker.io/v2/",
+	"https://ghcr.io/v2/",
+	"https://quay.io/v2/",
+}
+
+// doctorCheck is a single named diagnostic probe
+type doctorCheck struct {
+	Name  string
+	Probe func(ctx context.Context) (string, error)
+}
+
+// doctorResult is the outcome of one check
+type doctorResult struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	telemetry.TrackCLICommand("doctor", "", args)
+
+	results := runDoctorChecks(context.Background(), defaultDoctorChecks(), timeout)
+	fmt.Fprint(cmd.OutOrStdout(), formatDoctorReport(results))
+
+	if !doctorPassed(results) {
+		return fmt.Errorf("one or more doctor checks failed")
+	}
+	return nil
+}
+
+// defaultDoctorChecks returns the probes run by `ship doctor`
+func defaultDoctorChecks() []doctorCheck {
+	checks := []doctorCheck{
+		{Name: "docker daemon", Probe: probeDocker},
+		{Name: "dagger engine", Probe: probeDagger},
+	}
+
+	client := &http.Client{}
+	for _, registry := range doctorRegistries {
+		checks = append(checks, doctorCheck{
+			Name:  "registry " + strings.TrimSuffix(strings.TrimPrefix(registry, "https://"), "/v2/"),
+			Probe: registryProbe(client, registry),
+		})
+	}
+
+	return checks
+}
+
+// runDoctorChecks runs every check with its own timeout. Later checks still
+// run after a failure so the report is complete.
+func runDoctorChecks(ctx context.Context, checks []doctorCheck, timeout time.Duration) []doctorResult {
+	results := make([]doctorResult, 0, len(checks))
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		detail, err := check.Probe(checkCtx)
+		cancel()
+
+		if err != nil {
+			results = append(results, doctorResult{Name: check.Name, Detail: err.Error()})
+			continue
+		}
+		results = append(results, doctorResult{Name: check.Name, Passed: true, Detail: strings.TrimSpace(detail)})
+	}
+
+	return results
+}
+
+// doctorPassed reports whether every check passed
+func doctorPassed(results []doctorResult) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDoctorReport renders the per-check results and the overall verdict
+func formatDoctorReport(results []doctorResult) string {
+	var b strings.Builder
+	b.WriteString("Ship environment diagnostics\n\n")
+	for _, result := range results {
+		status := "OK"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %s\n", status, result.Name)
+		if result.Detail != "" {
+			for _, line := range strings.Split(result.Detail, "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+	}
+
+	verdict := "all checks passed"
+	if !doctorPassed(results) {
+		verdict = "some checks failed"
+	}
+	fmt.Fprintf(&b, "\nResult: %s\n", verdict)
+	return b.String()
+}
+
+// probeDocker asks the Docker CLI for the server version
+func probeDocker(ctx context.Context) (string, error) {
+	output, err := exec.CommandContext(ctx, "docker", "version", "--format", "{{.Server.Version}}").CombinedOutput()
+	if err != nil {
+		detail := strings.TrimSpace(string(output))
+		if detail == "" {
+			detail = err.Error()
+		}
+		return "", fmt.Errorf("%w\n%s", dagger.ErrDockerUnavailable, detail)
+	}
+	return "server version " + strings.TrimSpace(string(output)), nil
+}
+
+// probeDagger connects to the Dagger engine and closes the session again
+func probeDagger(ctx context.Context) (string, error) {
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer engine.Close()
+	return "connected", nil
+}
+
+// registryProbe checks that the registry API endpoint answers over HTTPS
+func registryProbe(client *http.Client, url string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("cannot reach %s: %w", url, err)
+		}
+		resp.Body.Close()
+
+		return fmt.Sprintf("reachable (HTTP %d)", resp.StatusCode), nil
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDoctorChecksReportsEachProbe(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "docker daemon", Probe: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("%w\nCannot connect to the Docker daemon", dagger.ErrDockerUnavailable)
+		}},
+		{Name: "dagger engine", Probe: func(ctx context.Context) (string, error) {
+			return "connected\n", nil
+		}},
+	}
+
+	results := runDoctorChecks(context.Background(), checks, time.Second)
+	require.Len(t, results, 2)
+
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Detail, "Docker does not appear to be running")
+	assert.True(t, results[1].Passed)
+	assert.Equal(t, "connected", results[1].Detail)
+	assert.False(t, doctorPassed(results))
+
+	report := formatDoctorReport(results)
+	assert.Contains(t, report, "[FAIL] docker daemon")
+	assert.Contains(t, report, "[OK] dagger engine")
+	assert.Contains(t, report, "Result: some checks failed")
+}
+
+func TestRunDoctorChecksAppliesTimeout(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "slow", Probe: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+
+	results := runDoctorChecks(context.Background(), checks, 10*time.Millisecond)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Detail, context.DeadlineExceeded.Error())
+}
+
+func TestDoctorAllPassed(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "docker daemon", Probe: func(ctx context.Context) (string, error) { return "server version 27.0.1", nil }},
+	}
+
+	results := runDoctorChecks(context.Background(), checks, time.Second)
+	assert.True(t, doctorPassed(results))
+	assert.Contains(t, formatDoctorReport(results), "Result: all checks passed")
+}
+
+func TestRegistryProbe(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer registry.Close()
+
+	detail, err := registryProbe(registry.Client(), registry.URL+"/v2/")(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "reachable (HTTP 401)", detail)
+
+	registry.Close()
+	_, err = registryProbe(registry.Client(), registry.URL+"/v2/")(context.Background())
+	assert.Error(t, err)
+}
+
+func TestIsDockerUnavailable(t *testing.T) {
+	assert.True(t, dagger.IsDockerUnavailable(errors.New("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?")))
+	assert.True(t, dagger.IsDockerUnavailable(fmt.Errorf("wrapped: %w", dagger.ErrDockerUnavailable)))
+	assert.True(t, dagger.IsDockerUnavailable(errors.New(`exec: "docker": executable file not found in $PATH`)))
+	assert.False(t, dagger.IsDockerUnavailable(errors.New("failed to pull image: manifest unknown")))
+	assert.False(t, dagger.IsDockerUnavailable(nil))
+}
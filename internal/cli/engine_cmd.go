@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/spf13/cobra"
+)
+
+var engineCmd = &cobra.Command{
+	Use:   "engine",
+	Short: "Manage a dedicated Dagger engine for Ship",
+	Long: `Manage a dedicated Dagger engine container with CPU and memory limits.
+
+Dagger has no per-container resource controls, and the engine it starts on its
+own is shared with every other Dagger client on the host, so Ship never changes
+that engine's limits. Instead, 'ship engine start' creates a separate engine
+container with the limits set at creation and prints the
+_EXPERIMENTAL_DAGGER_RUNNER_HOST value that points Ship at it. The limits are
+shared by every tool container run on that engine.`,
+}
+
+var engineStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a dedicated Dagger engine with resource limits",
+	Example: `  eval "$(ship engine start --cpu 2 --memory 4g)"
+  ship security trivy .`,
+	Args: cobra.NoArgs,
+	RunE: runEngineStart,
+}
+
+var engineStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Remove the dedicated Dagger engine container",
+	Args:  cobra.NoArgs,
+	RunE:  runEngineStop,
+}
+
+func init() {
+	rootCmd.AddCommand(engineCmd)
+	engineCmd.AddCommand(engineStartCmd)
+	engineCmd.AddCommand(engineStopCmd)
+
+	engineCmd.PersistentFlags().String("name", dagger.DefaultDedicatedEngineName, "Name of the engine container")
+	engineStartCmd.Flags().String("cpu", "", "Limit the CPUs of the engine (e.g. 2 or 1.5)")
+	engineStartCmd.Flags().String("memory", "", "Limit the memory of the engine (e.g. 512m or 4g)")
+}
+
+// engineLimitsFromFlags reads and validates the start command's limits
+func engineLimitsFromFlags(cmd *cobra.Command) (dagger.ResourceLimits, error) {
+	cpus, _ := cmd.Flags().GetString("cpu")
+	memory, _ := cmd.Flags().GetString("memory")
+
+	limits := dagger.ResourceLimits{CPUs: cpus, Memory: memory}
+	if limits.IsZero() {
+		return limits, fmt.Errorf("set --cpu, --memory or both")
+	}
+	return limits, limits.Validate()
+}
+
+func runEngineStart(cmd *cobra.Command, args []string) error {
+	limits, err := engineLimitsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	name, _ := cmd.Flags().GetString("name")
+
+	runnerHost, err := dagger.StartDedicatedEngine(cmd.Context(), limits, name)
+	if err != nil {
+		return err
+	}
+
+	// Printed on stdout so the output can be eval'ed
+	fmt.Fprintf(cmd.OutOrStdout(), "export %s=%s\n", dagger.RunnerHostEnv, runnerHost)
+	return nil
+}
+
+func runEngineStop(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	if err := dagger.StopDedicatedEngine(cmd.Context(), name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Stopped Dagger engine %s; unset %s to use the default engine again\n", name, dagger.RunnerHostEnv)
+	return nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEngineStartTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "start"}
+	cmd.Flags().String("cpu", "", "")
+	cmd.Flags().String("memory", "", "")
+	return cmd
+}
+
+func TestEngineLimitsFromFlags(t *testing.T) {
+	cmd := newEngineStartTestCmd()
+	_, err := engineLimitsFromFlags(cmd)
+	assert.ErrorContains(t, err, "set --cpu, --memory or both")
+
+	require.NoError(t, cmd.Flags().Set("cpu", "2"))
+	require.NoError(t, cmd.Flags().Set("memory", "4g"))
+	limits, err := engineLimitsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, dagger.ResourceLimits{CPUs: "2", Memory: "4g"}, limits)
+
+	require.NoError(t, cmd.Flags().Set("memory", "lots"))
+	_, err = engineLimitsFromFlags(cmd)
+	assert.ErrorContains(t, err, "invalid memory limit")
+}
+
+func TestEngineCommandsRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"engine", "start"})
+	require.NoError(t, err)
+	assert.Equal(t, engineStartCmd, cmd)
+	assert.Nil(t, rootCmd.PersistentFlags().Lookup("cpu"), "limits are not a per-run flag")
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().Bool("no-proxy-passthrough", false, "Do not copy HTTP_PROXY, HTTPS_PROXY and NO_PROXY from the host into tool containers")
+	rootCmd.PersistentFlags().Bool("follow-symlinks", false, "Scan the files and directories symlinks point to in Syft, Trivy and Gitleaks filesystem scans (default: symlinks are kept as links, so links outside the scanned directory are not scanned)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Re-run every tool step instead of serving it from Dagger's cache or the --cache-results cache (slower; the shared cache is left intact)")
+}
+
+// configureEngine applies the global engine flags to every engine the command creates
+func configureEngine(cmd *cobra.Command) error {
+	// Set as an environment variable so ship subprocesses started by the MCP server inherit it
+	if noProxy, _ := cmd.Flags().GetBool("no-proxy-passthrough"); noProxy {
+		os.Setenv(modules.NoProxyPassthroughEnv, "1")
+	}
+	if follow, _ := cmd.Flags().GetBool("follow-symlinks"); follow {
+		os.Setenv(modules.FollowSymlinksEnv, "1")
+	}
+	if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+		os.Setenv(modules.NoCacheEnv, "1")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEngineFlagsCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("no-cache", false, "")
+	cmd.Flags().Bool("no-proxy-passthrough", false, "")
+	cmd.Flags().Bool("follow-symlinks", false, "")
+	return cmd
+}
+
+func TestConfigureEngineNoProxyPassthrough(t *testing.T) {
+	t.Setenv(modules.NoProxyPassthroughEnv, "")
+	cmd := newEngineFlagsCmd()
+	require.NoError(t, configureEngine(cmd))
+	assert.Empty(t, os.Getenv(modules.NoProxyPassthroughEnv))
+
+	require.NoError(t, cmd.Flags().Set("no-proxy-passthrough", "true"))
+	require.NoError(t, configureEngine(cmd))
+	assert.Equal(t, "1", os.Getenv(modules.NoProxyPassthroughEnv))
+}
+
+func TestConfigureEngineFollowSymlinks(t *testing.T) {
+	t.Setenv(modules.FollowSymlinksEnv, "")
+	cmd := newEngineFlagsCmd()
+	require.NoError(t, configureEngine(cmd))
+	assert.Empty(t, os.Getenv(modules.FollowSymlinksEnv))
+
+	require.NoError(t, cmd.Flags().Set("follow-symlinks", "true"))
+	require.NoError(t, configureEngine(cmd))
+	assert.Equal(t, "1", os.Getenv(modules.FollowSymlinksEnv))
+}
+
+func TestConfigureEngineNoCache(t *testing.T) {
+	t.Setenv(modules.NoCacheEnv, "")
+	cmd := newEngineFlagsCmd()
+	require.NoError(t, configureEngine(cmd))
+	assert.Empty(t, os.Getenv(modules.NoCacheEnv))
+
+	require.NoError(t, cmd.Flags().Set("no-cache", "true"))
+	require.NoError(t, configureEngine(cmd))
+	assert.Equal(t, "1", os.Getenv(modules.NoCacheEnv))
+}
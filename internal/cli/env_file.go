@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("env-file", "", "Load environment variables for tools, such as cloud credentials and proxy settings, from a dotenv file")
+}
+
+// envKeyPattern matches valid environment variable names
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// loadEnvFile reads KEY=VALUE pairs from a dotenv file
+func loadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env file: %w", err)
+	}
+	defer f.Close()
+
+	vars, err := parseEnvFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// parseEnvFile parses dotenv content. Blank lines and # comments are skipped,
+// an optional "export " prefix is allowed, and values may be wrapped in single
+// or double quotes.
+func parseEnvFile(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+		if !envKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		value, err := unquoteEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	return vars, nil
+}
+
+func unquoteEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		return value, nil
+	}
+	if len(value) < 2 || value[len(value)-1] != quote {
+		return "", fmt.Errorf("unterminated quoted value")
+	}
+
+	value = value[1 : len(value)-1]
+	if quote == '"' {
+		value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value)
+	}
+	return value, nil
+}
+
+// containerEnvVars loads --env-file and merges --var on top, so explicit
+// --var values override the file
+func containerEnvVars(cmd *cobra.Command) (map[string]string, error) {
+	envFile, _ := cmd.Flags().GetString("env-file")
+	flagVars, _ := cmd.Flags().GetStringToString("var")
+
+	vars := make(map[string]string)
+	if envFile != "" {
+		fileVars, err := loadEnvFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileVars {
+			vars[key] = value
+		}
+	}
+
+	for key, value := range flagVars {
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// applyToolEnvFile exports the variables of --env-file, so tools pick them up
+// as they would variables set in the shell
+func applyToolEnvFile(cmd *cobra.Command) error {
+	vars, err := containerEnvVars(cmd)
+	if err != nil {
+		return err
+	}
+	setContainerEnvironmentVars(vars)
+	return nil
+}
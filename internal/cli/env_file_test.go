@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	content := `# cloud credentials
+AWS_REGION=us-east-1
+export AWS_PROFILE=audit
+
+HTTPS_PROXY="http://proxy.internal:3128"
+GREETING='hello world'
+MULTILINE="line1\nline2"
+EMPTY=
+WITH_EQUALS=a=b=c
+`
+
+	vars, err := parseEnvFile(strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"AWS_REGION":  "us-east-1",
+		"AWS_PROFILE": "audit",
+		"HTTPS_PROXY": "http://proxy.internal:3128",
+		"GREETING":    "hello world",
+		"MULTILINE":   "line1\nline2",
+		"EMPTY":       "",
+		"WITH_EQUALS": "a=b=c",
+	}, vars)
+}
+
+func TestParseEnvFileMalformedLines(t *testing.T) {
+	tests := map[string]string{
+		"missing equals":    "AWS_REGION=us-east-1\nJUSTAKEY\n",
+		"invalid name":      "1BAD=value\n",
+		"space in name":     "BAD NAME=value\n",
+		"unterminated":      "TOKEN=\"abc\n",
+		"mismatched quotes": "TOKEN='abc\"\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseEnvFile(strings.NewReader(content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := parseEnvFile(strings.NewReader("A=1\nJUSTAKEY\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestContainerEnvVarsPrecedence(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("AWS_REGION=us-east-1\nAWS_PROFILE=from-file\n"), 0600))
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("env-file", "", "")
+	cmd.Flags().StringToString("var", nil, "")
+	require.NoError(t, cmd.Flags().Set("env-file", envFile))
+	require.NoError(t, cmd.Flags().Set("var", "AWS_PROFILE=from-flag"))
+
+	vars, err := containerEnvVars(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"AWS_REGION":  "us-east-1",
+		"AWS_PROFILE": "from-flag",
+	}, vars)
+}
+
+func TestContainerEnvVarsMissingFile(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("env-file", "", "")
+	cmd.Flags().StringToString("var", nil, "")
+	require.NoError(t, cmd.Flags().Set("env-file", filepath.Join(t.TempDir(), "missing.env")))
+
+	_, err := containerEnvVars(cmd)
+	assert.Error(t, err)
+}
+
+func TestRunToolAppliesEnvFile(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("SHIP_TEST_ENV_FILE_REGION=eu-west-1\n"), 0600))
+	t.Setenv("SHIP_TEST_ENV_FILE_REGION", "")
+
+	cmd, _ := newStdinTestCmd(t, "", cobra.MaximumNArgs(1))
+	cmd.Flags().String("env-file", "", "")
+	require.NoError(t, cmd.Flags().Set("env-file", envFile))
+
+	var region string
+	run := func(cmd *cobra.Command, args []string) (string, error) {
+		region = os.Getenv("SHIP_TEST_ENV_FILE_REGION")
+		return "ok", nil
+	}
+	require.NoError(t, runTool("prowler", run)(cmd, nil))
+	assert.Equal(t, "eu-west-1", region, "tools see the variables of --env-file")
+
+	require.NoError(t, cmd.Flags().Set("env-file", filepath.Join(t.TempDir(), "missing.env")))
+	assert.ErrorContains(t, runTool("prowler", run)(cmd, nil), "failed to open env file")
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// enumValuesAnnotation is the flag annotation holding an enum flag's values
+const enumValuesAnnotation = "ship_enum_values"
+
+// registerEnumFlag declares the values an enum flag of cmd accepts so shell
+// completion suggests them and validateEnumFlags rejects any other value
+func registerEnumFlag(cmd *cobra.Command, name string, values ...string) {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.PersistentFlags().Lookup(name)
+	}
+	if flag == nil {
+		panic(fmt.Sprintf("registerEnumFlag: %s has no flag --%s", cmd.Name(), name))
+	}
+	if flag.Annotations == nil {
+		flag.Annotations = make(map[string][]string)
+	}
+	flag.Annotations[enumValuesAnnotation] = values
+
+	if err := cmd.RegisterFlagCompletionFunc(name, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		panic(err)
+	}
+}
+
+// validateEnumFlags checks every enum flag set on the command line, including
+// inherited ones, so an invalid value fails before any container starts
+func validateEnumFlags(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if err == nil {
+			err = validateEnumFlag(flag)
+		}
+	})
+	return err
+}
+
+// validateEnumFlag checks flag's value against its enum values
+func validateEnumFlag(flag *pflag.Flag) error {
+	return validateEnumValue(flag, flag.Value.String())
+}
+
+// validateEnumValue checks value against flag's enum values. An empty value
+// is accepted when it is the flag's default.
+func validateEnumValue(flag *pflag.Flag, value string) error {
+	values, ok := flag.Annotations[enumValuesAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if value == "" && flag.DefValue == "" {
+		return nil
+	}
+	if contains(values, value) {
+		return nil
+	}
+	return fmt.Errorf("invalid --%s %q: must be one of %s", flag.Name, value, strings.Join(values, ", "))
+}
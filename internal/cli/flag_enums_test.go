@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEnumFlagsRejectsInvalidType(t *testing.T) {
+	setFlagsForTest(t, trufflehogCmd, map[string]string{"type": "ftp"})
+
+	// The root pre-run fails before the command's RunE, and so before any
+	// container starts
+	err := rootCmd.PersistentPreRunE(trufflehogCmd, nil)
+	require.Error(t, err)
+	assert.Equal(t, `invalid --type "ftp": must be one of filesystem, git, github, gitlab, docker, s3`, err.Error())
+}
+
+func TestValidateEnumFlagsAcceptsValidValues(t *testing.T) {
+	setFlagsForTest(t, trivyCmd, map[string]string{
+		"compliance": "k8s-cis",
+		"format":     "table",
+	})
+
+	assert.NoError(t, validateEnumFlags(trivyCmd))
+}
+
+func TestValidateEnumFlagsIgnoresUnsetFlags(t *testing.T) {
+	assert.NoError(t, validateEnumFlags(policySentryQueryServiceCmd), "an empty default is not validated")
+}
+
+func TestValidateEnumFlagsInherited(t *testing.T) {
+	cmd := &cobra.Command{Use: "root"}
+	cmd.PersistentFlags().String("output-format", "text", "")
+	registerEnumFlag(cmd, "output-format", "text", "json")
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	cmd.AddCommand(child)
+
+	require.NoError(t, child.ParseFlags([]string{"--output-format", "yaml"}))
+	assert.ErrorContains(t, validateEnumFlags(child), `invalid --output-format "yaml": must be one of text, json`)
+}
+
+func TestValidateEnumFlagsRejectsEmptyValue(t *testing.T) {
+	setFlagsForTest(t, grypeCmd, map[string]string{"format": ""})
+
+	assert.ErrorContains(t, validateEnumFlags(grypeCmd), `invalid --format ""`)
+}
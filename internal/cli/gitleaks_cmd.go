@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var gitleaksCmd = &cobra.Command{
+	Use:   "gitleaks [directory]",
+	Short: "Detect hardcoded secrets with Gitleaks",
+	Long: `Scan a git repository or directory for hardcoded secrets using Gitleaks.
+
+The directory defaults to the current directory. Secret values are shown in the
+output unless --redact is set.
+
+Examples:
+  # Scan the current repository's history
+  ship security gitleaks
+
+  # Scan files without git history and mask secret values for sharing
+  ship security gitleaks ./src --no-git --redact
+
+  # Write a full report to use as a future baseline
+  ship security gitleaks --report-path gitleaks-baseline.json
+
+  # Only report findings that are not in the baseline
+  ship security gitleaks --baseline gitleaks-baseline.json
+
+  # Scan only the last 10 commits in CI
+  ship security gitleaks --git --log-opts "HEAD~10..HEAD"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("gitleaks", runGitleaks),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(gitleaksCmd)
+
+	gitleaksCmd.Flags().Bool("redact", false, "Redact secret values in the output")
+	gitleaksCmd.Flags().Bool("git", false, "Scan git history (the default); required with --log-opts")
+	gitleaksCmd.Flags().Bool("no-git", false, "Scan the directory as plain files instead of git history")
+	gitleaksCmd.Flags().String("log-opts", "", "git log options selecting the commits to scan, e.g. HEAD~10..HEAD (requires --git)")
+	gitleaksCmd.Flags().Bool("verbose", false, "Show each finding in the output")
+	gitleaksCmd.Flags().String("report-format", "", "Report format (json, csv, junit, sarif)")
+	gitleaksCmd.Flags().String("report-path", "", "Write the full report to this file (JSON reports can be used as a --baseline)")
+	gitleaksCmd.Flags().String("baseline", "", "Baseline JSON report; findings already in it are not reported")
+}
+
+func runGitleaks(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	dir := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "gitleaks", args)
+
+	if err := validateGitleaksModeFlags(cmd); err != nil {
+		return "", err
+	}
+
+	opts, err := gitleaksOptionsFromFlags(cmd)
+	if err != nil {
+		return "", err
+	}
+	if err := validateGitleaksOptions(opts); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "gitleaks", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	gitleaksModule := modules.NewGitleaksModule(engine.GetClient())
+	result, err := gitleaksModule.Detect(ctx, dir, opts)
+	if errors.Is(err, modules.ErrGitleaksLeaksFound) {
+		// The findings are still printed when secrets are detected
+		if !opts.Redact {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Hint: findings include secret values; rerun with --redact to mask them before sharing this output.")
+		}
+		return result, err
+	}
+	if err != nil {
+		telemetry.TrackError("gitleaks", "detect", err.Error())
+		return "", fmt.Errorf("gitleaks scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("gitleaks_detect", "gitleaks", true, time.Since(start))
+	return result, nil
+}
+
+// gitleaksOptionsFromFlags maps the command's flags onto detect options. The
+// report path is resolved against --artifact-dir.
+func gitleaksOptionsFromFlags(cmd *cobra.Command) (modules.GitleaksDetectOptions, error) {
+	redact, _ := cmd.Flags().GetBool("redact")
+	noGit, _ := cmd.Flags().GetBool("no-git")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	reportFormat, _ := cmd.Flags().GetString("report-format")
+	reportPath, _ := cmd.Flags().GetString("report-path")
+	baseline, _ := cmd.Flags().GetString("baseline")
+	logOpts, _ := cmd.Flags().GetString("log-opts")
+
+	reportPath, err := cliArtifactPath(reportPath)
+	if err != nil {
+		return modules.GitleaksDetectOptions{}, err
+	}
+
+	return modules.GitleaksDetectOptions{
+		Redact:       redact,
+		NoGit:        noGit,
+		Verbose:      verbose,
+		ReportFormat: reportFormat,
+		ReportPath:   reportPath,
+		BaselinePath: baseline,
+		LogOpts:      logOpts,
+	}, nil
+}
+
+// validateGitleaksModeFlags checks the git scanning flags are consistent
+func validateGitleaksModeFlags(cmd *cobra.Command) error {
+	git, _ := cmd.Flags().GetBool("git")
+	noGit, _ := cmd.Flags().GetBool("no-git")
+	logOpts, _ := cmd.Flags().GetString("log-opts")
+
+	if git && noGit {
+		return fmt.Errorf("--git and --no-git are mutually exclusive")
+	}
+	if logOpts != "" && !git {
+		return fmt.Errorf("--log-opts requires --git")
+	}
+	return nil
+}
+
+// validateGitleaksOptions checks host inputs before any container starts
+func validateGitleaksOptions(opts modules.GitleaksDetectOptions) error {
+	if opts.BaselinePath != "" {
+		if ok, err := isRegularFile(modules.HostPath(opts.BaselinePath)); err != nil || !ok {
+			return fmt.Errorf("baseline %s is not a readable file", opts.BaselinePath)
+		}
+	}
+	return nil
+}
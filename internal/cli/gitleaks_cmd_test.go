@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitleaksCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "gitleaks"})
+	require.NoError(t, err)
+	assert.Equal(t, gitleaksCmd, cmd)
+}
+
+func TestGitleaksOptionsFromFlags(t *testing.T) {
+	setFlagsForTest(t, gitleaksCmd, map[string]string{
+		"redact":        "true",
+		"no-git":        "true",
+		"report-format": "sarif",
+	})
+
+	opts, err := gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.True(t, opts.Redact)
+	assert.True(t, opts.NoGit)
+	assert.False(t, opts.Verbose)
+	assert.Equal(t, "sarif", opts.ReportFormat)
+}
+
+func TestGitleaksOptionsFromFlags_BaselineAndReport(t *testing.T) {
+	setFlagsForTest(t, gitleaksCmd, map[string]string{
+		"baseline":    "gitleaks-baseline.json",
+		"report-path": "reports/gitleaks.json",
+	})
+	artifactDir := t.TempDir()
+	t.Setenv(shipMcp.ArtifactDirEnv, artifactDir)
+
+	opts, err := gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.Equal(t, "gitleaks-baseline.json", opts.BaselinePath)
+	assert.Equal(t, filepath.Join(artifactDir, "reports", "gitleaks.json"), opts.ReportPath)
+}
+
+func TestGitleaksOptionsFromFlags_ReportPathWithoutArtifactDir(t *testing.T) {
+	t.Setenv(shipMcp.ArtifactDirEnv, "")
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	setFlagsForTest(t, gitleaksCmd, map[string]string{"report-path": "gitleaks.json"})
+	opts, err := gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cwd, "gitleaks.json"), opts.ReportPath)
+
+	absolute := filepath.Join(t.TempDir(), "gitleaks.json")
+	setFlagsForTest(t, gitleaksCmd, map[string]string{"report-path": absolute})
+	opts, err = gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.Equal(t, absolute, opts.ReportPath)
+}
+
+func TestValidateGitleaksOptions(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(baseline, []byte("[]"), 0644))
+
+	assert.NoError(t, validateGitleaksOptions(modules.GitleaksDetectOptions{}))
+	assert.NoError(t, validateGitleaksOptions(modules.GitleaksDetectOptions{BaselinePath: baseline}))
+
+	err := validateGitleaksOptions(modules.GitleaksDetectOptions{BaselinePath: filepath.Join(t.TempDir(), "missing.json")})
+	assert.ErrorContains(t, err, "baseline")
+}
+
+func TestValidateGitleaksOptions_BaselineInWorkingDir(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "baseline.json"), []byte("[]"), 0644))
+	t.Setenv(modules.WorkingDirEnv, workingDir)
+
+	assert.NoError(t, validateGitleaksOptions(modules.GitleaksDetectOptions{BaselinePath: "baseline.json"}))
+}
+
+func TestGitleaksOptionsFromFlags_DefaultUnredacted(t *testing.T) {
+	opts, err := gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.False(t, opts.Redact)
+}
+
+func TestGitleaksOptionsFromFlags_LogOpts(t *testing.T) {
+	setFlagsForTest(t, gitleaksCmd, map[string]string{
+		"git":      "true",
+		"log-opts": "HEAD~10..HEAD",
+	})
+
+	require.NoError(t, validateGitleaksModeFlags(gitleaksCmd))
+	opts, err := gitleaksOptionsFromFlags(gitleaksCmd)
+	require.NoError(t, err)
+	assert.Equal(t, "HEAD~10..HEAD", opts.LogOpts)
+}
+
+func TestValidateGitleaksModeFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr string
+	}{
+		{name: "defaults", flags: map[string]string{}},
+		{name: "no-git", flags: map[string]string{"no-git": "true"}},
+		{name: "log-opts without git", flags: map[string]string{"log-opts": "HEAD~10..HEAD"}, wantErr: "--log-opts requires --git"},
+		{name: "log-opts with no-git", flags: map[string]string{"log-opts": "HEAD~10..HEAD", "no-git": "true"}, wantErr: "--log-opts requires --git"},
+		{name: "git and no-git", flags: map[string]string{"git": "true", "no-git": "true"}, wantErr: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setFlagsForTest(t, gitleaksCmd, tt.flags)
+
+			err := validateGitleaksModeFlags(gitleaksCmd)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// grypeSBOMPrefix marks a target as an existing SBOM to scan
+const grypeSBOMPrefix = "sbom:"
+
+// grypeOutputFormats are the values accepted by --format
+var grypeOutputFormats = []string{"table", "json", "sarif", "cyclonedx", formatJUnit}
+
+var grypeCmd = &cobra.Command{
+	Use:   "grype [target]",
+	Short: "Scan images, directories and SBOMs for vulnerabilities with Grype",
+	Long: `Scan a container image, a directory or an existing SBOM for known
+vulnerabilities using Grype.
+
+The target defaults to the current directory. A target that is an existing
+directory is cataloged and scanned; any other target is pulled as an image.
+Prefix the target with sbom: to scan an existing SBOM, such as one kept by
+ship security sbom-scan, which is faster than cataloging the source again.
+
+Examples:
+  # Scan the current directory
+  ship security grype
+
+  # Scan an image
+  ship security grype alpine:3.19
+
+  # Scan an SBOM generated earlier
+  ship security grype sbom:./sbom.json --format sarif`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("grype", runGrype),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(grypeCmd)
+
+	grypeCmd.Flags().String("format", "table", "Output format ("+strings.Join(grypeOutputFormats, ", ")+")")
+
+	registerEnumFlag(grypeCmd, "format", grypeOutputFormats...)
+}
+
+// grypeScanner is the subset of the Grype module the command dispatches to
+type grypeScanner interface {
+	ScanImage(ctx context.Context, imageName string, opts ...modules.GrypeOption) (string, error)
+	ScanDirectory(ctx context.Context, dir string, opts ...modules.GrypeOption) (string, error)
+	ScanSBOM(ctx context.Context, sbomPath string, opts ...modules.GrypeOption) (string, error)
+}
+
+func runGrype(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	format, _ := cmd.Flags().GetString("format")
+
+	telemetry.TrackCLICommand("security", "grype", args)
+
+	if !contains(grypeOutputFormats, format) {
+		return "", fmt.Errorf("unsupported --format %q: expected one of %s", format, strings.Join(grypeOutputFormats, ", "))
+	}
+
+	target := scanTargetDir(args)
+	if sbomPath, ok := strings.CutPrefix(target, grypeSBOMPrefix); ok {
+		isFile, err := isRegularFile(modules.HostPath(sbomPath))
+		if err != nil {
+			return "", fmt.Errorf("cannot read SBOM %s: %w", sbomPath, err)
+		}
+		if !isFile {
+			return "", fmt.Errorf("SBOM %s is not a file", sbomPath)
+		}
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "grype", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := dispatchGrypeScan(ctx, modules.NewGrypeModule(engine.GetClient()), target, modules.WithGrypeFormat(toolReportFormat(format)))
+	if err != nil {
+		telemetry.TrackError("grype", "scan", err.Error())
+		return "", fmt.Errorf("grype scan failed: %w", err)
+	}
+
+	if format == formatJUnit {
+		if result, err = sarifToJUnit("grype", result); err != nil {
+			return "", err
+		}
+	}
+
+	telemetry.TrackDaggerOperation("grype_scan", "grype", true, time.Since(start))
+	return result, nil
+}
+
+// dispatchGrypeScan scans target as an SBOM when it has the sbom: prefix, as a
+// directory when it names one and as an image otherwise
+func dispatchGrypeScan(ctx context.Context, scanner grypeScanner, target string, opts ...modules.GrypeOption) (string, error) {
+	if sbomPath, ok := strings.CutPrefix(target, grypeSBOMPrefix); ok {
+		return scanner.ScanSBOM(ctx, sbomPath, opts...)
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return scanner.ScanDirectory(ctx, target, opts...)
+	}
+	return scanner.ScanImage(ctx, target, opts...)
+}
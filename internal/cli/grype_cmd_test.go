@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGrypeScanner records which scan method was called
+type fakeGrypeScanner struct {
+	method string
+	target string
+	config modules.GrypeConfig
+}
+
+func (f *fakeGrypeScanner) record(method, target string, opts []modules.GrypeOption) (string, error) {
+	f.method, f.target = method, target
+	for _, opt := range opts {
+		opt(&f.config)
+	}
+	return method + " result", nil
+}
+
+func (f *fakeGrypeScanner) ScanImage(ctx context.Context, imageName string, opts ...modules.GrypeOption) (string, error) {
+	return f.record("ScanImage", imageName, opts)
+}
+
+func (f *fakeGrypeScanner) ScanDirectory(ctx context.Context, dir string, opts ...modules.GrypeOption) (string, error) {
+	return f.record("ScanDirectory", dir, opts)
+}
+
+func (f *fakeGrypeScanner) ScanSBOM(ctx context.Context, sbomPath string, opts ...modules.GrypeOption) (string, error) {
+	return f.record("ScanSBOM", sbomPath, opts)
+}
+
+func TestGrypeCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "grype"})
+	require.NoError(t, err)
+	assert.Equal(t, grypeCmd, cmd)
+}
+
+func TestDispatchGrypeScan(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		target     string
+		wantMethod string
+		wantTarget string
+	}{
+		{name: "sbom prefix", target: "sbom:./sbom.cdx.json", wantMethod: "ScanSBOM", wantTarget: "./sbom.cdx.json"},
+		{name: "directory", target: dir, wantMethod: "ScanDirectory", wantTarget: dir},
+		{name: "image", target: "alpine:3.19", wantMethod: "ScanImage", wantTarget: "alpine:3.19"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := &fakeGrypeScanner{}
+			result, err := dispatchGrypeScan(context.Background(), scanner, tt.target, modules.WithGrypeFormat("json"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, scanner.method)
+			assert.Equal(t, tt.wantTarget, scanner.target)
+			assert.Equal(t, "json", scanner.config.Format)
+			assert.Equal(t, tt.wantMethod+" result", result)
+		})
+	}
+}
+
+func TestRunGrype_SBOMMustBeAFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sbom"), 0o755))
+
+	_, err := runGrype(grypeCmd, []string{"sbom:" + filepath.Join(dir, "missing.json")})
+	assert.ErrorContains(t, err, "cannot read SBOM")
+
+	_, err = runGrype(grypeCmd, []string{"sbom:" + filepath.Join(dir, "sbom")})
+	assert.ErrorContains(t, err, "is not a file")
+}
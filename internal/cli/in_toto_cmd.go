@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var inTotoCmd = &cobra.Command{
+	Use:   "in-toto",
+	Short: "Verify software supply chain metadata with in-toto",
+	Long:  `Verify in-toto supply chain metadata and attestations in containerized environments.`,
+}
+
+var inTotoVerifyDSSECmd = &cobra.Command{
+	Use:   "verify-dsse",
+	Short: "Verify a DSSE-wrapped attestation against a public key",
+	Long: `Verify the signature on a DSSE (Dead Simple Signing Envelope) attestation
+using a PEM-encoded public key. The command fails if no signature verifies.
+
+Examples:
+  ship security in-toto verify-dsse --envelope ./provenance.intoto.json --key ./cosign.pub`,
+	Args: cobra.NoArgs,
+	RunE: runTool("in-toto", runInTotoVerifyDSSE),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(inTotoCmd)
+	inTotoCmd.AddCommand(inTotoVerifyDSSECmd)
+
+	inTotoVerifyDSSECmd.Flags().String("envelope", "", "Path to the DSSE envelope JSON file (required)")
+	inTotoVerifyDSSECmd.Flags().String("key", "", "Path to the PEM public key (required)")
+}
+
+func runInTotoVerifyDSSE(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	envelope, _ := cmd.Flags().GetString("envelope")
+	key, _ := cmd.Flags().GetString("key")
+
+	telemetry.TrackCLICommand("security", "in-toto_verify-dsse", args)
+
+	if err := validateInTotoDSSEFlags(envelope, key); err != nil {
+		telemetry.TrackError("validation", "in-toto_verify-dsse", err.Error())
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "in-toto", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	inTotoModule := modules.NewInTotoModule(engine.GetClient())
+	result, err := inTotoModule.VerifyDSSE(ctx, envelope, key)
+	if err != nil {
+		telemetry.TrackError("in-toto", "verify-dsse", err.Error())
+		return "", err
+	}
+
+	telemetry.TrackDaggerOperation("in_toto_verify_dsse", "in-toto", true, time.Since(start))
+	return result, nil
+}
+
+// validateInTotoDSSEFlags checks that both inputs for DSSE verification were given
+func validateInTotoDSSEFlags(envelope, key string) error {
+	if envelope == "" {
+		return fmt.Errorf("--envelope is required")
+	}
+	if key == "" {
+		return fmt.Errorf("--key is required")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInTotoDSSEFlags(t *testing.T) {
+	assert.NoError(t, validateInTotoDSSEFlags("envelope.json", "key.pub"))
+
+	err := validateInTotoDSSEFlags("", "key.pub")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--envelope")
+
+	err = validateInTotoDSSEFlags("envelope.json", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--key")
+}
+
+func TestInTotoVerifyDSSERouting(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "in-toto", "verify-dsse"})
+	require.NoError(t, err)
+	assert.Equal(t, inTotoVerifyDSSECmd, cmd)
+}
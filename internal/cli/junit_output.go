@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/cloudshipai/ship/internal/report/junit"
+)
+
+// formatJUnit is the --format value that converts a tool's SARIF report into
+// JUnit XML for CI test dashboards
+const formatJUnit = "junit"
+
+// toolReportFormat returns the format to request from the tool itself. JUnit is
+// produced from the tool's SARIF output.
+func toolReportFormat(format string) string {
+	if format == formatJUnit {
+		return "sarif"
+	}
+	return format
+}
+
+// sarifToJUnit converts SARIF tool output into a JUnit XML report with one
+// failing test case per finding
+func sarifToJUnit(tool string, output string) (string, error) {
+	findings, err := report.FromSARIF([]byte(output))
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %s output to JUnit: %w", tool, err)
+	}
+
+	data, err := junit.Marshal(tool, findings)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolReportFormat(t *testing.T) {
+	assert.Equal(t, "sarif", toolReportFormat(formatJUnit))
+	assert.Equal(t, "json", toolReportFormat("json"))
+	assert.Equal(t, "", toolReportFormat(""))
+}
+
+func TestSARIFToJUnit(t *testing.T) {
+	sarif := `{"runs":[{"tool":{"driver":{"name":"osv-scanner"}},"results":[
+		{"ruleId":"GHSA-1","level":"warning","message":{"text":"lodash vulnerable"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"package-lock.json"}}}]},
+		{"ruleId":"GHSA-2","level":"error","message":{"text":"minimist vulnerable"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"package-lock.json"}}}]}
+	]}]}`
+
+	output, err := sarifToJUnit("osv-scanner", sarif)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "<?xml"))
+	assert.Contains(t, output, `<testsuites name="osv-scanner" tests="2" failures="2">`)
+	assert.Equal(t, 2, strings.Count(output, "<failure "))
+
+	_, err = sarifToJUnit("osv-scanner", "table output")
+	assert.ErrorContains(t, err, "osv-scanner")
+}
+
+func TestScanFormatsAcceptJUnit(t *testing.T) {
+	setFlagsForTest(t, osvScannerCmd, map[string]string{"format": formatJUnit})
+	_, err := osvScannerOptionsFromFlags(osvScannerCmd)
+	assert.NoError(t, err)
+
+	assert.NoError(t, validateTruffleHogFormat("filesystem", formatJUnit))
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var kubeBenchCmd = &cobra.Command{
+	Use:   "kube-bench",
+	Short: "Check a Kubernetes cluster against the CIS benchmark with kube-bench",
+	Long: `Check a Kubernetes cluster against the CIS Kubernetes benchmark with
+kube-bench. By default kube-bench picks the benchmark matching the cluster's
+Kubernetes version; use --benchmark to force the spec for your distribution,
+such as a managed EKS, AKS or GKE cluster.
+
+Examples:
+  # Run the auto-detected benchmark
+  ship security kube-bench --kubeconfig ~/.kube/config
+
+  # Force the EKS benchmark
+  ship security kube-bench --kubeconfig ~/.kube/config --benchmark eks-1.2.0`,
+	Args: cobra.NoArgs,
+	RunE: runTool("kube-bench", runKubeBench),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(kubeBenchCmd)
+
+	kubeBenchCmd.Flags().String("kubeconfig", "", "Kubeconfig of the cluster to benchmark")
+	kubeBenchCmd.Flags().String("benchmark", "", "Benchmark spec to run, e.g. cis-1.23, eks-1.2.0, aks-1.0 (default: auto-detected)")
+}
+
+func runKubeBench(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	telemetry.TrackCLICommand("security", "kube-bench", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "kube-bench", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	kubeBenchModule := modules.NewKubeBenchModule(engine.GetClient())
+	result, err := kubeBenchModule.RunBenchmark(ctx, kubeconfig, kubeBenchOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("kube-bench", "run", err.Error())
+		return "", fmt.Errorf("kube-bench failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("kube_bench_run", "kube-bench", true, time.Since(start))
+	return result, nil
+}
+
+// kubeBenchOptionsFromFlags maps the command's flags onto module options
+func kubeBenchOptionsFromFlags(cmd *cobra.Command) []modules.KubeBenchOption {
+	benchmark, _ := cmd.Flags().GetString("benchmark")
+
+	return []modules.KubeBenchOption{
+		modules.WithKubeBenchBenchmark(benchmark),
+	}
+}
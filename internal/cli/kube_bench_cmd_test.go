@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeBenchCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "kube-bench"})
+	require.NoError(t, err)
+	assert.Equal(t, kubeBenchCmd, cmd)
+}
+
+func TestKubeBenchOptionsFromFlags(t *testing.T) {
+	config := &modules.KubeBenchConfig{}
+	for _, opt := range kubeBenchOptionsFromFlags(kubeBenchCmd) {
+		opt(config)
+	}
+	assert.Empty(t, config.Benchmark, "benchmark is auto-detected by default")
+
+	setFlagsForTest(t, kubeBenchCmd, map[string]string{"benchmark": "cis-1.23"})
+
+	config = &modules.KubeBenchConfig{}
+	for _, opt := range kubeBenchOptionsFromFlags(kubeBenchCmd) {
+		opt(config)
+	}
+	assert.Equal(t, "cis-1.23", config.Benchmark)
+}
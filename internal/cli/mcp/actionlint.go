@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -42,11 +41,11 @@ func addActionlintToolsDirect(s *server.MCPServer) {
 		color := request.GetBool("color", false)
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create actionlint module
 		actionlintModule := modules.NewActionlintModule(client)
@@ -99,11 +98,11 @@ func addActionlintToolsDirect(s *server.MCPServer) {
 		color := request.GetBool("color", false)
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create actionlint module and scan with external tools
 		actionlintModule := modules.NewActionlintModule(client)
@@ -127,11 +126,11 @@ func addActionlintToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create actionlint module and get version
 		actionlintModule := modules.NewActionlintModule(client)
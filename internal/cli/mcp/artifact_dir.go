@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ArtifactDirEnv names the directory artifact-producing tools write into. It
+// is set from ship's --artifact-dir flag.
+const ArtifactDirEnv = "SHIP_ARTIFACT_DIR"
+
+// toolArtifactsMetaKey is the result metadata field that carries artifact paths
+const toolArtifactsMetaKey = "artifacts"
+
+// ArtifactPath resolves the absolute path an artifact is written to. Paths are
+// relative to the artifact directory, which is created if needed, or the
+// current directory when none is configured. Absolute paths and paths that
+// escape the directory are rejected, so MCP clients cannot write elsewhere on
+// the host. An empty path resolves to the directory itself.
+func ArtifactPath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("artifact path %s must be relative to the artifact directory", path)
+	}
+
+	dir := os.Getenv(ArtifactDirEnv)
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", fmt.Errorf("failed to create artifact directory: %w", err)
+		}
+	} else {
+		dir = "."
+	}
+
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve artifact directory %s: %w", dir, err)
+	}
+	absPath := filepath.Join(base, path)
+	if rel, err := filepath.Rel(base, absPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("artifact path %s escapes the artifact directory", path)
+	}
+	return absPath, nil
+}
+
+// writeArtifact writes content to the artifact at path and returns its
+// absolute path
+func writeArtifact(path string, content string) (string, error) {
+	absPath, err := ArtifactPath(path)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", absPath, err)
+	}
+	if err := os.WriteFile(absPath, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write artifact %s: %w", absPath, err)
+	}
+	return absPath, nil
+}
+
+// withReportArtifact saves a successful report to outputFile, when set, and
+// records its absolute path in the result's metadata
+func withReportArtifact(result *mcp.CallToolResult, outputFile string, report string) *mcp.CallToolResult {
+	if outputFile == "" || result == nil || result.IsError {
+		return result
+	}
+
+	path, err := writeArtifact(outputFile, report)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = make(map[string]any)
+	}
+	result.Meta.AdditionalFields[toolArtifactsMetaKey] = map[string]string{"report": path}
+	return result
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactPath_ArtifactDir(t *testing.T) {
+	artifactDir := filepath.Join(t.TempDir(), "ci", "artifacts")
+	t.Setenv(ArtifactDirEnv, artifactDir)
+
+	path, err := ArtifactPath("sbom.cdx.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(artifactDir, "sbom.cdx.json"), path)
+	assert.DirExists(t, artifactDir, "the artifact directory is created")
+
+	dir, err := ArtifactPath("")
+	require.NoError(t, err)
+	assert.Equal(t, artifactDir, dir)
+
+	path, err = ArtifactPath("reports/../gitleaks.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(artifactDir, "gitleaks.json"), path)
+}
+
+func TestArtifactPath_RejectsPathsOutsideArtifactDir(t *testing.T) {
+	t.Setenv(ArtifactDirEnv, t.TempDir())
+
+	_, err := ArtifactPath(filepath.Join(t.TempDir(), "report.json"))
+	assert.ErrorContains(t, err, "must be relative to the artifact directory")
+
+	for _, path := range []string{"..", "../report.json", "reports/../../report.json"} {
+		_, err := ArtifactPath(path)
+		assert.ErrorContains(t, err, "escapes the artifact directory", path)
+	}
+}
+
+func TestArtifactPath_DefaultsToWorkingDirectory(t *testing.T) {
+	t.Setenv(ArtifactDirEnv, "")
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	path, err := ArtifactPath("./sbom.spdx.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(wd, "sbom.spdx.json"), path)
+}
+
+func TestWithReportArtifact_WritesIntoArtifactDir(t *testing.T) {
+	artifactDir := t.TempDir()
+	t.Setenv(ArtifactDirEnv, artifactDir)
+
+	result := withReportArtifact(mcp.NewToolResultText(`{"results":[]}`), "reports/terrascan.json", `{"results":[]}`)
+	require.False(t, result.IsError)
+
+	path := filepath.Join(artifactDir, "reports", "terrascan.json")
+	assert.Equal(t, map[string]string{"report": path}, result.Meta.AdditionalFields[toolArtifactsMetaKey])
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"results":[]}`, string(data))
+}
+
+func TestWithReportArtifact_Skipped(t *testing.T) {
+	t.Setenv(ArtifactDirEnv, t.TempDir())
+
+	assert.Nil(t, withReportArtifact(mcp.NewToolResultText("ok"), "", "ok").Meta, "no output file writes nothing")
+	assert.Nil(t, withReportArtifact(mcp.NewToolResultError("scan failed"), "report.json", "").Meta, "errors are not saved")
+}
+
+func TestWithReportArtifact_RejectsPathsOutsideArtifactDir(t *testing.T) {
+	artifactDir := filepath.Join(t.TempDir(), "artifacts")
+	t.Setenv(ArtifactDirEnv, artifactDir)
+
+	for _, outputFile := range []string{filepath.Join(t.TempDir(), "terrascan.json"), "../trufflehog.json"} {
+		result := withReportArtifact(mcp.NewToolResultText("{}"), outputFile, "{}")
+		assert.True(t, result.IsError, outputFile)
+	}
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(artifactDir), "trufflehog.json"))
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -31,11 +30,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listAccessKeysTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		username := request.GetString("user_name", "")
@@ -64,11 +63,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createAccessKeyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		username := request.GetString("user_name", "")
@@ -106,11 +105,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(updateAccessKeyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		accessKeyId := request.GetString("access_key_id", "")
@@ -145,11 +144,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(deleteAccessKeyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		accessKeyId := request.GetString("access_key_id", "")
@@ -179,11 +178,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getAccessKeyLastUsedTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		accessKeyId := request.GetString("access_key_id", "")
@@ -205,11 +204,11 @@ func addAWSIAMRotationToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create AWS IAM rotation module and get version
 		awsModule := modules.NewAWSIAMRotationModule(client)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -33,11 +32,11 @@ func addAWSPricingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(describeServicesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		serviceCode := request.GetString("service_code", "")
@@ -74,11 +73,11 @@ func addAWSPricingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getAttributeValuesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		serviceCode := request.GetString("service_code", "")
@@ -119,11 +118,11 @@ func addAWSPricingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getProductsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		serviceCode := request.GetString("service_code", "")
@@ -148,11 +147,11 @@ func addAWSPricingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create AWS pricing module and get version
 		awsModule := modules.NewAWSPricingModule(client)
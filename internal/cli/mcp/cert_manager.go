@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -30,11 +29,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(installTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		version := request.GetString("version", "v1.18.2")
@@ -59,11 +58,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(checkInstallTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		namespace := request.GetString("namespace", "cert-manager")
@@ -97,11 +96,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createCertRequestTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		name := request.GetString("name", "")
@@ -131,11 +130,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listCertificatesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		namespace := request.GetString("namespace", "")
@@ -167,11 +166,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(renewCertificateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		certName := request.GetString("cert_name", "")
@@ -207,11 +206,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(statusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		certName := request.GetString("certificate_name", "")
@@ -233,11 +232,11 @@ func addCertManagerToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create cert-manager module and get version
 		certManagerModule := modules.NewCertManagerModule(client)
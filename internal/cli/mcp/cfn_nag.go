@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -35,11 +34,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputPath := request.GetString("input_path", "")
@@ -72,11 +71,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithProfileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputPath := request.GetString("input_path", "")
@@ -112,11 +111,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithParametersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputPath := request.GetString("input_path", "")
@@ -140,11 +139,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listRulesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create CFN Nag module and list rules
 		cfnNagModule := modules.NewCfnNagModule(client)
@@ -170,11 +169,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(spcmScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputPath := request.GetString("input_path", "")
@@ -196,11 +195,11 @@ func addCfnNagToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create CFN Nag module and get version
 		cfnNagModule := modules.NewCfnNagModule(client)
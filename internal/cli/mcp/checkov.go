@@ -8,7 +8,7 @@ import (
 	"time"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/internal/logger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -36,7 +36,7 @@ func logDaggerOutput(toolName string, args []string, result string, duration tim
 		// Append to execution log file
 		logFile, logErr := os.OpenFile(executionLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 		if logErr == nil {
-			logFile.WriteString(logEntry)
+			logFile.WriteString(logger.Redact(logEntry))
 			logFile.Close()
 		}
 	}
@@ -90,11 +90,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 		startTime := time.Now()
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -143,11 +143,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		file := request.GetString("file", "")
@@ -180,11 +180,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithChecksTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -218,11 +218,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanDockerImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		dockerImage := request.GetString("docker_image", "")
@@ -253,11 +253,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanPackagesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -287,11 +287,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanSecretsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -321,11 +321,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithConfigTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -351,11 +351,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createConfigTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		configPath := request.GetString("config_path", "")
@@ -383,11 +383,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(downloadModulesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		directory := request.GetString("directory", "")
@@ -409,11 +409,11 @@ func addCheckovToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Checkov module and get version
 		checkovModule := modules.NewCheckovModule(client)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -30,11 +29,11 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(downloadTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		profile := request.GetString("profile", "")
@@ -66,11 +65,11 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputFile := request.GetString("input_file", "")
@@ -100,18 +99,19 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanPolicyFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		inputFile := request.GetString("input_file", "")
+		exclusionsFile := request.GetString("exclusions_file", "")
 
 		// Create Cloudsplaining module and scan policy file
 		cloudsplainingModule := modules.NewCloudsplainingModule(client)
-		result, err := cloudsplainingModule.ScanPolicyFile(ctx, inputFile)
+		result, err := cloudsplainingModule.ScanPolicyFile(ctx, inputFile, modules.WithCloudsplainingExclusions(exclusionsFile))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("cloudsplaining scan policy file failed: %v", err)), nil
 		}
@@ -125,11 +125,11 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createExclusionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Cloudsplaining module and create exclusions file
 		cloudsplainingModule := modules.NewCloudsplainingModule(client)
@@ -151,11 +151,11 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createMultiAccountConfigTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		outputFile := request.GetString("output_file", "")
@@ -192,11 +192,11 @@ func addCloudsplainingToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanMultiAccountTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		configFile := request.GetString("config_file", "")
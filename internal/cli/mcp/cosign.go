@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -34,11 +33,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(signImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageName := request.GetString("image_name", "")
@@ -66,25 +65,48 @@ func addCosignToolsDirect(s *server.MCPServer) {
 			mcp.Description("Path to verification key"),
 		),
 		mcp.WithBoolean("keyless",
-			mcp.Description("Use keyless verification"),
+			mcp.Description("Use keyless verification; requires the signer's certificate identity and OIDC issuer"),
+		),
+		mcp.WithString("certificate_identity",
+			mcp.Description("Identity the keyless signing certificate must be issued to"),
+		),
+		mcp.WithString("certificate_identity_regexp",
+			mcp.Description("Regular expression the keyless signing certificate identity must match"),
+		),
+		mcp.WithString("certificate_oidc_issuer",
+			mcp.Description("OIDC issuer of the keyless signing certificate, e.g. https://token.actions.githubusercontent.com"),
+		),
+		mcp.WithString("certificate_oidc_issuer_regexp",
+			mcp.Description("Regular expression the keyless signing certificate OIDC issuer must match"),
 		),
 	)
 	s.AddTool(verifyImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageName := request.GetString("image_name", "")
 		keyPath := request.GetString("key_path", "")
 		keyless := request.GetBool("keyless", false)
+		identity := modules.CosignIdentity{
+			Identity:       request.GetString("certificate_identity", ""),
+			IdentityRegexp: request.GetString("certificate_identity_regexp", ""),
+			Issuer:         request.GetString("certificate_oidc_issuer", ""),
+			IssuerRegexp:   request.GetString("certificate_oidc_issuer_regexp", ""),
+		}
+		if keyless {
+			if err := identity.Validate(); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
 
 		// Create Cosign module and verify image with options
 		cosignModule := modules.NewCosignModule(client)
-		result, err := cosignModule.VerifyImageWithOptions(ctx, imageName, keyPath, keyless)
+		result, err := cosignModule.VerifyImageWithOptions(ctx, imageName, keyPath, keyless, identity)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("cosign verify image failed: %v", err)), nil
 		}
@@ -101,11 +123,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(generateKeyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		outputPath := request.GetString("output_path", "/tmp")
@@ -137,11 +159,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(attestTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageName := request.GetString("image_name", "")
@@ -174,11 +196,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(verifyAttestationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageName := request.GetString("image_name", "")
@@ -211,11 +233,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(signBlobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		blobPath := request.GetString("blob_path", "")
@@ -249,11 +271,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(verifyBlobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		blobPath := request.GetString("blob_path", "")
@@ -284,11 +306,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(uploadBlobTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		blobPath := request.GetString("blob_path", "")
@@ -318,11 +340,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(uploadWasmTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		wasmPath := request.GetString("wasm_path", "")
@@ -352,11 +374,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(copyImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		sourceImage := request.GetString("source_image", "")
@@ -388,11 +410,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(signWasmTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		wasmArtifact := request.GetString("wasm_artifact", "")
@@ -415,11 +437,11 @@ func addCosignToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Cosign module and get version
 		cosignModule := modules.NewCosignModule(client)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -38,11 +37,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		region := request.GetString("region", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and run policy
 		custodianModule := modules.NewCustodianModule(client)
@@ -78,11 +77,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		region := request.GetString("region", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and run dry run
 		custodianModule := modules.NewCustodianModule(client)
@@ -111,11 +110,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		policyFile := request.GetString("policy_file", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and validate policy
 		custodianModule := modules.NewCustodianModule(client)
@@ -138,11 +137,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		resourceType := request.GetString("resource_type", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and get schema
 		custodianModule := modules.NewCustodianModule(client)
@@ -160,11 +159,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and get version
 		custodianModule := modules.NewCustodianModule(client)
@@ -198,11 +197,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		format := request.GetString("format", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and generate report
 		custodianModule := modules.NewCustodianModule(client)
@@ -231,11 +230,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		outputDir := request.GetString("output_dir", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and get logs
 		custodianModule := modules.NewCustodianModule(client)
@@ -272,11 +271,11 @@ func addCustodianToolsDirect(s *server.MCPServer) {
 		end := request.GetString("end", "")
 		
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create custodian module and get metrics
 		custodianModule := modules.NewCustodianModule(client)
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/internal/logger"
+)
+
+// sharedClient is a reference-counted connection shared by concurrent tool
+// handlers. The first acquisition connects, later ones reuse the connection,
+// and it is closed when the last reference is released.
+type sharedClient[T io.Closer] struct {
+	mu      sync.Mutex
+	connect func(ctx context.Context) (T, error)
+	client  T
+	open    bool
+	refs    int
+}
+
+func newSharedClient[T io.Closer](connect func(ctx context.Context) (T, error)) *sharedClient[T] {
+	return &sharedClient[T]{connect: connect}
+}
+
+// acquire returns the shared client, connecting if needed, and adds a
+// reference that must be dropped with release. The connection outlives ctx
+// so cancelling one tool call does not break the others.
+func (c *sharedClient[T]) acquire(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.open {
+		client, err := c.connect(context.WithoutCancel(ctx))
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		c.client = client
+		c.open = true
+	}
+
+	c.refs++
+	return c.client, nil
+}
+
+// hold adds a reference without connecting. It keeps the connection open
+// between tool calls until the returned func is called.
+func (c *sharedClient[T]) hold() func() {
+	c.mu.Lock()
+	c.refs++
+	c.mu.Unlock()
+
+	var once sync.Once
+	return func() { once.Do(c.release) }
+}
+
+// release drops a reference and closes the connection when none remain
+func (c *sharedClient[T]) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refs == 0 {
+		return
+	}
+	c.refs--
+	if c.refs == 0 && c.open {
+		c.client.Close()
+		var zero T
+		c.client = zero
+		c.open = false
+	}
+}
+
+// daggerClients is the Dagger connection shared by every tool handler
+var daggerClients = newSharedClient(connectDaggerClient)
+
+// daggerConnect opens a Dagger connection; tests replace it to inspect options
+var daggerConnect = func(ctx context.Context, logOutput io.Writer) (*dagger.Client, error) {
+	return dagger.Connect(ctx, dagger.WithLogOutput(logOutput))
+}
+
+func connectDaggerClient(ctx context.Context) (*dagger.Client, error) {
+	return daggerConnect(ctx, daggerLogOutput())
+}
+
+// daggerLogOutput returns where Dagger progress logs go: the execution log
+// when SHIP_EXECUTION_LOG is set, otherwise nil to discard them
+func daggerLogOutput() io.Writer {
+	executionLog := os.Getenv("SHIP_EXECUTION_LOG")
+	if executionLog == "" {
+		return nil
+	}
+	return appendFileWriter(executionLog)
+}
+
+// appendFileWriter appends each write to a file, opening it per write like
+// logDaggerOutput so the shared connection holds no file handle. Secret values
+// are redacted.
+type appendFileWriter string
+
+func (w appendFileWriter) Write(p []byte) (int, error) {
+	file, err := os.OpenFile(string(w), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(logger.Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// acquireDaggerClient returns the shared Dagger client. Each successful call
+// must be paired with releaseDaggerClient.
+func acquireDaggerClient(ctx context.Context) (*dagger.Client, error) {
+	return daggerClients.acquire(ctx)
+}
+
+// releaseDaggerClient drops a reference taken by acquireDaggerClient
+func releaseDaggerClient() {
+	daggerClients.release()
+}
+
+// HoldDaggerClient keeps the shared Dagger client open for the lifetime of a
+// server so tool calls reuse one connection. Calling the returned func on
+// shutdown closes the connection once in-flight tool calls have released it.
+func HoldDaggerClient() func() {
+	return daggerClients.hold()
+}
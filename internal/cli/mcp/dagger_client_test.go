@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConn counts how often it is closed
+type fakeConn struct {
+	closes int32
+}
+
+func (c *fakeConn) Close() error {
+	atomic.AddInt32(&c.closes, 1)
+	return nil
+}
+
+// countingConnect returns a connect func that records every connection it makes
+func countingConnect(conns *[]*fakeConn, mu *sync.Mutex) func(ctx context.Context) (*fakeConn, error) {
+	return func(ctx context.Context) (*fakeConn, error) {
+		time.Sleep(5 * time.Millisecond)
+		conn := &fakeConn{}
+		mu.Lock()
+		*conns = append(*conns, conn)
+		mu.Unlock()
+		return conn, nil
+	}
+}
+
+func TestSharedClient_ConcurrentAcquisitionsShareOneClient(t *testing.T) {
+	var conns []*fakeConn
+	var mu sync.Mutex
+	shared := newSharedClient(countingConnect(&conns, &mu))
+
+	const callers = 20
+	clients := make([]*fakeConn, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := shared.acquire(context.Background())
+			require.NoError(t, err)
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, conns, 1, "concurrent acquisitions should share one connection")
+	for _, client := range clients {
+		assert.Same(t, conns[0], client)
+	}
+
+	// The client closes only when the last reference is released
+	for i := 0; i < callers-1; i++ {
+		shared.release()
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&conns[0].closes))
+	shared.release()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&conns[0].closes))
+}
+
+func TestSharedClient_ReconnectsAfterClose(t *testing.T) {
+	var conns []*fakeConn
+	var mu sync.Mutex
+	shared := newSharedClient(countingConnect(&conns, &mu))
+
+	_, err := shared.acquire(context.Background())
+	require.NoError(t, err)
+	shared.release()
+
+	_, err = shared.acquire(context.Background())
+	require.NoError(t, err)
+	shared.release()
+
+	require.Len(t, conns, 2)
+	assert.Equal(t, int32(1), conns[0].closes)
+	assert.Equal(t, int32(1), conns[1].closes)
+
+	// Extra releases are ignored
+	shared.release()
+	assert.Equal(t, int32(1), conns[1].closes)
+}
+
+func TestSharedClient_HoldKeepsClientOpenBetweenCalls(t *testing.T) {
+	var conns []*fakeConn
+	var mu sync.Mutex
+	shared := newSharedClient(countingConnect(&conns, &mu))
+
+	releaseServer := shared.hold()
+	assert.Empty(t, conns, "holding does not connect")
+
+	for i := 0; i < 3; i++ {
+		_, err := shared.acquire(context.Background())
+		require.NoError(t, err)
+		shared.release()
+	}
+	require.Len(t, conns, 1, "calls reuse the connection while the server holds it")
+	assert.Equal(t, int32(0), conns[0].closes)
+
+	// Shutdown waits for in-flight calls
+	_, err := shared.acquire(context.Background())
+	require.NoError(t, err)
+	releaseServer()
+	releaseServer()
+	assert.Equal(t, int32(0), conns[0].closes)
+	shared.release()
+	assert.Equal(t, int32(1), conns[0].closes)
+}
+
+func TestSharedClient_ConnectError(t *testing.T) {
+	attempts := 0
+	shared := newSharedClient(func(ctx context.Context) (*fakeConn, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("engine unavailable")
+		}
+		return &fakeConn{}, nil
+	})
+
+	_, err := shared.acquire(context.Background())
+	assert.Error(t, err)
+
+	// A failed connection takes no reference and the next call retries
+	client, err := shared.acquire(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+	shared.release()
+	assert.Equal(t, int32(1), client.closes)
+}
+
+func TestSharedClient_OutlivesCallerContext(t *testing.T) {
+	var connectCtx context.Context
+	shared := newSharedClient(func(ctx context.Context) (*fakeConn, error) {
+		connectCtx = ctx
+		return &fakeConn{}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := shared.acquire(ctx)
+	require.NoError(t, err)
+	cancel()
+
+	assert.NoError(t, connectCtx.Err(), "cancelling one call must not tear down the shared connection")
+	shared.release()
+}
+
+// captureLogOutput stubs daggerConnect and returns the log writer it was given
+func captureLogOutput(t *testing.T) io.Writer {
+	t.Helper()
+	original := daggerConnect
+	t.Cleanup(func() { daggerConnect = original })
+
+	var logOutput io.Writer
+	daggerConnect = func(ctx context.Context, w io.Writer) (*dagger.Client, error) {
+		logOutput = w
+		return &dagger.Client{}, nil
+	}
+
+	_, err := connectDaggerClient(context.Background())
+	require.NoError(t, err)
+	return logOutput
+}
+
+func TestConnectDaggerClient_RoutesLogsToExecutionLog(t *testing.T) {
+	executionLog := filepath.Join(t.TempDir(), "execution.log")
+	t.Setenv("SHIP_EXECUTION_LOG", executionLog)
+
+	logOutput := captureLogOutput(t)
+	require.NotNil(t, logOutput, "Dagger logs should go to the execution log")
+
+	_, err := logOutput.Write([]byte("pulling image\n"))
+	require.NoError(t, err)
+	_, err = logOutput.Write([]byte("running trivy\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(executionLog)
+	require.NoError(t, err)
+	assert.Equal(t, "pulling image\nrunning trivy\n", string(data))
+}
+
+func TestConnectDaggerClient_DiscardsLogsWithoutExecutionLog(t *testing.T) {
+	t.Setenv("SHIP_EXECUTION_LOG", "")
+
+	assert.Nil(t, captureLogOutput(t))
+}
+
+func TestConnectDaggerClient_RedactsSecretsInExecutionLog(t *testing.T) {
+	executionLog := filepath.Join(t.TempDir(), "execution.log")
+	t.Setenv("SHIP_EXECUTION_LOG", executionLog)
+	logger.RedactSecrets("ghp-7d3b9c1f")
+
+	logOutput := captureLogOutput(t)
+	line := "exec env GITHUB_TOKEN=ghp-7d3b9c1f\n"
+	n, err := logOutput.Write([]byte(line))
+	require.NoError(t, err)
+	assert.Equal(t, len(line), n)
+
+	data, err := os.ReadFile(executionLog)
+	require.NoError(t, err)
+	assert.Equal(t, "exec env GITHUB_TOKEN=***\n", string(data))
+}
@@ -0,0 +1,29 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// withDaggerModule runs fn against a module built on the shared Dagger client
+// and returns its output as the tool result. Failing to connect or an error
+// from fn becomes a tool error tagged with its error_code; fn errors are
+// prefixed with failure.
+func withDaggerModule[T any](ctx context.Context, failure string, newModule func(*dagger.Client) T, fn func(module T) (string, error)) (*mcp.CallToolResult, error) {
+	client, err := acquireDaggerClient(ctx)
+	if err != nil {
+		return ship.NewToolErrorResult(ship.ErrorCodeEngineUnavailable, fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+	}
+	defer releaseDaggerClient()
+
+	output, err := fn(newModule(client))
+	if err != nil {
+		return toolErrorResult(fmt.Sprintf("%s: %v", failure, err), err), nil
+	}
+
+	return mcp.NewToolResultText(output), nil
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDaggerClients swaps the shared Dagger connection for the duration of a test
+func stubDaggerClients(t *testing.T, connect func(ctx context.Context) (*dagger.Client, error)) {
+	t.Helper()
+	original := daggerClients
+	daggerClients = newSharedClient(connect)
+	t.Cleanup(func() { daggerClients = original })
+}
+
+// fakeModule records the client it was built with
+type fakeModule struct {
+	client *dagger.Client
+}
+
+func newFakeModule(client *dagger.Client) *fakeModule {
+	return &fakeModule{client: client}
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "expected text content, got %T", result.Content[0])
+	return text.Text
+}
+
+func TestWithDaggerModule_Success(t *testing.T) {
+	client := &dagger.Client{}
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		return client, nil
+	})
+
+	var got *fakeModule
+	result, err := withDaggerModule(context.Background(), "scan failed", newFakeModule, func(module *fakeModule) (string, error) {
+		got = module
+		return "scan output", nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, "scan output", resultText(t, result))
+	require.NotNil(t, got)
+	assert.Same(t, client, got.client, "module should be built on the shared client")
+	assert.Zero(t, daggerClients.refs, "the client reference should be released")
+}
+
+func TestWithDaggerModule_ModuleError(t *testing.T) {
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		return &dagger.Client{}, nil
+	})
+
+	result, err := withDaggerModule(context.Background(), "Trivy image scan failed", newFakeModule, func(module *fakeModule) (string, error) {
+		return "partial output", errors.New("exit code 1")
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "Trivy image scan failed: exit code 1", resultText(t, result))
+	assert.Equal(t, "execution_failed", resultErrorCode(result))
+	assert.Zero(t, daggerClients.refs, "the client reference should be released")
+}
+
+func TestWithDaggerModule_ConnectError(t *testing.T) {
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		return nil, errors.New("engine unavailable")
+	})
+
+	called := false
+	result, err := withDaggerModule(context.Background(), "scan failed", newFakeModule, func(module *fakeModule) (string, error) {
+		called = true
+		return "", nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, called, "fn must not run without a client")
+	assert.True(t, result.IsError)
+	assert.Equal(t, "failed to create Dagger client: engine unavailable", resultText(t, result))
+	assert.Equal(t, "engine_unavailable", resultErrorCode(result))
+	assert.Zero(t, daggerClients.refs)
+}
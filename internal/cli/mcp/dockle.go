@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -25,21 +24,51 @@ func addDockleToolsDirect(s *server.MCPServer) {
 			mcp.Description("Container image reference to scan"),
 			mcp.Required(),
 		),
+		mcp.WithString("username",
+			mcp.Description("Username for private registry authentication"),
+		),
+		mcp.WithString("password",
+			mcp.Description("Password or token for private registry authentication"),
+		),
+		mcp.WithBoolean("insecure",
+			mcp.Description("Allow insecure registry connections (HTTP or self-signed certificates)"),
+		),
+		mcp.WithBoolean("no_cache",
+			mcp.Description("Disable the shared Dockle metadata cache"),
+		),
+		mcp.WithString("cache_key",
+			mcp.Description("Name of the Dockle metadata cache volume, to keep separate caches per project (default: ship-dockle-cache)"),
+		),
+		mcp.WithBoolean("offline",
+			mcp.Description("Run from the cached metadata without checking for updates"),
+		),
 	)
 	s.AddTool(scanImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageRef := request.GetString("image_ref", "")
+		username := request.GetString("username", "")
+		password := request.GetString("password", "")
+		insecure := request.GetBool("insecure", false)
+		noCache := request.GetBool("no_cache", false)
+		cacheKey := request.GetString("cache_key", "")
+		offline := request.GetBool("offline", false)
 
 		// Create Dockle module and scan image
 		dockleModule := modules.NewDockleModule(client)
-		result, err := dockleModule.ScanImageString(ctx, imageRef)
+		result, err := dockleModule.ScanImageString(ctx, imageRef,
+			modules.WithDockleRegistryAuth(username, password),
+			modules.WithDockleInsecure(insecure),
+			modules.WithDockleNoCache(noCache),
+			modules.WithDockleCacheKey(cacheKey),
+			modules.WithDockleOffline(offline),
+		)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("dockle scan image failed: %v", err)), nil
 		}
@@ -54,21 +83,37 @@ func addDockleToolsDirect(s *server.MCPServer) {
 			mcp.Description("Path to container image tarball"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean("no_cache",
+			mcp.Description("Disable the shared Dockle metadata cache"),
+		),
+		mcp.WithString("cache_key",
+			mcp.Description("Name of the Dockle metadata cache volume, to keep separate caches per project (default: ship-dockle-cache)"),
+		),
+		mcp.WithBoolean("offline",
+			mcp.Description("Run from the cached metadata without checking for updates"),
+		),
 	)
 	s.AddTool(scanTarballTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		tarballPath := request.GetString("tarball_path", "")
+		noCache := request.GetBool("no_cache", false)
+		cacheKey := request.GetString("cache_key", "")
+		offline := request.GetBool("offline", false)
 
 		// Create Dockle module and scan tarball
 		dockleModule := modules.NewDockleModule(client)
-		result, err := dockleModule.ScanTarballString(ctx, tarballPath)
+		result, err := dockleModule.ScanTarballString(ctx, tarballPath,
+			modules.WithDockleNoCache(noCache),
+			modules.WithDockleCacheKey(cacheKey),
+			modules.WithDockleOffline(offline),
+		)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("dockle scan tarball failed: %v", err)), nil
 		}
@@ -86,22 +131,37 @@ func addDockleToolsDirect(s *server.MCPServer) {
 		mcp.WithString("output_file",
 			mcp.Description("Output file path for JSON results"),
 		),
+		mcp.WithString("username",
+			mcp.Description("Username for private registry authentication"),
+		),
+		mcp.WithString("password",
+			mcp.Description("Password or token for private registry authentication"),
+		),
+		mcp.WithBoolean("insecure",
+			mcp.Description("Allow insecure registry connections (HTTP or self-signed certificates)"),
+		),
 	)
 	s.AddTool(scanJsonTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		imageRef := request.GetString("image_ref", "")
 		outputFile := request.GetString("output_file", "")
+		username := request.GetString("username", "")
+		password := request.GetString("password", "")
+		insecure := request.GetBool("insecure", false)
 
 		// Create Dockle module and scan image with JSON output
 		dockleModule := modules.NewDockleModule(client)
-		result, err := dockleModule.ScanImageJSON(ctx, imageRef, outputFile)
+		result, err := dockleModule.ScanImageJSON(ctx, imageRef, outputFile,
+			modules.WithDockleRegistryAuth(username, password),
+			modules.WithDockleInsecure(insecure),
+		)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("dockle scan json failed: %v", err)), nil
 		}
@@ -122,11 +182,11 @@ func addDockleToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanTarballJsonTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		tarballPath := request.GetString("tarball_path", "")
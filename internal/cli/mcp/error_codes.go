@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolUnavailableMessages are fragments of the Dagger errors reported when a
+// tool image cannot be pulled or a tool binary is missing from its image
+var toolUnavailableMessages = []string{
+	"pull access denied",
+	"failed to resolve source metadata",
+	"manifest unknown",
+	"executable file not found",
+}
+
+// findingsExceededErrors are the module errors returned when a tool ran but
+// its findings failed its threshold
+var findingsExceededErrors = []error{
+	modules.ErrCheckovFailedChecks,
+	modules.ErrGitleaksLeaksFound,
+}
+
+// classifyToolError wraps err with the ship sentinel error matching its cause:
+// ErrFindingsExceeded for findings that failed a tool's threshold and
+// ErrToolUnavailable for an image that cannot be pulled or a missing binary.
+// Other errors are returned unchanged.
+func classifyToolError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, findings := range findingsExceededErrors {
+		if errors.Is(err, findings) {
+			return fmt.Errorf("%w: %w", ship.ErrFindingsExceeded, err)
+		}
+	}
+	for _, fragment := range toolUnavailableMessages {
+		if strings.Contains(err.Error(), fragment) {
+			return fmt.Errorf("%w: %w", ship.ErrToolUnavailable, err)
+		}
+	}
+	return err
+}
+
+// toolErrorResult builds a tool error result with message whose metadata
+// carries the error_code of err
+func toolErrorResult(message string, err error) *mcp.CallToolResult {
+	return ship.NewToolErrorResult(ship.ErrorCodeFor(classifyToolError(err)), message)
+}
+
+// WithToolErrorCodes tags every tool error result that has no error_code yet
+// with one classified from its message, so clients can react to failures of
+// any tool without parsing messages
+func WithToolErrorCodes() server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err == nil && result != nil && result.IsError && resultErrorCode(result) == "" {
+				code := ship.ErrorCodeFor(classifyToolError(errors.New(resultMessage(result))))
+				if result.Meta == nil {
+					result.Meta = &mcp.Meta{}
+				}
+				if result.Meta.AdditionalFields == nil {
+					result.Meta.AdditionalFields = make(map[string]any)
+				}
+				result.Meta.AdditionalFields[ship.ErrorCodeMetaKey] = string(code)
+			}
+			return result, err
+		}
+	})
+}
+
+// resultErrorCode returns the error_code of result, if any
+func resultErrorCode(result *mcp.CallToolResult) string {
+	if result.Meta == nil {
+		return ""
+	}
+	code, _ := result.Meta.AdditionalFields[ship.ErrorCodeMetaKey].(string)
+	return code
+}
+
+// resultMessage joins the text content of result
+func resultMessage(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyToolError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ship.ErrorCode
+	}{
+		{"image pull", errors.New("failed to resolve source metadata for docker.io/aquasec/trivy:nope: not found"), ship.ErrorCodeToolUnavailable},
+		{"private image", errors.New("pull access denied for private/scanner"), ship.ErrorCodeToolUnavailable},
+		{"missing binary", errors.New(`exec: "checkov": executable file not found in $PATH`), ship.ErrorCodeToolUnavailable},
+		{"checkov failed checks", fmt.Errorf("scan: %w", modules.ErrCheckovFailedChecks), ship.ErrorCodeFindingsExceeded},
+		{"gitleaks leaks", modules.ErrGitleaksLeaksFound, ship.ErrorCodeFindingsExceeded},
+		{"other", errors.New("exit code 1"), ship.ErrorCodeExecutionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyToolError(tt.err)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, ship.ErrorCodeFor(err))
+		})
+	}
+}
+
+func TestWithDaggerModule_ToolUnavailable(t *testing.T) {
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		return &dagger.Client{}, nil
+	})
+
+	result, err := withDaggerModule(context.Background(), "Checkov scan failed", newFakeModule, func(module *fakeModule) (string, error) {
+		return "", errors.New("pull access denied for bridgecrew/checkov")
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, "Checkov scan failed: pull access denied for bridgecrew/checkov", resultText(t, result))
+	assert.Equal(t, "tool_unavailable", resultErrorCode(result))
+}
+
+func TestWithToolErrorCodes(t *testing.T) {
+	s := server.NewMCPServer("test", "1.0.0", WithToolErrorCodes())
+	s.AddTool(mcp.NewTool("untagged"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("failed to run nmap: executable file not found in $PATH"), nil
+	})
+	s.AddTool(mcp.NewTool("tagged"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return ship.NewToolErrorResult(ship.ErrorCodeInvalidInput, "target is required"), nil
+	})
+	s.AddTool(mcp.NewTool("ok"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	for name, want := range map[string]string{
+		"untagged": "tool_unavailable",
+		"tagged":   "invalid_input",
+		"ok":       "",
+	} {
+		result, err := CallTool(context.Background(), s, name, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, resultErrorCode(result), name)
+	}
+}
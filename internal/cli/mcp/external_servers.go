@@ -540,21 +540,33 @@ var ExternalMCPServers = map[string]ship.MCPServerConfig{
 
 // IsExternalMCPServer checks if the tool name matches an external MCP server
 func IsExternalMCPServer(toolName string) bool {
-	_, exists := ExternalMCPServers[toolName]
+	_, exists := GetExternalMCPServer(toolName)
 	return exists
 }
 
-// GetExternalMCPServer returns the configuration for an external MCP server
+// GetExternalMCPServer returns the configuration for an external MCP server.
+// Servers defined in ~/.ship/mcp-servers.yaml take precedence over built-in
+// servers of the same name.
 func GetExternalMCPServer(toolName string) (ship.MCPServerConfig, bool) {
+	if config, exists := userMCPServers()[toolName]; exists {
+		return config, true
+	}
 	config, exists := ExternalMCPServers[toolName]
 	return config, exists
 }
 
-// ListExternalMCPServers returns a list of all available external MCP server names
+// ListExternalMCPServers returns a list of all available external MCP server
+// names, built-in and user-defined
 func ListExternalMCPServers() []string {
-	servers := make([]string, 0, len(ExternalMCPServers))
+	user := userMCPServers()
+	servers := make([]string, 0, len(ExternalMCPServers)+len(user))
 	for name := range ExternalMCPServers {
 		servers = append(servers, name)
 	}
+	for name := range user {
+		if _, builtin := ExternalMCPServers[name]; !builtin {
+			servers = append(servers, name)
+		}
+	}
 	return servers
 }
@@ -3,9 +3,9 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -28,26 +28,36 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 			mcp.Description("Path to custom Falco rules"),
 		),
 		mcp.WithString("output_format",
-			mcp.Description("Output format"),
+			mcp.Description("Alert output format; json emits one machine-parseable alert per line (default: text)"),
 			mcp.Enum("json", "text"),
 		),
+		mcp.WithNumber("duration",
+			mcp.Description("Stop monitoring after this many seconds and return captured alerts (0 runs until stopped)"),
+		),
 	)
 	s.AddTool(startMonitoringTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		configPath := request.GetString("config_path", "")
 		rulesPath := request.GetString("rules_path", "")
 		outputFormat := request.GetString("output_format", "")
+		duration := request.GetInt("duration", 0)
+
+		if duration < 0 {
+			return mcp.NewToolResultError("duration must not be negative"), nil
+		}
 
 		// Create Falco module and start monitoring
 		falcoModule := modules.NewFalcoModule(client)
-		result, err := falcoModule.StartMonitoring(ctx, configPath, rulesPath, outputFormat)
+		result, err := falcoModule.StartMonitoring(ctx, configPath, rulesPath, outputFormat,
+			modules.WithFalcoDuration(time.Duration(duration)*time.Second),
+		)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("falco start monitoring failed: %v", err)), nil
 		}
@@ -65,11 +75,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(validateRulesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		rulesPath := request.GetString("rules_path", "")
@@ -96,11 +106,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(dryRunTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		configPath := request.GetString("config_path", "")
@@ -126,11 +136,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listFieldsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		source := request.GetString("source", "")
@@ -151,11 +161,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Falco module and get version
 		falcoModule := modules.NewFalcoModule(client)
@@ -176,11 +186,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listRulesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		rulesPath := request.GetString("rules_path", "")
@@ -208,11 +218,11 @@ func addFalcoToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(describeRuleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		ruleName := request.GetString("rule_name", "")
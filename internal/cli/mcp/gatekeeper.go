@@ -3,9 +3,10 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
-	"dagger.io/dagger"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -30,11 +31,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(installTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		version := request.GetString("version", "v3.20.0")
@@ -62,11 +63,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(uninstallTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		version := request.GetString("version", "v3.20.0")
@@ -92,11 +93,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(applyConstraintTemplateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		templateFile := request.GetString("template_file", "")
@@ -121,11 +122,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(applyConstraintTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		constraintFile := request.GetString("constraint_file", "")
@@ -146,11 +147,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getConstraintTemplatesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Gatekeeper module and get constraint templates
 		gatekeeperModule := modules.NewGatekeeperModule(client)
@@ -171,11 +172,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getConstraintsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		constraintType := request.GetString("constraint_type", "")
@@ -196,11 +197,11 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create Gatekeeper module and get status
 		gatekeeperModule := modules.NewGatekeeperModule(client)
@@ -211,4 +212,101 @@ func addGatekeeperToolsDirect(s *server.MCPServer) {
 
 		return mcp.NewToolResultText(result), nil
 	})
-}
\ No newline at end of file
+
+	// Simulate constraints against live cluster resources
+	simulateTool := mcp.NewTool("gatekeeper_simulate_cluster",
+		mcp.WithDescription("Dry-run Gatekeeper constraints against live cluster resources without applying anything"),
+		mcp.WithString("kubeconfig",
+			mcp.Description("Path to kubeconfig file for the target cluster"),
+			mcp.Required(),
+		),
+		mcp.WithString("templates_dir",
+			mcp.Description("Directory containing constraint templates"),
+			mcp.Required(),
+		),
+		mcp.WithString("constraints_dir",
+			mcp.Description("Directory containing constraints"),
+			mcp.Required(),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to evaluate (default: all namespaces)"),
+		),
+		mcp.WithString("resource_kinds",
+			mcp.Description("Comma-separated resource kinds to evaluate (e.g., pods,deployments)"),
+		),
+		mcp.WithString("output",
+			mcp.Description("Output format"),
+			mcp.Enum("default", "json", "yaml"),
+		),
+	)
+	s.AddTool(simulateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Create Dagger client
+		client, err := acquireDaggerClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		}
+		defer releaseDaggerClient()
+
+		// Get parameters
+		opts := []modules.GatekeeperOption{
+			modules.WithKubeconfigPath(request.GetString("kubeconfig", "")),
+			modules.WithTemplatesDir(request.GetString("templates_dir", "")),
+			modules.WithConstraintsDir(request.GetString("constraints_dir", "")),
+			modules.WithNamespace(request.GetString("namespace", "")),
+		}
+		if output := request.GetString("output", ""); output != "" && output != "default" {
+			opts = append(opts, modules.WithOutput(output))
+		}
+		if kinds := request.GetString("resource_kinds", ""); kinds != "" {
+			var resourceKinds []string
+			for _, kind := range strings.Split(kinds, ",") {
+				if kind = strings.TrimSpace(kind); kind != "" {
+					resourceKinds = append(resourceKinds, kind)
+				}
+			}
+			opts = append(opts, modules.WithResourceKinds(resourceKinds))
+		}
+
+		// Create Gatekeeper module and run simulation
+		gatekeeperModule := modules.NewGatekeeperModule(client)
+		result, err := gatekeeperModule.SimulateAgainstCluster(ctx, opts...)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("gatekeeper cluster simulation failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+
+	// Generate a constraint template from an existing Rego policy
+	templateFromRegoTool := mcp.NewTool("gatekeeper_generate_template_from_rego",
+		mcp.WithDescription("Wrap an existing OPA Rego policy into a Gatekeeper ConstraintTemplate; the policy needs a package and a violation rule"),
+		mcp.WithString("rego_file",
+			mcp.Description("Path to the Rego policy file"),
+			mcp.Required(),
+		),
+		mcp.WithString("kind",
+			mcp.Description("Constraint kind the template defines (default: derived from the Rego package)"),
+		),
+		mcp.WithString("output_file",
+			mcp.Description("Save the template to this file, relative to --artifact-dir or the current directory"),
+		),
+	)
+	s.AddTool(templateFromRegoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		regoFile := request.GetString("rego_file", "")
+		if regoFile == "" {
+			return mcp.NewToolResultError("rego_file is required"), nil
+		}
+
+		rego, err := os.ReadFile(modules.HostPath(regoFile))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to read rego policy: %v", err)), nil
+		}
+
+		template, err := modules.ConstraintTemplateFromRego(request.GetString("kind", ""), string(rego))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("gatekeeper template generation failed: %v", err)), nil
+		}
+
+		return withReportArtifact(mcp.NewToolResultText(template), request.GetString("output_file", ""), template), nil
+	})
+}
@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatekeeperGenerateTemplateFromRego(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ArtifactDirEnv, dir)
+	regoFile := filepath.Join(dir, "policy.rego")
+	require.NoError(t, os.WriteFile(regoFile, []byte("package k8sdenyall\n\nviolation[{\"msg\": \"denied\"}] {\n  true\n}\n"), 0o644))
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	AddGatekeeperTools(s, noopExecute)
+
+	result, err := CallTool(context.Background(), s, "gatekeeper_generate_template_from_rego", map[string]string{
+		"rego_file":   regoFile,
+		"kind":        "K8sDenyAll",
+		"output_file": "k8sdenyall.yaml",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, resultText(t, result))
+
+	template := resultText(t, result)
+	assert.Contains(t, template, "name: k8sdenyall")
+	assert.Contains(t, template, "kind: K8sDenyAll")
+
+	saved, err := os.ReadFile(filepath.Join(dir, "k8sdenyall.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, template, string(saved))
+}
+
+func TestGatekeeperGenerateTemplateFromRego_InvalidPolicy(t *testing.T) {
+	regoFile := filepath.Join(t.TempDir(), "policy.rego")
+	require.NoError(t, os.WriteFile(regoFile, []byte("package example\n\ndeny[msg] { msg := \"x\" }\n"), 0o644))
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	AddGatekeeperTools(s, noopExecute)
+
+	result, err := CallTool(context.Background(), s, "gatekeeper_generate_template_from_rego", map[string]string{"rego_file": regoFile})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(t, result), "no violation rule")
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddGitSecretsTools adds git-secrets (AWS secret scanning) MCP tool implementations using direct Dagger calls
@@ -38,11 +37,11 @@ func addGitSecretsToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		sourcePath := request.GetString("source_path", "")
@@ -86,11 +85,11 @@ func addGitSecretsToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(installHooksTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		repoPath := request.GetString("repo_path", "")
@@ -2,12 +2,12 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddGitleaksTools adds Gitleaks (fast secret scanning) MCP tool implementations using direct Dagger calls
@@ -33,7 +33,7 @@ func addGitleaksToolsDirect(s *server.MCPServer) {
 			mcp.Enum("json", "csv", "sarif"),
 		),
 		mcp.WithString("report_path",
-			mcp.Description("Path to write the report"),
+			mcp.Description("Path to write the report, relative to the artifact directory"),
 		),
 		mcp.WithBoolean("verbose",
 			mcp.Description("Show verbose output"),
@@ -41,14 +41,20 @@ func addGitleaksToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("no_git",
 			mcp.Description("Treat git repo as a regular directory and scan those files"),
 		),
+		mcp.WithBoolean("redact",
+			mcp.Description("Redact secret values in the output"),
+		),
+		mcp.WithString("log_opts",
+			mcp.Description("git log options selecting the commits to scan, e.g. HEAD~10..HEAD (not valid with no_git)"),
+		),
 	)
 	s.AddTool(detectTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		sourcePath := request.GetString("source_path", "")
@@ -57,10 +63,20 @@ func addGitleaksToolsDirect(s *server.MCPServer) {
 		reportPath := request.GetString("report_path", "")
 		verbose := request.GetBool("verbose", false)
 		noGit := request.GetBool("no_git", false)
+		redact := request.GetBool("redact", false)
+		logOpts := request.GetString("log_opts", "")
 
 		if sourcePath == "" {
 			return mcp.NewToolResultError("source_path is required"), nil
 		}
+		if logOpts != "" && noGit {
+			return mcp.NewToolResultError("log_opts cannot be used with no_git"), nil
+		}
+		if reportPath != "" {
+			if reportPath, err = ArtifactPath(reportPath); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
 
 		// Create Gitleaks module
 		gitleaksModule := modules.NewGitleaksModule(client)
@@ -72,10 +88,15 @@ func addGitleaksToolsDirect(s *server.MCPServer) {
 			ReportPath:   reportPath,
 			Verbose:      verbose,
 			NoGit:        noGit,
+			Redact:       redact,
+			LogOpts:      logOpts,
 		}
 
 		// Run detection
 		result, err := gitleaksModule.Detect(ctx, sourcePath, opts)
+		if errors.Is(err, modules.ErrGitleaksLeaksFound) {
+			return mcp.NewToolResultText(result), nil
+		}
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Gitleaks detection failed: %v", err)), nil
 		}
@@ -102,11 +123,11 @@ func addGitleaksToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(protectTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Get parameters
 		sourcePath := request.GetString("source_path", "")
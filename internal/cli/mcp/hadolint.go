@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AddHadolintTools adds hadolint (Dockerfile linter) MCP tool implementations
+func AddHadolintTools(s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc) {
+	// Ignore executeShipCommand - we use direct Dagger calls
+	addHadolintToolsDirect(s)
+}
+
+// addHadolintToolsDirect implements direct Dagger calls for hadolint tools
+func addHadolintToolsDirect(s *server.MCPServer) {
+	// Hadolint scan Dockerfile tool
+	scanTool := mcp.NewTool("hadolint_scan_dockerfile",
+		mcp.WithDescription("Lint a Dockerfile for best practices using hadolint"),
+		mcp.WithString("directory",
+			mcp.Description("Directory containing the Dockerfile"),
+			mcp.Required(),
+		),
+		mcp.WithString("dockerfile",
+			mcp.Description("Dockerfile path relative to directory (default: Dockerfile)"),
+		),
+		mcp.WithString("config",
+			mcp.Description("Path to hadolint config file (default: .hadolint.yaml in directory if present)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format"),
+			mcp.Enum("tty", "json", "checkstyle", "codeclimate", "gitlab_codeclimate", "gnu", "codacy", "sonarqube", "sarif"),
+		),
+		mcp.WithString("failure_threshold",
+			mcp.Description("Exit with failure only for rules at or above this severity"),
+			mcp.Enum("error", "warning", "info", "style", "ignore", "none"),
+		),
+		mcp.WithString("ignore",
+			mcp.Description("Comma-separated list of rules to ignore (e.g., DL3008,DL3009)"),
+		),
+	)
+	s.AddTool(scanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Create Dagger client
+		client, err := acquireDaggerClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		}
+		defer releaseDaggerClient()
+
+		// Get parameters
+		directory := request.GetString("directory", "")
+		dockerfile := request.GetString("dockerfile", "")
+		configPath := request.GetString("config", "")
+		format := request.GetString("format", "")
+		failureThreshold := request.GetString("failure_threshold", "")
+		ignore := request.GetString("ignore", "")
+
+		if directory == "" {
+			return mcp.NewToolResultError("directory is required"), nil
+		}
+
+		var opts []modules.HadolintOption
+		if configPath != "" {
+			opts = append(opts, modules.WithHadolintConfig(configPath))
+		}
+		if format != "" {
+			opts = append(opts, modules.WithHadolintFormat(format))
+		}
+		if failureThreshold != "" {
+			opts = append(opts, modules.WithHadolintFailureThreshold(failureThreshold))
+		}
+		if ignore != "" {
+			var rules []string
+			for _, rule := range strings.Split(ignore, ",") {
+				if rule = strings.TrimSpace(rule); rule != "" {
+					rules = append(rules, rule)
+				}
+			}
+			opts = append(opts, modules.WithHadolintIgnore(rules))
+		}
+
+		// Create hadolint module and lint Dockerfile
+		hadolintModule := modules.NewHadolintModule(client)
+		result, err := hadolintModule.ScanDockerfile(ctx, directory, dockerfile, opts...)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("hadolint scan failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+
+	// Hadolint get version tool
+	getVersionTool := mcp.NewTool("hadolint_get_version",
+		mcp.WithDescription("Get hadolint version information"),
+	)
+	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Create Dagger client
+		client, err := acquireDaggerClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		}
+		defer releaseDaggerClient()
+
+		// Create hadolint module and get version
+		hadolintModule := modules.NewHadolintModule(client)
+		result, err := hadolintModule.GetVersion(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("hadolint version failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	})
+}
@@ -67,6 +67,7 @@ func GenerateMCPHelpText() string {
 	helpText.WriteString("  ship mcp slack --var SLACK_MCP_XOXC_TOKEN=your_token     # Proxy Slack workspace operations\n")
 	helpText.WriteString("  ship mcp github --var GITHUB_PERSONAL_ACCESS_TOKEN=your_token  # Proxy GitHub operations\n")
 	helpText.WriteString("  ship mcp desktop-commander                                # Proxy desktop operations\n")
+	helpText.WriteString("  ship mcp filesystem --port 8080                          # Bridge a stdio server to HTTP (/mcp) and SSE (/sse)\n")
 	helpText.WriteString("\n")
 	helpText.WriteString("  # AWS Labs Official MCP Servers (requires 'uv' and AWS credentials)\n")
 	helpText.WriteString("  ship mcp aws-core --var AWS_PROFILE=default             # AWS core operations\n")
@@ -101,7 +102,7 @@ func generateExternalServersHelpText() string {
 
 	// Official ModelContextProtocol servers
 	for _, serverName := range officialServers {
-		if config, exists := ExternalMCPServers[serverName]; exists {
+		if config, exists := GetExternalMCPServer(serverName); exists {
 			description := getServerDescription(serverName)
 			helpText.WriteString(fmt.Sprintf("  %-16s - %s\n", config.Name, description))
 		}
@@ -111,13 +112,22 @@ func generateExternalServersHelpText() string {
 	// AWS Labs servers
 	helpText.WriteString("  # AWS Labs Official MCP Servers\n")
 	for _, serverName := range awsLabsServers {
-		if config, exists := ExternalMCPServers[serverName]; exists {
+		if config, exists := GetExternalMCPServer(serverName); exists {
 			description := getServerDescription(serverName)
 			helpText.WriteString(fmt.Sprintf("  %-16s - %s\n", config.Name, description))
 		}
 	}
 	helpText.WriteString("\n")
 
+	// Servers from ~/.ship/mcp-servers.yaml
+	if names := UserMCPServerNames(); len(names) > 0 {
+		helpText.WriteString("  # User-defined MCP Servers (~/" + UserMCPServersFile + ")\n")
+		for _, name := range names {
+			helpText.WriteString(fmt.Sprintf("  %-16s - %s\n", name, "User-defined MCP server"))
+		}
+		helpText.WriteString("\n")
+	}
+
 	return helpText.String()
 }
 
@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddIacPlanTools adds Infrastructure as Code planning MCP tool implementations using direct Dagger calls
@@ -40,11 +39,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformPlanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -90,11 +89,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformValidateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -130,11 +129,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformFormatTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -172,11 +171,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformShowTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -226,11 +225,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformWorkspaceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -270,11 +269,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformGraphTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
@@ -317,11 +316,11 @@ func addIacPlanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(terraformInitTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewIacPlanModule(client)
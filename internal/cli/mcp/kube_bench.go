@@ -8,7 +8,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddKubeBenchTools adds Kube-bench (Kubernetes CIS benchmark) MCP tool implementations using direct Dagger calls
@@ -27,7 +26,9 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 		),
 		mcp.WithString("benchmark",
 			mcp.Description("Manually specify CIS benchmark version"),
-			mcp.Enum("gke-1.0", "gke-1.2.0", "gke-1.6.0", "ack-1.0", "tkgi-1.2.53", "rke-cis-1.7", "rke2-cis-1.7", "k3s-cis-1.7", "rh-0.7", "rh-1.0"),
+			mcp.Enum("cis-1.20", "cis-1.23", "cis-1.24", "cis-1.7", "cis-1.8", "cis-1.9",
+				"eks-1.1.0", "eks-1.2.0", "eks-1.5.0", "aks-1.0", "gke-1.0", "gke-1.2.0", "gke-1.6.0", "ack-1.0",
+				"tkgi-1.2.53", "rke-cis-1.7", "rke2-cis-1.7", "k3s-cis-1.7", "rh-0.7", "rh-1.0"),
 		),
 		mcp.WithString("version",
 			mcp.Description("Manually specify Kubernetes version"),
@@ -53,11 +54,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(runTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
@@ -76,11 +77,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 				output, err = module.RunNodeBenchmark(ctx, kubeconfig)
 			} else {
 				// General benchmark with targets
-				output, err = module.RunBenchmark(ctx, kubeconfig)
+				output, err = module.RunBenchmark(ctx, kubeconfig, modules.WithKubeBenchBenchmark(request.GetString("benchmark", "")))
 			}
 		} else {
 			// General benchmark run
-			output, err = module.RunBenchmark(ctx, kubeconfig)
+			output, err = module.RunBenchmark(ctx, kubeconfig, modules.WithKubeBenchBenchmark(request.GetString("benchmark", "")))
 		}
 
 		// If specific output format requested, use custom output function
@@ -128,11 +129,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(runChecksTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
@@ -179,11 +180,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(runSkipTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
@@ -228,11 +229,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(runCustomOutputTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
@@ -261,11 +262,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(versionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
@@ -300,11 +301,11 @@ func addKubeBenchToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(runAsffTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeBenchModule(client)
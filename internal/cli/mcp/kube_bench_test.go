@@ -0,0 +1,26 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeBenchRunBenchmarkEnum(t *testing.T) {
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	AddKubeBenchTools(s, noopExecute)
+
+	var benchmarks []string
+	for _, tool := range registeredTools(s) {
+		if tool.Name != "kube_bench_run" {
+			continue
+		}
+		property, ok := tool.InputSchema.Properties["benchmark"].(map[string]any)
+		require.True(t, ok)
+		benchmarks, _ = property["enum"].([]string)
+	}
+
+	assert.Subset(t, benchmarks, []string{"cis-1.23", "eks-1.2.0", "aks-1.0"}, "the benchmarks the CLI documents can be forced over MCP")
+}
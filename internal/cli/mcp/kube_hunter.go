@@ -8,7 +8,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddKubeHunterTools adds Kube-hunter (Kubernetes penetration testing) MCP tool implementations using direct Dagger calls
@@ -29,6 +28,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("active",
 			mcp.Description("Enable active hunting (attempts to exploit vulnerabilities)"),
 		),
+		mcp.WithBoolean("i_understand_active_is_intrusive",
+			mcp.Description("Required confirmation when active is true; active hunting attempts real exploits"),
+		),
 		mcp.WithString("report",
 			mcp.Description("Report format: json, yaml, table"),
 			mcp.Enum("json", "yaml", "table"),
@@ -40,11 +42,11 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(remoteScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -56,6 +58,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		}
 
 		active := request.GetBool("active", false)
+		if err := modules.ConfirmKubeHunterActive(active, request.GetBool("i_understand_active_is_intrusive", false)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%v", modules.KubeHunterActiveWarning, err)), nil
+		}
 		reportFormat := request.GetString("report", "json")
 
 		// Run remote scan
@@ -64,7 +69,7 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError(fmt.Sprintf("kube-hunter remote scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(withActiveWarning(output, active)), nil
 	})
 
 	// Kube-hunter CIDR scan
@@ -77,6 +82,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("active",
 			mcp.Description("Enable active hunting"),
 		),
+		mcp.WithBoolean("i_understand_active_is_intrusive",
+			mcp.Description("Required confirmation when active is true; active hunting attempts real exploits"),
+		),
 		mcp.WithString("report",
 			mcp.Description("Report format: json, yaml, table"),
 			mcp.Enum("json", "yaml", "table"),
@@ -84,11 +92,11 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(cidrScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -100,6 +108,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		}
 
 		active := request.GetBool("active", false)
+		if err := modules.ConfirmKubeHunterActive(active, request.GetBool("i_understand_active_is_intrusive", false)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%v", modules.KubeHunterActiveWarning, err)), nil
+		}
 		reportFormat := request.GetString("report", "json")
 
 		// Run CIDR scan
@@ -108,7 +119,7 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError(fmt.Sprintf("kube-hunter CIDR scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(withActiveWarning(output, active)), nil
 	})
 
 	// Kube-hunter interface scan
@@ -121,6 +132,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("active",
 			mcp.Description("Enable active hunting"),
 		),
+		mcp.WithBoolean("i_understand_active_is_intrusive",
+			mcp.Description("Required confirmation when active is true; active hunting attempts real exploits"),
+		),
 		mcp.WithString("report",
 			mcp.Description("Report format: json, yaml, table"),
 			mcp.Enum("json", "yaml", "table"),
@@ -128,11 +142,11 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(interfaceScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -144,6 +158,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		}
 
 		active := request.GetBool("active", false)
+		if err := modules.ConfirmKubeHunterActive(active, request.GetBool("i_understand_active_is_intrusive", false)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%v", modules.KubeHunterActiveWarning, err)), nil
+		}
 		reportFormat := request.GetString("report", "json")
 
 		// Run interface scan
@@ -152,7 +169,7 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError(fmt.Sprintf("kube-hunter interface scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(withActiveWarning(output, active)), nil
 	})
 
 	// Kube-hunter pod scan
@@ -161,6 +178,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("active",
 			mcp.Description("Enable active hunting"),
 		),
+		mcp.WithBoolean("i_understand_active_is_intrusive",
+			mcp.Description("Required confirmation when active is true; active hunting attempts real exploits"),
+		),
 		mcp.WithString("report",
 			mcp.Description("Report format: json, yaml, table"),
 			mcp.Enum("json", "yaml", "table"),
@@ -174,11 +194,11 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(podScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -186,6 +206,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		// Get parameters
 		kubeconfig := request.GetString("kubeconfig", "")
 		active := request.GetBool("active", false)
+		if err := modules.ConfirmKubeHunterActive(active, request.GetBool("i_understand_active_is_intrusive", false)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%v", modules.KubeHunterActiveWarning, err)), nil
+		}
 		reportFormat := request.GetString("report", "json")
 
 		// Run pod scan
@@ -194,7 +217,7 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError(fmt.Sprintf("kube-hunter pod scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(withActiveWarning(output, active)), nil
 	})
 
 	// Kube-hunter list tests
@@ -206,11 +229,11 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(listTestsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -243,14 +266,17 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("active",
 			mcp.Description("Enable active hunting"),
 		),
+		mcp.WithBoolean("i_understand_active_is_intrusive",
+			mcp.Description("Required confirmation when active is true; active hunting attempts real exploits"),
+		),
 	)
 	s.AddTool(customHuntersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewKubeHunterModule(client)
@@ -284,6 +310,9 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 		}
 
 		active := request.GetBool("active", false)
+		if err := modules.ConfirmKubeHunterActive(active, request.GetBool("i_understand_active_is_intrusive", false)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%v", modules.KubeHunterActiveWarning, err)), nil
+		}
 
 		// Run custom hunters
 		output, err := module.RunCustomHunters(ctx, target, includeHunters, excludeHunters, active)
@@ -291,6 +320,14 @@ func addKubeHunterToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError(fmt.Sprintf("kube-hunter custom hunters failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return mcp.NewToolResultText(withActiveWarning(output, active)), nil
 	})
-}
\ No newline at end of file
+}
+
+// withActiveWarning prefixes results of active hunts with the intrusiveness warning
+func withActiveWarning(output string, active bool) string {
+	if !active {
+		return output
+	}
+	return modules.KubeHunterActiveWarning + "\n\n" + output
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"dagger.io/dagger"
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -37,11 +36,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("target is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.ScanHost(ctx, target, scanType)
@@ -71,11 +70,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("target is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.PortScan(ctx, target, ports)
@@ -101,11 +100,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("target is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.ServiceDetection(ctx, target)
@@ -135,11 +134,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("target is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.VulnerabilityScan(ctx, target, scriptCategory)
@@ -165,11 +164,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("network is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.NetworkDiscovery(ctx, network)
@@ -203,11 +202,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("script is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.ScriptScan(ctx, target, script)
@@ -223,11 +222,11 @@ func addNmapToolsDirect(s *server.MCPServer) {
 		mcp.WithDescription("Get Nmap version"),
 	)
 	s.AddTool(versionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNmapModule(client)
 		result, err := module.GetVersion(ctx)
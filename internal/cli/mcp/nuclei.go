@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"dagger.io/dagger"
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -37,11 +36,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("url is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.ScanURL(ctx, url, severity)
@@ -71,11 +70,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("url is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.ScanWithTemplate(ctx, url, template)
@@ -109,11 +108,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("tags is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.ScanWithTags(ctx, url, tags)
@@ -129,11 +128,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 		mcp.WithDescription("Update Nuclei vulnerability templates"),
 	)
 	s.AddTool(updateTemplatesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.UpdateTemplates(ctx)
@@ -159,11 +158,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("template_path is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.ValidateTemplate(ctx, templatePath)
@@ -193,11 +192,11 @@ func addNucleiToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("url is required"), nil
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewNucleiModule(client)
 		result, err := module.GenerateReport(ctx, url, reportType)
@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddPMapperTools adds PMapper (AWS IAM privilege escalation analysis) MCP tool implementations using direct Dagger calls
@@ -30,11 +29,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createGraphTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPMapperModule(client)
@@ -98,11 +97,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(privEscTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPMapperModule(client)
@@ -132,7 +131,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	visualizeTool := mcp.NewTool("pmapper_visualize",
 		mcp.WithDescription("Visualize IAM privilege graph using real pmapper CLI"),
 		mcp.WithString("filetype",
-			mcp.Description("Output file type (svg, png, etc.)"),
+			mcp.Description("Output file type; dot and graphml are returned as text, png and svg are written to output_dir"),
+			mcp.Enum("dot", "graphml", "png", "svg"),
+		),
+		mcp.WithString("output_dir",
+			mcp.Description("Directory to write png/svg visualizations to, relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithString("profile",
 			mcp.Description("AWS profile to use"),
@@ -143,11 +146,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(visualizeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPMapperModule(client)
@@ -155,6 +158,10 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 		// Get parameters
 		profile := request.GetString("profile", "")
 		filetype := request.GetString("filetype", "")
+		outputDir, err := ArtifactPath(request.GetString("output_dir", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		// Note: Dagger module doesn't support account parameter
 		if request.GetString("account", "") != "" {
@@ -162,7 +169,7 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 		}
 
 		// Visualize graph
-		output, err := module.VisualizeGraph(ctx, profile, filetype)
+		output, err := module.VisualizeGraph(ctx, profile, filetype, modules.WithPMapperOutputDir(outputDir))
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("pmapper visualize failed: %v", err)), nil
 		}
@@ -186,11 +193,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(queryWhoCanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPMapperModule(client)
@@ -234,11 +241,11 @@ func addPMapperToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(argqueryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPMapperModule(client)
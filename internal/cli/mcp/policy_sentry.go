@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddPolicySentryTools adds Policy Sentry (AWS IAM policy generator) MCP tool implementations using direct Dagger calls
@@ -32,11 +31,11 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(createTemplateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPolicySentryModule(client)
@@ -74,11 +73,11 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(writePolicyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPolicySentryModule(client)
@@ -145,11 +144,11 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(queryActionTableTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPolicySentryModule(client)
@@ -193,11 +192,11 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(queryConditionTableTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewPolicySentryModule(client)
@@ -241,9 +240,32 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(queryArnTableTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Note: ARN table query not available in Dagger module
-		// Only basic action and condition table queries are supported
-		return mcp.NewToolResultError("ARN table query not supported in Dagger module"), nil
+		// Create Dagger client
+		client, err := acquireDaggerClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		}
+		defer releaseDaggerClient()
+
+		// Create module instance
+		module := modules.NewPolicySentryModule(client)
+
+		// Get parameters
+		service := request.GetString("service", "")
+		if service == "" {
+			return mcp.NewToolResultError("service is required"), nil
+		}
+		name := request.GetString("name", "")
+		listArnTypes := request.GetBool("list_arn_types", false)
+		format := request.GetString("format", "")
+
+		// Query ARN table
+		output, err := module.QueryArnTable(ctx, service, name, listArnTypes, format)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("policy sentry query arn table failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(output), nil
 	})
 
 	// Policy Sentry query service table tool
@@ -255,9 +277,24 @@ func addPolicySentryToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(queryServiceTableTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Note: Service table query not available in Dagger module
-		// Only basic action and condition table queries are supported
-		return mcp.NewToolResultError("service table query not supported in Dagger module"), nil
+		// Create Dagger client
+		client, err := acquireDaggerClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		}
+		defer releaseDaggerClient()
+
+		// Create module instance
+		module := modules.NewPolicySentryModule(client)
+
+		// Query service table
+		format := request.GetString("format", "")
+		output, err := module.QueryServiceTable(ctx, format)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("policy sentry query service table failed: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(output), nil
 	})
 
 
@@ -35,6 +35,7 @@ var ToolRegistry = map[string][]ToolInfo{
 		{Name: "gitleaks", Description: "Fast secret scanning for git repositories", AddFunc: AddGitleaksTools, HasVariables: false},
 		{Name: "kubescape", Description: "Kubernetes security scanner", AddFunc: AddKubescapeTools, HasVariables: false},
 		{Name: "dockle", Description: "Container image linter", AddFunc: AddDockleTools, HasVariables: false},
+		{Name: "hadolint", Description: "Dockerfile linter", AddFunc: AddHadolintTools, HasVariables: false},
 		{Name: "sops", Description: "Secrets management", AddFunc: AddSOPSTools, HasVariables: true},
 		{Name: "ossf-scorecard", Description: "OSSF security scorecard", AddFunc: AddOSSFScorecardTools, HasVariables: false},
 		{Name: "steampipe", Description: "Cloud asset querying with SQL", AddFunc: AddSteampipeTools, HasVariables: true},
@@ -98,34 +99,31 @@ var ToolRegistry = map[string][]ToolInfo{
 	},
 }
 
-// RegisterAllTools registers all tools with the MCP server
-func RegisterAllTools(s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc) {
+// RegisterAllTools registers all tools that pass filter with the MCP server
+func RegisterAllTools(s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc, filter ToolFilter) {
 	for _, tools := range ToolRegistry {
 		for _, tool := range tools {
-			if tool.AddFunc != nil {
-				tool.AddFunc(s, executeShipCommand)
-			}
+			filter.register(tool, s, executeShipCommand)
 		}
 	}
 }
 
-// RegisterToolsByCategory registers tools from a specific category
-func RegisterToolsByCategory(category string, s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc) {
+// RegisterToolsByCategory registers tools from a specific category that pass filter
+func RegisterToolsByCategory(category string, s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc, filter ToolFilter) {
 	if tools, ok := ToolRegistry[category]; ok {
 		for _, tool := range tools {
-			if tool.AddFunc != nil {
-				tool.AddFunc(s, executeShipCommand)
-			}
+			filter.register(tool, s, executeShipCommand)
 		}
 	}
 }
 
-// RegisterToolByName registers a specific tool by name
-func RegisterToolByName(name string, s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc) {
+// RegisterToolByName registers a specific tool by name, keeping only the MCP
+// tools that pass filter
+func RegisterToolByName(name string, s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc, filter ToolFilter) {
 	for _, tools := range ToolRegistry {
 		for _, tool := range tools {
 			if tool.Name == name && tool.AddFunc != nil {
-				tool.AddFunc(s, executeShipCommand)
+				filter.register(tool, s, executeShipCommand)
 				return
 			}
 		}
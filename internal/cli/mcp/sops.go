@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddSOPSTools adds SOPS (Secrets OPerationS) MCP tool implementations using direct Dagger calls
@@ -34,6 +33,15 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		mcp.WithString("age_public_key",
 			mcp.Description("Age recipient public key for encryption"),
 		),
+		mcp.WithString("hc_vault_transit",
+			mcp.Description("HashiCorp Vault transit key URI (uses VAULT_ADDR/VAULT_TOKEN from the MCP server environment, which --var and --env-file set)"),
+		),
+		mcp.WithString("encrypted_regex",
+			mcp.Description("Only encrypt values whose keys match this regex (e.g., ^(data|stringData)$)"),
+		),
+		mcp.WithString("unencrypted_regex",
+			mcp.Description("Leave values whose keys match this regex unencrypted"),
+		),
 		mcp.WithString("output_file",
 			mcp.Description("Output file path for encrypted content"),
 		),
@@ -43,11 +51,11 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(encryptFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
@@ -58,10 +66,25 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		pgpFingerprint := request.GetString("pgp_fingerprint", "")
 		agePublicKey := request.GetString("age_public_key", "")
 		outputFile := request.GetString("output_file", "")
+		hcVaultTransit := request.GetString("hc_vault_transit", "")
+		encryptedRegex := request.GetString("encrypted_regex", "")
+		unencryptedRegex := request.GetString("unencrypted_regex", "")
 		inPlace := request.GetBool("in_place", false)
 
+		// Key material (age keys, Vault auth) comes from the environment
+		opts := modules.SOPSOptionsFromEnv()
+		if hcVaultTransit != "" {
+			opts = append(opts, modules.WithSOPSVaultTransit(hcVaultTransit))
+		}
+		if encryptedRegex != "" {
+			opts = append(opts, modules.WithSOPSEncryptedRegex(encryptedRegex))
+		}
+		if unencryptedRegex != "" {
+			opts = append(opts, modules.WithSOPSUnencryptedRegex(unencryptedRegex))
+		}
+
 		// Encrypt file
-		output, err := module.EncryptFile(ctx, filePath, kmsArn, pgpFingerprint, agePublicKey, outputFile, inPlace)
+		output, err := module.EncryptFile(ctx, filePath, kmsArn, pgpFingerprint, agePublicKey, outputFile, inPlace, opts...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("SOPS encrypt file failed: %v", err)), nil
 		}
@@ -82,11 +105,11 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(decryptFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
@@ -96,7 +119,7 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		outputFile := request.GetString("output_file", "")
 
 		// Decrypt file
-		output, err := module.DecryptFile(ctx, filePath, outputFile)
+		output, err := module.DecryptFile(ctx, filePath, outputFile, modules.SOPSOptionsFromEnv()...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("SOPS decrypt file failed: %v", err)), nil
 		}
@@ -120,17 +143,20 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		mcp.WithString("add_age",
 			mcp.Description("Add age recipient public key"),
 		),
+		mcp.WithString("add_hc_vault_transit",
+			mcp.Description("Add HashiCorp Vault transit key URI"),
+		),
 		mcp.WithBoolean("in_place",
 			mcp.Description("Update file in place"),
 		),
 	)
 	s.AddTool(updateKeysTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
@@ -140,10 +166,16 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		addKms := request.GetString("add_kms", "")
 		addPgp := request.GetString("add_pgp", "")
 		addAge := request.GetString("add_age", "")
+		addVaultTransit := request.GetString("add_hc_vault_transit", "")
 		inPlace := request.GetBool("in_place", false)
 
+		opts := modules.SOPSOptionsFromEnv()
+		if addVaultTransit != "" {
+			opts = append(opts, modules.WithSOPSVaultTransit(addVaultTransit))
+		}
+
 		// Update keys
-		output, err := module.UpdateKeys(ctx, filePath, addKms, addPgp, addAge, inPlace)
+		output, err := module.UpdateKeys(ctx, filePath, addKms, addPgp, addAge, inPlace, opts...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("SOPS update keys failed: %v", err)), nil
 		}
@@ -161,11 +193,11 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(editFileTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
@@ -174,7 +206,7 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 		filePath := request.GetString("file_path", "")
 
 		// Show file content
-		output, err := module.EditFile(ctx, filePath)
+		output, err := module.EditFile(ctx, filePath, modules.SOPSOptionsFromEnv()...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("SOPS edit file failed: %v", err)), nil
 		}
@@ -188,11 +220,11 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(versionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
@@ -219,11 +251,11 @@ func addSOPSToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(publishKeysTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewSOPSModule(client)
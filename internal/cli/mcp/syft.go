@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddSyftTools adds Syft (SBOM generation from container images and filesystems) MCP tool implementations using direct Dagger calls
@@ -27,27 +29,17 @@ func addNewSyftSBOMTool(s *server.MCPServer) {
 	sbomTool := mcp.NewTool("syft_sbom",
 		mcp.WithDescription("Generate CycloneDX or SPDX SBOM from a directory, image, or archive"),
 		mcp.WithString("target",
-			mcp.Description("Target to scan (e.g., dir:., docker:alpine:3.19, oci-archive:/path/image.tar)"),
+			mcp.Description("Target to scan (e.g., dir:., docker:alpine:3.19, oci-archive:/path/image.tar). Unprefixed targets are detected: local directories, .tar/.tar.gz/.tgz archives, or image references such as alpine:3.19"),
 			mcp.Required(),
 		),
 		mcp.WithString("format",
-			mcp.Description("Output format: cyclonedx-json or spdx-json (default: cyclonedx-json)"),
+			mcp.Description("Output format: cyclonedx-json or spdx-json (default: cyclonedx-json). Comma-separate several formats, e.g. cyclonedx-json,spdx-json, to write them all in one scan"),
 		),
 		mcp.WithString("output_path",
-			mcp.Description("Where to write SBOM (default: ./sbom.cdx.json or ./sbom.spdx.json based on format)"),
+			mcp.Description("Where to write SBOM (default: sbom.cdx.json or sbom.spdx.json based on format). With several formats, the directory to write each SBOM to. Paths are relative to --artifact-dir or the current directory; artifact paths are returned absolute"),
 		),
 	)
 	s.AddTool(sbomTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		if target == "" {
@@ -56,69 +48,140 @@ func addNewSyftSBOMTool(s *server.MCPServer) {
 		
 		format := request.GetString("format", "cyclonedx-json")
 		outputPath := request.GetString("output_path", "")
-		
-		// Set default output path based on format
-		if outputPath == "" {
-			if format == "spdx-json" {
-				outputPath = "./sbom.spdx.json"
-			} else {
-				outputPath = "./sbom.cdx.json"
-			}
-		}
 
-		// Generate SBOM
-		var stdout string
-		var stderr string
-		
-		// Determine target type and call appropriate method
-		if strings.HasPrefix(target, "dir:") {
-			dirPath := strings.TrimPrefix(target, "dir:")
-			stdout, err = module.GenerateSBOMFromDirectory(ctx, dirPath, format)
-		} else if strings.HasPrefix(target, "docker:") || strings.HasPrefix(target, "registry:") {
-			stdout, err = module.GenerateSBOMFromImage(ctx, target, format)
-		} else if strings.HasPrefix(target, "oci-archive:") {
-			// For archives, we'll use the archive analysis method if it exists
-			archivePath := strings.TrimPrefix(target, "oci-archive:")
-			// Try to use archive analysis, fall back to treating as directory
-			stdout, err = module.ArchiveAnalysis(ctx, archivePath, "oci", format, false, true, "")
+		// Several comma-separated formats are written in one scan into the
+		// output directory; a single format is written to the output file
+		formats := splitSyftFormats(format)
+		var outputs []modules.SyftOutput
+		if len(formats) > 1 {
+			outputDir, err := ArtifactPath(outputPath)
 			if err != nil {
-				// Fallback: treat as directory scan
-				stdout, err = module.GenerateSBOMFromDirectory(ctx, archivePath, format)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			outputs = syftSBOMOutputs(formats, outputDir)
 		} else {
-			// Default: treat as directory
-			stdout, err = module.GenerateSBOMFromDirectory(ctx, target, format)
-		}
-		
-		// Build result in the expected format
-		result := map[string]interface{}{
-			"status": "ok",
-			"stdout": stdout,
-			"stderr": stderr,
-			"artifacts": map[string]string{},
-			"summary": map[string]interface{}{},
-			"diagnostics": []string{},
-		}
-		
-		// Add artifact path
-		if format == "spdx-json" {
-			result["artifacts"].(map[string]string)["sbom_spdx"] = outputPath
-		} else {
-			result["artifacts"].(map[string]string)["sbom_cyclonedx"] = outputPath
-		}
-		
-		if err != nil {
-			result["status"] = "error"
-			result["stderr"] = err.Error()
-			result["diagnostics"] = []string{fmt.Sprintf("Syft SBOM generation failed: %v", err)}
+			if outputPath == "" {
+				outputPath = syftSBOMOutputs([]string{format}, "")[0].Path
+			}
+			path, err := ArtifactPath(outputPath)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			outputs = []modules.SyftOutput{{Format: format, Path: path}}
 		}
 
-		// Return as JSON
-		resultJSON, _ := json.Marshal(result)
-		return mcp.NewToolResultText(string(resultJSON)), nil
+		return withDaggerModule(ctx, "Syft SBOM generation failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			stdout, err := generateSyftSBOMFiles(ctx, module, detectSyftSource(target), outputs)
+			return syftSBOMResult(stdout, syftSBOMArtifacts(outputs), err), nil
+		})
 	})
 }
 
+// syftSBOMFileGenerator writes SBOM files for a source
+type syftSBOMFileGenerator interface {
+	GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []modules.SyftOutput) error
+}
+
+// generateSyftSBOMFiles writes every output SBOM for source. An OCI archive
+// that cannot be read as an archive is scanned as a directory instead. The
+// content of a single SBOM is returned as well.
+func generateSyftSBOMFiles(ctx context.Context, generator syftSBOMFileGenerator, source syftSource, outputs []modules.SyftOutput) (string, error) {
+	err := generator.GenerateSBOMFiles(ctx, source.kind, source.ref, outputs)
+	if err != nil && source.kind == modules.SyftSourceArchive && source.archiveType == "oci" {
+		err = generator.GenerateSBOMFiles(ctx, modules.SyftSourceDirectory, source.ref, outputs)
+	}
+	if err != nil || len(outputs) != 1 {
+		return "", err
+	}
+
+	content, err := os.ReadFile(outputs[0].Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read SBOM %s: %w", outputs[0].Path, err)
+	}
+	return string(content), nil
+}
+
+// syftSBOMResult builds the syft_sbom JSON result with its artifact paths
+func syftSBOMResult(stdout string, artifacts map[string]string, err error) string {
+	result := map[string]interface{}{
+		"status":      "ok",
+		"stdout":      stdout,
+		"stderr":      "",
+		"artifacts":   artifacts,
+		"summary":     map[string]interface{}{},
+		"diagnostics": []string{},
+	}
+
+	if err != nil {
+		result["status"] = "error"
+		result["stderr"] = err.Error()
+		result["diagnostics"] = []string{fmt.Sprintf("Syft SBOM generation failed: %v", err)}
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return string(resultJSON)
+}
+
+// syftFormatFileNames name the SBOM file written for each format when
+// several formats are requested
+var syftFormatFileNames = map[string]string{
+	"cyclonedx-json": "sbom.cdx.json",
+	"cyclonedx-xml":  "sbom.cdx.xml",
+	"spdx-json":      "sbom.spdx.json",
+	"spdx-tag-value": "sbom.spdx",
+	"syft-json":      "sbom.syft.json",
+	"json":           "sbom.syft.json",
+}
+
+// splitSyftFormats splits a comma-separated format list, dropping blanks and
+// duplicates
+func splitSyftFormats(format string) []string {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(format, ",") {
+		if f = strings.TrimSpace(f); f != "" && !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+// syftSBOMOutputs assigns each format its SBOM file. outputDir, when set, is
+// the directory the files are written to; otherwise the current directory.
+func syftSBOMOutputs(formats []string, outputDir string) []modules.SyftOutput {
+	outputs := make([]modules.SyftOutput, len(formats))
+	for i, format := range formats {
+		name, ok := syftFormatFileNames[format]
+		if !ok {
+			name = "sbom." + format
+		}
+		path := "./" + name
+		if outputDir != "" {
+			path = filepath.Join(outputDir, name)
+		}
+		outputs[i] = modules.SyftOutput{Format: format, Path: path}
+	}
+	return outputs
+}
+
+// syftSBOMArtifacts keys each output path by format, matching the
+// sbom_cyclonedx and sbom_spdx keys of single-format results
+func syftSBOMArtifacts(outputs []modules.SyftOutput) map[string]string {
+	artifacts := make(map[string]string, len(outputs))
+	for _, output := range outputs {
+		key := "sbom_" + strings.ReplaceAll(output.Format, "-", "_")
+		switch output.Format {
+		case "cyclonedx-json":
+			key = "sbom_cyclonedx"
+		case "spdx-json":
+			key = "sbom_spdx"
+		}
+		artifacts[key] = output.Path
+	}
+	return artifacts
+}
+
 // addSyftToolsDirect adds Syft tools using direct Dagger module calls
 func addSyftToolsDirect(s *server.MCPServer) {
 	// Syft generate SBOM from directory tool
@@ -133,49 +196,41 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(generateSBOMDirectoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		directory := request.GetString("directory", "")
 		format := request.GetString("format", "json")
 
-		// Generate SBOM from directory
-		stdout, err := module.GenerateSBOMFromDirectory(ctx, directory, format)
+		return withDaggerModule(ctx, "Syft generate SBOM from directory failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			// Generate SBOM from directory
+			stdout, err := module.GenerateSBOMFromDirectory(ctx, directory, format)
 		
-		// Build result in the expected format
-		result := map[string]interface{}{
-			"status": "ok",
-			"stdout": stdout,
-			"stderr": "",
-			"artifacts": map[string]string{},
-			"summary": map[string]interface{}{},
-			"diagnostics": []string{},
-		}
+			// Build result in the expected format
+			result := map[string]interface{}{
+				"status": "ok",
+				"stdout": stdout,
+				"stderr": "",
+				"artifacts": map[string]string{},
+				"summary": map[string]interface{}{},
+				"diagnostics": []string{},
+			}
 		
-		// Add artifact path based on format
-		if format == "spdx-json" {
-			result["artifacts"].(map[string]string)["sbom_spdx"] = "./sbom.spdx.json"
-		} else {
-			result["artifacts"].(map[string]string)["sbom_cyclonedx"] = "./sbom.cdx.json"
-		}
+			// Add artifact path based on format
+			if format == "spdx-json" {
+				result["artifacts"].(map[string]string)["sbom_spdx"] = "./sbom.spdx.json"
+			} else {
+				result["artifacts"].(map[string]string)["sbom_cyclonedx"] = "./sbom.cdx.json"
+			}
 		
-		if err != nil {
-			result["status"] = "error"
-			result["stderr"] = err.Error()
-			result["diagnostics"] = []string{fmt.Sprintf("Syft generate SBOM from directory failed: %v", err)}
-		}
+			if err != nil {
+				result["status"] = "error"
+				result["stderr"] = err.Error()
+				result["diagnostics"] = []string{fmt.Sprintf("Syft generate SBOM from directory failed: %v", err)}
+			}
 
-		// Return as JSON
-		resultJSON, _ := json.Marshal(result)
-		return mcp.NewToolResultText(string(resultJSON)), nil
+			// Return as JSON
+			resultJSON, _ := json.Marshal(result)
+			return string(resultJSON), nil
+		})
 	})
 
 	// Syft generate SBOM from image tool
@@ -190,27 +245,14 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(generateSBOMImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		image := request.GetString("image", "")
 		format := request.GetString("format", "json")
 
 		// Generate SBOM from image
-		output, err := module.GenerateSBOMFromImage(ctx, image, format)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft generate SBOM from image failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft generate SBOM from image failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.GenerateSBOMFromImage(ctx, image, format)
+		})
 	})
 
 	// Syft generate SBOM from package tool
@@ -229,28 +271,15 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(generateSBOMPackageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		directory := request.GetString("directory", "")
 		packageType := request.GetString("package_type", "")
 		format := request.GetString("format", "json")
 
 		// Generate SBOM from package
-		output, err := module.GenerateSBOMFromPackage(ctx, directory, packageType, format)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft generate SBOM from package failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft generate SBOM from package failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.GenerateSBOMFromPackage(ctx, directory, packageType, format)
+		})
 	})
 
 	// Syft generate attestations tool
@@ -265,27 +294,14 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(generateAttestationsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		format := request.GetString("format", "json")
 
 		// Generate attestations
-		output, err := module.GenerateAttestations(ctx, target, format)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft generate attestations failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft generate attestations failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.GenerateAttestations(ctx, target, format)
+		})
 	})
 
 	// Syft language specific cataloging tool
@@ -315,16 +331,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(languageSpecificCatalogingTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		languagesStr := request.GetString("languages", "")
@@ -339,12 +345,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		depthLimit := request.GetString("depth_limit", "")
 
 		// Language specific cataloging
-		output, err := module.LanguageSpecificCataloging(ctx, target, languages, outputFormat, packageManagers, includeDevDeps, includeTestDeps, depthLimit)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft language specific cataloging failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft language specific cataloging failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.LanguageSpecificCataloging(ctx, target, languages, outputFormat, packageManagers, includeDevDeps, includeTestDeps, depthLimit)
+		})
 	})
 
 	// Syft supply chain analysis tool
@@ -377,16 +380,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(supplyChainAnalysisTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		analysisDepth := request.GetString("analysis_depth", "")
@@ -402,12 +395,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		riskAssessment := request.GetString("risk_assessment", "")
 
 		// Supply chain analysis
-		output, err := module.SupplyChainAnalysis(ctx, target, analysisDepth, outputFormats, outputDirectory, includeTransitiveDeps, includeLicenseAnalysis, includeProvenance, riskAssessment)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft supply chain analysis failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft supply chain analysis failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.SupplyChainAnalysis(ctx, target, analysisDepth, outputFormats, outputDirectory, includeTransitiveDeps, includeLicenseAnalysis, includeProvenance, riskAssessment)
+		})
 	})
 
 	// Syft SBOM comparison tool
@@ -441,16 +431,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(sbomComparisonTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		baselineTarget := request.GetString("baseline_target", "")
 		comparisonTarget := request.GetString("comparison_target", "")
@@ -462,12 +442,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		includeVersionChanges := request.GetBool("include_version_changes", false)
 
 		// SBOM comparison
-		output, err := module.SBOMComparison(ctx, baselineTarget, comparisonTarget, comparisonType, outputFormat, diffOutputFile, showAddedOnly, showRemovedOnly, includeVersionChanges)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft SBOM comparison failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft SBOM comparison failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.SBOMComparison(ctx, baselineTarget, comparisonTarget, comparisonType, outputFormat, diffOutputFile, showAddedOnly, showRemovedOnly, includeVersionChanges)
+		})
 	})
 
 	// Syft compliance attestation tool
@@ -500,16 +477,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(complianceAttestationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		complianceFramework := request.GetString("compliance_framework", "")
@@ -521,12 +488,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		validateCompleteness := request.GetBool("validate_completeness", false)
 
 		// Compliance attestation
-		output, err := module.ComplianceAttestation(ctx, target, complianceFramework, outputFormat, attestationFormat, outputFile, includeSupplierInfo, includeHashes, validateCompleteness)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft compliance attestation failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft compliance attestation failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.ComplianceAttestation(ctx, target, complianceFramework, outputFormat, attestationFormat, outputFile, includeSupplierInfo, includeHashes, validateCompleteness)
+		})
 	})
 
 	// Syft archive analysis tool
@@ -553,16 +517,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(archiveAnalysisTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		archivePath := request.GetString("archive_path", "")
 		archiveType := request.GetString("archive_type", "")
@@ -572,12 +526,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		extractionDepth := request.GetString("extraction_depth", "")
 
 		// Archive analysis
-		output, err := module.ArchiveAnalysis(ctx, archivePath, archiveType, outputFormat, extractNested, includeMetadata, extractionDepth)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft archive analysis failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft archive analysis failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.ArchiveAnalysis(ctx, archivePath, archiveType, outputFormat, extractNested, includeMetadata, extractionDepth)
+		})
 	})
 
 	// Syft CI/CD pipeline integration tool
@@ -610,16 +561,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(cicdPipelineIntegrationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		pipelineStage := request.GetString("pipeline_stage", "")
@@ -635,12 +576,9 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		timeout := request.GetString("timeout", "")
 
 		// CI/CD pipeline integration
-		output, err := module.CICDPipelineIntegration(ctx, target, pipelineStage, artifactName, outputFormats, outputDirectory, failOnError, quietMode, timeout)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft CI/CD pipeline integration failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft CI/CD pipeline integration failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.CICDPipelineIntegration(ctx, target, pipelineStage, artifactName, outputFormats, outputDirectory, failOnError, quietMode, timeout)
+		})
 	})
 
 	// Syft metadata extraction tool
@@ -673,16 +611,6 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(metadataExtractionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewSyftModule(client)
-
 		// Get parameters
 		target := request.GetString("target", "")
 		metadataTypesStr := request.GetString("metadata_types", "")
@@ -698,11 +626,75 @@ func addSyftToolsDirect(s *server.MCPServer) {
 		customAnnotations := request.GetString("custom_annotations", "")
 
 		// Metadata extraction
-		output, err := module.MetadataExtraction(ctx, target, metadataTypes, outputFormat, includeFileMetadata, includeChecksums, includeCertificates, includeSignatures, customAnnotations)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Syft metadata extraction failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Syft metadata extraction failed", modules.NewSyftModule, func(module *modules.SyftModule) (string, error) {
+			return module.MetadataExtraction(ctx, target, metadataTypes, outputFormat, includeFileMetadata, includeChecksums, includeCertificates, includeSignatures, customAnnotations)
+		})
 	})
-}
\ No newline at end of file
+}
+
+// syftSource is a syft_sbom target resolved to what Syft should scan
+type syftSource struct {
+	// kind is one of the modules.SyftSource kinds
+	kind string
+	// ref is the directory or archive path, or the image reference
+	ref string
+	// archiveType is passed to Syft's --from for archives; empty auto-detects
+	archiveType string
+}
+
+// syftArchiveExtensions mark local files that are scanned as image archives
+var syftArchiveExtensions = []string{".tar", ".tar.gz", ".tgz"}
+
+// syftImageRefPattern matches image references: an optional registry host,
+// one or more lowercase path components, and an optional tag and digest
+var syftImageRefPattern = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9.-]*[a-zA-Z0-9])?(?::[0-9]+)?/)?[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*(?::[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(?:@sha256:[a-f0-9]{64})?$`)
+
+// detectSyftSource resolves a syft_sbom target. Explicit dir:, docker:,
+// registry: and oci-archive: prefixes win. Unprefixed targets are archives
+// when they have an archive extension, directories when they exist locally,
+// and images when they look like an image reference with a tag, digest or
+// registry host. Anything else is scanned as a directory.
+func detectSyftSource(target string) syftSource {
+	switch {
+	case strings.HasPrefix(target, "dir:"):
+		return syftSource{kind: modules.SyftSourceDirectory, ref: strings.TrimPrefix(target, "dir:")}
+	case strings.HasPrefix(target, "docker:"), strings.HasPrefix(target, "registry:"):
+		return syftSource{kind: modules.SyftSourceImage, ref: target}
+	case strings.HasPrefix(target, "oci-archive:"):
+		return syftSource{kind: modules.SyftSourceArchive, ref: strings.TrimPrefix(target, "oci-archive:"), archiveType: "oci"}
+	}
+
+	for _, ext := range syftArchiveExtensions {
+		if strings.HasSuffix(strings.ToLower(target), ext) {
+			return syftSource{kind: modules.SyftSourceArchive, ref: target}
+		}
+	}
+
+	if _, err := os.Stat(modules.HostPath(target)); err == nil {
+		return syftSource{kind: modules.SyftSourceDirectory, ref: target}
+	}
+
+	if isImageReference(target) {
+		// Pull from the registry; the Syft container has no Docker daemon
+		return syftSource{kind: modules.SyftSourceImage, ref: "registry:" + target}
+	}
+
+	return syftSource{kind: modules.SyftSourceDirectory, ref: target}
+}
+
+// isImageReference reports whether target is an image reference with a tag,
+// digest or registry host, e.g. alpine:3.19 or ghcr.io/org/app
+func isImageReference(target string) bool {
+	if !syftImageRefPattern.MatchString(target) {
+		return false
+	}
+
+	// A digest or a tag on the last component marks an image
+	if strings.Contains(target, "@") || strings.Contains(target[strings.LastIndex(target, "/")+1:], ":") {
+		return true
+	}
+
+	// Without either the first component must be a registry host
+	host, _, found := strings.Cut(target, "/")
+	return found && (strings.ContainsAny(host, ".:") || host == "localhost")
+}
@@ -0,0 +1,174 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSyftSource_BareImageReferences(t *testing.T) {
+	refs := []string{
+		"alpine:3.19",
+		"nginx:1.25-alpine",
+		"library/redis:7",
+		"ghcr.io/org/app",
+		"ghcr.io/org/app:v1.2.3",
+		"localhost:5000/app:dev",
+		"localhost/app",
+		"alpine@sha256:" + "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90",
+	}
+	for _, ref := range refs {
+		t.Run(ref, func(t *testing.T) {
+			assert.Equal(t, syftSource{kind: modules.SyftSourceImage, ref: "registry:" + ref}, detectSyftSource(ref))
+		})
+	}
+}
+
+func TestDetectSyftSource_Tarballs(t *testing.T) {
+	for _, path := range []string{"image.tar", "/tmp/build/image.tar.gz", "./out/app.TGZ"} {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, syftSource{kind: modules.SyftSourceArchive, ref: path}, detectSyftSource(path))
+		})
+	}
+}
+
+func TestDetectSyftSource_PlainDirectories(t *testing.T) {
+	// A local directory wins over an image-like name
+	dir := filepath.Join(t.TempDir(), "app:v1")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	assert.Equal(t, syftSource{kind: modules.SyftSourceDirectory, ref: dir}, detectSyftSource(dir))
+
+	for _, path := range []string{".", "./src", "/does/not/exist", "alpine", "my-project"} {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, syftSource{kind: modules.SyftSourceDirectory, ref: path}, detectSyftSource(path))
+		})
+	}
+}
+
+func TestDetectSyftSource_ResolvesAgainstWorkingDir(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(workingDir, "app:v1"), 0755))
+	t.Setenv(modules.WorkingDirEnv, workingDir)
+
+	// The module resolves the relative directory against --working-dir too
+	assert.Equal(t, syftSource{kind: modules.SyftSourceDirectory, ref: "app:v1"}, detectSyftSource("app:v1"))
+}
+
+func TestDetectSyftSource_Prefixes(t *testing.T) {
+	assert.Equal(t, syftSource{kind: modules.SyftSourceDirectory, ref: "./src"}, detectSyftSource("dir:./src"))
+	assert.Equal(t, syftSource{kind: modules.SyftSourceImage, ref: "docker:alpine:3.19"}, detectSyftSource("docker:alpine:3.19"))
+	assert.Equal(t, syftSource{kind: modules.SyftSourceImage, ref: "registry:alpine:3.19"}, detectSyftSource("registry:alpine:3.19"))
+	assert.Equal(t, syftSource{kind: modules.SyftSourceArchive, ref: "/path/image.tar", archiveType: "oci"}, detectSyftSource("oci-archive:/path/image.tar"))
+}
+
+func TestSyftSBOMOutputs_TwoFormatsProduceTwoArtifacts(t *testing.T) {
+	formats := splitSyftFormats("cyclonedx-json, spdx-json,cyclonedx-json,")
+	assert.Equal(t, []string{"cyclonedx-json", "spdx-json"}, formats)
+
+	outputs := syftSBOMOutputs(formats, "")
+	assert.Equal(t, []modules.SyftOutput{
+		{Format: "cyclonedx-json", Path: "./sbom.cdx.json"},
+		{Format: "spdx-json", Path: "./sbom.spdx.json"},
+	}, outputs)
+
+	outputs = syftSBOMOutputs(formats, "/tmp/sboms")
+	assert.Equal(t, "/tmp/sboms/sbom.cdx.json", outputs[0].Path)
+	assert.Equal(t, "/tmp/sboms/sbom.spdx.json", outputs[1].Path)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(syftSBOMResult("", syftSBOMArtifacts(outputs), nil)), &result))
+	assert.Equal(t, "ok", result["status"])
+	assert.Equal(t, map[string]any{
+		"sbom_cyclonedx": "/tmp/sboms/sbom.cdx.json",
+		"sbom_spdx":      "/tmp/sboms/sbom.spdx.json",
+	}, result["artifacts"])
+}
+
+func TestSyftSBOMOutputs_OtherFormats(t *testing.T) {
+	outputs := syftSBOMOutputs([]string{"syft-json", "github-json"}, "out")
+	assert.Equal(t, []modules.SyftOutput{
+		{Format: "syft-json", Path: "out/sbom.syft.json"},
+		{Format: "github-json", Path: "out/sbom.github-json"},
+	}, outputs)
+	assert.Equal(t, map[string]string{
+		"sbom_syft_json":   "out/sbom.syft.json",
+		"sbom_github_json": "out/sbom.github-json",
+	}, syftSBOMArtifacts(outputs))
+}
+
+func TestSyftSBOMResult_SingleFormatUnchanged(t *testing.T) {
+	assert.Equal(t, []string{"spdx-json"}, splitSyftFormats("spdx-json"), "one format keeps the single-output path")
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(syftSBOMResult(`{"spdxVersion":"SPDX-2.3"}`, map[string]string{"sbom_spdx": "./sbom.spdx.json"}, nil)), &result))
+	assert.Equal(t, map[string]any{
+		"status":      "ok",
+		"stdout":      `{"spdxVersion":"SPDX-2.3"}`,
+		"stderr":      "",
+		"artifacts":   map[string]any{"sbom_spdx": "./sbom.spdx.json"},
+		"summary":     map[string]any{},
+		"diagnostics": []any{},
+	}, result)
+
+	require.NoError(t, json.Unmarshal([]byte(syftSBOMResult("", map[string]string{}, errors.New("export failed"))), &result))
+	assert.Equal(t, "error", result["status"])
+	assert.Equal(t, []any{"Syft SBOM generation failed: export failed"}, result["diagnostics"])
+}
+
+// fakeSyftSBOMFileGenerator writes each requested output and records the
+// source kinds it was asked to scan
+type fakeSyftSBOMFileGenerator struct {
+	kinds   []string
+	failFor string
+}
+
+func (f *fakeSyftSBOMFileGenerator) GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []modules.SyftOutput) error {
+	f.kinds = append(f.kinds, kind)
+	if kind == f.failFor {
+		return errors.New("unsupported archive")
+	}
+	for _, output := range outputs {
+		if err := os.WriteFile(output.Path, []byte(`{"format":"`+output.Format+`"}`), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGenerateSyftSBOMFiles_SingleFormatWritesArtifact(t *testing.T) {
+	t.Setenv(ArtifactDirEnv, t.TempDir())
+	path, err := ArtifactPath(syftSBOMOutputs([]string{"spdx-json"}, "")[0].Path)
+	require.NoError(t, err)
+	outputs := []modules.SyftOutput{{Format: "spdx-json", Path: path}}
+
+	generator := &fakeSyftSBOMFileGenerator{}
+	stdout, err := generateSyftSBOMFiles(context.Background(), generator, detectSyftSource("."), outputs)
+	require.NoError(t, err)
+
+	assert.FileExists(t, path)
+	assert.Equal(t, `{"format":"spdx-json"}`, stdout)
+	assert.Equal(t, map[string]string{"sbom_spdx": path}, syftSBOMArtifacts(outputs))
+	assert.Equal(t, []string{modules.SyftSourceDirectory}, generator.kinds)
+}
+
+func TestGenerateSyftSBOMFiles_OCIArchiveFallsBackToDirectory(t *testing.T) {
+	dir := t.TempDir()
+	outputs := syftSBOMOutputs([]string{"cyclonedx-json", "spdx-json"}, dir)
+
+	generator := &fakeSyftSBOMFileGenerator{failFor: modules.SyftSourceArchive}
+	stdout, err := generateSyftSBOMFiles(context.Background(), generator, detectSyftSource("oci-archive:image.tar"), outputs)
+	require.NoError(t, err)
+
+	assert.Empty(t, stdout, "several formats are only reported as artifacts")
+	assert.Equal(t, []string{modules.SyftSourceArchive, modules.SyftSourceDirectory}, generator.kinds)
+	for _, output := range outputs {
+		assert.FileExists(t, output.Path)
+	}
+}
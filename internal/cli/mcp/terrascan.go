@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddTerrascanTools adds Terrascan (IaC security scanner) MCP tool implementations using direct Dagger calls
@@ -32,11 +31,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanDirectoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -67,11 +66,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanTerraformTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -102,11 +101,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanKubernetesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -142,11 +141,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithSeverityTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -182,11 +181,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanRemoteTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -223,11 +222,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithPolicyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -263,7 +262,7 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 			mcp.Enum("json", "yaml", "xml", "junit-xml", "sarif", "csv", "html"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file path for results"),
+			mcp.Description("Output file path for results, saved on the host relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithString("severity_threshold",
 			mcp.Description("Minimum severity threshold"),
@@ -284,11 +283,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(comprehensiveIaCScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -305,12 +304,12 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 		showPassed := request.GetBool("show_passed", false)
 
 		// Comprehensive IaC scan
-		output, err := module.ComprehensiveIaCScan(ctx, target, iacType, outputFormat, outputFile, severityThreshold, policyTypes, excludeRules, verbose, showPassed)
+		output, err := module.ComprehensiveIaCScan(ctx, target, iacType, outputFormat, "", severityThreshold, policyTypes, excludeRules, verbose, showPassed)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Terrascan comprehensive scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return withReportArtifact(mcp.NewToolResultText(output), outputFile, output), nil
 	})
 
 	// Terrascan compliance framework scanning tool
@@ -334,7 +333,7 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 			mcp.Enum("json", "yaml", "sarif", "junit-xml", "html"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file for compliance report"),
+			mcp.Description("Output file for compliance report, saved on the host relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithBoolean("include_severity_details",
 			mcp.Description("Include detailed severity analysis"),
@@ -342,11 +341,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(complianceFrameworkScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -360,12 +359,12 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 		includeSeverityDetails := request.GetBool("include_severity_details", false)
 
 		// Compliance framework scan
-		output, err := module.ComplianceFrameworkScan(ctx, target, complianceFramework, iacType, outputFormat, outputFile, includeSeverityDetails)
+		output, err := module.ComplianceFrameworkScan(ctx, target, complianceFramework, iacType, outputFormat, "", includeSeverityDetails)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Terrascan compliance scan failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return withReportArtifact(mcp.NewToolResultText(output), outputFile, output), nil
 	})
 
 	// Terrascan remote repository scanning tool
@@ -402,11 +401,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(remoteRepositoryScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -454,11 +453,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(customPolicyManagementTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -504,7 +503,7 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 			mcp.Enum("json", "sarif", "junit-xml", "csv"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file for CI artifacts"),
+			mcp.Description("Output file for CI artifacts, saved on the host relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithBoolean("fail_on_violations",
 			mcp.Description("Fail CI pipeline on policy violations"),
@@ -518,11 +517,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(cicdPipelineIntegrationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -539,12 +538,12 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 		quietMode := request.GetBool("quiet_mode", false)
 
 		// CI/CD pipeline integration
-		output, err := module.CICDPipelineIntegration(ctx, target, iacType, pipelineStage, gatePolicy, outputFormat, outputFile, failOnViolations, baselineFile, quietMode)
+		output, err := module.CICDPipelineIntegration(ctx, target, iacType, pipelineStage, gatePolicy, outputFormat, "", failOnViolations, baselineFile, quietMode)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Terrascan CI/CD integration failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return withReportArtifact(mcp.NewToolResultText(output), outputFile, output), nil
 	})
 
 	// Terrascan cloud provider specific scanning tool
@@ -579,11 +578,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(cloudProviderScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -643,11 +642,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(performanceOptimizationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -710,11 +709,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(comprehensiveReportingTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
@@ -748,11 +747,11 @@ func addTerrascanToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTerrascanModule(client)
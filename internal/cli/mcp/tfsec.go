@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"dagger.io/dagger"
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -33,11 +32,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		dir := request.GetString("directory", ".")
 		format := request.GetString("format", "json")
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.ScanDirectory(ctx, dir, format)
@@ -63,11 +62,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		dir := request.GetString("directory", ".")
 		severity := request.GetString("severity", "")
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.ScanWithSeverity(ctx, dir, severity)
@@ -97,11 +96,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 			excludes = strings.Split(excludesStr, ",")
 		}
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.ScanWithExcludes(ctx, dir, excludes)
@@ -126,11 +125,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		dir := request.GetString("directory", ".")
 		configPath := request.GetString("config_path", "")
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.ScanWithConfig(ctx, dir, configPath)
@@ -155,11 +154,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		dir := request.GetString("directory", ".")
 		tfvarsFile := request.GetString("tfvars_file", "")
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.ValidateTfvars(ctx, dir, tfvarsFile)
@@ -185,11 +184,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		dir := request.GetString("directory", ".")
 		reportType := request.GetString("report_type", "json")
 
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.GenerateReport(ctx, dir, reportType)
@@ -205,11 +204,11 @@ func addTfsecToolsDirect(s *server.MCPServer) {
 		mcp.WithDescription("Get tfsec version"),
 	)
 	s.AddTool(versionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to connect to dagger: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		module := modules.NewTfsecModule(client)
 		result, err := module.GetVersion(ctx)
@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CallTool invokes a tool registered on s without an MCP client. params are
+// raw key=value strings, converted to the types the tool declares for them.
+func CallTool(ctx context.Context, s *server.MCPServer, name string, params map[string]string) (*mcp.CallToolResult, error) {
+	var tool *mcp.Tool
+	for _, registered := range registeredTools(s) {
+		if registered.Name == name {
+			tool = &registered
+			break
+		}
+	}
+	if tool == nil {
+		return nil, fmt.Errorf("unknown MCP tool %q", name)
+	}
+
+	arguments, err := toolArguments(*tool, params)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      1,
+		"method":  mcp.MethodToolsCall,
+		"params": map[string]any{
+			"name":      name,
+			"arguments": arguments,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch response := s.HandleMessage(ctx, request).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.CallToolResult)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result from %s: %T", name, response.Result)
+		}
+		return &result, nil
+	case mcp.JSONRPCError:
+		return nil, fmt.Errorf("%s failed: %s", name, response.Error.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response from %s: %T", name, response)
+	}
+}
+
+// toolArguments converts raw parameter values to the types declared in the
+// tool's input schema and checks required parameters are present
+func toolArguments(tool mcp.Tool, params map[string]string) (map[string]any, error) {
+	arguments := make(map[string]any, len(params))
+	for key, value := range params {
+		property, ok := tool.InputSchema.Properties[key].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q for %s (available: %s)", key, tool.Name, strings.Join(toolParamNames(tool), ", "))
+		}
+
+		paramType, _ := property["type"].(string)
+		argument, err := convertToolParam(paramType, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %q: %w", key, err)
+		}
+		arguments[key] = argument
+	}
+
+	for _, required := range tool.InputSchema.Required {
+		if _, ok := arguments[required]; !ok {
+			return nil, fmt.Errorf("missing required parameter %q for %s", required, tool.Name)
+		}
+	}
+	return arguments, nil
+}
+
+// checkToolArguments rejects arguments the tool's input schema does not
+// declare, for tools where ignoring one would change what is scanned
+func checkToolArguments(tool mcp.Tool, request mcp.CallToolRequest) error {
+	for key := range request.GetArguments() {
+		if _, ok := tool.InputSchema.Properties[key]; !ok {
+			return fmt.Errorf("unknown parameter %q for %s (available: %s)", key, tool.Name, strings.Join(toolParamNames(tool), ", "))
+		}
+	}
+	return nil
+}
+
+// convertToolParam parses value as a JSON schema type. Arrays accept a JSON
+// array or a comma-separated list.
+func convertToolParam(paramType string, value string) (any, error) {
+	switch paramType {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "array":
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			var items []any
+			if err := json.Unmarshal([]byte(value), &items); err != nil {
+				return nil, fmt.Errorf("expected a JSON array: %w", err)
+			}
+			return items, nil
+		}
+		items := []any{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case "object":
+		var object map[string]any
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return nil, fmt.Errorf("expected a JSON object: %w", err)
+		}
+		return object, nil
+	default:
+		return value, nil
+	}
+}
+
+// toolParamNames lists the parameters a tool declares, sorted
+func toolParamNames(tool mcp.Tool) []string {
+	names := make([]string, 0, len(tool.InputSchema.Properties))
+	for name := range tool.InputSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEchoServer registers a tool with typed params that returns its arguments as JSON
+func newEchoServer(t *testing.T) *server.MCPServer {
+	t.Helper()
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	tool := mcp.NewTool("echo_scan",
+		mcp.WithString("target", mcp.Required()),
+		mcp.WithNumber("threshold"),
+		mcp.WithBoolean("verbose"),
+		mcp.WithArray("skip_dirs"),
+		mcp.WithObject("labels"),
+	)
+	tool.InputSchema.Properties["retries"] = map[string]any{"type": "integer"}
+
+	s.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := json.Marshal(request.GetArguments())
+		require.NoError(t, err)
+		return mcp.NewToolResultText(string(data)), nil
+	})
+	return s
+}
+
+func TestCallTool_ConvertsDeclaredTypes(t *testing.T) {
+	result, err := CallTool(context.Background(), newEchoServer(t), "echo_scan", map[string]string{
+		"target":    "./infra",
+		"threshold": "7.5",
+		"verbose":   "true",
+		"retries":   "3",
+		"skip_dirs": "vendor, node_modules",
+		"labels":    `{"team":"platform"}`,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.JSONEq(t, `{
+		"target": "./infra",
+		"threshold": 7.5,
+		"verbose": true,
+		"retries": 3,
+		"skip_dirs": ["vendor", "node_modules"],
+		"labels": {"team": "platform"}
+	}`, resultText(t, result))
+}
+
+func TestCallTool_JSONArrayParam(t *testing.T) {
+	result, err := CallTool(context.Background(), newEchoServer(t), "echo_scan", map[string]string{
+		"target":    ".",
+		"skip_dirs": `["a,b", "c"]`,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"target": ".", "skip_dirs": ["a,b", "c"]}`, resultText(t, result))
+}
+
+func TestCallTool_Errors(t *testing.T) {
+	s := newEchoServer(t)
+
+	tests := []struct {
+		name   string
+		tool   string
+		params map[string]string
+		want   string
+	}{
+		{name: "unknown tool", tool: "missing_tool", want: `unknown MCP tool "missing_tool"`},
+		{name: "unknown parameter", tool: "echo_scan", params: map[string]string{"target": ".", "depth": "2"}, want: `unknown parameter "depth" for echo_scan`},
+		{name: "invalid boolean", tool: "echo_scan", params: map[string]string{"target": ".", "verbose": "maybe"}, want: `invalid value for parameter "verbose"`},
+		{name: "invalid integer", tool: "echo_scan", params: map[string]string{"target": ".", "retries": "1.5"}, want: `invalid value for parameter "retries"`},
+		{name: "missing required", tool: "echo_scan", params: map[string]string{"verbose": "true"}, want: `missing required parameter "target"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CallTool(context.Background(), s, tt.tool, tt.params)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	toolCategoriesOnce sync.Once
+	toolCategories     map[string]string
+)
+
+// ToolCategory returns the registry category, e.g. "security", of the MCP
+// tool with the given name
+func ToolCategory(toolName string) (string, bool) {
+	toolCategoriesOnce.Do(func() {
+		toolCategories = buildToolCategories()
+	})
+	category, ok := toolCategories[toolName]
+	return category, ok
+}
+
+// buildToolCategories maps every MCP tool name to the category of the
+// registry tool that adds it. Categories are visited in sorted order so a
+// tool listed under several categories always gets the same one.
+func buildToolCategories() map[string]string {
+	categories := make([]string, 0, len(ToolRegistry))
+	for category := range ToolRegistry {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	toolCategories := make(map[string]string)
+	for _, category := range categories {
+		for _, tool := range ToolRegistry[category] {
+			if tool.AddFunc == nil {
+				continue
+			}
+			// Register on a scratch server to learn the MCP tool names
+			scratch := server.NewMCPServer("ship-categories", "1.0.0")
+			tool.AddFunc(scratch, nil)
+			for _, name := range registeredToolNames(scratch) {
+				if _, ok := toolCategories[name]; !ok {
+					toolCategories[name] = category
+				}
+			}
+		}
+	}
+	return toolCategories
+}
+
+// WithToolCategories tags the description of every listed tool with its
+// category, e.g. "[security] Scan container images ...", so clients can group
+// tools
+func WithToolCategories() server.ServerOption {
+	return server.WithToolFilter(func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		categorized := make([]mcp.Tool, len(tools))
+		for i, tool := range tools {
+			if category, ok := ToolCategory(tool.Name); ok {
+				tool.Description = categorizedDescription(category, tool.Description)
+			}
+			categorized[i] = tool
+		}
+		return categorized
+	})
+}
+
+// categorizedDescription prefixes description with its category tag
+func categorizedDescription(category, description string) string {
+	return "[" + category + "] " + description
+}
@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCategory(t *testing.T) {
+	category, ok := ToolCategory("trivy_scan_image")
+	require.True(t, ok)
+	assert.Equal(t, "security", category)
+
+	category, ok = ToolCategory("tflint_check")
+	require.True(t, ok)
+	assert.Equal(t, "terraform", category)
+
+	_, ok = ToolCategory("not_a_tool")
+	assert.False(t, ok)
+}
+
+func TestWithToolCategoriesTagsListedTools(t *testing.T) {
+	s := server.NewMCPServer("ship-test", "1.0.0", WithToolCategories())
+	RegisterAllTools(s, noopExecute, ToolFilter{})
+
+	descriptions := make(map[string]string)
+	for _, tool := range registeredTools(s) {
+		descriptions[tool.Name] = tool.Description
+		_, ok := ToolCategory(tool.Name)
+		assert.True(t, ok, "%s has a category", tool.Name)
+	}
+
+	assert.Regexp(t, `^\[security\] `, descriptions["trivy_scan_image"])
+	assert.Regexp(t, `^\[terraform\] `, descriptions["tflint_check"])
+}
+
+func TestWithToolCategoriesDoesNotStackPrefixes(t *testing.T) {
+	s := server.NewMCPServer("ship-test", "1.0.0", WithToolCategories())
+	RegisterToolsByCategory("terraform", s, noopExecute, ToolFilter{})
+	listed := registeredTools(s)
+	require.NotEmpty(t, listed)
+
+	// Listing twice must not stack category prefixes
+	for i, tool := range registeredTools(s) {
+		assert.Equal(t, listed[i].Description, tool.Description)
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolFilter restricts which tools are registered with the MCP server.
+// Patterns are globs (see path.Match) matched against both the registry tool
+// name (e.g. "trivy") and the individual MCP tool names it registers
+// (e.g. "trivy_scan_directory"). Deny takes precedence over allow, and an
+// empty allow list allows everything. The zero value filters nothing.
+type ToolFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// NewToolFilter builds a ToolFilter from comma-separated allow and deny lists
+// and validates every pattern
+func NewToolFilter(allow, deny string) (ToolFilter, error) {
+	filter := ToolFilter{
+		Allow: splitToolPatterns(allow),
+		Deny:  splitToolPatterns(deny),
+	}
+
+	for _, pattern := range append(append([]string{}, filter.Allow...), filter.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return ToolFilter{}, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// IsEmpty reports whether the filter lets every tool through
+func (f ToolFilter) IsEmpty() bool {
+	return len(f.Allow) == 0 && len(f.Deny) == 0
+}
+
+// Allows reports whether an MCP tool registered by the given registry tool
+// passes the filter
+func (f ToolFilter) Allows(registryName, toolName string) bool {
+	if matchesAnyToolPattern(f.Deny, registryName, toolName) {
+		return false
+	}
+	if len(f.Allow) == 0 {
+		return true
+	}
+	return matchesAnyToolPattern(f.Allow, registryName, toolName)
+}
+
+// register adds a registry tool to s, dropping any MCP tools the filter rejects
+func (f ToolFilter) register(tool ToolInfo, s *server.MCPServer, executeShipCommand ExecuteShipCommandFunc) {
+	if tool.AddFunc == nil {
+		return
+	}
+	if f.IsEmpty() {
+		tool.AddFunc(s, executeShipCommand)
+		return
+	}
+	// Deny on the registry name skips the tool without registering anything
+	if matchesAnyToolPattern(f.Deny, tool.Name) {
+		return
+	}
+
+	before := make(map[string]bool)
+	for _, name := range registeredToolNames(s) {
+		before[name] = true
+	}
+
+	tool.AddFunc(s, executeShipCommand)
+
+	var rejected []string
+	for _, name := range registeredToolNames(s) {
+		if !before[name] && !f.Allows(tool.Name, name) {
+			rejected = append(rejected, name)
+		}
+	}
+	if len(rejected) > 0 {
+		s.DeleteTools(rejected...)
+	}
+}
+
+// registeredToolNames lists the names of the tools currently registered on s
+func registeredToolNames(s *server.MCPServer) []string {
+	tools := registeredTools(s)
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// RegisteredToolCount returns how many tools are registered on s
+func RegisteredToolCount(s *server.MCPServer) int {
+	return len(registeredTools(s))
+}
+
+// registeredTools lists the tools currently registered on s
+func registeredTools(s *server.MCPServer) []mcp.Tool {
+	request := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	response, ok := s.HandleMessage(context.Background(), json.RawMessage(request)).(mcp.JSONRPCResponse)
+	if !ok {
+		// The server reports tools/list as unsupported until a tool is added
+		return nil
+	}
+
+	result, ok := response.Result.(mcp.ListToolsResult)
+	if !ok {
+		return nil
+	}
+	return result.Tools
+}
+
+func matchesAnyToolPattern(patterns []string, names ...string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitToolPatterns(list string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noopExecute(args []string) (*mcp.CallToolResult, error) {
+	return mcp.NewToolResultText(""), nil
+}
+
+func registeredAWSTools(t *testing.T, allow, deny string) []string {
+	t.Helper()
+
+	filter, err := NewToolFilter(allow, deny)
+	require.NoError(t, err)
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	RegisterToolsByCategory("aws", s, noopExecute, filter)
+	return registeredToolNames(s)
+}
+
+func TestToolFilterAllows(t *testing.T) {
+	filter, err := NewToolFilter("trivy, *_scan_*", "trivy_scan_image")
+	require.NoError(t, err)
+
+	assert.True(t, filter.Allows("trivy", "trivy_scan_directory"))
+	assert.True(t, filter.Allows("checkov", "checkov_scan_directory"))
+	assert.False(t, filter.Allows("checkov", "checkov_get_version"))
+	assert.False(t, filter.Allows("trivy", "trivy_scan_image"), "deny takes precedence over allow")
+}
+
+func TestToolFilterZeroValueAllowsEverything(t *testing.T) {
+	var filter ToolFilter
+
+	assert.True(t, filter.IsEmpty())
+	assert.True(t, filter.Allows("nmap", "nmap_scan"))
+}
+
+func TestNewToolFilterRejectsMalformedPattern(t *testing.T) {
+	_, err := NewToolFilter("trivy[", "")
+	assert.Error(t, err)
+}
+
+func TestRegisterToolsByCategoryFiltered(t *testing.T) {
+	all := registeredAWSTools(t, "", "")
+	require.NotEmpty(t, all)
+	assert.Contains(t, all, "parliament_lint_file")
+
+	t.Run("allow by registry name", func(t *testing.T) {
+		names := registeredAWSTools(t, "parliament", "")
+		require.NotEmpty(t, names)
+		for _, name := range names {
+			assert.True(t, strings.HasPrefix(name, "parliament_"), name)
+		}
+	})
+
+	t.Run("deny by registry name", func(t *testing.T) {
+		names := registeredAWSTools(t, "", "parliament")
+		assert.NotEmpty(t, names)
+		for _, name := range names {
+			assert.False(t, strings.HasPrefix(name, "parliament_"), name)
+		}
+	})
+
+	t.Run("allow by tool glob", func(t *testing.T) {
+		names := registeredAWSTools(t, "*_get_version", "")
+		require.NotEmpty(t, names)
+		for _, name := range names {
+			assert.True(t, strings.HasSuffix(name, "_get_version"), name)
+		}
+	})
+
+	t.Run("deny wins over allow", func(t *testing.T) {
+		names := registeredAWSTools(t, "parliament", "parliament_lint_file")
+		assert.NotEmpty(t, names)
+		assert.NotContains(t, names, "parliament_lint_file")
+	})
+}
+
+func TestRegisterToolByNameFiltered(t *testing.T) {
+	register := func(allow, deny string) []string {
+		filter, err := NewToolFilter(allow, deny)
+		require.NoError(t, err)
+
+		s := server.NewMCPServer("ship-test", "1.0.0")
+		RegisterToolByName("parliament", s, noopExecute, filter)
+		return registeredToolNames(s)
+	}
+
+	all := register("", "")
+	require.Contains(t, all, "parliament_lint_file")
+
+	names := register("*_get_version", "")
+	assert.NotContains(t, names, "parliament_lint_file")
+	for _, name := range names {
+		assert.True(t, strings.HasSuffix(name, "_get_version"), name)
+	}
+
+	assert.NotContains(t, register("", "parliament_lint_file"), "parliament_lint_file")
+	assert.Empty(t, register("", "parliament"))
+}
@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolWarningsMetaKey is the result metadata field that carries warnings
+const toolWarningsMetaKey = "warnings"
+
+// withToolWarnings attaches non-fatal warnings, such as ignored parameters, to
+// a successful result's metadata. Error results are returned unchanged.
+func withToolWarnings(result *mcp.CallToolResult, warnings []string) *mcp.CallToolResult {
+	if result == nil || result.IsError || len(warnings) == 0 {
+		return result
+	}
+
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = make(map[string]any)
+	}
+	result.Meta.AdditionalFields[toolWarningsMetaKey] = warnings
+	return result
+}
+
+// appendIgnoredParamWarnings adds a warning for each of params the request
+// sets to a non-empty string or true
+func appendIgnoredParamWarnings(warnings []string, request mcp.CallToolRequest, params ...string) []string {
+	args := request.GetArguments()
+	for _, param := range params {
+		switch value := args[param].(type) {
+		case nil:
+			continue
+		case string:
+			if value == "" {
+				continue
+			}
+		case bool:
+			if !value {
+				continue
+			}
+		}
+		warnings = append(warnings, fmt.Sprintf("%s parameter not supported with direct Dagger calls, ignoring it", param))
+	}
+	return warnings
+}
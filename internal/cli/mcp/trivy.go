@@ -3,13 +3,13 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/cloudshipai/ship/internal/telemetry"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddTrivyTools adds Trivy (universal vulnerability scanner) MCP tool implementations using direct Dagger calls
@@ -35,53 +35,32 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 			mcp.Enum("table", "json", "sarif", "template", "cyclonedx", "spdx", "spdx-json", "github", "cosign-vuln"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file path"),
+			mcp.Description("Path, relative to the artifact directory, to export the report to, e.g. a cosign-vuln predicate for cosign attest"),
 		),
 		mcp.WithString("scanners",
-			mcp.Description("Comma-separated scanners to use"),
+			mcp.Description("Comma-separated scanners: vuln,secret,misconfig,license (default: vuln)"),
 		),
 		mcp.WithBoolean("ignore_unfixed",
 			mcp.Description("Ignore unfixed vulnerabilities"),
 		),
 	)
 	s.AddTool(scanImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		imageName := request.GetString("image_name", "")
 		if imageName == "" {
 			return mcp.NewToolResultError("image_name is required"), nil
 		}
 
-		// Note: Dagger ScanImage function uses fixed parameters, some MCP parameters not directly supported
-		if outputFormat := request.GetString("output_format", ""); outputFormat != "" && outputFormat != "json" {
-			return mcp.NewToolResultError(fmt.Sprintf("Warning: output_format '%s' not supported, using json", outputFormat)), nil
-		}
-		if outputFile := request.GetString("output_file", ""); outputFile != "" {
-			return mcp.NewToolResultError("Warning: output_file parameter not supported with direct Dagger calls"), nil
-		}
-		if scanners := request.GetString("scanners", ""); scanners != "" {
-			return mcp.NewToolResultError("Warning: scanners parameter not supported with direct Dagger calls"), nil
-		}
-		if request.GetBool("ignore_unfixed", false) {
-			return mcp.NewToolResultError("Warning: ignore_unfixed parameter not supported with direct Dagger calls"), nil
-		}
-
-		// Scan image
-		output, err := module.ScanImage(ctx, imageName)
+		opts, err := trivyScanImageOptions(request)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy image scan failed: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		// Scan image
+		result, err := withDaggerModule(ctx, "Trivy image scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanImage(ctx, imageName, opts...)
+		})
+		return withToolWarnings(result, trivyScanImageWarnings(request)), err
 	})
 
 	// Trivy scan filesystem tool
@@ -98,7 +77,7 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 			mcp.Enum("table", "json", "sarif", "template", "cyclonedx", "spdx", "spdx-json"),
 		),
 		mcp.WithString("scanners",
-			mcp.Description("Comma-separated scanners: vuln,secret,misconfig,license"),
+			mcp.Description("Comma-separated scanners: vuln,secret,misconfig,license (default: vuln)"),
 		),
 		mcp.WithString("skip_dirs",
 			mcp.Description("Comma-separated directories to skip"),
@@ -108,51 +87,27 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(scanFilesystemTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		dir := request.GetString("directory", ".")
 
-		// Note: Dagger ScanFilesystem function uses fixed parameters, some MCP parameters not directly supported
-		if severity := request.GetString("severity", ""); severity != "" && severity != "HIGH,CRITICAL" {
-			// Severity parameter provided but will be ignored - Dagger module uses fixed HIGH,CRITICAL
-		}
-		if outputFormat := request.GetString("output_format", ""); outputFormat != "" && outputFormat != "json" {
-			return mcp.NewToolResultError(fmt.Sprintf("Warning: output_format '%s' not supported, using json", outputFormat)), nil
-		}
-		if scanners := request.GetString("scanners", ""); scanners != "" {
-			return mcp.NewToolResultError("Warning: scanners parameter not supported with direct Dagger calls"), nil
-		}
-		if skipDirs := request.GetString("skip_dirs", ""); skipDirs != "" {
-			return mcp.NewToolResultError("Warning: skip_dirs parameter not supported with direct Dagger calls"), nil
-		}
-		if request.GetBool("include_dev_deps", false) {
-			return mcp.NewToolResultError("Warning: include_dev_deps parameter not supported with direct Dagger calls"), nil
-		}
+		result, err := withDaggerModule(ctx, "Trivy filesystem scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			// Track tool execution
+			start := time.Now()
 
-		// Track tool execution
-		start := time.Now()
-		
-		// Scan filesystem
-		output, err := module.ScanFilesystem(ctx, dir)
-		
-		duration := time.Since(start)
-		
-		if err != nil {
-			telemetry.TrackToolExecution("trivy_filesystem", duration, false, "scan_failed")
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy filesystem scan failed: %v", err)), nil
-		}
-		
-		telemetry.TrackToolExecution("trivy_filesystem", duration, true, "")
-		return mcp.NewToolResultText(output), nil
+			// Scan filesystem
+			output, err := module.ScanFilesystem(ctx, dir, trivyScanOptions(request)...)
+
+			duration := time.Since(start)
+
+			if err != nil {
+				telemetry.TrackToolExecution("trivy_filesystem", duration, false, "scan_failed")
+				return "", err
+			}
+
+			telemetry.TrackToolExecution("trivy_filesystem", duration, true, "")
+			return output, nil
+		})
+		return withToolWarnings(result, trivyScanFilesystemWarnings(request)), err
 	})
 
 	// Trivy scan repository tool
@@ -176,50 +131,21 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 			mcp.Enum("table", "json", "sarif", "template", "cyclonedx", "spdx", "spdx-json"),
 		),
 		mcp.WithString("scanners",
-			mcp.Description("Comma-separated scanners: vuln,secret,misconfig,license"),
+			mcp.Description("Comma-separated scanners: vuln,secret,misconfig,license (default: vuln)"),
 		),
 	)
 	s.AddTool(scanRepositoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		repoURL := request.GetString("repo_url", "")
 		if repoURL == "" {
 			return mcp.NewToolResultError("repo_url is required"), nil
 		}
 
-		// Note: Dagger ScanRepository function uses fixed parameters, some MCP parameters not directly supported
-		if branch := request.GetString("branch", ""); branch != "" {
-			return mcp.NewToolResultError("Warning: branch parameter not supported with direct Dagger calls"), nil
-		}
-		if commit := request.GetString("commit", ""); commit != "" {
-			return mcp.NewToolResultError("Warning: commit parameter not supported with direct Dagger calls"), nil
-		}
-		if severity := request.GetString("severity", ""); severity != "" && severity != "HIGH,CRITICAL" {
-			// Severity parameter provided but will be ignored - Dagger module uses fixed HIGH,CRITICAL
-		}
-		if outputFormat := request.GetString("output_format", ""); outputFormat != "" && outputFormat != "json" {
-			return mcp.NewToolResultError(fmt.Sprintf("Warning: output_format '%s' not supported, using json", outputFormat)), nil
-		}
-		if scanners := request.GetString("scanners", ""); scanners != "" {
-			return mcp.NewToolResultError("Warning: scanners parameter not supported with direct Dagger calls"), nil
-		}
-
 		// Scan repository
-		output, err := module.ScanRepository(ctx, repoURL)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy repository scan failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		result, err := withDaggerModule(ctx, "Trivy repository scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanRepository(ctx, repoURL, trivyScanOptions(request)...)
+		})
+		return withToolWarnings(result, trivyScanRepositoryWarnings(request)), err
 	})
 
 	// Trivy scan config tool
@@ -243,40 +169,14 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(scanConfigTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		dir := request.GetString("directory", ".")
 
-		// Note: Dagger ScanConfig function uses fixed parameters, some MCP parameters not directly supported
-		if severity := request.GetString("severity", ""); severity != "" && severity != "HIGH,CRITICAL" {
-			// Severity parameter provided but will be ignored - Dagger module uses fixed HIGH,CRITICAL
-		}
-		if outputFormat := request.GetString("output_format", ""); outputFormat != "" && outputFormat != "json" {
-			return mcp.NewToolResultError(fmt.Sprintf("Warning: output_format '%s' not supported, using json", outputFormat)), nil
-		}
-		if policyBundle := request.GetString("policy_bundle", ""); policyBundle != "" {
-			return mcp.NewToolResultError("Warning: policy_bundle parameter not supported with direct Dagger calls"), nil
-		}
-		if configPolicy := request.GetString("config_policy", ""); configPolicy != "" {
-			return mcp.NewToolResultError("Warning: config_policy parameter not supported with direct Dagger calls"), nil
-		}
-
 		// Scan config
-		output, err := module.ScanConfig(ctx, dir)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy config scan failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		result, err := withDaggerModule(ctx, "Trivy config scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanConfig(ctx, dir)
+		})
+		return withToolWarnings(result, trivyScanConfigWarnings(request)), err
 	})
 
 	// Trivy scan SBOM tool
@@ -301,16 +201,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(scanSBOMTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		sbomPath := request.GetString("sbom_path", "")
 		if sbomPath == "" {
@@ -324,26 +214,26 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		ignoreUnfixed := request.GetBool("ignore_unfixed", false)
 
 		// Scan SBOM
-		output, err := module.ScanSBOM(ctx, sbomPath, severity, outputFormat, outputFile, ignoreUnfixed)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy SBOM scan failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy SBOM scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanSBOM(ctx, sbomPath, severity, outputFormat, outputFile, ignoreUnfixed)
+		})
 	})
 
 	// Trivy scan Kubernetes tool
 	scanKubernetesTool := mcp.NewTool("trivy_scan_kubernetes",
 		mcp.WithDescription("Scan Kubernetes cluster for vulnerabilities using Trivy"),
-		mcp.WithString("target",
-			mcp.Description("Kubernetes target to scan"),
-			mcp.Enum("cluster", "all", "workload", "node"),
+		mcp.WithString("kubeconfig",
+			mcp.Description("Path to kubeconfig file for the target cluster"),
+			mcp.Required(),
 		),
 		mcp.WithString("cluster_context",
-			mcp.Description("Kubernetes cluster context (default: current context)"),
+			mcp.Description("Kubeconfig context to scan (default: current context)"),
+		),
+		mcp.WithString("target",
+			mcp.Description("What to scan: cluster or all for everything, workload for the workload kinds, or a resource kind such as deployment (default: cluster). Cannot be combined with include_kinds"),
 		),
 		mcp.WithString("namespace",
-			mcp.Description("Kubernetes namespace to scan (default: all namespaces)"),
+			mcp.Description("Single namespace to scan. Cannot be combined with include_namespaces or exclude_namespaces"),
 		),
 		mcp.WithString("severity",
 			mcp.Description("Comma-separated severity levels to include (e.g. 'HIGH,CRITICAL')"),
@@ -358,34 +248,46 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		mcp.WithBoolean("include_images",
 			mcp.Description("Include container image scanning"),
 		),
+		mcp.WithString("include_namespaces",
+			mcp.Description("Comma-separated namespaces to scan (default: all namespaces)"),
+		),
+		mcp.WithString("exclude_namespaces",
+			mcp.Description("Comma-separated namespaces to skip (e.g. 'kube-system')"),
+		),
+		mcp.WithString("include_kinds",
+			mcp.Description("Comma-separated resource kinds to scan (e.g. 'deployment,pod')"),
+		),
 	)
 	s.AddTool(scanKubernetesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		// An ignored filter would silently widen the scan
+		if err := checkToolArguments(scanKubernetesTool, request); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		defer client.Close()
 
-		// Create module instance
-		module := modules.NewTrivyModule(client)
+		kubeconfig := request.GetString("kubeconfig", "")
+		if kubeconfig == "" {
+			return mcp.NewToolResultError("kubeconfig is required"), nil
+		}
 
 		// Get parameters
-		target := request.GetString("target", "cluster")
 		clusterContext := request.GetString("cluster_context", "")
-		namespace := request.GetString("namespace", "")
 		severity := request.GetString("severity", "")
 		outputFormat := request.GetString("output_format", "")
 		scanners := request.GetString("scanners", "")
 		includeImages := request.GetBool("include_images", false)
-
-		// Scan Kubernetes
-		output, err := module.ScanKubernetes(ctx, target, clusterContext, namespace, severity, outputFormat, scanners, includeImages)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy Kubernetes scan failed: %v", err)), nil
+		opts := []modules.TrivyKubernetesOption{
+			modules.WithTrivyIncludeNamespaces(splitTrivyList(request.GetString("include_namespaces", ""))),
+			modules.WithTrivyExcludeNamespaces(splitTrivyList(request.GetString("exclude_namespaces", ""))),
+			modules.WithTrivyIncludeKinds(splitTrivyList(request.GetString("include_kinds", ""))),
+			modules.WithTrivyNamespace(request.GetString("namespace", "")),
+			modules.WithTrivyTarget(request.GetString("target", "")),
+			modules.WithTrivyKubernetesKubeconfig(kubeconfig),
 		}
 
-		return mcp.NewToolResultText(output), nil
+		// Scan Kubernetes
+		return withDaggerModule(ctx, "Trivy Kubernetes scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanKubernetes(ctx, clusterContext, severity, outputFormat, scanners, includeImages, opts...)
+		})
 	})
 
 	// Trivy generate SBOM tool
@@ -413,16 +315,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(generateSBOMTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		targetType := request.GetString("target_type", "")
 		target := request.GetString("target", "")
@@ -441,12 +333,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Generate SBOM
-		output, err := module.GenerateSBOM(ctx, target, targetType, sbomFormat, outputFile, includeDevDeps)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy SBOM generation failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy SBOM generation failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.GenerateSBOM(ctx, target, targetType, sbomFormat, outputFile, includeDevDeps)
+		})
 	})
 
 	// Trivy scan with filters tool
@@ -478,16 +367,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(scanWithFiltersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		targetType := request.GetString("target_type", "")
 		target := request.GetString("target", "")
@@ -505,12 +384,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Scan with filters
-		output, err := module.ScanWithFilters(ctx, target, targetType, severity, vulnType, ignoreFile, ignoreUnfixed, exitCode)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy scan with filters failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy scan with filters failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ScanWithFilters(ctx, target, targetType, severity, vulnType, ignoreFile, ignoreUnfixed, exitCode)
+		})
 	})
 
 	// Trivy database operations tool
@@ -529,16 +405,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(databaseOperationsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		operation := request.GetString("operation", "")
 		skipUpdate := request.GetBool("skip_update", false)
@@ -549,12 +415,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Database operation
-		output, err := module.DatabaseUpdate(ctx, operation, skipUpdate, cacheDir)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy database operation failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy database operation failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.DatabaseUpdate(ctx, operation, skipUpdate, cacheDir)
+		})
 	})
 
 	// Trivy server mode tool
@@ -574,16 +437,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(serverModeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		listenPort := request.GetString("listen_port", "")
 		listenAddress := request.GetString("listen_address", "")
@@ -591,12 +444,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		token := request.GetString("token", "")
 
 		// Server mode
-		output, err := module.ServerMode(ctx, listenPort, listenAddress, debug, token)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy server mode failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy server mode failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ServerMode(ctx, listenPort, listenAddress, debug, token)
+		})
 	})
 
 	// Trivy client scan tool
@@ -624,16 +474,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(clientScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		targetType := request.GetString("target_type", "")
 		target := request.GetString("target", "")
@@ -652,12 +492,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Client scan
-		output, err := module.ClientScan(ctx, target, targetType, serverURL, token, outputFormat)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy client scan failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy client scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ClientScan(ctx, target, targetType, serverURL, token, outputFormat)
+		})
 	})
 
 	// Trivy plugin management tool
@@ -673,16 +510,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(pluginManagementTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		action := request.GetString("action", "")
 		pluginName := request.GetString("plugin_name", "")
@@ -692,12 +519,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Plugin management
-		output, err := module.PluginManagement(ctx, action, pluginName)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy plugin management failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy plugin management failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.PluginManagement(ctx, action, pluginName)
+		})
 	})
 
 	// Trivy convert SBOM tool
@@ -717,16 +541,6 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		),
 	)
 	s.AddTool(convertSBOMTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
-		}
-		defer client.Close()
-
-		// Create module instance
-		module := modules.NewTrivyModule(client)
-
 		// Get parameters
 		inputSBOM := request.GetString("input_sbom", "")
 		outputFormat := request.GetString("output_format", "")
@@ -740,12 +554,9 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		}
 
 		// Convert SBOM
-		output, err := module.ConvertSBOM(ctx, inputSBOM, outputFormat, outputFile)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy SBOM conversion failed: %v", err)), nil
-		}
-
-		return mcp.NewToolResultText(output), nil
+		return withDaggerModule(ctx, "Trivy SBOM conversion failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.ConvertSBOM(ctx, inputSBOM, outputFormat, outputFile)
+		})
 	})
 
 	// Trivy get version tool
@@ -753,22 +564,100 @@ func addTrivyToolsDirect(s *server.MCPServer) {
 		mcp.WithDescription("Get Trivy version information"),
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
+		// Get version
+		return withDaggerModule(ctx, "Trivy get version failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+			return module.GetVersion(ctx)
+		})
+	})
+}
+
+// trivyScanOptions maps the scanners parameter onto the module's scanner
+// selection; without it the module runs only the vuln scanner
+func trivyScanOptions(request mcp.CallToolRequest) []modules.TrivyOption {
+	var opts []modules.TrivyOption
+	if scanners := request.GetString("scanners", ""); scanners != "" {
+		opts = append(opts, modules.WithTrivyScanners(strings.Split(scanners, ",")))
+	}
+	return opts
+}
+
+// splitTrivyList splits a comma-separated namespace or kind list, dropping blanks
+func splitTrivyList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
-		defer client.Close()
+	}
+	return items
+}
 
-		// Create module instance
-		module := modules.NewTrivyModule(client)
+// trivyAttestationFormats are the image scan output formats usable as
+// attestations: a cosign vulnerability predicate and a GitHub dependency
+// snapshot
+var trivyAttestationFormats = map[string]bool{
+	"cosign-vuln": true,
+	"github":      true,
+}
 
-		// Get version
-		output, err := module.GetVersion(ctx)
+// trivyScanImageOptions adds the attestation output format and output_file
+// artifact path to the common scan options. The output file is confined to
+// the artifact directory.
+func trivyScanImageOptions(request mcp.CallToolRequest) ([]modules.TrivyOption, error) {
+	opts := trivyScanOptions(request)
+	if outputFormat := request.GetString("output_format", ""); trivyAttestationFormats[outputFormat] {
+		opts = append(opts, modules.WithTrivyFormat(outputFormat))
+	}
+	if outputFile := request.GetString("output_file", ""); outputFile != "" {
+		path, err := ArtifactPath(outputFile)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Trivy get version failed: %v", err)), nil
+			return nil, err
 		}
+		opts = append(opts, modules.WithTrivyOutput(path))
+	}
+	return opts, nil
+}
 
-		return mcp.NewToolResultText(output), nil
-	})
-}
\ No newline at end of file
+// The direct Dagger scans run Trivy with fixed JSON output and HIGH,CRITICAL
+// severity. Parameters they cannot honor are reported as warnings on an
+// otherwise successful result instead of aborting the scan.
+
+func trivyScanImageWarnings(request mcp.CallToolRequest) []string {
+	var warnings []string
+	warnings = appendTrivyFormatWarnings(warnings, request, trivyAttestationFormats)
+	warnings = appendIgnoredParamWarnings(warnings, request, "ignore_unfixed")
+	return warnings
+}
+
+func trivyScanFilesystemWarnings(request mcp.CallToolRequest) []string {
+	var warnings []string
+	warnings = appendTrivyFormatWarnings(warnings, request, nil)
+	warnings = appendIgnoredParamWarnings(warnings, request, "skip_dirs", "include_dev_deps")
+	return warnings
+}
+
+func trivyScanRepositoryWarnings(request mcp.CallToolRequest) []string {
+	var warnings []string
+	warnings = appendIgnoredParamWarnings(warnings, request, "branch", "commit")
+	warnings = appendTrivyFormatWarnings(warnings, request, nil)
+	return warnings
+}
+
+func trivyScanConfigWarnings(request mcp.CallToolRequest) []string {
+	var warnings []string
+	warnings = appendTrivyFormatWarnings(warnings, request, nil)
+	warnings = appendIgnoredParamWarnings(warnings, request, "policy_bundle", "config_policy")
+	return warnings
+}
+
+// appendTrivyFormatWarnings warns about severity and output_format values that
+// the scan does not support. Besides json, only the given formats are honored.
+func appendTrivyFormatWarnings(warnings []string, request mcp.CallToolRequest, formats map[string]bool) []string {
+	if severity := request.GetString("severity", ""); severity != "" && severity != "HIGH,CRITICAL" {
+		warnings = append(warnings, fmt.Sprintf("severity '%s' not supported, using HIGH,CRITICAL", severity))
+	}
+	if outputFormat := request.GetString("output_format", ""); outputFormat != "" && outputFormat != "json" && !formats[outputFormat] {
+		warnings = append(warnings, fmt.Sprintf("output_format '%s' not supported, using json", outputFormat))
+	}
+	return warnings
+}
@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newToolRequest(args map[string]any) mcp.CallToolRequest {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+	return request
+}
+
+func TestTrivyUnsupportedParamsStillReturnScanOutput(t *testing.T) {
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		return &dagger.Client{}, nil
+	})
+
+	request := newToolRequest(map[string]any{
+		"image_name":     "alpine:3.19",
+		"output_format":  "sarif",
+		"ignore_unfixed": true,
+	})
+
+	scanned := false
+	result, err := withDaggerModule(context.Background(), "Trivy image scan failed", modules.NewTrivyModule, func(module *modules.TrivyModule) (string, error) {
+		scanned = true
+		return `{"Results":[]}`, nil
+	})
+	result = withToolWarnings(result, trivyScanImageWarnings(request))
+	require.NoError(t, err)
+
+	assert.True(t, scanned, "the scan runs despite unsupported parameters")
+	assert.False(t, result.IsError)
+	assert.Equal(t, `{"Results":[]}`, resultText(t, result))
+	assert.Equal(t, []string{
+		"output_format 'sarif' not supported, using json",
+		"ignore_unfixed parameter not supported with direct Dagger calls, ignoring it",
+	}, result.Meta.AdditionalFields[toolWarningsMetaKey])
+
+	// Warnings travel in the result's _meta
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"warnings":["output_format 'sarif' not supported, using json"`)
+}
+
+func TestTrivyWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		warnings func(mcp.CallToolRequest) []string
+		args     map[string]any
+		want     []string
+	}{
+		{
+			name:     "supported values produce no warnings",
+			warnings: trivyScanImageWarnings,
+			args:     map[string]any{"image_name": "alpine", "output_format": "json", "severity": "HIGH,CRITICAL", "ignore_unfixed": false, "scanners": "vuln,secret"},
+		},
+		{
+			name:     "filesystem",
+			warnings: trivyScanFilesystemWarnings,
+			args:     map[string]any{"severity": "LOW", "skip_dirs": "vendor", "include_dev_deps": true},
+			want: []string{
+				"severity 'LOW' not supported, using HIGH,CRITICAL",
+				"skip_dirs parameter not supported with direct Dagger calls, ignoring it",
+				"include_dev_deps parameter not supported with direct Dagger calls, ignoring it",
+			},
+		},
+		{
+			name:     "repository",
+			warnings: trivyScanRepositoryWarnings,
+			args:     map[string]any{"repo_url": "https://github.com/org/repo", "branch": "main", "commit": "abc123", "scanners": "secret"},
+			want: []string{
+				"branch parameter not supported with direct Dagger calls, ignoring it",
+				"commit parameter not supported with direct Dagger calls, ignoring it",
+			},
+		},
+		{
+			name:     "config",
+			warnings: trivyScanConfigWarnings,
+			args:     map[string]any{"policy_bundle": "./policies", "output_format": "table"},
+			want: []string{
+				"output_format 'table' not supported, using json",
+				"policy_bundle parameter not supported with direct Dagger calls, ignoring it",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.warnings(newToolRequest(tt.args)))
+		})
+	}
+}
+
+func TestWithToolWarnings(t *testing.T) {
+	success := withToolWarnings(mcp.NewToolResultText("ok"), nil)
+	assert.Nil(t, success.Meta, "no warnings leave the result untouched")
+
+	failure := withToolWarnings(mcp.NewToolResultError("scan failed"), []string{"ignored"})
+	assert.Nil(t, failure.Meta, "errors carry no warnings")
+
+	assert.Nil(t, withToolWarnings(nil, []string{"ignored"}))
+}
+
+func TestTrivyScanOptions(t *testing.T) {
+	assert.Empty(t, trivyScanOptions(newToolRequest(map[string]any{"image_name": "alpine"})), "no scanners keeps the module default")
+
+	opts := trivyScanOptions(newToolRequest(map[string]any{"scanners": "vuln,secret"}))
+	require.Len(t, opts, 1)
+	config := &modules.TrivyConfig{}
+	opts[0](config)
+	assert.Equal(t, []string{"vuln", "secret"}, config.Scanners)
+}
+
+func TestTrivyScanImageOptions_AttestationFormats(t *testing.T) {
+	artifactDir := t.TempDir()
+	t.Setenv(ArtifactDirEnv, artifactDir)
+
+	for _, format := range []string{"cosign-vuln", "github"} {
+		t.Run(format, func(t *testing.T) {
+			request := newToolRequest(map[string]any{
+				"image_name":    "alpine:3.19",
+				"output_format": format,
+				"output_file":   "predicate.json",
+			})
+
+			opts, err := trivyScanImageOptions(request)
+			require.NoError(t, err)
+			config := &modules.TrivyConfig{}
+			for _, opt := range opts {
+				opt(config)
+			}
+			assert.Equal(t, format, config.Format)
+			assert.Equal(t, filepath.Join(artifactDir, "predicate.json"), config.OutputPath)
+			assert.Empty(t, trivyScanImageWarnings(request), "attestation formats and output_file are honored")
+		})
+	}
+}
+
+func TestTrivyScanImageOptions_UnsupportedFormatFallsBackToJSON(t *testing.T) {
+	request := newToolRequest(map[string]any{"image_name": "alpine:3.19", "output_format": "sarif"})
+
+	opts, err := trivyScanImageOptions(request)
+	require.NoError(t, err)
+	config := &modules.TrivyConfig{Format: "json"}
+	for _, opt := range opts {
+		opt(config)
+	}
+	assert.Equal(t, "json", config.Format)
+	assert.Empty(t, config.OutputPath)
+}
+
+func TestTrivyScanImageOptions_OutputFileConfinedToArtifactDir(t *testing.T) {
+	t.Setenv(ArtifactDirEnv, t.TempDir())
+
+	for _, outputFile := range []string{"/etc/cron.d/predicate", "../predicate.json"} {
+		_, err := trivyScanImageOptions(newToolRequest(map[string]any{"image_name": "alpine:3.19", "output_file": outputFile}))
+		assert.Error(t, err, outputFile)
+	}
+}
+
+func TestTrivyScanKubernetesRejectsUnknownParams(t *testing.T) {
+	stubDaggerClients(t, func(ctx context.Context) (*dagger.Client, error) {
+		t.Fatal("the scan must not start with an unknown parameter")
+		return nil, nil
+	})
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	addTrivyToolsDirect(s)
+
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      "trivy_scan_kubernetes",
+			"arguments": map[string]any{"kubeconfig": "/home/user/.kube/config", "namespaces": "payments"},
+		},
+	})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(context.Background(), request).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := response.Result.(mcp.CallToolResult)
+	require.True(t, ok)
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(t, &result), `unknown parameter "namespaces" for trivy_scan_kubernetes`)
+}
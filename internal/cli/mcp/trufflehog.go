@@ -8,7 +8,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddTrufflehogTools adds TruffleHog MCP tool implementations using direct Dagger calls
@@ -28,11 +27,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanDirectoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -59,11 +58,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanGitRepoTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -97,11 +96,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanGitHubTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -140,11 +139,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanGitHubOrgTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -179,11 +178,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanDockerImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -213,11 +212,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanS3Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -252,11 +251,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(scanWithVerificationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -291,30 +290,33 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		mcp.WithString("branch",
 			mcp.Description("Specific branch to scan"),
 		),
+		mcp.WithString("since_commit",
+			mcp.Description("Only scan commits after this commit"),
+		),
 		mcp.WithString("since_date",
-			mcp.Description("Only scan commits since this date (YYYY-MM-DD)"),
+			mcp.Description("Only scan commits since this date (YYYY-MM-DD); trufflehog cannot filter by date, so use since_commit instead"),
 		),
 		mcp.WithString("until_date",
-			mcp.Description("Only scan commits until this date (YYYY-MM-DD)"),
+			mcp.Description("Only scan commits until this date (YYYY-MM-DD); trufflehog cannot filter by date, so use since_commit instead"),
 		),
 		mcp.WithBoolean("only_verified",
 			mcp.Description("Only return verified secrets"),
 		),
 		mcp.WithString("output_format",
-			mcp.Description("Output format"),
-			mcp.Enum("json", "plain"),
+			mcp.Description("Output format: json for an array of results, jsonl for one result per line"),
+			mcp.Enum("json", "jsonl", "plain"),
 		),
 		mcp.WithString("exclude_paths",
-			mcp.Description("Comma-separated paths to exclude"),
+			mcp.Description("Comma-separated regular expressions of paths to exclude"),
 		),
 	)
 	s.AddTool(scanGitAdvancedTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -326,8 +328,13 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		}
 
 		branch := request.GetString("branch", "")
-		sinceDate := request.GetString("since_date", "")
-		untilDate := request.GetString("until_date", "")
+		sinceCommit := request.GetString("since_commit", "")
+
+		// trufflehog's git source only limits history by commit
+		if request.GetString("since_date", "") != "" || request.GetString("until_date", "") != "" {
+			return mcp.NewToolResultError("since_date and until_date are not supported by trufflehog; use since_commit instead"), nil
+		}
+
 		onlyVerified := request.GetBool("only_verified", false)
 		outputFormat := request.GetString("output_format", "")
 		excludePathsStr := request.GetString("exclude_paths", "")
@@ -343,7 +350,7 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		}
 
 		// Scan git advanced
-		output, err := module.ScanGitAdvanced(ctx, repoURL, branch, sinceDate, untilDate, onlyVerified, outputFormat, excludePaths)
+		output, err := module.ScanGitAdvanced(ctx, repoURL, branch, sinceCommit, onlyVerified, outputFormat, excludePaths)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("TruffleHog advanced git scan failed: %v", err)), nil
 		}
@@ -362,23 +369,23 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			mcp.Description("Only return verified secrets"),
 		),
 		mcp.WithString("exclude_paths",
-			mcp.Description("Comma-separated paths to exclude from scanning"),
+			mcp.Description("Comma-separated regular expressions of paths to exclude from scanning"),
 		),
 		mcp.WithString("output_format",
-			mcp.Description("Output format"),
-			mcp.Enum("json", "plain"),
+			mcp.Description("Output format: json for an array of results, jsonl for one result per line"),
+			mcp.Enum("json", "jsonl", "plain"),
 		),
 		mcp.WithString("max_depth",
-			mcp.Description("Maximum directory depth to scan"),
+			mcp.Description("Maximum directory depth to scan; trufflehog scans every level, so use exclude_paths instead"),
 		),
 	)
 	s.AddTool(scanFilesystemAdvancedTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -389,9 +396,13 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("path is required"), nil
 		}
 
+		// trufflehog's filesystem source has no depth limit
+		if request.GetString("max_depth", "") != "" {
+			return mcp.NewToolResultError("max_depth is not supported by trufflehog; use exclude_paths instead"), nil
+		}
+
 		onlyVerified := request.GetBool("only_verified", false)
 		outputFormat := request.GetString("output_format", "")
-		maxDepth := request.GetString("max_depth", "")
 		excludePathsStr := request.GetString("exclude_paths", "")
 
 		// Parse exclude paths
@@ -405,7 +416,7 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		}
 
 		// Scan filesystem advanced
-		output, err := module.ScanFilesystemAdvanced(ctx, path, onlyVerified, excludePaths, outputFormat, maxDepth)
+		output, err := module.ScanFilesystemAdvanced(ctx, path, onlyVerified, excludePaths, outputFormat)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("TruffleHog advanced filesystem scan failed: %v", err)), nil
 		}
@@ -424,20 +435,20 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			mcp.Description("Only return verified secrets"),
 		),
 		mcp.WithString("output_format",
-			mcp.Description("Output format"),
-			mcp.Enum("json", "plain"),
+			mcp.Description("Output format: json for an array of results, jsonl for one result per line"),
+			mcp.Enum("json", "jsonl", "plain"),
 		),
 		mcp.WithString("layers",
-			mcp.Description("Specific layers to scan (comma-separated)"),
+			mcp.Description("Specific layers to scan (comma-separated); trufflehog always scans every layer of the image"),
 		),
 	)
 	s.AddTool(scanDockerAdvancedTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -448,12 +459,16 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			return mcp.NewToolResultError("image is required"), nil
 		}
 
+		// trufflehog's docker source cannot select layers
+		if request.GetString("layers", "") != "" {
+			return mcp.NewToolResultError("layers is not supported by trufflehog; every layer of the image is scanned"), nil
+		}
+
 		onlyVerified := request.GetBool("only_verified", false)
 		outputFormat := request.GetString("output_format", "")
-		layers := request.GetString("layers", "")
 
 		// Scan docker advanced
-		output, err := module.ScanDockerAdvanced(ctx, image, onlyVerified, outputFormat, layers)
+		output, err := module.ScanDockerAdvanced(ctx, image, onlyVerified, outputFormat)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("TruffleHog advanced docker scan failed: %v", err)), nil
 		}
@@ -475,10 +490,10 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		),
 		mcp.WithString("output_format",
 			mcp.Description("Output format for results"),
-			mcp.Enum("json", "jsonl", "csv", "plain", "sarif"),
+			mcp.Enum("json", "jsonl", "plain"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file path for results"),
+			mcp.Description("Output file path for results, saved on the host relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithBoolean("only_verified",
 			mcp.Description("Only return verified secrets"),
@@ -491,7 +506,7 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			mcp.Enum("low", "medium", "high"),
 		),
 		mcp.WithString("exclude_paths",
-			mcp.Description("Comma-separated paths to exclude"),
+			mcp.Description("Comma-separated regular expressions of paths to exclude"),
 		),
 		mcp.WithString("include_paths",
 			mcp.Description("Comma-separated paths to include (whitelist)"),
@@ -499,11 +514,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(comprehensiveSecretDetectionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -542,12 +557,12 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		}
 
 		// Comprehensive secret detection
-		output, err := module.ComprehensiveSecretDetection(ctx, target, sourceType, outputFormat, outputFile, onlyVerified, includeDetectors, confidenceLevel, excludePaths, includePaths)
+		output, err := module.ComprehensiveSecretDetection(ctx, target, sourceType, outputFormat, "", onlyVerified, includeDetectors, confidenceLevel, excludePaths, includePaths)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("TruffleHog comprehensive detection failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return withReportArtifact(mcp.NewToolResultText(output), outputFile, output), nil
 	})
 
 	// TruffleHog cloud storage scanning tool
@@ -583,11 +598,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(cloudStorageScanningTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -641,11 +656,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(customDetectorManagementTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -711,11 +726,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(enterpriseGitScanningTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -767,7 +782,7 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 			mcp.Enum("json", "sarif", "junit", "csv"),
 		),
 		mcp.WithString("output_file",
-			mcp.Description("Output file for CI artifacts"),
+			mcp.Description("Output file for CI artifacts, saved on the host relative to --artifact-dir or the current directory"),
 		),
 		mcp.WithBoolean("fail_on_verified",
 			mcp.Description("Fail CI pipeline on verified secrets"),
@@ -784,11 +799,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(cicdPipelineIntegrationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -809,12 +824,12 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 		timeout := request.GetString("timeout", "")
 
 		// CI/CD pipeline integration
-		output, err := module.CICDPipelineIntegration(ctx, scanTarget, scanType, baselineFile, outputFormat, outputFile, failOnVerified, failOnUnverified, quietMode, timeout)
+		output, err := module.CICDPipelineIntegration(ctx, scanTarget, scanType, baselineFile, outputFormat, "", failOnVerified, failOnUnverified, quietMode, timeout)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("TruffleHog CI/CD integration failed: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return withReportArtifact(mcp.NewToolResultText(output), outputFile, output), nil
 	})
 
 	// TruffleHog performance optimization tool
@@ -853,11 +868,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(performanceOptimizationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -927,11 +942,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(comprehensiveReportingTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
@@ -975,11 +990,11 @@ func addTrufflehogToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewTruffleHogModule(client)
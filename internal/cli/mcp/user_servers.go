@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"gopkg.in/yaml.v3"
+)
+
+// UserMCPServersFile is where users define their own external MCP servers,
+// relative to the home directory
+const UserMCPServersFile = ".ship/mcp-servers.yaml"
+
+var (
+	serverNamePattern   = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// userMCPServersConfig is the format of ~/.ship/mcp-servers.yaml:
+//
+//	servers:
+//	  - name: linear
+//	    transport: stdio
+//	    command: npx
+//	    args: ["-y", "mcp-linear"]
+//	    variables:
+//	      - name: LINEAR_API_KEY
+//	        required: true
+//	        secret: true
+type userMCPServersConfig struct {
+	Servers []userMCPServer `yaml:"servers"`
+}
+
+type userMCPServer struct {
+	Name      string            `yaml:"name"`
+	Transport string            `yaml:"transport"`
+	Command   string            `yaml:"command"`
+	Args      []string          `yaml:"args"`
+	Env       map[string]string `yaml:"env"`
+	URL       string            `yaml:"url"`
+	Variables []userMCPVariable `yaml:"variables"`
+}
+
+type userMCPVariable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+	Secret      bool   `yaml:"secret"`
+}
+
+// LoadUserMCPServers reads user-defined external MCP servers from path. A
+// missing file defines no servers.
+func LoadUserMCPServers(path string) (map[string]ship.MCPServerConfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]ship.MCPServerConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP servers file: %w", err)
+	}
+
+	var file userMCPServersConfig
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	servers := make(map[string]ship.MCPServerConfig, len(file.Servers))
+	for i, server := range file.Servers {
+		config, err := server.config()
+		if err != nil {
+			return nil, fmt.Errorf("%s: server %d: %w", path, i+1, err)
+		}
+		if _, exists := servers[config.Name]; exists {
+			return nil, fmt.Errorf("%s: server '%s' is defined more than once", path, config.Name)
+		}
+		servers[config.Name] = config
+	}
+	return servers, nil
+}
+
+// config validates a user-defined server and converts it to a proxy
+// configuration. The transport defaults to stdio.
+func (s userMCPServer) config() (ship.MCPServerConfig, error) {
+	if !serverNamePattern.MatchString(s.Name) {
+		return ship.MCPServerConfig{}, fmt.Errorf("invalid server name %q", s.Name)
+	}
+
+	transport := s.Transport
+	if transport == "" {
+		transport = "stdio"
+	}
+	switch transport {
+	case "stdio":
+		if s.Command == "" {
+			return ship.MCPServerConfig{}, fmt.Errorf("server '%s' needs a command for the stdio transport", s.Name)
+		}
+	case "http", "sse":
+		if s.URL == "" {
+			return ship.MCPServerConfig{}, fmt.Errorf("server '%s' needs a url for the %s transport", s.Name, transport)
+		}
+	default:
+		return ship.MCPServerConfig{}, fmt.Errorf("server '%s' has unsupported transport %q: must be stdio, http or sse", s.Name, transport)
+	}
+
+	config := ship.MCPServerConfig{
+		Name:      s.Name,
+		Command:   s.Command,
+		Args:      s.Args,
+		Env:       map[string]string{},
+		BaseURL:   s.URL,
+		Transport: transport,
+	}
+	for key, value := range s.Env {
+		config.Env[key] = value
+	}
+	for _, variable := range s.Variables {
+		if !variableNamePattern.MatchString(variable.Name) {
+			return ship.MCPServerConfig{}, fmt.Errorf("server '%s' has invalid variable name %q", s.Name, variable.Name)
+		}
+		config.Variables = append(config.Variables, ship.Variable{
+			Name:        variable.Name,
+			Description: variable.Description,
+			Required:    variable.Required,
+			Default:     variable.Default,
+			Secret:      variable.Secret,
+		})
+	}
+	return config, nil
+}
+
+// userServers caches the servers of the user's file by path, so the file is
+// read, and a broken file reported, once
+var userServers struct {
+	mu      sync.Mutex
+	path    string
+	servers map[string]ship.MCPServerConfig
+}
+
+// userMCPServers returns the servers defined in ~/.ship/mcp-servers.yaml. An
+// invalid file is reported and ignored so built-in servers keep working.
+func userMCPServers() map[string]ship.MCPServerConfig {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(home, UserMCPServersFile)
+
+	userServers.mu.Lock()
+	defer userServers.mu.Unlock()
+	if userServers.servers != nil && userServers.path == path {
+		return userServers.servers
+	}
+
+	servers, err := LoadUserMCPServers(path)
+	if err != nil {
+		slog.Warn("ignoring user-defined MCP servers", "error", err)
+		servers = map[string]ship.MCPServerConfig{}
+	}
+	userServers.path = path
+	userServers.servers = servers
+	return servers
+}
+
+// UserMCPServerNames returns the names of the user-defined servers, sorted
+func UserMCPServerNames() []string {
+	servers := userMCPServers()
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeUserMCPServers writes ~/.ship/mcp-servers.yaml under a temporary home
+func writeUserMCPServers(t *testing.T, content string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, UserMCPServersFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadUserMCPServers(t *testing.T) {
+	path := writeUserMCPServers(t, `servers:
+  - name: linear
+    command: npx
+    args: ["-y", "mcp-linear"]
+    env:
+      LOG_LEVEL: error
+    variables:
+      - name: LINEAR_API_KEY
+        description: Linear API key
+        required: true
+        secret: true
+  - name: remote-docs
+    transport: http
+    url: https://docs.example.com/mcp
+`)
+
+	servers, err := LoadUserMCPServers(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ship.MCPServerConfig{
+		"linear": {
+			Name:      "linear",
+			Command:   "npx",
+			Args:      []string{"-y", "mcp-linear"},
+			Env:       map[string]string{"LOG_LEVEL": "error"},
+			Transport: "stdio",
+			Variables: []ship.Variable{{Name: "LINEAR_API_KEY", Description: "Linear API key", Required: true, Secret: true}},
+		},
+		"remote-docs": {
+			Name:      "remote-docs",
+			Env:       map[string]string{},
+			BaseURL:   "https://docs.example.com/mcp",
+			Transport: "http",
+		},
+	}, servers)
+}
+
+func TestLoadUserMCPServersMissingFile(t *testing.T) {
+	servers, err := LoadUserMCPServers(filepath.Join(t.TempDir(), "mcp-servers.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+}
+
+func TestLoadUserMCPServersInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing name", "servers:\n  - command: npx\n", `server 1: invalid server name ""`},
+		{"stdio without command", "servers:\n  - name: linear\n", "server 1: server 'linear' needs a command for the stdio transport"},
+		{"http without url", "servers:\n  - name: docs\n    transport: sse\n", "server 1: server 'docs' needs a url for the sse transport"},
+		{"unknown transport", "servers:\n  - name: docs\n    transport: grpc\n", `server 'docs' has unsupported transport "grpc": must be stdio, http or sse`},
+		{"invalid variable", "servers:\n  - name: linear\n    command: npx\n    variables:\n      - name: API-KEY\n", `server 'linear' has invalid variable name "API-KEY"`},
+		{"duplicate", "servers:\n  - name: linear\n    command: a\n  - name: linear\n    command: b\n", "server 'linear' is defined more than once"},
+		{"not yaml", "servers: [", "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadUserMCPServers(writeUserMCPServers(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUserDefinedServersMergeWithBuiltIn(t *testing.T) {
+	writeUserMCPServers(t, `servers:
+  - name: linear
+    command: npx
+    args: ["-y", "mcp-linear"]
+  - name: filesystem
+    command: npx
+    args: ["-y", "@modelcontextprotocol/server-filesystem", "/srv"]
+`)
+
+	assert.True(t, IsExternalMCPServer("linear"))
+	config, ok := GetExternalMCPServer("linear")
+	require.True(t, ok)
+	assert.Equal(t, []string{"-y", "mcp-linear"}, config.Args)
+
+	config, ok = GetExternalMCPServer("filesystem")
+	require.True(t, ok)
+	assert.Equal(t, "/srv", config.Args[2], "user-defined servers take precedence over built-in ones")
+
+	_, ok = GetExternalMCPServer("memory")
+	assert.True(t, ok, "built-in servers are still available")
+
+	names := ListExternalMCPServers()
+	assert.Contains(t, names, "linear")
+	assert.Len(t, names, len(ExternalMCPServers)+1, "an overridden built-in server is listed once")
+	assert.Equal(t, []string{"filesystem", "linear"}, UserMCPServerNames())
+	assert.Contains(t, generateExternalServersHelpText(), "linear")
+}
+
+func TestInvalidUserDefinedServersAreIgnored(t *testing.T) {
+	writeUserMCPServers(t, "servers:\n  - name: linear\n")
+
+	assert.False(t, IsExternalMCPServer("linear"))
+	assert.True(t, IsExternalMCPServer("filesystem"))
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/cloudshipai/ship/internal/dagger/modules"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
-	"dagger.io/dagger"
 )
 
 // AddZapTools adds OWASP ZAP (web application security scanner) MCP tool implementations using direct Dagger calls
@@ -32,11 +31,11 @@ func addZapToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(passiveScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -75,11 +74,11 @@ func addZapToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(activeScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -121,11 +120,11 @@ func addZapToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(spiderScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -162,14 +161,27 @@ func addZapToolsDirect(s *server.MCPServer) {
 			mcp.Description("Output format"),
 			mcp.Enum("json", "xml", "html"),
 		),
+		mcp.WithString("auth_script",
+			mcp.Description("Path to a ZAP hook script that authenticates before scanning"),
+		),
+		mcp.WithString("session",
+			mcp.Description("Path to an authenticated ZAP .session file; the other files of the session must sit next to it"),
+		),
+		mcp.WithString("alert_threshold",
+			mcp.Description("Only report alerts at or above this threshold"),
+			mcp.Enum("OFF", "LOW", "MEDIUM", "HIGH"),
+		),
+		mcp.WithString("scan_policy",
+			mcp.Description("Path to a ZAP .policy file to use as the scan policy"),
+		),
 	)
 	s.AddTool(fullScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -187,7 +199,7 @@ func addZapToolsDirect(s *server.MCPServer) {
 		}
 
 		// Perform full scan
-		output, err := module.FullScan(ctx, target, maxDuration)
+		output, err := module.FullScan(ctx, target, maxDuration, zapOptionsFromRequest(request)...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("ZAP full scan failed: %v", err)), nil
 		}
@@ -206,14 +218,27 @@ func addZapToolsDirect(s *server.MCPServer) {
 			mcp.Description("Output format"),
 			mcp.Enum("json", "xml", "html"),
 		),
+		mcp.WithString("auth_script",
+			mcp.Description("Path to a ZAP hook script that authenticates before scanning"),
+		),
+		mcp.WithString("session",
+			mcp.Description("Path to an authenticated ZAP .session file; the other files of the session must sit next to it"),
+		),
+		mcp.WithString("alert_threshold",
+			mcp.Description("Only report alerts at or above this threshold"),
+			mcp.Enum("OFF", "LOW", "MEDIUM", "HIGH"),
+		),
+		mcp.WithString("scan_policy",
+			mcp.Description("Path to a ZAP .policy file to use as the scan policy"),
+		),
 	)
 	s.AddTool(baselineScanTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -230,7 +255,7 @@ func addZapToolsDirect(s *server.MCPServer) {
 		}
 
 		// Perform baseline scan
-		output, err := module.BaselineScan(ctx, target)
+		output, err := module.BaselineScan(ctx, target, zapOptionsFromRequest(request)...)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("ZAP baseline scan failed: %v", err)), nil
 		}
@@ -244,11 +269,11 @@ func addZapToolsDirect(s *server.MCPServer) {
 	)
 	s.AddTool(getVersionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Create Dagger client
-		client, err := dagger.Connect(ctx, dagger.WithLogOutput(nil))
+		client, err := acquireDaggerClient(ctx)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("failed to create Dagger client: %v", err)), nil
 		}
-		defer client.Close()
+		defer releaseDaggerClient()
 
 		// Create module instance
 		module := modules.NewZapModule(client)
@@ -261,4 +286,22 @@ func addZapToolsDirect(s *server.MCPServer) {
 
 		return mcp.NewToolResultText(output), nil
 	})
-}
\ No newline at end of file
+}
+
+// zapOptionsFromRequest builds ZAP module options from shared scan parameters
+func zapOptionsFromRequest(request mcp.CallToolRequest) []modules.ZapOption {
+	var opts []modules.ZapOption
+	if authScript := request.GetString("auth_script", ""); authScript != "" {
+		opts = append(opts, modules.WithZapAuthScript(authScript))
+	}
+	if session := request.GetString("session", ""); session != "" {
+		opts = append(opts, modules.WithZapSession(session))
+	}
+	if threshold := request.GetString("alert_threshold", ""); threshold != "" {
+		opts = append(opts, modules.WithZapAlertThreshold(threshold))
+	}
+	if scanPolicy := request.GetString("scan_policy", ""); scanPolicy != "" {
+		opts = append(opts, modules.WithZapScanPolicy(scanPolicy))
+	}
+	return opts
+}
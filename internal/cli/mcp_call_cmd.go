@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+)
+
+var mcpCallCmd = &cobra.Command{
+	Use:   "call <tool>",
+	Short: "Invoke a single MCP tool locally and print its result",
+	Long: `Register an MCP tool and call it once without starting a server or
+connecting an MCP client.
+
+Each --param key=value sets one tool argument. Values are converted to the
+type the tool declares: booleans, numbers and integers are parsed, arrays take
+a JSON array or a comma-separated list and objects take JSON.
+
+Examples:
+  # Scan an image with Trivy
+  ship mcp call trivy_scan_image --param image_name=alpine:3.19
+
+  # Generate SBOMs in two formats
+  ship mcp call syft_sbom --param target=. --param format=cyclonedx-json,spdx-json
+
+  # Print the full MCP result, including metadata, as JSON
+  ship mcp call checkov_scan_directory --param directory=./infra --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPCall,
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpCallCmd)
+
+	mcpCallCmd.Flags().StringArray("param", nil, "Tool argument as key=value (repeatable)")
+	mcpCallCmd.Flags().Bool("json", false, "Print the full MCP tool result as JSON")
+}
+
+func runMCPCall(cmd *cobra.Command, args []string) error {
+	toolName := args[0]
+	rawParams, _ := cmd.Flags().GetStringArray("param")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	params, err := parseMCPCallParams(rawParams)
+	if err != nil {
+		return err
+	}
+	if err := configureMaxOutputTokens(cmd); err != nil {
+		return err
+	}
+
+	filter, err := shipMcp.NewToolFilter(toolName, "")
+	if err != nil {
+		return err
+	}
+
+	s := server.NewMCPServer("ship-call", "1.0.0")
+	shipMcp.RegisterAllTools(s, executeShipCommandWithStabilityEnhancements, filter)
+
+	// Hold the shared Dagger connection for the duration of the call, as the server does
+	releaseDaggerClient := shipMcp.HoldDaggerClient()
+	defer releaseDaggerClient()
+
+	result, err := shipMcp.CallTool(context.Background(), s, toolName, params)
+	if err != nil {
+		return err
+	}
+	return writeMCPCallResult(cmd, toolName, result, jsonOutput)
+}
+
+// parseMCPCallParams splits key=value --param flags. Values may contain '='
+// and commas; a repeated key keeps its last value.
+func parseMCPCallParams(rawParams []string) (map[string]string, error) {
+	params := make(map[string]string, len(rawParams))
+	for _, raw := range rawParams {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --param %q: expected key=value", raw)
+		}
+		params[strings.TrimSpace(key)] = value
+	}
+	return params, nil
+}
+
+// writeMCPCallResult prints the tool's text content, or the whole result with
+// --json. A tool error result is printed and returned as an error.
+func writeMCPCallResult(cmd *cobra.Command, toolName string, result *mcp.CallToolResult, jsonOutput bool) error {
+	out := cmd.OutOrStdout()
+	if jsonOutput {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				fmt.Fprintln(out, text.Text)
+			}
+		}
+	}
+
+	if result.IsError {
+		return fmt.Errorf("%s returned an error", toolName)
+	}
+	return nil
+}
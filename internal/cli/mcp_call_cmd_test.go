@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPCallCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"mcp", "call"})
+	require.NoError(t, err)
+	assert.Equal(t, mcpCallCmd, cmd)
+}
+
+func TestParseMCPCallParams(t *testing.T) {
+	params, err := parseMCPCallParams([]string{"target=.", "format=cyclonedx-json,spdx-json", "query=a=b", "target=./infra"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"target": "./infra",
+		"format": "cyclonedx-json,spdx-json",
+		"query":  "a=b",
+	}, params)
+
+	_, err = parseMCPCallParams([]string{"verbose"})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = parseMCPCallParams([]string{"=value"})
+	assert.ErrorContains(t, err, "expected key=value")
+}
+
+func TestWriteMCPCallResult(t *testing.T) {
+	cmd := &cobra.Command{}
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+
+	require.NoError(t, writeMCPCallResult(cmd, "trivy_scan_image", mcp.NewToolResultText(`{"Results":[]}`), false))
+	assert.Equal(t, "{\"Results\":[]}\n", stdout.String())
+
+	stdout.Reset()
+	err := writeMCPCallResult(cmd, "trivy_scan_image", mcp.NewToolResultError("image not found"), false)
+	assert.EqualError(t, err, "trivy_scan_image returned an error")
+	assert.Equal(t, "image not found\n", stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, writeMCPCallResult(cmd, "trivy_scan_image", mcp.NewToolResultText("ok"), true))
+	assert.Contains(t, stdout.String(), `"text": "ok"`)
+}
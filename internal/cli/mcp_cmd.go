@@ -4,14 +4,24 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
 	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/logger"
 	"github.com/cloudshipai/ship/internal/telemetry"
 	"github.com/cloudshipai/ship/pkg/ship"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -28,36 +38,68 @@ type ExecutionContext struct {
 // Global execution context
 var globalExecutionContext *ExecutionContext
 
+// mcpCategories are the tool categories ship mcp serves as a group
+var mcpCategories = []string{"terraform", "security", "aws", "kubernetes", "cloud", "supply-chain"}
+
 var mcpCmd = &cobra.Command{
 	Use:   "mcp [tool]",
 	Short: "Start MCP server for a specific tool or all tools",
-	Long: shipMcp.GenerateMCPHelpText(),
+	Long: "Start an MCP server that exposes specific Ship CLI tools for AI assistants.",
 	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeMCPTools,
 	RunE: runMCPServer,
 }
 
 func init() {
 	rootCmd.AddCommand(mcpCmd)
 
+	// The help lists the servers of ~/.ship/mcp-servers.yaml, so it is
+	// generated when shown rather than when every ship command starts
+	mcpCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		cmd.Long = shipMcp.GenerateMCPHelpText()
+		cmd.Parent().HelpFunc()(cmd, args)
+	})
+
 	mcpCmd.Flags().Int("port", 0, "Port to listen on (0 for stdio)")
 	mcpCmd.Flags().String("host", "localhost", "Host to bind to")
 	mcpCmd.Flags().Bool("stdio", true, "Use stdio transport (default)")
+	mcpCmd.Flags().Bool("http", false, "Serve MCP over streamable HTTP on --host and --port instead of stdio")
+	mcpCmd.Flags().String("allow-tools", "", "Comma-separated glob patterns of tools to register (e.g. trivy,*_scan_*)")
+	mcpCmd.Flags().String("deny-tools", "", "Comma-separated glob patterns of tools to exclude; takes precedence over --allow-tools")
 	mcpCmd.Flags().StringToString("var", nil, "Environment variables for MCP servers and containers (e.g., --var API_KEY=value --var DEBUG=true)")
-	mcpCmd.Flags().StringToString("image-tag", nil, "Override container image tags for tools (e.g., --image-tag trivy=aquasec/trivy:0.50.0 --image-tag checkov=bridgecrew/checkov:3.2.0)")
+	mcpCmd.Flags().String("env-file", "", "Load environment variables for MCP servers and containers from a dotenv file (--var values take precedence; reloaded on SIGHUP)")
+	mcpCmd.Flags().String("var-file", "", "Load variables for an external MCP server from a dotenv or YAML (.yaml, .yml) file (overrides --env-file; --var values take precedence)")
+	mcpCmd.Flags().StringToString("image-tag", nil, "Override container image tags or digests for tools (e.g., --image-tag trivy=aquasec/trivy:0.50.0 --image-tag checkov=bridgecrew/checkov@sha256:<digest>)")
 	mcpCmd.Flags().Bool("version", false, "Show version information for tools")
+	mcpCmd.Flags().Bool("json", false, "Print --version information as JSON")
 	mcpCmd.Flags().String("output-file", "", "Write tool output to file (in addition to MCP response)")
-	mcpCmd.Flags().String("execution-log", "", "Write execution logs and timing to file")
+	mcpCmd.Flags().String("execution-log", "", "Write execution logs, timing and Dagger progress output to file")
+	mcpCmd.Flags().String("metrics-file", "", "Write per-tool invocation counts and latency percentiles to this JSON file")
+	mcpCmd.Flags().Bool("refresh-tools", false, "List the tools of an external MCP server again instead of using the cached list in ~/.ship/cache/mcp (the server is started either way)")
+	mcpCmd.Flags().Int("max-output-tokens", defaultMaxMCPTokens, "Approximate tokens a tool response may use before it is split into chunks (env: "+maxOutputTokensEnv+")")
 }
 
 func runMCPServer(cmd *cobra.Command, args []string) error {
-	port, _ := cmd.Flags().GetInt("port")
-	host, _ := cmd.Flags().GetString("host")
-	useStdio, _ := cmd.Flags().GetBool("stdio")
-	envVars, _ := cmd.Flags().GetStringToString("var")
+	envVars, err := containerEnvVars(cmd)
+	if err != nil {
+		return err
+	}
 	imageTags, _ := cmd.Flags().GetStringToString("image-tag")
 	showVersion, _ := cmd.Flags().GetBool("version")
 	outputFile, _ := cmd.Flags().GetString("output-file")
 	executionLog, _ := cmd.Flags().GetString("execution-log")
+	allowTools, _ := cmd.Flags().GetString("allow-tools")
+	denyTools, _ := cmd.Flags().GetString("deny-tools")
+	metricsFile, _ := cmd.Flags().GetString("metrics-file")
+
+	toolFilter, err := shipMcp.NewToolFilter(allowTools, denyTools)
+	if err != nil {
+		return err
+	}
+
+	if err := configureMaxOutputTokens(cmd); err != nil {
+		return err
+	}
 
 	// Set global execution context for output options
 	globalExecutionContext = &ExecutionContext{
@@ -75,7 +117,8 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 
 	// Handle version requests
 	if showVersion {
-		return handleVersionRequest(args)
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		return handleVersionRequest(args, jsonOutput)
 	}
 
 	// Determine which tool to serve
@@ -89,7 +132,17 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 
 	// Create MCP server with enhanced configuration
 	serverName := fmt.Sprintf("ship-%s", toolName)
-	s := server.NewMCPServer(serverName, "1.0.0")
+	var metrics *mcpMetrics
+	if metricsFile != "" {
+		metrics = newMCPMetrics(metricsFile)
+	}
+	health := newMCPHealth()
+	s := server.NewMCPServer(serverName, "1.0.0",
+		server.WithToolHandlerMiddleware(metrics.middleware),
+		server.WithToolHandlerMiddleware(health.middleware),
+		shipMcp.WithToolErrorCodes(),
+		shipMcp.WithToolCategories(),
+	)
 
 	// Set environment variables for containerized tools
 	if len(envVars) > 0 {
@@ -98,20 +151,22 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 
 	// Set image tag overrides as environment variables
 	if len(imageTags) > 0 {
-		setImageTagOverrides(imageTags)
+		if err := setImageTagOverrides(imageTags); err != nil {
+			return err
+		}
 	}
 
 	// Add specific tools based on argument using the modular registry
-	switch toolName {
-	case "all":
+	switch {
+	case toolName == "all":
 		// Register all tools from all categories with enhanced execution wrapper
-		shipMcp.RegisterAllTools(s, executeShipCommandWithStabilityEnhancements)
-	case "terraform", "security", "aws", "kubernetes", "cloud", "supply-chain":
+		shipMcp.RegisterAllTools(s, executeShipCommandWithStabilityEnhancements, toolFilter)
+	case contains(mcpCategories, toolName):
 		// Register tools by category with enhanced execution wrapper
-		shipMcp.RegisterToolsByCategory(toolName, s, executeShipCommandWithStabilityEnhancements)
+		shipMcp.RegisterToolsByCategory(toolName, s, executeShipCommandWithStabilityEnhancements, toolFilter)
 	default:
 		// Check if this is a specific tool name with enhanced execution wrapper
-		shipMcp.RegisterToolByName(toolName, s, executeShipCommandWithStabilityEnhancements)
+		shipMcp.RegisterToolByName(toolName, s, executeShipCommandWithStabilityEnhancements, toolFilter)
 		
 		// If no tools were registered, check if it's an external MCP server  
 		// Note: we can't check s.Tools() as it's not exposed, so we'll assume tool was registered
@@ -124,20 +179,35 @@ func runMCPServer(cmd *cobra.Command, args []string) error {
 
 	// Add resources for documentation and help
 	addResources(s)
+	addChunkedOutputResources(s, chunkedOutputs)
+	addHealthResource(s, health)
 
-	// Add prompts only for 'all' mode
-	if toolName == "all" {
-		addPrompts(s)
-	}
+	// Add the prompts for the served tools: every prompt in 'all' mode and the
+	// prompts of the category otherwise
+	addPrompts(s, toolName)
+
+	// SIGHUP reloads --env-file, including image overrides, instead of
+	// terminating the server
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	defer func() {
+		signal.Stop(reloads)
+		close(reloads)
+	}()
+	go watchMCPReloads(newMCPSettingsReloader(cmd), reloads)
+
+	// Tool calls share one Dagger connection for the server's lifetime
+	releaseDaggerClient := shipMcp.HoldDaggerClient()
+	defer releaseDaggerClient()
 
 	// Start server with enhanced stability
-	if useStdio || port == 0 {
-		fmt.Fprintf(os.Stderr, "Starting %s MCP server on stdio with stability enhancements...\n", serverName)
-		return serveStdioWithStability(s)
-	} else {
-		fmt.Fprintf(os.Stderr, "Starting %s MCP server on %s:%d...\n", serverName, host, port)
-		return fmt.Errorf("HTTP server not implemented in this version, use --stdio")
+	if addr, useHTTP := mcpHTTPAddr(cmd); useHTTP {
+		slog.Info("starting MCP server", "server", serverName, "transport", "http", "url", "http://"+addr+mcpHTTPEndpoint)
+		return listenAndServeMCPHTTP(s, addr)
 	}
+
+	slog.Info("starting MCP server", "server", serverName, "transport", "stdio")
+	return serveStdioWithStability(s)
 }
 
 // Investigation tools removed to focus on Terraform analysis workflows
@@ -193,78 +263,12 @@ func addResources(s *server.MCPServer) {
 	})
 }
 
-func addPrompts(s *server.MCPServer) {
-	// Security audit prompt
-	securityPrompt := mcp.NewPrompt("security_audit",
-		mcp.WithPromptDescription("Comprehensive security audit of cloud infrastructure"),
-		mcp.WithArgument("provider",
-			mcp.ArgumentDescription("Cloud provider to audit (aws, azure, gcp)"),
-		),
-	)
-
-	s.AddPrompt(securityPrompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-		return &mcp.GetPromptResult{
-			Description: "Comprehensive security audit workflow",
-			Messages: []mcp.PromptMessage{
-				{
-					Role: "user",
-					Content: mcp.TextContent{
-						Type: "text",
-						Text: `Please perform a comprehensive security audit of my Terraform infrastructure. Follow these steps:
-
-1. Run terraform_checkov_scan to identify security issues in infrastructure-as-code
-2. Run terraform_security_scan for additional security analysis  
-3. Use terraform_lint to check for configuration best practices
-
-4. Summarize all findings with:
-   - Critical security issues requiring immediate attention
-   - Recommendations for improvement
-   - Best practices to implement
-
-Please be thorough and provide actionable recommendations.`,
-					},
-				},
-			},
-		}, nil
-	})
-
-	// Cost optimization prompt
-	costPrompt := mcp.NewPrompt("cost_optimization",
-		mcp.WithPromptDescription("Identify cost optimization opportunities"),
-		mcp.WithArgument("provider",
-			mcp.ArgumentDescription("Cloud provider to analyze (aws, azure, gcp)"),
-		),
-	)
-
-	s.AddPrompt(costPrompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-		return &mcp.GetPromptResult{
-			Description: "Cost optimization analysis workflow",
-			Messages: []mcp.PromptMessage{
-				{
-					Role: "user",
-					Content: mcp.TextContent{
-						Type: "text",
-						Text: `Help me optimize costs for my Terraform infrastructure:
-
-1. Use terraform_cost_analysis to analyze current cost projections
-2. Review Terraform configurations for cost optimization opportunities
-3. Use terraform_lint to identify inefficient resource configurations
-
-4. Provide a prioritized list of cost-saving recommendations:
-   - Quick wins (resource rightsizing, unused resources)
-   - Medium-term optimizations (reserved instances, storage classes)
-   - Long-term architectural improvements
-
-Include estimated cost savings where possible.`,
-					},
-				},
-			},
-		}, nil
-	})
-}
+// Default maximum tokens allowed in MCP response (conservative estimate)
+const defaultMaxMCPTokens = 20000
 
-// Maximum tokens allowed in MCP response (conservative estimate)
-const maxMCPTokens = 20000
+// maxMCPTokens is the response size above which output is chunked, set from
+// --max-output-tokens or SHIP_MAX_OUTPUT_TOKENS
+var maxMCPTokens = defaultMaxMCPTokens
 
 // Rough estimation: 1 token ≈ 4 characters for typical text
 const charsPerToken = 4
@@ -289,7 +293,7 @@ func executeShipCommandWithLogging(args []string) (*mcp.CallToolResult, error) {
 		// Append to execution log file
 		logFile, logErr := os.OpenFile(globalExecutionContext.ExecutionLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 		if logErr == nil {
-			logFile.WriteString(logEntry)
+			logFile.WriteString(logger.Redact(logEntry))
 			logFile.Close()
 		}
 	}
@@ -352,36 +356,46 @@ func executeShipCommand(args []string) (*mcp.CallToolResult, error) {
 	return mcp.NewToolResultText(outputStr), nil
 }
 
-func handleVersionRequest(args []string) error {
+func handleVersionRequest(args []string, jsonOutput bool) error {
 	toolName := "all"
 	if len(args) > 0 {
 		toolName = args[0]
 	}
 
-	fmt.Printf("Ship CLI Version Information for: %s\n", toolName)
-	fmt.Println("=====================================")
+	// Get versions for key containerized tools
+	tools := []string{toolName}
+	if toolName == "all" {
+		tools = []string{"checkov", "trivy", "tflint", "terragrunt", "terraform", "ansible"}
+	}
 
-	switch toolName {
-	case "all":
-		fmt.Println("Displaying version information for all available tools...")
-		// Get versions for key containerized tools
-		tools := []string{"checkov", "trivy", "tflint", "terragrunt", "terraform", "ansible"}
-		for _, tool := range tools {
-			if output, err := getToolVersion(tool); err == nil {
-				fmt.Printf("\n%s:\n%s\n", strings.ToUpper(tool), strings.TrimSpace(output))
-			} else {
-				fmt.Printf("\n%s: Version information unavailable (%v)\n", strings.ToUpper(tool), err)
-			}
+	results := processToolVersions.getAll(tools, toolVersionWorkers)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode version information: %w", err)
 		}
-	default:
-		// Get version for specific tool
-		if output, err := getToolVersion(toolName); err == nil {
-			fmt.Printf("\n%s:\n%s\n", strings.ToUpper(toolName), strings.TrimSpace(output))
-		} else {
-			return fmt.Errorf("failed to get version for %s: %v", toolName, err)
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("Ship CLI Version Information for: %s\n", toolName)
+		fmt.Println("=====================================")
+		if toolName == "all" {
+			fmt.Println("Displaying version information for all available tools...")
+		}
+		for _, result := range results {
+			if result.Error == "" {
+				fmt.Printf("\n%s:\n%s\n", strings.ToUpper(result.Tool), result.Version)
+			} else if toolName == "all" {
+				fmt.Printf("\n%s: Version information unavailable (%s)\n", strings.ToUpper(result.Tool), result.Error)
+			}
 		}
 	}
 
+	// A specific tool must report its version
+	if toolName != "all" && results[0].Error != "" {
+		return fmt.Errorf("failed to get version for %s: %s", toolName, results[0].Error)
+	}
+
 	return nil
 }
 
@@ -413,15 +427,28 @@ func needsChunking(text string) bool {
 func createChunkedResponse(text string) *mcp.CallToolResult {
 	maxChunkSize := maxMCPTokens * charsPerToken
 
-	// Split text into chunks, preferring to break at newlines
-	chunks := smartChunk(text, maxChunkSize)
+	// Split text into chunks, keeping JSON output valid and otherwise
+	// preferring to break at newlines
+	chunks := chunkOutput(text, maxChunkSize)
 
 	if len(chunks) <= 1 {
-		return mcp.NewToolResultText(text)
+		result := mcp.NewToolResultText(text)
+		if utf8.RuneCountInString(text) > maxChunkSize {
+			// A JSON document that cannot be split is returned whole
+			result.Meta = mcp.NewMetaFromMap(map[string]any{"large_output": true})
+		}
+		return result
+	}
+
+	// Store every chunk so clients can read them back as resources
+	id := chunkedOutputs.Put(chunks)
+	uris := make([]string, len(chunks))
+	for i := range chunks {
+		uris[i] = chunkResourceURI(id, i)
 	}
 
 	// Create a summary response with information about chunking
-	summary := fmt.Sprintf(`Output is large (%d characters, ~%d tokens) and has been summarized.
+	summary := fmt.Sprintf(`Output is large (%d characters, ~%d tokens) and has been split into chunks.
 
 TOTAL CHUNKS: %d
 
@@ -430,10 +457,8 @@ SUMMARY OF FIRST CHUNK:
 
 --- [Content continues in additional chunks] ---
 
-To see the full output, you can:
-1. Run the command with smaller scope (specific directory/subset)
-2. Use filtering options if available
-3. Process chunks individually if needed
+Read each chunk of the full output as a resource:
+%s
 
 FIRST CHUNK PREVIEW (showing first %d characters):
 %s`,
@@ -441,11 +466,52 @@ FIRST CHUNK PREVIEW (showing first %d characters):
 		utf8.RuneCountInString(text)/charsPerToken,
 		len(chunks),
 		getChunkSummary(chunks[0]),
+		strings.Join(uris, "\n"),
 		maxChunkSize/4, // Show 1/4 of max chunk size as preview
 		truncateText(chunks[0], maxChunkSize/4),
 	)
 
-	return mcp.NewToolResultText(summary)
+	result := mcp.NewToolResultText(summary)
+	result.Meta = mcp.NewMetaFromMap(map[string]any{"chunks": uris})
+	return result
+}
+
+// chunkOutput splits tool output for a chunked response. A JSON array is
+// split between its top-level elements so every chunk is a valid JSON array,
+// any other JSON document is kept whole and other output splits on lines.
+func chunkOutput(text string, maxSize int) []string {
+	trimmed := strings.TrimSpace(text)
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &elements); err == nil {
+		return chunkJSONArray(elements, maxSize)
+	}
+	if json.Valid([]byte(trimmed)) {
+		return []string{text}
+	}
+	return smartChunk(text, maxSize)
+}
+
+// chunkJSONArray groups array elements into JSON arrays of at most maxSize
+// characters. An element larger than maxSize gets a chunk of its own.
+func chunkJSONArray(elements []json.RawMessage, maxSize int) []string {
+	if len(elements) == 0 {
+		return []string{"[]"}
+	}
+
+	var chunks []string
+	var current []string
+	currentSize := 2 // the enclosing brackets
+	for _, element := range elements {
+		elementSize := utf8.RuneCount(element) + 1 // +1 for the separating comma
+		if currentSize+elementSize > maxSize && len(current) > 0 {
+			chunks = append(chunks, "["+strings.Join(current, ",")+"]")
+			current = nil
+			currentSize = 2
+		}
+		current = append(current, string(element))
+		currentSize += elementSize
+	}
+	return append(chunks, "["+strings.Join(current, ",")+"]")
 }
 
 func smartChunk(text string, maxSize int) []string {
@@ -519,9 +585,10 @@ func truncateText(text string, maxLen int) string {
 
 // runMCPProxy starts an MCP proxy server for external MCP servers
 func runMCPProxy(cmd *cobra.Command, serverName string) error {
-	useStdio, _ := cmd.Flags().GetBool("stdio")
-	port, _ := cmd.Flags().GetInt("port")
-	envVars, _ := cmd.Flags().GetStringToString("var")
+	envVars, err := mcpServerVars(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Get external server configuration
 	mcpConfig, exists := shipMcp.GetExternalMCPServer(serverName)
@@ -534,6 +601,10 @@ func runMCPProxy(cmd *cobra.Command, serverName string) error {
 		showVariableHelp(serverName)
 		return fmt.Errorf("variable validation failed: %w", err)
 	}
+	redactSecretVariables(mcpConfig)
+	if err := expandServerArgs(&mcpConfig); err != nil {
+		return err
+	}
 
 	ctx := context.Background()
 
@@ -544,41 +615,74 @@ func runMCPProxy(cmd *cobra.Command, serverName string) error {
 	}
 	defer proxy.Close()
 
-	// Discover tools from the external server
-	tools, err := proxy.DiscoverTools(ctx)
+	// Discover tools from the external server, reusing cached definitions
+	refreshTools, _ := cmd.Flags().GetBool("refresh-tools")
+	var toolCache *mcpToolCache
+	if dir, err := defaultMCPToolCacheDir(); err != nil {
+		slog.Warn("MCP tool cache disabled", "error", err)
+	} else {
+		toolCache = newMCPToolCache(dir, defaultMCPToolCacheTTL)
+	}
+	tools, err := discoverProxyTools(ctx, proxy, mcpConfig, toolCache, refreshTools)
 	if err != nil {
 		return fmt.Errorf("failed to discover tools from external server: %w", err)
 	}
 
-	fmt.Fprintf(os.Stderr, "Discovered %d tools from external MCP server\n", len(tools))
+	slog.Info("discovered tools from external MCP server", "server", serverName, "tools", len(tools))
 
 	// Create a Ship MCP server with the discovered tools
-	shipServer := ship.NewServer(fmt.Sprintf("ship-proxy-%s", serverName), "1.0.0")
-	for _, tool := range tools {
-		shipServer.AddTool(tool)
+	mcpServer, err := newMCPProxyServer(serverName, tools)
+	if err != nil {
+		return err
 	}
-	mcpServer := shipServer.Build()
 	defer mcpServer.Close()
+	serverInstance := mcpServer.GetMCPGoServer()
 
-	// Start the server
-	if err := mcpServer.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start MCP server: %w", err)
+	// Restart the external server if it crashes, keeping this side serving
+	supervisor := newProxySupervisor(proxy, mcpConfig)
+	supervisor.onRestart = func(definitions []ship.ProxyToolDefinition) {
+		if toolCache != nil {
+			key := mcpToolCacheKey(mcpConfig, proxy.ServerVersion())
+			if err := toolCache.put(key, mcpConfig.Name, proxy.ServerVersion(), definitions); err != nil {
+				slog.Warn("failed to cache tools of external MCP server", "server", serverName, "error", err)
+			}
+		}
+		for _, tool := range proxy.ProxyTools(definitions) {
+			if _, err := mcpServer.GetRegistry().GetTool(tool.Name()); err == nil {
+				continue
+			}
+			if err := mcpServer.AddTool(tool); err != nil {
+				slog.Warn("failed to add tool of restarted external MCP server", "server", serverName, "tool", tool.Name(), "error", err)
+			}
+		}
 	}
+	supervisorCtx, stopSupervisor := context.WithCancel(ctx)
+	defer stopSupervisor()
+	go supervisor.run(supervisorCtx)
 
-	// Get the mcp-go server instance
-	serverInstance := mcpServer.GetMCPGoServer()
-	if serverInstance == nil {
-		return fmt.Errorf("failed to get MCP server instance")
+	// Start the proxy server
+	if addr, useHTTP := mcpHTTPAddr(cmd); useHTTP {
+		slog.Info("starting MCP proxy", "server", serverName, "transport", "http", "url", "http://"+addr+mcpHTTPEndpoint,
+			"sse_url", "http://"+addr+mcpSSEEndpoint, "tools", mcpServer.GetRegistry().ListTools())
+		return listenAndServeMCPHTTP(serverInstance, addr)
 	}
 
-	// Start the proxy server
-	if useStdio || port == 0 {
-		fmt.Fprintf(os.Stderr, "Starting Ship proxy for %s on stdio...\n", serverName)
-		fmt.Fprintf(os.Stderr, "Available tools: %v\n", mcpServer.GetRegistry().ListTools())
-		return server.ServeStdio(serverInstance)
-	} else {
-		return fmt.Errorf("HTTP server not implemented in this version, use --stdio")
+	slog.Info("starting MCP proxy", "server", serverName, "transport", "stdio", "tools", mcpServer.GetRegistry().ListTools())
+	return server.ServeStdio(serverInstance)
+}
+
+// newMCPProxyServer builds and starts the Ship MCP server that serves the
+// tools of an external server. Proxied tools run no containers, so no Dagger
+// engine is started: the proxy can bridge a stdio server to HTTP on its own.
+func newMCPProxyServer(serverName string, tools []ship.Tool) (*ship.MCPServer, error) {
+	mcpServer := ship.NewServer(fmt.Sprintf("ship-proxy-%s", serverName), "1.0.0").
+		AddTools(tools...).
+		Build()
+
+	if err := mcpServer.StartWithoutEngine(); err != nil {
+		return nil, fmt.Errorf("failed to start MCP server: %w", err)
 	}
+	return mcpServer, nil
 }
 
 // validateAndMergeVariables validates required variables and merges user-provided vars with config
@@ -587,16 +691,15 @@ func validateAndMergeVariables(config *ship.MCPServerConfig, userVars map[string
 		return nil
 	}
 
-	// Check for required variables
+	// Check for required variables; an empty or blank value, such as from
+	// --var TOKEN=, counts as missing
 	for _, variable := range config.Variables {
 		if variable.Required {
-			// Check if provided by user
-			if _, exists := userVars[variable.Name]; !exists {
-				// Check if has default value
-				if variable.Default == "" {
-					return fmt.Errorf("required variable %s is missing (use --var %s=value)",
-						variable.Name, variable.Name)
-				}
+			value, exists := userVars[variable.Name]
+			// Check if provided by user, or has default value
+			if (exists && strings.TrimSpace(value) == "") || (!exists && variable.Default == "") {
+				return fmt.Errorf("required variable %s is missing or empty (use --var %s=value or --var-file)",
+					variable.Name, variable.Name)
 			}
 		}
 	}
@@ -621,6 +724,16 @@ func validateAndMergeVariables(config *ship.MCPServerConfig, userVars map[string
 	return nil
 }
 
+// redactSecretVariables keeps the values of an external server's secret
+// variables out of diagnostics and the execution log
+func redactSecretVariables(config ship.MCPServerConfig) {
+	for _, variable := range config.Variables {
+		if variable.Secret {
+			logger.RedactSecrets(config.Env[variable.Name])
+		}
+	}
+}
+
 // setContainerEnvironmentVars sets environment variables for containerized tools
 func setContainerEnvironmentVars(envVars map[string]string) {
 	for key, value := range envVars {
@@ -629,13 +742,23 @@ func setContainerEnvironmentVars(envVars map[string]string) {
 }
 
 // setImageTagOverrides sets environment variables for container image tag overrides
-func setImageTagOverrides(imageTags map[string]string) {
+func setImageTagOverrides(imageTags map[string]string) error {
+	for toolName, imageTag := range imageTags {
+		if toolName == "" {
+			return fmt.Errorf("invalid --image-tag %q: missing tool name", "="+imageTag)
+		}
+		if err := modules.ValidateImageOverride(imageTag); err != nil {
+			return fmt.Errorf("invalid --image-tag for %s: %w", toolName, err)
+		}
+	}
+
 	for toolName, imageTag := range imageTags {
 		// Convert tool name to uppercase and set as environment variable
-		// Format: SHIP_IMAGE_TAG_<TOOLNAME>=<image:tag>
-		envKey := fmt.Sprintf("SHIP_IMAGE_TAG_%s", strings.ToUpper(toolName))
+		// Format: SHIP_IMAGE_TAG_<TOOLNAME>=<image:tag> or <image@sha256:digest>
+		envKey := imageTagEnvPrefix + strings.ToUpper(toolName)
 		os.Setenv(envKey, imageTag)
 	}
+	return nil
 }
 
 // showVariableHelp displays information about available variables for a tool
@@ -680,6 +803,12 @@ func executeShipCommandWithStabilityEnhancements(args []string) (*mcp.CallToolRe
 	result, err := executeShipCommandWithContext(ctx, args)
 	
 	elapsed := time.Since(startTime)
+
+	slog.Debug("ship command executed",
+		"command", strings.Join(args, " "),
+		"duration_ms", elapsed.Milliseconds(),
+		"error", err != nil || (result != nil && result.IsError),
+	)
 	
 	// Enhanced logging for debugging transport issues
 	if globalExecutionContext != nil && globalExecutionContext.ExecutionLog != "" {
@@ -699,7 +828,7 @@ func executeShipCommandWithStabilityEnhancements(args []string) (*mcp.CallToolRe
 		
 		// Write to execution log with error handling
 		if logFile, logErr := os.OpenFile(globalExecutionContext.ExecutionLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); logErr == nil {
-			logFile.WriteString(logEntry)
+			logFile.WriteString(logger.Redact(logEntry))
 			logFile.Close()
 		}
 	}
@@ -789,8 +918,8 @@ func serveStdioWithStability(s *server.MCPServer) error {
 		for {
 			select {
 			case <-ticker.C:
-				// Write keepalive message to stderr (not interfering with stdio protocol)
-				fmt.Fprintf(os.Stderr, "[MCP-KEEPALIVE] Server running, PID: %d\n", os.Getpid())
+				// Log keepalive to stderr (not interfering with stdio protocol)
+				slog.Debug("MCP server running", "pid", os.Getpid())
 			case <-ctx.Done():
 				return
 			}
@@ -800,7 +929,7 @@ func serveStdioWithStability(s *server.MCPServer) error {
 	// Enhanced error handling for stdio transport
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Fprintf(os.Stderr, "[MCP-PANIC] Server panic recovered: %v\n", r)
+			slog.Error("MCP server panic recovered", "panic", r)
 		}
 	}()
 	
@@ -809,9 +938,107 @@ func serveStdioWithStability(s *server.MCPServer) error {
 	
 	// Enhanced error reporting
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "[MCP-ERROR] Server error: %v\n", err)
+		slog.Error("MCP server error", "error", err)
 	}
 	
 	return err
 }
 
+const (
+	// mcpHTTPEndpoint is the path the streamable HTTP transport is served on
+	mcpHTTPEndpoint = "/mcp"
+
+	// mcpSSEEndpoint and mcpSSEMessageEndpoint serve the legacy HTTP+SSE
+	// transport alongside streamable HTTP, for clients that only speak SSE
+	mcpSSEEndpoint        = "/sse"
+	mcpSSEMessageEndpoint = "/message"
+
+	// mcpHTTPDefaultPort is used when --http is given without --port
+	mcpHTTPDefaultPort = 8080
+
+	mcpHTTPShutdownTimeout = 10 * time.Second
+)
+
+// mcpHTTPAddr reports whether the HTTP transport was requested and the address
+// to listen on. Stdio stays the default: HTTP is used only when --http is set,
+// or when a non-zero --port is given without --stdio being explicitly set.
+func mcpHTTPAddr(cmd *cobra.Command) (string, bool) {
+	useHTTP, _ := cmd.Flags().GetBool("http")
+	useStdio, _ := cmd.Flags().GetBool("stdio")
+	port, _ := cmd.Flags().GetInt("port")
+	host, _ := cmd.Flags().GetString("host")
+
+	if !useHTTP {
+		if port == 0 || (cmd.Flags().Changed("stdio") && useStdio) {
+			return "", false
+		}
+	}
+
+	if port == 0 {
+		port = mcpHTTPDefaultPort
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(port)), true
+}
+
+// listenAndServeMCPHTTP serves s over streamable HTTP on addr until the process
+// receives SIGINT or SIGTERM
+func listenAndServeMCPHTTP(s *server.MCPServer, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return serveMCPHTTP(ctx, s, ln)
+}
+
+// serveMCPHTTP serves s over streamable HTTP, and over HTTP+SSE, on ln and
+// shuts down gracefully once ctx is cancelled
+func serveMCPHTTP(ctx context.Context, s *server.MCPServer, ln net.Listener) error {
+	mux := http.NewServeMux()
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// The SSE server owns httpServer so its shutdown also ends open SSE streams
+	sseServer := server.NewSSEServer(s,
+		server.WithHTTPServer(httpServer),
+		server.WithSSEEndpoint(mcpSSEEndpoint),
+		server.WithMessageEndpoint(mcpSSEMessageEndpoint),
+	)
+
+	mux.Handle(mcpHTTPEndpoint, server.NewStreamableHTTPServer(s, server.WithEndpointPath(mcpHTTPEndpoint)))
+	mux.Handle(mcpSSEEndpoint, sseServer.SSEHandler())
+	mux.Handle(mcpSSEMessageEndpoint, sseServer.MessageHandler())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("MCP HTTP server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), mcpHTTPShutdownTimeout)
+	defer cancel()
+
+	if err := sseServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down MCP HTTP server: %w", err)
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("MCP HTTP server failed: %w", err)
+	}
+
+	return nil
+}
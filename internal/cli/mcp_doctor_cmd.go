@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var mcpDoctorCmd = &cobra.Command{
+	Use:   "doctor [tool|all]",
+	Short: "Check that MCP tool containers can start",
+	Long: `Start each MCP tool's container and run its --version to confirm the
+image pulls and the tool runs before relying on the MCP server.
+
+Checks run concurrently and each tool is reported as OK or FAIL with the
+error. The command fails if any check fails.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMCPDoctor,
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpDoctorCmd)
+
+	mcpDoctorCmd.Flags().Duration("timeout", 5*time.Minute, "Timeout for each tool check, including the image pull")
+	mcpDoctorCmd.Flags().Int("workers", toolVersionWorkers, "Number of tool containers to check at once")
+	mcpDoctorCmd.Flags().Bool("json", false, "Print results as JSON")
+}
+
+// versionedModule is a tool module that can run its tool's --version
+type versionedModule interface {
+	GetVersion(ctx context.Context) (string, error)
+}
+
+// mcpDoctorModules builds the module used to check each MCP tool, keyed by
+// the tool name used with `ship mcp`
+var mcpDoctorModules = map[string]func(engine *dagger.Engine) versionedModule{
+	"actionlint":       func(e *dagger.Engine) versionedModule { return modules.NewActionlintModule(e.GetClient()) },
+	"aws-iam-rotation": func(e *dagger.Engine) versionedModule { return modules.NewAWSIAMRotationModule(e.GetClient()) },
+	"aws-pricing":      func(e *dagger.Engine) versionedModule { return modules.NewAWSPricingModule(e.GetClient()) },
+	"buildx":           func(e *dagger.Engine) versionedModule { return modules.NewBuildXModule(e.GetClient()) },
+	"cert-manager":     func(e *dagger.Engine) versionedModule { return modules.NewCertManagerModule(e.GetClient()) },
+	"cfn-nag":          func(e *dagger.Engine) versionedModule { return modules.NewCfnNagModule(e.GetClient()) },
+	"checkov":          func(e *dagger.Engine) versionedModule { return modules.NewCheckovModule(e.GetClient()) },
+	"cloudquery":       func(e *dagger.Engine) versionedModule { return modules.NewCloudQueryModule(e.GetClient()) },
+	"cloudsplaining":   func(e *dagger.Engine) versionedModule { return modules.NewCloudsplainingModule(e.GetClient()) },
+	"conftest":         func(e *dagger.Engine) versionedModule { return modules.NewConftestModule(e.GetClient()) },
+	"cosign":           func(e *dagger.Engine) versionedModule { return modules.NewCosignModule(e.GetClient()) },
+	"custodian":        func(e *dagger.Engine) versionedModule { return modules.NewCustodianModule(e.GetClient()) },
+	"dockle":           func(e *dagger.Engine) versionedModule { return modules.NewDockleModule(e.GetClient()) },
+	"falco":            func(e *dagger.Engine) versionedModule { return modules.NewFalcoModule(e.GetClient()) },
+	"github-admin":     func(e *dagger.Engine) versionedModule { return modules.NewGitHubAdminModule(e.GetClient()) },
+	"goldilocks":       func(e *dagger.Engine) versionedModule { return modules.NewGoldilocksModule(e.GetClient()) },
+	"hadolint":         func(e *dagger.Engine) versionedModule { return modules.NewHadolintModule(e.GetClient()) },
+	"inframap":         func(e *dagger.Engine) versionedModule { return modules.NewInfraMapModule(e.GetClient()) },
+	"infrascan":        func(e *dagger.Engine) versionedModule { return modules.NewInfraScanModule(e.GetClient()) },
+	"kube-bench":       func(e *dagger.Engine) versionedModule { return modules.NewKubeBenchModule(e.GetClient()) },
+	"kube-hunter":      func(e *dagger.Engine) versionedModule { return modules.NewKubeHunterModule(e.GetClient()) },
+	"kubescape":        func(e *dagger.Engine) versionedModule { return modules.NewKubescapeModule(e.GetClient()) },
+	"kuttl":            func(e *dagger.Engine) versionedModule { return modules.NewKuttlModule(e.GetClient()) },
+	"kyverno":          func(e *dagger.Engine) versionedModule { return modules.NewKyvernoModule(e.GetClient()) },
+	"license-detector": func(e *dagger.Engine) versionedModule { return modules.NewLicenseDetectorModule(e.GetClient()) },
+	"litmus":           func(e *dagger.Engine) versionedModule { return modules.NewLitmusModule(e.GetClient()) },
+	"nmap":             func(e *dagger.Engine) versionedModule { return modules.NewNmapModule(e.GetClient()) },
+	"nuclei":           func(e *dagger.Engine) versionedModule { return modules.NewNucleiModule(e.GetClient()) },
+	"opencode":         func(e *dagger.Engine) versionedModule { return modules.NewOpenCodeModule(e.GetClient()) },
+	"ossf-scorecard":   func(e *dagger.Engine) versionedModule { return modules.NewOSSFScorecardModule(e.GetClient()) },
+	"packer":           func(e *dagger.Engine) versionedModule { return modules.NewPackerModule(e.GetClient()) },
+	"powerpipe":        func(e *dagger.Engine) versionedModule { return modules.NewPowerpipeModule(e.GetClient()) },
+	"scout-suite":      func(e *dagger.Engine) versionedModule { return modules.NewScoutSuiteModule(e.GetClient()) },
+	"semgrep":          func(e *dagger.Engine) versionedModule { return modules.NewSemgrepModule(e.GetClient()) },
+	"sops":             func(e *dagger.Engine) versionedModule { return modules.NewSOPSModule(e.GetClient()) },
+	"steampipe":        func(e *dagger.Engine) versionedModule { return modules.NewSteampipeModule(e.GetClient()) },
+	"terraformer":      func(e *dagger.Engine) versionedModule { return modules.NewTerraformerModule(e.GetClient()) },
+	"terrascan":        func(e *dagger.Engine) versionedModule { return modules.NewTerrascanModule(e.GetClient()) },
+	"tfsec":            func(e *dagger.Engine) versionedModule { return modules.NewTfsecModule(e.GetClient()) },
+	"trivy":            func(e *dagger.Engine) versionedModule { return modules.NewTrivyModule(e.GetClient()) },
+	"trufflehog":       func(e *dagger.Engine) versionedModule { return modules.NewTruffleHogModule(e.GetClient()) },
+	"velero":           func(e *dagger.Engine) versionedModule { return modules.NewVeleroModule(e.GetClient()) },
+	"zap":              func(e *dagger.Engine) versionedModule { return modules.NewZapModule(e.GetClient()) },
+}
+
+// mcpDoctorResult is the container check outcome for one tool
+type mcpDoctorResult struct {
+	Tool    string `json:"tool"`
+	Passed  bool   `json:"passed"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func runMCPDoctor(cmd *cobra.Command, args []string) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	workers, _ := cmd.Flags().GetInt("workers")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	tools, err := mcpDoctorTools(args)
+	if err != nil {
+		return err
+	}
+
+	telemetry.TrackCLICommand("mcp-doctor", "", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	results := runMCPDoctorChecks(ctx, tools, engineVersionCheck(engine), workers, timeout)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode doctor results: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatMCPDoctorReport(results))
+	}
+
+	for _, result := range results {
+		if !result.Passed {
+			return fmt.Errorf("one or more MCP tool checks failed")
+		}
+	}
+	return nil
+}
+
+// mcpDoctorTools resolves the tool argument to the tools to check. No argument
+// or "all" checks every tool.
+func mcpDoctorTools(args []string) ([]string, error) {
+	if len(args) == 0 || args[0] == "all" {
+		tools := make([]string, 0, len(mcpDoctorModules))
+		for tool := range mcpDoctorModules {
+			tools = append(tools, tool)
+		}
+		sort.Strings(tools)
+		return tools, nil
+	}
+
+	if _, ok := mcpDoctorModules[args[0]]; !ok {
+		return nil, fmt.Errorf("unknown tool %q: run `ship mcp doctor all` to check every supported tool", args[0])
+	}
+	return []string{args[0]}, nil
+}
+
+// engineVersionCheck runs a tool's --version in its container on engine
+func engineVersionCheck(engine *dagger.Engine) func(ctx context.Context, tool string) (string, error) {
+	return func(ctx context.Context, tool string) (string, error) {
+		return mcpDoctorModules[tool](engine).GetVersion(ctx)
+	}
+}
+
+// runMCPDoctorChecks runs check for every tool with a bounded worker pool and
+// its own timeout. Results are returned in the same order as tools.
+func runMCPDoctorChecks(ctx context.Context, tools []string, check func(ctx context.Context, tool string) (string, error), workers int, timeout time.Duration) []mcpDoctorResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]mcpDoctorResult, len(tools))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tools); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				checkCtx, cancel := context.WithTimeout(ctx, timeout)
+				version, err := check(checkCtx, tools[i])
+				cancel()
+
+				results[i] = mcpDoctorResult{Tool: tools[i], Passed: err == nil, Version: strings.TrimSpace(version)}
+				if err != nil {
+					results[i].Version = ""
+					results[i].Error = err.Error()
+				}
+			}
+		}()
+	}
+
+	for i := range tools {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// formatMCPDoctorReport renders the per-tool results and a pass count
+func formatMCPDoctorReport(results []mcpDoctorResult) string {
+	var b strings.Builder
+	b.WriteString("MCP tool container checks\n\n")
+
+	passed := 0
+	for _, result := range results {
+		detail := result.Version
+		status := "OK"
+		if result.Passed {
+			passed++
+		} else {
+			status = "FAIL"
+			detail = result.Error
+		}
+
+		fmt.Fprintf(&b, "[%s] %s\n", status, result.Tool)
+		if detail != "" {
+			for _, line := range strings.Split(detail, "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "\nResult: %d/%d tools passed\n", passed, len(results))
+	return b.String()
+}
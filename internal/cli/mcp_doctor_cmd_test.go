@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVersionModule stands in for a tool module's container
+type fakeVersionModule struct {
+	version string
+	err     error
+	delay   time.Duration
+}
+
+func (m fakeVersionModule) GetVersion(ctx context.Context) (string, error) {
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	return m.version, m.err
+}
+
+func stubMCPDoctorModules(t *testing.T, stubs map[string]versionedModule) {
+	t.Helper()
+	original := mcpDoctorModules
+	t.Cleanup(func() { mcpDoctorModules = original })
+
+	mcpDoctorModules = make(map[string]func(engine *dagger.Engine) versionedModule)
+	for tool, module := range stubs {
+		module := module
+		mcpDoctorModules[tool] = func(*dagger.Engine) versionedModule { return module }
+	}
+}
+
+func TestRunMCPDoctorChecksReportsPassAndFail(t *testing.T) {
+	stubMCPDoctorModules(t, map[string]versionedModule{
+		"trivy":   fakeVersionModule{version: "Version: 0.58.1\n"},
+		"checkov": fakeVersionModule{err: errors.New("pull access denied for bridgecrew/checkov")},
+	})
+
+	tools, err := mcpDoctorTools([]string{"all"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkov", "trivy"}, tools)
+
+	results := runMCPDoctorChecks(context.Background(), tools, engineVersionCheck(nil), 2, time.Second)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, mcpDoctorResult{Tool: "checkov", Error: "pull access denied for bridgecrew/checkov"}, results[0])
+	assert.Equal(t, mcpDoctorResult{Tool: "trivy", Passed: true, Version: "Version: 0.58.1"}, results[1])
+
+	report := formatMCPDoctorReport(results)
+	assert.Contains(t, report, "[FAIL] checkov\n    pull access denied for bridgecrew/checkov\n")
+	assert.Contains(t, report, "[OK] trivy\n    Version: 0.58.1\n")
+	assert.Contains(t, report, "Result: 1/2 tools passed")
+}
+
+func TestRunMCPDoctorChecksBoundsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	check := func(ctx context.Context, tool string) (string, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return tool + " 1.0.0", nil
+	}
+
+	tools := []string{"a", "b", "c", "d", "e", "f"}
+	results := runMCPDoctorChecks(context.Background(), tools, check, 3, time.Second)
+
+	require.Len(t, results, len(tools))
+	for i, tool := range tools {
+		assert.Equal(t, tool, results[i].Tool, "results keep input order")
+		assert.True(t, results[i].Passed)
+	}
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1), "checks should run concurrently")
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3), "checks should not exceed the worker bound")
+}
+
+func TestRunMCPDoctorChecksAppliesTimeout(t *testing.T) {
+	stubMCPDoctorModules(t, map[string]versionedModule{
+		"zap": fakeVersionModule{version: "2.15.0", delay: time.Minute},
+	})
+
+	results := runMCPDoctorChecks(context.Background(), []string{"zap"}, engineVersionCheck(nil), 1, 10*time.Millisecond)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Error, context.DeadlineExceeded.Error())
+}
+
+func TestMCPDoctorTools(t *testing.T) {
+	stubMCPDoctorModules(t, map[string]versionedModule{
+		"trivy": fakeVersionModule{},
+		"tfsec": fakeVersionModule{},
+	})
+
+	tools, err := mcpDoctorTools(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tfsec", "trivy"}, tools)
+
+	tools, err = mcpDoctorTools([]string{"trivy"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trivy"}, tools)
+
+	_, err = mcpDoctorTools([]string{"nope"})
+	assert.ErrorContains(t, err, `unknown tool "nope"`)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+)
+
+// expandServerArgs expands ${VAR} references in an external server's command
+// and args, so a server can be configured with e.g. --profile ${AWS_PROFILE}.
+// Variables come from the server's merged variables, then the environment.
+// It runs after validateAndMergeVariables and before the server is started.
+func expandServerArgs(config *ship.MCPServerConfig) error {
+	lookup := func(name string) (string, bool) {
+		if value, ok := config.Env[name]; ok {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	}
+
+	command, err := expandVars(config.Command, lookup)
+	if err != nil {
+		return fmt.Errorf("invalid command of MCP server %s: %w", config.Name, err)
+	}
+
+	args := make([]string, len(config.Args))
+	for i, arg := range config.Args {
+		if args[i], err = expandVars(arg, lookup); err != nil {
+			return fmt.Errorf("invalid argument %q of MCP server %s: %w", arg, config.Name, err)
+		}
+	}
+
+	config.Command = command
+	config.Args = args
+	return nil
+}
+
+// expandVars replaces each ${VAR} in s with its value. $$ is a literal $, so
+// $${VAR} is left as ${VAR}; any other $ is kept as is. An undefined variable
+// is an error rather than silently becoming empty.
+func expandVars(s string, lookup func(name string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated ${ at offset %d", i)
+			}
+			name := s[i+2 : i+2+end]
+			if !envKeyPattern.MatchString(name) {
+				return "", fmt.Errorf("invalid variable name %q", name)
+			}
+			value, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("variable %s is not set (use --var %s=value)", name, name)
+			}
+			b.WriteString(value)
+			i += 2 + end
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
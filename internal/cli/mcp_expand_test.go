@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandServerArgs(t *testing.T) {
+	t.Setenv("SHIP_TEST_AWS_REGION", "eu-west-1")
+
+	config := ship.MCPServerConfig{
+		Name:    "aws-core",
+		Command: "uvx",
+		Args:    []string{"awslabs.core-mcp-server@latest", "--profile", "${AWS_PROFILE}", "--region=${SHIP_TEST_AWS_REGION}", "--name", "${AWS_PROFILE}-${SHIP_TEST_AWS_REGION}"},
+		Env:     map[string]string{"AWS_PROFILE": "prod"},
+	}
+	require.NoError(t, expandServerArgs(&config))
+	assert.Equal(t, "uvx", config.Command)
+	assert.Equal(t, []string{"awslabs.core-mcp-server@latest", "--profile", "prod", "--region=eu-west-1", "--name", "prod-eu-west-1"}, config.Args)
+}
+
+func TestExpandServerArgs_MergedVariablesWinOverEnvironment(t *testing.T) {
+	t.Setenv("AWS_PROFILE", "from-environment")
+
+	config := ship.MCPServerConfig{
+		Name:      "aws-core",
+		Args:      []string{"--profile", "${AWS_PROFILE}"},
+		Variables: []ship.Variable{{Name: "AWS_PROFILE"}},
+	}
+	require.NoError(t, validateAndMergeVariables(&config, map[string]string{"AWS_PROFILE": "from-var"}))
+	require.NoError(t, expandServerArgs(&config))
+	assert.Equal(t, []string{"--profile", "from-var"}, config.Args)
+}
+
+func TestExpandServerArgs_UndefinedVariable(t *testing.T) {
+	config := ship.MCPServerConfig{Name: "aws-core", Args: []string{"--profile", "${SHIP_TEST_UNSET_PROFILE}"}}
+	err := expandServerArgs(&config)
+	assert.EqualError(t, err, `invalid argument "${SHIP_TEST_UNSET_PROFILE}" of MCP server aws-core: variable SHIP_TEST_UNSET_PROFILE is not set (use --var SHIP_TEST_UNSET_PROFILE=value)`)
+	assert.Equal(t, []string{"--profile", "${SHIP_TEST_UNSET_PROFILE}"}, config.Args, "args are unchanged on error")
+}
+
+func TestExpandVars(t *testing.T) {
+	vars := map[string]string{"PROFILE": "prod", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{in: "--profile", want: "--profile"},
+		{in: "${PROFILE}", want: "prod"},
+		{in: "x${EMPTY}y", want: "xy"},
+		{in: "$${PROFILE}", want: "${PROFILE}"},
+		{in: "cost$$", want: "cost$"},
+		{in: "$PROFILE", want: "$PROFILE"},
+		{in: "price: $5", want: "price: $5"},
+		{in: "trailing $", want: "trailing $"},
+		{in: "${PROFILE", wantErr: "unterminated ${ at offset 0"},
+		{in: "${}", wantErr: `invalid variable name ""`},
+		{in: "${AWS-PROFILE}", wantErr: `invalid variable name "AWS-PROFILE"`},
+		{in: "${MISSING}", wantErr: "variable MISSING is not set (use --var MISSING=value)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := expandVars(tt.in, lookup)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mcpHealthURI is the resource clients read to check the server is alive
+const mcpHealthURI = "ship://health"
+
+// mcpHealth tracks the server state reported by the ship://health resource
+type mcpHealth struct {
+	startedAt time.Time
+
+	mu            sync.Mutex
+	lastCommandAt time.Time
+}
+
+// mcpHealthStatus is the JSON document served as ship://health
+type mcpHealthStatus struct {
+	Status        string     `json:"status"`
+	StartedAt     time.Time  `json:"started_at"`
+	UptimeSeconds int64      `json:"uptime_seconds"`
+	PID           int        `json:"pid"`
+	ToolCount     int        `json:"tool_count"`
+	LastCommandAt *time.Time `json:"last_command_at"`
+}
+
+func newMCPHealth() *mcpHealth {
+	return &mcpHealth{startedAt: time.Now()}
+}
+
+// middleware records when the most recent tool call started
+func (h *mcpHealth) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		h.mu.Lock()
+		h.lastCommandAt = time.Now()
+		h.mu.Unlock()
+
+		return next(ctx, request)
+	}
+}
+
+// status reports the server's health with the tool count of s
+func (h *mcpHealth) status(s *server.MCPServer) mcpHealthStatus {
+	h.mu.Lock()
+	lastCommandAt := h.lastCommandAt
+	h.mu.Unlock()
+
+	status := mcpHealthStatus{
+		Status:        "ok",
+		StartedAt:     h.startedAt.UTC(),
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+		PID:           os.Getpid(),
+		ToolCount:     shipMcp.RegisteredToolCount(s),
+	}
+	if !lastCommandAt.IsZero() {
+		utc := lastCommandAt.UTC()
+		status.LastCommandAt = &utc
+	}
+	return status
+}
+
+// addHealthResource serves the server's status as JSON at ship://health
+func addHealthResource(s *server.MCPServer, health *mcpHealth) {
+	resource := mcp.NewResource(mcpHealthURI,
+		"Ship MCP Server Health",
+		mcp.WithResourceDescription("Server uptime, PID, registered tool count and when the last tool call started"),
+		mcp.WithMIMEType("application/json"),
+	)
+
+	s.AddResource(resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		data, err := json.Marshal(health.status(s))
+		if err != nil {
+			return nil, err
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      mcpHealthURI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		}, nil
+	})
+}
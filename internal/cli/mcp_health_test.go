@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readHealth(t *testing.T, s *server.MCPServer) map[string]any {
+	t.Helper()
+	_, text := readResource(t, s, mcpHealthURI)
+	var status map[string]any
+	require.NoError(t, json.Unmarshal([]byte(text), &status))
+	return status
+}
+
+func TestHealthResource(t *testing.T) {
+	health := newMCPHealth()
+	health.startedAt = time.Now().Add(-90 * time.Second)
+
+	s := server.NewMCPServer("ship-test", "1.0.0", server.WithToolHandlerMiddleware(health.middleware))
+	for _, name := range []string{"trivy_scan_image", "checkov_scan_directory"} {
+		s.AddTool(mcp.NewTool(name), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	addHealthResource(s, health)
+
+	status := readHealth(t, s)
+	assert.Equal(t, "ok", status["status"])
+	assert.Equal(t, float64(os.Getpid()), status["pid"])
+	assert.Equal(t, float64(2), status["tool_count"])
+	assert.GreaterOrEqual(t, status["uptime_seconds"], float64(90))
+	assert.NotEmpty(t, status["started_at"])
+	assert.Contains(t, status, "last_command_at")
+	assert.Nil(t, status["last_command_at"], "no tool has been called yet")
+
+	before := time.Now().UTC().Add(-time.Second)
+	call := []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"trivy_scan_image","arguments":{}}}`)
+	_, ok := s.HandleMessage(context.Background(), call).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	status = readHealth(t, s)
+	lastCommandAt, err := time.Parse(time.RFC3339Nano, status["last_command_at"].(string))
+	require.NoError(t, err)
+	assert.True(t, lastCommandAt.After(before), "last_command_at records the tool call")
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeMCPHTTPListsTools(t *testing.T) {
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	s.AddTool(mcp.NewTool("echo", mcp.WithDescription("Echo test tool")),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveMCPHTTP(ctx, s, ln)
+	}()
+
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer reqCancel()
+
+	mcpClient, err := client.NewStreamableHttpClient("http://" + ln.Addr().String() + mcpHTTPEndpoint)
+	require.NoError(t, err)
+
+	require.NoError(t, mcpClient.Start(reqCtx))
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "ship-test-client", Version: "1.0.0"}
+	_, err = mcpClient.Initialize(reqCtx, initRequest)
+	require.NoError(t, err)
+
+	tools, err := mcpClient.ListTools(reqCtx, mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, tools.Tools, 1)
+	assert.Equal(t, "echo", tools.Tools[0].Name)
+
+	require.NoError(t, mcpClient.Close())
+
+	cancel()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(mcpHTTPShutdownTimeout + time.Second):
+		t.Fatal("MCP HTTP server did not shut down")
+	}
+}
+
+func TestMCPHTTPAddr(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "mcp"}
+		cmd.Flags().Int("port", 0, "")
+		cmd.Flags().String("host", "localhost", "")
+		cmd.Flags().Bool("stdio", true, "")
+		cmd.Flags().Bool("http", false, "")
+		return cmd
+	}
+
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		wantAddr string
+		wantHTTP bool
+	}{
+		{name: "stdio by default", flags: map[string]string{}},
+		{name: "port selects http", flags: map[string]string{"port": "9000"}, wantAddr: "localhost:9000", wantHTTP: true},
+		{name: "explicit stdio wins over port", flags: map[string]string{"port": "9000", "stdio": "true"}},
+		{name: "http flag uses default port", flags: map[string]string{"http": "true", "host": "0.0.0.0"}, wantAddr: "0.0.0.0:8080", wantHTTP: true},
+		{name: "http flag with port", flags: map[string]string{"http": "true", "port": "7000"}, wantAddr: "localhost:7000", wantHTTP: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCmd()
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			addr, useHTTP := mcpHTTPAddr(cmd)
+			assert.Equal(t, tt.wantHTTP, useHTTP)
+			assert.Equal(t, tt.wantAddr, addr)
+		})
+	}
+}
+
+func TestMCPProxyBridgesStdioServerOverHTTP(t *testing.T) {
+	config := fakeMCPServerConfig(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	proxy := ship.NewMCPProxy(config)
+	require.NoError(t, proxy.Connect(ctx))
+	defer proxy.Close()
+
+	tools, err := proxy.DiscoverTools(ctx)
+	require.NoError(t, err)
+
+	mcpServer, err := newMCPProxyServer(config.Name, tools)
+	require.NoError(t, err)
+	defer mcpServer.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveMCPHTTP(ctx, mcpServer.GetMCPGoServer(), ln)
+	}()
+
+	baseURL := "http://" + ln.Addr().String()
+	clients := map[string]func() (*client.Client, error){
+		"streamable http": func() (*client.Client, error) { return client.NewStreamableHttpClient(baseURL + mcpHTTPEndpoint) },
+		"sse":             func() (*client.Client, error) { return client.NewSSEMCPClient(baseURL + mcpSSEEndpoint) },
+	}
+
+	for name, newClient := range clients {
+		t.Run(name, func(t *testing.T) {
+			reqCtx, reqCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer reqCancel()
+
+			mcpClient, err := newClient()
+			require.NoError(t, err)
+			defer mcpClient.Close()
+			require.NoError(t, mcpClient.Start(reqCtx))
+
+			initRequest := mcp.InitializeRequest{}
+			initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initRequest.Params.ClientInfo = mcp.Implementation{Name: "ship-test-client", Version: "1.0.0"}
+			_, err = mcpClient.Initialize(reqCtx, initRequest)
+			require.NoError(t, err)
+
+			listed, err := mcpClient.ListTools(reqCtx, mcp.ListToolsRequest{})
+			require.NoError(t, err)
+			var names []string
+			for _, tool := range listed.Tools {
+				names = append(names, tool.Name)
+			}
+			assert.ElementsMatch(t, []string{"flaky_echo", "flaky_crash"}, names)
+
+			callRequest := mcp.CallToolRequest{}
+			callRequest.Params.Name = "flaky_echo"
+			callRequest.Params.Arguments = map[string]interface{}{"text": "over " + name}
+			result, err := mcpClient.CallTool(reqCtx, callRequest)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 1)
+			text, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "over "+name, text.Text)
+		})
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(mcpHTTPShutdownTimeout + time.Second):
+		t.Fatal("MCP HTTP bridge did not shut down")
+	}
+}
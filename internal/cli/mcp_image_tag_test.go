@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetImageTagOverrides(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0f", 32)
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "")
+	t.Setenv("SHIP_IMAGE_TAG_CHECKOV", "")
+
+	require.NoError(t, setImageTagOverrides(map[string]string{
+		"trivy":   "aquasec/trivy:0.50.0",
+		"checkov": "bridgecrew/checkov@" + digest,
+	}))
+	assert.Equal(t, "aquasec/trivy:0.50.0", os.Getenv("SHIP_IMAGE_TAG_TRIVY"))
+	assert.Equal(t, "bridgecrew/checkov@"+digest, os.Getenv("SHIP_IMAGE_TAG_CHECKOV"))
+}
+
+func TestSetImageTagOverridesRejectsMalformedDigest(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "")
+
+	err := setImageTagOverrides(map[string]string{
+		"trivy": "aquasec/trivy@sha256:deadbeef",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed digest")
+	assert.Empty(t, os.Getenv("SHIP_IMAGE_TAG_TRIVY"), "nothing is set when validation fails")
+}
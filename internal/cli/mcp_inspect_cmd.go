@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/spf13/cobra"
+)
+
+var mcpInspectCmd = &cobra.Command{
+	Use:   "inspect <external-server>",
+	Short: "List the tools of an external MCP server without serving them",
+	Long: `Connect to an external MCP server, list the tools it exposes and disconnect,
+to see what 'ship mcp <external-server>' would serve before wiring it into an
+agent. Tools are shown with the names Ship proxies them under.
+
+Variables the server requires are given as with 'ship mcp', using --var,
+--var-file or --env-file.
+
+Examples:
+  # List the tools of the filesystem server
+  ship mcp inspect filesystem
+
+  # Inspect a server that needs credentials, as JSON
+  ship mcp inspect brave-search --var BRAVE_API_KEY=... --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMCPInspect,
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpInspectCmd)
+
+	servers := shipMcp.ListExternalMCPServers()
+	sort.Strings(servers)
+	mcpInspectCmd.ValidArgs = servers
+
+	mcpInspectCmd.Flags().StringToString("var", nil, "Variables for the MCP server (e.g., --var API_KEY=value)")
+	mcpInspectCmd.Flags().String("var-file", "", "Load variables for the MCP server from a dotenv or YAML (.yaml, .yml) file (--var values take precedence)")
+	mcpInspectCmd.Flags().String("env-file", "", "Load variables for the MCP server from a dotenv file (--var-file and --var take precedence)")
+	mcpInspectCmd.Flags().Bool("json", false, "Print the tools as JSON")
+}
+
+// mcpInspectTool describes one tool of an external MCP server
+type mcpInspectTool struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Parameters  []ship.Parameter `json:"parameters"`
+}
+
+func runMCPInspect(cmd *cobra.Command, args []string) error {
+	serverName := args[0]
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	mcpConfig, exists := shipMcp.GetExternalMCPServer(serverName)
+	if !exists {
+		return fmt.Errorf("external MCP server '%s' not found in configurations", serverName)
+	}
+
+	envVars, err := mcpServerVars(cmd)
+	if err != nil {
+		return err
+	}
+	if err := validateAndMergeVariables(&mcpConfig, envVars); err != nil {
+		showVariableHelp(serverName)
+		return fmt.Errorf("variable validation failed: %w", err)
+	}
+	redactSecretVariables(mcpConfig)
+	if err := expandServerArgs(&mcpConfig); err != nil {
+		return err
+	}
+
+	tools, err := inspectMCPServer(context.Background(), mcpConfig)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(tools, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode tools: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	writeMCPInspectTable(cmd.OutOrStdout(), tools)
+	return nil
+}
+
+// inspectMCPServer connects to an external server, discovers its tools and
+// disconnects. Tools and their parameters are sorted by name.
+func inspectMCPServer(ctx context.Context, config ship.MCPServerConfig) ([]mcpInspectTool, error) {
+	proxy := ship.NewMCPProxy(config)
+	if err := proxy.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to external MCP server: %w", err)
+	}
+	defer proxy.Close()
+
+	discovered, err := proxy.DiscoverTools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover tools from external server: %w", err)
+	}
+
+	tools := make([]mcpInspectTool, 0, len(discovered))
+	for _, tool := range discovered {
+		params := append([]ship.Parameter{}, tool.Parameters()...)
+		sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+		tools = append(tools, mcpInspectTool{Name: tool.Name(), Description: tool.Description(), Parameters: params})
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools, nil
+}
+
+// writeMCPInspectTable prints one row per tool with its parameters as
+// name:type, required parameters marked with *
+func writeMCPInspectTable(out io.Writer, tools []mcpInspectTool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPARAMETERS\tDESCRIPTION")
+	fmt.Fprintln(w, "----\t----------\t-----------")
+
+	for _, tool := range tools {
+		params := make([]string, 0, len(tool.Parameters))
+		for _, param := range tool.Parameters {
+			required := ""
+			if param.Required {
+				required = "*"
+			}
+			params = append(params, fmt.Sprintf("%s%s:%s", param.Name, required, param.Type))
+		}
+		paramList := strings.Join(params, ", ")
+		if paramList == "" {
+			paramList = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", tool.Name, paramList, firstLine(tool.Description))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\n%d tools\n", len(tools))
+}
+
+// firstLine returns the first line of s
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
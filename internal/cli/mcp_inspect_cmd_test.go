@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServerTools are the tools of the fake server from TestFakeMCPServerProcess
+var fakeServerTools = []mcpInspectTool{
+	{Name: "flaky_crash", Description: "Proxied tool crash from flaky", Parameters: []ship.Parameter{}},
+	{Name: "flaky_echo", Description: "Proxied tool echo from flaky", Parameters: []ship.Parameter{
+		{Name: "text", Type: "string", Description: "Parameter for text"},
+	}},
+}
+
+func TestInspectMCPServer(t *testing.T) {
+	tools, err := inspectMCPServer(context.Background(), fakeMCPServerConfig(t))
+	require.NoError(t, err)
+	assert.Equal(t, fakeServerTools, tools)
+}
+
+func runMCPInspectForTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	config := fakeMCPServerConfig(t)
+	shipMcp.ExternalMCPServers["flaky"] = config
+	t.Cleanup(func() { delete(shipMcp.ExternalMCPServers, "flaky") })
+
+	cmd := &cobra.Command{Use: "inspect", Args: cobra.ExactArgs(1), RunE: runMCPInspect}
+	cmd.Flags().AddFlagSet(mcpInspectCmd.Flags())
+	t.Cleanup(func() { cmd.Flags().Set("json", "false") })
+	require.NoError(t, cmd.ParseFlags(args))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	err := cmd.RunE(cmd, cmd.Flags().Args())
+	return out.String(), err
+}
+
+func TestMCPInspectCmd_Table(t *testing.T) {
+	out, err := runMCPInspectForTest(t, "flaky")
+	require.NoError(t, err)
+	assert.Equal(t, `NAME         PARAMETERS   DESCRIPTION
+----         ----------   -----------
+flaky_crash  -            Proxied tool crash from flaky
+flaky_echo   text:string  Proxied tool echo from flaky
+
+2 tools
+`, out)
+}
+
+func TestMCPInspectCmd_JSON(t *testing.T) {
+	out, err := runMCPInspectForTest(t, "flaky", "--json")
+	require.NoError(t, err)
+
+	var tools []mcpInspectTool
+	require.NoError(t, json.Unmarshal([]byte(out), &tools))
+	assert.Equal(t, fakeServerTools, tools)
+}
+
+func TestMCPInspectCmd_UnknownServer(t *testing.T) {
+	err := runMCPInspect(mcpInspectCmd, []string{"no-such-server"})
+	assert.EqualError(t, err, "external MCP server 'no-such-server' not found in configurations")
+}
+
+func TestWriteMCPInspectTableMarksRequiredParameters(t *testing.T) {
+	var out bytes.Buffer
+	writeMCPInspectTable(&out, []mcpInspectTool{{
+		Name:        "github_create_issue",
+		Description: "Create an issue\nin a repository",
+		Parameters: []ship.Parameter{
+			{Name: "body", Type: "string"},
+			{Name: "title", Type: "string", Required: true},
+		},
+	}})
+	assert.Contains(t, out.String(), "github_create_issue  body:string, title*:string  Create an issue\n")
+}
+
+func TestUserDefinedServerIsProxied(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ship"), 0755))
+	config := fmt.Sprintf(`servers:
+  - name: my-tools
+    command: %q
+    args: ["-test.run=^TestFakeMCPServerProcess$"]
+    env:
+      %s: %q
+`, os.Args[0], fakeMCPServerMarkerEnv, filepath.Join(t.TempDir(), "started"))
+	require.NoError(t, os.WriteFile(filepath.Join(home, shipMcp.UserMCPServersFile), []byte(config), 0644))
+
+	require.True(t, shipMcp.IsExternalMCPServer("my-tools"))
+	assert.Contains(t, shipMcp.ListExternalMCPServers(), "my-tools")
+
+	mcpConfig, ok := shipMcp.GetExternalMCPServer("my-tools")
+	require.True(t, ok)
+	proxy := ship.NewMCPProxy(mcpConfig)
+	require.NoError(t, proxy.Connect(context.Background()))
+	t.Cleanup(func() { proxy.Close() })
+
+	tools, err := proxy.DiscoverTools(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", callProxyTool(t, tools, "my-tools_echo", map[string]interface{}{"text": "hello"}))
+
+	cmd := &cobra.Command{Use: "inspect", RunE: runMCPInspect}
+	cmd.Flags().AddFlagSet(mcpInspectCmd.Flags())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.RunE(cmd, []string{"my-tools"}))
+	assert.Contains(t, out.String(), "my-tools_echo")
+}
+
+func TestMCPHelpListsUserServersWhenShown(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ship"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, shipMcp.UserMCPServersFile), []byte("servers:\n  - name: my-tools\n    command: my-tools-server\n"), 0644))
+
+	assert.NotContains(t, mcpCmd.Long, "my-tools", "the user's servers are not read when ship starts")
+
+	long := mcpCmd.Long
+	t.Cleanup(func() {
+		mcpCmd.Long = long
+		mcpCmd.SetOut(nil)
+	})
+	var out bytes.Buffer
+	mcpCmd.SetOut(&out)
+	mcpCmd.HelpFunc()(mcpCmd, nil)
+	assert.Contains(t, out.String(), "my-tools")
+}
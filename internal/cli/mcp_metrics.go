@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mcpMetrics records per-tool invocation counts and latencies for the MCP server
+// and optionally persists them as JSON after every call
+type mcpMetrics struct {
+	mu    sync.Mutex
+	path  string
+	tools map[string]*mcpToolStats
+}
+
+// mcpLatencyWindow is how many recent calls per tool the latency percentiles
+// are computed over, so a long-running server keeps bounded memory
+const mcpLatencyWindow = 1024
+
+type mcpToolStats struct {
+	count  int
+	errors int
+	// latencies is a ring of the last mcpLatencyWindow call latencies; next
+	// is where the following one is written once it is full
+	latencies []time.Duration
+	next      int
+	max       time.Duration
+}
+
+// addLatency records a call latency, overwriting the oldest once the window is full
+func (s *mcpToolStats) addLatency(elapsed time.Duration) {
+	if elapsed > s.max {
+		s.max = elapsed
+	}
+	if len(s.latencies) < mcpLatencyWindow {
+		s.latencies = append(s.latencies, elapsed)
+		return
+	}
+	s.latencies[s.next] = elapsed
+	s.next = (s.next + 1) % mcpLatencyWindow
+}
+
+// mcpMetricsReport is the JSON document written to --metrics-file
+type mcpMetricsReport struct {
+	UpdatedAt time.Time                        `json:"updated_at"`
+	Tools     map[string]mcpToolMetricsSummary `json:"tools"`
+}
+
+type mcpToolMetricsSummary struct {
+	Count     int               `json:"count"`
+	Errors    int               `json:"errors"`
+	LatencyMs mcpLatencySummary `json:"latency_ms"`
+}
+
+// mcpLatencySummary holds percentiles over the last mcpLatencyWindow calls
+// and the maximum over all calls
+type mcpLatencySummary struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// newMCPMetrics creates a metrics recorder. An empty path keeps metrics in memory only.
+func newMCPMetrics(path string) *mcpMetrics {
+	return &mcpMetrics{
+		path:  path,
+		tools: make(map[string]*mcpToolStats),
+	}
+}
+
+// record adds one tool invocation
+func (m *mcpMetrics) record(tool string, elapsed time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.tools[tool]
+	if !ok {
+		stats = &mcpToolStats{}
+		m.tools[tool] = stats
+	}
+
+	stats.count++
+	if failed {
+		stats.errors++
+	}
+	stats.addLatency(elapsed)
+}
+
+// report summarizes the recorded invocations
+func (m *mcpMetrics) report() mcpMetricsReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := mcpMetricsReport{
+		UpdatedAt: time.Now().UTC(),
+		Tools:     make(map[string]mcpToolMetricsSummary, len(m.tools)),
+	}
+
+	for name, stats := range m.tools {
+		sorted := append([]time.Duration(nil), stats.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		report.Tools[name] = mcpToolMetricsSummary{
+			Count:  stats.count,
+			Errors: stats.errors,
+			LatencyMs: mcpLatencySummary{
+				P50: latencyPercentileMs(sorted, 50),
+				P90: latencyPercentileMs(sorted, 90),
+				P99: latencyPercentileMs(sorted, 99),
+				Max: float64(stats.max) / float64(time.Millisecond),
+			},
+		}
+	}
+
+	return report
+}
+
+// writeFile persists the current report to the metrics file, replacing it atomically
+func (m *mcpMetrics) writeFile() error {
+	if m.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m.report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode MCP metrics: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), ".ship-mcp-metrics-*")
+	if err != nil {
+		return fmt.Errorf("failed to write MCP metrics: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write MCP metrics: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write MCP metrics: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), m.path)
+}
+
+// middleware times every tool call, emits a structured log line and updates
+// the metrics file. A nil recorder only logs.
+func (m *mcpMetrics) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		startTime := time.Now()
+		result, err := next(ctx, request)
+		elapsed := time.Since(startTime)
+
+		tool := request.Params.Name
+		status := "success"
+		if err != nil {
+			status = "error"
+		} else if result != nil && result.IsError {
+			status = "tool_error"
+		}
+
+		level := slog.LevelDebug
+		if status != "success" {
+			level = slog.LevelWarn
+		}
+		slog.Log(ctx, level, "mcp tool call",
+			"tool", tool,
+			"status", status,
+			"duration_ms", elapsed.Milliseconds(),
+		)
+
+		if m != nil {
+			m.record(tool, elapsed, status != "success")
+			if writeErr := m.writeFile(); writeErr != nil {
+				slog.Warn("failed to update MCP metrics file", "path", m.path, "error", writeErr)
+			}
+		}
+
+		return result, err
+	}
+}
+
+// latencyPercentileMs returns the nearest-rank percentile of sorted latencies in milliseconds
+func latencyPercentileMs(sorted []time.Duration, percentile int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return float64(sorted[rank-1]) / float64(time.Millisecond)
+}
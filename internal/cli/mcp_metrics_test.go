@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callToolRequest(name string) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	return request
+}
+
+func TestMCPMetricsMiddlewareCountsInvocations(t *testing.T) {
+	metricsFile := filepath.Join(t.TempDir(), "metrics.json")
+	metrics := newMCPMetrics(metricsFile)
+
+	handler := metrics.middleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		time.Sleep(2 * time.Millisecond)
+		if request.Params.Name == "broken_tool" {
+			return nil, errors.New("boom")
+		}
+		if request.Params.Name == "failing_tool" {
+			return mcp.NewToolResultError("scan failed"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	const invocations = 5
+	for i := 0; i < invocations; i++ {
+		_, err := handler(context.Background(), callToolRequest("trivy_scan_directory"))
+		require.NoError(t, err)
+	}
+	_, err := handler(context.Background(), callToolRequest("failing_tool"))
+	require.NoError(t, err)
+	_, err = handler(context.Background(), callToolRequest("broken_tool"))
+	require.Error(t, err)
+
+	data, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+
+	var report mcpMetricsReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Tools, 3)
+
+	scan := report.Tools["trivy_scan_directory"]
+	assert.Equal(t, invocations, scan.Count)
+	assert.Equal(t, 0, scan.Errors)
+	assert.GreaterOrEqual(t, scan.LatencyMs.P50, 2.0)
+	assert.GreaterOrEqual(t, scan.LatencyMs.Max, scan.LatencyMs.P90)
+	assert.GreaterOrEqual(t, scan.LatencyMs.P99, scan.LatencyMs.P50)
+
+	assert.Equal(t, 1, report.Tools["failing_tool"].Count)
+	assert.Equal(t, 1, report.Tools["failing_tool"].Errors)
+	assert.Equal(t, 1, report.Tools["broken_tool"].Errors)
+}
+
+func TestMCPMetricsNilRecorderPassesThrough(t *testing.T) {
+	var metrics *mcpMetrics
+
+	handler := metrics.middleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	result, err := handler(context.Background(), callToolRequest("echo"))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestLatencyPercentileMs(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50.0, latencyPercentileMs(latencies, 50))
+	assert.Equal(t, 90.0, latencyPercentileMs(latencies, 90))
+	assert.Equal(t, 99.0, latencyPercentileMs(latencies, 99))
+	assert.Equal(t, 100.0, latencyPercentileMs(latencies, 100))
+	assert.Equal(t, 0.0, latencyPercentileMs(nil, 50))
+}
+
+func TestMCPMetricsLatencyWindowIsBounded(t *testing.T) {
+	metrics := newMCPMetrics("")
+
+	metrics.record("trivy_scan_image", 5*time.Second, false)
+	for i := 0; i < 3*mcpLatencyWindow; i++ {
+		metrics.record("trivy_scan_image", time.Millisecond, false)
+	}
+
+	stats := metrics.tools["trivy_scan_image"]
+	assert.Len(t, stats.latencies, mcpLatencyWindow)
+
+	summary := metrics.report().Tools["trivy_scan_image"]
+	assert.Equal(t, 3*mcpLatencyWindow+1, summary.Count)
+	assert.Equal(t, 1.0, summary.LatencyMs.P99, "percentiles cover the recent window")
+	assert.Equal(t, 5000.0, summary.LatencyMs.Max, "the maximum covers every call")
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// chunkedOutputURIPrefix is the resource URI prefix chunks of large tool
+// outputs are served under, as ship://output/<id>/<n>
+const chunkedOutputURIPrefix = "ship://output/"
+
+// maxStoredOutputs bounds how many chunked outputs are kept; the oldest is
+// dropped when another is stored
+const maxStoredOutputs = 20
+
+// chunkedOutputStore keeps the chunks of large tool outputs so clients can
+// read them back as resources
+type chunkedOutputStore struct {
+	mu      sync.Mutex
+	limit   int
+	outputs map[string][]string
+	order   []string
+}
+
+// chunkedOutputs stores the chunked outputs of the running MCP server
+var chunkedOutputs = newChunkedOutputStore(maxStoredOutputs)
+
+func newChunkedOutputStore(limit int) *chunkedOutputStore {
+	return &chunkedOutputStore{
+		limit:   limit,
+		outputs: make(map[string][]string),
+	}
+}
+
+// Put stores chunks and returns the id they are served under
+func (c *chunkedOutputStore) Put(chunks []string) string {
+	id := newChunkedOutputID()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.order) >= c.limit {
+		delete(c.outputs, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.outputs[id] = chunks
+	c.order = append(c.order, id)
+	return id
+}
+
+// Get returns chunk n of the output stored as id
+func (c *chunkedOutputStore) Get(id string, n int) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	chunks, ok := c.outputs[id]
+	if !ok || n < 0 || n >= len(chunks) {
+		return "", false
+	}
+	return chunks[n], true
+}
+
+func newChunkedOutputID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// chunkResourceURI is the resource URI of chunk n of the output stored as id
+func chunkResourceURI(id string, n int) string {
+	return fmt.Sprintf("%s%s/%d", chunkedOutputURIPrefix, id, n)
+}
+
+// parseChunkResourceURI splits a ship://output/<id>/<n> URI
+func parseChunkResourceURI(uri string) (string, int, error) {
+	id, index, ok := strings.Cut(strings.TrimPrefix(uri, chunkedOutputURIPrefix), "/")
+	if !strings.HasPrefix(uri, chunkedOutputURIPrefix) || !ok || id == "" {
+		return "", 0, fmt.Errorf("invalid output chunk URI %q: expected %s<id>/<n>", uri, chunkedOutputURIPrefix)
+	}
+	n, err := strconv.Atoi(index)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid chunk index in %q: %w", uri, err)
+	}
+	return id, n, nil
+}
+
+// addChunkedOutputResources serves stored output chunks as resources
+func addChunkedOutputResources(s *server.MCPServer, store *chunkedOutputStore) {
+	template := mcp.NewResourceTemplate(chunkedOutputURIPrefix+"{id}/{chunk}",
+		"Chunked tool output",
+		mcp.WithTemplateDescription("One chunk of a tool output too large to return in a single response"),
+		mcp.WithTemplateMIMEType("text/plain"),
+	)
+
+	s.AddResourceTemplate(template, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id, n, err := parseChunkResourceURI(request.Params.URI)
+		if err != nil {
+			return nil, err
+		}
+		chunk, ok := store.Get(id, n)
+		if !ok {
+			return nil, fmt.Errorf("output chunk %s not found; it may have expired", request.Params.URI)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/plain",
+				Text:     chunk,
+			},
+		}, nil
+	})
+}
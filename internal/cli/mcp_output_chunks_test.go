@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readResource reads uri from s through a resources/read request
+func readResource(t *testing.T, s *server.MCPServer, uri string) (mcp.JSONRPCMessage, string) {
+	t.Helper()
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]any{"uri": uri},
+	})
+	require.NoError(t, err)
+
+	response := s.HandleMessage(context.Background(), request)
+	if result, ok := response.(mcp.JSONRPCResponse); ok {
+		contents := result.Result.(mcp.ReadResourceResult).Contents
+		require.Len(t, contents, 1)
+		return response, contents[0].(mcp.TextResourceContents).Text
+	}
+	return response, ""
+}
+
+func TestCreateChunkedResponse_ChunksAreRetrievable(t *testing.T) {
+	var lines []string
+	for i := 0; len(strings.Join(lines, "\n")) <= 2*maxMCPTokens*charsPerToken; i++ {
+		lines = append(lines, fmt.Sprintf("finding %d: CRITICAL issue in module_%d", i, i))
+	}
+	output := strings.Join(lines, "\n")
+	require.True(t, needsChunking(output))
+
+	result := createChunkedResponse(output)
+	require.False(t, result.IsError)
+
+	uris, ok := result.Meta.AdditionalFields["chunks"].([]string)
+	require.True(t, ok)
+	require.Len(t, uris, 3)
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for i, uri := range uris {
+		assert.True(t, strings.HasPrefix(uri, "ship://output/"), uri)
+		assert.True(t, strings.HasSuffix(uri, fmt.Sprintf("/%d", i)), uri)
+		assert.Contains(t, text, uri, "the response lists every chunk URI")
+	}
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	addChunkedOutputResources(s, chunkedOutputs)
+
+	var chunks []string
+	for _, uri := range uris {
+		_, chunk := readResource(t, s, uri)
+		require.NotEmpty(t, chunk)
+		assert.LessOrEqual(t, len(chunk), maxMCPTokens*charsPerToken)
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, output, strings.Join(chunks, "\n"), "the chunks reassemble the full output")
+}
+
+func TestChunkedOutputResource_Missing(t *testing.T) {
+	store := newChunkedOutputStore(maxStoredOutputs)
+	id := store.Put([]string{"only chunk"})
+
+	s := server.NewMCPServer("ship-test", "1.0.0")
+	addChunkedOutputResources(s, store)
+
+	_, chunk := readResource(t, s, chunkResourceURI(id, 0))
+	assert.Equal(t, "only chunk", chunk)
+
+	for _, uri := range []string{chunkResourceURI(id, 1), chunkResourceURI("unknown", 0), "ship://output/" + id + "/x"} {
+		response, _ := readResource(t, s, uri)
+		_, isError := response.(mcp.JSONRPCError)
+		assert.True(t, isError, uri)
+	}
+}
+
+func TestChunkedOutputStore_DropsOldest(t *testing.T) {
+	store := newChunkedOutputStore(2)
+	first := store.Put([]string{"a"})
+	second := store.Put([]string{"b"})
+	third := store.Put([]string{"c"})
+
+	_, ok := store.Get(first, 0)
+	assert.False(t, ok, "the oldest output is dropped past the limit")
+	chunk, ok := store.Get(second, 0)
+	assert.True(t, ok)
+	assert.Equal(t, "b", chunk)
+	chunk, _ = store.Get(third, 0)
+	assert.Equal(t, "c", chunk)
+}
+
+func TestCreateChunkedResponse_SmallOutputUnchanged(t *testing.T) {
+	result := createChunkedResponse("short output")
+	assert.Equal(t, "short output", result.Content[0].(mcp.TextContent).Text)
+	assert.Nil(t, result.Meta)
+}
+
+func TestChunkOutput_JSONArrayChunksAreValidJSON(t *testing.T) {
+	var findings []map[string]any
+	for i := 0; i < 200; i++ {
+		findings = append(findings, map[string]any{
+			"id":       fmt.Sprintf("CKV_AWS_%d", i),
+			"severity": "HIGH",
+			"resource": fmt.Sprintf("aws_s3_bucket.bucket_%d", i),
+		})
+	}
+	data, err := json.MarshalIndent(findings, "", "  ")
+	require.NoError(t, err)
+
+	chunks := chunkOutput(string(data), 2000)
+	require.Greater(t, len(chunks), 1)
+
+	var rebuilt []map[string]any
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len(chunk), 2000)
+		var part []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(chunk), &part), "every chunk is a valid JSON array")
+		rebuilt = append(rebuilt, part...)
+	}
+	assert.Len(t, rebuilt, len(findings))
+	assert.Equal(t, "CKV_AWS_0", rebuilt[0]["id"])
+	assert.Equal(t, "CKV_AWS_199", rebuilt[199]["id"])
+}
+
+func TestChunkOutput_OversizedElementGetsOwnChunk(t *testing.T) {
+	large := `"` + strings.Repeat("x", 50) + `"`
+	chunks := chunkOutput(`["a",`+large+`,"b"]`, 20)
+	assert.Equal(t, []string{`["a"]`, `[` + large + `]`, `["b"]`}, chunks)
+}
+
+func TestChunkOutput_NonJSONSplitsOnLines(t *testing.T) {
+	chunks := chunkOutput("line one\nline two\nline three", 18)
+	assert.Equal(t, []string{"line one\nline two", "line three"}, chunks)
+
+	// Output that only starts like JSON is not treated as JSON
+	chunks = chunkOutput("[INFO] scanning\n[INFO] done", 16)
+	assert.Equal(t, []string{"[INFO] scanning", "[INFO] done"}, chunks)
+}
+
+func TestCreateChunkedResponse_LargeJSONObjectReturnedWhole(t *testing.T) {
+	object := map[string]string{"report": strings.Repeat("y", maxMCPTokens*charsPerToken)}
+	data, err := json.Marshal(object)
+	require.NoError(t, err)
+
+	result := createChunkedResponse(string(data))
+	assert.Equal(t, string(data), result.Content[0].(mcp.TextContent).Text)
+	require.NotNil(t, result.Meta)
+	assert.Equal(t, true, result.Meta.AdditionalFields["large_output"])
+}
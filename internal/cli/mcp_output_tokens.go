@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// maxOutputTokensEnv overrides the chunking threshold when --max-output-tokens is not set
+const maxOutputTokensEnv = "SHIP_MAX_OUTPUT_TOKENS"
+
+// configureMaxOutputTokens sets the chunking threshold from --max-output-tokens,
+// falling back to SHIP_MAX_OUTPUT_TOKENS and then the default
+func configureMaxOutputTokens(cmd *cobra.Command) error {
+	tokens, err := maxOutputTokens(cmd)
+	if err != nil {
+		return err
+	}
+	maxMCPTokens = tokens
+	return nil
+}
+
+// maxOutputTokens resolves the chunking threshold. The flag wins over the
+// environment variable and the value must be positive.
+func maxOutputTokens(cmd *cobra.Command) (int, error) {
+	if flag := cmd.Flags().Lookup("max-output-tokens"); flag != nil && flag.Changed {
+		tokens, _ := cmd.Flags().GetInt("max-output-tokens")
+		if tokens <= 0 {
+			return 0, fmt.Errorf("--max-output-tokens must be positive, got %d", tokens)
+		}
+		return tokens, nil
+	}
+
+	value := os.Getenv(maxOutputTokensEnv)
+	if value == "" {
+		return defaultMaxMCPTokens, nil
+	}
+	tokens, err := strconv.Atoi(value)
+	if err != nil || tokens <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", maxOutputTokensEnv, value)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaxOutputTokensCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "mcp"}
+	cmd.Flags().Int("max-output-tokens", defaultMaxMCPTokens, "")
+	return cmd
+}
+
+// restoreMaxMCPTokens resets the chunking threshold after the test
+func restoreMaxMCPTokens(t *testing.T) {
+	t.Helper()
+	previous := maxMCPTokens
+	t.Cleanup(func() { maxMCPTokens = previous })
+}
+
+func TestMaxOutputTokens(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		t.Setenv(maxOutputTokensEnv, "")
+		tokens, err := maxOutputTokens(newMaxOutputTokensCmd())
+		require.NoError(t, err)
+		assert.Equal(t, defaultMaxMCPTokens, tokens)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(maxOutputTokensEnv, "4000")
+		tokens, err := maxOutputTokens(newMaxOutputTokensCmd())
+		require.NoError(t, err)
+		assert.Equal(t, 4000, tokens)
+	})
+
+	t.Run("flag wins over environment", func(t *testing.T) {
+		t.Setenv(maxOutputTokensEnv, "4000")
+		cmd := newMaxOutputTokensCmd()
+		require.NoError(t, cmd.Flags().Set("max-output-tokens", "8000"))
+		tokens, err := maxOutputTokens(cmd)
+		require.NoError(t, err)
+		assert.Equal(t, 8000, tokens)
+	})
+
+	t.Run("must be positive", func(t *testing.T) {
+		t.Setenv(maxOutputTokensEnv, "")
+		cmd := newMaxOutputTokensCmd()
+		require.NoError(t, cmd.Flags().Set("max-output-tokens", "0"))
+		_, err := maxOutputTokens(cmd)
+		assert.ErrorContains(t, err, "--max-output-tokens must be positive")
+
+		for _, value := range []string{"-5", "lots"} {
+			t.Setenv(maxOutputTokensEnv, value)
+			_, err = maxOutputTokens(newMaxOutputTokensCmd())
+			assert.ErrorContains(t, err, maxOutputTokensEnv+" must be a positive integer")
+		}
+	})
+}
+
+func TestConfigureMaxOutputTokens_LowerThresholdChunks(t *testing.T) {
+	restoreMaxMCPTokens(t)
+	t.Setenv(maxOutputTokensEnv, "")
+
+	output := strings.Repeat("finding: HIGH severity issue\n", 200)
+	require.NoError(t, configureMaxOutputTokens(newMaxOutputTokensCmd()))
+	assert.False(t, needsChunking(output), "output fits the default threshold")
+
+	cmd := newMaxOutputTokensCmd()
+	require.NoError(t, cmd.Flags().Set("max-output-tokens", "500"))
+	require.NoError(t, configureMaxOutputTokens(cmd))
+	assert.Equal(t, 500, maxMCPTokens)
+	assert.True(t, needsChunking(output))
+
+	result := createChunkedResponse(output)
+	require.NotNil(t, result.Meta)
+	assert.Len(t, result.Meta.AdditionalFields["chunks"], 3)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "TOTAL CHUNKS: 3")
+}
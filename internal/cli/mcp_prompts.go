@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// mcpPrompt is a prompt template and the tool categories it is served with
+type mcpPrompt struct {
+	prompt     mcp.Prompt
+	categories []string
+	handler    server.PromptHandlerFunc
+}
+
+// addPrompts registers the prompts for mode: every prompt in 'all' mode, the
+// prompts of the category in category modes and none for a single tool
+func addPrompts(s *server.MCPServer, mode string) {
+	for _, p := range mcpPrompts() {
+		if mode == "all" || contains(p.categories, mode) {
+			s.AddPrompt(p.prompt, p.handler)
+		}
+	}
+}
+
+func mcpPrompts() []mcpPrompt {
+	return []mcpPrompt{
+		{
+			// Security audit prompt
+			prompt: mcp.NewPrompt("security_audit",
+				mcp.WithPromptDescription("Comprehensive security audit of cloud infrastructure"),
+				mcp.WithArgument("provider",
+					mcp.ArgumentDescription("Cloud provider to audit (aws, azure, gcp)"),
+				),
+			),
+			categories: []string{"terraform", "security"},
+			handler: func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+				return &mcp.GetPromptResult{
+					Description: "Comprehensive security audit workflow",
+					Messages: []mcp.PromptMessage{
+						{
+							Role: "user",
+							Content: mcp.TextContent{
+								Type: "text",
+								Text: `Please perform a comprehensive security audit of my Terraform infrastructure. Follow these steps:
+
+1. Run terraform_checkov_scan to identify security issues in infrastructure-as-code
+2. Run terraform_security_scan for additional security analysis  
+3. Use terraform_lint to check for configuration best practices
+
+4. Summarize all findings with:
+   - Critical security issues requiring immediate attention
+   - Recommendations for improvement
+   - Best practices to implement
+
+Please be thorough and provide actionable recommendations.`,
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		{
+			// Cost optimization prompt
+			prompt: mcp.NewPrompt("cost_optimization",
+				mcp.WithPromptDescription("Identify cost optimization opportunities"),
+				mcp.WithArgument("provider",
+					mcp.ArgumentDescription("Cloud provider to analyze (aws, azure, gcp)"),
+				),
+			),
+			categories: []string{"terraform", "cloud"},
+			handler: func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+				return &mcp.GetPromptResult{
+					Description: "Cost optimization analysis workflow",
+					Messages: []mcp.PromptMessage{
+						{
+							Role: "user",
+							Content: mcp.TextContent{
+								Type: "text",
+								Text: `Help me optimize costs for my Terraform infrastructure:
+
+1. Use terraform_cost_analysis to analyze current cost projections
+2. Review Terraform configurations for cost optimization opportunities
+3. Use terraform_lint to identify inefficient resource configurations
+
+4. Provide a prioritized list of cost-saving recommendations:
+   - Quick wins (resource rightsizing, unused resources)
+   - Medium-term optimizations (reserved instances, storage classes)
+   - Long-term architectural improvements
+
+Include estimated cost savings where possible.`,
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		{
+			// Compliance report prompt
+			prompt: mcp.NewPrompt("compliance_report",
+				mcp.WithPromptDescription("Compliance report of infrastructure against a framework"),
+				mcp.WithArgument("framework",
+					mcp.ArgumentDescription("Compliance framework (e.g. cis, nist, pci, hipaa, soc2, iso27001)"),
+					mcp.RequiredArgument(),
+				),
+				mcp.WithArgument("target",
+					mcp.ArgumentDescription("Directory holding the IaC or Kubernetes manifests (default: current directory)"),
+				),
+				mcp.WithArgument("iac_type",
+					mcp.ArgumentDescription("IaC type for Terrascan (terraform, k8s, helm, kustomize, cloudformation)"),
+				),
+			),
+			categories: []string{"terraform", "security", "kubernetes"},
+			handler:    complianceReportPrompt,
+		},
+		{
+			// Supply chain verification prompt
+			prompt: mcp.NewPrompt("supply_chain_verify",
+				mcp.WithPromptDescription("Verify the signature, provenance and SBOM of a container image"),
+				mcp.WithArgument("image",
+					mcp.ArgumentDescription("Container image to verify (e.g. ghcr.io/org/app:1.0)"),
+					mcp.RequiredArgument(),
+				),
+				mcp.WithArgument("key_path",
+					mcp.ArgumentDescription("Cosign public key; keyless verification is used when omitted"),
+				),
+			),
+			categories: []string{"security", "supply-chain"},
+			handler:    supplyChainVerifyPrompt,
+		},
+	}
+}
+
+func complianceReportPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	framework := request.Params.Arguments["framework"]
+	if framework == "" {
+		return nil, fmt.Errorf("framework argument is required")
+	}
+	target := promptArgument(request, "target", ".")
+	iacType := promptArgument(request, "iac_type", "terraform")
+
+	text := fmt.Sprintf(`Please produce a %[1]s compliance report for the infrastructure in %[2]s. Follow these steps:
+
+1. Run terrascan_compliance_framework_scan on %[2]s with compliance_framework %[1]s and iac_type %[3]s
+2. If %[2]s contains Kubernetes manifests, run kubescape_scan_manifests on it with the framework closest to %[1]s
+3. Where a cluster is available, run kubescape_scan_cluster for runtime posture
+
+4. Summarize the results as a compliance report:
+   - Controls passed and failed, grouped by %[1]s requirement
+   - Failed controls ordered by severity, with the affected resources
+   - Remediation steps for each failed control
+
+Call out any %[1]s requirements the scans could not assess.`, framework, target, iacType)
+
+	return &mcp.GetPromptResult{
+		Description: "Compliance report workflow",
+		Messages: []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+		},
+	}, nil
+}
+
+func supplyChainVerifyPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	image := request.Params.Arguments["image"]
+	if image == "" {
+		return nil, fmt.Errorf("image argument is required")
+	}
+
+	verification := "keyless=true and the signer's certificate_identity and certificate_oidc_issuer"
+	if keyPath := request.Params.Arguments["key_path"]; keyPath != "" {
+		verification = "key_path " + keyPath
+	}
+
+	text := fmt.Sprintf(`Please verify the software supply chain of the image %[1]s. Follow these steps:
+
+1. Run cosign_verify_image on %[1]s with %[2]s to check its signature
+2. Run cosign_verify_attestation on %[1]s with %[2]s to check its SLSA provenance attestation
+3. Run syft_sbom on %[1]s to generate an SBOM of its contents
+
+4. Summarize the results with:
+   - Whether the signature and provenance are valid, and who signed them
+   - Notable packages and licenses from the SBOM
+   - Gaps in the supply chain and how to close them
+
+Treat a failed verification as a blocking issue.`, image, verification)
+
+	return &mcp.GetPromptResult{
+		Description: "Supply chain verification workflow",
+		Messages: []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+		},
+	}, nil
+}
+
+// promptArgument returns a prompt argument, or fallback when it is unset
+func promptArgument(request mcp.GetPromptRequest, name, fallback string) string {
+	if value := strings.TrimSpace(request.Params.Arguments[name]); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listPrompts returns the names of the prompts registered on s
+func listPrompts(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/list",
+	})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(context.Background(), request).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	var names []string
+	for _, prompt := range response.Result.(mcp.ListPromptsResult).Prompts {
+		names = append(names, prompt.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getPrompt renders a prompt and returns the text of its messages
+func getPrompt(t *testing.T, s *server.MCPServer, name string, arguments map[string]string) ([]string, mcp.JSONRPCMessage) {
+	t.Helper()
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]any{"name": name, "arguments": arguments},
+	})
+	require.NoError(t, err)
+
+	response := s.HandleMessage(context.Background(), request)
+	result, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		return nil, response
+	}
+	var texts []string
+	for _, message := range result.Result.(mcp.GetPromptResult).Messages {
+		texts = append(texts, message.Content.(mcp.TextContent).Text)
+	}
+	return texts, response
+}
+
+func newPromptServer(mode string) *server.MCPServer {
+	s := server.NewMCPServer("ship-test", "1.0.0", server.WithPromptCapabilities(true))
+	addPrompts(s, mode)
+	return s
+}
+
+func TestAddPrompts_ByMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		prompts []string
+	}{
+		{"all", []string{"compliance_report", "cost_optimization", "security_audit", "supply_chain_verify"}},
+		{"terraform", []string{"compliance_report", "cost_optimization", "security_audit"}},
+		{"security", []string{"compliance_report", "security_audit", "supply_chain_verify"}},
+		{"kubernetes", []string{"compliance_report"}},
+		{"supply-chain", []string{"supply_chain_verify"}},
+		{"cloud", []string{"cost_optimization"}},
+		{"trivy", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			assert.Equal(t, tt.prompts, listPrompts(t, newPromptServer(tt.mode)))
+		})
+	}
+}
+
+func TestComplianceReportPrompt(t *testing.T) {
+	s := newPromptServer("all")
+
+	texts, _ := getPrompt(t, s, "compliance_report", map[string]string{"framework": "pci", "target": "./infra"})
+	require.Len(t, texts, 1)
+	assert.Contains(t, texts[0], "terrascan_compliance_framework_scan on ./infra with compliance_framework pci and iac_type terraform")
+	assert.Contains(t, texts[0], "kubescape_scan_manifests")
+
+	texts, response := getPrompt(t, s, "compliance_report", nil)
+	assert.Empty(t, texts)
+	assert.IsType(t, mcp.JSONRPCError{}, response, "framework is required")
+}
+
+func TestSupplyChainVerifyPrompt(t *testing.T) {
+	s := newPromptServer("all")
+
+	texts, _ := getPrompt(t, s, "supply_chain_verify", map[string]string{"image": "ghcr.io/org/app:1.0"})
+	require.Len(t, texts, 1)
+	assert.Contains(t, texts[0], "cosign_verify_image on ghcr.io/org/app:1.0 with keyless=true")
+	assert.Contains(t, texts[0], "cosign_verify_attestation")
+	assert.Contains(t, texts[0], "syft_sbom")
+
+	texts, _ = getPrompt(t, s, "supply_chain_verify", map[string]string{"image": "app:1.0", "key_path": "cosign.pub"})
+	require.Len(t, texts, 1)
+	assert.Contains(t, texts[0], "with key_path cosign.pub")
+}
+
+func TestExistingPromptsRenderMessages(t *testing.T) {
+	s := newPromptServer("all")
+	for _, name := range []string{"security_audit", "cost_optimization"} {
+		texts, _ := getPrompt(t, s, name, nil)
+		require.Len(t, texts, 1, name)
+		assert.NotEmpty(t, texts[0], name)
+	}
+}
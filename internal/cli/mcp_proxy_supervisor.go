@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+)
+
+const (
+	defaultProxyHealthInterval = 15 * time.Second
+	defaultProxyMaxRestarts    = 5
+	defaultProxyRestartBackoff = time.Second
+	maxProxyRestartBackoff     = 30 * time.Second
+	proxyPingTimeout           = 5 * time.Second
+)
+
+// supervisedProxy is the part of ship.MCPProxy the supervisor needs to check
+// and restart an external server
+type supervisedProxy interface {
+	proxyToolSource
+	Ping(ctx context.Context) error
+	Restart(ctx context.Context) error
+}
+
+// proxySupervisor health checks a proxied external MCP server and restarts
+// it when it stops responding, so a crashing server does not take the Ship
+// side of the proxy down with it. Tools keep working across restarts because
+// proxy tools always call through the proxy's current connection.
+type proxySupervisor struct {
+	proxy  supervisedProxy
+	config ship.MCPServerConfig
+
+	interval    time.Duration
+	maxRestarts int
+	backoff     time.Duration
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	// onRestart receives the tools re-discovered after a restart
+	onRestart func(definitions []ship.ProxyToolDefinition)
+
+	restarts int
+}
+
+func newProxySupervisor(proxy supervisedProxy, config ship.MCPServerConfig) *proxySupervisor {
+	return &proxySupervisor{
+		proxy:       proxy,
+		config:      config,
+		interval:    defaultProxyHealthInterval,
+		maxRestarts: defaultProxyMaxRestarts,
+		backoff:     defaultProxyRestartBackoff,
+		sleep:       sleepContext,
+	}
+}
+
+// run checks the server every interval until ctx is done or the server could
+// not be restarted
+func (s *proxySupervisor) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.check(ctx); err != nil {
+				slog.Error("giving up on external MCP server; its tools will fail until Ship is restarted",
+					"server", s.config.Name, "error", err)
+				return
+			}
+		}
+	}
+}
+
+// check pings the server and restarts it when it does not respond
+func (s *proxySupervisor) check(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, proxyPingTimeout)
+	err := s.proxy.Ping(pingCtx)
+	cancel()
+	if err == nil {
+		return nil
+	}
+
+	slog.Warn("external MCP server is not responding", "server", s.config.Name, "error", err)
+	return s.restart(ctx)
+}
+
+// restart makes up to maxRestarts attempts to restart the server and
+// re-discover its tools, doubling the wait between attempts
+func (s *proxySupervisor) restart(ctx context.Context) error {
+	backoff := s.backoff
+	for attempt := 1; attempt <= s.maxRestarts; attempt++ {
+		if err := s.sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, maxProxyRestartBackoff)
+
+		if err := s.proxy.Restart(ctx); err != nil {
+			slog.Warn("failed to restart external MCP server",
+				"server", s.config.Name, "attempt", attempt, "max_attempts", s.maxRestarts, "error", err)
+			continue
+		}
+		definitions, err := s.proxy.DiscoverToolDefinitions(ctx)
+		if err != nil {
+			slog.Warn("failed to discover tools after restarting external MCP server",
+				"server", s.config.Name, "attempt", attempt, "max_attempts", s.maxRestarts, "error", err)
+			continue
+		}
+
+		s.restarts++
+		slog.Warn("restarted external MCP server",
+			"server", s.config.Name, "attempt", attempt, "restarts", s.restarts, "tools", len(definitions))
+		if s.onRestart != nil {
+			s.onRestart(definitions)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("external MCP server '%s' did not recover after %d restart attempts", s.config.Name, s.maxRestarts)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMCPServerMarkerEnv makes the test binary serve as the fake external MCP
+// server; the marker file it names records that the server has started before
+const fakeMCPServerMarkerEnv = "SHIP_FAKE_MCP_SERVER_MARKER"
+
+// TestFakeMCPServerProcess is not a test: it is the fake external MCP server
+// started by the supervisor tests. Its crash tool makes the first instance
+// exit; later instances stay up.
+func TestFakeMCPServerProcess(t *testing.T) {
+	marker := os.Getenv(fakeMCPServerMarkerEnv)
+	if marker == "" {
+		return
+	}
+
+	_, err := os.Stat(marker)
+	firstStart := errors.Is(err, os.ErrNotExist)
+	if firstStart {
+		os.WriteFile(marker, nil, 0644)
+	}
+
+	s := server.NewMCPServer("flaky", "1.0.0")
+	s.AddTool(mcp.NewTool("echo", mcp.WithString("text")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(request.GetString("text", "")), nil
+	})
+	s.AddTool(mcp.NewTool("crash"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !firstStart {
+			return mcp.NewToolResultText("still up"), nil
+		}
+		// Exit after the response is written
+		time.AfterFunc(50*time.Millisecond, func() { os.Exit(1) })
+		return mcp.NewToolResultText("crashing"), nil
+	})
+
+	server.ServeStdio(s)
+	os.Exit(0)
+}
+
+func fakeMCPServerConfig(t *testing.T) ship.MCPServerConfig {
+	t.Helper()
+	return ship.MCPServerConfig{
+		Name:      "flaky",
+		Command:   os.Args[0],
+		Args:      []string{"-test.run=^TestFakeMCPServerProcess$"},
+		Env:       map[string]string{fakeMCPServerMarkerEnv: filepath.Join(t.TempDir(), "started")},
+		Transport: "stdio",
+	}
+}
+
+func callProxyTool(t *testing.T, tools []ship.Tool, name string, params map[string]interface{}) string {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.Name() == name {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			result, err := tool.Execute(ctx, params, nil)
+			require.NoError(t, err)
+			require.NoError(t, result.Error)
+			return result.Content
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return ""
+}
+
+// waitForExit waits until the external server stops answering pings
+func waitForExit(t *testing.T, proxy *ship.MCPProxy) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		return proxy.Ping(ctx) != nil
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestProxySupervisor_RestartsCrashedServer(t *testing.T) {
+	config := fakeMCPServerConfig(t)
+	proxy := ship.NewMCPProxy(config)
+	require.NoError(t, proxy.Connect(context.Background()))
+	t.Cleanup(func() { proxy.Close() })
+
+	tools, err := proxy.DiscoverTools(context.Background())
+	require.NoError(t, err)
+
+	var waits []time.Duration
+	var rediscovered []ship.ProxyToolDefinition
+	supervisor := newProxySupervisor(proxy, config)
+	supervisor.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	supervisor.onRestart = func(definitions []ship.ProxyToolDefinition) {
+		rediscovered = definitions
+	}
+
+	require.NoError(t, supervisor.check(context.Background()), "a healthy server is left alone")
+	assert.Zero(t, supervisor.restarts)
+
+	assert.Equal(t, "crashing", callProxyTool(t, tools, "flaky_crash", nil))
+	waitForExit(t, proxy)
+
+	require.NoError(t, supervisor.check(context.Background()))
+	assert.Equal(t, 1, supervisor.restarts)
+	assert.Equal(t, []time.Duration{defaultProxyRestartBackoff}, waits)
+	assert.Len(t, rediscovered, 2, "tools are re-discovered after the restart")
+
+	assert.Equal(t, "hello", callProxyTool(t, tools, "flaky_echo", map[string]interface{}{"text": "hello"}),
+		"existing tools use the restarted server")
+	assert.Equal(t, "still up", callProxyTool(t, tools, "flaky_crash", nil))
+
+	require.NoError(t, supervisor.check(context.Background()))
+	assert.Equal(t, 1, supervisor.restarts, "the restarted server stays up")
+}
+
+// failingProxy never answers pings and cannot be restarted
+type failingProxy struct {
+	fakeProxyToolSource
+	restartAttempts int
+}
+
+func (f *failingProxy) Ping(ctx context.Context) error { return errors.New("broken pipe") }
+
+func (f *failingProxy) Restart(ctx context.Context) error {
+	f.restartAttempts++
+	return errors.New("npx exited with status 1")
+}
+
+func TestProxySupervisor_BoundedRestarts(t *testing.T) {
+	proxy := &failingProxy{}
+	var waits []time.Duration
+	supervisor := newProxySupervisor(proxy, ship.MCPServerConfig{Name: "flaky"})
+	supervisor.maxRestarts = 6
+	supervisor.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	err := supervisor.check(context.Background())
+	assert.EqualError(t, err, "external MCP server 'flaky' did not recover after 6 restart attempts")
+	assert.Equal(t, 6, proxy.restartAttempts)
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, maxProxyRestartBackoff,
+	}, waits, "the wait between attempts doubles up to the maximum")
+}
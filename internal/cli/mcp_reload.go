@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+)
+
+// imageTagEnvPrefix prefixes the environment variables holding image overrides
+const imageTagEnvPrefix = "SHIP_IMAGE_TAG_"
+
+// mcpSettingsReloader re-reads --env-file when a running MCP server receives
+// SIGHUP. The file's variables, including SHIP_IMAGE_TAG_<TOOL> image
+// overrides, are the only reloadable settings: tools read them on every run,
+// so a reload takes effect on the next tool call without re-registering tools
+// or dropping the client connection. Values set with --var and --image-tag
+// keep precedence over the file, as at startup. The served tools, filters,
+// transport, engine options and output settings need a restart.
+type mcpSettingsReloader struct {
+	envFile   string
+	flagVars  map[string]string
+	imageTags map[string]string
+	// loaded holds the file's variables as last applied, so variables removed
+	// from the file can be unset
+	loaded map[string]string
+}
+
+func newMCPSettingsReloader(cmd *cobra.Command) *mcpSettingsReloader {
+	envFile, _ := cmd.Flags().GetString("env-file")
+	flagVars, _ := cmd.Flags().GetStringToString("var")
+	imageTags, _ := cmd.Flags().GetStringToString("image-tag")
+
+	r := &mcpSettingsReloader{
+		envFile:   envFile,
+		flagVars:  flagVars,
+		imageTags: imageTags,
+	}
+	if envFile != "" {
+		// The file was validated and applied when the server started
+		r.loaded, _ = loadEnvFile(envFile)
+	}
+	return r
+}
+
+// reload applies the current contents of --env-file. An invalid file, or an
+// invalid image override in it, leaves the previous settings in place.
+func (r *mcpSettingsReloader) reload() error {
+	if r.envFile == "" {
+		return fmt.Errorf("nothing to reload: start the server with --env-file to reload its variables")
+	}
+
+	vars, err := loadEnvFile(r.envFile)
+	if err != nil {
+		return err
+	}
+	for key, value := range vars {
+		if tool, ok := strings.CutPrefix(key, imageTagEnvPrefix); ok {
+			if err := modules.ValidateImageOverride(value); err != nil {
+				return fmt.Errorf("invalid image override for %s: %w", strings.ToLower(tool), err)
+			}
+		}
+	}
+
+	for key := range r.loaded {
+		if _, ok := vars[key]; !ok && !r.setByFlag(key) {
+			os.Unsetenv(key)
+		}
+	}
+	for key, value := range vars {
+		if !r.setByFlag(key) {
+			os.Setenv(key, value)
+		}
+	}
+	r.loaded = vars
+	return nil
+}
+
+// setByFlag reports whether --var or --image-tag sets key, which then wins
+// over the file
+func (r *mcpSettingsReloader) setByFlag(key string) bool {
+	if _, ok := r.flagVars[key]; ok {
+		return true
+	}
+	for tool := range r.imageTags {
+		if key == imageTagEnvPrefix+strings.ToUpper(tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchMCPReloads reloads the settings on every signal received until
+// signals is closed
+func watchMCPReloads(r *mcpSettingsReloader, signals <-chan os.Signal) {
+	for range signals {
+		if err := r.reload(); err != nil {
+			slog.Warn("MCP reload failed, keeping previous settings", "error", err)
+			continue
+		}
+		slog.Info("MCP settings reloaded", "env_file", r.envFile)
+	}
+}
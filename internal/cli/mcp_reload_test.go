@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReloadTestCommand returns a command with the MCP server's env flags,
+// reading envFile, with the given flag values set
+func newReloadTestCommand(t *testing.T, envFile string, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("env-file", "", "")
+	cmd.Flags().StringToString("var", nil, "")
+	cmd.Flags().StringToString("image-tag", nil, "")
+	require.NoError(t, cmd.Flags().Set("env-file", envFile))
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return cmd
+}
+
+func writeEnvFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestMCPReloadOnSIGHUPUpdatesImage(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "")
+	envFile := filepath.Join(t.TempDir(), ".env")
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=aquasec/trivy:0.50.0\n")
+	os.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.50.0")
+
+	reloads := make(chan os.Signal, 1)
+	defer close(reloads)
+	go watchMCPReloads(newMCPSettingsReloader(newReloadTestCommand(t, envFile, nil)), reloads)
+
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=aquasec/trivy:0.51.0\n")
+	reloads <- syscall.SIGHUP
+
+	assert.Eventually(t, func() bool {
+		return os.Getenv("SHIP_IMAGE_TAG_TRIVY") == "aquasec/trivy:0.51.0"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMCPReloadRemovedOverrideRevertsToDefault(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.50.0")
+	envFile := filepath.Join(t.TempDir(), ".env")
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=aquasec/trivy:0.50.0\n")
+
+	reloader := newMCPSettingsReloader(newReloadTestCommand(t, envFile, nil))
+	writeEnvFile(t, envFile, "AWS_REGION=us-east-1\n")
+	t.Setenv("AWS_REGION", "")
+
+	require.NoError(t, reloader.reload())
+	assert.Empty(t, os.Getenv("SHIP_IMAGE_TAG_TRIVY"))
+	assert.Equal(t, "us-east-1", os.Getenv("AWS_REGION"))
+}
+
+func TestMCPReloadFlagsTakePrecedence(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.49.0")
+	t.Setenv("AWS_PROFILE", "from-flag")
+	envFile := filepath.Join(t.TempDir(), ".env")
+	writeEnvFile(t, envFile, "")
+
+	reloader := newMCPSettingsReloader(newReloadTestCommand(t, envFile, map[string]string{
+		"image-tag": "trivy=aquasec/trivy:0.49.0",
+		"var":       "AWS_PROFILE=from-flag",
+	}))
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=aquasec/trivy:0.51.0\nAWS_PROFILE=from-file\n")
+
+	require.NoError(t, reloader.reload())
+	assert.Equal(t, "aquasec/trivy:0.49.0", os.Getenv("SHIP_IMAGE_TAG_TRIVY"))
+	assert.Equal(t, "from-flag", os.Getenv("AWS_PROFILE"))
+}
+
+func TestMCPReloadInvalidOverrideKeepsPreviousSettings(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.50.0")
+	envFile := filepath.Join(t.TempDir(), ".env")
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=aquasec/trivy:0.50.0\n")
+
+	reloader := newMCPSettingsReloader(newReloadTestCommand(t, envFile, nil))
+	writeEnvFile(t, envFile, "SHIP_IMAGE_TAG_TRIVY=not a valid image\n")
+
+	assert.ErrorContains(t, reloader.reload(), "invalid image override for trivy")
+	assert.Equal(t, "aquasec/trivy:0.50.0", os.Getenv("SHIP_IMAGE_TAG_TRIVY"))
+}
+
+func TestMCPReloadWithoutEnvFile(t *testing.T) {
+	reloader := newMCPSettingsReloader(newReloadTestCommand(t, "", nil))
+	assert.ErrorContains(t, reloader.reload(), "nothing to reload")
+}
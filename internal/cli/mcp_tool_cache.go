@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+)
+
+const defaultMCPToolCacheTTL = 24 * time.Hour
+
+// mcpToolCache stores the tools discovered on external MCP servers so the
+// proxy does not list them again on every start. The server itself is still
+// started, since the version it reports is part of the key.
+type mcpToolCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cachedMCPTools is the on-disk form of an external server's discovered tools
+type cachedMCPTools struct {
+	Server    string                     `json:"server"`
+	Version   string                     `json:"version,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+	Tools     []ship.ProxyToolDefinition `json:"tools"`
+}
+
+func newMCPToolCache(dir string, ttl time.Duration) *mcpToolCache {
+	return &mcpToolCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// defaultMCPToolCacheDir is ~/.ship/cache/mcp
+func defaultMCPToolCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory for MCP tool cache: %w", err)
+	}
+	return filepath.Join(home, ".ship", "cache", "mcp"), nil
+}
+
+// mcpToolCacheKey hashes what identifies an external server's tools: its
+// name, the version it reports and how it is started. Changing the command,
+// arguments, environment or URL gives a new key, so stale definitions are
+// never reused.
+func mcpToolCacheKey(config ship.MCPServerConfig, serverVersion string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "server:%s\nversion:%s\n", config.Name, serverVersion)
+	fmt.Fprintf(h, "transport:%s\ncommand:%s\nurl:%s\n", config.Transport, config.Command, config.BaseURL)
+	for _, arg := range config.Args {
+		fmt.Fprintf(h, "arg:%s\n", arg)
+	}
+	envNames := make([]string, 0, len(config.Env))
+	for name := range config.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		fmt.Fprintf(h, "env:%s=%s\n", name, config.Env[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached tools for key if present and not expired
+func (c *mcpToolCache) get(key string) ([]ship.ProxyToolDefinition, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cachedMCPTools
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if c.ttl > 0 && c.now().Sub(entry.CreatedAt) > c.ttl {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return entry.Tools, true
+}
+
+// put stores the tools discovered on server for key
+func (c *mcpToolCache) put(key, server, serverVersion string, tools []ship.ProxyToolDefinition) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create MCP tool cache: %w", err)
+	}
+
+	data, err := json.Marshal(cachedMCPTools{Server: server, Version: serverVersion, CreatedAt: c.now(), Tools: tools})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached MCP tools: %w", err)
+	}
+
+	return os.WriteFile(c.path(key), data, 0600)
+}
+
+func (c *mcpToolCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// proxyToolSource is the part of ship.MCPProxy used to discover tools
+type proxyToolSource interface {
+	ServerVersion() string
+	DiscoverToolDefinitions(ctx context.Context) ([]ship.ProxyToolDefinition, error)
+	ProxyTools(definitions []ship.ProxyToolDefinition) []ship.Tool
+}
+
+// discoverProxyTools returns the external server's tools from cache, or
+// lists them on the server and caches them when there is no cached entry or
+// refresh is set. A nil cache always lists the tools.
+func discoverProxyTools(ctx context.Context, proxy proxyToolSource, config ship.MCPServerConfig, cache *mcpToolCache, refresh bool) ([]ship.Tool, error) {
+	var key string
+	if cache != nil {
+		key = mcpToolCacheKey(config, proxy.ServerVersion())
+		if !refresh {
+			if definitions, ok := cache.get(key); ok {
+				slog.Debug("using cached tools of external MCP server", "server", config.Name, "tools", len(definitions))
+				return proxy.ProxyTools(definitions), nil
+			}
+		}
+	}
+
+	definitions, err := proxy.DiscoverToolDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		if err := cache.put(key, config.Name, proxy.ServerVersion(), definitions); err != nil {
+			slog.Warn("failed to cache tools of external MCP server", "server", config.Name, "error", err)
+		}
+	}
+	return proxy.ProxyTools(definitions), nil
+}
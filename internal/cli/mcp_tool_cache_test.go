@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProxyToolSource counts how often tools are listed on the server
+type fakeProxyToolSource struct {
+	version     string
+	definitions []ship.ProxyToolDefinition
+	discoveries int
+}
+
+func (f *fakeProxyToolSource) ServerVersion() string { return f.version }
+
+func (f *fakeProxyToolSource) DiscoverToolDefinitions(ctx context.Context) ([]ship.ProxyToolDefinition, error) {
+	f.discoveries++
+	return f.definitions, nil
+}
+
+func (f *fakeProxyToolSource) ProxyTools(definitions []ship.ProxyToolDefinition) []ship.Tool {
+	tools := make([]ship.Tool, 0, len(definitions))
+	for _, definition := range definitions {
+		tools = append(tools, ship.NewProxyTool("filesystem_"+definition.Name, definition.Description, definition.Parameters, nil, definition.Name))
+	}
+	return tools
+}
+
+var testFilesystemServer = ship.MCPServerConfig{
+	Name:      "filesystem",
+	Command:   "npx",
+	Args:      []string{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+	Transport: "stdio",
+}
+
+func newFakeFilesystemServer() *fakeProxyToolSource {
+	return &fakeProxyToolSource{
+		version: "0.6.2",
+		definitions: []ship.ProxyToolDefinition{{
+			Name:        "read_file",
+			Description: "Read a file",
+			Parameters:  []ship.Parameter{{Name: "path", Type: "string", Description: "Path of the file"}},
+		}},
+	}
+}
+
+func TestDiscoverProxyTools_CacheHit(t *testing.T) {
+	cache := newMCPToolCache(t.TempDir(), time.Hour)
+	server := newFakeFilesystemServer()
+
+	tools, err := discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, 1, server.discoveries)
+
+	tools, err = discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.discoveries, "the second start uses the cached tools")
+	require.Len(t, tools, 1)
+	assert.Equal(t, "filesystem_read_file", tools[0].Name())
+	assert.Equal(t, "Read a file", tools[0].Description())
+	assert.Equal(t, server.definitions[0].Parameters, tools[0].Parameters())
+}
+
+func TestDiscoverProxyTools_MissOnChangedServer(t *testing.T) {
+	cache := newMCPToolCache(t.TempDir(), time.Hour)
+	server := newFakeFilesystemServer()
+
+	_, err := discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+
+	changedArgs := testFilesystemServer
+	changedArgs.Args = []string{"-y", "@modelcontextprotocol/server-filesystem", "/srv"}
+	_, err = discoverProxyTools(context.Background(), server, changedArgs, cache, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.discoveries, "changed arguments are not served from cache")
+
+	changedEnv := testFilesystemServer
+	changedEnv.Env = map[string]string{"ALLOWED_PATHS": "/srv"}
+	_, err = discoverProxyTools(context.Background(), server, changedEnv, cache, false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, server.discoveries, "a changed environment is not served from cache")
+
+	server.version = "0.7.0"
+	_, err = discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, server.discoveries, "a new server version is not served from cache")
+}
+
+func TestDiscoverProxyTools_RefreshTools(t *testing.T) {
+	cache := newMCPToolCache(t.TempDir(), time.Hour)
+	server := newFakeFilesystemServer()
+
+	_, err := discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+
+	server.definitions = append(server.definitions, ship.ProxyToolDefinition{Name: "write_file", Description: "Write a file"})
+	tools, err := discoverProxyTools(context.Background(), server, testFilesystemServer, cache, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.discoveries, "--refresh-tools bypasses the cache")
+	assert.Len(t, tools, 2)
+
+	tools, err = discoverProxyTools(context.Background(), server, testFilesystemServer, cache, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.discoveries)
+	assert.Len(t, tools, 2, "refreshed tools replace the cached ones")
+}
+
+func TestMCPToolCache_Expires(t *testing.T) {
+	cache := newMCPToolCache(t.TempDir(), time.Hour)
+	key := mcpToolCacheKey(testFilesystemServer, "0.6.2")
+	require.NoError(t, cache.put(key, "filesystem", "0.6.2", newFakeFilesystemServer().definitions))
+
+	_, ok := cache.get(key)
+	assert.True(t, ok)
+
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, ok = cache.get(key)
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/logger"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAndMergeVariables_SecretsNeverLogged(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.New(&out, slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	config := ship.MCPServerConfig{
+		Name: "brave-search",
+		Variables: []ship.Variable{
+			{Name: "BRAVE_API_KEY", Required: true, Secret: true},
+			{Name: "BRAVE_SEARCH_COUNT", Default: "10"},
+		},
+	}
+	require.NoError(t, validateAndMergeVariables(&config, map[string]string{"BRAVE_API_KEY": "brv-0a9e55d2"}))
+	assert.Equal(t, "brv-0a9e55d2", config.Env["BRAVE_API_KEY"], "the server still receives the secret")
+
+	redactSecretVariables(config)
+	slog.Info("starting external MCP server", "server", config.Name, "env", config.Env)
+	slog.Debug("MCP server running", "args", "--var BRAVE_API_KEY=brv-0a9e55d2")
+
+	assert.NotContains(t, out.String(), "brv-0a9e55d2")
+	assert.Contains(t, out.String(), "BRAVE_API_KEY:***")
+	assert.Contains(t, out.String(), "BRAVE_SEARCH_COUNT:10", "values of other variables are logged")
+}
+
+func TestValidateAndMergeVariables_RequiredValues(t *testing.T) {
+	variables := []ship.Variable{
+		{Name: "GITHUB_TOKEN", Required: true, Secret: true},
+		{Name: "GITHUB_HOST", Required: true, Default: "github.com"},
+	}
+
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		wantErr string
+	}{
+		{"missing", map[string]string{}, "required variable GITHUB_TOKEN is missing or empty (use --var GITHUB_TOKEN=value or --var-file)"},
+		{"empty", map[string]string{"GITHUB_TOKEN": ""}, "required variable GITHUB_TOKEN is missing or empty (use --var GITHUB_TOKEN=value or --var-file)"},
+		{"whitespace only", map[string]string{"GITHUB_TOKEN": " \t"}, "required variable GITHUB_TOKEN is missing or empty (use --var GITHUB_TOKEN=value or --var-file)"},
+		{"empty with default", map[string]string{"GITHUB_TOKEN": "ghp-1", "GITHUB_HOST": ""}, "required variable GITHUB_HOST is missing or empty (use --var GITHUB_HOST=value or --var-file)"},
+		{"valid", map[string]string{"GITHUB_TOKEN": "ghp-1"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ship.MCPServerConfig{Name: "github", Variables: variables}
+			err := validateAndMergeVariables(&config, tt.vars)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"GITHUB_TOKEN": "ghp-1", "GITHUB_HOST": "github.com"}, config.Env)
+		})
+	}
+}
@@ -49,6 +49,9 @@ func init() {
 	modulesCmd.AddCommand(modulesInfoCmd)
 	modulesCmd.AddCommand(modulesNewCmd)
 
+	modulesCmd.PersistentFlags().String("public-key", "", "PEM public key (e.g. cosign.pub) that module.yaml.sig signatures are verified against")
+	modulesCmd.PersistentFlags().Bool("require-signed", false, "Refuse modules whose manifest signature does not verify against --public-key")
+
 	// Flags for list command
 	modulesListCmd.Flags().StringP("type", "t", "", "Filter by module type (docker, dagger)")
 	modulesListCmd.Flags().StringP("source", "s", "", "Filter by source (builtin, user, project, git)")
@@ -59,6 +62,10 @@ func init() {
 	modulesNewCmd.Flags().StringP("description", "d", "", "Module description")
 	modulesNewCmd.Flags().StringP("author", "a", "", "Module author")
 	modulesNewCmd.Flags().StringP("output", "o", "", "Output directory (default: ~/.ship/modules/[module-name])")
+
+	registerEnumFlag(modulesListCmd, "type", "docker", "dagger")
+	registerEnumFlag(modulesListCmd, "source", "builtin", "user", "project", "git")
+	registerEnumFlag(modulesNewCmd, "type", "docker", "dagger")
 }
 
 func runModulesList(cmd *cobra.Command, args []string) error {
@@ -89,6 +96,9 @@ func runModulesList(cmd *cobra.Command, args []string) error {
 		{"prowler", "Multi-cloud security assessment", "security", "toniblyx/prowler:latest"},
 		{"trufflehog", "Verified secret detection", "security", "trufflesecurity/trufflehog:latest"},
 		{"cosign", "Container signing and verification", "security", "gcr.io/projectsigstore/cosign:latest"},
+		{"in-toto", "Supply chain attestation verification", "security", "python:3.11-slim"},
+		{"slsa-verifier", "SLSA provenance verification", "security", "golang:1.23-alpine"},
+		{"rekor", "Rekor transparency log queries", "security", "gcr.io/projectsigstore/rekor-cli:latest"},
 
 		// Security Tools (High-Priority Supply Chain)
 		{"gatekeeper", "OPA Gatekeeper policy validation", "security", "openpolicyagent/gatekeeper:latest"},
@@ -117,7 +127,7 @@ func runModulesList(cmd *cobra.Command, args []string) error {
 		{"powerpipe", "Infrastructure benchmarking", "security", "turbot/powerpipe:latest"},
 		{"velero", "Kubernetes backup and disaster recovery", "security", "velero/velero:latest"},
 		{"goldilocks", "Kubernetes resource recommendations", "security", "us-docker.pkg.dev/fairwinds-ops/oss/goldilocks:latest"},
-		{"osv-scanner", "Open Source Vulnerability scanning", "security", "gcr.io/osv-scanner/osv-scanner:latest"},
+		{"osv-scanner", "Open Source Vulnerability scanning", "security", "ghcr.io/google/osv-scanner:latest"},
 		{"license-detector", "Software license detection", "security", "licensefinder/license_finder:latest"},
 		{"iac-plan", "Infrastructure as Code planning", "security", "hashicorp/terraform:latest"},
 
@@ -232,6 +242,18 @@ func runModulesList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// moduleConfigFromFlags builds the module configuration from the modules
+// command's signature flags
+func moduleConfigFromFlags(cmd *cobra.Command) modules.ModuleConfig {
+	publicKey, _ := cmd.Flags().GetString("public-key")
+	requireSigned, _ := cmd.Flags().GetBool("require-signed")
+
+	return modules.ModuleConfig{
+		PublicKey:     publicKey,
+		RequireSigned: requireSigned,
+	}
+}
+
 func runModulesInfo(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 	moduleName := args[0]
@@ -247,9 +269,9 @@ func runModulesInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create module manager for custom modules
-	manager := modules.NewManager(modules.ModuleConfig{
-		AllowUntrusted: true,
-	})
+	config := moduleConfigFromFlags(cmd)
+	config.AllowUntrusted = true
+	manager := modules.NewManager(config)
 
 	// Load modules
 	if err := manager.LoadModules(ctx); err != nil {
@@ -270,6 +292,7 @@ func runModulesInfo(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Type: %s\n", module.Spec.Type)
 	fmt.Printf("Source: %s\n", module.Source)
 	fmt.Printf("Trusted: %t\n", module.Trusted)
+	fmt.Printf("Signed: %t\n", module.Signed)
 
 	if module.Path != "" {
 		fmt.Printf("Path: %s\n", module.Path)
@@ -358,6 +381,23 @@ func runModulesInfo(cmd *cobra.Command, args []string) error {
 		if module.Spec.Docker.WorkingDir != "" {
 			fmt.Printf("  Working Directory: %s\n", module.Spec.Docker.WorkingDir)
 		}
+
+		// Container restrictions enforced from the declared permissions
+		if policy, err := modules.NewContainerPolicy(module.Spec.Permissions); err != nil {
+			fmt.Printf("  Restrictions: invalid permissions: %v\n", err)
+		} else {
+			network := "none"
+			if policy.Network {
+				network = "allowed"
+			}
+			workspace := map[modules.WorkspaceAccess]string{
+				modules.WorkspaceNone:      "not mounted",
+				modules.WorkspaceReadOnly:  "read-only",
+				modules.WorkspaceReadWrite: "read-write",
+			}[policy.Workspace]
+			fmt.Printf("  Network: %s\n", network)
+			fmt.Printf("  Workspace: %s\n", workspace)
+		}
 	}
 
 	if module.Spec.Dagger != nil {
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+var modulesRunCmd = &cobra.Command{
+	Use:   "run [flags] <module-name> <command> [command flags and args]",
+	Short: "Run a command of a docker module",
+	Long: `Run a command declared by a docker module with the current directory at
+/workspace; the command's declared flags and any further arguments are passed
+to the container.
+
+The container is restricted to the module's declared permissions: the
+workspace is only given to modules declaring filesystem:read, and is writable
+only for modules declaring filesystem:write. Modules declaring network run
+through Dagger; all others run with docker run --network none, since Dagger
+cannot deny a container network access. Untrusted modules run without
+--allow-untrusted when they only declare filesystem:read; those that request
+more, or mount volumes, are refused unless it is given. Volumes of untrusted
+modules must lie inside the current directory.
+
+Flags of ship modules run go before the module name; everything after the
+command belongs to the module.
+
+Examples:
+  # Run the scan command of a project module
+  ship modules run my-scanner scan --severity high ./src
+
+  # Pass environment to the container
+  ship modules run --env LOG_LEVEL=debug my-scanner scan`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runModulesRun,
+}
+
+func init() {
+	modulesCmd.AddCommand(modulesRunCmd)
+
+	modulesRunCmd.Flags().StringArrayP("env", "e", nil, "Environment variable for the module container, as KEY=VALUE (repeatable)")
+	modulesRunCmd.Flags().Bool("allow-untrusted", false, "Run untrusted modules even when they request permissions beyond filesystem:read or mount volumes")
+
+	// Everything after the module name is parsed by the module command
+	modulesRunCmd.Flags().SetInterspersed(false)
+}
+
+func runModulesRun(cmd *cobra.Command, args []string) error {
+	return runModulesRunWith(cmd, args, nil)
+}
+
+// runModulesRunWith runs a module command with runner running its container;
+// a nil runner picks Dagger or docker run by the module's network permission
+func runModulesRunWith(cmd *cobra.Command, args []string, runner modules.ContainerRunner) error {
+	moduleName, command, rest := args[0], args[1], args[2:]
+	envPairs, _ := cmd.Flags().GetStringArray("env")
+	allowUntrusted, _ := cmd.Flags().GetBool("allow-untrusted")
+
+	env, err := parseModuleEnv(envPairs)
+	if err != nil {
+		return err
+	}
+
+	config := moduleConfigFromFlags(cmd)
+	config.AllowUntrusted = allowUntrusted
+	manager := modules.NewManager(config)
+	if runner != nil {
+		manager.SetContainerRunner(runner)
+	}
+	if err := manager.LoadModules(cmd.Context()); err != nil {
+		return fmt.Errorf("failed to load modules: %w", err)
+	}
+
+	module, err := manager.GetModule(moduleName)
+	if err != nil {
+		return err
+	}
+	if module.Spec.Type != modules.ModuleTypeDocker || module.Spec.Docker == nil || module.Spec.Docker.Image == "" {
+		return fmt.Errorf("module '%s' is not a docker module with an image", moduleName)
+	}
+	if len(env) > 0 {
+		merged := make(map[string]string, len(module.Spec.Docker.Env)+len(env))
+		for name, value := range module.Spec.Docker.Env {
+			merged[name] = value
+		}
+		for name, value := range env {
+			merged[name] = value
+		}
+		module.Spec.Docker.Env = merged
+	}
+
+	moduleCmd, err := manager.CommandFor(moduleName, command)
+	if err != nil {
+		return err
+	}
+	moduleCmd.SilenceUsage = true
+	moduleCmd.SilenceErrors = true
+	moduleCmd.SetArgs(rest)
+	moduleCmd.SetOut(cmd.OutOrStdout())
+	moduleCmd.SetErr(cmd.ErrOrStderr())
+	return moduleCmd.ExecuteContext(cmd.Context())
+}
+
+// parseModuleEnv parses KEY=VALUE pairs given with --env
+func parseModuleEnv(pairs []string) (map[string]string, error) {
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --env %q: must be KEY=VALUE", pair)
+		}
+		env[name] = value
+	}
+	return env, nil
+}
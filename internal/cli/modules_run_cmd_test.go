@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupModuleProject makes a project directory holding the fixture modules
+// in .ship/modules the working directory
+func setupModuleProject(t *testing.T) {
+	t.Helper()
+
+	fixtures, err := filepath.Abs(filepath.Join("testdata", "modules"))
+	require.NoError(t, err)
+
+	project := t.TempDir()
+	for _, name := range []string{"echo-scanner", "uploader"} {
+		data, err := os.ReadFile(filepath.Join(fixtures, name, "module.yaml"))
+		require.NoError(t, err)
+		dir := filepath.Join(project, ".ship", "modules", name)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "module.yaml"), data, 0644))
+	}
+
+	t.Setenv("HOME", t.TempDir())
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(project))
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// recordingModuleRunner records the module containers it is asked to run
+// instead of running them
+type recordingModuleRunner struct {
+	containers []modules.ModuleContainer
+}
+
+func (r *recordingModuleRunner) Run(ctx context.Context, container modules.ModuleContainer) (*modules.ExecutionResult, error) {
+	r.containers = append(r.containers, container)
+	return &modules.ExecutionResult{}, nil
+}
+
+// runModulesRunForTest parses args with the flags of ship modules run and
+// runs the command, returning the container it ran, if any
+func runModulesRunForTest(t *testing.T, args ...string) (modules.ModuleContainer, error) {
+	t.Helper()
+	runner := &recordingModuleRunner{}
+	cmd := &cobra.Command{Use: "run", Args: cobra.MinimumNArgs(2), RunE: func(cmd *cobra.Command, args []string) error {
+		return runModulesRunWith(cmd, args, runner)
+	}}
+	cmd.Flags().StringArrayP("env", "e", nil, "")
+	cmd.Flags().Bool("allow-untrusted", false, "")
+	cmd.Flags().String("public-key", "", "")
+	cmd.Flags().Bool("require-signed", false, "")
+	cmd.Flags().SetInterspersed(false)
+	require.NoError(t, cmd.ParseFlags(args))
+
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetContext(context.Background())
+	err := cmd.RunE(cmd, cmd.Flags().Args())
+	if len(runner.containers) == 0 {
+		return modules.ModuleContainer{}, err
+	}
+	require.Len(t, runner.containers, 1)
+	return runner.containers[0], err
+}
+
+func TestModulesRunCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"modules", "run"})
+	require.NoError(t, err)
+	assert.Equal(t, modulesRunCmd, cmd)
+}
+
+func TestModulesRun_PassesDeclaredFlags(t *testing.T) {
+	setupModuleProject(t)
+
+	container, err := runModulesRunForTest(t, "--env", "LOG_LEVEL=debug",
+		"echo-scanner", "scan", "-s", "high", "--fail-fast", "--exclude", "vendor,dist", "./src")
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, modules.ModuleContainer{
+		Image:      "example/echo-scanner:1.0",
+		Command:    []string{"scan", "--severity", "high", "--fail-fast", "--exclude", "vendor", "--exclude", "dist", "./src"},
+		WorkingDir: "/workspace",
+		Env:        map[string]string{"LOG_LEVEL": "debug", "SCANNER_MODE": "fast"},
+		Workspace:  wd,
+	}, container)
+}
+
+func TestModulesRun_DefaultFlagValues(t *testing.T) {
+	setupModuleProject(t)
+
+	container, err := runModulesRunForTest(t, "echo-scanner", "scan")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scan", "--severity", "medium"}, container.Command, "declared defaults are passed, unset flags are not")
+}
+
+func TestModulesRun_Errors(t *testing.T) {
+	setupModuleProject(t)
+
+	_, err := runModulesRunForTest(t, "echo-scanner", "deploy")
+	assert.ErrorContains(t, err, "command 'deploy' not found in module 'echo-scanner'")
+
+	_, err = runModulesRunForTest(t, "missing", "scan")
+	assert.ErrorContains(t, err, "module not found: missing")
+
+	_, err = runModulesRunForTest(t, "--env", "NOVALUE", "echo-scanner", "scan")
+	assert.ErrorContains(t, err, `invalid --env "NOVALUE"`)
+
+	_, err = runModulesRunForTest(t, "uploader", "upload")
+	assert.ErrorContains(t, err, "requests network")
+
+	container, err := runModulesRunForTest(t, "--allow-untrusted", "uploader", "upload")
+	require.NoError(t, err)
+	assert.Equal(t, "example/uploader:1.0", container.Image)
+	assert.True(t, container.Network, "a module granted network keeps it")
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Supported values for --notify-on
+const (
+	notifyOnFailure = "failure"
+	notifyOnAlways  = "always"
+)
+
+// notifyTimeout bounds the webhook call so a slow endpoint cannot hold up a scan
+const notifyTimeout = 10 * time.Second
+
+func init() {
+	rootCmd.PersistentFlags().String("notify-webhook", "", "POST a JSON summary of the scan to this URL when it completes")
+	rootCmd.PersistentFlags().String("notify-on", notifyOnFailure, "When to call --notify-webhook: failure (the tool failed or reported findings) or always")
+
+	registerEnumFlag(rootCmd, "notify-on", notifyOnFailure, notifyOnAlways)
+}
+
+// scanNotification is the JSON summary posted to --notify-webhook
+type scanNotification struct {
+	Tool     string `json:"tool"`
+	Status   string `json:"status"`
+	Findings *int   `json:"findings"`
+	Error    string `json:"error,omitempty"`
+}
+
+// failed reports whether the scan errored or reported findings
+func (n scanNotification) failed() bool {
+	return n.Status == "error" || (n.Findings != nil && *n.Findings > 0)
+}
+
+// validateNotifyOn checks the --notify-on value
+func validateNotifyOn(cmd *cobra.Command) error {
+	notifyOn, _ := cmd.Flags().GetString("notify-on")
+	switch notifyOn {
+	case notifyOnFailure, notifyOnAlways:
+		return nil
+	default:
+		return fmt.Errorf("invalid --notify-on %q: must be %s or %s", notifyOn, notifyOnFailure, notifyOnAlways)
+	}
+}
+
+// notifyScanResult posts the scan summary to --notify-webhook when configured.
+// Webhook failures are logged and never fail the scan.
+func notifyScanResult(cmd *cobra.Command, tool string, result string, err error) {
+	url, _ := cmd.Flags().GetString("notify-webhook")
+	if url == "" {
+		return
+	}
+	notifyOn, _ := cmd.Flags().GetString("notify-on")
+
+	notification := newScanNotification(tool, result, err)
+	if notifyOn != notifyOnAlways && !notification.failed() {
+		return
+	}
+
+	client := &http.Client{Timeout: notifyTimeout}
+	if postErr := postNotification(context.Background(), client, url, notification); postErr != nil {
+		slog.Warn("failed to send scan notification", "tool", tool, "error", postErr)
+	}
+}
+
+// newScanNotification summarizes a tool result
+func newScanNotification(tool string, result string, err error) scanNotification {
+	notification := scanNotification{Tool: tool, Status: "ok"}
+	if err != nil {
+		notification.Status = "error"
+		notification.Error = err.Error()
+	}
+	if count, ok := countFindings(result); ok {
+		notification.Findings = &count
+	}
+	return notification
+}
+
+// postNotification sends the summary as a JSON POST and expects a 2xx response
+func postNotification(ctx context.Context, client *http.Client, url string, notification scanNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// countFindings counts findings in structured tool output: a JSON array, a
+// SARIF log, an object with a findings or results array, or JSON lines. It
+// reports false when the output is not in a recognised structured form.
+func countFindings(output string) (int, bool) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return 0, false
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(output), &value); err == nil {
+		return countJSONFindings(value)
+	}
+
+	// JSON lines: every line must be a JSON object
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var object map[string]any
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			return 0, false
+		}
+		count++
+	}
+	return count, true
+}
+
+func countJSONFindings(value any) (int, bool) {
+	switch v := value.(type) {
+	case []any:
+		return len(v), true
+	case map[string]any:
+		if runs, ok := v["runs"].([]any); ok {
+			count := 0
+			for _, run := range runs {
+				if run, ok := run.(map[string]any); ok {
+					results, _ := run["results"].([]any)
+					count += len(results)
+				}
+			}
+			return count, true
+		}
+		for _, key := range []string{"findings", "results"} {
+			if items, ok := v[key].([]any); ok {
+				return len(items), true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNotifyTestCmd returns a command with the notification flags set
+func newNotifyTestCmd(t *testing.T, url, notifyOn string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "scan"}
+	cmd.Flags().String("notify-webhook", "", "")
+	cmd.Flags().String("notify-on", notifyOnFailure, "")
+	require.NoError(t, cmd.Flags().Set("notify-webhook", url))
+	require.NoError(t, cmd.Flags().Set("notify-on", notifyOn))
+	return cmd
+}
+
+// newWebhookServer records the bodies posted to it
+func newWebhookServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received = append(received, payload)
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestNotifyScanResult_Payload(t *testing.T) {
+	server, received := newWebhookServer(t, http.StatusOK)
+	cmd := newNotifyTestCmd(t, server.URL, notifyOnAlways)
+
+	notifyScanResult(cmd, "trivy", `[{"id":"CVE-2024-1"},{"id":"CVE-2024-2"}]`, nil)
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, map[string]any{
+		"tool":     "trivy",
+		"status":   "ok",
+		"findings": float64(2),
+	}, (*received)[0])
+}
+
+func TestNotifyScanResult_ErrorPayload(t *testing.T) {
+	server, received := newWebhookServer(t, http.StatusOK)
+	cmd := newNotifyTestCmd(t, server.URL, notifyOnFailure)
+
+	notifyScanResult(cmd, "checkov", "plain text output", errors.New("checkov failed"))
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "error", (*received)[0]["status"])
+	assert.Equal(t, "checkov failed", (*received)[0]["error"])
+	assert.Nil(t, (*received)[0]["findings"], "unstructured output has no findings count")
+}
+
+func TestNotifyScanResult_OnFailureFiltering(t *testing.T) {
+	server, received := newWebhookServer(t, http.StatusOK)
+	cmd := newNotifyTestCmd(t, server.URL, notifyOnFailure)
+
+	// A clean scan is not sent
+	notifyScanResult(cmd, "trivy", `[]`, nil)
+	assert.Empty(t, *received)
+
+	// Findings count as a failure
+	notifyScanResult(cmd, "trivy", `{"results":[{"id":"CVE-2024-1"}]}`, nil)
+	require.Len(t, *received, 1)
+	assert.Equal(t, float64(1), (*received)[0]["findings"])
+
+	// As does a tool error
+	notifyScanResult(cmd, "trivy", "", errors.New("scan failed"))
+	assert.Len(t, *received, 2)
+}
+
+func TestNotifyScanResult_AlwaysSendsCleanScans(t *testing.T) {
+	server, received := newWebhookServer(t, http.StatusOK)
+	cmd := newNotifyTestCmd(t, server.URL, notifyOnAlways)
+
+	notifyScanResult(cmd, "trivy", `[]`, nil)
+	require.Len(t, *received, 1)
+	assert.Equal(t, float64(0), (*received)[0]["findings"])
+}
+
+func TestNotifyScanResult_WebhookFailureIsNonFatal(t *testing.T) {
+	server, received := newWebhookServer(t, http.StatusInternalServerError)
+	cmd := newNotifyTestCmd(t, server.URL, notifyOnAlways)
+
+	assert.NotPanics(t, func() { notifyScanResult(cmd, "trivy", `[]`, nil) })
+	assert.Len(t, *received, 1)
+
+	unreachable := newNotifyTestCmd(t, "http://127.0.0.1:1/hook", notifyOnAlways)
+	assert.NotPanics(t, func() { notifyScanResult(unreachable, "trivy", `[]`, nil) })
+}
+
+func TestValidateNotifyOn(t *testing.T) {
+	assert.NoError(t, validateNotifyOn(newNotifyTestCmd(t, "", notifyOnFailure)))
+	assert.NoError(t, validateNotifyOn(newNotifyTestCmd(t, "", notifyOnAlways)))
+	assert.Error(t, validateNotifyOn(newNotifyTestCmd(t, "", "sometimes")))
+}
+
+func TestCountFindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		count  int
+		ok     bool
+	}{
+		{"json array", `[{"a":1},{"a":2},{"a":3}]`, 3, true},
+		{"sarif", `{"runs":[{"results":[{},{}]},{"results":[{}]}]}`, 3, true},
+		{"findings object", `{"findings":[{}]}`, 1, true},
+		{"json lines", "{\"a\":1}\n{\"a\":2}\n", 2, true},
+		{"empty", "", 0, false},
+		{"text", "2 leaks found", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, ok := countFindings(tt.output)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+}
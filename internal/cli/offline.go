@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().Bool("offline", false, "Run without network access: tools skip vulnerability database downloads and update checks, and Ship sends no telemetry (images and databases must already be cached)")
+}
+
+// configureOffline puts tools in offline mode and turns off Ship's own network
+// calls. Features that need the network fail fast instead of hanging.
+func configureOffline(cmd *cobra.Command) error {
+	if offline, _ := cmd.Flags().GetBool("offline"); !offline {
+		return nil
+	}
+
+	if webhook, _ := cmd.Flags().GetString("notify-webhook"); webhook != "" {
+		return fmt.Errorf("--notify-webhook needs network access and cannot be used with --offline")
+	}
+
+	// Set as environment variables so ship subprocesses started by the MCP server inherit them
+	os.Setenv(modules.OfflineEnv, "1")
+	os.Setenv("SHIP_TELEMETRY", "false")
+	telemetry.Disable()
+	return nil
+}
+
+// OfflineRequested reports whether args, the command line without the program
+// name, enable --offline. main checks it before telemetry starts, ahead of
+// flag parsing.
+func OfflineRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		value, ok := strings.CutPrefix(arg, "--offline")
+		switch {
+		case !ok:
+			continue
+		case value == "":
+			return true
+		case strings.HasPrefix(value, "="):
+			offline, err := strconv.ParseBool(value[1:])
+			return err == nil && offline
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOfflineTestCommand(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("offline", false, "")
+	cmd.Flags().String("notify-webhook", "", "")
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return cmd
+}
+
+func TestConfigureOffline(t *testing.T) {
+	t.Setenv(modules.OfflineEnv, "")
+	t.Setenv("SHIP_TELEMETRY", "")
+
+	require.NoError(t, configureOffline(newOfflineTestCommand(t, map[string]string{"offline": "true"})))
+	assert.Equal(t, "1", os.Getenv(modules.OfflineEnv))
+	assert.Equal(t, "false", os.Getenv("SHIP_TELEMETRY"), "ship subprocesses start with telemetry off")
+	assert.False(t, telemetry.IsEnabled())
+}
+
+func TestConfigureOfflineDisabled(t *testing.T) {
+	t.Setenv(modules.OfflineEnv, "")
+
+	require.NoError(t, configureOffline(newOfflineTestCommand(t, nil)))
+	assert.Empty(t, os.Getenv(modules.OfflineEnv))
+}
+
+func TestConfigureOfflineRejectsWebhook(t *testing.T) {
+	t.Setenv(modules.OfflineEnv, "")
+
+	err := configureOffline(newOfflineTestCommand(t, map[string]string{
+		"offline":        "true",
+		"notify-webhook": "https://hooks.example.com/ship",
+	}))
+	assert.ErrorContains(t, err, "cannot be used with --offline")
+	assert.Empty(t, os.Getenv(modules.OfflineEnv))
+}
+
+func TestOfflineRequested(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want bool
+	}{
+		"flag":              {[]string{"security", "trivy", "--offline"}, true},
+		"flag before":       {[]string{"--offline", "security", "grype"}, true},
+		"true value":        {[]string{"security", "trivy", "--offline=true"}, true},
+		"false value":       {[]string{"security", "trivy", "--offline=false"}, false},
+		"absent":            {[]string{"security", "trivy"}, false},
+		"after terminator":  {[]string{"mcp", "--", "--offline"}, false},
+		"other flag prefix": {[]string{"--offline-cache"}, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OfflineRequested(tt.args))
+		})
+	}
+}
+
+func TestOfflineFlagRegistered(t *testing.T) {
+	assert.NotNil(t, rootCmd.PersistentFlags().Lookup("offline"))
+}
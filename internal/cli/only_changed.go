@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// onlyChangedDefaultRef is diffed against when --only-changed has no value,
+// covering staged, unstaged and untracked changes
+const onlyChangedDefaultRef = "HEAD"
+
+// onlyChangedTools scan a directory of files and can be limited to the changed
+// ones. Other tools either need the whole repository (gitleaks reads git
+// history) or do not scan a directory, so they warn and scan the full target.
+var onlyChangedTools = map[string]bool{
+	"actionlint":  true,
+	"cfn-nag":     true,
+	"checkov":     true,
+	"osv-scanner": true,
+	"scan-all":    true,
+	"terrascan":   true,
+}
+
+// errNoChangedFiles is returned when --only-changed finds nothing to scan
+var errNoChangedFiles = errors.New("no changed files to scan")
+
+func init() {
+	rootCmd.PersistentFlags().String("only-changed", "", "Scan only files changed relative to a git ref (HEAD when given without a value)")
+	rootCmd.PersistentFlags().Lookup("only-changed").NoOptDefVal = onlyChangedDefaultRef
+}
+
+// applyOnlyChanged limits the scan target to files changed since the
+// --only-changed ref. The changed files are copied into a temporary directory
+// that replaces the target argument; cleanup removes it. Unsupported tools
+// and targets that are not git directories warn on stderr and keep args.
+func applyOnlyChanged(cmd *cobra.Command, tool string, args []string) ([]string, func(), error) {
+	noop := func() {}
+	flag := cmd.Flags().Lookup("only-changed")
+	if flag == nil || !flag.Changed {
+		return args, noop, nil
+	}
+	ref := flag.Value.String()
+	stderr := cmd.ErrOrStderr()
+
+	if !onlyChangedTools[tool] {
+		warnOnlyChangedFallback(stderr, "%s needs the whole repository", tool)
+		return args, noop, nil
+	}
+
+	target := scanTargetDir(args)
+	if info, err := os.Stat(target); err != nil || !info.IsDir() {
+		warnOnlyChangedFallback(stderr, "%s is not a directory", target)
+		return args, noop, nil
+	}
+
+	files, err := changedFiles(target, ref)
+	if err != nil {
+		warnOnlyChangedFallback(stderr, "%v", err)
+		return args, noop, nil
+	}
+	if len(files) == 0 {
+		return nil, noop, errNoChangedFiles
+	}
+
+	dir, err := copyChangedFiles(target, files)
+	if err != nil {
+		return nil, noop, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	runArgs := append([]string{dir}, args[min(len(args), 1):]...)
+	return runArgs, cleanup, nil
+}
+
+func warnOnlyChangedFallback(w io.Writer, format string, a ...any) {
+	fmt.Fprintf(w, "Warning: --only-changed ignored (%s); scanning the full target\n", fmt.Sprintf(format, a...))
+}
+
+// changedFiles lists files under dir that differ from ref, including untracked
+// files, as paths relative to dir. Deleted files are left out.
+func changedFiles(dir, ref string) ([]string, error) {
+	diff, err := gitOutput(dir, "diff", "--name-only", "--relative", "--diff-filter=ACMR", ref, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", ref, err)
+	}
+	untracked, err := gitOutput(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range append(strings.Split(diff, "\n"), strings.Split(untracked, "\n")...) {
+		if file = strings.TrimSpace(file); file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// copyChangedFiles copies files from root into a new temporary directory,
+// keeping their relative paths
+func copyChangedFiles(root string, files []string) (string, error) {
+	dir, err := os.MkdirTemp("", "ship-changed-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create changed-files directory: %w", err)
+	}
+
+	for _, file := range files {
+		if err := copyFile(filepath.Join(root, file), filepath.Join(dir, file)); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("failed to copy changed file %s: %w", file, err)
+		}
+	}
+	return dir, nil
+}
+
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitRepo creates a repository with main.tf and modules/vpc.tf committed
+func newGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	writeTestFile(t, dir, "main.tf", "resource {}")
+	writeTestFile(t, dir, "modules/vpc.tf", "module {}")
+	writeTestFile(t, dir, "README.md", "docs")
+
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	base := []string{"-C", dir, "-c", "user.email=test@example.com", "-c", "user.name=test", "-c", "commit.gpgsign=false"}
+	out, err := exec.Command("git", append(base, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func newOnlyChangedTestCmd(t *testing.T, ref string) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	cmd := &cobra.Command{Use: "scan"}
+	cmd.Flags().String("only-changed", "", "")
+	cmd.Flags().Lookup("only-changed").NoOptDefVal = onlyChangedDefaultRef
+	if ref != "" {
+		require.NoError(t, cmd.Flags().Set("only-changed", ref))
+	}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	return cmd, &stderr
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := newGitRepo(t)
+	writeTestFile(t, dir, "main.tf", "resource { changed }")
+	writeTestFile(t, dir, "modules/new.tf", "module { new }")
+	require.NoError(t, os.Remove(filepath.Join(dir, "README.md")))
+
+	files, err := changedFiles(dir, "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.tf", "modules/new.tf"}, files, "modified and untracked files, without deletions")
+
+	// Paths are relative to the scanned subdirectory
+	files, err = changedFiles(filepath.Join(dir, "modules"), "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.tf"}, files)
+}
+
+func TestChangedFiles_AgainstRef(t *testing.T) {
+	dir := newGitRepo(t)
+	runGit(t, dir, "tag", "base")
+	writeTestFile(t, dir, "modules/vpc.tf", "module { changed }")
+	runGit(t, dir, "commit", "-q", "-am", "change vpc")
+
+	files, err := changedFiles(dir, "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"modules/vpc.tf"}, files)
+
+	_, err = changedFiles(dir, "no-such-ref")
+	assert.Error(t, err)
+}
+
+func TestApplyOnlyChanged_MountsChangedFiles(t *testing.T) {
+	dir := newGitRepo(t)
+	writeTestFile(t, dir, "main.tf", "resource { changed }")
+
+	cmd, stderr := newOnlyChangedTestCmd(t, "HEAD")
+	args, cleanup, err := applyOnlyChanged(cmd, "checkov", []string{dir})
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.Len(t, args, 1)
+	assert.NotEqual(t, dir, args[0])
+	data, err := os.ReadFile(filepath.Join(args[0], "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "resource { changed }", string(data))
+	assert.NoFileExists(t, filepath.Join(args[0], "modules", "vpc.tf"))
+	assert.Empty(t, stderr.String())
+
+	cleanup()
+	assert.NoDirExists(t, args[0])
+}
+
+func TestApplyOnlyChanged_NoChanges(t *testing.T) {
+	dir := newGitRepo(t)
+	cmd, _ := newOnlyChangedTestCmd(t, "HEAD")
+
+	_, _, err := applyOnlyChanged(cmd, "checkov", []string{dir})
+	assert.ErrorIs(t, err, errNoChangedFiles)
+}
+
+func TestApplyOnlyChanged_Fallbacks(t *testing.T) {
+	dir := newGitRepo(t)
+	writeTestFile(t, dir, "main.tf", "resource { changed }")
+
+	t.Run("flag not set", func(t *testing.T) {
+		cmd, stderr := newOnlyChangedTestCmd(t, "")
+		args, _, err := applyOnlyChanged(cmd, "checkov", []string{dir})
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, args)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("whole repository tool", func(t *testing.T) {
+		cmd, stderr := newOnlyChangedTestCmd(t, "HEAD")
+		args, _, err := applyOnlyChanged(cmd, "gitleaks", []string{dir})
+		require.NoError(t, err)
+		assert.Equal(t, []string{dir}, args)
+		assert.Contains(t, stderr.String(), "Warning: --only-changed ignored (gitleaks needs the whole repository)")
+	})
+
+	t.Run("not a git repository", func(t *testing.T) {
+		cmd, stderr := newOnlyChangedTestCmd(t, "HEAD")
+		plain := t.TempDir()
+		args, _, err := applyOnlyChanged(cmd, "checkov", []string{plain})
+		require.NoError(t, err)
+		assert.Equal(t, []string{plain}, args)
+		assert.Contains(t, stderr.String(), "Warning: --only-changed ignored")
+	})
+
+	t.Run("file target", func(t *testing.T) {
+		cmd, stderr := newOnlyChangedTestCmd(t, "HEAD")
+		file := filepath.Join(dir, "main.tf")
+		args, _, err := applyOnlyChanged(cmd, "cfn-nag", []string{file})
+		require.NoError(t, err)
+		assert.Equal(t, []string{file}, args)
+		assert.Contains(t, stderr.String(), "is not a directory")
+	})
+}
+
+func TestOnlyChangedFlagDefaultsToHEAD(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("only-changed")
+	require.NotNil(t, flag)
+	assert.Equal(t, onlyChangedDefaultRef, flag.NoOptDefVal)
+}
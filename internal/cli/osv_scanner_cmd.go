@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var osvScannerCmd = &cobra.Command{
+	Use:   "osv-scanner [directory|lockfile]",
+	Short: "Scan dependencies for known vulnerabilities with OSV-Scanner",
+	Long: `Scan lockfiles and SBOMs against the OSV vulnerability database using OSV-Scanner.
+
+The target defaults to the current directory. When the target is a file it is
+scanned as a single lockfile.
+
+Examples:
+  # Scan the current directory
+  ship security osv-scanner
+
+  # Scan a monorepo recursively and emit SARIF
+  ship security osv-scanner ./services --recursive --format sarif
+
+  # Scan a single lockfile as JSON
+  ship security osv-scanner ./package-lock.json --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("osv-scanner", runOSVScanner),
+}
+
+// osvScannerFormats are the report formats supported by the osv-scanner command
+var osvScannerFormats = []string{"table", "json", "sarif", formatJUnit}
+
+func init() {
+	securityToolsCmd.AddCommand(osvScannerCmd)
+
+	osvScannerCmd.Flags().String("format", "table", "Output format (table, json, sarif, junit)")
+	osvScannerCmd.Flags().Bool("recursive", false, "Scan subdirectories for lockfiles")
+
+	registerEnumFlag(osvScannerCmd, "format", "table", "json", "sarif", formatJUnit)
+}
+
+func runOSVScanner(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	target := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "osv-scanner", args)
+
+	opts, err := osvScannerOptionsFromFlags(cmd)
+	if err != nil {
+		telemetry.TrackError("validation", "osv-scanner", err.Error())
+		return "", err
+	}
+
+	isLockfile, err := isRegularFile(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid scan target: %w", err)
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "osv-scanner", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	osvModule := modules.NewOSVScannerModule(engine.GetClient())
+	var result string
+	if isLockfile {
+		result, err = osvModule.ScanLockfile(ctx, target, opts...)
+	} else {
+		result, err = osvModule.ScanDirectory(ctx, target, opts...)
+	}
+	if err != nil {
+		telemetry.TrackError("osv-scanner", "scan", err.Error())
+		return "", fmt.Errorf("osv-scanner scan failed: %w", err)
+	}
+
+	if format, _ := cmd.Flags().GetString("format"); format == formatJUnit {
+		if result, err = sarifToJUnit("osv-scanner", result); err != nil {
+			return "", err
+		}
+	}
+
+	telemetry.TrackDaggerOperation("osv_scanner_scan", "osv-scanner", true, time.Since(start))
+	return result, nil
+}
+
+// osvScannerOptionsFromFlags maps the command's flags onto module options
+func osvScannerOptionsFromFlags(cmd *cobra.Command) ([]modules.OSVScannerOption, error) {
+	format, _ := cmd.Flags().GetString("format")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+
+	if !contains(osvScannerFormats, format) {
+		return nil, fmt.Errorf("invalid --format %q: must be one of %v", format, osvScannerFormats)
+	}
+
+	return []modules.OSVScannerOption{
+		modules.WithOSVScannerFormat(toolReportFormat(format)),
+		modules.WithOSVScannerRecursive(recursive),
+	}, nil
+}
+
+// isRegularFile reports whether path is a file rather than a directory
+func isRegularFile(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return info.Mode().IsRegular(), nil
+}
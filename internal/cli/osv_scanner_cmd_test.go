@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSVScannerOptionsFromFlags_Format(t *testing.T) {
+	setFlagsForTest(t, osvScannerCmd, map[string]string{
+		"format":    "sarif",
+		"recursive": "true",
+	})
+
+	opts, err := osvScannerOptionsFromFlags(osvScannerCmd)
+	require.NoError(t, err)
+
+	config := &modules.OSVScannerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	assert.Equal(t, "sarif", config.Format)
+	assert.True(t, config.Recursive)
+}
+
+func TestOSVScannerOptionsFromFlags_InvalidFormat(t *testing.T) {
+	setFlagsForTest(t, osvScannerCmd, map[string]string{"format": "xml"})
+
+	_, err := osvScannerOptionsFromFlags(osvScannerCmd)
+	assert.Error(t, err)
+}
+
+func TestIsRegularFile(t *testing.T) {
+	dir := t.TempDir()
+	lockfile := filepath.Join(dir, "go.sum")
+	require.NoError(t, os.WriteFile(lockfile, []byte(""), 0644))
+
+	isFile, err := isRegularFile(dir)
+	require.NoError(t, err)
+	assert.False(t, isFile, "directories should be scanned as directories")
+
+	isFile, err = isRegularFile(lockfile)
+	require.NoError(t, err)
+	assert.True(t, isFile, "files should be scanned as lockfiles")
+
+	_, err = isRegularFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
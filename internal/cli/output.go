@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Supported values for the global --output-format flag
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// outputEnvelope is the uniform JSON result printed with --output-format json
+type outputEnvelope struct {
+	Tool        string   `json:"tool"`
+	Status      string   `json:"status"`
+	Stdout      string   `json:"stdout"`
+	Diagnostics []string `json:"diagnostics"`
+}
+
+// toolRunFunc runs a tool command and returns its raw output
+type toolRunFunc func(cmd *cobra.Command, args []string) (string, error)
+
+func init() {
+	rootCmd.PersistentFlags().String("output-format", outputFormatText, "Result output format (text, json)")
+
+	registerEnumFlag(rootCmd, "output-format", outputFormatText, outputFormatJSON)
+}
+
+// runTool adapts a toolRunFunc into a cobra RunE that shows progress while the
+// tool runs and renders the result according to --output-format. Variables of
+// --env-file are exported before the tool runs. With --stdin
+// or a "-" target each target read from stdin is scanned in turn, and with
+// --targets-file each target listed in the file with its own flags.
+func runTool(tool string, run toolRunFunc) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validateNotifyOn(cmd); err != nil {
+			return err
+		}
+		if err := applyToolEnvFile(cmd); err != nil {
+			return err
+		}
+
+		entries, err := targetsFileEntries(cmd, args)
+		if err != nil {
+			return err
+		}
+		targets, rest, err := stdinTargets(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		stop := startProgress(cmd.ErrOrStderr(), progressEnabled(cmd), tool, progressInterval)
+		var result string
+		if entries != nil {
+			result, err = runTargetsFile(cmd, entries, func(target string) (string, error) {
+				return runToolTarget(cmd, tool, run, []string{target})
+			})
+		} else if targets != nil {
+			result, err = runStdinTargets(targets, func(target string) (string, error) {
+				return runToolTarget(cmd, tool, run, append([]string{target}, rest...))
+			})
+		} else {
+			result, err = runToolTarget(cmd, tool, run, args)
+		}
+		stop()
+
+		notifyScanResult(cmd, tool, result, err)
+		return handleOutput(cmd, tool, result, err)
+	}
+}
+
+// runToolTarget runs the tool for one set of arguments, serving and storing
+// results in the result cache and applying --only-changed
+func runToolTarget(cmd *cobra.Command, tool string, run toolRunFunc, args []string) (string, error) {
+	cache, key, err := resultCacheForCommand(cmd, tool, args)
+	if err != nil {
+		return "", err
+	}
+	if cache != nil {
+		if result, ok := cache.get(key); ok {
+			return result, nil
+		}
+	}
+
+	runArgs, cleanup, err := applyOnlyChanged(cmd, tool, args)
+	if errors.Is(err, errNoChangedFiles) {
+		return "No changed files to scan", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	result, err := run(cmd, runArgs)
+
+	// Only successful results are cached so failures are always retried
+	if cache != nil && err == nil {
+		if cacheErr := cache.put(key, tool, result); cacheErr != nil {
+			slog.Warn("failed to cache tool result", "tool", tool, "error", cacheErr)
+		}
+	}
+
+	return result, err
+}
+
+// handleOutput prints a tool result in the selected output format. The error is
+// returned unchanged so the exit status still reflects failure.
+func handleOutput(cmd *cobra.Command, tool string, result string, err error) error {
+	format, _ := cmd.Flags().GetString("output-format")
+	return writeOutput(cmd.OutOrStdout(), format, tool, result, err)
+}
+
+// writeOutput writes result to w as plain text or as a JSON envelope
+func writeOutput(w io.Writer, format string, tool string, result string, err error) error {
+	switch format {
+	case "", outputFormatText:
+		if result != "" {
+			fmt.Fprintln(w, result)
+		}
+		return err
+	case outputFormatJSON:
+		envelope := outputEnvelope{
+			Tool:        tool,
+			Status:      "ok",
+			Stdout:      strings.TrimRight(result, "\n"),
+			Diagnostics: []string{},
+		}
+		if err != nil {
+			envelope.Status = "error"
+			envelope.Diagnostics = append(envelope.Diagnostics, err.Error())
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(envelope); encodeErr != nil {
+			return fmt.Errorf("failed to encode output: %w", encodeErr)
+		}
+		return err
+	default:
+		return fmt.Errorf("invalid --output-format %q: must be %s or %s", format, outputFormatText, outputFormatJSON)
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOutput_JSONSuccess(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeOutput(&buf, outputFormatJSON, "terrascan", "{\"results\": []}\n", nil)
+	require.NoError(t, err)
+
+	var envelope outputEnvelope
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &envelope))
+	assert.Equal(t, "terrascan", envelope.Tool)
+	assert.Equal(t, "ok", envelope.Status)
+	assert.Equal(t, "{\"results\": []}", envelope.Stdout)
+	assert.Empty(t, envelope.Diagnostics)
+	assert.Contains(t, buf.String(), `"diagnostics": []`)
+}
+
+func TestWriteOutput_JSONError(t *testing.T) {
+	var buf bytes.Buffer
+	runErr := errors.New("checkov reported failed checks")
+	err := writeOutput(&buf, outputFormatJSON, "checkov", "Check: CKV_AWS_20 FAILED", runErr)
+	assert.Equal(t, runErr, err, "the original error should be returned for the exit status")
+
+	var envelope outputEnvelope
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &envelope))
+	assert.Equal(t, "checkov", envelope.Tool)
+	assert.Equal(t, "error", envelope.Status)
+	assert.Equal(t, "Check: CKV_AWS_20 FAILED", envelope.Stdout)
+	assert.Equal(t, []string{"checkov reported failed checks"}, envelope.Diagnostics)
+}
+
+func TestWriteOutput_Text(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeOutput(&buf, outputFormatText, "terrascan", "no violations", nil))
+	assert.Equal(t, "no violations\n", buf.String())
+}
+
+func TestWriteOutput_InvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, writeOutput(&buf, "yaml", "terrascan", "", nil))
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var parliamentCmd = &cobra.Command{
+	Use:   "parliament [policy-file]",
+	Short: "Lint AWS IAM policies with Parliament",
+	Long: `Lint AWS IAM policy documents for errors and risky patterns using Parliament.
+
+Provide either a policy file argument or an inline document with --policy-string.
+
+Examples:
+  # Lint a policy file
+  ship security parliament ./policy.json
+
+  # Lint an inline policy and emit JSON findings
+  ship security parliament --json --policy-string '{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("parliament", runParliament),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(parliamentCmd)
+
+	parliamentCmd.Flags().Bool("json", false, "Output findings as JSON")
+	parliamentCmd.Flags().String("policy-string", "", "Inline IAM policy document to lint")
+}
+
+func runParliament(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	policyString, _ := cmd.Flags().GetString("policy-string")
+
+	telemetry.TrackCLICommand("security", "parliament", args)
+
+	policyFile, err := parliamentPolicyFile(args, policyString)
+	if err != nil {
+		telemetry.TrackError("validation", "parliament", err.Error())
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "parliament", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	parliamentModule := modules.NewParliamentModule(engine.GetClient())
+	opts := []modules.ParliamentOption{modules.WithParliamentJSON(jsonOutput)}
+
+	var result string
+	if policyFile != "" {
+		result, err = parliamentModule.LintPolicy(ctx, policyFile, opts...)
+	} else {
+		result, err = parliamentModule.LintPolicyStdin(ctx, policyString, opts...)
+	}
+	if err != nil {
+		telemetry.TrackError("parliament", "lint", err.Error())
+		return "", fmt.Errorf("parliament lint failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("parliament_lint", "parliament", true, time.Since(start))
+	return result, nil
+}
+
+// parliamentPolicyFile returns the policy file to lint, or "" when the inline
+// policy string should be used. Exactly one input source is allowed.
+func parliamentPolicyFile(args []string, policyString string) (string, error) {
+	hasFile := len(args) > 0 && args[0] != ""
+	switch {
+	case hasFile && policyString != "":
+		return "", fmt.Errorf("provide either a policy file or --policy-string, not both")
+	case hasFile:
+		return args[0], nil
+	case policyString != "":
+		return "", nil
+	default:
+		return "", fmt.Errorf("a policy file argument or --policy-string is required")
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParliamentPolicyFile(t *testing.T) {
+	file, err := parliamentPolicyFile([]string{"policy.json"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "policy.json", file)
+
+	file, err = parliamentPolicyFile(nil, `{"Version":"2012-10-17"}`)
+	require.NoError(t, err)
+	assert.Empty(t, file, "inline policies are piped via stdin")
+
+	_, err = parliamentPolicyFile([]string{"policy.json"}, `{"Version":"2012-10-17"}`)
+	assert.Error(t, err)
+
+	_, err = parliamentPolicyFile(nil, "")
+	assert.Error(t, err)
+}
+
+func TestParliamentCmdFlags(t *testing.T) {
+	jsonFlag := parliamentCmd.Flags().Lookup("json")
+	require.NotNil(t, jsonFlag)
+	assert.Equal(t, "false", jsonFlag.DefValue)
+	assert.NotNil(t, parliamentCmd.Flags().Lookup("policy-string"))
+}
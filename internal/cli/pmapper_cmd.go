@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var pmapperCmd = &cobra.Command{
+	Use:   "pmapper",
+	Short: "Map AWS IAM privileges with PMapper",
+	Long: `Analyze AWS IAM principals and privilege escalation paths using PMapper.
+
+AWS credentials are taken from --profile or the AWS_* environment variables.`,
+}
+
+var pmapperVisualizeCmd = &cobra.Command{
+	Use:   "visualize",
+	Short: "Render the IAM privilege graph",
+	Long: `Render the IAM privilege graph for an AWS account.
+
+dot and graphml output is printed so it can be piped into other graph tools;
+png and svg output is written to --output-dir, relative to --artifact-dir or
+the current directory, and the file path is printed.
+
+Examples:
+  # Export the graph as GraphML
+  ship security pmapper visualize --profile prod --format graphml > iam.graphml
+
+  # Render a PNG into ./reports
+  ship security pmapper visualize --profile prod --format png --output-dir ./reports`,
+	RunE: runTool("pmapper", runPMapperVisualize),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(pmapperCmd)
+	pmapperCmd.AddCommand(pmapperVisualizeCmd)
+
+	pmapperCmd.PersistentFlags().String("profile", "", "AWS profile to use")
+
+	pmapperVisualizeCmd.Flags().String("format", "svg", "Output format (dot, graphml, png, svg)")
+	pmapperVisualizeCmd.Flags().String("output-dir", ".", "Directory to write png/svg output to")
+
+	registerEnumFlag(pmapperVisualizeCmd, "format", "dot", "graphml", "png", "svg")
+}
+
+func runPMapperVisualize(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	profile, _ := cmd.Flags().GetString("profile")
+	format, _ := cmd.Flags().GetString("format")
+	outputDir, err := pmapperOutputDirFromFlags(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	telemetry.TrackCLICommand("security", "pmapper_visualize", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "pmapper", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	pmapperModule := modules.NewPMapperModule(engine.GetClient())
+	result, err := pmapperModule.VisualizeGraph(ctx, profile, format, modules.WithPMapperOutputDir(outputDir))
+	if err != nil {
+		telemetry.TrackError("pmapper", "visualize", err.Error())
+		return "", fmt.Errorf("pmapper visualize failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("pmapper_visualize", "pmapper", true, time.Since(start))
+	return result, nil
+}
+
+// pmapperOutputDirFromFlags resolves --output-dir against --artifact-dir
+func pmapperOutputDirFromFlags(cmd *cobra.Command) (string, error) {
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	if outputDir == "" {
+		outputDir = "."
+	}
+	return cliArtifactPath(outputDir)
+}
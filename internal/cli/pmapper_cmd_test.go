@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPMapperOutputDirFromFlags_ArtifactDir(t *testing.T) {
+	artifactDir := filepath.Join(t.TempDir(), "artifacts")
+	t.Setenv(shipMcp.ArtifactDirEnv, artifactDir)
+
+	dir, err := pmapperOutputDirFromFlags(pmapperVisualizeCmd)
+	require.NoError(t, err)
+	assert.Equal(t, artifactDir, dir)
+	assert.DirExists(t, artifactDir)
+
+	setFlagsForTest(t, pmapperVisualizeCmd, map[string]string{"output-dir": "graphs"})
+	dir, err = pmapperOutputDirFromFlags(pmapperVisualizeCmd)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(artifactDir, "graphs"), dir)
+}
+
+func TestPMapperOutputDirFromFlags_Defaults(t *testing.T) {
+	t.Setenv(shipMcp.ArtifactDirEnv, "")
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	dir, err := pmapperOutputDirFromFlags(pmapperVisualizeCmd)
+	require.NoError(t, err)
+	assert.Equal(t, cwd, dir)
+
+	absolute := t.TempDir()
+	setFlagsForTest(t, pmapperVisualizeCmd, map[string]string{"output-dir": absolute})
+	dir, err = pmapperOutputDirFromFlags(pmapperVisualizeCmd)
+	require.NoError(t, err)
+	assert.Equal(t, absolute, dir)
+}
+
+func TestPMapperOutputDirFromFlags_IgnoresWorkingDir(t *testing.T) {
+	artifactDir := t.TempDir()
+	t.Setenv(shipMcp.ArtifactDirEnv, artifactDir)
+	t.Setenv(modules.WorkingDirEnv, t.TempDir())
+
+	setFlagsForTest(t, pmapperVisualizeCmd, map[string]string{"output-dir": "graphs"})
+	dir, err := pmapperOutputDirFromFlags(pmapperVisualizeCmd)
+	require.NoError(t, err)
+
+	// The visualization lands in the artifact directory, not the scanned project
+	assert.Equal(t, filepath.Join(artifactDir, "graphs"), dir)
+	assert.Equal(t, dir, modules.HostPath(dir))
+}
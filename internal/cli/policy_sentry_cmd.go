@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var policySentryCmd = &cobra.Command{
+	Use:   "policy-sentry",
+	Short: "Query AWS IAM metadata with Policy Sentry",
+	Long:  `Query the AWS IAM actions, resources and condition keys database bundled with Policy Sentry.`,
+}
+
+var policySentryQueryArnCmd = &cobra.Command{
+	Use:   "query-arn",
+	Short: "List ARN formats for a service",
+	Long: `List the ARN formats for an AWS service using Policy Sentry's arn-table.
+
+Examples:
+  # List all ARN formats for S3
+  ship security policy-sentry query-arn --service s3
+
+  # Show the ARN format for a single resource type as JSON
+  ship security policy-sentry query-arn --service s3 --name bucket --format json`,
+	Args: cobra.NoArgs,
+	RunE: runTool("policy-sentry", runPolicySentryQueryArn),
+}
+
+var policySentryQueryServiceCmd = &cobra.Command{
+	Use:   "query-service",
+	Short: "List all AWS services",
+	Long: `List all AWS services known to Policy Sentry using its service-table.
+
+Examples:
+  ship security policy-sentry query-service --format json`,
+	Args: cobra.NoArgs,
+	RunE: runTool("policy-sentry", runPolicySentryQueryService),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(policySentryCmd)
+	policySentryCmd.AddCommand(policySentryQueryArnCmd)
+	policySentryCmd.AddCommand(policySentryQueryServiceCmd)
+
+	policySentryQueryArnCmd.Flags().String("service", "", "AWS service prefix, e.g. s3 (required)")
+	policySentryQueryArnCmd.Flags().String("name", "", "Resource ARN type name, e.g. bucket")
+	policySentryQueryArnCmd.Flags().Bool("list-arn-types", false, "List ARN types only")
+	policySentryQueryArnCmd.Flags().String("format", "", "Output format (yaml, json)")
+	_ = policySentryQueryArnCmd.MarkFlagRequired("service")
+
+	policySentryQueryServiceCmd.Flags().String("format", "", "Output format (yaml, json, csv)")
+
+	registerEnumFlag(policySentryQueryArnCmd, "format", "yaml", "json")
+	registerEnumFlag(policySentryQueryServiceCmd, "format", "yaml", "json", "csv")
+}
+
+func runPolicySentryQueryArn(cmd *cobra.Command, args []string) (string, error) {
+	service, _ := cmd.Flags().GetString("service")
+	name, _ := cmd.Flags().GetString("name")
+	listArnTypes, _ := cmd.Flags().GetBool("list-arn-types")
+	format, _ := cmd.Flags().GetString("format")
+
+	return runPolicySentryQuery("query_arn", func(ctx context.Context, m *modules.PolicySentryModule) (string, error) {
+		return m.QueryArnTable(ctx, service, name, listArnTypes, format)
+	})
+}
+
+func runPolicySentryQueryService(cmd *cobra.Command, args []string) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+
+	return runPolicySentryQuery("query_service", func(ctx context.Context, m *modules.PolicySentryModule) (string, error) {
+		return m.QueryServiceTable(ctx, format)
+	})
+}
+
+// runPolicySentryQuery runs a Policy Sentry query against a fresh Dagger engine
+func runPolicySentryQuery(operation string, query func(context.Context, *modules.PolicySentryModule) (string, error)) (string, error) {
+	start := time.Now()
+	telemetry.TrackCLICommand("security", "policy-sentry_"+operation, nil)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "policy-sentry", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := query(ctx, modules.NewPolicySentryModule(engine.GetClient()))
+	if err != nil {
+		telemetry.TrackError("policy-sentry", operation, err.Error())
+		return "", fmt.Errorf("policy sentry %s failed: %w", operation, err)
+	}
+
+	telemetry.TrackDaggerOperation("policy_sentry_"+operation, "policy-sentry", true, time.Since(start))
+	return result, nil
+}
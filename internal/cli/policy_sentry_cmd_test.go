@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySentrySubcommandRouting(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "policy-sentry", "query-arn"})
+	require.NoError(t, err)
+	assert.Equal(t, policySentryQueryArnCmd, cmd)
+
+	cmd, _, err = rootCmd.Find([]string{"security", "policy-sentry", "query-service"})
+	require.NoError(t, err)
+	assert.Equal(t, policySentryQueryServiceCmd, cmd)
+}
+
+func TestPolicySentryQueryArnRequiresService(t *testing.T) {
+	flag := policySentryQueryArnCmd.Flags().Lookup("service")
+	require.NotNil(t, flag)
+	assert.Contains(t, flag.Annotations, "cobra_annotation_bash_completion_one_required_flag")
+}
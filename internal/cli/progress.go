@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// progressInterval is how often the progress indicator redraws
+const progressInterval = 250 * time.Millisecond
+
+var progressFrames = []string{"|", "/", "-", "\\"}
+
+func init() {
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress progress output on stderr")
+}
+
+// progressEnabled reports whether a progress indicator should be shown: only on
+// an interactive terminal and never with --quiet
+func progressEnabled(cmd *cobra.Command) bool {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	return !quiet && isTerminal(os.Stdout) && isTerminal(os.Stderr)
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// startProgress draws a spinner with the elapsed time on w until the returned
+// stop function is called. stop waits for the drawing goroutine to exit and
+// clears the line. When disabled nothing is written.
+func startProgress(w io.Writer, enabled bool, label string, interval time.Duration) (stop func()) {
+	if !enabled {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	start := time.Now()
+
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for frame := 0; ; frame++ {
+			elapsed := time.Since(start).Truncate(time.Second)
+			fmt.Fprintf(w, "\r%s running %s (%s)", progressFrames[frame%len(progressFrames)], label, elapsed)
+
+			select {
+			case <-done:
+				// Clear the spinner line so results start on a clean line
+				fmt.Fprint(w, "\r\033[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+	}
+}
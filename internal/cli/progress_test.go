@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for use by the progress goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestStartProgress_DisabledWritesNothing(t *testing.T) {
+	var out syncBuffer
+	stop := startProgress(&out, false, "prowler", time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	assert.Empty(t, out.String())
+}
+
+func TestStartProgress_StopsGoroutine(t *testing.T) {
+	var out syncBuffer
+	stop := startProgress(&out, true, "zap", time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("progress goroutine did not stop")
+	}
+
+	written := out.String()
+	assert.Contains(t, written, "running zap")
+	assert.True(t, strings.HasSuffix(written, "\r\033[K"), "spinner line should be cleared on stop")
+
+	// Nothing is written once stop has returned
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, written, out.String())
+}
+
+func TestProgressEnabled_NonTTY(t *testing.T) {
+	// go test does not attach stdout to a terminal
+	assert.False(t, progressEnabled(terrascanCmd))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// prowlerProviders are the cloud providers prowler can assess
+var prowlerProviders = []string{"aws", "azure", "gcp"}
+
+var prowlerCmd = &cobra.Command{
+	Use:   "prowler [provider]",
+	Short: "Assess cloud security posture with Prowler",
+	Long: `Assess an AWS, Azure or GCP account against Prowler's security checks. The
+provider defaults to aws; credentials are read from the provider's usual
+environment variables. For gcp, the key file named by
+GOOGLE_APPLICATION_CREDENTIALS is mounted into the scan.
+
+--region limits an AWS assessment to one region; every region is assessed
+otherwise.
+
+Use --mutelist to mute accepted findings with a Prowler mutelist YAML file.
+Muted findings stay in the report, flagged as muted, so they no longer count
+as failures.
+
+Examples:
+  # Assess the AWS account behind the current credentials
+  ship security prowler
+
+  # Assess one region, muting accepted findings
+  ship security prowler aws --region eu-west-1 --mutelist ./mutelist.yaml`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: prowlerProviders,
+	RunE:      runTool("prowler", runProwler),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(prowlerCmd)
+
+	prowlerCmd.Flags().String("region", "", "AWS region to assess (default: every region)")
+	prowlerCmd.Flags().String("mutelist", "", "Mutelist YAML file of accepted findings to mute")
+}
+
+func runProwler(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	provider := "aws"
+	if len(args) > 0 {
+		provider = args[0]
+	}
+
+	telemetry.TrackCLICommand("security", "prowler", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "prowler", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	prowlerModule := modules.NewProwlerModule(engine.GetClient())
+	result, err := prowlerModule.Scan(ctx, provider, prowlerOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("prowler", "scan", err.Error())
+		return "", fmt.Errorf("prowler scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("prowler_scan", "prowler", true, time.Since(start))
+	return result, nil
+}
+
+// prowlerOptionsFromFlags maps the command's flags onto module options
+func prowlerOptionsFromFlags(cmd *cobra.Command) []modules.ProwlerOption {
+	region, _ := cmd.Flags().GetString("region")
+	mutelist, _ := cmd.Flags().GetString("mutelist")
+
+	return []modules.ProwlerOption{
+		modules.WithProwlerRegion(region),
+		modules.WithProwlerMutelist(mutelist),
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProwlerCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "prowler"})
+	require.NoError(t, err)
+	assert.Equal(t, prowlerCmd, cmd)
+}
+
+func TestProwlerOptionsFromFlags(t *testing.T) {
+	config := &modules.ProwlerConfig{}
+	for _, opt := range prowlerOptionsFromFlags(prowlerCmd) {
+		opt(config)
+	}
+	assert.Empty(t, config.MutelistFile, "no mutelist by default")
+
+	setFlagsForTest(t, prowlerCmd, map[string]string{
+		"mutelist": "./mutelist.yaml",
+		"region":   "eu-west-1",
+	})
+
+	config = &modules.ProwlerConfig{}
+	for _, opt := range prowlerOptionsFromFlags(prowlerCmd) {
+		opt(config)
+	}
+	assert.Equal(t, "./mutelist.yaml", config.MutelistFile)
+	assert.Equal(t, "eu-west-1", config.Region)
+}
+
+func TestProwlerCmdRejectsUnknownProvider(t *testing.T) {
+	assert.Error(t, prowlerCmd.Args(prowlerCmd, []string{"oracle"}))
+	assert.NoError(t, prowlerCmd.Args(prowlerCmd, []string{"gcp"}))
+}
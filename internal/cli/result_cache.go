@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const defaultResultCacheTTL = 24 * time.Hour
+
+// resultCacheIgnoredFlags do not change a tool's output and are left out of the cache key
+var resultCacheIgnoredFlags = map[string]bool{
+	"cache-results":  true,
+	"cache-ttl":      true,
+	"output-format":  true,
+	"quiet":          true,
+	"log-level":      true,
+	"log-file":       true,
+	"no-cache":       true,
+	"notify-webhook": true,
+	"notify-on":      true,
+	"parallel":       true,
+	"stdin":          true,
+	"targets-file":   true,
+}
+
+// resultCacheImage is a tool image a cached command runs: the name its
+// SHIP_IMAGE_TAG_<TOOL> override is read under and its default reference
+type resultCacheImage struct {
+	Tool    string
+	Default string
+}
+
+// resultCacheTools are the tools whose result depends only on local files or
+// an image reference, so hashing those inputs identifies the result, mapped
+// to the images they run. Every other tool queries live systems such as
+// clusters, cloud accounts or registries and is never cached.
+var resultCacheTools = map[string][]resultCacheImage{
+	"actionlint":  {{"actionlint", "wolff2023/actionlint:latest"}},
+	"cfn-nag":     {{"cfn-nag", "stelligent/cfn_nag:latest"}},
+	"checkov":     {{"checkov", "bridgecrew/checkov:latest"}},
+	"conftest":    {{"conftest", "openpolicyagent/conftest:latest"}},
+	"gitleaks":    {{"gitleaks", "zricethezav/gitleaks:latest"}},
+	"grype":       {{"grype", "anchore/grype:latest"}},
+	"osv-scanner": {{"osv-scanner", "ghcr.io/google/osv-scanner:latest"}},
+	"parliament":  {{"parliament", "python:3.11-slim"}},
+	"sbom-scan":   {{"syft", "anchore/syft:latest"}, {"grype", "anchore/grype:latest"}},
+	"scan-all": {
+		{"checkov", "bridgecrew/checkov:latest"},
+		{"grype", "anchore/grype:latest"},
+		{"osv-scanner", "ghcr.io/google/osv-scanner:latest"},
+		{"trufflehog", "trufflesecurity/trufflehog:latest"},
+	},
+	"terrascan": {{"terrascan", "tenable/terrascan:latest"}},
+	"trivy":     {{"trivy", "aquasec/trivy:latest"}},
+}
+
+// resolveResultCacheImages pins image references to the digests they point
+// at now, so a new :latest image changes the cache key. It is a variable so
+// tests can avoid the engine.
+var resolveResultCacheImages = daggerImageDigests
+
+// resultCacheLiveFlags point a cacheable tool at a live system, such as a
+// Trivy Kubernetes compliance scan of a cluster, so runs that set them are not
+// cached
+var resultCacheLiveFlags = map[string]bool{
+	"kubeconfig": true,
+}
+
+func init() {
+	rootCmd.PersistentFlags().Bool("cache-results", false, "Reuse a previous result of a filesystem or image scan when the tool, its image digest, its flags and the scanned local files are unchanged (stored in ~/.ship/cache; tools that query clusters or cloud accounts, and remote targets such as images and repository URLs, are never cached)")
+	rootCmd.PersistentFlags().Duration("cache-ttl", defaultResultCacheTTL, "How long cached results stay valid with --cache-results")
+}
+
+// resultCache stores successful tool results on disk keyed by a hash of
+// everything that can change the result
+type resultCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+	// refresh skips cached results, as --no-cache does, while still storing
+	// the new ones
+	refresh bool
+}
+
+// cachedResult is the on-disk form of a cached tool result
+type cachedResult struct {
+	Tool      string    `json:"tool"`
+	CreatedAt time.Time `json:"created_at"`
+	Output    string    `json:"output"`
+}
+
+func newResultCache(dir string, ttl time.Duration) *resultCache {
+	return &resultCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// defaultResultCacheDir is ~/.ship/cache/results
+func defaultResultCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory for result cache: %w", err)
+	}
+	return filepath.Join(home, ".ship", "cache", "results"), nil
+}
+
+// get returns the cached output for key if present and not expired
+func (c *resultCache) get(key string) (string, bool) {
+	if c.refresh {
+		return "", false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+
+	var entry cachedResult
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+	if c.ttl > 0 && c.now().Sub(entry.CreatedAt) > c.ttl {
+		os.Remove(c.path(key))
+		return "", false
+	}
+
+	return entry.Output, true
+}
+
+// put stores output for key
+func (c *resultCache) put(key, tool, output string) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create result cache: %w", err)
+	}
+
+	data, err := json.Marshal(cachedResult{Tool: tool, CreatedAt: c.now(), Output: output})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached result: %w", err)
+	}
+
+	return os.WriteFile(c.path(key), data, 0600)
+}
+
+func (c *resultCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// resultCacheForCommand returns the cache and key for a tool run, or a nil
+// cache when --cache-results is off, the tool is not in resultCacheTools or
+// the run scans a remote target. With --no-cache results are refreshed rather
+// than served from the cache.
+func resultCacheForCommand(cmd *cobra.Command, tool string, args []string) (*resultCache, string, error) {
+	enabled, _ := cmd.Flags().GetBool("cache-results")
+	images, cacheable := resultCacheTools[tool]
+	if !enabled || !cacheable {
+		return nil, "", nil
+	}
+	flags := changedFlags(cmd)
+	if hasRemoteInput(flags, args) {
+		return nil, "", nil
+	}
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+
+	dir, err := defaultResultCacheDir()
+	if err != nil {
+		return nil, "", err
+	}
+
+	refs, err := resolveResultCacheImages(cmd.Context(), resultCacheImageRefs(images))
+	if err != nil {
+		return nil, "", err
+	}
+
+	key, err := resultCacheKey(cmd.CommandPath(), refs, flags, args)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cache := newResultCache(dir, ttl)
+	cache.refresh, _ = cmd.Flags().GetBool("no-cache")
+	return cache, key, nil
+}
+
+// hasRemoteInput reports whether a run scans something the cache key cannot
+// hash: an argument that is not a local path, such as an image, repository
+// or cluster, a flag set to a URL, or a flag in resultCacheLiveFlags. Those
+// can change without their name changing, so their results are not cached.
+func hasRemoteInput(flags []string, args []string) bool {
+	for _, arg := range args {
+		if _, err := os.Stat(modules.HostPath(arg)); err != nil {
+			return true
+		}
+	}
+	for _, flag := range flags {
+		name, value, ok := strings.Cut(flag, "=")
+		if resultCacheLiveFlags[name] || (ok && strings.Contains(value, "://")) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFlags lists the explicitly set flags that can affect a tool's result
+func changedFlags(cmd *cobra.Command) []string {
+	var flags []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !resultCacheIgnoredFlags[f.Name] {
+			flags = append(flags, f.Name+"="+f.Value.String())
+		}
+	})
+	sort.Strings(flags)
+	return flags
+}
+
+// resultCacheImageRefs returns the image references the tools run, with any
+// SHIP_IMAGE_TAG_<TOOL> override applied
+func resultCacheImageRefs(images []resultCacheImage) []string {
+	refs := make([]string, 0, len(images))
+	for _, image := range images {
+		refs = append(refs, modules.ImageTag(image.Tool, image.Default))
+	}
+	return refs
+}
+
+// daggerImageDigests resolves each reference to its digest through the
+// Dagger engine. References already pinned by digest are kept; when the
+// engine or registry is unreachable the references are used as they are.
+func daggerImageDigests(ctx context.Context, refs []string) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var engine *dagger.Engine
+	resolved := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if strings.Contains(ref, "@sha256:") {
+			resolved = append(resolved, ref)
+			continue
+		}
+		if engine == nil {
+			var err error
+			if engine, err = dagger.NewEngine(ctx); err != nil {
+				return refs, nil
+			}
+			defer engine.Close()
+		}
+		digest, err := engine.GetClient().Container().From(ref).ImageRef(ctx)
+		if err != nil {
+			digest = ref
+		}
+		resolved = append(resolved, digest)
+	}
+	return resolved, nil
+}
+
+// resultCacheKey hashes the command, the Ship version, the resolved tool
+// images, the flags and the content of every input path. Input paths are the
+// arguments and flag values that exist on disk, or the working directory when
+// the command has no arguments.
+func resultCacheKey(commandPath string, images []string, flags []string, args []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "command:%s\nversion:%s\n", commandPath, version)
+	for _, image := range images {
+		fmt.Fprintf(h, "image:%s\n", image)
+	}
+
+	inputs := args
+	if len(inputs) == 0 {
+		inputs = []string{scanTargetDir(nil)}
+	}
+
+	for _, flag := range flags {
+		fmt.Fprintf(h, "flag:%s\n", flag)
+		if _, value, ok := strings.Cut(flag, "="); ok {
+			inputs = append(inputs, value)
+		}
+	}
+
+	for _, arg := range args {
+		fmt.Fprintf(h, "arg:%s\n", arg)
+	}
+
+	for _, input := range inputs {
+		input = modules.HostPath(input)
+		if _, err := os.Stat(input); err != nil {
+			continue
+		}
+		if err := hashPath(h, input); err != nil {
+			return "", fmt.Errorf("failed to hash %s for result cache: %w", input, err)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashPath writes the relative path and content of every regular file under root
+func hashPath(h hash.Hash, root string) error {
+	fmt.Fprintf(h, "path:%s\n", root)
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "file:%s\n", filepath.ToSlash(rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(h, f)
+		return err
+	})
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkovImages is the image checkov runs by default
+var checkovImages = []string{"bridgecrew/checkov:latest"}
+
+func writeScanInput(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(content), 0644))
+}
+
+func TestResultCacheHit(t *testing.T) {
+	input := t.TempDir()
+	writeScanInput(t, input, `resource "aws_s3_bucket" "b" {}`)
+
+	cache := newResultCache(t.TempDir(), time.Hour)
+	key, err := resultCacheKey("ship security checkov", checkovImages, []string{"framework=terraform"}, []string{input})
+	require.NoError(t, err)
+
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.put(key, "checkov", "Passed checks: 3"))
+
+	again, err := resultCacheKey("ship security checkov", checkovImages, []string{"framework=terraform"}, []string{input})
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	output, ok := cache.get(again)
+	assert.True(t, ok)
+	assert.Equal(t, "Passed checks: 3", output)
+}
+
+func TestResultCacheRefreshSkipsCachedResults(t *testing.T) {
+	cache := newResultCache(t.TempDir(), time.Hour)
+	require.NoError(t, cache.put("key", "checkov", "old result"))
+
+	cache.refresh = true
+	_, ok := cache.get("key")
+	assert.False(t, ok, "--no-cache does not serve cached results")
+
+	require.NoError(t, cache.put("key", "checkov", "new result"))
+	cache.refresh = false
+	output, ok := cache.get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "new result", output)
+}
+
+func TestResultCacheMissAfterContentChange(t *testing.T) {
+	input := t.TempDir()
+	writeScanInput(t, input, `resource "aws_s3_bucket" "b" {}`)
+
+	cache := newResultCache(t.TempDir(), time.Hour)
+	key, err := resultCacheKey("ship security checkov", checkovImages, nil, []string{input})
+	require.NoError(t, err)
+	require.NoError(t, cache.put(key, "checkov", "old result"))
+
+	writeScanInput(t, input, `resource "aws_s3_bucket" "b" { acl = "public-read" }`)
+	changed, err := resultCacheKey("ship security checkov", checkovImages, nil, []string{input})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, key, changed)
+	_, ok := cache.get(changed)
+	assert.False(t, ok)
+}
+
+func TestResultCacheKeyChangesWithFlagsAndImage(t *testing.T) {
+	input := t.TempDir()
+	writeScanInput(t, input, "content")
+
+	base, err := resultCacheKey("ship security checkov", checkovImages, nil, []string{input})
+	require.NoError(t, err)
+
+	withFlag, err := resultCacheKey("ship security checkov", checkovImages, []string{"soft-fail=true"}, []string{input})
+	require.NoError(t, err)
+	assert.NotEqual(t, base, withFlag)
+
+	withImage, err := resultCacheKey("ship security checkov", []string{"bridgecrew/checkov:3.2.0"}, nil, []string{input})
+	require.NoError(t, err)
+	assert.NotEqual(t, base, withImage, "changing the tool image invalidates the cache")
+}
+
+func TestResultCacheImageRefs(t *testing.T) {
+	t.Setenv("SHIP_IMAGE_TAG_CHECKOV", "")
+	assert.Equal(t, []string{"bridgecrew/checkov:latest"}, resultCacheImageRefs(resultCacheTools["checkov"]))
+
+	t.Setenv("SHIP_IMAGE_TAG_CHECKOV", "bridgecrew/checkov:3.2.0")
+	assert.Equal(t, []string{"bridgecrew/checkov:3.2.0"}, resultCacheImageRefs(resultCacheTools["checkov"]))
+}
+
+func TestResultCacheForCommand_MissWhenLatestImageChanges(t *testing.T) {
+	cmd := newResultCacheTestCmd(t)
+	input := t.TempDir()
+	writeScanInput(t, input, "content")
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	stubResultCacheImages(t, func(refs []string) []string {
+		pinned := make([]string, len(refs))
+		for i, ref := range refs {
+			pinned[i] = ref + "@" + digest
+		}
+		return pinned
+	})
+
+	_, before, err := resultCacheForCommand(cmd, "checkov", []string{input})
+	require.NoError(t, err)
+	_, same, err := resultCacheForCommand(cmd, "checkov", []string{input})
+	require.NoError(t, err)
+	assert.Equal(t, before, same)
+
+	// A new image is pushed under the same :latest tag
+	digest = "sha256:" + strings.Repeat("b", 64)
+	_, after, err := resultCacheForCommand(cmd, "checkov", []string{input})
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after, "a new :latest image is a cache miss")
+}
+
+func TestResultCacheTTLExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := newResultCache(t.TempDir(), time.Hour)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.put("key", "trivy", "result"))
+
+	now = now.Add(30 * time.Minute)
+	_, ok := cache.get("key")
+	assert.True(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok = cache.get("key")
+	assert.False(t, ok)
+
+	_, err := os.Stat(cache.path("key"))
+	assert.True(t, os.IsNotExist(err), "expired entries are removed")
+}
+
+func TestHasRemoteInput(t *testing.T) {
+	input := t.TempDir()
+
+	assert.False(t, hasRemoteInput(nil, nil), "the working directory is local")
+	assert.False(t, hasRemoteInput([]string{"framework=terraform"}, []string{input}))
+	assert.True(t, hasRemoteInput(nil, []string{"alpine:3.19"}), "images are not cached")
+	assert.True(t, hasRemoteInput(nil, []string{input, "https://github.com/org/repo"}))
+	assert.True(t, hasRemoteInput([]string{"repo=https://github.com/org/repo"}, nil), "repository URLs in flags are not cached")
+}
+
+// newResultCacheTestCmd builds a command with --cache-results set, storing the
+// cache under a temporary home directory
+func newResultCacheTestCmd(t *testing.T) *cobra.Command {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	cmd := &cobra.Command{Use: "scan"}
+	cmd.Flags().Bool("cache-results", true, "")
+	cmd.Flags().Duration("cache-ttl", time.Hour, "")
+	cmd.Flags().Bool("no-cache", false, "")
+	cmd.Flags().String("kubeconfig", "", "")
+	stubResultCacheImages(t, func(refs []string) []string { return refs })
+	return cmd
+}
+
+// stubResultCacheImages resolves image digests with resolve instead of the engine
+func stubResultCacheImages(t *testing.T, resolve func(refs []string) []string) {
+	t.Helper()
+	original := resolveResultCacheImages
+	resolveResultCacheImages = func(ctx context.Context, refs []string) ([]string, error) {
+		return resolve(refs), nil
+	}
+	t.Cleanup(func() { resolveResultCacheImages = original })
+}
+
+func TestRunToolTarget_CachesFilesystemTools(t *testing.T) {
+	cmd := newResultCacheTestCmd(t)
+	input := t.TempDir()
+	writeScanInput(t, input, `resource "aws_s3_bucket" "b" {}`)
+
+	var calls [][]string
+	run := recordingRun(&calls, func(string) (string, error) { return "Passed checks: 3", nil })
+
+	for i := 0; i < 2; i++ {
+		result, err := runToolTarget(cmd, "checkov", run, []string{input})
+		require.NoError(t, err)
+		assert.Equal(t, "Passed checks: 3", result)
+	}
+	assert.Len(t, calls, 1, "the second run is served from the cache")
+}
+
+func TestRunToolTarget_NeverCachesKubeBench(t *testing.T) {
+	cmd := newResultCacheTestCmd(t)
+	t.Setenv(modules.WorkingDirEnv, t.TempDir())
+
+	var calls [][]string
+	run := func(cmd *cobra.Command, args []string) (string, error) {
+		calls = append(calls, args)
+		return "[PASS] 1.1.1", nil
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := runToolTarget(cmd, "kube-bench", run, nil)
+		require.NoError(t, err)
+	}
+	assert.Len(t, calls, 2, "kube-bench queries the live cluster on every run")
+
+	cache, _, err := resultCacheForCommand(cmd, "kube-bench", nil)
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+}
+
+func TestResultCacheForCommand_SkipsLiveClusterScans(t *testing.T) {
+	cmd := newResultCacheTestCmd(t)
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1"), 0600))
+	require.NoError(t, cmd.Flags().Set("kubeconfig", kubeconfig))
+
+	cache, _, err := resultCacheForCommand(cmd, "trivy", nil)
+	require.NoError(t, err)
+	assert.Nil(t, cache, "a kubeconfig points trivy at a live cluster")
+}
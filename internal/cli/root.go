@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/cloudshipai/ship/internal/logger"
@@ -42,14 +44,41 @@ func init() {
 	rootCmd.Version = version
 
 	// Set up logging
-	rootCmd.PersistentFlags().String("log-level", "info", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().String("log-level", "info", "Log level (debug, info, warn, error) (env: "+logger.LevelEnv+")")
 	rootCmd.PersistentFlags().String("log-file", "", "Log file path")
+	registerEnumFlag(rootCmd, "log-level", logger.Levels...)
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		// Read log level and log file from flags
-		logLevel, _ := cmd.Flags().GetString("log-level")
-		logFile, _ := cmd.Flags().GetString("log-file")
+		// Reject invalid enum flag values before doing any work
+		if err := validateEnumFlags(cmd); err != nil {
+			return err
+		}
 
 		// Configure logger
-		return logger.Init(logLevel, logFile)
+		logFile, _ := cmd.Flags().GetString("log-file")
+		if err := logger.Init(logLevelFromFlags(cmd), logFile); err != nil {
+			return err
+		}
+
+		configureArtifactDir(cmd)
+		if err := configureOffline(cmd); err != nil {
+			return err
+		}
+		if err := configureWorkingDir(cmd); err != nil {
+			return err
+		}
+		return configureEngine(cmd)
+	}
+}
+
+// logLevelFromFlags returns --log-level when given, then SHIP_LOG_LEVEL, then
+// the flag's default
+func logLevelFromFlags(cmd *cobra.Command) string {
+	level, _ := cmd.Flags().GetString("log-level")
+	if cmd.Flags().Changed("log-level") {
+		return level
+	}
+	if env := os.Getenv(logger.LevelEnv); env != "" {
+		return env
 	}
+	return level
 }
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogLevelTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("log-level", "info", "")
+	return cmd
+}
+
+func TestLogLevelFromFlags(t *testing.T) {
+	t.Setenv(logger.LevelEnv, "")
+	assert.Equal(t, "info", logLevelFromFlags(newLogLevelTestCommand()))
+
+	t.Setenv(logger.LevelEnv, "debug")
+	assert.Equal(t, "debug", logLevelFromFlags(newLogLevelTestCommand()), "the environment overrides the default")
+
+	cmd := newLogLevelTestCommand()
+	require.NoError(t, cmd.Flags().Set("log-level", "error"))
+	assert.Equal(t, "error", logLevelFromFlags(cmd), "the flag overrides the environment")
+}
+
+func TestLogLevelFlagValidated(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("log-level")
+	require.NotNil(t, flag)
+	assert.Equal(t, logger.Levels, flag.Annotations[enumValuesAnnotation])
+}
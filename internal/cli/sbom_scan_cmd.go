@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// sbomScanFiles maps the SBOM formats grype can read back to the file name
+// written with --keep-sbom
+var sbomScanFiles = map[string]string{
+	"cyclonedx-json": "sbom.cdx.json",
+	"spdx-json":      "sbom.spdx.json",
+	"syft-json":      "sbom.syft.json",
+}
+
+// sbomScanReportFormats are the Grype report formats accepted by --format
+var sbomScanReportFormats = []string{"json", "sarif", "table"}
+
+var sbomScanCmd = &cobra.Command{
+	Use:   "sbom-scan <target>",
+	Short: "Generate an SBOM with Syft and scan it for vulnerabilities with Grype",
+	Long: `Catalog a directory, archive or container image with Syft, then scan the
+resulting SBOM with Grype in one step.
+
+The output is a JSON document holding the SBOM and the Grype report. The
+intermediate SBOM is removed afterwards unless --keep-sbom is set, in which
+case it is written to --artifact-dir, or the current directory, and its
+absolute path is included.
+
+With --with-vulns the vulnerabilities Grype finds are embedded in the
+CycloneDX SBOM instead, and the enriched SBOM is printed, and kept, as a
+single artifact.
+
+Examples:
+  # Scan the current directory
+  ship security sbom-scan .
+
+  # Scan an image and keep its CycloneDX SBOM
+  ship security sbom-scan alpine:3.19 --keep-sbom
+
+  # Use SPDX and report findings as SARIF
+  ship security sbom-scan ./service --sbom-format spdx-json --format sarif
+
+  # Keep one CycloneDX SBOM with the vulnerabilities embedded
+  ship security sbom-scan alpine:3.19 --with-vulns --keep-sbom`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTool("sbom-scan", runSBOMScan),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(sbomScanCmd)
+
+	sbomScanCmd.Flags().String("sbom-format", "cyclonedx-json", "SBOM format generated by Syft (cyclonedx-json, spdx-json, syft-json)")
+	sbomScanCmd.Flags().String("format", "json", "Grype report format ("+strings.Join(sbomScanReportFormats, ", ")+")")
+	sbomScanCmd.Flags().Bool("keep-sbom", false, "Keep the generated SBOM in --artifact-dir or the current directory")
+	sbomScanCmd.Flags().Bool("with-vulns", false, "Embed Grype's vulnerabilities in the CycloneDX SBOM and print the enriched SBOM")
+
+	registerEnumFlag(sbomScanCmd, "sbom-format", "cyclonedx-json", "spdx-json", "syft-json")
+	registerEnumFlag(sbomScanCmd, "format", sbomScanReportFormats...)
+}
+
+// sbomGenerator is the subset of the Syft module sbom-scan uses
+type sbomGenerator interface {
+	GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []modules.SyftOutput) error
+}
+
+// sbomScanResult is the combined output of sbom-scan. Report holds the Grype
+// output as JSON, or as a JSON string for table reports.
+type sbomScanResult struct {
+	Target   string          `json:"target"`
+	SBOMPath string          `json:"sbom_path,omitempty"`
+	SBOM     json.RawMessage `json:"sbom"`
+	Report   json.RawMessage `json:"report"`
+}
+
+func runSBOMScan(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	sbomFormat, _ := cmd.Flags().GetString("sbom-format")
+	reportFormat, _ := cmd.Flags().GetString("format")
+	keepSBOM, _ := cmd.Flags().GetBool("keep-sbom")
+	withVulns, _ := cmd.Flags().GetBool("with-vulns")
+
+	telemetry.TrackCLICommand("security", "sbom-scan", args)
+
+	if _, ok := sbomScanFiles[sbomFormat]; !ok {
+		return "", fmt.Errorf("unsupported --sbom-format %q: expected cyclonedx-json, spdx-json or syft-json", sbomFormat)
+	}
+	if !contains(sbomScanReportFormats, reportFormat) {
+		return "", fmt.Errorf("unsupported --format %q: expected one of %s", reportFormat, strings.Join(sbomScanReportFormats, ", "))
+	}
+	if withVulns {
+		if sbomFormat != "cyclonedx-json" {
+			return "", fmt.Errorf("--with-vulns requires --sbom-format cyclonedx-json")
+		}
+		if cmd.Flags().Changed("format") {
+			return "", fmt.Errorf("--format cannot be used with --with-vulns, which prints the enriched SBOM")
+		}
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "sbom-scan", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	client := engine.GetClient()
+	result, err := sbomThenScan(ctx, modules.NewSyftModule(client), modules.NewGrypeModule(client), scanTargetDir(args), sbomFormat, reportFormat, keepSBOM, withVulns)
+	if err != nil {
+		telemetry.TrackError("sbom-scan", "scan", err.Error())
+		return "", fmt.Errorf("sbom-scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("sbom_scan", "sbom-scan", true, time.Since(start))
+	return result, nil
+}
+
+// sbomThenScan generates an SBOM for target and scans it with Grype. The SBOM
+// is written to a temporary directory that is removed afterwards, or kept as
+// an artifact when keepSBOM is set. withVulns returns, and keeps, the
+// CycloneDX SBOM enriched with Grype's vulnerabilities instead of the
+// combined result.
+func sbomThenScan(ctx context.Context, generator sbomGenerator, scanner grypeScanner, target, sbomFormat, reportFormat string, keepSBOM, withVulns bool) (string, error) {
+	var sbomPath string
+	if keepSBOM {
+		path, err := shipMcp.ArtifactPath(sbomScanFiles[sbomFormat])
+		if err != nil {
+			return "", err
+		}
+		sbomPath = path
+	} else {
+		tmpDir, err := os.MkdirTemp("", "ship-sbom-scan-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create SBOM directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		sbomPath = filepath.Join(tmpDir, sbomScanFiles[sbomFormat])
+	}
+
+	kind, ref := sbomScanSource(target)
+	if err := generator.GenerateSBOMFiles(ctx, kind, ref, []modules.SyftOutput{{Format: sbomFormat, Path: sbomPath}}); err != nil {
+		return "", fmt.Errorf("failed to generate SBOM: %w", err)
+	}
+
+	sbom, err := os.ReadFile(sbomPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read generated SBOM: %w", err)
+	}
+	if !json.Valid(sbom) {
+		return "", fmt.Errorf("syft produced an invalid %s SBOM", sbomFormat)
+	}
+
+	if withVulns {
+		// Grype reports the vulnerabilities in CycloneDX so they can be merged
+		reportFormat = "cyclonedx-json"
+	}
+	report, err := scanner.ScanSBOM(ctx, sbomPath, modules.WithGrypeFormat(reportFormat))
+	if err != nil {
+		return "", fmt.Errorf("failed to scan SBOM: %w", err)
+	}
+
+	if withVulns {
+		enriched, err := mergeCycloneDXVulnerabilities(sbom, []byte(report))
+		if err != nil {
+			return "", err
+		}
+		if keepSBOM {
+			if err := os.WriteFile(sbomPath, enriched, 0o644); err != nil {
+				return "", fmt.Errorf("failed to write enriched SBOM: %w", err)
+			}
+		}
+		return string(enriched), nil
+	}
+
+	result := sbomScanResult{
+		Target: target,
+		SBOM:   json.RawMessage(sbom),
+		Report: jsonOrString(report),
+	}
+	if keepSBOM {
+		result.SBOMPath = sbomPath
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// sbomScanSource picks the Syft source kind for target: an existing directory,
+// a tarball or otherwise an image pulled from its registry
+func sbomScanSource(target string) (string, string) {
+	if info, err := os.Stat(target); err == nil {
+		if info.IsDir() {
+			return modules.SyftSourceDirectory, target
+		}
+		return modules.SyftSourceArchive, target
+	}
+	return modules.SyftSourceImage, "registry:" + target
+}
+
+// jsonOrString returns output unchanged when it is JSON and encoded as a JSON
+// string otherwise
+func jsonOrString(output string) json.RawMessage {
+	trimmed := strings.TrimSpace(output)
+	if trimmed != "" && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	data, _ := json.Marshal(output)
+	return data
+}
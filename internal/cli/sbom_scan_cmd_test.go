@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSBOM = `{"bomFormat":"CycloneDX","components":[{"name":"openssl","version":"3.1.4"}]}`
+
+// fakeSBOMGenerator writes a fixed SBOM to every requested output
+type fakeSBOMGenerator struct {
+	kind    string
+	ref     string
+	outputs []modules.SyftOutput
+	err     error
+}
+
+func (f *fakeSBOMGenerator) GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []modules.SyftOutput) error {
+	f.kind, f.ref, f.outputs = kind, ref, outputs
+	if f.err != nil {
+		return f.err
+	}
+	for _, output := range outputs {
+		if err := os.WriteFile(output.Path, []byte(testSBOM), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sbomReadingScanner records the SBOM contents passed to ScanSBOM
+type sbomReadingScanner struct {
+	fakeGrypeScanner
+	sbom string
+}
+
+func (s *sbomReadingScanner) ScanSBOM(ctx context.Context, sbomPath string, opts ...modules.GrypeOption) (string, error) {
+	data, err := os.ReadFile(sbomPath)
+	if err != nil {
+		return "", err
+	}
+	s.sbom = string(data)
+	s.record("ScanSBOM", sbomPath, opts)
+	return `{"matches":[{"vulnerability":{"id":"CVE-2024-0001"}}]}`, nil
+}
+
+func TestSBOMScanCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "sbom-scan"})
+	require.NoError(t, err)
+	assert.Equal(t, sbomScanCmd, cmd)
+}
+
+func TestSBOMThenScan_PassesGeneratedSBOMToGrype(t *testing.T) {
+	generator := &fakeSBOMGenerator{}
+	scanner := &sbomReadingScanner{}
+
+	output, err := sbomThenScan(context.Background(), generator, scanner, "alpine:3.19", "cyclonedx-json", "json", false, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, modules.SyftSourceImage, generator.kind)
+	assert.Equal(t, "registry:alpine:3.19", generator.ref)
+	require.Len(t, generator.outputs, 1)
+	assert.Equal(t, "cyclonedx-json", generator.outputs[0].Format)
+
+	assert.Equal(t, "ScanSBOM", scanner.method)
+	assert.Equal(t, generator.outputs[0].Path, scanner.target, "grype scans the SBOM syft wrote")
+	assert.Equal(t, testSBOM, scanner.sbom)
+	assert.Equal(t, "json", scanner.config.Format)
+
+	var result sbomScanResult
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, "alpine:3.19", result.Target)
+	assert.JSONEq(t, testSBOM, string(result.SBOM))
+	assert.JSONEq(t, `{"matches":[{"vulnerability":{"id":"CVE-2024-0001"}}]}`, string(result.Report))
+	assert.Empty(t, result.SBOMPath)
+
+	_, err = os.Stat(scanner.target)
+	assert.True(t, os.IsNotExist(err), "the intermediate SBOM is removed")
+}
+
+func TestSBOMThenScan_KeepSBOM(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	scanner := &sbomReadingScanner{}
+	output, err := sbomThenScan(context.Background(), &fakeSBOMGenerator{}, scanner, dir, "spdx-json", "json", true, false)
+	require.NoError(t, err)
+
+	var result sbomScanResult
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, scanner.target, result.SBOMPath)
+	assert.Equal(t, "sbom.spdx.json", filepath.Base(result.SBOMPath))
+	assert.True(t, filepath.IsAbs(result.SBOMPath))
+
+	kept, err := os.ReadFile(result.SBOMPath)
+	require.NoError(t, err)
+	assert.Equal(t, testSBOM, string(kept))
+}
+
+func TestSBOMThenScan_KeepSBOMInArtifactDir(t *testing.T) {
+	artifactDir := filepath.Join(t.TempDir(), "artifacts")
+	t.Setenv(shipMcp.ArtifactDirEnv, artifactDir)
+
+	output, err := sbomThenScan(context.Background(), &fakeSBOMGenerator{}, &sbomReadingScanner{}, "alpine:3.19", "cyclonedx-json", "json", true, false)
+	require.NoError(t, err)
+
+	var result sbomScanResult
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, filepath.Join(artifactDir, "sbom.cdx.json"), result.SBOMPath)
+	assert.FileExists(t, result.SBOMPath)
+}
+
+func TestSBOMThenScan_GenerateFailureSkipsGrype(t *testing.T) {
+	scanner := &sbomReadingScanner{}
+	_, err := sbomThenScan(context.Background(), &fakeSBOMGenerator{err: errors.New("image not found")}, scanner, "missing:latest", "cyclonedx-json", "json", false, false)
+	assert.ErrorContains(t, err, "failed to generate SBOM: image not found")
+	assert.Empty(t, scanner.method)
+}
+
+func TestSBOMScanSource(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "image.tar")
+	require.NoError(t, os.WriteFile(archive, nil, 0o644))
+
+	kind, ref := sbomScanSource(dir)
+	assert.Equal(t, modules.SyftSourceDirectory, kind)
+	assert.Equal(t, dir, ref)
+
+	kind, ref = sbomScanSource(archive)
+	assert.Equal(t, modules.SyftSourceArchive, kind)
+	assert.Equal(t, archive, ref)
+
+	kind, ref = sbomScanSource("nginx:1.25")
+	assert.Equal(t, modules.SyftSourceImage, kind)
+	assert.Equal(t, "registry:nginx:1.25", ref)
+}
+
+func TestJSONOrString(t *testing.T) {
+	assert.Equal(t, `{"a":1}`, string(jsonOrString("{\"a\":1}\n")))
+	assert.Equal(t, `"NAME  VULNERABILITY\n"`, string(jsonOrString("NAME  VULNERABILITY\n")))
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// cycloneDXComponent holds the component fields used to match Grype's
+// components to the SBOM's
+type cycloneDXComponent struct {
+	BOMRef string `json:"bom-ref"`
+	PURL   string `json:"purl"`
+}
+
+// mergeCycloneDXVulnerabilities embeds the vulnerabilities of a Grype
+// CycloneDX report into a Syft CycloneDX SBOM. Grype names components with its
+// own bom-refs, so each affected ref is rewritten to the SBOM component with
+// the same package URL. Vulnerabilities the SBOM already lists are kept and
+// not duplicated.
+func mergeCycloneDXVulnerabilities(sbom []byte, grypeReport []byte) ([]byte, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(sbom, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse CycloneDX SBOM: %w", err)
+	}
+
+	var report struct {
+		Components      []cycloneDXComponent `json:"components"`
+		Vulnerabilities []map[string]any     `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(grypeReport, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse Grype CycloneDX report: %w", err)
+	}
+
+	var components []cycloneDXComponent
+	if raw, ok := document["components"]; ok {
+		if err := json.Unmarshal(raw, &components); err != nil {
+			return nil, fmt.Errorf("failed to parse SBOM components: %w", err)
+		}
+	}
+	refsByPURL := make(map[string]string, len(components))
+	for _, component := range components {
+		if component.PURL != "" && component.BOMRef != "" {
+			refsByPURL[component.PURL] = component.BOMRef
+		}
+	}
+	refs := make(map[string]string, len(report.Components))
+	for _, component := range report.Components {
+		if ref, ok := refsByPURL[component.PURL]; ok {
+			refs[component.BOMRef] = ref
+		}
+	}
+
+	var vulnerabilities []map[string]any
+	if raw, ok := document["vulnerabilities"]; ok {
+		if err := json.Unmarshal(raw, &vulnerabilities); err != nil {
+			return nil, fmt.Errorf("failed to parse SBOM vulnerabilities: %w", err)
+		}
+	}
+	seen := make(map[string]bool, len(vulnerabilities))
+	for _, vulnerability := range vulnerabilities {
+		seen[vulnerabilityKey(vulnerability)] = true
+	}
+
+	for _, vulnerability := range report.Vulnerabilities {
+		remapAffectedRefs(vulnerability, refs)
+		if key := vulnerabilityKey(vulnerability); !seen[key] {
+			seen[key] = true
+			vulnerabilities = append(vulnerabilities, vulnerability)
+		}
+	}
+
+	if vulnerabilities == nil {
+		vulnerabilities = []map[string]any{}
+	}
+	merged, err := json.Marshal(vulnerabilities)
+	if err != nil {
+		return nil, err
+	}
+	document["vulnerabilities"] = merged
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// remapAffectedRefs points a vulnerability's affects entries at the SBOM's
+// bom-refs, leaving refs without a matching component unchanged
+func remapAffectedRefs(vulnerability map[string]any, refs map[string]string) {
+	affects, _ := vulnerability["affects"].([]any)
+	for _, affected := range affects {
+		entry, ok := affected.(map[string]any)
+		if !ok {
+			continue
+		}
+		if ref, ok := refs[fmt.Sprint(entry["ref"])]; ok {
+			entry["ref"] = ref
+		}
+	}
+}
+
+// vulnerabilityKey identifies a vulnerability by its ID and affected refs
+func vulnerabilityKey(vulnerability map[string]any) string {
+	key := []string{fmt.Sprint(vulnerability["id"])}
+	affects, _ := vulnerability["affects"].([]any)
+	for _, affected := range affects {
+		if entry, ok := affected.(map[string]any); ok {
+			key = append(key, fmt.Sprint(entry["ref"]))
+		}
+	}
+	return strings.Join(key, "|")
+}
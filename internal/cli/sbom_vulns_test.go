@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	shipMcp "github.com/cloudshipai/ship/internal/cli/mcp"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSyftCycloneDX is an inventory-only SBOM as Syft writes it
+const testSyftCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "pkg:apk/alpine/openssl@3.1.4?package-id=0a1b", "type": "library", "name": "openssl", "version": "3.1.4", "purl": "pkg:apk/alpine/openssl@3.1.4"},
+    {"bom-ref": "pkg:apk/alpine/zlib@1.3?package-id=2c3d", "type": "library", "name": "zlib", "version": "1.3", "purl": "pkg:apk/alpine/zlib@1.3"}
+  ]
+}`
+
+// testGrypeCycloneDX is Grype's CycloneDX report for testSyftCycloneDX, which
+// refers to components by its own bom-refs
+const testGrypeCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "f00dfeed", "type": "library", "name": "openssl", "version": "3.1.4", "purl": "pkg:apk/alpine/openssl@3.1.4"}
+  ],
+  "vulnerabilities": [
+    {
+      "bom-ref": "urn:uuid:1",
+      "id": "CVE-2024-0727",
+      "source": {"name": "alpine:distro:alpine:3.19", "url": "https://security.alpinelinux.org/vuln/CVE-2024-0727"},
+      "ratings": [{"score": 5.5, "severity": "medium", "method": "CVSSv31"}],
+      "affects": [{"ref": "f00dfeed"}]
+    },
+    {
+      "bom-ref": "urn:uuid:2",
+      "id": "CVE-2024-9999",
+      "ratings": [{"severity": "high"}],
+      "affects": [{"ref": "unknown-component"}]
+    }
+  ]
+}`
+
+type cycloneDXVulnerability struct {
+	ID      string `json:"id"`
+	Affects []struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+	Ratings []struct {
+		Severity string `json:"severity"`
+	} `json:"ratings"`
+}
+
+func enrichedVulnerabilities(t *testing.T, enriched []byte) []cycloneDXVulnerability {
+	t.Helper()
+	var document struct {
+		BOMFormat       string                   `json:"bomFormat"`
+		Components      []cycloneDXComponent     `json:"components"`
+		Vulnerabilities []cycloneDXVulnerability `json:"vulnerabilities"`
+	}
+	require.NoError(t, json.Unmarshal(enriched, &document))
+	assert.Equal(t, "CycloneDX", document.BOMFormat)
+	assert.Len(t, document.Components, 2, "the SBOM inventory is kept")
+	return document.Vulnerabilities
+}
+
+func TestMergeCycloneDXVulnerabilities(t *testing.T) {
+	enriched, err := mergeCycloneDXVulnerabilities([]byte(testSyftCycloneDX), []byte(testGrypeCycloneDX))
+	require.NoError(t, err)
+
+	vulnerabilities := enrichedVulnerabilities(t, enriched)
+	require.Len(t, vulnerabilities, 2)
+
+	assert.Equal(t, "CVE-2024-0727", vulnerabilities[0].ID)
+	require.Len(t, vulnerabilities[0].Affects, 1)
+	assert.Equal(t, "pkg:apk/alpine/openssl@3.1.4?package-id=0a1b", vulnerabilities[0].Affects[0].Ref, "refs point at the SBOM's components")
+	assert.Equal(t, "medium", vulnerabilities[0].Ratings[0].Severity)
+
+	assert.Equal(t, "CVE-2024-9999", vulnerabilities[1].ID)
+	assert.Equal(t, "unknown-component", vulnerabilities[1].Affects[0].Ref, "unmatched refs are left unchanged")
+}
+
+func TestMergeCycloneDXVulnerabilities_NoDuplicates(t *testing.T) {
+	enriched, err := mergeCycloneDXVulnerabilities([]byte(testSyftCycloneDX), []byte(testGrypeCycloneDX))
+	require.NoError(t, err)
+
+	again, err := mergeCycloneDXVulnerabilities(enriched, []byte(testGrypeCycloneDX))
+	require.NoError(t, err)
+	assert.Len(t, enrichedVulnerabilities(t, again), 2)
+}
+
+func TestMergeCycloneDXVulnerabilities_NoFindings(t *testing.T) {
+	enriched, err := mergeCycloneDXVulnerabilities([]byte(testSyftCycloneDX), []byte(`{"bomFormat":"CycloneDX"}`))
+	require.NoError(t, err)
+	assert.Contains(t, string(enriched), `"vulnerabilities": []`)
+}
+
+func TestMergeCycloneDXVulnerabilities_InvalidReport(t *testing.T) {
+	_, err := mergeCycloneDXVulnerabilities([]byte(testSyftCycloneDX), []byte("NAME  VULNERABILITY"))
+	assert.ErrorContains(t, err, "failed to parse Grype CycloneDX report")
+}
+
+// cycloneDXReportScanner returns testGrypeCycloneDX from ScanSBOM
+type cycloneDXReportScanner struct {
+	fakeGrypeScanner
+}
+
+func (s *cycloneDXReportScanner) ScanSBOM(ctx context.Context, sbomPath string, opts ...modules.GrypeOption) (string, error) {
+	s.record("ScanSBOM", sbomPath, opts)
+	return testGrypeCycloneDX, nil
+}
+
+// cycloneDXSBOMGenerator writes testSyftCycloneDX to every requested output
+type cycloneDXSBOMGenerator struct{}
+
+func (cycloneDXSBOMGenerator) GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []modules.SyftOutput) error {
+	for _, output := range outputs {
+		if err := os.WriteFile(output.Path, []byte(testSyftCycloneDX), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestSBOMThenScan_WithVulns(t *testing.T) {
+	artifactDir := t.TempDir()
+	t.Setenv(shipMcp.ArtifactDirEnv, artifactDir)
+
+	scanner := &cycloneDXReportScanner{}
+	output, err := sbomThenScan(context.Background(), cycloneDXSBOMGenerator{}, scanner, "alpine:3.19", "cyclonedx-json", "json", true, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cyclonedx-json", scanner.config.Format, "grype reports in CycloneDX to merge")
+	assert.Len(t, enrichedVulnerabilities(t, []byte(output)), 2)
+
+	kept, err := os.ReadFile(filepath.Join(artifactDir, "sbom.cdx.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, output, string(kept), "the kept SBOM is the enriched one")
+}
+
+func TestRunSBOMScan_WithVulnsRequiresCycloneDX(t *testing.T) {
+	setFlagsForTest(t, sbomScanCmd, map[string]string{
+		"with-vulns":  "true",
+		"sbom-format": "spdx-json",
+	})
+
+	_, err := runSBOMScan(sbomScanCmd, []string{"."})
+	assert.ErrorContains(t, err, "--with-vulns requires --sbom-format cyclonedx-json")
+}
@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/cloudshipai/ship/internal/report/junit"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/spf13/cobra"
+)
+
+var scanAllCmd = &cobra.Command{
+	Use:   "scan-all [directory]",
+	Short: "Run several scanners and report their combined findings",
+	Long: `Run checkov, grype, osv-scanner and trufflehog against a directory and
+combine their findings into one report.
+
+Each tool's output (SARIF, or Grype's and TruffleHog's JSON results) is normalized so
+findings can be compared across tools. With --dedup, findings with the same rule, file, line and message are
+collapsed into one entry that lists every tool that reported it.
+
+--severity-map remaps tool-specific severities, such as Grype's
+"negligible", onto the canonical scale (none/info, note/low,
+warning/medium, error/high, critical) before findings are summarized and
+gated. SARIF tools report SARIF levels. Keys are a severity or tool:severity, e.g.
+"negligible=info,checkov:warning=error". --fail-on fails the command when a
+finding is at or above the given severity after remapping.
+
+A tool that fails is listed in the report and makes the command fail after
+the remaining tools have run. Tools run one at a time unless --parallel allows
+several to run concurrently; the report order does not depend on which tool
+finishes first.
+
+--policy-as-code gates the combined report with Conftest: the report is
+evaluated as JSON against the Rego policies in the given directory, and the
+command fails when a deny rule matches, e.g. "no errors in production modules".
+Policies read input.findings, whose entries carry tool, rule_id, file, line,
+severity and message, input.summary with the count per severity, and
+input.errors for tools that failed.
+
+Examples:
+  # Scan the current directory with every tool
+  ship security scan-all
+
+  # Combine dependency and secret scanning, collapsing duplicates
+  ship security scan-all ./app --tools osv-scanner,trufflehog --dedup
+
+  # Emit JUnit XML for a CI test dashboard
+  ship security scan-all --dedup --format junit
+
+  # Run every tool at once
+  ship security scan-all --parallel 4
+
+  # Fail on error findings, counting checkov warnings as errors
+  ship security scan-all --severity-map checkov:warning=error --fail-on error
+
+  # Fail when the findings violate the team's policies
+  ship security scan-all --dedup --policy-as-code ./policy/scan`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("scan-all", runScanAll),
+}
+
+// scanAllFormats are the report formats supported by scan-all
+var scanAllFormats = []string{"text", "json", formatJUnit}
+
+func init() {
+	securityToolsCmd.AddCommand(scanAllCmd)
+
+	scanAllCmd.Flags().String("tools", "", "Comma-separated tools to run (default: all of "+strings.Join(scanAllToolNames(), ", ")+")")
+	scanAllCmd.Flags().Bool("dedup", false, "Collapse identical findings reported by several tools")
+	scanAllCmd.Flags().String("format", "text", "Report format (text, json, junit)")
+	scanAllCmd.Flags().Int("parallel", 1, "Number of tools to run concurrently")
+	scanAllCmd.Flags().String("policy-as-code", "", "Directory of Rego policies the combined report must pass; the command fails when a policy denies")
+	scanAllCmd.Flags().String("policy-namespace", "", "Policy namespace evaluated with --policy-as-code (default main)")
+	scanAllCmd.Flags().StringToString("severity-map", nil, "Remap tool severities onto the canonical scale, as severity=canonical or tool:severity=canonical pairs")
+	scanAllCmd.Flags().String("fail-on", "", "Fail when a finding is at or above this severity, after --severity-map")
+
+	registerEnumFlag(scanAllCmd, "format", scanAllFormats...)
+	registerEnumFlag(scanAllCmd, "fail-on", report.Severities()...)
+}
+
+// scanAllTool is one scanner run by scan-all. Run returns the tool's output,
+// which Parse turns into findings; tools without Parse return SARIF.
+type scanAllTool struct {
+	Name  string
+	Run   func(ctx context.Context, dir string) (string, error)
+	Parse func(data []byte) ([]report.Finding, error)
+}
+
+// scanAllResult is the outcome of one tool
+type scanAllResult struct {
+	Tool     string
+	Findings []report.Finding
+	Err      error
+}
+
+// scanAllReport is the combined report printed by scan-all
+type scanAllReport struct {
+	Findings []report.Finding `json:"findings"`
+	Errors   []scanAllError   `json:"errors"`
+	// Summary counts the findings per severity
+	Summary map[string]int `json:"summary"`
+	// Policy is the --policy-as-code verdict on the findings and errors
+	Policy *scanPolicyResult `json:"policy,omitempty"`
+}
+
+type scanAllError struct {
+	Tool  string `json:"tool"`
+	Error string `json:"error"`
+}
+
+// scanAllToolNames lists the tools scan-all runs by default
+func scanAllToolNames() []string {
+	var names []string
+	for _, tool := range newScanAllTools(nil) {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// newScanAllTools returns the scanners backed by the engine's modules. The
+// Dagger client is safe for concurrent use and each run builds its own module,
+// so the tools can share one engine when run in parallel.
+func newScanAllTools(engine *dagger.Engine) []scanAllTool {
+	return []scanAllTool{
+		{Name: "checkov", Run: func(ctx context.Context, dir string) (string, error) {
+			return modules.NewCheckovModule(engine.GetClient()).Scan(ctx, dir,
+				modules.WithCheckovOutput("sarif"),
+				modules.WithCheckovSoftFail(true))
+		}},
+		{Name: "grype", Run: func(ctx context.Context, dir string) (string, error) {
+			return modules.NewGrypeModule(engine.GetClient()).ScanDirectory(ctx, dir,
+				modules.WithGrypeFormat("json"))
+		}, Parse: report.FromGrype},
+		{Name: "osv-scanner", Run: func(ctx context.Context, dir string) (string, error) {
+			return modules.NewOSVScannerModule(engine.GetClient()).ScanDirectory(ctx, dir,
+				modules.WithOSVScannerFormat("sarif"),
+				modules.WithOSVScannerRecursive(true))
+		}},
+		{Name: "trufflehog", Run: func(ctx context.Context, dir string) (string, error) {
+			return modules.NewTruffleHogModule(engine.GetClient()).ScanFilesystemAdvanced(ctx, dir, false, nil, "jsonl")
+		}, Parse: report.FromTruffleHog},
+	}
+}
+
+func runScanAll(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	dir := scanTargetDir(args)
+	toolList, _ := cmd.Flags().GetString("tools")
+	dedup, _ := cmd.Flags().GetBool("dedup")
+	format, _ := cmd.Flags().GetString("format")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	policyDir, _ := cmd.Flags().GetString("policy-as-code")
+	policyNamespace, _ := cmd.Flags().GetString("policy-namespace")
+	severityPairs, _ := cmd.Flags().GetStringToString("severity-map")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+
+	telemetry.TrackCLICommand("security", "scan-all", args)
+
+	if !contains(scanAllFormats, format) {
+		return "", fmt.Errorf("invalid --format %q: must be one of %v", format, scanAllFormats)
+	}
+	if parallel < 1 {
+		return "", fmt.Errorf("invalid --parallel %d: must be at least 1", parallel)
+	}
+	severityMap, err := report.ParseSeverityMap(severityPairs)
+	if err != nil {
+		return "", fmt.Errorf("invalid --severity-map: %w", err)
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "scan-all", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	tools, err := selectScanAllTools(newScanAllTools(engine), splitCommaList(toolList))
+	if err != nil {
+		return "", err
+	}
+
+	scanReport := buildScanAllReport(runScanAllTools(ctx, tools, dir, parallel), dedup, severityMap)
+	if policyDir != "" {
+		scanReport.Policy, err = evaluateScanPolicy(ctx, modules.NewConftestModule(engine.GetClient()), scanReport, policyDir, policyNamespace)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	output, err := formatScanAllReport(scanReport, format)
+	if err != nil {
+		return "", err
+	}
+
+	gateErr := scanAllGate(scanReport, len(tools), failOn)
+	telemetry.TrackDaggerOperation("scan_all", "scan-all", gateErr == nil, time.Since(start))
+	return output, gateErr
+}
+
+// scanAllGate returns the error scan-all fails with: failed tools first, then
+// a policy denial, then findings at or above the --fail-on severity
+func scanAllGate(scanReport scanAllReport, toolCount int, failOn string) error {
+	if len(scanReport.Errors) > 0 {
+		return fmt.Errorf("%d of %d tools failed", len(scanReport.Errors), toolCount)
+	}
+	if scanReport.Policy != nil && !scanReport.Policy.Passed {
+		return fmt.Errorf("policy denied the scan results: %s: %w", strings.Join(scanReport.Policy.Denials, "; "), ship.ErrFindingsExceeded)
+	}
+	if failOn != "" {
+		if failing := report.AtOrAbove(scanReport.Findings, failOn); len(failing) > 0 {
+			return fmt.Errorf("%d findings at or above %s severity: %w", len(failing), failOn, ship.ErrFindingsExceeded)
+		}
+	}
+	return nil
+}
+
+// selectScanAllTools filters tools to the requested names, keeping all when none are given
+func selectScanAllTools(tools []scanAllTool, names []string) ([]scanAllTool, error) {
+	if len(names) == 0 {
+		return tools, nil
+	}
+
+	byName := make(map[string]scanAllTool)
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	var selected []scanAllTool
+	for _, name := range names {
+		tool, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown tool %q in --tools: must be one of %s", name, strings.Join(scanAllToolNames(), ", "))
+		}
+		selected = append(selected, tool)
+	}
+	return selected, nil
+}
+
+// runScanAllTools runs the tools on a pool of workers and normalizes their
+// output. Results are returned in the order of tools.
+func runScanAllTools(ctx context.Context, tools []scanAllTool, dir string, workers int) []scanAllResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]scanAllResult, len(tools))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tools); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runScanAllTool(ctx, tools[i], dir)
+			}
+		}()
+	}
+
+	for i := range tools {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func runScanAllTool(ctx context.Context, tool scanAllTool, dir string) scanAllResult {
+	output, err := tool.Run(ctx, dir)
+	if err != nil {
+		return scanAllResult{Tool: tool.Name, Err: err}
+	}
+
+	parse := tool.Parse
+	if parse == nil {
+		parse = report.FromSARIF
+	}
+	findings, err := parse([]byte(output))
+	if err != nil {
+		return scanAllResult{Tool: tool.Name, Err: err}
+	}
+
+	// Attribute findings to the scan-all tool name rather than the SARIF driver name
+	for i := range findings {
+		findings[i].Tool = tool.Name
+	}
+	return scanAllResult{Tool: tool.Name, Findings: findings}
+}
+
+// buildScanAllReport combines the tool results in order, remapping their
+// severities and optionally collapsing duplicates
+func buildScanAllReport(results []scanAllResult, dedup bool, severityMap report.SeverityMap) scanAllReport {
+	scanReport := scanAllReport{Findings: []report.Finding{}, Errors: []scanAllError{}}
+	for _, result := range results {
+		if result.Err != nil {
+			scanReport.Errors = append(scanReport.Errors, scanAllError{Tool: result.Tool, Error: result.Err.Error()})
+			continue
+		}
+		scanReport.Findings = append(scanReport.Findings, result.Findings...)
+	}
+
+	severityMap.Apply(scanReport.Findings)
+	if dedup {
+		scanReport.Findings = report.Dedup(scanReport.Findings)
+	}
+	scanReport.Summary = report.CountBySeverity(scanReport.Findings)
+	return scanReport
+}
+
+// formatScanAllReport renders the combined report
+func formatScanAllReport(scanReport scanAllReport, format string) (string, error) {
+	switch format {
+	case formatJUnit:
+		data, err := junit.Marshal("scan-all", scanReport.Findings)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "json":
+		data, err := json.MarshalIndent(scanReport, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode report: %w", err)
+		}
+		return string(data), nil
+	default:
+		return formatScanAllText(scanReport), nil
+	}
+}
+
+func formatScanAllText(scanReport scanAllReport) string {
+	var b strings.Builder
+	for _, finding := range scanReport.Findings {
+		subject := finding.RuleID
+		if finding.File != "" {
+			subject += " " + finding.File
+			if finding.Line > 0 {
+				subject += fmt.Sprintf(":%d", finding.Line)
+			}
+		}
+		tools := finding.Tool
+		if len(finding.Tools) > 0 {
+			tools = strings.Join(finding.Tools, ", ")
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s (%s)\n", strings.ToUpper(finding.Severity), subject, finding.Message, tools)
+	}
+	for _, scanErr := range scanReport.Errors {
+		fmt.Fprintf(&b, "[FAILED] %s: %s\n", scanErr.Tool, scanErr.Error)
+	}
+	fmt.Fprintf(&b, "\n%d findings, %d tools failed\n", len(scanReport.Findings), len(scanReport.Errors))
+	if summary := formatSeveritySummary(scanReport.Summary); summary != "" {
+		fmt.Fprintf(&b, "by severity: %s\n", summary)
+	}
+
+	if policy := scanReport.Policy; policy != nil {
+		for _, denial := range policy.Denials {
+			fmt.Fprintf(&b, "[DENIED] %s\n", denial)
+		}
+		for _, warning := range policy.Warnings {
+			fmt.Fprintf(&b, "[POLICY WARNING] %s\n", warning)
+		}
+		if policy.Passed {
+			fmt.Fprintf(&b, "policy passed\n")
+		} else {
+			fmt.Fprintf(&b, "policy denied: %d violations\n", len(policy.Denials))
+		}
+	}
+	return b.String()
+}
+
+// formatSeveritySummary lists severity counts, most severe first, e.g.
+// "error 2, warning 1"
+func formatSeveritySummary(summary map[string]int) string {
+	severities := make([]string, 0, len(summary))
+	for severity := range summary {
+		severities = append(severities, severity)
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		ri, rj := report.SeverityRank(severities[i]), report.SeverityRank(severities[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return severities[i] < severities[j]
+	})
+
+	parts := make([]string, len(severities))
+	for i, severity := range severities {
+		parts[i] = fmt.Sprintf("%s %d", severity, summary[severity])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Two synthetic tool outputs that both report CVE-2024-1234 in go.sum
+const (
+	trivySARIF = `{"runs":[{"tool":{"driver":{"name":"Trivy"}},"results":[
+		{"ruleId":"CVE-2024-1234","level":"error","message":{"text":"golang.org/x/net vulnerable"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"go.sum"},"region":{"startLine":10}}}]},
+		{"ruleId":"CVE-2024-5555","level":"warning","message":{"text":"golang.org/x/text vulnerable"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"go.sum"},"region":{"startLine":22}}}]}
+	]}]}`
+	osvSARIF = `{"runs":[{"tool":{"driver":{"name":"osv-scanner"}},"results":[
+		{"ruleId":"CVE-2024-1234","level":"warning","message":{"text":"golang.org/x/net vulnerable"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"go.sum"},"region":{"startLine":10}}}]}
+	]}]}`
+)
+
+func staticScanAllTool(name, output string, err error) scanAllTool {
+	return scanAllTool{Name: name, Run: func(ctx context.Context, dir string) (string, error) {
+		return output, err
+	}}
+}
+
+func TestScanAllCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "scan-all"})
+	require.NoError(t, err)
+	assert.Equal(t, scanAllCmd, cmd)
+}
+
+func TestBuildScanAllReport_Dedup(t *testing.T) {
+	tools := []scanAllTool{
+		staticScanAllTool("trivy", trivySARIF, nil),
+		staticScanAllTool("osv-scanner", osvSARIF, nil),
+	}
+	results := runScanAllTools(context.Background(), tools, ".", 1)
+
+	t.Run("without dedup", func(t *testing.T) {
+		scanReport := buildScanAllReport(results, false, nil)
+		assert.Len(t, scanReport.Findings, 3)
+	})
+
+	t.Run("with dedup", func(t *testing.T) {
+		scanReport := buildScanAllReport(results, true, nil)
+		require.Len(t, scanReport.Findings, 2)
+
+		shared := scanReport.Findings[0]
+		assert.Equal(t, "CVE-2024-1234", shared.RuleID)
+		assert.Equal(t, []string{"osv-scanner", "trivy"}, shared.Tools)
+		assert.Equal(t, []string{"trivy"}, scanReport.Findings[1].Tools)
+	})
+}
+
+func TestBuildScanAllReport_ToolErrors(t *testing.T) {
+	tools := []scanAllTool{
+		staticScanAllTool("trivy", trivySARIF, nil),
+		staticScanAllTool("checkov", "", errors.New("engine unavailable")),
+		staticScanAllTool("osv-scanner", "No package sources found", nil),
+	}
+
+	scanReport := buildScanAllReport(runScanAllTools(context.Background(), tools, ".", 1), true, nil)
+	assert.Len(t, scanReport.Findings, 2)
+	require.Len(t, scanReport.Errors, 2)
+	assert.Equal(t, "checkov", scanReport.Errors[0].Tool)
+	assert.Equal(t, "osv-scanner", scanReport.Errors[1].Tool)
+}
+
+func TestSelectScanAllTools(t *testing.T) {
+	tools := []scanAllTool{
+		staticScanAllTool("checkov", "", nil),
+		staticScanAllTool("trufflehog", "", nil),
+	}
+
+	selected, err := selectScanAllTools(tools, nil)
+	require.NoError(t, err)
+	assert.Len(t, selected, 2)
+
+	selected, err = selectScanAllTools(tools, []string{"trufflehog"})
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Equal(t, "trufflehog", selected[0].Name)
+
+	_, err = selectScanAllTools(tools, []string{"nessus"})
+	assert.ErrorContains(t, err, "unknown tool")
+}
+
+func TestFormatScanAllReport(t *testing.T) {
+	results := runScanAllTools(context.Background(), []scanAllTool{
+		staticScanAllTool("trivy", trivySARIF, nil),
+		staticScanAllTool("osv-scanner", osvSARIF, nil),
+	}, ".", 1)
+	scanReport := buildScanAllReport(results, true, nil)
+
+	text, err := formatScanAllReport(scanReport, "text")
+	require.NoError(t, err)
+	assert.Contains(t, text, "[ERROR] CVE-2024-1234 go.sum:10: golang.org/x/net vulnerable (osv-scanner, trivy)")
+	assert.Contains(t, text, "2 findings, 0 tools failed")
+
+	data, err := formatScanAllReport(scanReport, "json")
+	require.NoError(t, err)
+	var decoded scanAllReport
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	assert.Equal(t, scanReport.Findings, decoded.Findings)
+
+	xml, err := formatScanAllReport(scanReport, formatJUnit)
+	require.NoError(t, err)
+	assert.Contains(t, xml, `<testsuites name="scan-all" tests="2" failures="2">`)
+}
+
+// slowScanAllTool sleeps for delay, tracking how many tools run at once
+func slowScanAllTool(name string, delay time.Duration, output string, inFlight, maxInFlight *int32) scanAllTool {
+	return scanAllTool{Name: name, Run: func(ctx context.Context, dir string) (string, error) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		return output, nil
+	}}
+}
+
+func TestRunScanAllTools_Parallel(t *testing.T) {
+	var inFlight, maxInFlight int32
+	// The first tool is the slowest so completion order differs from input order
+	tools := []scanAllTool{
+		slowScanAllTool("trivy", 60*time.Millisecond, trivySARIF, &inFlight, &maxInFlight),
+		slowScanAllTool("osv-scanner", 10*time.Millisecond, osvSARIF, &inFlight, &maxInFlight),
+		slowScanAllTool("empty", 30*time.Millisecond, `{"runs":[]}`, &inFlight, &maxInFlight),
+	}
+
+	results := runScanAllTools(context.Background(), tools, ".", 3)
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "trivy", results[0].Tool)
+	assert.Len(t, results[0].Findings, 2)
+	assert.Equal(t, "osv-scanner", results[1].Tool)
+	assert.Len(t, results[1].Findings, 1)
+	assert.Equal(t, "empty", results[2].Tool)
+	assert.Empty(t, results[2].Findings)
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1), "tools should run concurrently")
+
+	// The combined report matches a serial run
+	serial := runScanAllTools(context.Background(), tools, ".", 1)
+	assert.Equal(t, buildScanAllReport(serial, true, nil), buildScanAllReport(results, true, nil))
+}
+
+func TestRunScanAllTools_BoundedWorkers(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var tools []scanAllTool
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		tools = append(tools, slowScanAllTool(name, 15*time.Millisecond, `{"runs":[]}`, &inFlight, &maxInFlight))
+	}
+
+	results := runScanAllTools(context.Background(), tools, ".", 2)
+	require.Len(t, results, 5)
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		assert.Equal(t, name, results[i].Tool)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestRunScanAllTools_CollectsErrorsConcurrently(t *testing.T) {
+	tools := []scanAllTool{
+		staticScanAllTool("trivy", trivySARIF, nil),
+		staticScanAllTool("checkov", "", errors.New("engine unavailable")),
+	}
+
+	scanReport := buildScanAllReport(runScanAllTools(context.Background(), tools, ".", 2), false, nil)
+	assert.Len(t, scanReport.Findings, 2)
+	require.Len(t, scanReport.Errors, 1)
+	assert.Equal(t, "checkov", scanReport.Errors[0].Tool)
+}
+
+func TestBuildScanAllReport_SeverityMap(t *testing.T) {
+	grypeJSON := `{"matches":[{"vulnerability":{"id":"CVE-2023-0001","severity":"Negligible","fix":{"versions":[],"state":"not-fixed"}},
+		"artifact":{"name":"zlib","version":"1.2.13","type":"deb","locations":[{"path":"/var/lib/dpkg/status"}]}}],
+		"descriptor":{"name":"grype","version":"0.74.0"}}`
+	grype := staticScanAllTool("grype", grypeJSON, nil)
+	grype.Parse = report.FromGrype
+	results := runScanAllTools(context.Background(), []scanAllTool{
+		staticScanAllTool("trivy", trivySARIF, nil),
+		grype,
+	}, ".", 1)
+
+	unmapped := buildScanAllReport(results, false, nil)
+	assert.Equal(t, map[string]int{"error": 1, "warning": 1, "negligible": 1}, unmapped.Summary)
+
+	severityMap, err := report.ParseSeverityMap(map[string]string{
+		"negligible":    "info",
+		"trivy:warning": "error",
+	})
+	require.NoError(t, err)
+	mapped := buildScanAllReport(results, false, severityMap)
+	assert.Equal(t, map[string]int{"error": 2, "info": 1}, mapped.Summary)
+
+	text, err := formatScanAllReport(mapped, "text")
+	require.NoError(t, err)
+	assert.Contains(t, text, "[INFO] CVE-2023-0001 var/lib/dpkg/status: zlib 1.2.13 is vulnerable (grype)")
+	assert.Contains(t, text, "by severity: error 2, info 1")
+}
+
+func TestScanAllGate_FailOn(t *testing.T) {
+	results := runScanAllTools(context.Background(), []scanAllTool{
+		staticScanAllTool("osv-scanner", osvSARIF, nil),
+	}, ".", 1)
+
+	unmapped := buildScanAllReport(results, false, nil)
+	assert.NoError(t, scanAllGate(unmapped, 1, ""), "findings do not fail without --fail-on")
+	assert.NoError(t, scanAllGate(unmapped, 1, "error"))
+	assert.EqualError(t, scanAllGate(unmapped, 1, "warning"), "1 findings at or above warning severity: findings exceeded threshold")
+
+	severityMap, err := report.ParseSeverityMap(map[string]string{"osv-scanner:warning": "critical"})
+	require.NoError(t, err)
+	mapped := buildScanAllReport(results, false, severityMap)
+	assert.EqualError(t, scanAllGate(mapped, 1, "error"), "1 findings at or above error severity: findings exceeded threshold", "remapped severities meet the threshold")
+
+	severityMap, err = report.ParseSeverityMap(map[string]string{"warning": "note"})
+	require.NoError(t, err)
+	assert.NoError(t, scanAllGate(buildScanAllReport(results, false, severityMap), 1, "warning"), "downgraded severities fall below the threshold")
+}
+
+func TestScanAllTruffleHogJSONResults(t *testing.T) {
+	var trufflehog scanAllTool
+	for _, tool := range newScanAllTools(nil) {
+		if tool.Name == "trufflehog" {
+			trufflehog = tool
+		}
+	}
+	require.NotNil(t, trufflehog.Parse, "trufflehog results are JSON lines, not SARIF")
+
+	output := `{"SourceMetadata":{"Data":{"Filesystem":{"file":"config/prod.env","line":3}}},"DetectorName":"AWS","Verified":true,"Raw":"AKIAEXAMPLE"}` + "\n"
+	results := runScanAllTools(context.Background(), []scanAllTool{
+		{Name: "trufflehog", Run: func(ctx context.Context, dir string) (string, error) { return output, nil }, Parse: trufflehog.Parse},
+		staticScanAllTool("osv-scanner", osvSARIF, nil),
+	}, ".", 1)
+
+	scanReport := buildScanAllReport(results, false, nil)
+	require.Empty(t, scanReport.Errors)
+	require.Len(t, scanReport.Findings, 2)
+	assert.Equal(t, report.Finding{Tool: "trufflehog", RuleID: "AWS", File: "config/prod.env", Line: 3, Severity: "high", Message: "Verified AWS secret"}, scanReport.Findings[0])
+	assert.EqualError(t, scanAllGate(scanReport, 2, "high"), "1 findings at or above high severity: findings exceeded threshold")
+}
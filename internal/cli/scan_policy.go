@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+)
+
+// policyTester is the subset of the Conftest module the policy gate uses
+type policyTester interface {
+	Test(ctx context.Context, path string, opts ...modules.ConftestOption) (string, error)
+}
+
+// scanPolicyResult is the outcome of evaluating a scan report against the
+// --policy-as-code policies
+type scanPolicyResult struct {
+	Passed   bool     `json:"passed"`
+	Denials  []string `json:"denials"`
+	Warnings []string `json:"warnings"`
+}
+
+// conftestResult is one entry of conftest's JSON output
+type conftestResult struct {
+	Filename  string `json:"filename"`
+	Namespace string `json:"namespace"`
+	Failures  []struct {
+		Msg string `json:"msg"`
+	} `json:"failures"`
+	Warnings []struct {
+		Msg string `json:"msg"`
+	} `json:"warnings"`
+}
+
+// evaluateScanPolicy writes the scan report to a JSON file and tests it with
+// conftest against the policies in policyDir. Policies see the report as
+// input, e.g. input.findings[_].severity, and fail the gate with deny rules.
+func evaluateScanPolicy(ctx context.Context, tester policyTester, scanReport scanAllReport, policyDir string, namespace string) (*scanPolicyResult, error) {
+	info, err := os.Stat(policyDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --policy-as-code: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("invalid --policy-as-code: %s is not a directory", policyDir)
+	}
+
+	data, err := json.Marshal(scanReport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report for policy evaluation: %w", err)
+	}
+	dir, err := os.MkdirTemp("", "ship-scan-policy-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy input directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "scan-results.json")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write policy input: %w", err)
+	}
+
+	output, err := tester.Test(ctx, input,
+		modules.WithConftestPolicyPath(policyDir),
+		modules.WithConftestNamespace(namespace),
+		modules.WithConftestOutput("json"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("policy evaluation failed: %w", err)
+	}
+	return parseConftestResults(output)
+}
+
+// parseConftestResults collects the failure and warning messages of
+// conftest's JSON output
+func parseConftestResults(output string) (*scanPolicyResult, error) {
+	var results []conftestResult
+	if err := json.Unmarshal([]byte(output), &results); err != nil {
+		return nil, fmt.Errorf("failed to parse conftest output: %w\n%s", err, output)
+	}
+
+	result := &scanPolicyResult{Denials: []string{}, Warnings: []string{}}
+	for _, entry := range results {
+		for _, failure := range entry.Failures {
+			result.Denials = append(result.Denials, failure.Msg)
+		}
+		for _, warning := range entry.Warnings {
+			result.Warnings = append(result.Warnings, warning.Msg)
+		}
+	}
+	result.Passed = len(result.Denials) == 0
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scanPolicyFixture holds a policy that denies errors in production modules
+// and warns about tools that did not run
+const scanPolicyFixture = "testdata/scan-policy"
+
+// recordingPolicyTester records how conftest is called and returns canned
+// conftest JSON output
+type recordingPolicyTester struct {
+	path   string
+	config modules.ConftestConfig
+	input  scanAllReport
+	output string
+}
+
+func (r *recordingPolicyTester) Test(ctx context.Context, path string, opts ...modules.ConftestOption) (string, error) {
+	r.path = path
+	for _, opt := range opts {
+		opt(&r.config)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(data, &r.input); err != nil {
+		return "", err
+	}
+	return r.output, nil
+}
+
+func TestEvaluateScanPolicy_ConftestInput(t *testing.T) {
+	scanReport := scanAllReport{
+		Findings: []report.Finding{
+			{Tool: "checkov", RuleID: "CKV_AWS_20", File: "modules/dev/s3.tf", Severity: "error"},
+		},
+		Errors: []scanAllError{},
+	}
+
+	tester := &recordingPolicyTester{output: `[{"filename":"scan-results.json","namespace":"main","successes":1,"failures":[],"warnings":[]}]`}
+	result, err := evaluateScanPolicy(context.Background(), tester, scanReport, scanPolicyFixture, "main")
+	require.NoError(t, err)
+
+	assert.True(t, result.Passed)
+	assert.Equal(t, scanReport, tester.input, "policies see the scan report as input")
+	assert.Equal(t, scanPolicyFixture, tester.config.PolicyPath)
+	assert.Equal(t, "main", tester.config.Namespace)
+	assert.Equal(t, "json", tester.config.Output)
+	assert.True(t, strings.HasSuffix(tester.path, ".json"), "conftest picks its parser from the extension")
+	assert.NoFileExists(t, tester.path, "the policy input is removed")
+}
+
+func TestParseConftestResults(t *testing.T) {
+	result, err := parseConftestResults(`[{"filename":"scan-results.json","namespace":"main","successes":0,
+		"failures":[{"msg":"checkov reported CKV_AWS_20 in modules/prod/s3.tf"}],
+		"warnings":[{"msg":"trufflehog did not run: image pull failed"}]}]`)
+	require.NoError(t, err)
+
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{"checkov reported CKV_AWS_20 in modules/prod/s3.tf"}, result.Denials)
+	assert.Equal(t, []string{"trufflehog did not run: image pull failed"}, result.Warnings)
+}
+
+// TestEvaluateScanPolicy_Conftest evaluates scanPolicyFixture's Rego with the
+// real conftest image
+func TestEvaluateScanPolicy_Conftest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping conftest integration test in short mode")
+	}
+	if err := exec.Command("docker", "info").Run(); err != nil {
+		t.Skipf("Docker not available: %v", err)
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		t.Skipf("Dagger not available: %v", err)
+	}
+	defer engine.Close()
+	conftest := modules.NewConftestModule(engine.GetClient())
+
+	passing := scanAllReport{
+		Findings: []report.Finding{
+			{Tool: "checkov", RuleID: "CKV_AWS_20", File: "modules/dev/s3.tf", Severity: "error"},
+			{Tool: "checkov", RuleID: "CKV_AWS_18", File: "modules/prod/s3.tf", Severity: "warning"},
+		},
+		Errors: []scanAllError{},
+	}
+	result, err := evaluateScanPolicy(ctx, conftest, passing, scanPolicyFixture, "")
+	require.NoError(t, err)
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Denials)
+	assert.Empty(t, result.Warnings)
+
+	denied := scanAllReport{
+		Findings: []report.Finding{
+			{Tool: "checkov", RuleID: "CKV_AWS_20", File: "modules/prod/s3.tf", Severity: "error"},
+		},
+		Errors: []scanAllError{{Tool: "trufflehog", Error: "image pull failed"}},
+	}
+	result, err = evaluateScanPolicy(ctx, conftest, denied, scanPolicyFixture, "main")
+	require.NoError(t, err)
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{"checkov reported CKV_AWS_20 in modules/prod/s3.tf"}, result.Denials)
+	assert.Equal(t, []string{"trufflehog did not run: image pull failed"}, result.Warnings)
+}
+
+func TestEvaluateScanPolicy_MissingPolicyDir(t *testing.T) {
+	_, err := evaluateScanPolicy(context.Background(), &recordingPolicyTester{}, scanAllReport{}, "testdata/missing", "")
+	assert.ErrorContains(t, err, "invalid --policy-as-code")
+}
+
+func TestParseConftestResults_InvalidOutput(t *testing.T) {
+	_, err := parseConftestResults("Error: no policies found in [/policies]")
+	assert.ErrorContains(t, err, "failed to parse conftest output")
+}
+
+func TestFormatScanAllText_Policy(t *testing.T) {
+	text := formatScanAllText(scanAllReport{Policy: &scanPolicyResult{
+		Denials:  []string{"checkov reported CKV_AWS_20 in modules/prod/s3.tf"},
+		Warnings: []string{},
+	}})
+
+	assert.Contains(t, text, "[DENIED] checkov reported CKV_AWS_20 in modules/prod/s3.tf")
+	assert.Contains(t, text, "policy denied: 1 violations")
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+)
+
+var securityToolsCmd = &cobra.Command{
+	Use:   "security",
+	Short: "Run containerized security scanners",
+	Long: `Run security scanners in containerized environments using Dagger.
+
+Each subcommand wraps a single tool and prints the tool's report to stdout.
+
+Examples:
+  # Scan the current directory with Terrascan
+  ship security terrascan
+
+  # Scan a Terraform directory, skipping specific rules
+  ship security terrascan ./infra --iac-type terraform --skip-rules AC_AWS_0207,AC_AWS_0214`,
+}
+
+func init() {
+	rootCmd.AddCommand(securityToolsCmd)
+}
+
+// scanTargetDir returns the directory argument, defaulting to the working
+// directory. A relative argument that exists under --working-dir is resolved
+// against it; other arguments, such as image references, are returned as given.
+func scanTargetDir(args []string) string {
+	if len(args) == 0 || args[0] == "" {
+		return modules.HostPath(".")
+	}
+	if resolved := modules.HostPath(args[0]); resolved != args[0] {
+		if _, err := os.Stat(resolved); err == nil {
+			return resolved
+		}
+	}
+	return args[0]
+}
+
+// splitCommaList splits a comma-separated flag value, dropping empty entries
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// stdinTarget is the target argument that reads targets from stdin
+const stdinTarget = "-"
+
+func init() {
+	rootCmd.PersistentFlags().Bool("stdin", false, "Read newline-separated scan targets from stdin and scan each (same as passing - as the target)")
+}
+
+// stdinTargetResult is the outcome of scanning one target read from stdin
+type stdinTargetResult struct {
+	Target string
+	Output string
+	Err    error
+}
+
+// stdinTargets returns the targets to scan when --stdin is set or the target
+// argument is "-", along with the arguments that follow the target. It
+// returns nil targets when the command scans its arguments as usual.
+func stdinTargets(cmd *cobra.Command, args []string) ([]string, []string, error) {
+	useStdin, _ := cmd.Flags().GetBool("stdin")
+	switch {
+	case len(args) > 0 && args[0] == stdinTarget:
+		args = args[1:]
+	case useStdin:
+		if len(args) > 0 {
+			return nil, nil, fmt.Errorf("--stdin cannot be combined with a target argument")
+		}
+		if err := cmd.ValidateArgs([]string{stdinTarget}); err != nil {
+			return nil, nil, fmt.Errorf("--stdin is not supported: %s does not take a target", cmd.CommandPath())
+		}
+	default:
+		return nil, nil, nil
+	}
+
+	targets, err := readTargets(cmd.InOrStdin())
+	if err != nil {
+		return nil, nil, err
+	}
+	return targets, args, nil
+}
+
+// readTargets reads one target per line, skipping blank lines
+func readTargets(r io.Reader) ([]string, error) {
+	var targets []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if target := strings.TrimSpace(scanner.Text()); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read targets from stdin: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no targets read from stdin")
+	}
+	return targets, nil
+}
+
+// runStdinTargets scans every target, continuing past failures, and combines
+// the outputs. The error reports how many targets failed.
+func runStdinTargets(targets []string, scan func(target string) (string, error)) (string, error) {
+	results := make([]stdinTargetResult, len(targets))
+	failed := 0
+	for i, target := range targets {
+		output, err := scan(target)
+		results[i] = stdinTargetResult{Target: target, Output: output, Err: err}
+		if err != nil {
+			failed++
+		}
+	}
+
+	output := formatStdinTargetResults(results)
+	if failed > 0 {
+		return output, fmt.Errorf("%d of %d targets failed", failed, len(targets))
+	}
+	return output, nil
+}
+
+// formatStdinTargetResults combines per-target outputs. When every output is
+// JSON, such as with --format json, the result is a JSON array with one
+// entry per target; otherwise each output follows a target header.
+func formatStdinTargetResults(results []stdinTargetResult) string {
+	if allJSONOutputs(results) {
+		type entry struct {
+			Target string          `json:"target"`
+			Output json.RawMessage `json:"output,omitempty"`
+			Error  string          `json:"error,omitempty"`
+		}
+		entries := make([]entry, len(results))
+		for i, result := range results {
+			entries[i] = entry{Target: result.Target}
+			if result.Err != nil {
+				entries[i].Error = result.Err.Error()
+			} else {
+				entries[i].Output = json.RawMessage(strings.TrimSpace(result.Output))
+			}
+		}
+		if data, err := json.MarshalIndent(entries, "", "  "); err == nil {
+			return string(data)
+		}
+	}
+
+	sections := make([]string, len(results))
+	for i, result := range results {
+		body := strings.TrimRight(result.Output, "\n")
+		if result.Err != nil {
+			body = strings.TrimLeft(body+"\nError: "+result.Err.Error(), "\n")
+		}
+		sections[i] = fmt.Sprintf("==> %s <==\n%s", result.Target, body)
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// allJSONOutputs reports whether every successful output is a JSON document
+// and at least one target succeeded
+func allJSONOutputs(results []stdinTargetResult) bool {
+	succeeded := false
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		output := strings.TrimSpace(result.Output)
+		if output == "" || !json.Valid([]byte(output)) {
+			return false
+		}
+		succeeded = true
+	}
+	return succeeded
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStdinTestCmd builds a command with the flags runTool reads and stdin set
+// to input
+func newStdinTestCmd(t *testing.T, input string, args cobra.PositionalArgs) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	cmd := &cobra.Command{Use: "scan", Args: args}
+	cmd.Flags().Bool("stdin", false, "")
+	cmd.Flags().String("notify-on", notifyOnFailure, "")
+	cmd.Flags().String("output-format", outputFormatText, "")
+	cmd.Flags().String("format", "", "")
+	cmd.SetIn(strings.NewReader(input))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	return cmd, &stdout
+}
+
+// recordingRun returns a toolRunFunc that records the args of every call
+func recordingRun(calls *[][]string, output func(target string) (string, error)) toolRunFunc {
+	return func(cmd *cobra.Command, args []string) (string, error) {
+		*calls = append(*calls, args)
+		return output(args[0])
+	}
+}
+
+func TestRunTool_DashScansEachStdinTarget(t *testing.T) {
+	cmd, stdout := newStdinTestCmd(t, "alpine:3.19\n\n  nginx:1.25  \nredis:7\n", cobra.MaximumNArgs(1))
+
+	var calls [][]string
+	run := recordingRun(&calls, func(target string) (string, error) {
+		return "scanned " + target + "\n", nil
+	})
+
+	require.NoError(t, runTool("trivy", run)(cmd, []string{"-"}))
+
+	assert.Equal(t, [][]string{{"alpine:3.19"}, {"nginx:1.25"}, {"redis:7"}}, calls)
+	assert.Equal(t, "==> alpine:3.19 <==\nscanned alpine:3.19\n\n==> nginx:1.25 <==\nscanned nginx:1.25\n\n==> redis:7 <==\nscanned redis:7\n", stdout.String())
+}
+
+func TestRunTool_StdinFlagKeepsFollowingArgs(t *testing.T) {
+	cmd, _ := newStdinTestCmd(t, "./a\n./b\n", cobra.RangeArgs(1, 2))
+
+	var calls [][]string
+	run := recordingRun(&calls, func(target string) (string, error) { return "ok", nil })
+
+	require.NoError(t, runTool("checkov", run)(cmd, []string{"-", "extra"}))
+	assert.Equal(t, [][]string{{"./a", "extra"}, {"./b", "extra"}}, calls)
+
+	cmd, _ = newStdinTestCmd(t, "./a\n./b\n", cobra.MaximumNArgs(1))
+	require.NoError(t, cmd.Flags().Set("stdin", "true"))
+	calls = nil
+	require.NoError(t, runTool("checkov", run)(cmd, nil))
+	assert.Equal(t, [][]string{{"./a"}, {"./b"}}, calls)
+}
+
+func TestRunTool_StdinJSONOutputsAreAggregated(t *testing.T) {
+	cmd, stdout := newStdinTestCmd(t, "alpine:3.19\nmissing:latest\nnginx:1.25\n", cobra.MaximumNArgs(1))
+	require.NoError(t, cmd.Flags().Set("format", "json"))
+
+	var calls [][]string
+	run := recordingRun(&calls, func(target string) (string, error) {
+		if target == "missing:latest" {
+			return "", errors.New("image not found")
+		}
+		return `{"image":"` + target + `","vulnerabilities":[]}` + "\n", nil
+	})
+
+	err := runTool("trivy", run)(cmd, []string{"-"})
+	assert.EqualError(t, err, "1 of 3 targets failed")
+	assert.Len(t, calls, 3, "later targets are scanned after a failure")
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "alpine:3.19", entries[0]["target"])
+	assert.Equal(t, map[string]any{"image": "alpine:3.19", "vulnerabilities": []any{}}, entries[0]["output"])
+	assert.Equal(t, "missing:latest", entries[1]["target"])
+	assert.Equal(t, "image not found", entries[1]["error"])
+	assert.NotContains(t, entries[1], "output")
+	assert.Equal(t, "nginx:1.25", entries[2]["target"])
+}
+
+func TestRunTool_StdinComposesWithJSONEnvelope(t *testing.T) {
+	cmd, stdout := newStdinTestCmd(t, "a\nb\n", cobra.MaximumNArgs(1))
+	require.NoError(t, cmd.Flags().Set("output-format", outputFormatJSON))
+
+	var calls [][]string
+	run := recordingRun(&calls, func(target string) (string, error) { return `["` + target + `"]`, nil })
+	require.NoError(t, runTool("gitleaks", run)(cmd, []string{"-"}))
+
+	var envelope outputEnvelope
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &envelope))
+	assert.Equal(t, "ok", envelope.Status)
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(envelope.Stdout), &entries))
+	assert.Len(t, entries, 2)
+}
+
+func TestStdinTargets_Errors(t *testing.T) {
+	cmd, _ := newStdinTestCmd(t, "\n  \n", cobra.MaximumNArgs(1))
+	_, _, err := stdinTargets(cmd, []string{"-"})
+	assert.EqualError(t, err, "no targets read from stdin")
+
+	cmd, _ = newStdinTestCmd(t, "a\n", cobra.MaximumNArgs(1))
+	require.NoError(t, cmd.Flags().Set("stdin", "true"))
+	_, _, err = stdinTargets(cmd, []string{"./dir"})
+	assert.ErrorContains(t, err, "cannot be combined with a target argument")
+
+	cmd, _ = newStdinTestCmd(t, "a\n", cobra.NoArgs)
+	require.NoError(t, cmd.Flags().Set("stdin", "true"))
+	_, _, err = stdinTargets(cmd, nil)
+	assert.ErrorContains(t, err, "does not take a target")
+
+	cmd, _ = newStdinTestCmd(t, "a\n", cobra.MaximumNArgs(1))
+	targets, _, err := stdinTargets(cmd, []string{"./dir"})
+	require.NoError(t, err)
+	assert.Nil(t, targets, "a regular target does not read stdin")
+}
+
+func TestFormatStdinTargetResults_TextWithError(t *testing.T) {
+	output := formatStdinTargetResults([]stdinTargetResult{
+		{Target: "a", Output: "clean\n"},
+		{Target: "b", Err: errors.New("exit status 2")},
+	})
+	assert.Equal(t, "==> a <==\nclean\n\n==> b <==\nError: exit status 2", output)
+}
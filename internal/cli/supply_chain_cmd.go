@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var supplyChainCmd = &cobra.Command{
+	Use:   "supply-chain",
+	Short: "Software supply chain verification",
+	Long:  `Verify container image signatures and build provenance across supply chain tools, and query the Rekor transparency log.`,
+}
+
+var supplyChainVerifyCmd = &cobra.Command{
+	Use:   "verify <image>",
+	Short: "Verify an image's signature and SLSA provenance",
+	Long: `Verify a container image end to end:
+
+  1. cosign signature verification (--key, or --keyless with the expected
+     signer's --certificate-identity and --certificate-oidc-issuer)
+  2. SLSA provenance verification with slsa-verifier (when --source-uri is set)
+
+Each step's result is reported and the command fails if any required step fails.
+
+Examples:
+  # Verify a keyless signature and the SLSA provenance
+  ship supply-chain verify ghcr.io/org/app@sha256:... --keyless \
+    --certificate-identity-regexp '^https://github.com/org/app/' \
+    --certificate-oidc-issuer https://token.actions.githubusercontent.com \
+    --source-uri github.com/org/app
+
+  # Verify a key-based signature only
+  ship supply-chain verify ghcr.io/org/app:1.0 --key ./cosign.pub`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTool("supply-chain", runSupplyChainVerify),
+}
+
+func init() {
+	rootCmd.AddCommand(supplyChainCmd)
+	supplyChainCmd.AddCommand(supplyChainVerifyCmd)
+
+	supplyChainVerifyCmd.Flags().String("key", "", "Cosign public key for signature verification")
+	supplyChainVerifyCmd.Flags().Bool("keyless", false, "Use keyless (Fulcio/Rekor) signature verification")
+	supplyChainVerifyCmd.Flags().String("certificate-identity", "", "Identity the keyless signing certificate must be issued to")
+	supplyChainVerifyCmd.Flags().String("certificate-identity-regexp", "", "Regular expression the keyless signing certificate identity must match")
+	supplyChainVerifyCmd.Flags().String("certificate-oidc-issuer", "", "OIDC issuer of the keyless signing certificate")
+	supplyChainVerifyCmd.Flags().String("certificate-oidc-issuer-regexp", "", "Regular expression the keyless signing certificate OIDC issuer must match")
+	supplyChainVerifyCmd.Flags().String("source-uri", "", "Expected source repository for SLSA provenance (e.g. github.com/org/repo)")
+	supplyChainVerifyCmd.Flags().String("builder-id", "", "Expected SLSA builder ID")
+}
+
+// supplyChainVerifier runs the individual verification steps
+type supplyChainVerifier interface {
+	VerifySignature(ctx context.Context, image string, key string, keyless bool, identity modules.CosignIdentity) (string, error)
+	VerifyProvenance(ctx context.Context, image string, sourceURI string, builderID string) (string, error)
+}
+
+// daggerSupplyChainVerifier verifies using the cosign and slsa-verifier modules
+type daggerSupplyChainVerifier struct {
+	cosign *modules.CosignModule
+	slsa   *modules.SLSAVerifierModule
+}
+
+func (v *daggerSupplyChainVerifier) VerifySignature(ctx context.Context, image string, key string, keyless bool, identity modules.CosignIdentity) (string, error) {
+	return v.cosign.VerifyImageSignature(ctx, image, key, keyless, identity)
+}
+
+func (v *daggerSupplyChainVerifier) VerifyProvenance(ctx context.Context, image string, sourceURI string, builderID string) (string, error) {
+	return v.slsa.VerifyImage(ctx, image, sourceURI, builderID)
+}
+
+type supplyChainVerifyOptions struct {
+	Key       string
+	Keyless   bool
+	Identity  modules.CosignIdentity
+	SourceURI string
+	BuilderID string
+}
+
+// supplyChainStep is the outcome of one verification step
+type supplyChainStep struct {
+	Name     string
+	Status   string // pass, fail or skipped
+	Required bool
+	Detail   string
+}
+
+func runSupplyChainVerify(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	image := args[0]
+	key, _ := cmd.Flags().GetString("key")
+	keyless, _ := cmd.Flags().GetBool("keyless")
+	sourceURI, _ := cmd.Flags().GetString("source-uri")
+	builderID, _ := cmd.Flags().GetString("builder-id")
+	opts := supplyChainVerifyOptions{
+		Key:       key,
+		Keyless:   keyless,
+		SourceURI: sourceURI,
+		BuilderID: builderID,
+	}
+	opts.Identity.Identity, _ = cmd.Flags().GetString("certificate-identity")
+	opts.Identity.IdentityRegexp, _ = cmd.Flags().GetString("certificate-identity-regexp")
+	opts.Identity.Issuer, _ = cmd.Flags().GetString("certificate-oidc-issuer")
+	opts.Identity.IssuerRegexp, _ = cmd.Flags().GetString("certificate-oidc-issuer-regexp")
+
+	telemetry.TrackCLICommand("supply-chain", "verify", args)
+
+	if err := validateSupplyChainVerify(opts); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "supply-chain_verify", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	verifier := &daggerSupplyChainVerifier{
+		cosign: modules.NewCosignModule(engine.GetClient()),
+		slsa:   modules.NewSLSAVerifierModule(engine.GetClient()),
+	}
+
+	steps := verifySupplyChain(ctx, verifier, image, opts)
+
+	report := formatSupplyChainReport(image, steps)
+
+	passed := supplyChainPassed(steps)
+	telemetry.TrackDaggerOperation("supply_chain_verify", "supply-chain", passed, time.Since(start))
+	if !passed {
+		return report, fmt.Errorf("supply chain verification failed for %s", image)
+	}
+	return report, nil
+}
+
+// validateSupplyChainVerify requires exactly one of --key and --keyless, and
+// the expected signer identity for keyless verification
+func validateSupplyChainVerify(opts supplyChainVerifyOptions) error {
+	if opts.Key == "" && !opts.Keyless {
+		return fmt.Errorf("either --key or --keyless is required")
+	}
+	if opts.Key != "" && opts.Keyless {
+		return fmt.Errorf("--key and --keyless are mutually exclusive")
+	}
+	if opts.Keyless {
+		if opts.Identity.Identity == "" && opts.Identity.IdentityRegexp == "" {
+			return fmt.Errorf("--keyless requires --certificate-identity or --certificate-identity-regexp")
+		}
+		if opts.Identity.Issuer == "" && opts.Identity.IssuerRegexp == "" {
+			return fmt.Errorf("--keyless requires --certificate-oidc-issuer or --certificate-oidc-issuer-regexp")
+		}
+	}
+	return nil
+}
+
+// verifySupplyChain runs every verification step and collects the results.
+// Later steps still run after a failure so the report is complete.
+func verifySupplyChain(ctx context.Context, verifier supplyChainVerifier, image string, opts supplyChainVerifyOptions) []supplyChainStep {
+	var steps []supplyChainStep
+
+	output, err := verifier.VerifySignature(ctx, image, opts.Key, opts.Keyless, opts.Identity)
+	steps = append(steps, newSupplyChainStep("cosign signature", true, output, err))
+
+	if opts.SourceURI == "" {
+		steps = append(steps, supplyChainStep{
+			Name:   "slsa provenance",
+			Status: "skipped",
+			Detail: "no --source-uri given",
+		})
+	} else {
+		output, err = verifier.VerifyProvenance(ctx, image, opts.SourceURI, opts.BuilderID)
+		steps = append(steps, newSupplyChainStep("slsa provenance", true, output, err))
+	}
+
+	return steps
+}
+
+func newSupplyChainStep(name string, required bool, output string, err error) supplyChainStep {
+	if err != nil {
+		return supplyChainStep{Name: name, Status: "fail", Required: required, Detail: err.Error()}
+	}
+	return supplyChainStep{Name: name, Status: "pass", Required: required, Detail: strings.TrimSpace(output)}
+}
+
+// supplyChainPassed reports whether every required step passed
+func supplyChainPassed(steps []supplyChainStep) bool {
+	for _, step := range steps {
+		if step.Required && step.Status != "pass" {
+			return false
+		}
+	}
+	return true
+}
+
+// formatSupplyChainReport renders the per-step results and the overall verdict
+func formatSupplyChainReport(image string, steps []supplyChainStep) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Supply chain verification for %s\n\n", image)
+	for _, step := range steps {
+		fmt.Fprintf(&b, "[%s] %s\n", strings.ToUpper(step.Status), step.Name)
+		if step.Detail != "" {
+			for _, line := range strings.Split(step.Detail, "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+	}
+
+	result := "PASS"
+	if !supplyChainPassed(steps) {
+		result = "FAIL"
+	}
+	fmt.Fprintf(&b, "\nResult: %s\n", result)
+	return b.String()
+}
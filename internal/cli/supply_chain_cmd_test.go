@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSupplyChainVerifier struct {
+	signatureErr  error
+	provenanceErr error
+	provenanceRan bool
+	identity      modules.CosignIdentity
+}
+
+func (f *fakeSupplyChainVerifier) VerifySignature(ctx context.Context, image string, key string, keyless bool, identity modules.CosignIdentity) (string, error) {
+	f.identity = identity
+	if f.signatureErr != nil {
+		return "", f.signatureErr
+	}
+	return "Verification for " + image + " -- signatures verified", nil
+}
+
+func (f *fakeSupplyChainVerifier) VerifyProvenance(ctx context.Context, image string, sourceURI string, builderID string) (string, error) {
+	f.provenanceRan = true
+	if f.provenanceErr != nil {
+		return "", f.provenanceErr
+	}
+	return "PASSED: SLSA verification passed", nil
+}
+
+func TestVerifySupplyChain_AllPass(t *testing.T) {
+	verifier := &fakeSupplyChainVerifier{}
+	steps := verifySupplyChain(context.Background(), verifier, "ghcr.io/org/app@sha256:abc", supplyChainVerifyOptions{
+		Keyless:   true,
+		SourceURI: "github.com/org/app",
+	})
+
+	require.Len(t, steps, 2)
+	assert.Equal(t, "pass", steps[0].Status)
+	assert.Equal(t, "pass", steps[1].Status)
+	assert.True(t, supplyChainPassed(steps))
+	assert.Contains(t, formatSupplyChainReport("ghcr.io/org/app@sha256:abc", steps), "Result: PASS")
+}
+
+func TestVerifySupplyChain_OneFail(t *testing.T) {
+	verifier := &fakeSupplyChainVerifier{provenanceErr: errors.New("source URI mismatch")}
+	steps := verifySupplyChain(context.Background(), verifier, "ghcr.io/org/app@sha256:abc", supplyChainVerifyOptions{
+		Key:       "cosign.pub",
+		SourceURI: "github.com/org/other",
+	})
+
+	require.Len(t, steps, 2)
+	assert.Equal(t, "pass", steps[0].Status)
+	assert.Equal(t, "fail", steps[1].Status)
+	assert.False(t, supplyChainPassed(steps))
+
+	report := formatSupplyChainReport("ghcr.io/org/app@sha256:abc", steps)
+	assert.Contains(t, report, "[FAIL] slsa provenance")
+	assert.Contains(t, report, "source URI mismatch")
+	assert.Contains(t, report, "Result: FAIL")
+}
+
+func TestVerifySupplyChain_SignatureFailStillRunsProvenance(t *testing.T) {
+	verifier := &fakeSupplyChainVerifier{signatureErr: errors.New("no matching signatures")}
+	steps := verifySupplyChain(context.Background(), verifier, "app:1.0", supplyChainVerifyOptions{
+		Keyless:   true,
+		SourceURI: "github.com/org/app",
+	})
+
+	assert.True(t, verifier.provenanceRan)
+	assert.Equal(t, "fail", steps[0].Status)
+	assert.False(t, supplyChainPassed(steps))
+}
+
+func TestVerifySupplyChain_SkipsProvenanceWithoutSourceURI(t *testing.T) {
+	verifier := &fakeSupplyChainVerifier{}
+	steps := verifySupplyChain(context.Background(), verifier, "app:1.0", supplyChainVerifyOptions{Keyless: true})
+
+	require.Len(t, steps, 2)
+	assert.False(t, verifier.provenanceRan)
+	assert.Equal(t, "skipped", steps[1].Status)
+	assert.True(t, supplyChainPassed(steps))
+}
+
+func TestVerifySupplyChain_PassesKeylessIdentity(t *testing.T) {
+	verifier := &fakeSupplyChainVerifier{}
+	identity := modules.CosignIdentity{
+		IdentityRegexp: "^https://github.com/org/app/",
+		Issuer:         "https://token.actions.githubusercontent.com",
+	}
+	verifySupplyChain(context.Background(), verifier, "app:1.0", supplyChainVerifyOptions{Keyless: true, Identity: identity})
+
+	assert.Equal(t, identity, verifier.identity)
+}
+
+func TestValidateSupplyChainVerify(t *testing.T) {
+	identity := modules.CosignIdentity{Identity: "release@example.com", Issuer: "https://accounts.google.com"}
+
+	assert.NoError(t, validateSupplyChainVerify(supplyChainVerifyOptions{Key: "cosign.pub"}))
+	assert.NoError(t, validateSupplyChainVerify(supplyChainVerifyOptions{Keyless: true, Identity: identity}))
+	assert.ErrorContains(t, validateSupplyChainVerify(supplyChainVerifyOptions{}), "either --key or --keyless is required")
+	assert.ErrorContains(t, validateSupplyChainVerify(supplyChainVerifyOptions{Key: "cosign.pub", Keyless: true}), "mutually exclusive")
+	assert.ErrorContains(t, validateSupplyChainVerify(supplyChainVerifyOptions{Keyless: true, Identity: modules.CosignIdentity{Issuer: "https://accounts.google.com"}}),
+		"--keyless requires --certificate-identity or --certificate-identity-regexp")
+	assert.ErrorContains(t, validateSupplyChainVerify(supplyChainVerifyOptions{Keyless: true, Identity: modules.CosignIdentity{Identity: "release@example.com"}}),
+		"--keyless requires --certificate-oidc-issuer or --certificate-oidc-issuer-regexp")
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var supplyChainRekorCmd = &cobra.Command{
+	Use:   "rekor",
+	Short: "Query the Rekor transparency log",
+	Long: `Look up entries in the Rekor transparency log by artifact hash or entry UUID.
+Each entry is printed as JSON with its inclusion proof, showing when and how
+the artifact's signature was recorded.
+
+Examples:
+  # Find the entries for an artifact
+  ship supply-chain rekor --hash sha256:8f6b4dc6a3b4b8e7c0f2fbbd1c1b3b2f9b0e5f2a4c7d3e1f0a9b8c7d6e5f4a3b
+
+  # Get one entry from a private Rekor instance
+  ship supply-chain rekor --uuid 24296fb24b8ad77a... --rekor-server https://rekor.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runTool("rekor", runSupplyChainRekor),
+}
+
+func init() {
+	supplyChainCmd.AddCommand(supplyChainRekorCmd)
+
+	supplyChainRekorCmd.Flags().String("hash", "", "SHA-256 hash of the artifact (sha256:<hex> or <hex>)")
+	supplyChainRekorCmd.Flags().String("uuid", "", "UUID of the transparency log entry")
+	supplyChainRekorCmd.Flags().String("rekor-server", "", "Rekor server URL (default: the public instance)")
+}
+
+// rekorLookup queries the Rekor transparency log
+type rekorLookup interface {
+	GetEntry(ctx context.Context, uuid string, server string) (string, error)
+	SearchByHash(ctx context.Context, hash string, server string) ([]string, error)
+}
+
+type rekorQueryOptions struct {
+	Hash   string
+	UUID   string
+	Server string
+}
+
+func runSupplyChainRekor(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	hash, _ := cmd.Flags().GetString("hash")
+	uuid, _ := cmd.Flags().GetString("uuid")
+	server, _ := cmd.Flags().GetString("rekor-server")
+
+	telemetry.TrackCLICommand("supply-chain", "rekor", args)
+
+	opts := rekorQueryOptions{Hash: hash, UUID: uuid, Server: server}
+	if err := validateRekorQuery(opts); err != nil {
+		telemetry.TrackError("validation", "supply-chain_rekor", err.Error())
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "supply-chain_rekor", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := queryRekor(ctx, modules.NewRekorModule(engine.GetClient()), opts)
+	if err != nil {
+		telemetry.TrackError("rekor", "query", err.Error())
+		return "", err
+	}
+
+	telemetry.TrackDaggerOperation("supply_chain_rekor", "rekor", true, time.Since(start))
+	return result, nil
+}
+
+// validateRekorQuery checks that exactly one of --hash and --uuid was given
+func validateRekorQuery(opts rekorQueryOptions) error {
+	if opts.Hash == "" && opts.UUID == "" {
+		return fmt.Errorf("either --hash or --uuid is required")
+	}
+	if opts.Hash != "" && opts.UUID != "" {
+		return fmt.Errorf("--hash and --uuid are mutually exclusive")
+	}
+	return nil
+}
+
+// queryRekor returns the entry with the given UUID, or every entry recorded
+// for the given artifact hash
+func queryRekor(ctx context.Context, lookup rekorLookup, opts rekorQueryOptions) (string, error) {
+	if opts.UUID != "" {
+		return lookup.GetEntry(ctx, opts.UUID, opts.Server)
+	}
+
+	uuids, err := lookup.SearchByHash(ctx, opts.Hash, opts.Server)
+	if err != nil {
+		return "", err
+	}
+	if len(uuids) == 0 {
+		return "", fmt.Errorf("no rekor entries found for %s", opts.Hash)
+	}
+
+	entries := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		entry, err := lookup.GetEntry(ctx, uuid, opts.Server)
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, strings.TrimSpace(entry))
+	}
+	return strings.Join(entries, "\n"), nil
+}
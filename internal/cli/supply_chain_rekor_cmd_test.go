@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRekorLookup records the queries it receives and serves entries by UUID
+type fakeRekorLookup struct {
+	entries map[string]string
+	hashes  map[string][]string
+
+	gets     []string
+	searches []string
+	servers  []string
+}
+
+func (f *fakeRekorLookup) GetEntry(ctx context.Context, uuid string, server string) (string, error) {
+	f.gets = append(f.gets, uuid)
+	f.servers = append(f.servers, server)
+	entry, ok := f.entries[uuid]
+	if !ok {
+		return "", fmt.Errorf("entry %s not found", uuid)
+	}
+	return entry, nil
+}
+
+func (f *fakeRekorLookup) SearchByHash(ctx context.Context, hash string, server string) ([]string, error) {
+	f.searches = append(f.searches, hash)
+	f.servers = append(f.servers, server)
+	return f.hashes[hash], nil
+}
+
+func TestQueryRekorByUUID(t *testing.T) {
+	lookup := &fakeRekorLookup{entries: map[string]string{"uuid-1": `{"uuid-1":{"verification":{"inclusionProof":{}}}}`}}
+
+	result, err := queryRekor(context.Background(), lookup, rekorQueryOptions{UUID: "uuid-1", Server: "https://rekor.example.com"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "inclusionProof")
+	assert.Equal(t, []string{"uuid-1"}, lookup.gets)
+	assert.Empty(t, lookup.searches, "a uuid lookup does not search")
+	assert.Equal(t, []string{"https://rekor.example.com"}, lookup.servers)
+}
+
+func TestQueryRekorByHash(t *testing.T) {
+	lookup := &fakeRekorLookup{
+		entries: map[string]string{"uuid-1": "{\"uuid-1\":{}}\n", "uuid-2": "{\"uuid-2\":{}}\n"},
+		hashes:  map[string][]string{"sha256:abc": {"uuid-1", "uuid-2"}},
+	}
+
+	result, err := queryRekor(context.Background(), lookup, rekorQueryOptions{Hash: "sha256:abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"uuid-1\":{}}\n{\"uuid-2\":{}}", result)
+	assert.Equal(t, []string{"sha256:abc"}, lookup.searches)
+	assert.Equal(t, []string{"uuid-1", "uuid-2"}, lookup.gets, "every entry found for the hash is fetched")
+
+	_, err = queryRekor(context.Background(), lookup, rekorQueryOptions{Hash: "sha256:def"})
+	assert.ErrorContains(t, err, "no rekor entries found for sha256:def")
+}
+
+func TestValidateRekorQuery(t *testing.T) {
+	assert.NoError(t, validateRekorQuery(rekorQueryOptions{Hash: "sha256:abc"}))
+	assert.NoError(t, validateRekorQuery(rekorQueryOptions{UUID: "uuid-1"}))
+	assert.ErrorContains(t, validateRekorQuery(rekorQueryOptions{}), "either --hash or --uuid is required")
+	assert.ErrorContains(t, validateRekorQuery(rekorQueryOptions{Hash: "sha256:abc", UUID: "uuid-1"}), "mutually exclusive")
+}
+
+func TestSupplyChainRekorRouting(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"supply-chain", "rekor"})
+	require.NoError(t, err)
+	assert.Equal(t, supplyChainRekorCmd, cmd)
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("targets-file", "", "YAML or JSON list of scan targets, each with its own flags, to scan in turn into a combined report")
+}
+
+// targetsFileEntry is one target of a --targets-file and the flags it is
+// scanned with, on top of the flags given on the command line
+type targetsFileEntry struct {
+	Target string                 `yaml:"target"`
+	Flags  map[string]interface{} `yaml:"flags"`
+}
+
+// flagValues returns the entry's flags as command line values. Lists are
+// joined with commas; names are sorted so flags are applied in a stable order.
+func (e targetsFileEntry) flagValues() ([]string, map[string]string) {
+	names := make([]string, 0, len(e.Flags))
+	values := make(map[string]string, len(e.Flags))
+	for name, value := range e.Flags {
+		names = append(names, name)
+		switch v := value.(type) {
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[name] = strings.Join(items, ",")
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	sort.Strings(names)
+	return names, values
+}
+
+// parseTargetsFile reads a list of targets from YAML or JSON data, either as
+// a top-level list or under a "targets" key:
+//
+//	targets:
+//	  - target: alpine:3.19
+//	    flags:
+//	      severity: [CRITICAL, HIGH]
+//	  - target: ./infra
+func parseTargetsFile(data []byte) ([]targetsFileEntry, error) {
+	var entries []targetsFileEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		var doc struct {
+			Targets []targetsFileEntry `yaml:"targets"`
+		}
+		if docErr := yaml.Unmarshal(data, &doc); docErr != nil {
+			return nil, fmt.Errorf("failed to parse targets file: %w", err)
+		}
+		entries = doc.Targets
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("targets file lists no targets")
+	}
+	for i, entry := range entries {
+		if strings.TrimSpace(entry.Target) == "" {
+			return nil, fmt.Errorf("targets file entry %d has no target", i+1)
+		}
+	}
+	return entries, nil
+}
+
+// targetsFileEntries returns the entries of --targets-file, checked against
+// cmd's flags before any target is scanned, or nil when the flag is not set
+func targetsFileEntries(cmd *cobra.Command, args []string) ([]targetsFileEntry, error) {
+	path, _ := cmd.Flags().GetString("targets-file")
+	if path == "" {
+		return nil, nil
+	}
+
+	useStdin, _ := cmd.Flags().GetBool("stdin")
+	if useStdin || len(args) > 0 {
+		return nil, fmt.Errorf("--targets-file cannot be combined with --stdin or a target argument")
+	}
+	if err := cmd.ValidateArgs([]string{stdinTarget}); err != nil {
+		return nil, fmt.Errorf("--targets-file is not supported: %s does not take a target", cmd.CommandPath())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read targets file: %w", err)
+	}
+	entries, err := parseTargetsFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range entries {
+		names, values := entry.flagValues()
+		for _, name := range names {
+			flag := cmd.Flags().Lookup(name)
+			if flag == nil {
+				return nil, fmt.Errorf("targets file entry %d (%s): unknown flag --%s for %s", i+1, entry.Target, name, cmd.CommandPath())
+			}
+			if name == "targets-file" || name == "stdin" {
+				return nil, fmt.Errorf("targets file entry %d (%s): --%s cannot be set per target", i+1, entry.Target, name)
+			}
+			if err := validateEnumValue(flag, values[name]); err != nil {
+				return nil, fmt.Errorf("targets file entry %d (%s): %w", i+1, entry.Target, err)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// runTargetsFile scans every entry with its flags applied, continuing past
+// failures, and combines the outputs like targets read from stdin
+func runTargetsFile(cmd *cobra.Command, entries []targetsFileEntry, scan func(target string) (string, error)) (string, error) {
+	results := make([]stdinTargetResult, len(entries))
+	failed := 0
+	for i, entry := range entries {
+		output, err := withEntryFlags(cmd, entry, func() (string, error) {
+			return scan(entry.Target)
+		})
+		results[i] = stdinTargetResult{Target: entry.Target, Output: output, Err: err}
+		if err != nil {
+			failed++
+		}
+	}
+
+	output := formatStdinTargetResults(results)
+	if failed > 0 {
+		return output, fmt.Errorf("%d of %d targets failed", failed, len(entries))
+	}
+	return output, nil
+}
+
+// withEntryFlags sets the entry's flags on cmd for the duration of run and
+// then restores the command line values
+func withEntryFlags(cmd *cobra.Command, entry targetsFileEntry, run func() (string, error)) (string, error) {
+	names, values := entry.flagValues()
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		restore := saveFlag(flag)
+		defer restore()
+
+		if err := setFlag(flag, values[name]); err != nil {
+			return "", fmt.Errorf("invalid --%s %q: %w", name, values[name], err)
+		}
+	}
+	return run()
+}
+
+// saveFlag returns a func restoring flag's current value and changed state
+func saveFlag(flag *pflag.Flag) func() {
+	changed := flag.Changed
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		saved := slice.GetSlice()
+		return func() {
+			_ = slice.Replace(saved)
+			flag.Changed = changed
+		}
+	}
+
+	saved := flag.Value.String()
+	return func() {
+		_ = flag.Value.Set(saved)
+		flag.Changed = changed
+	}
+}
+
+// setFlag sets flag to value, replacing rather than appending to slice flags
+func setFlag(flag *pflag.Flag, value string) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		if err := slice.Replace(splitCommaList(value)); err != nil {
+			return err
+		}
+	} else if err := flag.Value.Set(value); err != nil {
+		return err
+	}
+	flag.Changed = true
+	return nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTargetsFile writes content to a targets file and sets --targets-file
+func writeTargetsFile(t *testing.T, cmd *cobra.Command, name, content string) {
+	t.Helper()
+	cmd.Flags().String("targets-file", "", "")
+	require.NoError(t, cmd.Flags().Set("targets-file", writeTargetsFileContent(t, name, content)))
+}
+
+func TestParseTargetsFile(t *testing.T) {
+	yamlEntries, err := parseTargetsFile([]byte(`
+targets:
+  - target: alpine:3.19
+    flags:
+      severity: [CRITICAL, HIGH]
+      ignore-unfixed: true
+  - target: ./infra
+`))
+	require.NoError(t, err)
+	require.Len(t, yamlEntries, 2)
+	assert.Equal(t, "alpine:3.19", yamlEntries[0].Target)
+	names, values := yamlEntries[0].flagValues()
+	assert.Equal(t, []string{"ignore-unfixed", "severity"}, names)
+	assert.Equal(t, map[string]string{"ignore-unfixed": "true", "severity": "CRITICAL,HIGH"}, values)
+	assert.Equal(t, "./infra", yamlEntries[1].Target)
+	assert.Empty(t, yamlEntries[1].Flags)
+
+	jsonEntries, err := parseTargetsFile([]byte(`[{"target": "nginx:1.25", "flags": {"severity": "LOW"}}]`))
+	require.NoError(t, err)
+	require.Len(t, jsonEntries, 1)
+	_, values = jsonEntries[0].flagValues()
+	assert.Equal(t, map[string]string{"severity": "LOW"}, values)
+}
+
+func TestParseTargetsFile_Invalid(t *testing.T) {
+	_, err := parseTargetsFile([]byte("targets: []\n"))
+	assert.ErrorContains(t, err, "no targets")
+
+	_, err = parseTargetsFile([]byte("- flags: {severity: LOW}\n"))
+	assert.ErrorContains(t, err, "entry 1 has no target")
+
+	_, err = parseTargetsFile([]byte("targets: ["))
+	assert.ErrorContains(t, err, "failed to parse targets file")
+}
+
+func TestRunTool_TargetsFileAppliesPerEntryFlags(t *testing.T) {
+	cmd, stdout := newStdinTestCmd(t, "", cobra.MaximumNArgs(1))
+	cmd.Flags().String("severity", "UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL", "")
+	cmd.Flags().StringSlice("skip-dirs", nil, "")
+	require.NoError(t, cmd.Flags().Set("skip-dirs", "vendor"))
+	writeTargetsFile(t, cmd, "targets.yaml", `
+- target: alpine:3.19
+  flags:
+    severity: CRITICAL
+    skip-dirs: [node_modules, dist]
+- target: nginx:1.25
+`)
+
+	type call struct {
+		Target   string
+		Severity string
+		SkipDirs []string
+		Changed  bool
+	}
+	var calls []call
+	run := func(cmd *cobra.Command, args []string) (string, error) {
+		severity, _ := cmd.Flags().GetString("severity")
+		skipDirs, _ := cmd.Flags().GetStringSlice("skip-dirs")
+		calls = append(calls, call{args[0], severity, skipDirs, cmd.Flags().Changed("severity")})
+		return "scanned " + args[0], nil
+	}
+
+	require.NoError(t, runTool("trivy", run)(cmd, nil))
+
+	assert.Equal(t, []call{
+		{"alpine:3.19", "CRITICAL", []string{"node_modules", "dist"}, true},
+		{"nginx:1.25", "UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL", []string{"vendor"}, false},
+	}, calls)
+	assert.Equal(t, "==> alpine:3.19 <==\nscanned alpine:3.19\n\n==> nginx:1.25 <==\nscanned nginx:1.25\n", stdout.String())
+
+	severity, _ := cmd.Flags().GetString("severity")
+	assert.Equal(t, "UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL", severity, "command line flags are restored after the run")
+}
+
+func TestRunTool_TargetsFileRejectsInvalidEntriesBeforeScanning(t *testing.T) {
+	cmd, _ := newStdinTestCmd(t, "", cobra.MaximumNArgs(1))
+	cmd.Flags().String("scanners", "vuln", "")
+	registerEnumFlag(cmd, "scanners", "vuln", "misconfig")
+	writeTargetsFile(t, cmd, "targets.json", `[
+  {"target": "alpine:3.19"},
+  {"target": "./infra", "flags": {"scanners": "bogus"}}
+]`)
+
+	var calls [][]string
+	run := recordingRun(&calls, func(target string) (string, error) { return "ok", nil })
+
+	err := runTool("trivy", run)(cmd, nil)
+	assert.ErrorContains(t, err, `targets file entry 2 (./infra): invalid --scanners "bogus"`)
+	assert.Empty(t, calls)
+
+	require.NoError(t, cmd.Flags().Set("targets-file", writeTargetsFileContent(t, "targets.yaml", "- target: x\n  flags: {no-such-flag: 1}\n")))
+	assert.ErrorContains(t, runTool("trivy", run)(cmd, nil), "unknown flag --no-such-flag")
+}
+
+func TestRunTool_TargetsFileConflicts(t *testing.T) {
+	cmd, _ := newStdinTestCmd(t, "", cobra.MaximumNArgs(1))
+	writeTargetsFile(t, cmd, "targets.yaml", "- target: alpine:3.19\n")
+	run := func(cmd *cobra.Command, args []string) (string, error) { return "ok", nil }
+
+	assert.ErrorContains(t, runTool("trivy", run)(cmd, []string{"nginx"}), "cannot be combined")
+
+	noArgs, _ := newStdinTestCmd(t, "", cobra.NoArgs)
+	writeTargetsFile(t, noArgs, "targets.yaml", "- target: alpine:3.19\n")
+	assert.ErrorContains(t, runTool("trivy", run)(noArgs, nil), "does not take a target")
+}
+
+// writeTargetsFileContent writes content to a temporary targets file and
+// returns its path
+func writeTargetsFileContent(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
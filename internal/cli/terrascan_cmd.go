@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var terrascanCmd = &cobra.Command{
+	Use:   "terrascan [directory]",
+	Short: "Scan Infrastructure as Code with Terrascan",
+	Long: `Scan Infrastructure as Code for security and compliance violations using Terrascan.
+
+The directory defaults to the current directory.
+
+Examples:
+  # Scan the current directory
+  ship security terrascan
+
+  # Scan Kubernetes manifests for high severity issues only
+  ship security terrascan ./k8s --iac-type k8s --severity high
+
+  # Scan with a custom policy directory and skip specific rules
+  ship security terrascan ./infra --policy-path ./policies --skip-rules AC_AWS_0207`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("terrascan", runTerrascan),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(terrascanCmd)
+
+	terrascanCmd.Flags().String("iac-type", "", "IaC type to scan (terraform, k8s, helm, kustomize, cft, docker, arm)")
+	terrascanCmd.Flags().String("policy-path", "", "Directory containing custom Rego policies")
+	terrascanCmd.Flags().String("severity", "", "Minimum severity to report (low, medium, high)")
+	terrascanCmd.Flags().String("skip-rules", "", "Comma-separated list of rule IDs to skip")
+}
+
+func runTerrascan(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	dir := scanTargetDir(args)
+
+	telemetry.TrackCLICommand("security", "terrascan", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "terrascan", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	terrascanModule := modules.NewTerrascanModule(engine.GetClient())
+	result, err := terrascanModule.Scan(ctx, dir, terrascanOptionsFromFlags(cmd)...)
+	if err != nil {
+		telemetry.TrackError("terrascan", "scan", err.Error())
+		return "", fmt.Errorf("terrascan scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("terrascan_scan", "terrascan", true, time.Since(start))
+	return result, nil
+}
+
+// terrascanOptionsFromFlags maps the command's flags onto module options
+func terrascanOptionsFromFlags(cmd *cobra.Command) []modules.TerrascanOption {
+	iacType, _ := cmd.Flags().GetString("iac-type")
+	policyPath, _ := cmd.Flags().GetString("policy-path")
+	severity, _ := cmd.Flags().GetString("severity")
+	skipRules, _ := cmd.Flags().GetString("skip-rules")
+
+	return []modules.TerrascanOption{
+		modules.WithTerrascanIaCType(iacType),
+		modules.WithTerrascanPolicyPath(policyPath),
+		modules.WithTerrascanSeverity(severity),
+		modules.WithTerrascanSkipRules(splitCommaList(skipRules)),
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setFlagsForTest sets flags on cmd and restores their previous values when the test ends
+func setFlagsForTest(t *testing.T, cmd *cobra.Command, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		flag := cmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "flag %s should exist", name)
+		t.Cleanup(saveFlag(flag))
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+}
+
+func TestTerrascanCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "terrascan"})
+	require.NoError(t, err)
+	assert.Equal(t, terrascanCmd, cmd)
+}
+
+func TestTerrascanOptionsFromFlags(t *testing.T) {
+	setFlagsForTest(t, terrascanCmd, map[string]string{
+		"iac-type":    "terraform",
+		"policy-path": "./policies",
+		"severity":    "high",
+		"skip-rules":  "AC_AWS_0207, AC_AWS_0214",
+	})
+
+	config := &modules.TerrascanConfig{}
+	for _, opt := range terrascanOptionsFromFlags(terrascanCmd) {
+		opt(config)
+	}
+
+	assert.Equal(t, "terraform", config.IaCType)
+	assert.Equal(t, "./policies", config.PolicyPath)
+	assert.Equal(t, "high", config.Severity)
+	assert.Equal(t, []string{"AC_AWS_0207", "AC_AWS_0214"}, config.SkipRules)
+}
+
+func TestTerrascanOptionsFromFlags_Defaults(t *testing.T) {
+	config := &modules.TerrascanConfig{}
+	for _, opt := range terrascanOptionsFromFlags(terrascanCmd) {
+		opt(config)
+	}
+
+	assert.Empty(t, config.IaCType)
+	assert.Empty(t, config.PolicyPath)
+	assert.Empty(t, config.Severity)
+	assert.Empty(t, config.SkipRules)
+}
+
+func TestScanTargetDir(t *testing.T) {
+	assert.Equal(t, ".", scanTargetDir(nil))
+	assert.Equal(t, ".", scanTargetDir([]string{""}))
+	assert.Equal(t, "./infra", scanTargetDir([]string{"./infra"}))
+}
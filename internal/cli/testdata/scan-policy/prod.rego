@@ -0,0 +1,17 @@
+package main
+
+import rego.v1
+
+# Errors in production modules block the change
+deny contains msg if {
+	some finding in input.findings
+	finding.severity == "error"
+	startswith(finding.file, "modules/prod/")
+	msg := sprintf("%s reported %s in %s", [finding.tool, finding.rule_id, finding.file])
+}
+
+# A scanner that could not run leaves the result incomplete
+warn contains msg if {
+	some failure in input.errors
+	msg := sprintf("%s did not run: %s", [failure.tool, failure.error])
+}
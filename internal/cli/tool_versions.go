@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"strings"
+	"sync"
+)
+
+// toolVersionWorkers bounds how many version containers run at once
+const toolVersionWorkers = 4
+
+// toolVersionResult is the version lookup outcome for one tool
+type toolVersionResult struct {
+	Tool    string `json:"tool"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// toolVersionCache memoizes tool version lookups for the lifetime of the process.
+// Concurrent lookups of the same tool share a single fetch.
+type toolVersionCache struct {
+	fetch func(tool string) (string, error)
+
+	mu      sync.Mutex
+	entries map[string]*toolVersionEntry
+}
+
+type toolVersionEntry struct {
+	once    sync.Once
+	version string
+	err     error
+}
+
+// processToolVersions caches versions fetched by `ship mcp --version`
+var processToolVersions = newToolVersionCache(getToolVersion)
+
+func newToolVersionCache(fetch func(tool string) (string, error)) *toolVersionCache {
+	return &toolVersionCache{
+		fetch:   fetch,
+		entries: make(map[string]*toolVersionEntry),
+	}
+}
+
+// get returns the version of tool, fetching it on first access
+func (c *toolVersionCache) get(tool string) (string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[tool]
+	if !ok {
+		entry = &toolVersionEntry{}
+		c.entries[tool] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		version, err := c.fetch(tool)
+		entry.version = strings.TrimSpace(version)
+		entry.err = err
+	})
+
+	return entry.version, entry.err
+}
+
+// getAll fetches the versions of tools with a bounded worker pool. Results are
+// returned in the same order as tools.
+func (c *toolVersionCache) getAll(tools []string, workers int) []toolVersionResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]toolVersionResult, len(tools))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tools); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				version, err := c.get(tools[i])
+				results[i] = toolVersionResult{Tool: tools[i], Version: version}
+				if err != nil {
+					results[i].Error = err.Error()
+				}
+			}
+		}()
+	}
+
+	for i := range tools {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolVersionCacheFetchesConcurrently(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	cache := newToolVersionCache(func(tool string) (string, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		mu.Lock()
+		calls[tool]++
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		if tool == "ansible" {
+			return "", errors.New("image not found")
+		}
+		return tool + " 1.0.0\n", nil
+	})
+
+	tools := []string{"checkov", "trivy", "tflint", "ansible"}
+	results := cache.getAll(tools, 4)
+
+	require.Len(t, results, len(tools))
+	for i, tool := range tools {
+		assert.Equal(t, tool, results[i].Tool)
+	}
+	assert.Equal(t, "checkov 1.0.0", results[0].Version)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, "image not found", results[3].Error)
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(1), "versions should be fetched concurrently")
+
+	// Second access is served from the cache
+	again := cache.getAll(tools, 4)
+	assert.Equal(t, results, again)
+	for _, tool := range tools {
+		assert.Equal(t, 1, calls[tool], tool)
+	}
+}
+
+func TestToolVersionCacheSharesInFlightFetch(t *testing.T) {
+	var calls int32
+	cache := newToolVersionCache(func(tool string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return "v1", nil
+	})
+
+	results := cache.getAll([]string{"trivy", "trivy", "trivy"}, 3)
+	for _, result := range results {
+		assert.Equal(t, "v1", result.Version)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var trivyCmd = &cobra.Command{
+	Use:   "trivy [target]",
+	Short: "Scan images and directories for vulnerabilities or compliance with Trivy",
+	Long: `Scan a container image or a directory for vulnerabilities using Trivy, or
+evaluate a compliance spec with --compliance.
+
+The target defaults to the current directory. A target that is an existing
+directory is scanned as a filesystem; any other target is pulled as an image.
+
+Compliance specs:
+  docker-cis          CIS Docker benchmark for the image target
+  k8s-cis             CIS Kubernetes benchmark for a cluster
+  k8s-nsa             NSA/CISA Kubernetes hardening guidance for a cluster
+  k8s-pss-baseline    Pod Security Standards, baseline profile
+  k8s-pss-restricted  Pod Security Standards, restricted profile
+
+Kubernetes specs scan the cluster of --kubeconfig; the target, when given,
+selects its context. --report summary lists each control's status and
+--report all adds the findings behind it.
+
+--misconfig-only checks a directory of infrastructure as code, such as
+Terraform or Kubernetes manifests, for misconfigurations only. It skips the
+vulnerability and secret scanners, like trivy --scanners misconfig.
+
+Vulnerability scans of Java archives need trivy's Java DB, which is
+downloaded separately from ghcr.io and cached between runs. Behind a proxy
+that blocks it, point --java-db-repository at a reachable mirror.
+
+Examples:
+  # Scan the current directory
+  ship security trivy
+
+  # Fast IaC check of a Terraform directory
+  ship security trivy ./infra --misconfig-only
+
+  # CIS Docker benchmark of an image
+  ship security trivy nginx:1.25 --compliance docker-cis
+
+  # Full CIS Kubernetes report as a table
+  ship security trivy --compliance k8s-cis --kubeconfig ~/.kube/config --report all --format table`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("trivy", runTrivy),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(trivyCmd)
+
+	trivyCmd.Flags().String("compliance", "", "Compliance spec to evaluate by name or versioned ID (docker-cis, k8s-cis, k8s-nsa, k8s-pss-baseline, k8s-pss-restricted, or e.g. k8s-cis-1.23)")
+	trivyCmd.Flags().String("report", "summary", "Compliance report detail (summary, all)")
+	trivyCmd.Flags().String("format", "json", "Output format (json; table for compliance; cosign-vuln, github for scans)")
+	trivyCmd.Flags().String("kubeconfig", "", "Kubeconfig of the cluster scanned by Kubernetes compliance specs")
+	trivyCmd.Flags().Bool("misconfig-only", false, "Only check a directory for IaC misconfigurations (same as trivy --scanners misconfig)")
+	trivyCmd.Flags().String("java-db-repository", "", "OCI repository to download trivy's Java DB from, e.g. a mirror reachable behind a proxy")
+
+	registerEnumFlag(trivyCmd, "compliance", modules.TrivyComplianceSpecNames()...)
+	registerEnumFlag(trivyCmd, "report", "summary", "all")
+	registerEnumFlag(trivyCmd, "format", "json", "table", "cosign-vuln", "github")
+}
+
+// trivyScanner is the subset of the Trivy module the command dispatches to
+type trivyScanner interface {
+	ScanImage(ctx context.Context, imageName string, opts ...modules.TrivyOption) (string, error)
+	ScanFilesystem(ctx context.Context, dir string, opts ...modules.TrivyOption) (string, error)
+	ScanCompliance(ctx context.Context, target string, specName string, opts ...modules.TrivyComplianceOption) (string, error)
+}
+
+func runTrivy(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+
+	telemetry.TrackCLICommand("security", "trivy", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "trivy", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := dispatchTrivyScan(ctx, modules.NewTrivyModule(engine.GetClient()), cmd, args)
+	if err != nil {
+		telemetry.TrackError("trivy", "scan", err.Error())
+		return "", fmt.Errorf("trivy scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("trivy_scan", "trivy", true, time.Since(start))
+	return result, nil
+}
+
+// dispatchTrivyScan evaluates the --compliance spec when set, and otherwise
+// scans the target as a directory when it names one and as an image otherwise
+func dispatchTrivyScan(ctx context.Context, scanner trivyScanner, cmd *cobra.Command, args []string) (string, error) {
+	compliance, _ := cmd.Flags().GetString("compliance")
+	format, _ := cmd.Flags().GetString("format")
+	misconfigOnly, _ := cmd.Flags().GetBool("misconfig-only")
+
+	if compliance != "" {
+		if misconfigOnly {
+			return "", fmt.Errorf("--misconfig-only cannot be combined with --compliance")
+		}
+
+		spec, ok := modules.LookupTrivyComplianceSpec(compliance)
+		if !ok {
+			return "", fmt.Errorf("unsupported --compliance %q: expected one of %s", compliance, strings.Join(modules.TrivyComplianceSpecNames(), ", "))
+		}
+
+		var target string
+		if len(args) > 0 {
+			target = args[0]
+		}
+		if !spec.IsCluster() && target == "" {
+			return "", fmt.Errorf("--compliance %s needs an image target", compliance)
+		}
+
+		report, _ := cmd.Flags().GetString("report")
+		kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+		return scanner.ScanCompliance(ctx, target, compliance,
+			modules.WithTrivyComplianceReport(report),
+			modules.WithTrivyComplianceFormat(format),
+			modules.WithTrivyComplianceKubeconfig(kubeconfig),
+		)
+	}
+
+	if cmd.Flags().Changed("report") || cmd.Flags().Changed("kubeconfig") {
+		return "", fmt.Errorf("--report and --kubeconfig require --compliance")
+	}
+
+	javaDBRepository, _ := cmd.Flags().GetString("java-db-repository")
+	opts := []modules.TrivyOption{
+		modules.WithTrivyFormat(format),
+		modules.WithTrivyJavaDBRepository(javaDBRepository),
+	}
+
+	target := scanTargetDir(args)
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		if misconfigOnly {
+			opts = append(opts, modules.WithTrivyMisconfigOnly())
+		}
+		return scanner.ScanFilesystem(ctx, target, opts...)
+	}
+	if misconfigOnly {
+		return "", fmt.Errorf("--misconfig-only needs a directory target, %s is not a directory", target)
+	}
+	return scanner.ScanImage(ctx, target, opts...)
+}
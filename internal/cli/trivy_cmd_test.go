@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrivyScanner records which scan method was called
+type fakeTrivyScanner struct {
+	method     string
+	target     string
+	spec       string
+	config     modules.TrivyConfig
+	compliance modules.TrivyComplianceConfig
+}
+
+func (f *fakeTrivyScanner) ScanImage(ctx context.Context, imageName string, opts ...modules.TrivyOption) (string, error) {
+	f.method, f.target = "ScanImage", imageName
+	for _, opt := range opts {
+		opt(&f.config)
+	}
+	return "image report", nil
+}
+
+func (f *fakeTrivyScanner) ScanFilesystem(ctx context.Context, dir string, opts ...modules.TrivyOption) (string, error) {
+	f.method, f.target = "ScanFilesystem", dir
+	for _, opt := range opts {
+		opt(&f.config)
+	}
+	return "filesystem report", nil
+}
+
+func (f *fakeTrivyScanner) ScanCompliance(ctx context.Context, target string, specName string, opts ...modules.TrivyComplianceOption) (string, error) {
+	f.method, f.target, f.spec = "ScanCompliance", target, specName
+	for _, opt := range opts {
+		opt(&f.compliance)
+	}
+	return `{"ID":"docker-cis-1.6.0","SummaryControls":[]}`, nil
+}
+
+func TestTrivyCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "trivy"})
+	require.NoError(t, err)
+	assert.Equal(t, trivyCmd, cmd)
+}
+
+func TestDispatchTrivyScan_Compliance(t *testing.T) {
+	setFlagsForTest(t, trivyCmd, map[string]string{
+		"compliance": "docker-cis",
+		"report":     "all",
+	})
+
+	scanner := &fakeTrivyScanner{}
+	result, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{"nginx:1.25"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ScanCompliance", scanner.method)
+	assert.Equal(t, "nginx:1.25", scanner.target)
+	assert.Equal(t, "docker-cis", scanner.spec)
+	assert.Equal(t, "all", scanner.compliance.Report)
+	assert.Equal(t, "json", scanner.compliance.Format)
+	assert.JSONEq(t, `{"ID":"docker-cis-1.6.0","SummaryControls":[]}`, result, "the compliance report is returned as is")
+}
+
+func TestDispatchTrivyScan_ClusterComplianceWithoutTarget(t *testing.T) {
+	setFlagsForTest(t, trivyCmd, map[string]string{
+		"compliance": "k8s-cis",
+		"kubeconfig": "/home/user/.kube/config",
+		"format":     "table",
+	})
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, scanner.target, "cluster specs use the kubeconfig's current context")
+	assert.Equal(t, "/home/user/.kube/config", scanner.compliance.Kubeconfig)
+	assert.Equal(t, "table", scanner.compliance.Format)
+	assert.Equal(t, "summary", scanner.compliance.Report)
+}
+
+func TestTrivyCmd_ComplianceAcceptsVersionedIDs(t *testing.T) {
+	setFlagsForTest(t, trivyCmd, map[string]string{"compliance": "k8s-cis-1.23"})
+	assert.NoError(t, validateEnumFlags(trivyCmd), "LookupTrivyComplianceSpec resolves versioned IDs")
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "k8s-cis-1.23", scanner.spec)
+}
+
+func TestDispatchTrivyScan_ComplianceErrors(t *testing.T) {
+	t.Run("unknown spec", func(t *testing.T) {
+		setFlagsForTest(t, trivyCmd, map[string]string{"compliance": "pci-dss"})
+		_, err := dispatchTrivyScan(context.Background(), &fakeTrivyScanner{}, trivyCmd, []string{"nginx:1.25"})
+		assert.ErrorContains(t, err, `unsupported --compliance "pci-dss"`)
+	})
+
+	t.Run("image spec without target", func(t *testing.T) {
+		setFlagsForTest(t, trivyCmd, map[string]string{"compliance": "docker-cis"})
+		_, err := dispatchTrivyScan(context.Background(), &fakeTrivyScanner{}, trivyCmd, nil)
+		assert.ErrorContains(t, err, "needs an image target")
+	})
+
+	t.Run("report without compliance", func(t *testing.T) {
+		setFlagsForTest(t, trivyCmd, map[string]string{"report": "all"})
+		_, err := dispatchTrivyScan(context.Background(), &fakeTrivyScanner{}, trivyCmd, nil)
+		assert.ErrorContains(t, err, "require --compliance")
+	})
+}
+
+func TestDispatchTrivyScan_VulnerabilityScan(t *testing.T) {
+	dir := t.TempDir()
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "ScanFilesystem", scanner.method)
+	assert.Equal(t, dir, scanner.target)
+	assert.Equal(t, "json", scanner.config.Format)
+
+	scanner = &fakeTrivyScanner{}
+	_, err = dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{"alpine:3.19"})
+	require.NoError(t, err)
+	assert.Equal(t, "ScanImage", scanner.method)
+}
+
+func TestDispatchTrivyScan_JavaDBRepository(t *testing.T) {
+	setFlagsForTest(t, trivyCmd, map[string]string{
+		"java-db-repository": "mirror.example.com/aquasecurity/trivy-java-db:1",
+	})
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{"eclipse-temurin:21"})
+	require.NoError(t, err)
+	assert.Equal(t, "mirror.example.com/aquasecurity/trivy-java-db:1", scanner.config.JavaDBRepository)
+}
+
+func TestDispatchTrivyScan_MisconfigOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{dir})
+	require.NoError(t, err)
+	assert.Nil(t, scanner.config.Scanners, "the module's default scanners apply without --misconfig-only")
+
+	setFlagsForTest(t, trivyCmd, map[string]string{"misconfig-only": "true"})
+
+	scanner = &fakeTrivyScanner{}
+	_, err = dispatchTrivyScan(context.Background(), scanner, trivyCmd, []string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "ScanFilesystem", scanner.method)
+	assert.Equal(t, []string{"misconfig"}, scanner.config.Scanners)
+
+	_, err = dispatchTrivyScan(context.Background(), &fakeTrivyScanner{}, trivyCmd, []string{"alpine:3.19"})
+	assert.ErrorContains(t, err, "needs a directory target")
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var trivyK8sCmd = &cobra.Command{
+	Use:   "trivy-k8s",
+	Short: "Scan a Kubernetes cluster for vulnerabilities and misconfigurations with Trivy",
+	Long: `Scan the workloads of the Kubernetes cluster in --kubeconfig with Trivy.
+
+The current context of the kubeconfig is scanned unless --context names
+another. --target selects what is scanned: cluster or all for everything,
+workload for the workload kinds, or a resource kind such as deployment;
+--include-kinds lists several kinds instead. Limit the scan to one namespace
+with --namespace, to some with --include-namespaces, or skip some, such as
+kube-system, with --exclude-namespaces.
+
+Examples:
+  # Scan the cluster of the current kubeconfig context
+  ship security trivy-k8s --kubeconfig ~/.kube/config
+
+  # Scan the deployments of two application namespaces for critical issues
+  ship security trivy-k8s --kubeconfig ~/.kube/config --include-namespaces payments,orders --include-kinds deployment --severity CRITICAL
+
+  # Scan the workloads of the payments namespace
+  ship security trivy-k8s --kubeconfig ~/.kube/config --target workload --namespace payments
+
+  # Scan everything except the system namespaces of the staging context
+  ship security trivy-k8s --kubeconfig ~/.kube/config --context staging --exclude-namespaces kube-system,kube-public`,
+	Args: cobra.NoArgs,
+	RunE: runTool("trivy-k8s", runTrivyK8s),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(trivyK8sCmd)
+
+	trivyK8sCmd.Flags().String("context", "", "Kubeconfig context to scan (default: the current context)")
+	trivyK8sCmd.Flags().String("target", "cluster", "What to scan: cluster, all, workload, or a resource kind")
+	trivyK8sCmd.Flags().String("namespace", "", "Single namespace to scan")
+	trivyK8sCmd.Flags().StringSlice("include-namespaces", nil, "Comma-separated namespaces to scan")
+	trivyK8sCmd.Flags().StringSlice("exclude-namespaces", nil, "Comma-separated namespaces to skip")
+	trivyK8sCmd.Flags().StringSlice("include-kinds", nil, "Comma-separated resource kinds to scan, e.g. deployment,pod")
+	trivyK8sCmd.Flags().String("severity", "", "Comma-separated severities to report, e.g. HIGH,CRITICAL")
+	trivyK8sCmd.Flags().String("kubeconfig", "", "Kubeconfig of the cluster to scan")
+	_ = trivyK8sCmd.MarkFlagRequired("kubeconfig")
+
+	trivyK8sCmd.MarkFlagsMutuallyExclusive("namespace", "include-namespaces")
+	trivyK8sCmd.MarkFlagsMutuallyExclusive("namespace", "exclude-namespaces")
+	trivyK8sCmd.MarkFlagsMutuallyExclusive("target", "include-kinds")
+}
+
+// trivyK8sScanner is the subset of the Trivy module the command calls
+type trivyK8sScanner interface {
+	ScanKubernetes(ctx context.Context, clusterContext string, severity string, outputFormat string, scanners string, includeImages bool, opts ...modules.TrivyKubernetesOption) (string, error)
+}
+
+func runTrivyK8s(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+
+	telemetry.TrackCLICommand("security", "trivy-k8s", args)
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "trivy-k8s", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := scanTrivyK8s(ctx, modules.NewTrivyModule(engine.GetClient()), cmd)
+	if err != nil {
+		telemetry.TrackError("trivy", "k8s", err.Error())
+		return "", fmt.Errorf("trivy kubernetes scan failed: %w", err)
+	}
+
+	telemetry.TrackDaggerOperation("trivy_k8s_scan", "trivy", true, time.Since(start))
+	return result, nil
+}
+
+// scanTrivyK8s runs the Kubernetes scan with the command's flags
+func scanTrivyK8s(ctx context.Context, scanner trivyK8sScanner, cmd *cobra.Command) (string, error) {
+	clusterContext, _ := cmd.Flags().GetString("context")
+	target, _ := cmd.Flags().GetString("target")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	severity, _ := cmd.Flags().GetString("severity")
+	include, _ := cmd.Flags().GetStringSlice("include-namespaces")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude-namespaces")
+	kinds, _ := cmd.Flags().GetStringSlice("include-kinds")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	return scanner.ScanKubernetes(ctx, clusterContext, severity, "json", "", false,
+		modules.WithTrivyIncludeNamespaces(include),
+		modules.WithTrivyExcludeNamespaces(exclude),
+		modules.WithTrivyIncludeKinds(kinds),
+		modules.WithTrivyNamespace(namespace),
+		modules.WithTrivyTarget(target),
+		modules.WithTrivyKubernetesKubeconfig(kubeconfig),
+	)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrivyK8sScanner records the arguments of a Kubernetes scan
+type fakeTrivyK8sScanner struct {
+	clusterContext string
+	severity       string
+	config         modules.TrivyKubernetesConfig
+}
+
+func (f *fakeTrivyK8sScanner) ScanKubernetes(ctx context.Context, clusterContext string, severity string, outputFormat string, scanners string, includeImages bool, opts ...modules.TrivyKubernetesOption) (string, error) {
+	f.clusterContext, f.severity = clusterContext, severity
+	for _, opt := range opts {
+		opt(&f.config)
+	}
+	return "k8s report", nil
+}
+
+func TestTrivyK8sCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "trivy-k8s"})
+	require.NoError(t, err)
+	assert.Equal(t, trivyK8sCmd, cmd)
+}
+
+func TestScanTrivyK8s_Defaults(t *testing.T) {
+	scanner := &fakeTrivyK8sScanner{}
+	_, err := scanTrivyK8s(context.Background(), scanner, trivyK8sCmd)
+	require.NoError(t, err)
+
+	assert.Empty(t, scanner.clusterContext)
+	assert.Empty(t, scanner.config.IncludeNamespaces)
+	assert.Empty(t, scanner.config.ExcludeNamespaces)
+	assert.Empty(t, scanner.config.IncludeKinds)
+}
+
+func TestTrivyK8sCmd_RequiresKubeconfig(t *testing.T) {
+	flag := trivyK8sCmd.Flags().Lookup("kubeconfig")
+	require.NotNil(t, flag)
+	assert.Equal(t, []string{"true"}, flag.Annotations[cobra.BashCompOneRequiredFlag])
+}
+
+func TestScanTrivyK8s_NamespaceFilters(t *testing.T) {
+	setFlagsForTest(t, trivyK8sCmd, map[string]string{
+		"include-namespaces": "payments,orders",
+		"exclude-namespaces": "kube-system",
+		"include-kinds":      "deployment",
+		"severity":           "CRITICAL",
+		"kubeconfig":         "/home/user/.kube/config",
+	})
+
+	scanner := &fakeTrivyK8sScanner{}
+	_, err := scanTrivyK8s(context.Background(), scanner, trivyK8sCmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"payments", "orders"}, scanner.config.IncludeNamespaces)
+	assert.Equal(t, []string{"kube-system"}, scanner.config.ExcludeNamespaces)
+	assert.Equal(t, []string{"deployment"}, scanner.config.IncludeKinds)
+	assert.Equal(t, "/home/user/.kube/config", scanner.config.Kubeconfig)
+	assert.Equal(t, "CRITICAL", scanner.severity)
+}
+
+func TestScanTrivyK8s_Context(t *testing.T) {
+	setFlagsForTest(t, trivyK8sCmd, map[string]string{"context": "staging"})
+
+	scanner := &fakeTrivyK8sScanner{}
+	_, err := scanTrivyK8s(context.Background(), scanner, trivyK8sCmd)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", scanner.clusterContext)
+}
+
+func TestScanTrivyK8s_TargetAndNamespace(t *testing.T) {
+	setFlagsForTest(t, trivyK8sCmd, map[string]string{
+		"target":    "deployment",
+		"namespace": "payments",
+	})
+
+	scanner := &fakeTrivyK8sScanner{}
+	_, err := scanTrivyK8s(context.Background(), scanner, trivyK8sCmd)
+	require.NoError(t, err)
+	assert.Equal(t, "deployment", scanner.config.Target)
+	assert.Equal(t, "payments", scanner.config.Namespace)
+}
+
+func TestTrivyK8sCmd_NamespaceExclusiveWithFilters(t *testing.T) {
+	for _, group := range [][]string{
+		{"namespace", "include-namespaces"},
+		{"namespace", "exclude-namespaces"},
+		{"target", "include-kinds"},
+	} {
+		for _, name := range group {
+			flag := trivyK8sCmd.Flags().Lookup(name)
+			require.NotNil(t, flag, name)
+			assert.Contains(t, flag.Annotations["cobra_annotation_mutually_exclusive"], strings.Join(group, " "), name)
+		}
+	}
+}
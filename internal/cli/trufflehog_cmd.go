@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudshipai/ship/internal/dagger"
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/cloudshipai/ship/internal/report/junit"
+	"github.com/cloudshipai/ship/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// trufflehogScanTypes are the sources accepted by --type
+var trufflehogScanTypes = []string{"filesystem", "git", "github", "gitlab", "docker", "s3"}
+
+// trufflehogOutputFormats are the values accepted by --format
+var trufflehogOutputFormats = []string{"json", "jsonl", formatJUnit}
+
+var trufflehogCmd = &cobra.Command{
+	Use:   "trufflehog [target]",
+	Short: "Detect verified secrets with TruffleHog",
+	Long: `Scan a directory, repository, image or bucket for secrets using TruffleHog.
+
+The target is a directory for --type filesystem (defaulting to the current
+directory), a repository URL for git, github and gitlab, an image for docker
+and a bucket name for s3. GitLab scans need a token from --token or GITLAB_TOKEN.
+
+--format selects json (an array of results), jsonl (one result per line) or
+junit output for filesystem, git and docker scans; without it TruffleHog's
+default output is returned.
+
+Examples:
+  # Scan the current directory
+  ship security trufflehog
+
+  # Scan a GitHub repository
+  ship security trufflehog https://github.com/acme/api --type github
+
+  # Scan a GitLab repository
+  GITLAB_TOKEN=glpat-... ship security trufflehog https://gitlab.com/acme/api --type gitlab
+
+  # Produce JUnit XML for a CI test dashboard
+  ship security trufflehog --format junit`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTool("trufflehog", runTruffleHog),
+}
+
+func init() {
+	securityToolsCmd.AddCommand(trufflehogCmd)
+
+	trufflehogCmd.Flags().String("type", "filesystem", "Source to scan ("+strings.Join(trufflehogScanTypes, ", ")+")")
+	trufflehogCmd.Flags().String("format", "", "Output format for filesystem, git and docker scans ("+strings.Join(trufflehogOutputFormats, ", ")+")")
+	trufflehogCmd.Flags().String("token", "", "Access token for github or gitlab sources (defaults to GITHUB_TOKEN or GITLAB_TOKEN)")
+
+	registerEnumFlag(trufflehogCmd, "type", trufflehogScanTypes...)
+	registerEnumFlag(trufflehogCmd, "format", trufflehogOutputFormats...)
+}
+
+// trufflehogScanner is the subset of the TruffleHog module the command dispatches to
+type trufflehogScanner interface {
+	ScanDirectory(ctx context.Context, dir string) (string, error)
+	ScanGitRepo(ctx context.Context, repoURL string) (string, error)
+	ScanGitHub(ctx context.Context, repo string, token string) (string, error)
+	ScanGitLab(ctx context.Context, repo string, token string) (string, error)
+	ScanDockerImage(ctx context.Context, imageName string) (string, error)
+	ScanS3(ctx context.Context, bucket string) (string, error)
+	ScanFilesystemAdvanced(ctx context.Context, path string, onlyVerified bool, excludePaths []string, outputFormat string) (string, error)
+	ScanGitAdvanced(ctx context.Context, repoURL string, branch string, sinceCommit string, onlyVerified bool, outputFormat string, excludePaths []string) (string, error)
+	ScanDockerAdvanced(ctx context.Context, image string, onlyVerified bool, outputFormat string) (string, error)
+}
+
+func runTruffleHog(cmd *cobra.Command, args []string) (string, error) {
+	start := time.Now()
+	scanType, _ := cmd.Flags().GetString("type")
+	token, _ := cmd.Flags().GetString("token")
+	format, _ := cmd.Flags().GetString("format")
+
+	telemetry.TrackCLICommand("security", "trufflehog", args)
+
+	target, token, err := trufflehogScanInput(scanType, args, token)
+	if err != nil {
+		return "", err
+	}
+	if err := validateTruffleHogFormat(scanType, format); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	engine, err := dagger.NewEngine(ctx)
+	if err != nil {
+		telemetry.TrackError("dagger", "trufflehog", err.Error())
+		return "", fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+
+	result, err := dispatchTruffleHogScan(ctx, modules.NewTruffleHogModule(engine.GetClient()), scanType, target, token, trufflehogReportFormat(format))
+	if err != nil {
+		telemetry.TrackError("trufflehog", scanType, err.Error())
+		return "", fmt.Errorf("trufflehog scan failed: %w", err)
+	}
+
+	if format == formatJUnit {
+		if result, err = trufflehogToJUnit(result); err != nil {
+			return "", err
+		}
+	}
+
+	telemetry.TrackDaggerOperation("trufflehog_"+scanType, "trufflehog", true, time.Since(start))
+	return result, nil
+}
+
+// trufflehogReportFormat returns the format to request from TruffleHog. JUnit
+// is produced from its JSON lines, as TruffleHog has no SARIF output.
+func trufflehogReportFormat(format string) string {
+	if format == formatJUnit {
+		return "jsonl"
+	}
+	return format
+}
+
+// trufflehogToJUnit converts TruffleHog's JSON lines into a JUnit XML report
+// with one failing test case per secret
+func trufflehogToJUnit(output string) (string, error) {
+	findings, err := report.FromTruffleHog([]byte(output))
+	if err != nil {
+		return "", fmt.Errorf("failed to convert trufflehog output to JUnit: %w", err)
+	}
+
+	data, err := junit.Marshal("trufflehog", findings)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// trufflehogScanInput validates the scan type and resolves its target and token
+func trufflehogScanInput(scanType string, args []string, token string) (string, string, error) {
+	switch scanType {
+	case "filesystem":
+		return scanTargetDir(args), "", nil
+	case "git", "github", "gitlab", "docker", "s3":
+	default:
+		return "", "", fmt.Errorf("unsupported --type %q: expected one of %s", scanType, strings.Join(trufflehogScanTypes, ", "))
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		return "", "", fmt.Errorf("--type %s requires a target", scanType)
+	}
+
+	if scanType == "gitlab" {
+		if token == "" {
+			token = os.Getenv("GITLAB_TOKEN")
+		}
+		if token == "" {
+			return "", "", fmt.Errorf("--type gitlab requires a token: pass --token or set GITLAB_TOKEN")
+		}
+	}
+
+	return args[0], token, nil
+}
+
+// validateTruffleHogFormat checks --format is known and supported by the scan type
+func validateTruffleHogFormat(scanType, format string) error {
+	if format == "" {
+		return nil
+	}
+	if !contains(trufflehogOutputFormats, format) {
+		return fmt.Errorf("unsupported --format %q: expected one of %s", format, strings.Join(trufflehogOutputFormats, ", "))
+	}
+	switch scanType {
+	case "filesystem", "git", "docker":
+		return nil
+	default:
+		return fmt.Errorf("--format is not supported for --type %s; use filesystem, git or docker", scanType)
+	}
+}
+
+// dispatchTruffleHogScan runs the module scan matching scanType. A format
+// routes filesystem, git and docker scans through the advanced methods that
+// accept an output format.
+func dispatchTruffleHogScan(ctx context.Context, scanner trufflehogScanner, scanType, target, token, format string) (string, error) {
+	if format != "" {
+		switch scanType {
+		case "filesystem":
+			return scanner.ScanFilesystemAdvanced(ctx, target, false, nil, format)
+		case "git":
+			return scanner.ScanGitAdvanced(ctx, target, "", "", false, format, nil)
+		case "docker":
+			return scanner.ScanDockerAdvanced(ctx, target, false, format)
+		}
+	}
+
+	switch scanType {
+	case "filesystem":
+		return scanner.ScanDirectory(ctx, target)
+	case "git":
+		return scanner.ScanGitRepo(ctx, target)
+	case "github":
+		return scanner.ScanGitHub(ctx, target, token)
+	case "gitlab":
+		return scanner.ScanGitLab(ctx, target, token)
+	case "docker":
+		return scanner.ScanDockerImage(ctx, target)
+	case "s3":
+		return scanner.ScanS3(ctx, target)
+	default:
+		return "", fmt.Errorf("unsupported --type %q", scanType)
+	}
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTruffleHogScanner records which scan method was called
+type fakeTruffleHogScanner struct {
+	method string
+	target string
+	token  string
+	format string
+}
+
+func (f *fakeTruffleHogScanner) record(method, target, token string) (string, error) {
+	f.method, f.target, f.token = method, target, token
+	return method + " result", nil
+}
+
+func (f *fakeTruffleHogScanner) ScanDirectory(ctx context.Context, dir string) (string, error) {
+	return f.record("ScanDirectory", dir, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanGitRepo(ctx context.Context, repoURL string) (string, error) {
+	return f.record("ScanGitRepo", repoURL, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanGitHub(ctx context.Context, repo string, token string) (string, error) {
+	return f.record("ScanGitHub", repo, token)
+}
+
+func (f *fakeTruffleHogScanner) ScanGitLab(ctx context.Context, repo string, token string) (string, error) {
+	return f.record("ScanGitLab", repo, token)
+}
+
+func (f *fakeTruffleHogScanner) ScanDockerImage(ctx context.Context, imageName string) (string, error) {
+	return f.record("ScanDockerImage", imageName, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanS3(ctx context.Context, bucket string) (string, error) {
+	return f.record("ScanS3", bucket, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanFilesystemAdvanced(ctx context.Context, path string, onlyVerified bool, excludePaths []string, outputFormat string) (string, error) {
+	f.format = outputFormat
+	return f.record("ScanFilesystemAdvanced", path, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanGitAdvanced(ctx context.Context, repoURL string, branch string, sinceCommit string, onlyVerified bool, outputFormat string, excludePaths []string) (string, error) {
+	f.format = outputFormat
+	return f.record("ScanGitAdvanced", repoURL, "")
+}
+
+func (f *fakeTruffleHogScanner) ScanDockerAdvanced(ctx context.Context, image string, onlyVerified bool, outputFormat string) (string, error) {
+	f.format = outputFormat
+	return f.record("ScanDockerAdvanced", image, "")
+}
+
+func TestTruffleHogCmdRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"security", "trufflehog"})
+	require.NoError(t, err)
+	assert.Equal(t, trufflehogCmd, cmd)
+}
+
+func TestDispatchTruffleHogScan(t *testing.T) {
+	tests := map[string]string{
+		"filesystem": "ScanDirectory",
+		"git":        "ScanGitRepo",
+		"github":     "ScanGitHub",
+		"gitlab":     "ScanGitLab",
+		"docker":     "ScanDockerImage",
+		"s3":         "ScanS3",
+	}
+
+	for scanType, method := range tests {
+		t.Run(scanType, func(t *testing.T) {
+			scanner := &fakeTruffleHogScanner{}
+			result, err := dispatchTruffleHogScan(context.Background(), scanner, scanType, "target", "tok", "")
+			require.NoError(t, err)
+			assert.Equal(t, method, scanner.method)
+			assert.Equal(t, "target", scanner.target)
+			assert.Equal(t, method+" result", result)
+		})
+	}
+
+	t.Run("gitlab passes token", func(t *testing.T) {
+		scanner := &fakeTruffleHogScanner{}
+		_, err := dispatchTruffleHogScan(context.Background(), scanner, "gitlab", "https://gitlab.com/acme/api", "glpat-test", "")
+		require.NoError(t, err)
+		assert.Equal(t, "glpat-test", scanner.token)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := dispatchTruffleHogScan(context.Background(), &fakeTruffleHogScanner{}, "svn", "target", "", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestTruffleHogScanInput(t *testing.T) {
+	t.Run("filesystem defaults to current directory", func(t *testing.T) {
+		target, _, err := trufflehogScanInput("filesystem", nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, ".", target)
+	})
+
+	t.Run("remote types require a target", func(t *testing.T) {
+		_, _, err := trufflehogScanInput("git", nil, "")
+		assert.ErrorContains(t, err, "requires a target")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, _, err := trufflehogScanInput("svn", []string{"repo"}, "")
+		assert.ErrorContains(t, err, "unsupported --type")
+	})
+}
+
+func TestTruffleHogScanInput_GitLabToken(t *testing.T) {
+	repo := []string{"https://gitlab.com/acme/api"}
+
+	t.Run("missing token", func(t *testing.T) {
+		t.Setenv("GITLAB_TOKEN", "")
+		_, _, err := trufflehogScanInput("gitlab", repo, "")
+		assert.ErrorContains(t, err, "requires a token")
+	})
+
+	t.Run("token flag", func(t *testing.T) {
+		t.Setenv("GITLAB_TOKEN", "")
+		_, token, err := trufflehogScanInput("gitlab", repo, "glpat-flag")
+		require.NoError(t, err)
+		assert.Equal(t, "glpat-flag", token)
+	})
+
+	t.Run("token from environment", func(t *testing.T) {
+		t.Setenv("GITLAB_TOKEN", "glpat-env")
+		target, token, err := trufflehogScanInput("gitlab", repo, "")
+		require.NoError(t, err)
+		assert.Equal(t, repo[0], target)
+		assert.Equal(t, "glpat-env", token)
+	})
+
+	t.Run("token flag wins over environment", func(t *testing.T) {
+		t.Setenv("GITLAB_TOKEN", "glpat-env")
+		_, token, err := trufflehogScanInput("gitlab", repo, "glpat-flag")
+		require.NoError(t, err)
+		assert.Equal(t, "glpat-flag", token)
+	})
+}
+
+func TestDispatchTruffleHogScan_Formats(t *testing.T) {
+	methods := map[string]string{
+		"filesystem": "ScanFilesystemAdvanced",
+		"git":        "ScanGitAdvanced",
+		"docker":     "ScanDockerAdvanced",
+	}
+
+	for scanType, method := range methods {
+		for _, format := range []string{"json", "jsonl"} {
+			t.Run(scanType+"/"+format, func(t *testing.T) {
+				scanner := &fakeTruffleHogScanner{}
+				_, err := dispatchTruffleHogScan(context.Background(), scanner, scanType, "target", "", format)
+				require.NoError(t, err)
+				assert.Equal(t, method, scanner.method)
+				assert.Equal(t, "target", scanner.target)
+				assert.Equal(t, format, scanner.format)
+			})
+		}
+	}
+}
+
+func TestValidateTruffleHogFormat(t *testing.T) {
+	assert.NoError(t, validateTruffleHogFormat("github", ""))
+	for _, format := range []string{"json", "jsonl", formatJUnit} {
+		assert.NoError(t, validateTruffleHogFormat("filesystem", format), format)
+	}
+
+	assert.ErrorContains(t, validateTruffleHogFormat("filesystem", "xml"), "unsupported --format")
+	assert.ErrorContains(t, validateTruffleHogFormat("filesystem", "sarif"), "unsupported --format")
+	assert.ErrorContains(t, validateTruffleHogFormat("gitlab", "json"), "not supported for --type gitlab")
+}
+
+func TestTruffleHogJUnitIsBuiltFromJSONLines(t *testing.T) {
+	assert.Equal(t, "jsonl", trufflehogReportFormat(formatJUnit))
+	assert.Equal(t, "json", trufflehogReportFormat("json"))
+
+	output := `{"SourceMetadata":{"Data":{"Filesystem":{"file":"app.env","line":2}}},"DetectorName":"AWS","Verified":true}` + "\n"
+	xml, err := trufflehogToJUnit(output)
+	require.NoError(t, err)
+	assert.Contains(t, xml, "AWS")
+	assert.Contains(t, xml, "app.env")
+}
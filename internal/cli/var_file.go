@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// loadVarFile reads external MCP server variables from a dotenv file or, for
+// .yaml and .yml files, from a YAML mapping of variable names to values
+func loadVarFile(path string) (map[string]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" {
+		vars, err := loadEnvFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load var file: %w", err)
+		}
+		return vars, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open var file: %w", err)
+	}
+	vars, err := parseVarFileYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// parseVarFileYAML parses a YAML mapping such as
+//
+//	AWS_PROFILE: prod
+//	AWS_REGION: us-east-1
+//	FASTMCP_LOG_LEVEL: ERROR
+//
+// Values must be scalars; numbers and booleans are kept as written.
+func parseVarFileYAML(data []byte) (map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse var file: %w", err)
+	}
+
+	vars := make(map[string]string)
+	if len(doc.Content) == 0 {
+		return vars, nil
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("var file must be a mapping of variable names to values")
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if !envKeyPattern.MatchString(key.Value) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", key.Line, key.Value)
+		}
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: value of %s must be a string, number or boolean", value.Line, key.Value)
+		}
+		if value.Tag == "!!null" {
+			vars[key.Value] = ""
+			continue
+		}
+		vars[key.Value] = value.Value
+	}
+	return vars, nil
+}
+
+// mcpServerVars returns the variables for an external MCP server: --env-file,
+// then --var-file, then --var, each taking precedence over the one before
+func mcpServerVars(cmd *cobra.Command) (map[string]string, error) {
+	vars, err := containerEnvVars(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	varFile, _ := cmd.Flags().GetString("var-file")
+	if varFile == "" {
+		return vars, nil
+	}
+	fileVars, err := loadVarFile(varFile)
+	if err != nil {
+		return nil, err
+	}
+
+	flagVars, _ := cmd.Flags().GetStringToString("var")
+	for key, value := range fileVars {
+		if _, ok := flagVars[key]; !ok {
+			vars[key] = value
+		}
+	}
+	return vars, nil
+}
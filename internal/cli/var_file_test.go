@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/logger"
+	"github.com/cloudshipai/ship/pkg/ship"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeVarFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func newVarFileTestCmd(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("env-file", "", "")
+	cmd.Flags().String("var-file", "", "")
+	cmd.Flags().StringToString("var", nil, "")
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return cmd
+}
+
+func TestLoadVarFile(t *testing.T) {
+	want := map[string]string{
+		"AWS_PROFILE":       "prod",
+		"AWS_REGION":        "us-east-1",
+		"FASTMCP_LOG_LEVEL": "ERROR",
+		"MAX_RESULTS":       "25",
+		"READ_ONLY":         "true",
+	}
+
+	dotenv := writeVarFile(t, "aws.env", "AWS_PROFILE=prod\nAWS_REGION=us-east-1\nFASTMCP_LOG_LEVEL=ERROR\nMAX_RESULTS=25\nREAD_ONLY=true\n")
+	vars, err := loadVarFile(dotenv)
+	require.NoError(t, err)
+	assert.Equal(t, want, vars)
+
+	yamlFile := writeVarFile(t, "aws.yaml", "AWS_PROFILE: prod\nAWS_REGION: \"us-east-1\"\nFASTMCP_LOG_LEVEL: ERROR\nMAX_RESULTS: 25\nREAD_ONLY: true\n")
+	vars, err = loadVarFile(yamlFile)
+	require.NoError(t, err)
+	assert.Equal(t, want, vars)
+}
+
+func TestLoadVarFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"yaml list", "vars.yml", "- AWS_PROFILE\n", "must be a mapping of variable names to values"},
+		{"nested value", "vars.yaml", "AWS:\n  PROFILE: prod\n", "line 2: value of AWS must be a string, number or boolean"},
+		{"invalid name", "vars.yaml", "AWS-PROFILE: prod\n", `line 1: invalid variable name "AWS-PROFILE"`},
+		{"malformed dotenv", "vars.env", "AWS_PROFILE\n", "line 1: expected KEY=VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadVarFile(writeVarFile(t, tt.file, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := loadVarFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to open var file")
+}
+
+func TestMCPServerVarsPrecedence(t *testing.T) {
+	envFile := writeVarFile(t, ".env", "AWS_REGION=eu-west-1\nAWS_PROFILE=from-env-file\nDEBUG=1\n")
+	varFile := writeVarFile(t, "aws.yaml", "AWS_REGION: us-east-1\nAWS_PROFILE: from-var-file\n")
+
+	cmd := newVarFileTestCmd(t, map[string]string{
+		"env-file": envFile,
+		"var-file": varFile,
+		"var":      "AWS_PROFILE=from-flag",
+	})
+
+	vars, err := mcpServerVars(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"AWS_REGION":  "us-east-1",
+		"AWS_PROFILE": "from-flag",
+		"DEBUG":       "1",
+	}, vars)
+}
+
+func TestMCPServerVarsFromFileSatisfyRequiredVariables(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.New(&out, slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	varFile := writeVarFile(t, "brave.yaml", "BRAVE_API_KEY: brv-51c8e0f7\n")
+	vars, err := mcpServerVars(newVarFileTestCmd(t, map[string]string{"var-file": varFile}))
+	require.NoError(t, err)
+
+	config := ship.MCPServerConfig{
+		Name:      "brave-search",
+		Variables: []ship.Variable{{Name: "BRAVE_API_KEY", Required: true, Secret: true}},
+	}
+	require.NoError(t, validateAndMergeVariables(&config, vars))
+	assert.Equal(t, "brv-51c8e0f7", config.Env["BRAVE_API_KEY"])
+
+	redactSecretVariables(config)
+	slog.Info("starting external MCP server", "server", config.Name, "env", config.Env)
+	assert.NotContains(t, out.String(), "brv-51c8e0f7", "secrets from the var file are masked")
+	assert.Contains(t, out.String(), "BRAVE_API_KEY:***")
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("working-dir", "", "Directory scanned by default and that relative scan paths are resolved against (default: current directory)")
+}
+
+// configureWorkingDir exports --working-dir as an absolute path. Every module
+// mount, including those made by MCP tool handlers, resolves relative host
+// paths against it instead of the current directory.
+func configureWorkingDir(cmd *cobra.Command) error {
+	dir, _ := cmd.Flags().GetString("working-dir")
+	if dir == "" {
+		return nil
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve --working-dir %s: %w", dir, err)
+	}
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return fmt.Errorf("invalid --working-dir: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("invalid --working-dir: %s is not a directory", dir)
+	}
+
+	os.Setenv(modules.WorkingDirEnv, absDir)
+	return nil
+}
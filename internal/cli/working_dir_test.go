@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorkingDirTestCommand(t *testing.T, dir string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("working-dir", "", "")
+	require.NoError(t, cmd.Flags().Set("working-dir", dir))
+	return cmd
+}
+
+func TestConfigureWorkingDir(t *testing.T) {
+	t.Setenv(modules.WorkingDirEnv, "")
+	dir := t.TempDir()
+
+	require.NoError(t, configureWorkingDir(newWorkingDirTestCommand(t, dir)))
+	assert.Equal(t, dir, os.Getenv(modules.WorkingDirEnv))
+}
+
+func TestConfigureWorkingDirRejectsFile(t *testing.T) {
+	t.Setenv(modules.WorkingDirEnv, "")
+	file := filepath.Join(t.TempDir(), "main.tf")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+	assert.ErrorContains(t, configureWorkingDir(newWorkingDirTestCommand(t, file)), "is not a directory")
+	assert.ErrorContains(t, configureWorkingDir(newWorkingDirTestCommand(t, filepath.Join(file, "missing"))), "invalid --working-dir")
+	assert.Empty(t, os.Getenv(modules.WorkingDirEnv))
+}
+
+func TestScanTargetDirUsesWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "infra"), 0o755))
+	t.Setenv(modules.WorkingDirEnv, dir)
+
+	assert.Equal(t, dir, scanTargetDir(nil))
+	assert.Equal(t, filepath.Join(dir, "infra"), scanTargetDir([]string{"infra"}))
+	assert.Equal(t, "nginx:1.25", scanTargetDir([]string{"nginx:1.25"}), "targets missing from the working dir are kept")
+}
+
+func TestDispatchTrivyScan_MountsWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(modules.WorkingDirEnv, dir)
+
+	scanner := &fakeTrivyScanner{}
+	_, err := dispatchTrivyScan(context.Background(), scanner, trivyCmd, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ScanFilesystem", scanner.method)
+	assert.Equal(t, dir, scanner.target)
+}
@@ -2,9 +2,11 @@ package dagger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"dagger.io/dagger"
 	"github.com/cloudshipai/ship/internal/dagger/modules"
@@ -16,6 +18,41 @@ type Engine struct {
 	ctx    context.Context
 }
 
+// ErrDockerUnavailable is returned when the Dagger engine cannot be started
+// because no container runtime is reachable
+var ErrDockerUnavailable = errors.New("Docker does not appear to be running; Ship needs Docker or a Dagger engine to run tools " +
+	"(start Docker, or set _EXPERIMENTAL_DAGGER_RUNNER_HOST to an existing engine, then run 'ship doctor' to check your setup)")
+
+// dockerUnavailableMarkers are error fragments that indicate the container
+// runtime, rather than Dagger itself, is the problem
+var dockerUnavailableMarkers = []string{
+	"cannot connect to the docker daemon",
+	"is the docker daemon running",
+	"docker.sock",
+	"docker_engine",
+	"executable file not found",
+	"error during connect",
+}
+
+// IsDockerUnavailable reports whether err was caused by a missing or stopped
+// container runtime
+func IsDockerUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrDockerUnavailable) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, marker := range dockerUnavailableMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewEngine creates a new Dagger engine instance
 func NewEngine(ctx context.Context, logLevel ...string) (*Engine, error) {
 	var logOutput io.Writer = io.Discard
@@ -26,6 +63,9 @@ func NewEngine(ctx context.Context, logLevel ...string) (*Engine, error) {
 	// Initialize Dagger client
 	client, err := dagger.Connect(ctx, dagger.WithLogOutput(logOutput))
 	if err != nil {
+		if IsDockerUnavailable(err) {
+			return nil, fmt.Errorf("%w\n\nDetails: %v", ErrDockerUnavailable, err)
+		}
 		return nil, fmt.Errorf("failed to connect to dagger: %w", err)
 	}
 
@@ -65,7 +105,7 @@ func (e *Engine) RunContainer(image string, args []string) (string, error) {
 // BuildContainer builds a container from a directory with a Dockerfile
 func (e *Engine) BuildContainer(contextDir string, dockerfile string) (*dagger.Container, error) {
 	// Get the build context directory
-	src := e.client.Host().Directory(contextDir)
+	src := e.client.Host().Directory(modules.HostPath(contextDir))
 
 	// Build the container
 	container := e.client.Container().Build(src, dagger.ContainerBuildOpts{
@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"dagger.io/dagger"
@@ -26,9 +28,8 @@ func NewActionlintModule(client *dagger.Client) *ActionlintModule {
 
 // ScanDirectory scans a directory for GitHub Actions workflow issues
 func (m *ActionlintModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			actionlintBinary,
@@ -57,9 +58,8 @@ func (m *ActionlintModule) ScanDirectory(ctx context.Context, dir string) (strin
 
 // ScanFile scans a specific workflow file
 func (m *ActionlintModule) ScanFile(ctx context.Context, filePath string) (string, error) {
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
-		WithFile("/workspace/workflow.yml", m.client.Host().File(filePath)).
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithFile("/workspace/workflow.yml", hostFile(m.client, filePath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			actionlintBinary,
@@ -110,9 +110,8 @@ func (m *ActionlintModule) ScanDirectoryWithOptions(ctx context.Context, dir str
 		args = append(args, "-color")
 	}
 
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -148,9 +147,8 @@ func (m *ActionlintModule) ScanWithExternalTools(ctx context.Context, dir, shell
 		args = append(args, "-color")
 	}
 
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -205,9 +203,8 @@ func (m *ActionlintModule) ScanSpecificFiles(ctx context.Context, dir string, wo
 		}
 	}
 
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -231,8 +228,7 @@ func (m *ActionlintModule) ScanSpecificFiles(ctx context.Context, dir string, wo
 
 // GetVersion returns the version of actionlint
 func (m *ActionlintModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("wolff2023/actionlint:latest").
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
 		WithExec([]string{actionlintBinary, "-version"})
 
 	output, err := container.Stdout(ctx)
@@ -242,3 +238,164 @@ func (m *ActionlintModule) GetVersion(ctx context.Context) (string, error) {
 
 	return output, nil
 }
+
+// Scan scans the workflows at path with the given scan options. path may be a
+// directory or a single workflow file.
+func (m *ActionlintModule) Scan(ctx context.Context, path string, opts ...ActionlintOption) (string, error) {
+	config := newActionlintConfig(opts)
+
+	dir, file, err := resolveActionlintTarget(path)
+	if err != nil {
+		return "", err
+	}
+
+	args, err := actionlintScanArgs(config, file)
+	if err != nil {
+		return "", err
+	}
+
+	container := toolContainer(m.client, "wolff2023/actionlint:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace").
+		WithExec(args, dagger.ContainerWithExecOpts{
+			// actionlint returns non-zero exit code when it finds issues
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	stderr, _ := container.Stderr(ctx)
+
+	// If there's stderr output, there might be an error
+	if stderr != "" {
+		return "", fmt.Errorf("actionlint stderr: %s", stderr)
+	}
+
+	// If there's no output, it means no issues were found (success case)
+	if output == "" {
+		return "No workflow issues found", nil
+	}
+
+	return output, nil
+}
+
+// resolveActionlintTarget splits path into the directory to mount and, when path
+// is a single workflow file, the file name to lint inside it
+func resolveActionlintTarget(path string) (string, string, error) {
+	info, err := os.Stat(HostPath(path))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to access %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return path, "", nil
+	}
+	return filepath.Dir(path), filepath.Base(path), nil
+}
+
+func newActionlintConfig(opts []ActionlintOption) *ActionlintConfig {
+	config := &ActionlintConfig{
+		Format:     "json",
+		Shellcheck: true,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// actionlintScanArgs builds the actionlint command line. An empty executable
+// path disables the shellcheck or pyflakes integration. When file is set only
+// that workflow is linted.
+func actionlintScanArgs(config *ActionlintConfig, file string) ([]string, error) {
+	formatArgs, err := actionlintFormatArgs(config.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{actionlintBinary}, formatArgs...)
+
+	if config.Shellcheck {
+		args = append(args, "-shellcheck", "shellcheck")
+	} else {
+		args = append(args, "-shellcheck=")
+	}
+
+	if config.Pyflakes {
+		args = append(args, "-pyflakes", "pyflakes")
+	} else {
+		args = append(args, "-pyflakes=")
+	}
+
+	if file != "" {
+		args = append(args, file)
+	}
+
+	return args, nil
+}
+
+// actionlintFormatArgs maps an output format onto actionlint's -format template
+func actionlintFormatArgs(format string) ([]string, error) {
+	switch format {
+	case "default":
+		return nil, nil
+	case "", "json":
+		return []string{"-format", "{{json .}}"}, nil
+	case "sarif":
+		return []string{"-format", actionlintSARIFTemplate}, nil
+	default:
+		return nil, fmt.Errorf("unsupported actionlint format %q: must be default, json or sarif", format)
+	}
+}
+
+// actionlintSARIFTemplate renders actionlint errors as a SARIF 2.1.0 log
+const actionlintSARIFTemplate = `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "actionlint", "informationUri": "https://github.com/rhysd/actionlint"}},
+    "results": [
+      {{- range $i, $e := . }}{{ if $i }},{{ end }}
+      {
+        "ruleId": {{ json $e.Kind }},
+        "level": "error",
+        "message": {"text": {{ json $e.Message }}},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": {{ json $e.Filepath }}},
+          "region": {"startLine": {{ $e.Line }}, "startColumn": {{ $e.Column }}}
+        }}]
+      }
+      {{- end }}
+    ]
+  }]
+}`
+
+// ActionlintConfig holds the settings for an actionlint run
+type ActionlintConfig struct {
+	Format     string
+	Shellcheck bool
+	Pyflakes   bool
+}
+
+// ActionlintOption sets a field of ActionlintConfig
+type ActionlintOption func(*ActionlintConfig)
+
+// WithActionlintFormat sets the output format: json (the default), sarif, or
+// default for actionlint's own text output
+func WithActionlintFormat(format string) ActionlintOption {
+	return func(c *ActionlintConfig) {
+		c.Format = format
+	}
+}
+
+// WithActionlintShellcheck toggles shellcheck for run: steps (enabled by default)
+func WithActionlintShellcheck(enabled bool) ActionlintOption {
+	return func(c *ActionlintConfig) {
+		c.Shellcheck = enabled
+	}
+}
+
+// WithActionlintPyflakes toggles pyflakes for Python run: steps (disabled by default)
+func WithActionlintPyflakes(enabled bool) ActionlintOption {
+	return func(c *ActionlintConfig) {
+		c.Pyflakes = enabled
+	}
+}
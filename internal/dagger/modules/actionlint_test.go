@@ -0,0 +1,93 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestActionlintScanArgs_Defaults(t *testing.T) {
+	args, err := actionlintScanArgs(newActionlintConfig(nil), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{actionlintBinary, "-format", "{{json .}}", "-shellcheck", "shellcheck", "-pyflakes="}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestActionlintScanArgs_DisableShellcheck(t *testing.T) {
+	args, err := actionlintScanArgs(newActionlintConfig([]ActionlintOption{
+		WithActionlintShellcheck(false),
+	}), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{actionlintBinary, "-format", "{{json .}}", "-shellcheck=", "-pyflakes="}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestActionlintScanArgs_EnablePyflakes(t *testing.T) {
+	args, err := actionlintScanArgs(newActionlintConfig([]ActionlintOption{
+		WithActionlintPyflakes(true),
+	}), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{actionlintBinary, "-format", "{{json .}}", "-shellcheck", "shellcheck", "-pyflakes", "pyflakes"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestActionlintScanArgs_SingleFileSARIF(t *testing.T) {
+	args, err := actionlintScanArgs(newActionlintConfig([]ActionlintOption{
+		WithActionlintFormat("sarif"),
+	}), "ci.yml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{actionlintBinary, "-format", actionlintSARIFTemplate, "-shellcheck", "shellcheck", "-pyflakes=", "ci.yml"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestActionlintFormatArgs(t *testing.T) {
+	if args, err := actionlintFormatArgs("default"); err != nil || args != nil {
+		t.Errorf("Expected no format args for default, got %v (%v)", args, err)
+	}
+	if _, err := actionlintFormatArgs("xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestResolveActionlintTarget(t *testing.T) {
+	dir := t.TempDir()
+	workflow := filepath.Join(dir, "ci.yml")
+	if err := os.WriteFile(workflow, []byte("on: push\n"), 0644); err != nil {
+		t.Fatalf("Failed to write workflow: %v", err)
+	}
+
+	gotDir, gotFile, err := resolveActionlintTarget(dir)
+	if err != nil || gotDir != dir || gotFile != "" {
+		t.Errorf("Expected directory target %q, got %q %q (%v)", dir, gotDir, gotFile, err)
+	}
+
+	gotDir, gotFile, err = resolveActionlintTarget(workflow)
+	if err != nil || gotDir != dir || gotFile != "ci.yml" {
+		t.Errorf("Expected file target in %q, got %q %q (%v)", dir, gotDir, gotFile, err)
+	}
+
+	if _, _, err := resolveActionlintTarget(filepath.Join(dir, "missing.yml")); err == nil {
+		t.Error("Expected error for missing path")
+	}
+}
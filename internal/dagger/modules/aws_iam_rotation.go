@@ -26,8 +26,7 @@ func NewAWSIAMRotationModule(client *dagger.Client) *AWSIAMRotationModule {
 
 // RotateAccessKeys rotates AWS access keys for a user
 func (m *AWSIAMRotationModule) RotateAccessKeys(ctx context.Context, username string, profile string) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -53,8 +52,7 @@ func (m *AWSIAMRotationModule) RotateAccessKeys(ctx context.Context, username st
 
 // ListAccessKeys lists access keys for a user
 func (m *AWSIAMRotationModule) ListAccessKeys(ctx context.Context, username string, profile string) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -80,8 +78,7 @@ func (m *AWSIAMRotationModule) ListAccessKeys(ctx context.Context, username stri
 
 // DeleteAccessKey deletes an access key
 func (m *AWSIAMRotationModule) DeleteAccessKey(ctx context.Context, username string, accessKeyId string, profile string) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -108,8 +105,7 @@ func (m *AWSIAMRotationModule) DeleteAccessKey(ctx context.Context, username str
 
 // UpdateAccessKey updates access key status
 func (m *AWSIAMRotationModule) UpdateAccessKey(ctx context.Context, username string, accessKeyId string, status string, profile string) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -137,8 +133,7 @@ func (m *AWSIAMRotationModule) UpdateAccessKey(ctx context.Context, username str
 
 // GetAccessKeyLastUsed gets access key last used info
 func (m *AWSIAMRotationModule) GetAccessKeyLastUsed(ctx context.Context, accessKeyId string, profile string) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -164,8 +159,7 @@ func (m *AWSIAMRotationModule) GetAccessKeyLastUsed(ctx context.Context, accessK
 
 // GetVersion returns the AWS CLI version
 func (m *AWSIAMRotationModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{"aws", "--version"})
 
 	output, err := container.Stdout(ctx)
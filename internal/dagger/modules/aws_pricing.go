@@ -32,8 +32,7 @@ func (m *AWSPricingModule) GetServicePricing(ctx context.Context, service, regio
 		region = "us-east-1"
 	}
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{awsPricingBinary, "pricing", "describe-services", 
 			"--service-code", service,
 			"--region", "us-east-1", // Pricing API is only available in us-east-1
@@ -58,8 +57,7 @@ func (m *AWSPricingModule) GetEC2Pricing(ctx context.Context, instanceType, regi
 	}
 
 	// Use AWS CLI to get EC2 pricing
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{awsPricingBinary, "pricing", "get-products",
 			"--service-code", "AmazonEC2",
 			"--region", "us-east-1", // Pricing API is only available in us-east-1
@@ -93,8 +91,7 @@ func (m *AWSPricingModule) GetRDSPricing(ctx context.Context, instanceClass, eng
 		region = "us-east-1"
 	}
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{awsPricingBinary, "pricing", "get-products",
 			"--service-code", "AmazonRDS",
 			"--region", "us-east-1", // Pricing API is only available in us-east-1
@@ -117,8 +114,7 @@ func (m *AWSPricingModule) GetRDSPricing(ctx context.Context, instanceClass, eng
 
 // ListServices lists available AWS services for pricing
 func (m *AWSPricingModule) ListServices(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{awsPricingBinary, "pricing", "describe-services",
 			"--region", "us-east-1", // Pricing API is only available in us-east-1
 			"--format-version", "aws_v1",
@@ -195,8 +191,7 @@ case "%s" in
 esac
 `, resourceType, size, region, resourceType, size, region, size, getLocationFromRegion(region), size, region, region, resourceType)
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}).
 		WithNewFile("/tmp/calculate.sh", script, dagger.ContainerWithNewFileOpts{
 			Permissions: 0755,
@@ -222,8 +217,7 @@ func (m *AWSPricingModule) DescribeServices(ctx context.Context, serviceCode str
 		args = append(args, "--max-items", maxItems)
 	}
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -248,8 +242,7 @@ func (m *AWSPricingModule) GetProducts(ctx context.Context, serviceCode, filters
 		args = append(args, "--max-items", maxItems)
 	}
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -262,8 +255,7 @@ func (m *AWSPricingModule) GetProducts(ctx context.Context, serviceCode, filters
 
 // GetVersion returns the AWS CLI version
 func (m *AWSPricingModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec([]string{awsPricingBinary, "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -309,8 +301,7 @@ func (m *AWSPricingModule) GetAttributeValues(ctx context.Context, serviceCode,
 		args = append(args, "--max-items", maxItems)
 	}
 
-	container := m.client.Container().
-		From("amazon/aws-cli:latest").
+	container := toolContainer(m.client, "amazon/aws-cli:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
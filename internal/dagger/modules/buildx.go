@@ -32,10 +32,9 @@ func (m *BuildXModule) Build(ctx context.Context, srcDir string, tag string, pla
 	}
 
 	// Create a container with Docker BuildX installed
-	container := m.client.Container().
-		From(getImageTag("buildx", "docker:latest")).
+	container := toolContainer(m.client, getImageTag("buildx", "docker:latest")).
 		WithUnixSocket("/var/run/docker.sock", m.client.Host().UnixSocket("/var/run/docker.sock")).
-		WithDirectory("/build", m.client.Host().Directory(srcDir)).
+		WithDirectory("/build", hostDirectory(m.client, srcDir)).
 		WithWorkdir("/build")
 
 	// Set up buildx
@@ -91,10 +90,9 @@ func (m *BuildXModule) Publish(ctx context.Context, srcDir string, tag string, p
 	}
 
 	// Create a container with Docker BuildX installed
-	container := m.client.Container().
-		From(getImageTag("buildx", "docker:latest")).
+	container := toolContainer(m.client, getImageTag("buildx", "docker:latest")).
 		WithUnixSocket("/var/run/docker.sock", m.client.Host().UnixSocket("/var/run/docker.sock")).
-		WithDirectory("/build", m.client.Host().Directory(srcDir)).
+		WithDirectory("/build", hostDirectory(m.client, srcDir)).
 		WithWorkdir("/build")
 
 	// Login to registry
@@ -148,12 +146,11 @@ func (m *BuildXModule) Publish(ctx context.Context, srcDir string, tag string, p
 
 // Dev returns a development container with Docker BuildX installed
 func (m *BuildXModule) Dev(ctx context.Context, srcDir string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("buildx", "docker:latest")).
+	container := toolContainer(m.client, getImageTag("buildx", "docker:latest")).
 		WithUnixSocket("/var/run/docker.sock", m.client.Host().UnixSocket("/var/run/docker.sock"))
 
 	if srcDir != "" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(srcDir)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, srcDir)).
 			WithWorkdir("/workspace")
 	}
 
@@ -181,8 +178,7 @@ func (m *BuildXModule) Dev(ctx context.Context, srcDir string) (string, error) {
 
 // GetVersion returns the BuildX version information
 func (m *BuildXModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("buildx", "docker:latest")).
+	container := toolContainer(m.client, getImageTag("buildx", "docker:latest")).
 		WithExec([]string{"docker", "buildx", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
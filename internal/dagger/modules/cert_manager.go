@@ -25,11 +25,10 @@ func NewCertManagerModule(client *dagger.Client) *CertManagerModule {
 
 // GetCertificates lists certificates
 func (m *CertManagerModule) GetCertificates(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/jetstack/cert-manager-ctl:latest")
+	container := toolContainer(m.client, "quay.io/jetstack/cert-manager-ctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -49,11 +48,10 @@ func (m *CertManagerModule) GetCertificates(ctx context.Context, namespace strin
 
 // CheckCertificate checks certificate status
 func (m *CertManagerModule) CheckCertificate(ctx context.Context, name string, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/jetstack/cert-manager-ctl:latest")
+	container := toolContainer(m.client, "quay.io/jetstack/cert-manager-ctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -73,11 +71,10 @@ func (m *CertManagerModule) CheckCertificate(ctx context.Context, name string, n
 
 // RenewCertificate renews a certificate
 func (m *CertManagerModule) RenewCertificate(ctx context.Context, name string, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/jetstack/cert-manager-ctl:latest")
+	container := toolContainer(m.client, "quay.io/jetstack/cert-manager-ctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -105,8 +102,7 @@ func (m *CertManagerModule) Install(ctx context.Context, version string, dryRun
 		args = append(args, "--dry-run=client")
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -123,11 +119,10 @@ func (m *CertManagerModule) CheckInstallation(ctx context.Context, namespace str
 		namespace = "cert-manager"
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "get", "pods", "-n", namespace})
@@ -153,14 +148,13 @@ func (m *CertManagerModule) CreateCertificateRequest(ctx context.Context, name s
 		args = append(args, "--timeout", timeout)
 	}
 
-	container := m.client.Container().
-		From("quay.io/jetstack/cert-manager-ctl:latest")
+	container := toolContainer(m.client, "quay.io/jetstack/cert-manager-ctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 	if fromCertificateFile != "" {
-		container = container.WithFile("/tmp/cert.yaml", m.client.Host().File(fromCertificateFile))
+		container = container.WithFile("/tmp/cert.yaml", hostFile(m.client, fromCertificateFile))
 	}
 
 	container = container.WithExec(args)
@@ -182,11 +176,10 @@ func (m *CertManagerModule) ListCertificates(ctx context.Context, namespace stri
 		args = append(args, "-n", namespace)
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args)
@@ -206,11 +199,10 @@ func (m *CertManagerModule) RenewAllCertificates(ctx context.Context, namespace
 		args = append(args, "-n", namespace)
 	}
 
-	container := m.client.Container().
-		From("quay.io/jetstack/cert-manager-ctl:latest")
+	container := toolContainer(m.client, "quay.io/jetstack/cert-manager-ctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args)
@@ -226,8 +218,7 @@ func (m *CertManagerModule) RenewAllCertificates(ctx context.Context, namespace
 // GetVersion returns the version of cert-manager
 func (m *CertManagerModule) GetVersion(ctx context.Context) (string, error) {
 	// Use kubectl image and return cert-manager info since cmctl image doesn't exist
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec([]string{"kubectl", "version", "--client", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
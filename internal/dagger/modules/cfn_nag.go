@@ -23,8 +23,7 @@ func NewCfnNagModule(client *dagger.Client) *CfnNagModule {
 
 // GetVersion returns the version of cfn-nag
 func (m *CfnNagModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
 		WithExec([]string{"cfn_nag", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -44,9 +43,8 @@ func (m *CfnNagModule) GetVersion(ctx context.Context) (string, error) {
 
 // ScanTemplate scans a CloudFormation template
 func (m *CfnNagModule) ScanTemplate(ctx context.Context, templatePath string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, templatePath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"cfn_nag_scan",
@@ -71,9 +69,8 @@ func (m *CfnNagModule) ScanTemplate(ctx context.Context, templatePath string) (s
 
 // ScanDirectory scans all CloudFormation templates in a directory
 func (m *CfnNagModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"cfn_nag_scan",
@@ -98,10 +95,9 @@ func (m *CfnNagModule) ScanDirectory(ctx context.Context, dir string) (string, e
 
 // ScanWithRules scans with custom rules
 func (m *CfnNagModule) ScanWithRules(ctx context.Context, templatePath string, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(templatePath)).
-		WithDirectory("/workspace/rules", m.client.Host().Directory(rulesPath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, templatePath)).
+		WithDirectory("/workspace/rules", hostDirectory(m.client, rulesPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"cfn_nag_scan",
@@ -127,9 +123,8 @@ func (m *CfnNagModule) ScanWithRules(ctx context.Context, templatePath string, r
 
 // ScanWithProfile scans with specific rule profile
 func (m *CfnNagModule) ScanWithProfile(ctx context.Context, templatePath string, profilePath string, denyListPath string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, templatePath)).
 		WithWorkdir("/workspace")
 
 	args := []string{
@@ -139,12 +134,12 @@ func (m *CfnNagModule) ScanWithProfile(ctx context.Context, templatePath string,
 	}
 
 	if profilePath != "" {
-		container = container.WithFile("/workspace/profile.yaml", m.client.Host().File(profilePath))
-		args = append(args, "--rule-profile", "profile.yaml")
+		container = container.WithFile("/workspace/profile.yaml", hostFile(m.client, profilePath))
+		args = append(args, "--profile-path", "profile.yaml")
 	}
 
 	if denyListPath != "" {
-		container = container.WithFile("/workspace/deny.yaml", m.client.Host().File(denyListPath))
+		container = container.WithFile("/workspace/deny.yaml", hostFile(m.client, denyListPath))
 		args = append(args, "--deny-list-path", "deny.yaml")
 	}
 
@@ -167,8 +162,7 @@ func (m *CfnNagModule) ScanWithProfile(ctx context.Context, templatePath string,
 
 // ListRules lists all available cfn-nag rules
 func (m *CfnNagModule) ListRules(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
 		WithExec([]string{
 			"cfn_nag_rules",
 		}, dagger.ContainerWithExecOpts{
@@ -190,9 +184,8 @@ func (m *CfnNagModule) ListRules(ctx context.Context) (string, error) {
 
 // GenerateWhitelist generates a whitelist template
 func (m *CfnNagModule) GenerateWhitelist(ctx context.Context, templatePath string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, templatePath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"cfn_nag_scan",
@@ -218,9 +211,8 @@ func (m *CfnNagModule) GenerateWhitelist(ctx context.Context, templatePath strin
 
 // ScanWithSuppression scans with rule suppression
 func (m *CfnNagModule) ScanWithSuppression(ctx context.Context, templatePath string, suppressRules []string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, templatePath)).
 		WithWorkdir("/workspace")
 
 	args := []string{
@@ -261,15 +253,14 @@ func (m *CfnNagModule) ScanWithSuppression(ctx context.Context, templatePath str
 
 // Scan scans a CloudFormation template with options
 func (m *CfnNagModule) Scan(ctx context.Context, inputPath string, outputFormat string, debug bool) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest")
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest")
 
 	// Determine if input is file or directory
 	args := []string{"cfn_nag_scan", "--input-path"}
 	
 	// Mount the input file or directory
 	if inputPath != "" {
-		container = container.WithFile("/workspace/input", m.client.Host().File(inputPath)).
+		container = container.WithFile("/workspace/input", hostFile(m.client, inputPath)).
 			WithWorkdir("/workspace")
 		args = append(args, "input")
 	} else {
@@ -305,9 +296,8 @@ func (m *CfnNagModule) Scan(ctx context.Context, inputPath string, outputFormat
 
 // ScanWithParameters scans with parameter values
 func (m *CfnNagModule) ScanWithParameters(ctx context.Context, inputPath string, parameterValuesPath string, conditionValuesPath string, ruleArguments string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(inputPath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, inputPath)).
 		WithWorkdir("/workspace")
 
 	args := []string{
@@ -317,12 +307,12 @@ func (m *CfnNagModule) ScanWithParameters(ctx context.Context, inputPath string,
 	}
 
 	if parameterValuesPath != "" {
-		container = container.WithFile("/workspace/params.json", m.client.Host().File(parameterValuesPath))
+		container = container.WithFile("/workspace/params.json", hostFile(m.client, parameterValuesPath))
 		args = append(args, "--parameter-values-path", "params.json")
 	}
 
 	if conditionValuesPath != "" {
-		container = container.WithFile("/workspace/conditions.json", m.client.Host().File(conditionValuesPath))
+		container = container.WithFile("/workspace/conditions.json", hostFile(m.client, conditionValuesPath))
 		args = append(args, "--condition-values-path", "conditions.json")
 	}
 
@@ -349,9 +339,8 @@ func (m *CfnNagModule) ScanWithParameters(ctx context.Context, inputPath string,
 
 // SPCMScan runs Stelligent Policy Complexity Metrics scan
 func (m *CfnNagModule) SPCMScan(ctx context.Context, inputPath string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("stelligent/cfn_nag:latest").
-		WithFile("/workspace/template.yaml", m.client.Host().File(inputPath)).
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithFile("/workspace/template.yaml", hostFile(m.client, inputPath)).
 		WithWorkdir("/workspace")
 
 	// SPCM is typically a metric calculation - using cfn_nag_scan with metrics flag
@@ -384,4 +373,113 @@ func (m *CfnNagModule) SPCMScan(ctx context.Context, inputPath string, outputFor
 	}
 
 	return "", fmt.Errorf("failed to run SPCM scan: no output received")
-}
\ No newline at end of file
+}
+
+const (
+	cfnNagInputMount    = "/workspace/input"
+	cfnNagRulesMount    = "/workspace/rules"
+	cfnNagProfileMount  = "/workspace/profile.txt"
+	cfnNagDenyListMount = "/workspace/deny-list.txt"
+)
+
+// ScanWithOptions scans a template file or directory with custom rules and
+// rule filtering. A profile limits the scan to the listed rule IDs and a deny
+// list excludes rule IDs.
+func (m *CfnNagModule) ScanWithOptions(ctx context.Context, inputPath string, isDir bool, opts ...CfnNagOption) (string, error) {
+	config := &CfnNagConfig{
+		OutputFormat: "json",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	container := toolContainer(m.client, "stelligent/cfn_nag:latest").
+		WithWorkdir("/workspace")
+
+	if isDir {
+		container = container.WithDirectory(cfnNagInputMount, hostDirectory(m.client, inputPath))
+	} else {
+		container = container.WithFile(cfnNagInputMount, hostFile(m.client, inputPath))
+	}
+	if config.RulesDir != "" {
+		container = container.WithDirectory(cfnNagRulesMount, hostDirectory(m.client, config.RulesDir))
+	}
+	if config.ProfilePath != "" {
+		container = container.WithFile(cfnNagProfileMount, hostFile(m.client, config.ProfilePath))
+	}
+	if config.DenyListPath != "" {
+		container = container.WithFile(cfnNagDenyListMount, hostFile(m.client, config.DenyListPath))
+	}
+
+	container = container.WithExec(cfnNagScanArgs(config), dagger.ContainerWithExecOpts{
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run cfn-nag scan: no output received")
+}
+
+// cfnNagScanArgs builds the cfn_nag_scan command line for the mounted input
+func cfnNagScanArgs(config *CfnNagConfig) []string {
+	args := []string{"cfn_nag_scan", "--input-path", cfnNagInputMount, "--output-format", config.OutputFormat}
+
+	if config.RulesDir != "" {
+		args = append(args, "--rule-directory", cfnNagRulesMount)
+	}
+	if config.ProfilePath != "" {
+		args = append(args, "--profile-path", cfnNagProfileMount)
+	}
+	if config.DenyListPath != "" {
+		args = append(args, "--deny-list-path", cfnNagDenyListMount)
+	}
+
+	return args
+}
+
+// CfnNagConfig holds the settings for a cfn_nag run
+type CfnNagConfig struct {
+	OutputFormat string
+	RulesDir     string
+	ProfilePath  string
+	DenyListPath string
+}
+
+// CfnNagOption sets a field of CfnNagConfig
+type CfnNagOption func(*CfnNagConfig)
+
+// WithCfnNagOutputFormat sets the output format (json, txt)
+func WithCfnNagOutputFormat(format string) CfnNagOption {
+	return func(c *CfnNagConfig) {
+		c.OutputFormat = format
+	}
+}
+
+// WithCfnNagRulesDir mounts a directory of custom rules
+func WithCfnNagRulesDir(dir string) CfnNagOption {
+	return func(c *CfnNagConfig) {
+		c.RulesDir = dir
+	}
+}
+
+// WithCfnNagProfile mounts a profile file listing the only rule IDs to apply
+func WithCfnNagProfile(path string) CfnNagOption {
+	return func(c *CfnNagConfig) {
+		c.ProfilePath = path
+	}
+}
+
+// WithCfnNagDenyList mounts a deny list file of rule IDs to skip
+func WithCfnNagDenyList(path string) CfnNagOption {
+	return func(c *CfnNagConfig) {
+		c.DenyListPath = path
+	}
+}
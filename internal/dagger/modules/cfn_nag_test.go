@@ -0,0 +1,34 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCfnNagScanArgs_Defaults(t *testing.T) {
+	args := cfnNagScanArgs(&CfnNagConfig{OutputFormat: "json"})
+
+	expected := []string{"cfn_nag_scan", "--input-path", cfnNagInputMount, "--output-format", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestCfnNagScanArgs_RuleFiltering(t *testing.T) {
+	args := cfnNagScanArgs(&CfnNagConfig{
+		OutputFormat: "txt",
+		RulesDir:     "./rules",
+		ProfilePath:  "./profile.txt",
+		DenyListPath: "./deny.txt",
+	})
+
+	expected := []string{
+		"cfn_nag_scan", "--input-path", cfnNagInputMount, "--output-format", "txt",
+		"--rule-directory", cfnNagRulesMount,
+		"--profile-path", cfnNagProfileMount,
+		"--deny-list-path", cfnNagDenyListMount,
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
@@ -2,8 +2,10 @@ package modules
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -27,9 +29,8 @@ func NewCheckovModule(client *dagger.Client) *CheckovModule {
 
 // ScanDirectory scans a directory for security issues
 func (m *CheckovModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			checkovBinary,
@@ -58,9 +59,8 @@ func (m *CheckovModule) ScanFile(ctx context.Context, filePath string) (string,
 	dir := filepath.Dir(filePath)
 	filename := filepath.Base(filePath)
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			checkovBinary,
@@ -86,9 +86,8 @@ func (m *CheckovModule) ScanFile(ctx context.Context, filePath string) (string,
 
 // ScanWithPolicy scans using custom policies
 func (m *CheckovModule) ScanWithPolicy(ctx context.Context, dir string, policyPath string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir))
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir))
 
 	args := []string{
 		"checkov",
@@ -99,7 +98,7 @@ func (m *CheckovModule) ScanWithPolicy(ctx context.Context, dir string, policyPa
 
 	// If policy path is provided, mount it
 	if policyPath != "" {
-		container = container.WithDirectory("/policies", m.client.Host().Directory(policyPath))
+		container = container.WithDirectory("/policies", hostDirectory(m.client, policyPath))
 		args = append(args, "--external-checks-dir", "/policies")
 	}
 
@@ -126,9 +125,8 @@ func (m *CheckovModule) ScanMultiFramework(ctx context.Context, dir string, fram
 		args = append(args, "--framework", framework)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args)
 
@@ -156,9 +154,8 @@ func (m *CheckovModule) ScanWithSeverity(ctx context.Context, dir string, severi
 		}
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args)
 
@@ -184,9 +181,8 @@ func (m *CheckovModule) ScanWithSkips(ctx context.Context, dir string, skipCheck
 		args = append(args, "--skip-check", check)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args)
 
@@ -208,11 +204,10 @@ func (m *CheckovModule) ScanDockerImage(ctx context.Context, dockerImage string,
 		args = append(args, "--output", output)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest"))
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest"))
 
 	if dockerfilePath != "" {
-		container = container.WithFile("/workspace/Dockerfile", m.client.Host().File(dockerfilePath))
+		container = container.WithFile("/workspace/Dockerfile", hostFile(m.client, dockerfilePath))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
@@ -232,9 +227,8 @@ func (m *CheckovModule) ScanPackages(ctx context.Context, dir string, output str
 		args = append(args, "--output", output)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -263,9 +257,8 @@ func (m *CheckovModule) ScanSecrets(ctx context.Context, dir string, output stri
 		args = append(args, "--output", output)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -279,10 +272,9 @@ func (m *CheckovModule) ScanSecrets(ctx context.Context, dir string, output stri
 
 // ScanWithConfig scans using configuration file
 func (m *CheckovModule) ScanWithConfig(ctx context.Context, dir string, configFile string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
-		WithFile("/workspace/config.yml", m.client.Host().File(configFile)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithFile("/workspace/config.yml", hostFile(m.client, configFile)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"checkov", "--directory", ".", "--config-file", "config.yml"}, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -296,8 +288,7 @@ func (m *CheckovModule) ScanWithConfig(ctx context.Context, dir string, configFi
 
 // CreateConfig generates configuration file from current settings
 func (m *CheckovModule) CreateConfig(ctx context.Context, configPath string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
 		WithExec([]string{"checkov", "--create-config", "/workspace/config.yml"})
 
 	output, err := container.Stdout(ctx)
@@ -315,9 +306,8 @@ func (m *CheckovModule) ScanWithExternalModules(ctx context.Context, dir string,
 		args = append(args, "--download-external-modules", "true")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -331,8 +321,7 @@ func (m *CheckovModule) ScanWithExternalModules(ctx context.Context, dir string,
 
 // GetVersion returns the version of Checkov
 func (m *CheckovModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
 		WithExec([]string{"checkov", "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -362,9 +351,8 @@ func (m *CheckovModule) ScanDirectoryWithOptions(ctx context.Context, dir string
 		args = append(args, "--quiet")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -389,9 +377,8 @@ func (m *CheckovModule) ScanFileWithOptions(ctx context.Context, filePath string
 		args = append(args, "--output", "json")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithFile("/workspace/input", m.client.Host().File(filePath)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithFile("/workspace/input", hostFile(m.client, filePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -415,9 +402,8 @@ func (m *CheckovModule) ScanWithSpecificChecks(ctx context.Context, dir string,
 	}
 	args = append(args, "--output", "json")
 
-	container := m.client.Container().
-		From(getImageTag("checkov", "bridgecrew/checkov:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
 
@@ -428,3 +414,122 @@ func (m *CheckovModule) ScanWithSpecificChecks(ctx context.Context, dir string,
 
 	return "", fmt.Errorf("failed to scan with specific checks: no output received")
 }
+
+// ErrCheckovFailedChecks is returned alongside the report when checks fail and soft-fail is off
+var ErrCheckovFailedChecks = errors.New("checkov reported failed checks")
+
+// Scan scans a directory with the given scan options. Unless soft-fail is set,
+// failed checks are reported as ErrCheckovFailedChecks together with the output.
+func (m *CheckovModule) Scan(ctx context.Context, dir string, opts ...CheckovOption) (string, error) {
+	config := &CheckovConfig{
+		Output: "cli",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	container := toolContainer(m.client, getImageTag("checkov", "bridgecrew/checkov:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace").
+		WithExec(checkovScanArgs(config), dagger.ContainerWithExecOpts{
+			// Checkov returns non-zero exit code when it finds issues, which is expected
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	if output == "" {
+		stderr, _ := container.Stderr(ctx)
+		return "", fmt.Errorf("failed to run checkov: no output received: %s", stderr)
+	}
+
+	exitCode, err := container.ExitCode(ctx)
+	if err != nil {
+		return output, fmt.Errorf("failed to get checkov exit code: %w", err)
+	}
+
+	return output, checkovExitError(exitCode, config.SoftFail)
+}
+
+// checkovScanArgs builds the checkov command line
+func checkovScanArgs(config *CheckovConfig) []string {
+	args := []string{checkovBinary, "--directory", "."}
+
+	for _, framework := range config.Frameworks {
+		args = append(args, "--framework", framework)
+	}
+	if len(config.Checks) > 0 {
+		args = append(args, "--check", strings.Join(config.Checks, ","))
+	}
+	if len(config.SkipChecks) > 0 {
+		args = append(args, "--skip-check", strings.Join(config.SkipChecks, ","))
+	}
+	if config.Output != "" {
+		args = append(args, "--output", config.Output)
+	}
+	if config.SoftFail {
+		args = append(args, "--soft-fail")
+	}
+
+	return args
+}
+
+// checkovExitError maps checkov's exit code onto an error. Exit code 1 means
+// failed checks, which soft-fail suppresses; any other non-zero code is a
+// checkov error and is always reported.
+func checkovExitError(exitCode int, softFail bool) error {
+	switch exitCode {
+	case 0:
+		return nil
+	case 1:
+		if softFail {
+			return nil
+		}
+		return ErrCheckovFailedChecks
+	default:
+		return fmt.Errorf("checkov exited with code %d", exitCode)
+	}
+}
+
+// CheckovConfig holds the settings for a Checkov run
+type CheckovConfig struct {
+	Frameworks []string
+	Checks     []string
+	SkipChecks []string
+	Output     string
+	SoftFail   bool
+}
+
+// CheckovOption sets a field of CheckovConfig
+type CheckovOption func(*CheckovConfig)
+
+func WithCheckovFrameworks(frameworks []string) CheckovOption {
+	return func(c *CheckovConfig) {
+		c.Frameworks = frameworks
+	}
+}
+
+func WithCheckovChecks(checks []string) CheckovOption {
+	return func(c *CheckovConfig) {
+		c.Checks = checks
+	}
+}
+
+func WithCheckovSkipChecks(checks []string) CheckovOption {
+	return func(c *CheckovConfig) {
+		c.SkipChecks = checks
+	}
+}
+
+// WithCheckovOutput sets the report format (cli, json, sarif)
+func WithCheckovOutput(output string) CheckovOption {
+	return func(c *CheckovConfig) {
+		c.Output = output
+	}
+}
+
+// WithCheckovSoftFail reports findings without failing the scan
+func WithCheckovSoftFail(softFail bool) CheckovOption {
+	return func(c *CheckovConfig) {
+		c.SoftFail = softFail
+	}
+}
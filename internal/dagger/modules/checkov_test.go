@@ -0,0 +1,67 @@
+package modules
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckovScanArgs_Frameworks(t *testing.T) {
+	args := checkovScanArgs(&CheckovConfig{
+		Frameworks: []string{"terraform", "kubernetes"},
+		Checks:     []string{"CKV_AWS_20"},
+		SkipChecks: []string{"CKV_AWS_57", "CKV_AWS_18"},
+		Output:     "sarif",
+	})
+
+	expected := []string{
+		"checkov", "--directory", ".",
+		"--framework", "terraform",
+		"--framework", "kubernetes",
+		"--check", "CKV_AWS_20",
+		"--skip-check", "CKV_AWS_57,CKV_AWS_18",
+		"--output", "sarif",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestCheckovScanArgs_SoftFail(t *testing.T) {
+	args := checkovScanArgs(&CheckovConfig{Output: "cli", SoftFail: true})
+
+	expected := []string{"checkov", "--directory", ".", "--output", "cli", "--soft-fail"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestCheckovExitError(t *testing.T) {
+	if err := checkovExitError(0, false); err != nil {
+		t.Errorf("Expected no error for clean scan, got %v", err)
+	}
+	if err := checkovExitError(1, true); err != nil {
+		t.Errorf("Expected soft-fail to suppress failure, got %v", err)
+	}
+	if err := checkovExitError(1, false); !errors.Is(err, ErrCheckovFailedChecks) {
+		t.Errorf("Expected ErrCheckovFailedChecks, got %v", err)
+	}
+	if err := checkovExitError(0, true); err != nil {
+		t.Errorf("Expected no error for clean soft-fail scan, got %v", err)
+	}
+}
+
+func TestCheckovExitError_OtherCodes(t *testing.T) {
+	for _, softFail := range []bool{false, true} {
+		for _, exitCode := range []int{2, 137} {
+			err := checkovExitError(exitCode, softFail)
+			if err == nil {
+				t.Errorf("Expected error for exit code %d (soft-fail %v)", exitCode, softFail)
+				continue
+			}
+			if errors.Is(err, ErrCheckovFailedChecks) {
+				t.Errorf("Expected exit code %d not to be reported as failed checks", exitCode)
+			}
+		}
+	}
+}
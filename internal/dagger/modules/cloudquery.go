@@ -25,9 +25,8 @@ func NewCloudQueryModule(client *dagger.Client) *CloudQueryModule {
 
 // SyncWithConfig syncs cloud resources using configuration
 func (m *CloudQueryModule) SyncWithConfig(ctx context.Context, configPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithDirectory("/config", m.client.Host().Directory(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithDirectory("/config", hostDirectory(m.client, configPath)).
 		WithExec([]string{
 			cloudqueryBinary,
 			"sync",
@@ -44,9 +43,8 @@ func (m *CloudQueryModule) SyncWithConfig(ctx context.Context, configPath string
 
 // ValidateConfig validates CloudQuery configuration
 func (m *CloudQueryModule) ValidateConfig(ctx context.Context, configPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithDirectory("/config", m.client.Host().Directory(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithDirectory("/config", hostDirectory(m.client, configPath)).
 		WithExec([]string{
 			cloudqueryBinary,
 			"validate-config",
@@ -63,8 +61,7 @@ func (m *CloudQueryModule) ValidateConfig(ctx context.Context, configPath string
 
 // ListProviders lists available CloudQuery providers
 func (m *CloudQueryModule) ListProviders(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{
 			cloudqueryBinary,
 			"provider",
@@ -81,9 +78,8 @@ func (m *CloudQueryModule) ListProviders(ctx context.Context) (string, error) {
 
 // MigrateConfig updates destination schema
 func (m *CloudQueryModule) MigrateConfig(ctx context.Context, configPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithDirectory("/config", m.client.Host().Directory(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithDirectory("/config", hostDirectory(m.client, configPath)).
 		WithExec([]string{
 			cloudqueryBinary,
 			"migrate",
@@ -108,8 +104,7 @@ func (m *CloudQueryModule) InitConfig(ctx context.Context, source string, destin
 		args = append(args, "--destination", destination)
 	}
 
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -122,9 +117,8 @@ func (m *CloudQueryModule) InitConfig(ctx context.Context, source string, destin
 
 // TestConnection tests plugin connections
 func (m *CloudQueryModule) TestConnection(ctx context.Context, configPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithDirectory("/config", m.client.Host().Directory(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithDirectory("/config", hostDirectory(m.client, configPath)).
 		WithExec([]string{
 			cloudqueryBinary,
 			"test-connection",
@@ -152,8 +146,7 @@ func (m *CloudQueryModule) GetTables(ctx context.Context, source string, outputD
 		args = append(args, "--format", format)
 	}
 
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -166,8 +159,7 @@ func (m *CloudQueryModule) GetTables(ctx context.Context, source string, outputD
 
 // Login to CloudQuery Hub
 func (m *CloudQueryModule) Login(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{"cloudquery", "login"})
 
 	output, err := container.Stdout(ctx)
@@ -180,8 +172,7 @@ func (m *CloudQueryModule) Login(ctx context.Context) (string, error) {
 
 // Logout from CloudQuery Hub
 func (m *CloudQueryModule) Logout(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{"cloudquery", "logout"})
 
 	output, err := container.Stdout(ctx)
@@ -194,8 +185,7 @@ func (m *CloudQueryModule) Logout(ctx context.Context) (string, error) {
 
 // InstallPlugin installs a CloudQuery plugin
 func (m *CloudQueryModule) InstallPlugin(ctx context.Context, pluginName string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{"cloudquery", "plugin", "install", pluginName})
 
 	output, err := container.Stdout(ctx)
@@ -208,8 +198,7 @@ func (m *CloudQueryModule) InstallPlugin(ctx context.Context, pluginName string)
 
 // GetVersion returns the version of CloudQuery
 func (m *CloudQueryModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{"cloudquery", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -239,9 +228,8 @@ func (m *CloudQueryModule) SyncWithOptions(ctx context.Context, configPath strin
 		args = append(args, "--no-migrate")
 	}
 
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithFile("/config", m.client.Host().File(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithFile("/config", hostFile(m.client, configPath)).
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -260,9 +248,8 @@ func (m *CloudQueryModule) MigrateWithOptions(ctx context.Context, configPath st
 		args = append(args, "--log-level", logLevel)
 	}
 
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
-		WithFile("/config", m.client.Host().File(configPath)).
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
+		WithFile("/config", hostFile(m.client, configPath)).
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -275,8 +262,7 @@ func (m *CloudQueryModule) MigrateWithOptions(ctx context.Context, configPath st
 
 // Switch between CloudQuery contexts or configurations
 func (m *CloudQueryModule) Switch(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/cloudquery/cloudquery:latest").
+	container := toolContainer(m.client, "ghcr.io/cloudquery/cloudquery:latest").
 		WithExec([]string{"cloudquery", "switch"})
 
 	output, err := container.Stdout(ctx)
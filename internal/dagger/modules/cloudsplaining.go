@@ -23,10 +23,12 @@ func NewCloudsplainingModule(client *dagger.Client) *CloudsplainingModule {
 }
 
 // ScanAccountAuthorization scans account authorization details
-func (m *CloudsplainingModule) ScanAccountAuthorization(ctx context.Context, profile string) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
-		WithEnvVariable("AWS_PROFILE", profile)
+func (m *CloudsplainingModule) ScanAccountAuthorization(ctx context.Context, profile string, opts ...CloudsplainingOption) (string, error) {
+	config := newCloudsplainingConfig(opts)
+
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
+		WithEnvVariable("AWS_PROFILE", profile).
+		WithWorkdir("/workspace")
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
 		container = container.
@@ -35,12 +37,16 @@ func (m *CloudsplainingModule) ScanAccountAuthorization(ctx context.Context, pro
 			WithEnvVariable("AWS_REGION", os.Getenv("AWS_REGION"))
 	}
 
+	container = m.withExclusions(container, config)
+
 	// First download, then scan
-	container = container.WithExec([]string{
-		"sh", "-c", "cloudsplaining download --profile " + profile + " && cloudsplaining scan --input-file default.json --output /workspace/results.json",
-	}, dagger.ContainerWithExecOpts{
-		Expect: "ANY",
-	})
+	container = container.
+		WithExec([]string{"cloudsplaining", "download", "--profile", profile}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		}).
+		WithExec(cloudsplainingScanArgs(config, "scan", "default.json", "/workspace/results.json"), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
 
 	output, err := container.Stdout(ctx)
 	if err != nil {
@@ -55,16 +61,15 @@ func (m *CloudsplainingModule) ScanAccountAuthorization(ctx context.Context, pro
 }
 
 // ScanPolicyFile scans a specific IAM policy file
-func (m *CloudsplainingModule) ScanPolicyFile(ctx context.Context, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath)).
-		WithWorkdir("/workspace").
-		WithExec([]string{
-			"cloudsplaining", "scan-policy-file",
-			"--input-file", "policy.json",
-			"--output", "results.json",
-		}, dagger.ContainerWithExecOpts{
+func (m *CloudsplainingModule) ScanPolicyFile(ctx context.Context, policyPath string, opts ...CloudsplainingOption) (string, error) {
+	config := newCloudsplainingConfig(opts)
+
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
+		WithWorkdir("/workspace")
+
+	container = m.withExclusions(container, config).
+		WithExec(cloudsplainingScanArgs(config, "scan-policy-file", "policy.json", "results.json"), dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
@@ -82,9 +87,8 @@ func (m *CloudsplainingModule) ScanPolicyFile(ctx context.Context, policyPath st
 
 // CreateReportFromResults creates an HTML report from scan results
 func (m *CloudsplainingModule) CreateReportFromResults(ctx context.Context, resultsPath string) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
-		WithFile("/workspace/results.json", m.client.Host().File(resultsPath)).
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
+		WithFile("/workspace/results.json", hostFile(m.client, resultsPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"cloudsplaining", "create-report",
@@ -108,8 +112,7 @@ func (m *CloudsplainingModule) CreateReportFromResults(ctx context.Context, resu
 
 // ScanWithMinimization scans with policy minimization recommendations
 func (m *CloudsplainingModule) ScanWithMinimization(ctx context.Context, profile string, minimizeStatementId string) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
 		WithEnvVariable("AWS_PROFILE", profile)
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -151,8 +154,7 @@ func (m *CloudsplainingModule) Download(ctx context.Context, profile string, inc
 		args = append(args, "--include-non-default-policy-versions")
 	}
 
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest")
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest")
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
 		container = container.
@@ -187,13 +189,12 @@ func (m *CloudsplainingModule) ScanAccountData(ctx context.Context, inputFile st
 		args = append(args, "--output", outputDir)
 	}
 
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
-		WithFile("/workspace/input.json", m.client.Host().File(inputFile)).
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
+		WithFile("/workspace/input.json", hostFile(m.client, inputFile)).
 		WithWorkdir("/workspace")
 
 	if exclusionsFile != "" {
-		container = container.WithFile("/workspace/exclusions.yml", m.client.Host().File(exclusionsFile))
+		container = container.WithFile("/workspace/exclusions.yml", hostFile(m.client, exclusionsFile))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
@@ -214,8 +215,7 @@ func (m *CloudsplainingModule) ScanAccountData(ctx context.Context, inputFile st
 
 // CreateExclusionsFile creates exclusions file template
 func (m *CloudsplainingModule) CreateExclusionsFile(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
 		WithExec([]string{"cloudsplaining", "create-exclusions-file"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -234,8 +234,7 @@ func (m *CloudsplainingModule) CreateExclusionsFile(ctx context.Context) (string
 
 // CreateMultiAccountConfig creates multi-account configuration file
 func (m *CloudsplainingModule) CreateMultiAccountConfig(ctx context.Context, outputFile string) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
 		WithExec([]string{"cloudsplaining", "create-multi-account-config-file", "-o", "/workspace/config.yml"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -269,9 +268,8 @@ func (m *CloudsplainingModule) ScanMultiAccount(ctx context.Context, configFile
 		args = append(args, "--output-directory", outputDirectory)
 	}
 
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
-		WithFile("/workspace/config.yml", m.client.Host().File(configFile)).
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
+		WithFile("/workspace/config.yml", hostFile(m.client, configFile)).
 		WithWorkdir("/workspace")
 
 	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
@@ -299,8 +297,7 @@ func (m *CloudsplainingModule) ScanMultiAccount(ctx context.Context, configFile
 
 // GetVersion returns the version of CloudSplaining
 func (m *CloudsplainingModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("markpepapa/cloudsplaining:latest").
+	container := toolContainer(m.client, "markpepapa/cloudsplaining:latest").
 		WithExec([]string{"cloudsplaining", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -317,4 +314,47 @@ func (m *CloudsplainingModule) GetVersion(ctx context.Context) (string, error) {
 	}
 	
 	return "", fmt.Errorf("failed to get cloudsplaining version: no output received")
-}
\ No newline at end of file
+}
+
+// cloudsplainingExclusionsMount is where the exclusions file is mounted inside the container
+const cloudsplainingExclusionsMount = "/workspace/exclusions.yml"
+
+func newCloudsplainingConfig(opts []CloudsplainingOption) *CloudsplainingConfig {
+	config := &CloudsplainingConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// withExclusions mounts the exclusions file when one is configured
+func (m *CloudsplainingModule) withExclusions(container *dagger.Container, config *CloudsplainingConfig) *dagger.Container {
+	if config.ExclusionsFile == "" {
+		return container
+	}
+	return container.WithFile(cloudsplainingExclusionsMount, hostFile(m.client, config.ExclusionsFile))
+}
+
+// cloudsplainingScanArgs builds a cloudsplaining scan command line
+func cloudsplainingScanArgs(config *CloudsplainingConfig, command, inputFile, output string) []string {
+	args := []string{"cloudsplaining", command, "--input-file", inputFile}
+	if config.ExclusionsFile != "" {
+		args = append(args, "--exclusions-file", cloudsplainingExclusionsMount)
+	}
+	return append(args, "--output", output)
+}
+
+// CloudsplainingConfig holds the settings for a Cloudsplaining run
+type CloudsplainingConfig struct {
+	ExclusionsFile string
+}
+
+// CloudsplainingOption sets a field of CloudsplainingConfig
+type CloudsplainingOption func(*CloudsplainingConfig)
+
+// WithCloudsplainingExclusions mounts a YAML exclusions file to suppress expected findings
+func WithCloudsplainingExclusions(path string) CloudsplainingOption {
+	return func(c *CloudsplainingConfig) {
+		c.ExclusionsFile = path
+	}
+}
@@ -0,0 +1,31 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloudsplainingScanArgs_Default(t *testing.T) {
+	args := cloudsplainingScanArgs(newCloudsplainingConfig(nil), "scan", "default.json", "/workspace/results.json")
+
+	expected := []string{"cloudsplaining", "scan", "--input-file", "default.json", "--output", "/workspace/results.json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestCloudsplainingScanArgs_Exclusions(t *testing.T) {
+	config := newCloudsplainingConfig([]CloudsplainingOption{
+		WithCloudsplainingExclusions("./exclusions.yml"),
+	})
+	args := cloudsplainingScanArgs(config, "scan-policy-file", "policy.json", "results.json")
+
+	expected := []string{
+		"cloudsplaining", "scan-policy-file", "--input-file", "policy.json",
+		"--exclusions-file", cloudsplainingExclusionsMount,
+		"--output", "results.json",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
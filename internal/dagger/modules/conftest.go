@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"dagger.io/dagger"
 )
@@ -23,10 +25,9 @@ func NewConftestModule(client *dagger.Client) *ConftestModule {
 
 // TestWithPolicy tests files against OPA policies
 func (m *ConftestModule) TestWithPolicy(ctx context.Context, dir string, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
-		WithDirectory("/policies", m.client.Host().Directory(policyPath)).
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithDirectory("/policies", hostDirectory(m.client, policyPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"/conftest", "test",
@@ -47,10 +48,9 @@ func (m *ConftestModule) TestWithPolicy(ctx context.Context, dir string, policyP
 
 // TestFile tests a specific file against policies
 func (m *ConftestModule) TestFile(ctx context.Context, filePath string, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
-		WithFile("/workspace/target.yaml", m.client.Host().File(filePath)).
-		WithDirectory("/policies", m.client.Host().Directory(policyPath)).
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
+		WithFile("/workspace/target.yaml", hostFile(m.client, filePath)).
+		WithDirectory("/policies", hostDirectory(m.client, policyPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"/conftest", "test",
@@ -71,9 +71,8 @@ func (m *ConftestModule) TestFile(ctx context.Context, filePath string, policyPa
 
 // VerifyPolicies runs policy unit tests
 func (m *ConftestModule) VerifyPolicies(ctx context.Context, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
-		WithDirectory("/policies", m.client.Host().Directory(policyPath)).
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
+		WithDirectory("/policies", hostDirectory(m.client, policyPath)).
 		WithExec([]string{
 			"/conftest", "verify",
 			"--policy", "/policies",
@@ -96,9 +95,8 @@ func (m *ConftestModule) ParseFile(ctx context.Context, filePath string, parser
 		args = append(args, "--parser", parser)
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
-		WithFile("/workspace/target.yaml", m.client.Host().File(filePath)).
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
+		WithFile("/workspace/target.yaml", hostFile(m.client, filePath)).
 		WithWorkdir("/workspace").
 		WithExec(args)
 
@@ -117,11 +115,10 @@ func (m *ConftestModule) PushPolicies(ctx context.Context, registryURL string, p
 		args = append(args, "/policies")
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest")
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest")
 
 	if policyPath != "" {
-		container = container.WithDirectory("/policies", m.client.Host().Directory(policyPath))
+		container = container.WithDirectory("/policies", hostDirectory(m.client, policyPath))
 	}
 
 	container = container.WithExec(args)
@@ -136,8 +133,7 @@ func (m *ConftestModule) PushPolicies(ctx context.Context, registryURL string, p
 
 // GetVersion returns the version of Conftest
 func (m *ConftestModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
 		WithExec([]string{"/conftest", "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -170,13 +166,12 @@ func (m *ConftestModule) TestWithOptions(ctx context.Context, inputFile string,
 		args = append(args, "--parser", parser)
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest").
-		WithFile("/workspace/input", m.client.Host().File(inputFile)).
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest").
+		WithFile("/workspace/input", hostFile(m.client, inputFile)).
 		WithWorkdir("/workspace")
 
 	if policy != "" {
-		container = container.WithDirectory("/policies", m.client.Host().Directory(policy))
+		container = container.WithDirectory("/policies", hostDirectory(m.client, policy))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
@@ -200,11 +195,10 @@ func (m *ConftestModule) VerifyWithOptions(ctx context.Context, policy string, s
 		args = append(args, "--show-builtin-errors")
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/conftest:latest")
+	container := toolContainer(m.client, "openpolicyagent/conftest:latest")
 
 	if policy != "" {
-		container = container.WithDirectory("/policies", m.client.Host().Directory(policy))
+		container = container.WithDirectory("/policies", hostDirectory(m.client, policy))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{Expect: "ANY"})
@@ -216,3 +210,134 @@ func (m *ConftestModule) VerifyWithOptions(ctx context.Context, policy string, s
 
 	return "", fmt.Errorf("failed to run conftest verify with options: no output received")
 }
+
+// conftestPolicyMount is where the policy directory is mounted inside the container
+const conftestPolicyMount = "/policies"
+
+// Test tests a file, or every file in a directory, against OPA policies with
+// the given options
+func (m *ConftestModule) Test(ctx context.Context, path string, opts ...ConftestOption) (string, error) {
+	config := newConftestConfig(opts)
+
+	container := toolContainer(m.client, getImageTag("conftest", "openpolicyagent/conftest:latest"))
+
+	input := "."
+	if info, err := os.Stat(HostPath(path)); err == nil && !info.IsDir() {
+		input = filepath.Base(path)
+		container = container.WithFile("/workspace/"+input, hostFile(m.client, path))
+	} else {
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, path))
+	}
+	container = container.WithWorkdir("/workspace")
+
+	if config.PolicyPath != "" {
+		container = container.WithDirectory(conftestPolicyMount, hostDirectory(m.client, config.PolicyPath))
+	}
+
+	container = container.WithExec(conftestTestArgs(config, input), dagger.ContainerWithExecOpts{
+		// conftest exits non-zero when a policy fails
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run conftest test: no output received")
+}
+
+func newConftestConfig(opts []ConftestOption) *ConftestConfig {
+	config := &ConftestConfig{
+		Output: "json",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// conftestTestArgs builds the conftest test command line for input, a file
+// name or "." for the whole workspace
+func conftestTestArgs(config *ConftestConfig, input string) []string {
+	args := []string{"/conftest", "test", input, "--output", config.Output}
+
+	if config.PolicyPath != "" {
+		args = append(args, "--policy", conftestPolicyMount)
+	}
+	if config.AllNamespaces {
+		args = append(args, "--all-namespaces")
+	} else if config.Namespace != "" {
+		args = append(args, "--namespace", config.Namespace)
+	}
+	if config.Combine {
+		args = append(args, "--combine")
+	}
+	if config.Parser != "" {
+		args = append(args, "--parser", config.Parser)
+	}
+
+	return args
+}
+
+// ConftestConfig holds the settings for a Conftest run
+type ConftestConfig struct {
+	PolicyPath    string
+	Namespace     string
+	AllNamespaces bool
+	Combine       bool
+	Output        string
+	Parser        string
+}
+
+// ConftestOption sets a field of ConftestConfig
+type ConftestOption func(*ConftestConfig)
+
+// WithConftestPolicyPath sets the directory holding the Rego policies
+func WithConftestPolicyPath(path string) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.PolicyPath = path
+	}
+}
+
+// WithConftestNamespace sets the policy namespace to evaluate
+func WithConftestNamespace(namespace string) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.Namespace = namespace
+	}
+}
+
+// WithConftestAllNamespaces evaluates the policies in every namespace instead
+// of a single one
+func WithConftestAllNamespaces(allNamespaces bool) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.AllNamespaces = allNamespaces
+	}
+}
+
+// WithConftestCombine combines all input files into one document, so policies
+// can relate resources declared in different files
+func WithConftestCombine(combine bool) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.Combine = combine
+	}
+}
+
+// WithConftestOutput sets the output format (json, table, tap, junit, ...)
+func WithConftestOutput(output string) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.Output = output
+	}
+}
+
+// WithConftestParser forces the parser used for the input files
+func WithConftestParser(parser string) ConftestOption {
+	return func(c *ConftestConfig) {
+		c.Parser = parser
+	}
+}
@@ -0,0 +1,55 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConftestTestArgs_Defaults(t *testing.T) {
+	args := conftestTestArgs(newConftestConfig(nil), ".")
+
+	expected := []string{"/conftest", "test", ".", "--output", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestConftestTestArgs_Combine(t *testing.T) {
+	config := newConftestConfig([]ConftestOption{
+		WithConftestPolicyPath("./policies"),
+		WithConftestCombine(true),
+	})
+	args := conftestTestArgs(config, ".")
+
+	expected := []string{"/conftest", "test", ".", "--output", "json", "--policy", "/policies", "--combine"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestConftestTestArgs_AllNamespaces(t *testing.T) {
+	config := newConftestConfig([]ConftestOption{
+		WithConftestNamespace("kubernetes"),
+		WithConftestAllNamespaces(true),
+	})
+	args := conftestTestArgs(config, "deployment.yaml")
+
+	expected := []string{"/conftest", "test", "deployment.yaml", "--output", "json", "--all-namespaces"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestConftestTestArgs_NamespaceAndParser(t *testing.T) {
+	config := newConftestConfig([]ConftestOption{
+		WithConftestNamespace("kubernetes"),
+		WithConftestOutput("table"),
+		WithConftestParser("yaml"),
+	})
+	args := conftestTestArgs(config, ".")
+
+	expected := []string{"/conftest", "test", ".", "--output", "table", "--namespace", "kubernetes", "--parser", "yaml"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
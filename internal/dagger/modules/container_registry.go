@@ -37,8 +37,7 @@ func (m *ContainerRegistryModule) Login(ctx context.Context, registry, username,
 		args = append(args, "--password", password)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "docker-cli"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -61,8 +60,7 @@ func (m *ContainerRegistryModule) Login(ctx context.Context, registry, username,
 
 // PushImage pushes an image to the registry
 func (m *ContainerRegistryModule) PushImage(ctx context.Context, image string) (string, error) {
-	container := m.client.Container().
-		From("docker:dind").
+	container := toolContainer(m.client, "docker:dind").
 		WithExec([]string{dockerBinary, "push", image})
 
 	output, err := container.Stdout(ctx)
@@ -76,7 +74,7 @@ func (m *ContainerRegistryModule) PushImage(ctx context.Context, image string) (
 // PullImage pulls an image from the registry
 func (m *ContainerRegistryModule) PullImage(ctx context.Context, image string) (string, error) {
 	// Use Dagger's native container pulling with a simple command
-	container := m.client.Container().From(image).WithExec([]string{"echo", "pulled"})
+	container := toolContainer(m.client, image).WithExec([]string{"echo", "pulled"})
 	
 	// Get output to confirm it was pulled
 	output, err := container.Stdout(ctx)
@@ -97,8 +95,7 @@ func (m *ContainerRegistryModule) ListImages(ctx context.Context, repository str
 		args = append(args, "--all")
 	}
 
-	container := m.client.Container().
-		From("docker:dind").
+	container := toolContainer(m.client, "docker:dind").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -112,7 +109,7 @@ func (m *ContainerRegistryModule) ListImages(ctx context.Context, repository str
 // TagImage creates a tag for an image
 func (m *ContainerRegistryModule) TagImage(ctx context.Context, sourceImage, targetImage string) (string, error) {
 	// In Dagger, we can simulate tagging by confirming the source exists
-	container := m.client.Container().From(sourceImage).WithExec([]string{"echo", "tagged"})
+	container := toolContainer(m.client, sourceImage).WithExec([]string{"echo", "tagged"})
 	
 	// Verify source image exists
 	output, err := container.Stdout(ctx)
@@ -130,8 +127,7 @@ func (m *ContainerRegistryModule) Logout(ctx context.Context, registry string) (
 		args = append(args, registry)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "docker-cli"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
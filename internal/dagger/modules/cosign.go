@@ -2,8 +2,11 @@ package modules
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -26,8 +29,7 @@ func NewCosignModule(client *dagger.Client) *CosignModule {
 
 // VerifyImage verifies a signed container image
 func (m *CosignModule) VerifyImage(ctx context.Context, imageName string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1").
 		WithExec([]string{cosignBinary, "verify", imageName})
 
@@ -45,9 +47,8 @@ func (m *CosignModule) VerifyImage(ctx context.Context, imageName string) (strin
 
 // VerifyImageWithKey verifies an image with a specific public key
 func (m *CosignModule) VerifyImageWithKey(ctx context.Context, imageName string, publicKeyPath string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/public.key", m.client.Host().File(publicKeyPath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/public.key", hostFile(m.client, publicKeyPath)).
 		WithExec([]string{cosignBinary, "verify", "--key", "/tmp/public.key", imageName})
 
 	output, err := container.Stdout(ctx)
@@ -64,9 +65,8 @@ func (m *CosignModule) VerifyImageWithKey(ctx context.Context, imageName string,
 
 // SignImage signs a container image (requires authentication)
 func (m *CosignModule) SignImage(ctx context.Context, imageName string, privateKeyPath string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/private.key", m.client.Host().File(privateKeyPath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/private.key", hostFile(m.client, privateKeyPath)).
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if os.Getenv("COSIGN_PASSWORD") != "" {
@@ -89,8 +89,7 @@ func (m *CosignModule) SignImage(ctx context.Context, imageName string, privateK
 
 // SignImageKeyless signs an image using keyless signing (OIDC)
 func (m *CosignModule) SignImageKeyless(ctx context.Context, imageName string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1").
 		WithExec([]string{"cosign", "sign", imageName}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -117,8 +116,7 @@ func (m *CosignModule) VerifyAttestation(ctx context.Context, imageName string,
 		args = append(args, "--type", attestationType)
 	}
 
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1").
 		WithExec(args)
 
@@ -136,9 +134,8 @@ func (m *CosignModule) VerifyAttestation(ctx context.Context, imageName string,
 
 // GenerateKeyPair generates a new signing key pair
 func (m *CosignModule) GenerateKeyPair(ctx context.Context, outputDir string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(outputDir)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, outputDir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{cosignBinary, "generate-key-pair"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -159,10 +156,9 @@ func (m *CosignModule) GenerateKeyPair(ctx context.Context, outputDir string) (s
 
 // AttestSBOM creates an SBOM attestation for an image
 func (m *CosignModule) AttestSBOM(ctx context.Context, imageName string, sbomPath string, privateKeyPath string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/sbom.json", m.client.Host().File(sbomPath)).
-		WithFile("/tmp/private.key", m.client.Host().File(privateKeyPath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/sbom.json", hostFile(m.client, sbomPath)).
+		WithFile("/tmp/private.key", hostFile(m.client, privateKeyPath)).
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if os.Getenv("COSIGN_PASSWORD") != "" {
@@ -197,12 +193,11 @@ func (m *CosignModule) SignBlob(ctx context.Context, blobPath string, keyPath st
 	}
 	args = append(args, "/tmp/blob")
 
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/blob", m.client.Host().File(blobPath))
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/blob", hostFile(m.client, blobPath))
 
 	if keyPath != "" {
-		container = container.WithFile("/tmp/private.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/private.key", hostFile(m.client, keyPath))
 	}
 
 	if os.Getenv("COSIGN_PASSWORD") != "" {
@@ -234,13 +229,12 @@ func (m *CosignModule) VerifyBlob(ctx context.Context, blobPath string, signatur
 	}
 	args = append(args, "/tmp/blob")
 
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/blob", m.client.Host().File(blobPath)).
-		WithFile("/tmp/signature", m.client.Host().File(signaturePath))
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/blob", hostFile(m.client, blobPath)).
+		WithFile("/tmp/signature", hostFile(m.client, signaturePath))
 
 	if keyPath != "" {
-		container = container.WithFile("/tmp/public.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/public.key", hostFile(m.client, keyPath))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
@@ -262,9 +256,8 @@ func (m *CosignModule) VerifyBlob(ctx context.Context, blobPath string, signatur
 
 // UploadBlob uploads generic artifact as a blob to registry
 func (m *CosignModule) UploadBlob(ctx context.Context, blobPath string, registryURL string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/blob", m.client.Host().File(blobPath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/blob", hostFile(m.client, blobPath)).
 		WithExec([]string{cosignBinary, "upload", "blob", "-f", "/tmp/blob", registryURL})
 
 	output, err := container.Stdout(ctx)
@@ -277,9 +270,8 @@ func (m *CosignModule) UploadBlob(ctx context.Context, blobPath string, registry
 
 // UploadWasm uploads WebAssembly module to registry
 func (m *CosignModule) UploadWasm(ctx context.Context, wasmPath string, registryURL string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/wasm", m.client.Host().File(wasmPath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/wasm", hostFile(m.client, wasmPath)).
 		WithExec([]string{cosignBinary, "upload", "wasm", "-f", "/tmp/wasm", registryURL})
 
 	output, err := container.Stdout(ctx)
@@ -292,8 +284,7 @@ func (m *CosignModule) UploadWasm(ctx context.Context, wasmPath string, registry
 
 // CopyImage copies images between registries
 func (m *CosignModule) CopyImage(ctx context.Context, sourceImage string, destinationImage string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithExec([]string{cosignBinary, "copy", sourceImage, destinationImage})
 
 	output, err := container.Stdout(ctx)
@@ -306,8 +297,7 @@ func (m *CosignModule) CopyImage(ctx context.Context, sourceImage string, destin
 
 // GetVersion returns the version of Cosign
 func (m *CosignModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithExec([]string{cosignBinary, "version"})
 
 	output, err := container.Stdout(ctx)
@@ -322,8 +312,7 @@ func (m *CosignModule) GetVersion(ctx context.Context) (string, error) {
 func (m *CosignModule) SignImageWithOptions(ctx context.Context, imageName string, keyPath string, keyless bool) (string, error) {
 	args := []string{"cosign", "sign"}
 	
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if keyless {
@@ -334,7 +323,7 @@ func (m *CosignModule) SignImageWithOptions(ctx context.Context, imageName strin
 	} else if keyPath != "" {
 		// Use key-based signing
 		args = append(args, "--key", "/tmp/private.key")
-		container = container.WithFile("/tmp/private.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/private.key", hostFile(m.client, keyPath))
 		if os.Getenv("COSIGN_PASSWORD") != "" {
 			container = container.WithEnvVariable("COSIGN_PASSWORD", os.Getenv("COSIGN_PASSWORD"))
 		}
@@ -356,20 +345,15 @@ func (m *CosignModule) SignImageWithOptions(ctx context.Context, imageName strin
 }
 
 // VerifyImageWithOptions verifies container image with comprehensive options
-func (m *CosignModule) VerifyImageWithOptions(ctx context.Context, imageName string, keyPath string, keyless bool) (string, error) {
-	args := []string{"cosign", "verify"}
-	
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+func (m *CosignModule) VerifyImageWithOptions(ctx context.Context, imageName string, keyPath string, keyless bool, identity CosignIdentity) (string, error) {
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if !keyless && keyPath != "" {
-		args = append(args, "--key", "/tmp/public.key")
-		container = container.WithFile("/tmp/public.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/public.key", hostFile(m.client, keyPath))
 	}
 
-	args = append(args, imageName)
-	container = container.WithExec(args)
+	container = container.WithExec(cosignVerifyArgs(imageName, keyPath, keyless, identity))
 
 	output, err := container.Stdout(ctx)
 	if err != nil {
@@ -387,14 +371,13 @@ func (m *CosignModule) VerifyImageWithOptions(ctx context.Context, imageName str
 func (m *CosignModule) AttestWithOptions(ctx context.Context, imageName string, predicatePath string, keyPath string) (string, error) {
 	args := []string{"cosign", "attest", "--predicate", "/tmp/predicate.json"}
 	
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
-		WithFile("/tmp/predicate.json", m.client.Host().File(predicatePath)).
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithFile("/tmp/predicate.json", hostFile(m.client, predicatePath)).
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if keyPath != "" {
 		args = append(args, "--key", "/tmp/private.key")
-		container = container.WithFile("/tmp/private.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/private.key", hostFile(m.client, keyPath))
 		if os.Getenv("COSIGN_PASSWORD") != "" {
 			container = container.WithEnvVariable("COSIGN_PASSWORD", os.Getenv("COSIGN_PASSWORD"))
 		}
@@ -419,17 +402,16 @@ func (m *CosignModule) AttestWithOptions(ctx context.Context, imageName string,
 func (m *CosignModule) VerifyAttestationWithOptions(ctx context.Context, imageName string, keyPath string, policyPath string) (string, error) {
 	args := []string{"cosign", "verify-attestation"}
 	
-	container := m.client.Container().
-		From("gcr.io/projectsigstore/cosign:latest").
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
 		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
 
 	if keyPath != "" {
 		args = append(args, "--key", "/tmp/public.key")
-		container = container.WithFile("/tmp/public.key", m.client.Host().File(keyPath))
+		container = container.WithFile("/tmp/public.key", hostFile(m.client, keyPath))
 	}
 	if policyPath != "" {
 		args = append(args, "--policy", "/tmp/policy.json")
-		container = container.WithFile("/tmp/policy.json", m.client.Host().File(policyPath))
+		container = container.WithFile("/tmp/policy.json", hostFile(m.client, policyPath))
 	}
 
 	args = append(args, imageName)
@@ -445,4 +427,213 @@ func (m *CosignModule) VerifyAttestationWithOptions(ctx context.Context, imageNa
 	}
 
 	return output, nil
-}
\ No newline at end of file
+}
+
+// VerifyImageSignature verifies an image signature and, unlike VerifyImageWithOptions,
+// returns an error when verification fails. Keyless verification checks the
+// signing certificate against identity.
+func (m *CosignModule) VerifyImageSignature(ctx context.Context, imageName string, keyPath string, keyless bool, identity CosignIdentity) (string, error) {
+	if keyless {
+		if err := identity.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	container := toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest").
+		WithEnvVariable("COSIGN_EXPERIMENTAL", "1")
+
+	if !keyless && keyPath != "" {
+		container = container.WithFile("/tmp/public.key", hostFile(m.client, keyPath))
+	}
+
+	container = container.WithExec(cosignVerifyArgs(imageName, keyPath, keyless, identity))
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		return "", fmt.Errorf("cosign signature verification failed: %w\nStderr: %s", err, stderr)
+	}
+
+	return output, nil
+}
+
+// CosignIdentity is the signer a keyless signature's certificate must match.
+// Each field takes an exact value or a regular expression.
+type CosignIdentity struct {
+	Identity       string
+	IdentityRegexp string
+	Issuer         string
+	IssuerRegexp   string
+}
+
+// Validate requires the certificate identity and OIDC issuer that cosign
+// needs for keyless verification
+func (i CosignIdentity) Validate() error {
+	if i.Identity == "" && i.IdentityRegexp == "" {
+		return fmt.Errorf("keyless verification requires a certificate identity or identity regexp")
+	}
+	if i.Issuer == "" && i.IssuerRegexp == "" {
+		return fmt.Errorf("keyless verification requires a certificate OIDC issuer or issuer regexp")
+	}
+	return nil
+}
+
+// cosignVerifyArgs builds the cosign verify command line; the key is mounted at /tmp/public.key
+func cosignVerifyArgs(imageName string, keyPath string, keyless bool, identity CosignIdentity) []string {
+	args := []string{cosignBinary, "verify"}
+	if !keyless && keyPath != "" {
+		args = append(args, "--key", "/tmp/public.key")
+	}
+	if keyless {
+		args = append(args, cosignIdentityArgs(identity)...)
+	}
+	return append(args, imageName)
+}
+
+// cosignIdentityArgs maps a keyless signer identity onto cosign's certificate flags
+func cosignIdentityArgs(identity CosignIdentity) []string {
+	var args []string
+	if identity.Identity != "" {
+		args = append(args, "--certificate-identity", identity.Identity)
+	}
+	if identity.IdentityRegexp != "" {
+		args = append(args, "--certificate-identity-regexp", identity.IdentityRegexp)
+	}
+	if identity.Issuer != "" {
+		args = append(args, "--certificate-oidc-issuer", identity.Issuer)
+	}
+	if identity.IssuerRegexp != "" {
+		args = append(args, "--certificate-oidc-issuer-regexp", identity.IssuerRegexp)
+	}
+	return args
+}
+
+// cosignSBOMMount is where an SBOM to attach is mounted in the cosign container
+const cosignSBOMMount = "/tmp/sbom"
+
+// cosignDockerConfigMount is the DOCKER_CONFIG directory the host's Docker
+// configuration is mounted into, so cosign can authenticate to registries
+const cosignDockerConfigMount = "/tmp/docker-config"
+
+// CosignSBOMTypes are the SBOM types cosign attach sbom accepts
+var CosignSBOMTypes = []string{"spdx", "cyclonedx", "syft"}
+
+// AttachSBOM attaches an SBOM file to an image in its registry. An empty
+// sbomType is detected from the SBOM's content.
+func (m *CosignModule) AttachSBOM(ctx context.Context, imageName string, sbomPath string, sbomType string) (string, error) {
+	if sbomType == "" {
+		content, err := os.ReadFile(HostPath(sbomPath))
+		if err != nil {
+			return "", fmt.Errorf("failed to read SBOM %s: %w", sbomPath, err)
+		}
+		sbomType = cosignSBOMType(content)
+	}
+
+	container, err := withCosignRegistryAuth(m.client, toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest"))
+	if err != nil {
+		return "", err
+	}
+	container = container.
+		WithFile(cosignSBOMMount, hostFile(m.client, sbomPath)).
+		WithExec(cosignAttachSBOMArgs(imageName, sbomType))
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		return "", fmt.Errorf("failed to attach SBOM to %s: %w\nStderr: %s", imageName, err, stderr)
+	}
+	if output == "" {
+		return fmt.Sprintf("Attached %s SBOM %s to %s", sbomType, sbomPath, imageName), nil
+	}
+
+	return output, nil
+}
+
+// DownloadSBOM downloads the SBOM attached to an image. With an output path
+// the SBOM is written there instead of being returned.
+func (m *CosignModule) DownloadSBOM(ctx context.Context, imageName string, outputPath string) (string, error) {
+	container, err := withCosignRegistryAuth(m.client, toolContainer(m.client, "gcr.io/projectsigstore/cosign:latest"))
+	if err != nil {
+		return "", err
+	}
+	container = container.WithExec(cosignDownloadSBOMArgs(imageName))
+
+	sbom, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		return "", fmt.Errorf("failed to download SBOM of %s: %w\nStderr: %s", imageName, err, stderr)
+	}
+	if outputPath == "" {
+		return sbom, nil
+	}
+
+	if err := os.WriteFile(outputPath, []byte(sbom), 0644); err != nil {
+		return "", fmt.Errorf("failed to write SBOM to %s: %w", outputPath, err)
+	}
+	return fmt.Sprintf("Downloaded SBOM of %s to %s", imageName, outputPath), nil
+}
+
+// cosignAttachSBOMArgs builds the cosign attach sbom command line; the SBOM is
+// mounted at cosignSBOMMount
+func cosignAttachSBOMArgs(imageName string, sbomType string) []string {
+	return []string{cosignBinary, "attach", "sbom", "--sbom", cosignSBOMMount, "--type", sbomType, imageName}
+}
+
+// cosignSBOMType detects an SBOM's type from its JSON content, defaulting to
+// spdx as cosign does
+func cosignSBOMType(content []byte) string {
+	var doc struct {
+		BOMFormat   string          `json:"bomFormat"`
+		SPDXVersion string          `json:"spdxVersion"`
+		Descriptor  json.RawMessage `json:"descriptor"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return "spdx"
+	}
+	switch {
+	case strings.EqualFold(doc.BOMFormat, "CycloneDX"):
+		return "cyclonedx"
+	case doc.SPDXVersion == "" && len(doc.Descriptor) > 0:
+		return "syft"
+	default:
+		return "spdx"
+	}
+}
+
+// dockerConfigFile returns the host's Docker configuration file, honoring
+// DOCKER_CONFIG
+func dockerConfigFile(getenv func(string) string) string {
+	if dir := getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	if home := getenv("HOME"); home != "" {
+		return filepath.Join(home, ".docker", "config.json")
+	}
+	return ""
+}
+
+// withCosignRegistryAuth mounts the host's Docker configuration into a cosign
+// container as a secret so it can push to and pull from private registries.
+// Credentials kept in a credential helper are not available in the container.
+func withCosignRegistryAuth(client *dagger.Client, container *dagger.Container) (*dagger.Container, error) {
+	path := dockerConfigFile(os.Getenv)
+	if path == "" {
+		return container, nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return container, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker configuration %s: %w", path, err)
+	}
+
+	return container.
+		WithMountedSecret(cosignDockerConfigMount+"/config.json", client.SetSecret(secretName("docker-config", string(content)), string(content))).
+		WithEnvVariable("DOCKER_CONFIG", cosignDockerConfigMount), nil
+}
+
+// cosignDownloadSBOMArgs builds the cosign download sbom command line
+func cosignDownloadSBOMArgs(imageName string) []string {
+	return []string{cosignBinary, "download", "sbom", imageName}
+}
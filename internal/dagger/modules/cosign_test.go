@@ -0,0 +1,84 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCosignVerifyArgs(t *testing.T) {
+	args := cosignVerifyArgs("app:1.0", "cosign.pub", false, CosignIdentity{})
+	expected := []string{cosignBinary, "verify", "--key", "/tmp/public.key", "app:1.0"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	identity := CosignIdentity{
+		IdentityRegexp: "^https://github.com/org/app/",
+		Issuer:         "https://token.actions.githubusercontent.com",
+	}
+	args = cosignVerifyArgs("app:1.0", "cosign.pub", true, identity)
+	expected = []string{cosignBinary, "verify",
+		"--certificate-identity-regexp", "^https://github.com/org/app/",
+		"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+		"app:1.0"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected keyless args %v, got %v", expected, args)
+	}
+}
+
+func TestCosignIdentityValidate(t *testing.T) {
+	valid := CosignIdentity{Identity: "release@example.com", IssuerRegexp: "^https://accounts.google.com$"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected identity to be valid, got %v", err)
+	}
+
+	if err := (CosignIdentity{Issuer: "https://token.actions.githubusercontent.com"}).Validate(); err == nil {
+		t.Error("Expected an error without a certificate identity")
+	}
+	if err := (CosignIdentity{Identity: "release@example.com"}).Validate(); err == nil {
+		t.Error("Expected an error without an OIDC issuer")
+	}
+}
+
+func TestCosignAttachSBOMArgs(t *testing.T) {
+	args := cosignAttachSBOMArgs("ghcr.io/org/app:1.0", "cyclonedx")
+	expected := []string{cosignBinary, "attach", "sbom", "--sbom", cosignSBOMMount, "--type", "cyclonedx", "ghcr.io/org/app:1.0"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestCosignSBOMType(t *testing.T) {
+	tests := map[string]string{
+		`{"bomFormat":"CycloneDX","specVersion":"1.5"}`:          "cyclonedx",
+		`{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT"}`: "spdx",
+		`{"artifacts":[],"descriptor":{"name":"syft"}}`:          "syft",
+		`not json`: "spdx",
+	}
+	for content, expected := range tests {
+		if got := cosignSBOMType([]byte(content)); got != expected {
+			t.Errorf("Expected type %s for %s, got %s", expected, content, got)
+		}
+	}
+}
+
+func TestDockerConfigFile(t *testing.T) {
+	env := map[string]string{"HOME": "/home/dev"}
+	getenv := func(name string) string { return env[name] }
+	if got := dockerConfigFile(getenv); got != "/home/dev/.docker/config.json" {
+		t.Errorf("Expected the default Docker config, got %s", got)
+	}
+
+	env["DOCKER_CONFIG"] = "/ci/docker"
+	if got := dockerConfigFile(getenv); got != "/ci/docker/config.json" {
+		t.Errorf("Expected DOCKER_CONFIG to be honored, got %s", got)
+	}
+}
+
+func TestCosignDownloadSBOMArgs(t *testing.T) {
+	args := cosignDownloadSBOMArgs("ghcr.io/org/app@sha256:abc")
+	expected := []string{cosignBinary, "download", "sbom", "ghcr.io/org/app@sha256:abc"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
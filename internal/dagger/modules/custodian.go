@@ -24,9 +24,8 @@ func NewCustodianModule(client *dagger.Client) *CustodianModule {
 
 // RunPolicy runs a custodian policy
 func (m *CustodianModule) RunPolicy(ctx context.Context, policyPath string, outputDir string) (string, error) {
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
 		WithExec([]string{
 			"sh", "-c",
 			"/src/.venv/bin/custodian run -s /output /policy.yml 2>&1",
@@ -44,9 +43,8 @@ func (m *CustodianModule) RunPolicy(ctx context.Context, policyPath string, outp
 func (m *CustodianModule) ValidatePolicy(ctx context.Context, policyPath string) (string, error) {
 	// Use a wrapper script to capture both stdout and stderr, and only fail on actual validation errors
 	// This works around Dagger's issue with stderr output causing failures
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
 		WithExec([]string{
 			"sh", "-c", 
 			`output=$(/src/.venv/bin/custodian validate /policy.yml 2>&1); echo "$output"; if echo "$output" | grep -q "Configuration invalid"; then exit 1; else exit 0; fi`,
@@ -80,9 +78,8 @@ func (m *CustodianModule) ValidatePolicy(ctx context.Context, policyPath string)
 
 // DryRun performs a dry run of a policy
 func (m *CustodianModule) DryRun(ctx context.Context, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
 		WithExec([]string{
 			"sh", "-c",
 			"/src/.venv/bin/custodian run --dryrun -s /output /policy.yml 2>&1",
@@ -105,8 +102,7 @@ func (m *CustodianModule) Schema(ctx context.Context, resourceType string) (stri
 	}
 	cmd += " 2>&1"
 
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
 		WithExec([]string{"sh", "-c", cmd})
 
 	output, err := container.Stdout(ctx)
@@ -120,8 +116,7 @@ func (m *CustodianModule) Schema(ctx context.Context, resourceType string) (stri
 // GetVersion returns the version of Cloud Custodian
 func (m *CustodianModule) GetVersion(ctx context.Context) (string, error) {
 	// Use wrapper script to handle stderr output
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
 		WithExec([]string{
 			"sh", "-c", 
 			"/src/.venv/bin/custodian version 2>&1",
@@ -147,10 +142,9 @@ func (m *CustodianModule) Report(ctx context.Context, policyPath string, outputD
 	
 	cmd += " /policy.yml 2>&1"
 
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
-		WithDirectory("/output", m.client.Host().Directory(outputDir)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
+		WithDirectory("/output", hostDirectory(m.client, outputDir)).
 		WithExec([]string{"sh", "-c", cmd})
 
 	output, err := container.Stdout(ctx)
@@ -163,10 +157,9 @@ func (m *CustodianModule) Report(ctx context.Context, policyPath string, outputD
 
 // Logs retrieves logs for a specific policy
 func (m *CustodianModule) Logs(ctx context.Context, policyPath string, outputDir string) (string, error) {
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
-		WithDirectory("/output", m.client.Host().Directory(outputDir)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
+		WithDirectory("/output", hostDirectory(m.client, outputDir)).
 		WithExec([]string{
 			"sh", "-c",
 			"/src/.venv/bin/custodian logs -s /output /policy.yml 2>&1",
@@ -195,10 +188,9 @@ func (m *CustodianModule) Metrics(ctx context.Context, policyPath string, output
 	
 	cmd += " /policy.yml 2>&1"
 
-	container := m.client.Container().
-		From("cloudcustodian/c7n:latest").
-		WithFile("/policy.yml", m.client.Host().File(policyPath)).
-		WithDirectory("/output", m.client.Host().Directory(outputDir)).
+	container := toolContainer(m.client, "cloudcustodian/c7n:latest").
+		WithFile("/policy.yml", hostFile(m.client, policyPath)).
+		WithDirectory("/output", hostDirectory(m.client, outputDir)).
 		WithExec([]string{"sh", "-c", cmd})
 
 	output, err := container.Stdout(ctx)
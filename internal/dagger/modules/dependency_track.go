@@ -27,10 +27,9 @@ func NewDependencyTrackModule(client *dagger.Client) *DependencyTrackModule {
 
 // ScanSBOM scans a Software Bill of Materials (SBOM) file using dtrack-cli
 func (m *DependencyTrackModule) ScanSBOM(ctx context.Context, sbomPath string) (string, error) {
-	sbomFile := m.Client.Host().File(sbomPath)
+	sbomFile := hostFile(m.Client, sbomPath)
 	
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithFile("/app/sbom.json", sbomFile).
 		WithExec([]string{
@@ -45,11 +44,10 @@ func (m *DependencyTrackModule) ScanSBOM(ctx context.Context, sbomPath string) (
 
 // AnalyzeProject generates and uploads SBOM for a project directory
 func (m *DependencyTrackModule) AnalyzeProject(ctx context.Context, projectPath string, projectName string, projectVersion string) (string, error) {
-	projectDir := m.Client.Host().Directory(projectPath)
+	projectDir := hostDirectory(m.Client, projectPath)
 	
 	// First generate SBOM using syft, then upload to Dependency Track
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithExec([]string{"sh", "-c", "curl -sSfL https://raw.githubusercontent.com/anchore/syft/main/install.sh | sh -s -- -b /usr/local/bin"}).
@@ -69,8 +67,7 @@ func (m *DependencyTrackModule) AnalyzeProject(ctx context.Context, projectPath
 
 // GenerateReport generates a vulnerability report using dtrack-cli (requires existing project)
 func (m *DependencyTrackModule) GenerateReport(ctx context.Context, projectName string, projectVersion string) (string, error) {
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithExec([]string{
 			"sh", "-c", 
@@ -82,10 +79,9 @@ func (m *DependencyTrackModule) GenerateReport(ctx context.Context, projectName
 
 // ValidateComponents uploads SBOM for validation (policy evaluation happens server-side)
 func (m *DependencyTrackModule) ValidateComponents(ctx context.Context, projectPath string, projectName string, projectVersion string) (string, error) {
-	projectDir := m.Client.Host().Directory(projectPath)
+	projectDir := hostDirectory(m.Client, projectPath)
 	
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithExec([]string{"sh", "-c", "curl -sSfL https://raw.githubusercontent.com/anchore/syft/main/install.sh | sh -s -- -b /usr/local/bin"}).
@@ -105,10 +101,9 @@ func (m *DependencyTrackModule) ValidateComponents(ctx context.Context, projectP
 
 // TrackDependencies uploads SBOM to track dependencies (tracking happens server-side)
 func (m *DependencyTrackModule) TrackDependencies(ctx context.Context, projectPath string, projectName string, projectVersion string) (string, error) {
-	projectDir := m.Client.Host().Directory(projectPath)
+	projectDir := hostDirectory(m.Client, projectPath)
 	
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithExec([]string{"sh", "-c", "curl -sSfL https://raw.githubusercontent.com/anchore/syft/main/install.sh | sh -s -- -b /usr/local/bin"}).
@@ -128,7 +123,7 @@ func (m *DependencyTrackModule) TrackDependencies(ctx context.Context, projectPa
 
 // UploadBOM uploads BOM file to Dependency Track using dtrack-cli
 func (m *DependencyTrackModule) UploadBOM(ctx context.Context, bomPath string, projectName string, projectVersion string, serverURL string, apiKey string) (string, error) {
-	bomFile := m.Client.Host().File(bomPath)
+	bomFile := hostFile(m.Client, bomPath)
 	
 	args := []string{
 		"dtrack-cli",
@@ -147,8 +142,7 @@ func (m *DependencyTrackModule) UploadBOM(ctx context.Context, bomPath string, p
 		args = append(args, "--api-key", apiKey)
 	}
 
-	result := m.Client.Container().
-		From("node:alpine").
+	result := toolContainer(m.Client, "node:alpine").
 		WithExec([]string{"npm", "install", "-g", "@fjbarrena/dtrack-cli"}).
 		WithFile("/app/bom.json", bomFile).
 		WithExec(args)
@@ -158,7 +152,7 @@ func (m *DependencyTrackModule) UploadBOM(ctx context.Context, bomPath string, p
 
 // UploadBOMAPI uploads BOM to Dependency Track via REST API using curl
 func (m *DependencyTrackModule) UploadBOMAPI(ctx context.Context, bomPath string, serverURL string, apiKey string, projectName string, projectVersion string, autoCreate bool) (string, error) {
-	bomFile := m.Client.Host().File(bomPath)
+	bomFile := hostFile(m.Client, bomPath)
 	
 	args := []string{
 		"curl", "-X", "POST", serverURL + "/api/v1/bom",
@@ -177,8 +171,7 @@ func (m *DependencyTrackModule) UploadBOMAPI(ctx context.Context, bomPath string
 		args = append(args, "-F", "autoCreate=true")
 	}
 
-	result := m.Client.Container().
-		From("alpine:latest").
+	result := toolContainer(m.Client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithFile("/app/bom.json", bomFile).
 		WithExec(args)
@@ -188,52 +181,46 @@ func (m *DependencyTrackModule) UploadBOMAPI(ctx context.Context, bomPath string
 
 // GenerateBOM generates CycloneDX BOM using various build tools
 func (m *DependencyTrackModule) GenerateBOM(ctx context.Context, projectType string, projectPath string, outputFile string) (string, error) {
-	projectDir := m.Client.Host().Directory(projectPath)
+	projectDir := hostDirectory(m.Client, projectPath)
 	
 	var result *dagger.Container
 	
 	switch projectType {
 	case "npm":
-		result = m.Client.Container().
-			From("node:alpine").
+		result = toolContainer(m.Client, "node:alpine").
 			WithExec([]string{"npm", "install", "-g", "@cyclonedx/cyclonedx-npm"}).
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
 			WithExec([]string{cyclonedxNpmBinary, "-o", "bom.json"})
 		
 	case "maven":
-		result = m.Client.Container().
-			From("maven:3-openjdk-11").
+		result = toolContainer(m.Client, "maven:3-openjdk-11").
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
 			WithExec([]string{"mvn", "org.cyclonedx:cyclonedx-maven-plugin:makeBom"})
 		
 	case "gradle":
-		result = m.Client.Container().
-			From("gradle:jdk11").
+		result = toolContainer(m.Client, "gradle:jdk11").
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
 			WithExec([]string{"gradle", "cyclonedxBom"})
 		
 	case "pip":
-		result = m.Client.Container().
-			From("python:3.9-alpine").
+		result = toolContainer(m.Client, "python:3.9-alpine").
 			WithExec([]string{"pip", "install", "cyclonedx-bom"}).
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
 			WithExec([]string{cyclonedxPyBinary, "-o", "bom.json"})
 		
 	case "composer":
-		result = m.Client.Container().
-			From("composer:latest").
+		result = toolContainer(m.Client, "composer:latest").
 			WithExec([]string{"composer", "global", "require", "cyclonedx/cyclonedx-php-composer"}).
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
 			WithExec([]string{"cyclonedx-php", "composer"})
 		
 	case "dotnet":
-		result = m.Client.Container().
-			From("mcr.microsoft.com/dotnet/sdk:6.0").
+		result = toolContainer(m.Client, "mcr.microsoft.com/dotnet/sdk:6.0").
 			WithExec([]string{"dotnet", "tool", "install", "--global", "CycloneDX"}).
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
@@ -241,8 +228,7 @@ func (m *DependencyTrackModule) GenerateBOM(ctx context.Context, projectType str
 		
 	default:
 		// Default to npm
-		result = m.Client.Container().
-			From("node:alpine").
+		result = toolContainer(m.Client, "node:alpine").
 			WithExec([]string{"npm", "install", "-g", "@cyclonedx/cyclonedx-npm"}).
 			WithDirectory("/app/project", projectDir).
 			WithWorkdir("/app/project").
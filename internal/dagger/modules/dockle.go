@@ -3,6 +3,7 @@ package modules
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -11,6 +12,13 @@ type DockleModule struct {
 	client *dagger.Client
 }
 
+const (
+	// dockleCacheVolumeKey is kept stable so repeated scans share one cache
+	dockleCacheVolumeKey = "ship-dockle-cache"
+
+	dockleDefaultCacheDir = "/root/.cache/dockle"
+)
+
 func NewDockleModule(client *dagger.Client) *DockleModule {
 	return &DockleModule{
 		client: client,
@@ -19,8 +27,7 @@ func NewDockleModule(client *dagger.Client) *DockleModule {
 
 // GetVersion returns the version of Dockle
 func (m *DockleModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14").
+	container := toolContainer(m.client, "goodwithtech/dockle:v0.4.14").
 		WithExec([]string{"dockle", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -49,8 +56,7 @@ func (m *DockleModule) ScanImage(ctx context.Context, imageRef string, opts ...D
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14")
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config)
 
 	args := []string{"dockle"}
 
@@ -103,12 +109,11 @@ func (m *DockleModule) ScanTarball(ctx context.Context, tarballPath string, opts
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14")
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config)
 
 	// Mount tarball file
 	if tarballPath != "" {
-		container = container.WithMountedFile("/workspace/image.tar", m.client.Host().File(tarballPath))
+		container = container.WithMountedFile("/workspace/image.tar", hostFile(m.client, tarballPath))
 	}
 
 	args := []string{"dockle"}
@@ -157,12 +162,11 @@ func (m *DockleModule) ScanDockerfile(ctx context.Context, dockerfilePath string
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14")
+	container := toolContainer(m.client, "goodwithtech/dockle:v0.4.14")
 
 	// Mount Dockerfile
 	if dockerfilePath != "" {
-		container = container.WithMountedFile("/workspace/Dockerfile", m.client.Host().File(dockerfilePath))
+		container = container.WithMountedFile("/workspace/Dockerfile", hostFile(m.client, dockerfilePath))
 	}
 
 	args := []string{"dockle"}
@@ -202,8 +206,7 @@ func (m *DockleModule) ScanDockerfile(ctx context.Context, dockerfilePath string
 
 // ListChecks lists all available Dockle security checks
 func (m *DockleModule) ListChecks(ctx context.Context) (*dagger.Container, error) {
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14")
+	container := toolContainer(m.client, "goodwithtech/dockle:v0.4.14")
 
 	return container.WithExec([]string{"dockle", "--help"}, dagger.ContainerWithExecOpts{
 		Expect: "ANY",
@@ -221,12 +224,11 @@ func (m *DockleModule) ScanWithPolicy(ctx context.Context, imageRef string, poli
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14")
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config)
 
 	// Mount policy file
 	if policyPath != "" {
-		container = container.WithMountedFile("/workspace/.dockleignore", m.client.Host().File(policyPath))
+		container = container.WithMountedFile("/workspace/.dockleignore", hostFile(m.client, policyPath))
 	}
 
 	args := []string{"dockle"}
@@ -255,9 +257,13 @@ func (m *DockleModule) ScanWithPolicy(ctx context.Context, imageRef string, poli
 }
 
 // ScanImageString scans a container image and returns string output (MCP compatible)
-func (m *DockleModule) ScanImageString(ctx context.Context, imageRef string) (string, error) {
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14").
+func (m *DockleModule) ScanImageString(ctx context.Context, imageRef string, opts ...DockleOption) (string, error) {
+	config := &DockleConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config).
 		WithExec([]string{"dockle", imageRef}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -276,11 +282,15 @@ func (m *DockleModule) ScanImageString(ctx context.Context, imageRef string) (st
 }
 
 // ScanTarballString scans a container image tarball and returns string output (MCP compatible)
-func (m *DockleModule) ScanTarballString(ctx context.Context, tarballPath string) (string, error) {
-	tarballFile := m.client.Host().File(tarballPath)
+func (m *DockleModule) ScanTarballString(ctx context.Context, tarballPath string, opts ...DockleOption) (string, error) {
+	config := &DockleConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	tarballFile := hostFile(m.client, tarballPath)
 	
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14").
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config).
 		WithFile("/workspace/image.tar", tarballFile).
 		WithExec([]string{"dockle", "--input", "/workspace/image.tar"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -300,7 +310,12 @@ func (m *DockleModule) ScanTarballString(ctx context.Context, tarballPath string
 }
 
 // ScanImageJSON scans a container image and returns JSON output (MCP compatible)
-func (m *DockleModule) ScanImageJSON(ctx context.Context, imageRef string, outputFile string) (string, error) {
+func (m *DockleModule) ScanImageJSON(ctx context.Context, imageRef string, outputFile string, opts ...DockleOption) (string, error) {
+	config := &DockleConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
 	args := []string{"dockle", "-f", "json"}
 	
 	if outputFile != "" {
@@ -308,8 +323,7 @@ func (m *DockleModule) ScanImageJSON(ctx context.Context, imageRef string, outpu
 	}
 	args = append(args, imageRef)
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14").
+	container := m.withDockleEnv(toolContainer(m.client, "goodwithtech/dockle:v0.4.14"), config).
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -329,7 +343,7 @@ func (m *DockleModule) ScanImageJSON(ctx context.Context, imageRef string, outpu
 
 // ScanTarballJSON scans a container image tarball and returns JSON output (MCP compatible)
 func (m *DockleModule) ScanTarballJSON(ctx context.Context, tarballPath string, outputFile string) (string, error) {
-	tarballFile := m.client.Host().File(tarballPath)
+	tarballFile := hostFile(m.client, tarballPath)
 	
 	args := []string{"dockle", "-f", "json", "--input", "/workspace/image.tar"}
 	
@@ -337,8 +351,7 @@ func (m *DockleModule) ScanTarballJSON(ctx context.Context, tarballPath string,
 		args = append(args, "-o", outputFile)
 	}
 
-	container := m.client.Container().
-		From("goodwithtech/dockle:v0.4.14").
+	container := toolContainer(m.client, "goodwithtech/dockle:v0.4.14").
 		WithFile("/workspace/image.tar", tarballFile).
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -357,6 +370,63 @@ func (m *DockleModule) ScanTarballJSON(ctx context.Context, tarballPath string,
 	return "", fmt.Errorf("failed to scan tarball with JSON output: no output received")
 }
 
+// withDockleEnv applies registry credentials, runtime env and the metadata
+// cache to the container. Credentials are passed as Dagger secrets so they
+// never appear in the exec args or logs.
+func (m *DockleModule) withDockleEnv(container *dagger.Container, config *DockleConfig) *dagger.Container {
+	for name, value := range dockleSecretEnv(config) {
+		container = container.WithSecretVariable(name, m.client.SetSecret(secretName(strings.ToLower(name), value), value))
+	}
+	for name, value := range dockleEnv(config) {
+		container = container.WithEnvVariable(name, value)
+	}
+	if key, path, ok := dockleCacheMount(config); ok {
+		container = container.WithMountedCache(path, m.client.CacheVolume(key))
+	}
+	return container
+}
+
+// dockleCacheMount returns the cache volume key and mount path, and whether
+// a cache should be attached at all. The volume lives in the Dagger engine,
+// so the key is what callers choose to share or separate caches by.
+func dockleCacheMount(config *DockleConfig) (string, string, bool) {
+	if config.NoCache {
+		return "", "", false
+	}
+	key := config.CacheKey
+	if key == "" {
+		key = dockleCacheVolumeKey
+	}
+	return key, dockleDefaultCacheDir, true
+}
+
+// dockleSecretEnv returns the registry credential environment variables
+func dockleSecretEnv(config *DockleConfig) map[string]string {
+	env := map[string]string{}
+	if config.Username != "" {
+		env["DOCKLE_USERNAME"] = config.Username
+	}
+	if config.Password != "" {
+		env["DOCKLE_PASSWORD"] = config.Password
+	}
+	return env
+}
+
+// dockleEnv returns the non-secret environment variables for a Dockle run
+func dockleEnv(config *DockleConfig) map[string]string {
+	env := map[string]string{}
+	if config.Insecure {
+		env["DOCKLE_INSECURE"] = "true"
+	}
+	if config.Offline {
+		env["DOCKLE_OFFLINE"] = "true"
+	}
+	if _, path, ok := dockleCacheMount(config); ok {
+		env["DOCKLE_CACHE_DIR"] = path
+	}
+	return env
+}
+
 type DockleConfig struct {
 	Format     string
 	Output     string
@@ -364,6 +434,12 @@ type DockleConfig struct {
 	AcceptKey  []string
 	AcceptFile []string
 	Ignore     []string
+	Username   string
+	Password   string
+	Insecure   bool
+	CacheKey   string
+	NoCache    bool
+	Offline    bool
 }
 
 type DockleOption func(*DockleConfig)
@@ -402,4 +478,42 @@ func WithDockleIgnore(ignores []string) DockleOption {
 	return func(c *DockleConfig) {
 		c.Ignore = ignores
 	}
-}
\ No newline at end of file
+}
+
+// WithDockleRegistryAuth sets credentials for pulling from a private registry
+func WithDockleRegistryAuth(username, password string) DockleOption {
+	return func(c *DockleConfig) {
+		c.Username = username
+		c.Password = password
+	}
+}
+
+// WithDockleInsecure allows insecure (HTTP or self-signed) registry connections
+func WithDockleInsecure(insecure bool) DockleOption {
+	return func(c *DockleConfig) {
+		c.Insecure = insecure
+	}
+}
+
+// WithDockleCacheKey names the metadata cache volume, so separate projects can
+// keep separate caches
+func WithDockleCacheKey(key string) DockleOption {
+	return func(c *DockleConfig) {
+		c.CacheKey = key
+	}
+}
+
+// WithDockleNoCache disables the metadata cache volume
+func WithDockleNoCache(noCache bool) DockleOption {
+	return func(c *DockleConfig) {
+		c.NoCache = noCache
+	}
+}
+
+// WithDockleOffline runs Dockle from the cached metadata without contacting
+// the network for updates
+func WithDockleOffline(offline bool) DockleOption {
+	return func(c *DockleConfig) {
+		c.Offline = offline
+	}
+}
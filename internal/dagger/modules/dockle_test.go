@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDockleSecretEnv(t *testing.T) {
+	config := &DockleConfig{}
+	WithDockleRegistryAuth("robot", "s3cret")(config)
+
+	expected := map[string]string{
+		"DOCKLE_USERNAME": "robot",
+		"DOCKLE_PASSWORD": "s3cret",
+	}
+	if got := dockleSecretEnv(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected secret env %v, got %v", expected, got)
+	}
+
+	// Credentials must never leak into plain env variables
+	for name, value := range dockleEnv(config) {
+		if strings.Contains(value, "s3cret") || strings.Contains(name, "PASSWORD") {
+			t.Errorf("Credential leaked into plain env: %s=%s", name, value)
+		}
+	}
+}
+
+func TestDockleSecretEnv_NoCredentials(t *testing.T) {
+	if got := dockleSecretEnv(&DockleConfig{}); len(got) != 0 {
+		t.Errorf("Expected no secret env without credentials, got %v", got)
+	}
+}
+
+func TestDockleEnv_Insecure(t *testing.T) {
+	config := &DockleConfig{NoCache: true}
+	if got := dockleEnv(config); len(got) != 0 {
+		t.Errorf("Expected no env by default, got %v", got)
+	}
+
+	WithDockleInsecure(true)(config)
+	if got := dockleEnv(config)["DOCKLE_INSECURE"]; got != "true" {
+		t.Errorf("Expected DOCKLE_INSECURE=true, got %q", got)
+	}
+}
+
+func TestDockleCacheMount(t *testing.T) {
+	key, path, ok := dockleCacheMount(&DockleConfig{})
+	if !ok {
+		t.Fatal("Expected cache to be attached by default")
+	}
+	if key != "ship-dockle-cache" || path != dockleDefaultCacheDir {
+		t.Errorf("Expected stable key and default path, got key=%q path=%q", key, path)
+	}
+
+	config := &DockleConfig{}
+	WithDockleCacheKey("project-a")(config)
+	key2, path2, _ := dockleCacheMount(config)
+	if key2 != "project-a" {
+		t.Errorf("Expected custom cache key, got %q", key2)
+	}
+	if path2 != dockleDefaultCacheDir {
+		t.Errorf("Expected cache path to stay fixed, got %q", path2)
+	}
+	if got := dockleEnv(config)["DOCKLE_CACHE_DIR"]; got != dockleDefaultCacheDir {
+		t.Errorf("Expected DOCKLE_CACHE_DIR=%s, got %q", dockleDefaultCacheDir, got)
+	}
+
+	WithDockleNoCache(true)(config)
+	if _, _, ok := dockleCacheMount(config); ok {
+		t.Error("Expected no cache when disabled")
+	}
+	if _, exists := dockleEnv(config)["DOCKLE_CACHE_DIR"]; exists {
+		t.Error("Expected DOCKLE_CACHE_DIR to be unset when cache is disabled")
+	}
+}
+
+func TestDockleEnv_Offline(t *testing.T) {
+	config := &DockleConfig{}
+	if _, exists := dockleEnv(config)["DOCKLE_OFFLINE"]; exists {
+		t.Error("Expected DOCKLE_OFFLINE to be unset by default")
+	}
+
+	WithDockleOffline(true)(config)
+	if got := dockleEnv(config)["DOCKLE_OFFLINE"]; got != "true" {
+		t.Errorf("Expected DOCKLE_OFFLINE=true, got %q", got)
+	}
+}
@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"dagger.io/dagger"
 )
@@ -13,7 +15,15 @@ type FalcoModule struct {
 	name   string
 }
 
-const falcoBinary = "falco"
+const (
+	falcoBinary = "falco"
+
+	// falcoShutdownGrace is how long Falco gets to flush alerts after SIGINT
+	falcoShutdownGrace = 10 * time.Second
+
+	// falcoStartupGrace covers image pull and driver load on top of a bounded run
+	falcoStartupGrace = 2 * time.Minute
+)
 
 // NewFalcoModule creates a new Falco module
 func NewFalcoModule(client *dagger.Client) *FalcoModule {
@@ -25,11 +35,10 @@ func NewFalcoModule(client *dagger.Client) *FalcoModule {
 
 // RunWithDefaultRules runs Falco with default rules
 func (m *FalcoModule) RunWithDefaultRules(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -48,12 +57,11 @@ func (m *FalcoModule) RunWithDefaultRules(ctx context.Context, kubeconfig string
 
 // RunWithCustomRules runs Falco with custom rules
 func (m *FalcoModule) RunWithCustomRules(ctx context.Context, rulesPath string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
-		WithDirectory("/etc/falco/rules.d", m.client.Host().Directory(rulesPath))
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
+		WithDirectory("/etc/falco/rules.d", hostDirectory(m.client, rulesPath))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -73,9 +81,8 @@ func (m *FalcoModule) RunWithCustomRules(ctx context.Context, rulesPath string,
 
 // ValidateRules validates Falco rules syntax
 func (m *FalcoModule) ValidateRules(ctx context.Context, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
-		WithDirectory("/rules", m.client.Host().Directory(rulesPath)).
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
+		WithDirectory("/rules", hostDirectory(m.client, rulesPath)).
 		WithExec([]string{
 			falcoBinary,
 			"--validate", "/rules",
@@ -92,16 +99,15 @@ func (m *FalcoModule) ValidateRules(ctx context.Context, rulesPath string) (stri
 
 // DryRun performs dry run without monitoring
 func (m *FalcoModule) DryRun(ctx context.Context, rulesPath string, configPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "--dry-run"}
 	if configPath != "" {
-		container = container.WithFile("/etc/falco/falco.yaml", m.client.Host().File(configPath))
+		container = container.WithFile("/etc/falco/falco.yaml", hostFile(m.client, configPath))
 		args = append(args, "--config", "/etc/falco/falco.yaml")
 	}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "--rules", "/etc/falco/custom_rules.yaml")
 	}
 
@@ -117,8 +123,7 @@ func (m *FalcoModule) DryRun(ctx context.Context, rulesPath string, configPath s
 
 // ListFields lists available fields for Falco rules
 func (m *FalcoModule) ListFields(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
 		WithExec([]string{"falco", "--list"})
 
 	output, err := container.Stdout(ctx)
@@ -131,12 +136,11 @@ func (m *FalcoModule) ListFields(ctx context.Context) (string, error) {
 
 // ListRules lists all loaded Falco rules
 func (m *FalcoModule) ListRules(ctx context.Context, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "--list-rules"}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "--rules", "/etc/falco/custom_rules.yaml")
 	}
 
@@ -159,12 +163,11 @@ func (m *FalcoModule) ListRules(ctx context.Context, rulesPath string) (string,
 
 // DescribeRule describes a specific Falco rule
 func (m *FalcoModule) DescribeRule(ctx context.Context, ruleName string, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "--describe-rule", ruleName}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "--rules", "/etc/falco/custom_rules.yaml")
 	}
 
@@ -180,8 +183,7 @@ func (m *FalcoModule) DescribeRule(ctx context.Context, ruleName string, rulesPa
 
 // GetVersion returns the version of Falco
 func (m *FalcoModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
 		WithExec([]string{"falco", "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -192,26 +194,46 @@ func (m *FalcoModule) GetVersion(ctx context.Context) (string, error) {
 	return output, nil
 }
 
-// StartMonitoring starts Falco runtime security monitoring (MCP compatible)
-func (m *FalcoModule) StartMonitoring(ctx context.Context, configPath string, rulesPath string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+// StartMonitoring starts Falco runtime security monitoring (MCP compatible).
+// With WithFalcoDuration the run is bounded and returns the alerts captured in that window.
+func (m *FalcoModule) StartMonitoring(ctx context.Context, configPath string, rulesPath string, outputFormat string, opts ...FalcoOption) (string, error) {
+	config := &FalcoConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Duration+falcoShutdownGrace+falcoStartupGrace)
+		defer cancel()
+	}
+
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco"}
 	
 	if configPath != "" {
-		container = container.WithFile("/etc/falco/custom_falco.yaml", m.client.Host().File(configPath))
+		container = container.WithFile("/etc/falco/custom_falco.yaml", hostFile(m.client, configPath))
 		args = append(args, "-c", "/etc/falco/custom_falco.yaml")
 	}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "-r", "/etc/falco/custom_rules.yaml")
 	}
-	if outputFormat == "json" {
-		args = append(args, "-o", "json_output=true")
+	formatArgs, err := falcoFormatArgs(outputFormat)
+	if err != nil {
+		return "", err
 	}
+	args = append(args, formatArgs...)
 
-	container = container.WithExec(args)
+	if config.Duration > 0 {
+		// A bounded run ends with SIGINT from timeout, which exits non-zero
+		container = container.WithExec(falcoBoundedArgs(args, config.Duration), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+	} else {
+		container = container.WithExec(args)
+	}
 
 	output, err := container.Stdout(ctx)
 	if err != nil {
@@ -221,12 +243,60 @@ func (m *FalcoModule) StartMonitoring(ctx context.Context, configPath string, ru
 	return output, nil
 }
 
+// falcoBoundedArgs wraps a falco command so it receives SIGINT after duration,
+// letting Falco shut down gracefully and flush captured alerts
+func falcoBoundedArgs(args []string, duration time.Duration) []string {
+	seconds := int(duration.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	bounded := []string{"timeout",
+		"--signal=INT",
+		"--kill-after=" + strconv.Itoa(int(falcoShutdownGrace/time.Second)) + "s",
+		strconv.Itoa(seconds) + "s",
+	}
+	return append(bounded, args...)
+}
+
+// falcoFormatArgs returns the falco arguments for the requested alert format.
+// Text is Falco's default, so it needs no arguments.
+func falcoFormatArgs(format string) ([]string, error) {
+	switch format {
+	case "", "text":
+		return nil, nil
+	case "json":
+		return []string{"-o", "json_output=true"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported falco output format %q (use text or json)", format)
+	}
+}
+
+// falcoValidateArgs builds the falco command for rule validation
+func falcoValidateArgs(rulesFile string) []string {
+	return []string{falcoBinary, "-V", rulesFile}
+}
+
+// FalcoConfig holds the settings for a Falco run
+type FalcoConfig struct {
+	Duration time.Duration
+}
+
+// FalcoOption sets a field of FalcoConfig
+type FalcoOption func(*FalcoConfig)
+
+// WithFalcoDuration stops monitoring after the given duration
+func WithFalcoDuration(duration time.Duration) FalcoOption {
+	return func(c *FalcoConfig) {
+		c.Duration = duration
+	}
+}
+
 // ValidateRulesSimple validates Falco rules syntax (MCP compatible)
 func (m *FalcoModule) ValidateRulesSimple(ctx context.Context, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
-		WithFile("/etc/falco/rules_to_validate.yaml", m.client.Host().File(rulesPath)).
-		WithExec([]string{"falco", "-V", "/etc/falco/rules_to_validate.yaml"})
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
+		WithFile("/etc/falco/rules_to_validate.yaml", hostFile(m.client, rulesPath)).
+		WithExec(falcoValidateArgs("/etc/falco/rules_to_validate.yaml"))
 
 	output, err := container.Stdout(ctx)
 	if err != nil {
@@ -238,17 +308,16 @@ func (m *FalcoModule) ValidateRulesSimple(ctx context.Context, rulesPath string)
 
 // DryRunSimple performs dry run without monitoring (MCP compatible)
 func (m *FalcoModule) DryRunSimple(ctx context.Context, configPath string, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "--dry-run"}
 	
 	if configPath != "" {
-		container = container.WithFile("/etc/falco/custom_falco.yaml", m.client.Host().File(configPath))
+		container = container.WithFile("/etc/falco/custom_falco.yaml", hostFile(m.client, configPath))
 		args = append(args, "-c", "/etc/falco/custom_falco.yaml")
 	}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "-r", "/etc/falco/custom_rules.yaml")
 	}
 
@@ -270,8 +339,7 @@ func (m *FalcoModule) ListFieldsWithSource(ctx context.Context, source string) (
 		args = append(args, source)
 	}
 
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -284,8 +352,7 @@ func (m *FalcoModule) ListFieldsWithSource(ctx context.Context, source string) (
 
 // GetVersionSimple returns the version of Falco (MCP compatible)
 func (m *FalcoModule) GetVersionSimple(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest").
+	container := toolContainer(m.client, "falcosecurity/falco:latest").
 		WithExec([]string{"falco", "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -298,12 +365,11 @@ func (m *FalcoModule) GetVersionSimple(ctx context.Context) (string, error) {
 
 // ListRulesSimple lists all loaded Falco rules (MCP compatible)
 func (m *FalcoModule) ListRulesSimple(ctx context.Context, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "-L"}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "-r", "/etc/falco/custom_rules.yaml")
 	}
 
@@ -319,12 +385,11 @@ func (m *FalcoModule) ListRulesSimple(ctx context.Context, rulesPath string) (st
 
 // DescribeRuleSimple describes a specific Falco rule (MCP compatible)
 func (m *FalcoModule) DescribeRuleSimple(ctx context.Context, ruleName string, rulesPath string) (string, error) {
-	container := m.client.Container().
-		From("falcosecurity/falco:latest")
+	container := toolContainer(m.client, "falcosecurity/falco:latest")
 
 	args := []string{"falco", "-l", ruleName}
 	if rulesPath != "" {
-		container = container.WithFile("/etc/falco/custom_rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/etc/falco/custom_rules.yaml", hostFile(m.client, rulesPath))
 		args = append(args, "-r", "/etc/falco/custom_rules.yaml")
 	}
 
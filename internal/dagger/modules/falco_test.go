@@ -0,0 +1,58 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFalcoBoundedArgs(t *testing.T) {
+	args := []string{"falco", "-o", "json_output=true"}
+
+	got := falcoBoundedArgs(args, 30*time.Second)
+	expected := []string{"timeout", "--signal=INT", "--kill-after=10s", "30s", "falco", "-o", "json_output=true"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestFalcoBoundedArgs_SubSecond(t *testing.T) {
+	got := falcoBoundedArgs([]string{"falco"}, 100*time.Millisecond)
+	if got[3] != "1s" {
+		t.Errorf("Expected sub-second duration to round up to 1s, got %q", got[3])
+	}
+}
+
+func TestFalcoValidateArgs_Unbounded(t *testing.T) {
+	expected := []string{"falco", "-V", "/etc/falco/rules_to_validate.yaml"}
+	if got := falcoValidateArgs("/etc/falco/rules_to_validate.yaml"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected validate args %v, got %v", expected, got)
+	}
+}
+
+func TestFalcoFormatArgs(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{"", nil},
+		{"text", nil},
+		{"json", []string{"-o", "json_output=true"}},
+	}
+
+	for _, tt := range tests {
+		got, err := falcoFormatArgs(tt.format)
+		if err != nil {
+			t.Fatalf("Unexpected error for format %q: %v", tt.format, err)
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Format %q: expected %v, got %v", tt.format, tt.expected, got)
+		}
+	}
+}
+
+func TestFalcoFormatArgs_Unsupported(t *testing.T) {
+	if _, err := falcoFormatArgs("xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -25,11 +25,10 @@ func NewFleetModule(client *dagger.Client) *FleetModule {
 
 // GetClusters lists Fleet clusters
 func (m *FleetModule) GetClusters(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("rancher/fleet:v0.10.4")
+	container := toolContainer(m.client, "rancher/fleet:v0.10.4")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -47,11 +46,10 @@ func (m *FleetModule) GetClusters(ctx context.Context, kubeconfig string) (strin
 
 // GetGitRepos lists Git repositories managed by Fleet
 func (m *FleetModule) GetGitRepos(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("rancher/fleet:v0.10.4")
+	container := toolContainer(m.client, "rancher/fleet:v0.10.4")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -81,12 +79,11 @@ spec:
   - %s
 `, name, repoURL, branch, path)
 
-	container := m.client.Container().
-		From("rancher/fleet:v0.10.4").
+	container := toolContainer(m.client, "rancher/fleet:v0.10.4").
 		WithNewFile("/gitrepo.yaml", gitRepoYAML)
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -112,11 +109,10 @@ func (m *FleetModule) GetBundles(ctx context.Context, kubeconfig string, namespa
 	}
 	args = append(args, "--output", "json")
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args)
@@ -139,11 +135,10 @@ func (m *FleetModule) GetBundleDeployments(ctx context.Context, kubeconfig strin
 	}
 	args = append(args, "--output", "json")
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args)
@@ -163,11 +158,10 @@ func (m *FleetModule) DescribeGitRepo(ctx context.Context, name string, namespac
 		args = append(args, "-n", namespace)
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args)
@@ -186,11 +180,10 @@ func (m *FleetModule) Install(ctx context.Context, namespace string, kubeconfig
 		namespace = "cattle-fleet-system"
 	}
 
-	container := m.client.Container().
-		From("alpine/helm:latest")
+	container := toolContainer(m.client, "alpine/helm:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -211,15 +204,14 @@ func (m *FleetModule) Install(ctx context.Context, namespace string, kubeconfig
 
 // ApplyGitRepo applies Fleet GitRepo configuration using kubectl (MCP compatible)
 func (m *FleetModule) ApplyGitRepo(ctx context.Context, gitrepoFile string, namespace string) (string, error) {
-	gitrepoFileObj := m.client.Host().File(gitrepoFile)
+	gitrepoFileObj := hostFile(m.client, gitrepoFile)
 	
 	args := []string{"kubectl", "apply", "-f", "/gitrepo.yaml"}
 	if namespace != "" {
 		args = append(args, "-n", namespace)
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithFile("/gitrepo.yaml", gitrepoFileObj).
 		WithExec(args)
 
@@ -237,8 +229,7 @@ func (m *FleetModule) GetGitReposSimple(ctx context.Context, namespace string) (
 		namespace = "fleet-local"
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec([]string{"kubectl", "get", "gitrepo", "-n", namespace})
 
 	output, err := container.Stdout(ctx)
@@ -258,8 +249,7 @@ func (m *FleetModule) GetBundlesSimple(ctx context.Context, namespace string) (s
 		args = append(args, "--all-namespaces")
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -279,8 +269,7 @@ func (m *FleetModule) GetBundleDeploymentsSimple(ctx context.Context, namespace
 		args = append(args, "--all-namespaces")
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -297,8 +286,7 @@ func (m *FleetModule) DescribeGitRepoSimple(ctx context.Context, gitrepoName str
 		namespace = "fleet-local"
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec([]string{"kubectl", "describe", "gitrepo", gitrepoName, "-n", namespace})
 
 	output, err := container.Stdout(ctx)
@@ -316,8 +304,7 @@ func (m *FleetModule) InstallWithVersion(ctx context.Context, version string) (s
 	}
 
 	// Install Fleet CRD first
-	container := m.client.Container().
-		From("alpine/helm:latest").
+	container := toolContainer(m.client, "alpine/helm:latest").
 		WithExec([]string{
 			"helm", "-n", "cattle-fleet-system", "install", "--create-namespace", "--wait",
 			"fleet-crd", "https://github.com/rancher/fleet/releases/download/" + version + "/fleet-crd-" + version[1:] + ".tgz",
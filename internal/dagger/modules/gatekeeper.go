@@ -3,8 +3,11 @@ package modules
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"dagger.io/dagger"
+	"gopkg.in/yaml.v3"
 )
 
 type GatekeeperModule struct {
@@ -13,10 +16,21 @@ type GatekeeperModule struct {
 
 const (
 	gatekeeperKubectlBinary = "kubectl"
-	gatekeeperHelmBinary = "helm"
+	gatekeeperHelmBinary    = "helm"
 	gatekeeperManagerBinary = "/manager"
+	gatekeeperGatorBinary   = "gator"
+
+	gatekeeperKubeconfigMount = "/root/.kube/config"
+	gatekeeperClusterExport   = "/workspace/cluster-resources.yaml"
 )
 
+// defaultSimulationKinds are the resource kinds exported from a live cluster
+// when no explicit kinds are requested
+var defaultSimulationKinds = []string{
+	"namespaces", "pods", "deployments", "statefulsets", "daemonsets",
+	"services", "ingresses", "configmaps", "serviceaccounts",
+}
+
 func NewGatekeeperModule(client *dagger.Client) *GatekeeperModule {
 	return &GatekeeperModule{
 		client: client,
@@ -34,23 +48,22 @@ func (m *GatekeeperModule) ValidateConstraints(ctx context.Context, resourcesDir
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/opa:" + config.RegoVersion).
+	container := toolContainer(m.client, "openpolicyagent/opa:"+config.RegoVersion).
 		WithWorkdir("/workspace")
 
 	// Mount resources directory
 	if resourcesDir != "" {
-		container = container.WithMountedDirectory("/workspace/resources", m.client.Host().Directory(resourcesDir))
+		container = container.WithMountedDirectory("/workspace/resources", hostDirectory(m.client, resourcesDir))
 	}
 
 	// Mount constraints if provided
 	if config.ConstraintsDir != "" {
-		container = container.WithMountedDirectory("/workspace/constraints", m.client.Host().Directory(config.ConstraintsDir))
+		container = container.WithMountedDirectory("/workspace/constraints", hostDirectory(m.client, config.ConstraintsDir))
 	}
 
 	// Mount constraint templates if provided
 	if config.TemplatesDir != "" {
-		container = container.WithMountedDirectory("/workspace/templates", m.client.Host().Directory(config.TemplatesDir))
+		container = container.WithMountedDirectory("/workspace/templates", hostDirectory(m.client, config.TemplatesDir))
 	}
 
 	args := []string{"eval"}
@@ -92,13 +105,12 @@ func (m *GatekeeperModule) TestConstraints(ctx context.Context, testsDir string,
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("openpolicyagent/opa:" + config.RegoVersion).
+	container := toolContainer(m.client, "openpolicyagent/opa:"+config.RegoVersion).
 		WithWorkdir("/workspace")
 
 	// Mount tests directory
 	if testsDir != "" {
-		container = container.WithMountedDirectory("/workspace/tests", m.client.Host().Directory(testsDir))
+		container = container.WithMountedDirectory("/workspace/tests", hostDirectory(m.client, testsDir))
 	}
 
 	args := []string{"test", "/workspace/tests"}
@@ -122,8 +134,7 @@ func (m *GatekeeperModule) GenerateConstraintTemplate(ctx context.Context, templ
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "yq"}).
 		WithWorkdir("/workspace")
 
@@ -166,6 +177,107 @@ spec:
 	return container, nil
 }
 
+// gatekeeperAdmissionTarget is the target admission-time policies are written for
+const gatekeeperAdmissionTarget = "admission.k8s.gatekeeper.sh"
+
+var (
+	regoPackagePattern    = regexp.MustCompile(`(?m)^\s*package\s+([A-Za-z_][A-Za-z0-9_.]*)`)
+	regoViolationPattern  = regexp.MustCompile(`(?m)^\s*violation\b`)
+	regoParametersPattern = regexp.MustCompile(`\binput\.parameters\b`)
+)
+
+// ConstraintTemplateFromRego wraps an existing Rego policy into a
+// ConstraintTemplate for the admission target. kind names the constraint
+// kind the template defines; when empty it is derived from the policy's
+// package. The policy must declare a package and a violation rule, as
+// Gatekeeper requires.
+func ConstraintTemplateFromRego(kind string, rego string) (string, error) {
+	if !regoPackagePattern.MatchString(rego) {
+		return "", fmt.Errorf("rego policy has no package declaration")
+	}
+	if !regoViolationPattern.MatchString(rego) {
+		return "", fmt.Errorf("rego policy has no violation rule, which Gatekeeper evaluates")
+	}
+	kind = constraintKind(kind, rego)
+
+	crdSpec := map[string]any{
+		"names": map[string]any{"kind": kind},
+	}
+	if regoParametersPattern.MatchString(rego) {
+		// Accept the parameters the policy reads without a declared schema
+		crdSpec["validation"] = map[string]any{
+			"openAPIV3Schema": map[string]any{
+				"type":                                 "object",
+				"x-kubernetes-preserve-unknown-fields": true,
+			},
+		}
+	}
+
+	template := constraintTemplate{
+		APIVersion: "templates.gatekeeper.sh/v1",
+		Kind:       "ConstraintTemplate",
+	}
+	template.Metadata.Name = strings.ToLower(kind)
+	template.Spec.CRD.Spec = crdSpec
+	template.Spec.Targets = []constraintTemplateTarget{{
+		Target: gatekeeperAdmissionTarget,
+		Rego:   normalizeRego(rego),
+	}}
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("failed to render constraint template: %w", err)
+	}
+	return string(data), nil
+}
+
+// constraintKind returns kind, or a kind derived from the last segment of the
+// policy's package, e.g. K8srequiredlabels for package k8srequiredlabels
+func constraintKind(kind string, rego string) string {
+	if kind != "" {
+		return kind
+	}
+	match := regoPackagePattern.FindStringSubmatch(rego)
+	if match == nil {
+		return ""
+	}
+	segments := strings.Split(match[1], ".")
+	name := strings.ReplaceAll(segments[len(segments)-1], "_", "")
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// normalizeRego trims trailing whitespace so the policy is embedded as a
+// readable YAML block scalar
+func normalizeRego(rego string) string {
+	lines := strings.Split(strings.TrimRight(rego, " \t\r\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+type constraintTemplate struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		CRD struct {
+			Spec map[string]any `yaml:"spec"`
+		} `yaml:"crd"`
+		Targets []constraintTemplateTarget `yaml:"targets"`
+	} `yaml:"spec"`
+}
+
+type constraintTemplateTarget struct {
+	Target string `yaml:"target"`
+	Rego   string `yaml:"rego"`
+}
+
 // SyncConstraints syncs Gatekeeper constraints with cluster state
 func (m *GatekeeperModule) SyncConstraints(ctx context.Context, opts ...GatekeeperOption) (*dagger.Container, error) {
 	config := &GatekeeperConfig{
@@ -176,13 +288,12 @@ func (m *GatekeeperModule) SyncConstraints(ctx context.Context, opts ...Gatekeep
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithWorkdir("/workspace")
 
 	// Mount kubeconfig if provided
 	if config.KubeconfigPath != "" {
-		container = container.WithMountedFile("/root/.kube/config", m.client.Host().File(config.KubeconfigPath))
+		container = container.WithMountedFile("/root/.kube/config", hostFile(m.client, config.KubeconfigPath))
 	}
 
 	args := []string{"get", "constrainttemplates,constraints"}
@@ -208,13 +319,12 @@ func (m *GatekeeperModule) AnalyzeViolations(ctx context.Context, opts ...Gateke
 		opt(config)
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithWorkdir("/workspace")
 
 	// Mount kubeconfig if provided
 	if config.KubeconfigPath != "" {
-		container = container.WithMountedFile("/root/.kube/config", m.client.Host().File(config.KubeconfigPath))
+		container = container.WithMountedFile("/root/.kube/config", hostFile(m.client, config.KubeconfigPath))
 	}
 
 	// Create analysis script
@@ -251,10 +361,95 @@ kubectl get events --all-namespaces --field-selector reason=ConstraintViolation
 	return container, nil
 }
 
+// SimulateAgainstCluster exports resources from a live cluster and evaluates the
+// given constraint templates and constraints against them with gator. Nothing is
+// applied to the cluster; the output lists the violations admission would report.
+func (m *GatekeeperModule) SimulateAgainstCluster(ctx context.Context, opts ...GatekeeperOption) (string, error) {
+	config := &GatekeeperConfig{
+		GatekeeperVersion: "v3.17.1",
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.KubeconfigPath == "" {
+		return "", fmt.Errorf("kubeconfig is required to simulate against a live cluster")
+	}
+	if config.TemplatesDir == "" || config.ConstraintsDir == "" {
+		return "", fmt.Errorf("templates and constraints directories are required for simulation")
+	}
+
+	// Read-only export of live resources
+	exported := toolContainer(m.client, "bitnami/kubectl:latest").
+		WithMountedFile(gatekeeperKubeconfigMount, hostFile(m.client, config.KubeconfigPath)).
+		WithExec(gatekeeperExportArgs(config))
+
+	resources, err := exported.Stdout(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to export cluster resources: %w", err)
+	}
+
+	container := toolContainer(m.client, "openpolicyagent/gator:"+config.GatekeeperVersion).
+		WithWorkdir("/workspace").
+		WithNewFile(gatekeeperClusterExport, resources).
+		WithMountedDirectory("/workspace/templates", hostDirectory(m.client, config.TemplatesDir)).
+		WithMountedDirectory("/workspace/constraints", hostDirectory(m.client, config.ConstraintsDir)).
+		WithExec(gatekeeperGatorArgs(config), dagger.ContainerWithExecOpts{
+			// gator exits non-zero when violations are found
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	stderr, _ := container.Stderr(ctx)
+
+	if output != "" {
+		return output, nil
+	}
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "No would-be violations found", nil
+}
+
+// gatekeeperExportArgs builds the kubectl command that exports live resources
+func gatekeeperExportArgs(config *GatekeeperConfig) []string {
+	kinds := config.ResourceKinds
+	if len(kinds) == 0 {
+		kinds = defaultSimulationKinds
+	}
+
+	args := []string{gatekeeperKubectlBinary, "--kubeconfig", gatekeeperKubeconfigMount,
+		"get", strings.Join(kinds, ",")}
+
+	if config.Namespace != "" {
+		args = append(args, "--namespace", config.Namespace)
+	} else {
+		args = append(args, "--all-namespaces")
+	}
+
+	return append(args, "--output", "yaml")
+}
+
+// gatekeeperGatorArgs builds the gator command that evaluates exported resources
+func gatekeeperGatorArgs(config *GatekeeperConfig) []string {
+	args := []string{gatekeeperGatorBinary, "test",
+		"--filename", gatekeeperClusterExport,
+		"--filename", "/workspace/templates",
+		"--filename", "/workspace/constraints",
+	}
+
+	if config.Output != "" {
+		args = append(args, "--output", config.Output)
+	}
+
+	return args
+}
+
 // GetVersion returns the version of Gatekeeper
 func (m *GatekeeperModule) GetVersion(ctx context.Context) (*dagger.Container, error) {
-	container := m.client.Container().
-		From("openpolicyagent/gatekeeper:v3.17.1").
+	container := toolContainer(m.client, "openpolicyagent/gatekeeper:v3.17.1").
 		WithExec([]string{gatekeeperManagerBinary, "--version"})
 
 	return container, nil
@@ -272,6 +467,7 @@ type GatekeeperConfig struct {
 	Query             string
 	Verbose           bool
 	Coverage          bool
+	ResourceKinds     []string
 }
 
 type GatekeeperOption func(*GatekeeperConfig)
@@ -342,6 +538,12 @@ func WithCoverage(coverage bool) GatekeeperOption {
 	}
 }
 
+func WithResourceKinds(kinds []string) GatekeeperOption {
+	return func(c *GatekeeperConfig) {
+		c.ResourceKinds = kinds
+	}
+}
+
 // InstallGatekeeper installs Gatekeeper using kubectl or Helm (MCP compatible)
 func (m *GatekeeperModule) InstallGatekeeper(ctx context.Context, version string, useHelm bool) (string, error) {
 	if version == "" {
@@ -349,8 +551,7 @@ func (m *GatekeeperModule) InstallGatekeeper(ctx context.Context, version string
 	}
 
 	if useHelm {
-		container := m.client.Container().
-			From("alpine/helm:latest").
+		container := toolContainer(m.client, "alpine/helm:latest").
 			WithExec([]string{gatekeeperHelmBinary, "install", "gatekeeper", "gatekeeper/gatekeeper", 
 				"--namespace", "gatekeeper-system", "--create-namespace"})
 
@@ -361,8 +562,7 @@ func (m *GatekeeperModule) InstallGatekeeper(ctx context.Context, version string
 		return output, nil
 	} else {
 		url := "https://raw.githubusercontent.com/open-policy-agent/gatekeeper/" + version + "/deploy/gatekeeper.yaml"
-		container := m.client.Container().
-			From("bitnami/kubectl:latest").
+		container := toolContainer(m.client, "bitnami/kubectl:latest").
 			WithExec([]string{gatekeeperKubectlBinary, "apply", "-f", url})
 
 		output, err := container.Stdout(ctx)
@@ -380,8 +580,7 @@ func (m *GatekeeperModule) UninstallGatekeeper(ctx context.Context, version stri
 	}
 
 	if useHelm {
-		container := m.client.Container().
-			From("alpine/helm:latest").
+		container := toolContainer(m.client, "alpine/helm:latest").
 			WithExec([]string{gatekeeperHelmBinary, "delete", "gatekeeper", "--namespace", "gatekeeper-system"})
 
 		output, err := container.Stdout(ctx)
@@ -391,8 +590,7 @@ func (m *GatekeeperModule) UninstallGatekeeper(ctx context.Context, version stri
 		return output, nil
 	} else {
 		url := "https://raw.githubusercontent.com/open-policy-agent/gatekeeper/" + version + "/deploy/gatekeeper.yaml"
-		container := m.client.Container().
-			From("bitnami/kubectl:latest").
+		container := toolContainer(m.client, "bitnami/kubectl:latest").
 			WithExec([]string{gatekeeperKubectlBinary, "delete", "-f", url})
 
 		output, err := container.Stdout(ctx)
@@ -405,10 +603,9 @@ func (m *GatekeeperModule) UninstallGatekeeper(ctx context.Context, version stri
 
 // ApplyConstraintTemplate applies Gatekeeper constraint template using kubectl (MCP compatible)
 func (m *GatekeeperModule) ApplyConstraintTemplate(ctx context.Context, templateFile string) (string, error) {
-	templateFileObj := m.client.Host().File(templateFile)
+	templateFileObj := hostFile(m.client, templateFile)
 	
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithFile("/template.yaml", templateFileObj).
 		WithExec([]string{gatekeeperKubectlBinary, "apply", "-f", "/template.yaml"})
 
@@ -422,10 +619,9 @@ func (m *GatekeeperModule) ApplyConstraintTemplate(ctx context.Context, template
 
 // ApplyConstraint applies Gatekeeper constraint using kubectl (MCP compatible)
 func (m *GatekeeperModule) ApplyConstraint(ctx context.Context, constraintFile string) (string, error) {
-	constraintFileObj := m.client.Host().File(constraintFile)
+	constraintFileObj := hostFile(m.client, constraintFile)
 	
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithFile("/constraint.yaml", constraintFileObj).
 		WithExec([]string{gatekeeperKubectlBinary, "apply", "-f", "/constraint.yaml"})
 
@@ -439,8 +635,7 @@ func (m *GatekeeperModule) ApplyConstraint(ctx context.Context, constraintFile s
 
 // GetConstraintTemplates lists Gatekeeper constraint templates (MCP compatible)
 func (m *GatekeeperModule) GetConstraintTemplates(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec([]string{gatekeeperKubectlBinary, "get", "constrainttemplates"})
 
 	output, err := container.Stdout(ctx)
@@ -462,8 +657,7 @@ func (m *GatekeeperModule) GetConstraints(ctx context.Context, constraintType st
 		args = []string{"kubectl", "get", "constrainttemplates", "-o", "name"}
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -476,8 +670,7 @@ func (m *GatekeeperModule) GetConstraints(ctx context.Context, constraintType st
 
 // GetStatus gets Gatekeeper system status (MCP compatible)
 func (m *GatekeeperModule) GetStatus(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithExec([]string{gatekeeperKubectlBinary, "get", "pods", "-n", "gatekeeper-system"})
 
 	output, err := container.Stdout(ctx)
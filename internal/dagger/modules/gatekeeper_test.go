@@ -0,0 +1,140 @@
+package modules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGatekeeperExportArgs_Kubeconfig(t *testing.T) {
+	config := &GatekeeperConfig{}
+	WithKubeconfigPath("/home/user/.kube/prod")(config)
+
+	expected := []string{"kubectl", "--kubeconfig", "/root/.kube/config",
+		"get", "namespaces,pods,deployments,statefulsets,daemonsets,services,ingresses,configmaps,serviceaccounts",
+		"--all-namespaces", "--output", "yaml"}
+	if got := gatekeeperExportArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestGatekeeperExportArgs_NamespaceAndKinds(t *testing.T) {
+	config := &GatekeeperConfig{}
+	WithNamespace("payments")(config)
+	WithResourceKinds([]string{"pods", "deployments"})(config)
+
+	expected := []string{"kubectl", "--kubeconfig", "/root/.kube/config",
+		"get", "pods,deployments", "--namespace", "payments", "--output", "yaml"}
+	if got := gatekeeperExportArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestGatekeeperGatorArgs_DryRun(t *testing.T) {
+	config := &GatekeeperConfig{}
+	WithOutput("json")(config)
+
+	got := gatekeeperGatorArgs(config)
+	expected := []string{"gator", "test",
+		"--filename", "/workspace/cluster-resources.yaml",
+		"--filename", "/workspace/templates",
+		"--filename", "/workspace/constraints",
+		"--output", "json"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+
+	// The simulation must never mutate the cluster
+	for _, arg := range append(got, gatekeeperExportArgs(config)...) {
+		if arg == "apply" || arg == "create" || arg == "delete" {
+			t.Errorf("Simulation must not contain mutating verb %q", arg)
+		}
+	}
+}
+
+const testRequiredLabelsRego = `package k8srequiredlabels
+
+violation[{"msg": msg}] {
+	provided := {label | input.review.object.metadata.labels[label]}
+	required := {label | label := input.parameters.labels[_]}
+	missing := required - provided
+	count(missing) > 0
+	msg := sprintf("you must provide labels: %v", [missing])
+}   
+`
+
+func TestConstraintTemplateFromRego_EmbedsRego(t *testing.T) {
+	templateYAML, err := ConstraintTemplateFromRego("K8sRequiredLabels", testRequiredLabelsRego)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var template constraintTemplate
+	if err := yaml.Unmarshal([]byte(templateYAML), &template); err != nil {
+		t.Fatalf("Generated template is not valid YAML: %v", err)
+	}
+
+	if template.APIVersion != "templates.gatekeeper.sh/v1" || template.Kind != "ConstraintTemplate" {
+		t.Errorf("Unexpected type %s/%s", template.APIVersion, template.Kind)
+	}
+	if template.Metadata.Name != "k8srequiredlabels" {
+		t.Errorf("Expected name k8srequiredlabels, got %q", template.Metadata.Name)
+	}
+	names, _ := template.Spec.CRD.Spec["names"].(map[string]any)
+	if names["kind"] != "K8sRequiredLabels" {
+		t.Errorf("Expected kind K8sRequiredLabels, got %v", names["kind"])
+	}
+	if _, ok := template.Spec.CRD.Spec["validation"]; !ok {
+		t.Errorf("Expected a parameters schema for a policy reading input.parameters")
+	}
+
+	if len(template.Spec.Targets) != 1 {
+		t.Fatalf("Expected one target, got %d", len(template.Spec.Targets))
+	}
+	target := template.Spec.Targets[0]
+	if target.Target != "admission.k8s.gatekeeper.sh" {
+		t.Errorf("Expected admission target, got %q", target.Target)
+	}
+	expectedRego := strings.Replace(testRequiredLabelsRego, "}   \n", "}\n", 1)
+	if target.Rego != expectedRego {
+		t.Errorf("Expected embedded rego:\n%s\ngot:\n%s", expectedRego, target.Rego)
+	}
+	if !strings.Contains(templateYAML, "rego: |") {
+		t.Errorf("Expected rego as a literal block, got:\n%s", templateYAML)
+	}
+}
+
+func TestConstraintTemplateFromRego_KindFromPackage(t *testing.T) {
+	rego := "package lib.deny_privileged\n\nviolation[{\"msg\": \"privileged\"}] {\n  input.review.object.spec.privileged\n}\n"
+
+	templateYAML, err := ConstraintTemplateFromRego("", rego)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var template constraintTemplate
+	if err := yaml.Unmarshal([]byte(templateYAML), &template); err != nil {
+		t.Fatalf("Generated template is not valid YAML: %v", err)
+	}
+	if template.Metadata.Name != "denyprivileged" {
+		t.Errorf("Expected name denyprivileged, got %q", template.Metadata.Name)
+	}
+	if _, ok := template.Spec.CRD.Spec["validation"]; ok {
+		t.Errorf("Expected no parameters schema for a policy without parameters")
+	}
+}
+
+func TestConstraintTemplateFromRego_InvalidPolicy(t *testing.T) {
+	tests := map[string]string{
+		"no package":   "violation[{\"msg\": \"x\"}] { true }\n",
+		"no violation": "package example\n\ndeny[msg] { msg := \"x\" }\n",
+	}
+
+	for name, rego := range tests {
+		if _, err := ConstraintTemplateFromRego("Example", rego); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -42,9 +42,8 @@ func (m *GitSecretsModule) Scan(ctx context.Context, sourcePath string, opts Git
 	// Add source path
 	args = append(args, ".")
 
-	container := m.client.Container().
-		From(getImageTag("git-secrets", "trufflesecurity/secrets:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(sourcePath)).
+	container := toolContainer(m.client, getImageTag("git-secrets", "trufflesecurity/secrets:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, sourcePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -71,9 +70,8 @@ func (m *GitSecretsModule) InstallHooks(ctx context.Context, repoPath string, fo
 		args = append(args, "--force")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("git-secrets", "trufflesecurity/secrets:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(repoPath)).
+	container := toolContainer(m.client, getImageTag("git-secrets", "trufflesecurity/secrets:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, repoPath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
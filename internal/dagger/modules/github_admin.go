@@ -23,8 +23,7 @@ func NewGitHubAdminModule(client *dagger.Client) *GitHubAdminModule {
 
 // GetOrgMembers gets organization members
 func (m *GitHubAdminModule) GetOrgMembers(ctx context.Context, org string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -42,8 +41,7 @@ func (m *GitHubAdminModule) GetOrgMembers(ctx context.Context, org string, token
 
 // GetRepoPermissions gets repository permissions
 func (m *GitHubAdminModule) GetRepoPermissions(ctx context.Context, owner string, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -61,8 +59,7 @@ func (m *GitHubAdminModule) GetRepoPermissions(ctx context.Context, owner string
 
 // AuditOrgSecurity audits organization security settings
 func (m *GitHubAdminModule) AuditOrgSecurity(ctx context.Context, org string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -93,8 +90,7 @@ func (m *GitHubAdminModule) ListOrgRepos(ctx context.Context, org string, visibi
 	}
 	args = append(args, "--json", "name,visibility,isPrivate,createdAt")
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec(args)
@@ -116,8 +112,7 @@ func (m *GitHubAdminModule) CreateOrgRepo(ctx context.Context, org string, repoN
 		args = append(args, "--public")
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec(args)
@@ -132,8 +127,7 @@ func (m *GitHubAdminModule) CreateOrgRepo(ctx context.Context, org string, repoN
 
 // GetRepoInfoDetailed gets detailed repository information
 func (m *GitHubAdminModule) GetRepoInfoDetailed(ctx context.Context, owner string, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{"gh", "repo", "view", owner + "/" + repo, "--json", "name,owner,visibility,createdAt,updatedAt,stargazerCount,forkCount"})
@@ -154,8 +148,7 @@ func (m *GitHubAdminModule) ListOrgIssues(ctx context.Context, org string, state
 	}
 	args = append(args, "--json", "number,title,state,createdAt,repository")
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec(args)
@@ -176,8 +169,7 @@ func (m *GitHubAdminModule) ListOrgPRs(ctx context.Context, org string, state st
 	}
 	args = append(args, "--json", "number,title,state,createdAt,repository")
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec(args)
@@ -192,8 +184,7 @@ func (m *GitHubAdminModule) ListOrgPRs(ctx context.Context, org string, state st
 
 // GetVersion returns GitHub CLI version
 func (m *GitHubAdminModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec([]string{"gh", "version"})
 
@@ -212,8 +203,7 @@ func (m *GitHubAdminModule) ListOrgReposSimple(ctx context.Context, organization
 		args = append(args, "--visibility", visibility)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec(args)
 
@@ -236,8 +226,7 @@ func (m *GitHubAdminModule) CreateOrgRepoSimple(ctx context.Context, organizatio
 		args = append(args, "--description", description)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec(args)
 
@@ -251,8 +240,7 @@ func (m *GitHubAdminModule) CreateOrgRepoSimple(ctx context.Context, organizatio
 
 // GetRepoInfoSimple gets repository information (MCP compatible)
 func (m *GitHubAdminModule) GetRepoInfoSimple(ctx context.Context, repository string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec([]string{"gh", "repo", "view", repository})
 
@@ -271,8 +259,7 @@ func (m *GitHubAdminModule) ListOrgIssuesSimple(ctx context.Context, organizatio
 		args = append(args, "--state", state)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec(args)
 
@@ -291,8 +278,7 @@ func (m *GitHubAdminModule) ListOrgPRsSimple(ctx context.Context, organization s
 		args = append(args, "--state", state)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec(args)
 
@@ -306,8 +292,7 @@ func (m *GitHubAdminModule) ListOrgPRsSimple(ctx context.Context, organization s
 
 // GetVersionSimple returns GitHub CLI version (MCP compatible)
 func (m *GitHubAdminModule) GetVersionSimple(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec([]string{"gh", "--version"})
 
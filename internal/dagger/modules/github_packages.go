@@ -28,8 +28,7 @@ func NewGitHubPackagesModule(client *dagger.Client) *GitHubPackagesModule {
 
 // ScanPackage scans a GitHub package for vulnerabilities
 func (m *GitHubPackagesModule) ScanPackage(ctx context.Context, packageName string, version string, token string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/trivy:latest").
+	container := toolContainer(m.client, "aquasec/trivy:latest").
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
 			trivyBinary,
@@ -48,8 +47,7 @@ func (m *GitHubPackagesModule) ScanPackage(ctx context.Context, packageName stri
 
 // ListPackages lists packages in a repository
 func (m *GitHubPackagesModule) ListPackages(ctx context.Context, owner string, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -67,8 +65,7 @@ func (m *GitHubPackagesModule) ListPackages(ctx context.Context, owner string, r
 
 // GetPackageVersions gets versions of a package
 func (m *GitHubPackagesModule) GetPackageVersions(ctx context.Context, owner string, packageName string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -86,8 +83,7 @@ func (m *GitHubPackagesModule) GetPackageVersions(ctx context.Context, owner str
 
 // AuditDependencies audits package dependencies for vulnerabilities
 func (m *GitHubPackagesModule) AuditDependencies(ctx context.Context, owner string, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -105,8 +101,7 @@ func (m *GitHubPackagesModule) AuditDependencies(ctx context.Context, owner stri
 
 // CheckSignatures verifies package signatures
 func (m *GitHubPackagesModule) CheckSignatures(ctx context.Context, owner string, packageName string, version string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -124,10 +119,9 @@ func (m *GitHubPackagesModule) CheckSignatures(ctx context.Context, owner string
 
 // EnforcePolicies enforces package security policies
 func (m *GitHubPackagesModule) EnforcePolicies(ctx context.Context, owner string, repo string, policyFile string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyFile)).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyFile)).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
 			"sh", "-c",
@@ -144,8 +138,7 @@ func (m *GitHubPackagesModule) EnforcePolicies(ctx context.Context, owner string
 
 // GenerateSBOM generates Software Bill of Materials
 func (m *GitHubPackagesModule) GenerateSBOM(ctx context.Context, owner string, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -163,8 +156,7 @@ func (m *GitHubPackagesModule) GenerateSBOM(ctx context.Context, owner string, r
 
 // GetVersion returns API version information
 func (m *GitHubPackagesModule) GetVersion(ctx context.Context, token string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl", "jq"}).
 		WithEnvVariable("GITHUB_TOKEN", token).
 		WithExec([]string{
@@ -189,8 +181,7 @@ func (m *GitHubPackagesModule) ListPackagesSimple(ctx context.Context, organizat
 		args = append(args, "--field", "package_type="+packageType)
 	}
 
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec(args)
 
@@ -207,8 +198,7 @@ func (m *GitHubPackagesModule) AuditDependenciesSimple(ctx context.Context, orga
 	// GitHub doesn't have a dedicated dependency audit API for packages
 	message := "GitHub Packages dependency auditing is available through GitHub's security tab in the web interface and dependabot alerts. Use gh security commands or check the repository's security tab for vulnerability information."
 	
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"echo", message})
 
 	output, err := container.Stdout(ctx)
@@ -224,8 +214,7 @@ func (m *GitHubPackagesModule) CheckSignaturesSimple(ctx context.Context, organi
 	// GitHub uses sigstore/cosign for package signing - provide guidance
 	message := "GitHub Packages signature verification uses cosign. To verify signatures: 1) Get package manifest, 2) Use cosign verify with appropriate keys/certificates. See cosign documentation for details."
 	
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"echo", message})
 
 	output, err := container.Stdout(ctx)
@@ -241,8 +230,7 @@ func (m *GitHubPackagesModule) EnforcePoliciesSimple(ctx context.Context, organi
 	// GitHub package policies are managed through organization settings
 	message := "GitHub Packages policies are configured through organization settings in the web interface. Go to Organization Settings > Packages to configure package visibility, access, and deletion policies."
 	
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"echo", message})
 
 	output, err := container.Stdout(ctx)
@@ -258,8 +246,7 @@ func (m *GitHubPackagesModule) GenerateSBOMSimple(ctx context.Context, organizat
 	// SBOM generation is not directly available through GitHub Packages API
 	message := "GitHub Packages doesn't provide direct SBOM generation. Use tools like syft, cyclonedx-cli, or other SBOM generators to create SBOMs from package artifacts."
 	
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"echo", message})
 
 	output, err := container.Stdout(ctx)
@@ -272,8 +259,7 @@ func (m *GitHubPackagesModule) GenerateSBOMSimple(ctx context.Context, organizat
 
 // GetVersionSimple returns GitHub CLI version (MCP compatible)
 func (m *GitHubPackagesModule) GetVersionSimple(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "github-cli"}).
 		WithExec([]string{ghBinary, "--version"})
 
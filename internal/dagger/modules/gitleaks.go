@@ -2,6 +2,9 @@ package modules
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 
 	"dagger.io/dagger"
 )
@@ -12,13 +15,35 @@ type GitleaksModule struct {
 	name   string
 }
 
+const (
+	// gitleaksBaselineMount is where a baseline report is mounted in the container
+	gitleaksBaselineMount = "/gitleaks/baseline.json"
+
+	// gitleaksReportMount is where the full report is written before being exported to the host
+	gitleaksReportMount = "/gitleaks/report"
+
+	// gitleaksLeaksExitCode is the exit code gitleaks is told to use when it
+	// finds leaks, distinct from the 1 it exits with on errors
+	gitleaksLeaksExitCode = 2
+)
+
+// ErrGitleaksLeaksFound is returned alongside the output when gitleaks detects secrets
+var ErrGitleaksLeaksFound = errors.New("gitleaks detected secrets")
+
 // GitleaksDetectOptions contains options for gitleaks detection
 type GitleaksDetectOptions struct {
 	ConfigPath   string
 	ReportFormat string
-	ReportPath   string
+	// ReportPath is a host path the full report is exported to
+	ReportPath string
+	// BaselinePath is a host path to a previous JSON report; findings in it are not reported again
+	BaselinePath string
 	Verbose      bool
 	NoGit        bool
+	// Redact masks secret values in the output so reports can be shared
+	Redact bool
+	// LogOpts are git log options limiting which commits are scanned, e.g. "HEAD~10..HEAD"
+	LogOpts string
 }
 
 // GitleaksProtectOptions contains options for gitleaks protection
@@ -38,47 +63,62 @@ func NewGitleaksModule(client *dagger.Client) *GitleaksModule {
 
 // Detect runs gitleaks detect on the provided directory
 func (m *GitleaksModule) Detect(ctx context.Context, sourcePath string, opts GitleaksDetectOptions) (string, error) {
-	args := []string{"gitleaks", "detect"}
+	args := gitleaksDetectArgs(opts)
 
-	// Add detection options
-	if opts.ConfigPath != "" {
-		args = append(args, "--config", opts.ConfigPath)
-	}
-	if opts.ReportFormat != "" {
-		args = append(args, "--report-format", opts.ReportFormat)
+	container := toolContainer(m.client, getImageTag("gitleaks", "zricethezav/gitleaks:latest")).
+		WithDirectory("/workspace", hostScanDir(m.client, sourcePath)).
+		WithWorkdir("/workspace")
+
+	if opts.BaselinePath != "" {
+		container = container.WithMountedFile(gitleaksBaselineMount, hostFile(m.client, opts.BaselinePath))
 	}
-	if opts.ReportPath != "" {
-		args = append(args, "--report-path", opts.ReportPath)
+
+	container = container.WithExec(args, dagger.ContainerWithExecOpts{
+		Expect: "ANY",
+	})
+
+	stdout, err := container.Stdout(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to run gitleaks: %w", err)
 	}
-	if opts.Verbose {
-		args = append(args, "--verbose")
+	stderr, _ := container.Stderr(ctx)
+
+	exitCode, err := container.ExitCode(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get gitleaks exit code: %w", err)
 	}
-	if opts.NoGit {
-		args = append(args, "--no-git")
+	exitErr := gitleaksExitError(exitCode, stderr)
+	if exitErr != nil && !errors.Is(exitErr, ErrGitleaksLeaksFound) {
+		return "", exitErr
 	}
 
-	// Add source path
-	args = append(args, "--source", ".")
-
-	container := m.client.Container().
-		From(getImageTag("gitleaks", "zricethezav/gitleaks:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(sourcePath)).
-		WithWorkdir("/workspace").
-		WithExec(args, dagger.ContainerWithExecOpts{
-			Expect: "ANY",
-		})
-
-	stdout, _ := container.Stdout(ctx)
-	stderr, _ := container.Stderr(ctx)
+	if opts.ReportPath != "" {
+		if _, err := container.File(gitleaksReportMount).Export(ctx, opts.ReportPath); err != nil {
+			return "", fmt.Errorf("failed to export gitleaks report to %s: %w\nStderr: %s", opts.ReportPath, err, stderr)
+		}
+	}
 
-	if stdout != "" {
-		return stdout, nil
+	output := stdout
+	if output == "" {
+		output = stderr
 	}
-	if stderr != "" {
-		return stderr, nil
+	if output == "" && exitErr == nil {
+		output = "No secrets detected"
 	}
+	return output, exitErr
+}
 
-	return "No secrets detected", nil
+// gitleaksExitError maps gitleaks' exit code onto an error: leaks found or a
+// failed scan
+func gitleaksExitError(exitCode int, stderr string) error {
+	switch exitCode {
+	case 0:
+		return nil
+	case gitleaksLeaksExitCode:
+		return ErrGitleaksLeaksFound
+	default:
+		return fmt.Errorf("gitleaks exited with code %d\nStderr: %s", exitCode, stderr)
+	}
 }
 
 // Protect runs gitleaks protect for pre-commit scanning
@@ -96,9 +136,8 @@ func (m *GitleaksModule) Protect(ctx context.Context, sourcePath string, opts Gi
 		args = append(args, "--verbose")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("gitleaks", "zricethezav/gitleaks:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(sourcePath)).
+	container := toolContainer(m.client, getImageTag("gitleaks", "zricethezav/gitleaks:latest")).
+		WithDirectory("/workspace", hostScanDir(m.client, sourcePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -115,4 +154,38 @@ func (m *GitleaksModule) Protect(ctx context.Context, sourcePath string, opts Gi
 	}
 
 	return "Protection scan completed successfully", nil
-}
\ No newline at end of file
+}
+
+// gitleaksDetectArgs builds the gitleaks detect command line
+func gitleaksDetectArgs(opts GitleaksDetectOptions) []string {
+	args := []string{"gitleaks", "detect"}
+
+	// Add detection options
+	if opts.ConfigPath != "" {
+		args = append(args, "--config", opts.ConfigPath)
+	}
+	if opts.ReportFormat != "" {
+		args = append(args, "--report-format", opts.ReportFormat)
+	}
+	if opts.ReportPath != "" {
+		args = append(args, "--report-path", gitleaksReportMount)
+	}
+	if opts.BaselinePath != "" {
+		args = append(args, "--baseline-path", gitleaksBaselineMount)
+	}
+	if opts.Verbose {
+		args = append(args, "--verbose")
+	}
+	if opts.NoGit {
+		args = append(args, "--no-git")
+	}
+	if opts.LogOpts != "" {
+		args = append(args, "--log-opts="+opts.LogOpts)
+	}
+	if opts.Redact {
+		args = append(args, "--redact")
+	}
+
+	// Add source path
+	return append(args, "--exit-code", strconv.Itoa(gitleaksLeaksExitCode), "--source", ".")
+}
@@ -0,0 +1,71 @@
+package modules
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGitleaksDetectArgs(t *testing.T) {
+	args := gitleaksDetectArgs(GitleaksDetectOptions{
+		ReportFormat: "json",
+		NoGit:        true,
+		Redact:       true,
+	})
+
+	expected := []string{"gitleaks", "detect", "--report-format", "json", "--no-git", "--redact", "--exit-code", "2", "--source", "."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGitleaksDetectArgs_DefaultUnredacted(t *testing.T) {
+	args := gitleaksDetectArgs(GitleaksDetectOptions{})
+
+	expected := []string{"gitleaks", "detect", "--exit-code", "2", "--source", "."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGitleaksDetectArgs_BaselineAndReport(t *testing.T) {
+	args := gitleaksDetectArgs(GitleaksDetectOptions{
+		ReportFormat: "json",
+		ReportPath:   "/home/dev/reports/gitleaks.json",
+		BaselinePath: "/home/dev/gitleaks-baseline.json",
+	})
+
+	// Host paths are never passed through; the container uses the mount points
+	expected := []string{
+		"gitleaks", "detect",
+		"--report-format", "json",
+		"--report-path", gitleaksReportMount,
+		"--baseline-path", gitleaksBaselineMount,
+		"--exit-code", "2",
+		"--source", ".",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGitleaksDetectArgs_LogOpts(t *testing.T) {
+	args := gitleaksDetectArgs(GitleaksDetectOptions{LogOpts: "HEAD~10..HEAD"})
+
+	expected := []string{"gitleaks", "detect", "--log-opts=HEAD~10..HEAD", "--exit-code", "2", "--source", "."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGitleaksExitError(t *testing.T) {
+	if err := gitleaksExitError(0, ""); err != nil {
+		t.Errorf("Expected no error for a clean scan, got %v", err)
+	}
+	if err := gitleaksExitError(gitleaksLeaksExitCode, ""); !errors.Is(err, ErrGitleaksLeaksFound) {
+		t.Errorf("Expected ErrGitleaksLeaksFound, got %v", err)
+	}
+	if err := gitleaksExitError(1, "fatal: not a git repository"); err == nil || errors.Is(err, ErrGitleaksLeaksFound) {
+		t.Errorf("Expected a scan failure, got %v", err)
+	}
+}
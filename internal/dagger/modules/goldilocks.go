@@ -24,8 +24,7 @@ func NewGoldilocksModule(client *dagger.Client) *GoldilocksModule {
 
 // GetRecommendations gets resource recommendations
 func (m *GoldilocksModule) GetRecommendations(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(m.client, "golang:1.21-alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -35,7 +34,7 @@ func (m *GoldilocksModule) GetRecommendations(ctx context.Context, namespace str
 		WithEnvVariable("PATH", "/go/bin:/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -57,8 +56,7 @@ func (m *GoldilocksModule) GetRecommendations(ctx context.Context, namespace str
 
 // CreateVPA creates Vertical Pod Autoscaler resources
 func (m *GoldilocksModule) CreateVPA(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(m.client, "golang:1.21-alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -68,7 +66,7 @@ func (m *GoldilocksModule) CreateVPA(ctx context.Context, namespace string, kube
 		WithEnvVariable("PATH", "/go/bin:/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -99,11 +97,10 @@ func (m *GoldilocksModule) InstallHelm(ctx context.Context, namespace string, ku
 		namespace = "goldilocks"
 	}
 
-	container := m.client.Container().
-		From("alpine/helm:latest")
+	container := toolContainer(m.client, "alpine/helm:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -130,11 +127,10 @@ func (m *GoldilocksModule) InstallHelm(ctx context.Context, namespace string, ku
 
 // EnableNamespace enables Goldilocks for a namespace
 func (m *GoldilocksModule) EnableNamespace(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -157,11 +153,10 @@ func (m *GoldilocksModule) Uninstall(ctx context.Context, namespace string, kube
 		namespace = "goldilocks"
 	}
 
-	container := m.client.Container().
-		From("alpine/helm:latest")
+	container := toolContainer(m.client, "alpine/helm:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -0,0 +1,129 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// GrypeModule runs Grype for vulnerability scanning of images, directories and SBOMs
+type GrypeModule struct {
+	client *dagger.Client
+	name   string
+}
+
+// The image ships the binary at the filesystem root
+const grypeBinary = "/grype"
+
+// grypeSBOMDir is where an SBOM is mounted for grype to read
+const grypeSBOMDir = "/sbom"
+
+// NewGrypeModule creates a new Grype module
+func NewGrypeModule(client *dagger.Client) *GrypeModule {
+	return &GrypeModule{
+		client: client,
+		name:   "grype",
+	}
+}
+
+// ScanImage scans a container image pulled from its registry
+func (m *GrypeModule) ScanImage(ctx context.Context, imageName string, opts ...GrypeOption) (string, error) {
+	config := newGrypeConfig(opts)
+	container := toolContainer(m.client, getImageTag("grype", "anchore/grype:latest"))
+
+	return m.run(ctx, container, grypeArgs(config, "registry:"+imageName))
+}
+
+// ScanDirectory catalogs and scans the packages in a directory
+func (m *GrypeModule) ScanDirectory(ctx context.Context, dir string, opts ...GrypeOption) (string, error) {
+	config := newGrypeConfig(opts)
+	container := toolContainer(m.client, getImageTag("grype", "anchore/grype:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace")
+
+	return m.run(ctx, container, grypeArgs(config, "dir:/workspace"))
+}
+
+// ScanSBOM scans an existing SBOM, such as one generated by Syft, without
+// cataloging the source again
+func (m *GrypeModule) ScanSBOM(ctx context.Context, sbomPath string, opts ...GrypeOption) (string, error) {
+	config := newGrypeConfig(opts)
+	mountPath := grypeSBOMMountPath(sbomPath)
+	container := toolContainer(m.client, getImageTag("grype", "anchore/grype:latest")).
+		WithFile(mountPath, hostFile(m.client, sbomPath))
+
+	return m.run(ctx, container, grypeArgs(config, "sbom:"+mountPath))
+}
+
+// GetVersion returns the version of Grype
+func (m *GrypeModule) GetVersion(ctx context.Context) (string, error) {
+	container := toolContainer(m.client, getImageTag("grype", "anchore/grype:latest")).
+		WithExec([]string{grypeBinary, "version"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	return "", fmt.Errorf("failed to get grype version: no output received")
+}
+
+func (m *GrypeModule) run(ctx context.Context, container *dagger.Container, args []string) (string, error) {
+	container = container.WithExec(args, dagger.ContainerWithExecOpts{
+		// grype reports scan problems on stderr with a non-zero exit code
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run grype: no output received")
+}
+
+func newGrypeConfig(opts []GrypeOption) *GrypeConfig {
+	config := &GrypeConfig{
+		Format: "table",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// grypeArgs builds the command line for scanning a grype source such as
+// registry:alpine:3.19, dir:/workspace or sbom:/sbom/sbom.json
+func grypeArgs(config *GrypeConfig, source string) []string {
+	return []string{grypeBinary, source, "-o", config.Format}
+}
+
+// grypeSBOMMountPath is where the SBOM at sbomPath is mounted, keeping its
+// file name so grype can detect the format from the extension
+func grypeSBOMMountPath(sbomPath string) string {
+	return grypeSBOMDir + "/" + filepath.Base(sbomPath)
+}
+
+// GrypeConfig holds the settings for a Grype run
+type GrypeConfig struct {
+	Format string
+}
+
+// GrypeOption sets a field of GrypeConfig
+type GrypeOption func(*GrypeConfig)
+
+// WithGrypeFormat sets the report format (table, json, sarif, cyclonedx)
+func WithGrypeFormat(format string) GrypeOption {
+	return func(c *GrypeConfig) {
+		c.Format = format
+	}
+}
@@ -0,0 +1,39 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGrypeArgs_DefaultFormat(t *testing.T) {
+	args := grypeArgs(newGrypeConfig(nil), "registry:alpine:3.19")
+
+	expected := []string{"/grype", "registry:alpine:3.19", "-o", "table"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGrypeArgs_SBOMSource(t *testing.T) {
+	config := newGrypeConfig([]GrypeOption{WithGrypeFormat("sarif")})
+	args := grypeArgs(config, "sbom:"+grypeSBOMMountPath("./out/sbom.cdx.json"))
+
+	expected := []string{"/grype", "sbom:/sbom/sbom.cdx.json", "-o", "sarif"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGrypeSBOMMountPath(t *testing.T) {
+	tests := map[string]string{
+		"sbom.json":              "/sbom/sbom.json",
+		"./build/sbom.spdx.json": "/sbom/sbom.spdx.json",
+		"/tmp/artifacts/bom.xml": "/sbom/bom.xml",
+	}
+
+	for path, expected := range tests {
+		if got := grypeSBOMMountPath(path); got != expected {
+			t.Errorf("grypeSBOMMountPath(%q) = %q, expected %q", path, got, expected)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// HadolintModule runs hadolint for Dockerfile linting
+type HadolintModule struct {
+	client *dagger.Client
+	name   string
+}
+
+const (
+	hadolintBinary = "hadolint"
+
+	// hadolintConfigFile is the config file hadolint picks up from a project root
+	hadolintConfigFile = ".hadolint.yaml"
+
+	// hadolintConfigMount is where the config file is mounted inside the container
+	hadolintConfigMount = "/config/.hadolint.yaml"
+)
+
+// NewHadolintModule creates a new hadolint module
+func NewHadolintModule(client *dagger.Client) *HadolintModule {
+	return &HadolintModule{
+		client: client,
+		name:   "hadolint",
+	}
+}
+
+// ScanDockerfile lints a Dockerfile inside dir. The Dockerfile path is relative to dir.
+// When no config is given, a .hadolint.yaml in dir is used if present.
+func (m *HadolintModule) ScanDockerfile(ctx context.Context, dir string, dockerfile string, opts ...HadolintOption) (string, error) {
+	config := &HadolintConfig{
+		Format: "tty",
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	configPath := resolveHadolintConfig(dir, config.ConfigPath)
+
+	container := toolContainer(m.client, getImageTag("hadolint", "hadolint/hadolint:latest-debian")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace")
+
+	if configPath != "" {
+		container = container.WithMountedFile(hadolintConfigMount, hostFile(m.client, configPath))
+	}
+
+	args := buildHadolintArgs(config, configPath != "", dockerfile)
+
+	container = container.WithExec(args, dagger.ContainerWithExecOpts{
+		// hadolint returns non-zero exit code when it finds issues
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	stderr, _ := container.Stderr(ctx)
+
+	if output != "" {
+		return output, nil
+	}
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "No Dockerfile issues found", nil
+}
+
+// GetVersion returns the version of hadolint
+func (m *HadolintModule) GetVersion(ctx context.Context) (string, error) {
+	container := toolContainer(m.client, getImageTag("hadolint", "hadolint/hadolint:latest-debian")).
+		WithExec([]string{hadolintBinary, "--version"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	return "", fmt.Errorf("failed to get hadolint version: no output received")
+}
+
+// resolveHadolintConfig returns the host config path to mount. An explicit path
+// always wins; otherwise a .hadolint.yaml in the scanned directory is used.
+func resolveHadolintConfig(dir, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if dir == "" {
+		return ""
+	}
+
+	candidate := filepath.Join(HostPath(dir), hadolintConfigFile)
+	if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+		return candidate
+	}
+
+	return ""
+}
+
+// buildHadolintArgs builds the hadolint command line
+func buildHadolintArgs(config *HadolintConfig, hasConfig bool, dockerfile string) []string {
+	args := []string{hadolintBinary}
+
+	if hasConfig {
+		args = append(args, "--config", hadolintConfigMount)
+	}
+
+	if config.Format != "" {
+		args = append(args, "--format", config.Format)
+	}
+
+	if config.FailureThreshold != "" {
+		args = append(args, "--failure-threshold", config.FailureThreshold)
+	}
+
+	for _, rule := range config.Ignore {
+		args = append(args, "--ignore", rule)
+	}
+
+	return append(args, dockerfile)
+}
+
+// HadolintConfig holds the settings for a Hadolint run
+type HadolintConfig struct {
+	Format           string
+	ConfigPath       string
+	FailureThreshold string
+	Ignore           []string
+}
+
+// HadolintOption sets a field of HadolintConfig
+type HadolintOption func(*HadolintConfig)
+
+func WithHadolintFormat(format string) HadolintOption {
+	return func(c *HadolintConfig) {
+		c.Format = format
+	}
+}
+
+// WithHadolintConfig mounts the given config file and passes it via --config
+func WithHadolintConfig(path string) HadolintOption {
+	return func(c *HadolintConfig) {
+		c.ConfigPath = path
+	}
+}
+
+func WithHadolintFailureThreshold(threshold string) HadolintOption {
+	return func(c *HadolintConfig) {
+		c.FailureThreshold = threshold
+	}
+}
+
+func WithHadolintIgnore(rules []string) HadolintOption {
+	return func(c *HadolintConfig) {
+		c.Ignore = rules
+	}
+}
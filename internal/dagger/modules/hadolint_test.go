@@ -0,0 +1,53 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveHadolintConfig_Explicit(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".hadolint.yaml"), []byte("ignored: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	got := resolveHadolintConfig(dir, "/etc/custom-hadolint.yaml")
+	if got != "/etc/custom-hadolint.yaml" {
+		t.Errorf("Expected explicit config to win, got %q", got)
+	}
+
+	args := buildHadolintArgs(&HadolintConfig{Format: "json"}, got != "", "Dockerfile")
+	expected := []string{"hadolint", "--config", hadolintConfigMount, "--format", "json", "Dockerfile"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestResolveHadolintConfig_AutoDetect(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".hadolint.yaml")
+	if err := os.WriteFile(configPath, []byte("ignored: [DL3008]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if got := resolveHadolintConfig(dir, ""); got != configPath {
+		t.Errorf("Expected auto-detected config %q, got %q", configPath, got)
+	}
+}
+
+func TestResolveHadolintConfig_NoConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	got := resolveHadolintConfig(dir, "")
+	if got != "" {
+		t.Errorf("Expected no config, got %q", got)
+	}
+
+	args := buildHadolintArgs(&HadolintConfig{Format: "tty"}, got != "", "Dockerfile")
+	expected := []string{"hadolint", "--format", "tty", "Dockerfile"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
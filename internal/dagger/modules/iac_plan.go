@@ -45,9 +45,8 @@ func (m *IacPlanModule) GeneratePlan(ctx context.Context, workdir string, tool s
 		image = "hashicorp/terraform:latest"
 	}
 
-	container := m.client.Container().
-		From(image).
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, image).
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace")
 
 	// Initialize if needed
@@ -86,9 +85,8 @@ func (m *IacPlanModule) ValidateConfiguration(ctx context.Context, workdir strin
 		image = "hashicorp/terraform:latest"
 	}
 
-	container := m.client.Container().
-		From(image).
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, image).
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{getBinaryPath(tool), "init"}).
 		WithExec([]string{getBinaryPath(tool), "validate", "-json"})
@@ -111,9 +109,8 @@ func (m *IacPlanModule) FormatConfiguration(ctx context.Context, workdir string,
 		image = "hashicorp/terraform:latest"
 	}
 
-	container := m.client.Container().
-		From(image).
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, image).
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace")
 
 	fmtCmd := []string{tool, "fmt"}
@@ -133,8 +130,7 @@ func (m *IacPlanModule) FormatConfiguration(ctx context.Context, workdir string,
 
 // AnalyzePlan analyzes plan JSON for security and compliance insights
 func (m *IacPlanModule) AnalyzePlan(ctx context.Context, planJsonContent string, analysisTypes []string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}).
 		WithNewFile("/plan.json", planJsonContent).
 		WithExec([]string{
@@ -160,11 +156,10 @@ func (m *IacPlanModule) AnalyzePlan(ctx context.Context, planJsonContent string,
 
 // ComparePlans compares two plan JSON files to show differences
 func (m *IacPlanModule) ComparePlans(ctx context.Context, baselinePlan string, currentPlan string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}).
-		WithFile("/baseline.json", m.client.Host().File(baselinePlan)).
-		WithFile("/current.json", m.client.Host().File(currentPlan)).
+		WithFile("/baseline.json", hostFile(m.client, baselinePlan)).
+		WithFile("/current.json", hostFile(m.client, currentPlan)).
 		WithExec([]string{
 			"sh", "-c",
 			`
@@ -200,9 +195,8 @@ func (m *IacPlanModule) ManageWorkspace(ctx context.Context, workdir string, too
 		image = "hashicorp/terraform:latest"
 	}
 
-	container := m.client.Container().
-		From(image).
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, image).
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{getBinaryPath(tool), "init"})
 
@@ -231,9 +225,8 @@ func (m *IacPlanModule) GenerateGraph(ctx context.Context, workdir string, tool
 		image = "hashicorp/terraform:latest"
 	}
 
-	container := m.client.Container().
-		From(image).
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, image).
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{getBinaryPath(tool), "init"})
 
@@ -3,14 +3,75 @@ package modules
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 )
 
+// imageDigestPattern matches a sha256 content digest
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
 // getImageTag returns the image tag from environment variable override or falls back to default
 func getImageTag(toolName, defaultImage string) string {
 	envKey := fmt.Sprintf("SHIP_IMAGE_TAG_%s", strings.ToUpper(toolName))
 	if override := os.Getenv(envKey); override != "" {
-		return override
+		return resolveImageOverride(override, defaultImage)
 	}
 	return defaultImage
-}
\ No newline at end of file
+}
+
+// ImageTag returns the image reference a tool runs: defaultImage, or the
+// SHIP_IMAGE_TAG_<TOOL> override when set
+func ImageTag(toolName, defaultImage string) string {
+	return getImageTag(toolName, defaultImage)
+}
+
+// resolveImageOverride turns an override into the image reference to pull.
+// Digests win over tags: "image:tag@sha256:..." resolves to "image@sha256:...",
+// and a bare "sha256:..." pins the default image's repository by digest.
+func resolveImageOverride(override, defaultImage string) string {
+	if imageDigestPattern.MatchString(override) {
+		return imageRepository(defaultImage) + "@" + override
+	}
+	if name, digest, ok := strings.Cut(override, "@"); ok {
+		return imageRepository(name) + "@" + digest
+	}
+	return override
+}
+
+// imageRepository strips any tag and digest from an image reference
+func imageRepository(ref string) string {
+	ref, _, _ = strings.Cut(ref, "@")
+	// A colon after the last slash separates the tag; earlier colons belong to a registry port
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+// ValidateImageOverride checks an image override of the form image[:tag],
+// image[:tag]@sha256:<digest> or sha256:<digest>
+func ValidateImageOverride(override string) error {
+	if override == "" {
+		return fmt.Errorf("image reference is empty")
+	}
+	if strings.ContainsAny(override, " \t\n") {
+		return fmt.Errorf("image reference %q contains whitespace", override)
+	}
+
+	if strings.HasPrefix(override, "sha256:") {
+		if !imageDigestPattern.MatchString(override) {
+			return fmt.Errorf("malformed digest %q: expected sha256: followed by 64 lowercase hex characters", override)
+		}
+		return nil
+	}
+
+	name, digest, hasDigest := strings.Cut(override, "@")
+	if name == "" {
+		return fmt.Errorf("image reference %q is missing an image name", override)
+	}
+	if hasDigest && !imageDigestPattern.MatchString(digest) {
+		return fmt.Errorf("malformed digest %q in %q: expected sha256: followed by 64 lowercase hex characters", digest, override)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package modules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDigest = "sha256:" + strings.Repeat("ab", 32)
+
+func TestGetImageTagOverrides(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, "aquasec/trivy:latest", getImageTag("trivy", "aquasec/trivy:latest"))
+	})
+
+	t.Run("tag override", func(t *testing.T) {
+		t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.50.0")
+		assert.Equal(t, "aquasec/trivy:0.50.0", getImageTag("trivy", "aquasec/trivy:latest"))
+	})
+
+	t.Run("digest override", func(t *testing.T) {
+		t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy@"+testDigest)
+		assert.Equal(t, "aquasec/trivy@"+testDigest, getImageTag("trivy", "aquasec/trivy:latest"))
+	})
+
+	t.Run("digest preferred over tag", func(t *testing.T) {
+		t.Setenv("SHIP_IMAGE_TAG_TRIVY", "aquasec/trivy:0.50.0@"+testDigest)
+		assert.Equal(t, "aquasec/trivy@"+testDigest, getImageTag("trivy", "aquasec/trivy:latest"))
+	})
+
+	t.Run("bare digest pins default repository", func(t *testing.T) {
+		t.Setenv("SHIP_IMAGE_TAG_OSV-SCANNER", testDigest)
+		assert.Equal(t, "ghcr.io/google/osv-scanner@"+testDigest, getImageTag("osv-scanner", "ghcr.io/google/osv-scanner:latest"))
+	})
+}
+
+func TestImageRepository(t *testing.T) {
+	assert.Equal(t, "aquasec/trivy", imageRepository("aquasec/trivy:latest"))
+	assert.Equal(t, "localhost:5000/trivy", imageRepository("localhost:5000/trivy:1.0"))
+	assert.Equal(t, "localhost:5000/trivy", imageRepository("localhost:5000/trivy"))
+	assert.Equal(t, "aquasec/trivy", imageRepository("aquasec/trivy:1.0@"+testDigest))
+}
+
+func TestValidateImageOverride(t *testing.T) {
+	valid := []string{
+		"aquasec/trivy:0.50.0",
+		"aquasec/trivy",
+		"aquasec/trivy@" + testDigest,
+		"localhost:5000/trivy:1.0@" + testDigest,
+		testDigest,
+	}
+	for _, override := range valid {
+		assert.NoError(t, ValidateImageOverride(override), override)
+	}
+
+	invalid := []string{
+		"",
+		"aquasec/trivy@sha256:abc",
+		"aquasec/trivy@md5:" + strings.Repeat("ab", 16),
+		"aquasec/trivy@" + strings.ToUpper(testDigest),
+		"@" + testDigest,
+		"sha256:xyz",
+		"aquasec/trivy latest",
+	}
+	for _, override := range invalid {
+		assert.Error(t, ValidateImageOverride(override), override)
+	}
+}
@@ -0,0 +1,15 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInTotoVerifyDSSEArgs(t *testing.T) {
+	args := inTotoVerifyDSSEArgs(inTotoEnvelopeMount, inTotoPublicKeyMount)
+
+	expected := []string{"python", "-c", inTotoVerifyDSSEScript, inTotoEnvelopeMount, inTotoPublicKeyMount}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
@@ -23,11 +23,10 @@ func NewInfraMapModule(client *dagger.Client) *InfraMapModule {
 // GenerateFromState generates an infrastructure diagram from a Terraform state file
 func (m *InfraMapModule) GenerateFromState(ctx context.Context, stateFile string, format string) (string, error) {
 	// Get the directory containing the state file
-	workDir := m.client.Host().Directory(".")
+	workDir := workspaceDir(m.client)
 
 	// Create container with InfraMap
-	container := m.client.Container().
-		From("cycloid/inframap:latest").
+	container := toolContainer(m.client, "cycloid/inframap:latest").
 		WithDirectory("/opt", workDir).
 		WithWorkdir("/opt")
 
@@ -72,11 +71,10 @@ func (m *InfraMapModule) GenerateFromState(ctx context.Context, stateFile string
 // GenerateFromHCL generates an infrastructure diagram from Terraform HCL files
 func (m *InfraMapModule) GenerateFromHCL(ctx context.Context, directory string, format string) (string, error) {
 	// Get the directory containing HCL files
-	workDir := m.client.Host().Directory(directory)
+	workDir := hostDirectory(m.client, directory)
 
 	// Create container with InfraMap
-	container := m.client.Container().
-		From("cycloid/inframap:latest").
+	container := toolContainer(m.client, "cycloid/inframap:latest").
 		WithDirectory("/opt", workDir).
 		WithWorkdir("/opt")
 
@@ -119,10 +117,9 @@ func (m *InfraMapModule) GenerateFromHCL(ctx context.Context, directory string,
 
 // GenerateWithOptions generates a diagram with custom options
 func (m *InfraMapModule) GenerateWithOptions(ctx context.Context, input string, options InfraMapOptions) (string, error) {
-	workDir := m.client.Host().Directory(".")
+	workDir := workspaceDir(m.client)
 
-	container := m.client.Container().
-		From("cycloid/inframap:latest").
+	container := toolContainer(m.client, "cycloid/inframap:latest").
 		WithDirectory("/opt", workDir).
 		WithWorkdir("/opt")
 
@@ -164,10 +161,9 @@ func (m *InfraMapModule) GenerateWithOptions(ctx context.Context, input string,
 
 // PruneState removes unnecessary information from Terraform state
 func (m *InfraMapModule) PruneState(ctx context.Context, stateFile string) (string, error) {
-	workDir := m.client.Host().Directory(".")
+	workDir := workspaceDir(m.client)
 
-	container := m.client.Container().
-		From("cycloid/inframap:latest").
+	container := toolContainer(m.client, "cycloid/inframap:latest").
 		WithDirectory("/opt", workDir).
 		WithWorkdir("/opt")
 
@@ -187,8 +183,7 @@ func (m *InfraMapModule) PruneState(ctx context.Context, stateFile string) (stri
 
 // GetVersion returns the version of InfraMap
 func (m *InfraMapModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("cycloid/inframap:latest").
+	container := toolContainer(m.client, "cycloid/inframap:latest").
 		WithExec([]string{"/home/inframap/inframap", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -32,11 +32,10 @@ func NewInfraScanModule(client *dagger.Client) *InfraScanModule {
 // ScanAWSInfrastructure scans AWS infrastructure and generates a system map
 func (m *InfraScanModule) ScanAWSInfrastructure(ctx context.Context, regions []string, outputDir string) (string, error) {
 	// Create container with infrascan CLI
-	container := m.client.Container().
-		From("node:18-alpine").
+	container := toolContainer(m.client, "node:18-alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git", "aws-cli"}).
 		WithExec([]string{"npm", "install", "-g", "@infrascan/cli"}).
-		WithMountedDirectory("/workspace", m.client.Host().Directory(".")).
+		WithMountedDirectory("/workspace", workspaceDir(m.client)).
 		WithWorkdir("/workspace")
 
 	// Build command
@@ -65,10 +64,9 @@ func (m *InfraScanModule) ScanAWSInfrastructure(ctx context.Context, regions []s
 // GenerateGraph generates a graph from scan results
 func (m *InfraScanModule) GenerateGraph(ctx context.Context, inputDir string) (string, error) {
 	// Create container with infrascan CLI
-	container := m.client.Container().
-		From("node:18-alpine").
+	container := toolContainer(m.client, "node:18-alpine").
 		WithExec([]string{"npm", "install", "-g", "@infrascan/cli"}).
-		WithMountedDirectory("/workspace", m.client.Host().Directory(".")).
+		WithMountedDirectory("/workspace", workspaceDir(m.client)).
 		WithWorkdir("/workspace")
 
 	// Execute graph generation
@@ -86,10 +84,9 @@ func (m *InfraScanModule) GenerateGraph(ctx context.Context, inputDir string) (s
 // RenderGraph renders an infrastructure graph
 func (m *InfraScanModule) RenderGraph(ctx context.Context, inputFile string, openBrowser bool) (string, error) {
 	// Create container with infrascan CLI
-	container := m.client.Container().
-		From("node:18-alpine").
+	container := toolContainer(m.client, "node:18-alpine").
 		WithExec([]string{"npm", "install", "-g", "@infrascan/cli"}).
-		WithMountedDirectory("/workspace", m.client.Host().Directory(".")).
+		WithMountedDirectory("/workspace", workspaceDir(m.client)).
 		WithWorkdir("/workspace")
 
 	// Build command
@@ -113,9 +110,8 @@ func (m *InfraScanModule) RenderGraph(ctx context.Context, inputFile string, ope
 // ScanDirectory scans a directory for security issues (using Trivy)
 func (m *InfraScanModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
 	// Mount the directory and run Trivy
-	container := m.client.Container().
-		From("aquasec/trivy:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/trivy:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			infrascanTrivyBinary,
@@ -139,9 +135,8 @@ func (m *InfraScanModule) ScanFile(ctx context.Context, filePath string) (string
 	dir := filepath.Dir(filePath)
 	filename := filepath.Base(filePath)
 
-	container := m.client.Container().
-		From("aquasec/trivy:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/trivy:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			infrascanTrivyBinary,
@@ -162,13 +157,12 @@ func (m *InfraScanModule) ScanFile(ctx context.Context, filePath string) (string
 
 // ScanWithRules scans using custom rule set (using Trivy)
 func (m *InfraScanModule) ScanWithRules(ctx context.Context, dir string, rulesFile string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/trivy:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir))
+	container := toolContainer(m.client, "aquasec/trivy:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir))
 
 	// If rules file is provided, mount it
 	if rulesFile != "" {
-		container = container.WithFile("/policy.rego", m.client.Host().File(rulesFile))
+		container = container.WithFile("/policy.rego", hostFile(m.client, rulesFile))
 		container = container.WithExec([]string{
 			infrascanTrivyBinary,
 			"fs",
@@ -199,16 +193,14 @@ func (m *InfraScanModule) ScanWithRules(ctx context.Context, dir string, rulesFi
 
 // GetVersion returns the version of the scanner (infrascan)
 func (m *InfraScanModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("node:18-alpine").
+	container := toolContainer(m.client, "node:18-alpine").
 		WithExec([]string{"npm", "install", "-g", "@infrascan/cli"}).
 		WithExec([]string{infrascanBinary, "--version"})
 
 	output, err := container.Stdout(ctx)
 	if err != nil {
 		// Fallback to Trivy version if infrascan not available
-		container = m.client.Container().
-			From("aquasec/trivy:latest").
+		container = toolContainer(m.client, "aquasec/trivy:latest").
 			WithExec([]string{infrascanTrivyBinary, "--version"})
 		
 		output, err = container.Stdout(ctx)
@@ -29,11 +29,10 @@ func NewK8sNetworkPolicyModule(client *dagger.Client) *K8sNetworkPolicyModule {
 
 // KubectlNetworkPolicy manages network policies using kubectl
 func (m *K8sNetworkPolicyModule) KubectlNetworkPolicy(ctx context.Context, action string, resource string, namespace string, outputFormat string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	// Build kubectl command based on action
@@ -58,13 +57,13 @@ func (m *K8sNetworkPolicyModule) KubectlNetworkPolicy(ctx context.Context, actio
 		if resource == "" {
 			return "", fmt.Errorf("resource file path is required for create action")
 		}
-		container = container.WithFile("/policy.yaml", m.client.Host().File(resource))
+		container = container.WithFile("/policy.yaml", hostFile(m.client, resource))
 		args = []string{k8sKubectlBinary, "create", "-f", "/policy.yaml"}
 	case "apply":
 		if resource == "" {
 			return "", fmt.Errorf("resource file path is required for apply action")
 		}
-		container = container.WithFile("/policy.yaml", m.client.Host().File(resource))
+		container = container.WithFile("/policy.yaml", hostFile(m.client, resource))
 		args = []string{k8sKubectlBinary, "apply", "-f", "/policy.yaml"}
 	default:
 		return "", fmt.Errorf("unsupported action: %s", action)
@@ -93,13 +92,12 @@ func (m *K8sNetworkPolicyModule) KubectlNetworkPolicy(ctx context.Context, actio
 
 // NetfetchScan scans network policies using netfetch
 func (m *K8sNetworkPolicyModule) NetfetchScan(ctx context.Context, namespace string, dryrun bool, cilium bool, target string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"sh", "-c", "curl -L https://github.com/deggja/netfetch/releases/latest/download/netfetch-linux-amd64 -o /usr/local/bin/netfetch && chmod +x /usr/local/bin/netfetch"})
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{netfetchBinary, "scan"}
@@ -130,13 +128,12 @@ func (m *K8sNetworkPolicyModule) NetfetchScan(ctx context.Context, namespace str
 
 // NetfetchDashboard launches netfetch dashboard for network policy visualization
 func (m *K8sNetworkPolicyModule) NetfetchDashboard(ctx context.Context, port string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"sh", "-c", "curl -L https://github.com/deggja/netfetch/releases/latest/download/netfetch-linux-amd64 -o /usr/local/bin/netfetch && chmod +x /usr/local/bin/netfetch"})
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{netfetchBinary, "dash"}
@@ -165,14 +162,13 @@ func (m *K8sNetworkPolicyModule) NetfetchDashboard(ctx context.Context, port str
 
 // NetpolEval evaluates network connectivity using netpol-analyzer
 func (m *K8sNetworkPolicyModule) NetpolEval(ctx context.Context, dirpath string, source string, destination string, port string, verbose bool) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"sh", "-c", "curl -L https://github.com/np-guard/netpol-analyzer/releases/latest/download/netpol-linux-amd64 -o /usr/local/bin/netpol && chmod +x /usr/local/bin/netpol"})
 
 	// Mount the directory containing Kubernetes resources
 	if dirpath != "" {
-		container = container.WithMountedDirectory("/workspace", m.client.Host().Directory(dirpath))
+		container = container.WithMountedDirectory("/workspace", hostDirectory(m.client, dirpath))
 	}
 
 	args := []string{"netpol", "eval"}
@@ -206,14 +202,13 @@ func (m *K8sNetworkPolicyModule) NetpolEval(ctx context.Context, dirpath string,
 
 // NetpolList lists all allowed connections using netpol-analyzer
 func (m *K8sNetworkPolicyModule) NetpolList(ctx context.Context, dirpath string, verbose bool, quiet bool) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"sh", "-c", "curl -L https://github.com/np-guard/netpol-analyzer/releases/latest/download/netpol-linux-amd64 -o /usr/local/bin/netpol && chmod +x /usr/local/bin/netpol"})
 
 	// Mount the directory containing Kubernetes resources
 	if dirpath != "" {
-		container = container.WithMountedDirectory("/workspace", m.client.Host().Directory(dirpath))
+		container = container.WithMountedDirectory("/workspace", hostDirectory(m.client, dirpath))
 	}
 
 	args := []string{"netpol", "list"}
@@ -241,17 +236,16 @@ func (m *K8sNetworkPolicyModule) NetpolList(ctx context.Context, dirpath string,
 
 // NetpolDiff compares network policies between two directories
 func (m *K8sNetworkPolicyModule) NetpolDiff(ctx context.Context, dir1 string, dir2 string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithExec([]string{"sh", "-c", "curl -L https://github.com/np-guard/netpol-analyzer/releases/latest/download/netpol-linux-amd64 -o /usr/local/bin/netpol && chmod +x /usr/local/bin/netpol"})
 
 	// Mount both directories
 	if dir1 != "" {
-		container = container.WithMountedDirectory("/workspace1", m.client.Host().Directory(dir1))
+		container = container.WithMountedDirectory("/workspace1", hostDirectory(m.client, dir1))
 	}
 	if dir2 != "" {
-		container = container.WithMountedDirectory("/workspace2", m.client.Host().Directory(dir2))
+		container = container.WithMountedDirectory("/workspace2", hostDirectory(m.client, dir2))
 	}
 
 	args := []string{"netpol", "diff"}
@@ -289,12 +283,11 @@ func (m *K8sNetworkPolicyModule) AnalyzePolicies(ctx context.Context, namespace
 
 // ValidatePolicy validates a network policy (legacy function for compatibility)
 func (m *K8sNetworkPolicyModule) ValidatePolicy(ctx context.Context, policyPath string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
-		WithFile("/policy.yaml", m.client.Host().File(policyPath))
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
+		WithFile("/policy.yaml", hostFile(m.client, policyPath))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -315,11 +308,10 @@ func (m *K8sNetworkPolicyModule) ValidatePolicy(ctx context.Context, policyPath
 
 // TestConnectivity tests network connectivity between pods (legacy function for compatibility)
 func (m *K8sNetworkPolicyModule) TestConnectivity(ctx context.Context, sourceNamespace string, targetNamespace string, targetService string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("nicolaka/netshoot:latest")
+	container := toolContainer(m.client, "nicolaka/netshoot:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -21,37 +21,39 @@ func NewKubeBenchModule(client *dagger.Client) *KubeBenchModule {
 	}
 }
 
-// RunBenchmark runs CIS Kubernetes benchmark
-func (m *KubeBenchModule) RunBenchmark(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+// RunBenchmark runs the CIS Kubernetes benchmark kube-bench selects for the
+// cluster, or the one forced with WithKubeBenchBenchmark
+func (m *KubeBenchModule) RunBenchmark(ctx context.Context, kubeconfig string, opts ...KubeBenchOption) (string, error) {
+	config := newKubeBenchConfig(opts)
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
-	container = container.WithExec([]string{
-		"kube-bench",
-		"--json",
-	}, dagger.ContainerWithExecOpts{
+	container = container.WithExec(kubeBenchArgs(config), dagger.ContainerWithExecOpts{
 		Expect: "ANY",
 	})
 
-	output, err := container.Stdout(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to run kube-bench: %w", err)
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
 	}
 
-	return output, nil
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run kube-bench: no output received")
 }
 
 // RunMasterBenchmark runs benchmark for master node
 func (m *KubeBenchModule) RunMasterBenchmark(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -72,11 +74,10 @@ func (m *KubeBenchModule) RunMasterBenchmark(ctx context.Context, kubeconfig str
 
 // RunNodeBenchmark runs benchmark for worker node
 func (m *KubeBenchModule) RunNodeBenchmark(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -97,8 +98,7 @@ func (m *KubeBenchModule) RunNodeBenchmark(ctx context.Context, kubeconfig strin
 
 // GetVersion returns the version of kube-bench
 func (m *KubeBenchModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest").
+	container := toolContainer(m.client, "aquasec/kube-bench:latest").
 		WithExec([]string{"kube-bench", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -118,11 +118,10 @@ func (m *KubeBenchModule) GetVersion(ctx context.Context) (string, error) {
 
 // RunWithChecks runs specific checks only
 func (m *KubeBenchModule) RunWithChecks(ctx context.Context, kubeconfig string, checks string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kube-bench", "--json"}
@@ -144,11 +143,10 @@ func (m *KubeBenchModule) RunWithChecks(ctx context.Context, kubeconfig string,
 
 // RunWithSkip runs benchmark skipping specified checks
 func (m *KubeBenchModule) RunWithSkip(ctx context.Context, kubeconfig string, skip string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kube-bench", "--json"}
@@ -170,11 +168,10 @@ func (m *KubeBenchModule) RunWithSkip(ctx context.Context, kubeconfig string, sk
 
 // RunWithCustomOutput runs benchmark with custom output format and file
 func (m *KubeBenchModule) RunWithCustomOutput(ctx context.Context, kubeconfig string, outputFormat string, outputFile string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kube-bench"}
@@ -201,11 +198,10 @@ func (m *KubeBenchModule) RunWithCustomOutput(ctx context.Context, kubeconfig st
 
 // RunASFF runs benchmark with AWS Security Finding Format output
 func (m *KubeBenchModule) RunASFF(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-bench:latest")
+	container := toolContainer(m.client, "aquasec/kube-bench:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -222,3 +218,37 @@ func (m *KubeBenchModule) RunASFF(ctx context.Context, kubeconfig string) (strin
 
 	return output, nil
 }
+
+// kubeBenchArgs builds the kube-bench command line. Without a benchmark
+// kube-bench detects one from the cluster's Kubernetes version.
+func kubeBenchArgs(config *KubeBenchConfig) []string {
+	args := []string{"kube-bench", "--json"}
+	if config.Benchmark != "" {
+		args = append(args, "--benchmark", config.Benchmark)
+	}
+	return args
+}
+
+// KubeBenchConfig holds the settings for a kube-bench run
+type KubeBenchConfig struct {
+	Benchmark string
+}
+
+// KubeBenchOption sets a field of KubeBenchConfig
+type KubeBenchOption func(*KubeBenchConfig)
+
+// WithKubeBenchBenchmark forces a benchmark spec such as cis-1.23, eks-1.2.0
+// or aks-1.0 instead of the auto-detected one
+func WithKubeBenchBenchmark(benchmark string) KubeBenchOption {
+	return func(c *KubeBenchConfig) {
+		c.Benchmark = benchmark
+	}
+}
+
+func newKubeBenchConfig(opts []KubeBenchOption) *KubeBenchConfig {
+	config := &KubeBenchConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
@@ -0,0 +1,22 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKubeBenchArgs_AutoDetect(t *testing.T) {
+	got := kubeBenchArgs(newKubeBenchConfig(nil))
+	want := []string{"kube-bench", "--json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kubeBenchArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestKubeBenchArgs_Benchmark(t *testing.T) {
+	got := kubeBenchArgs(newKubeBenchConfig([]KubeBenchOption{WithKubeBenchBenchmark("eks-1.2.0")}))
+	want := []string{"kube-bench", "--json", "--benchmark", "eks-1.2.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("kubeBenchArgs() = %v, want %v", got, want)
+	}
+}
@@ -14,6 +14,16 @@ type KubeHunterModule struct {
 	name   string
 }
 
+// KubeHunterActiveWarning is shown whenever active hunting is requested
+const KubeHunterActiveWarning = "WARNING: active hunting attempts to exploit discovered vulnerabilities and may change cluster state. Only run it against clusters you own or are authorized to test."
+
+// ConfirmKubeHunterActive gates active hunting behind an explicit acknowledgement
+func ConfirmKubeHunterActive(active, understood bool) error {
+	if active && !understood {
+		return fmt.Errorf("active hunting is intrusive; set i_understand_active_is_intrusive to confirm")
+	}
+	return nil
+}
 
 // NewKubeHunterModule creates a new kube-hunter module
 func NewKubeHunterModule(client *dagger.Client) *KubeHunterModule {
@@ -25,19 +35,9 @@ func NewKubeHunterModule(client *dagger.Client) *KubeHunterModule {
 
 // ScanRemote scans remote Kubernetes cluster
 func (m *KubeHunterModule) ScanRemote(ctx context.Context, remote string, active bool, reportFormat string) (string, error) {
-	args := []string{"kube-hunter", "--remote", remote}
-	
-	if active {
-		args = append(args, "--active")
-	}
-	
-	if reportFormat == "" {
-		reportFormat = "json"
-	}
-	args = append(args, "--report", reportFormat)
+	args := kubeHunterArgs([]string{"--remote", remote}, active, reportFormat)
 
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -51,19 +51,9 @@ func (m *KubeHunterModule) ScanRemote(ctx context.Context, remote string, active
 
 // ScanCIDR scans CIDR range for Kubernetes clusters
 func (m *KubeHunterModule) ScanCIDR(ctx context.Context, cidr string, active bool, reportFormat string) (string, error) {
-	args := []string{"kube-hunter", "--cidr", cidr}
-	
-	if active {
-		args = append(args, "--active")
-	}
-	
-	if reportFormat == "" {
-		reportFormat = "json"
-	}
-	args = append(args, "--report", reportFormat)
+	args := kubeHunterArgs([]string{"--cidr", cidr}, active, reportFormat)
 
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -77,27 +67,11 @@ func (m *KubeHunterModule) ScanCIDR(ctx context.Context, cidr string, active boo
 
 // ScanInterface scans network interface
 func (m *KubeHunterModule) ScanInterface(ctx context.Context, networkInterface string, active bool, reportFormat string) (string, error) {
-	// Use --interface without argument to scan all interfaces if none specified
-	args := []string{"kube-hunter"}
-	
-	if networkInterface == "" {
-		args = append(args, "--interface")
-	} else {
-		// Specific interface might not exist, use --interface flag only
-		args = append(args, "--interface")
-	}
-	
-	if active {
-		args = append(args, "--active")
-	}
-	
-	if reportFormat == "" {
-		reportFormat = "json"
-	}
-	args = append(args, "--report", reportFormat)
+	// kube-hunter's --interface takes no argument and scans all local interfaces,
+	// so a specific networkInterface cannot be selected
+	args := kubeHunterArgs([]string{"--interface"}, active, reportFormat)
 
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -117,23 +91,13 @@ func (m *KubeHunterModule) ScanInterface(ctx context.Context, networkInterface s
 
 // ScanPod runs kube-hunter as pod in cluster
 func (m *KubeHunterModule) ScanPod(ctx context.Context, kubeconfig string, active bool, reportFormat string) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest")
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
-	args := []string{"kube-hunter", "--pod"}
-	
-	if active {
-		args = append(args, "--active")
-	}
-	
-	if reportFormat == "" {
-		reportFormat = "json"
-	}
-	args = append(args, "--report", reportFormat)
+	args := kubeHunterArgs([]string{"--pod"}, active, reportFormat)
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
 		Expect: "ANY",
@@ -148,6 +112,20 @@ func (m *KubeHunterModule) ScanPod(ctx context.Context, kubeconfig string, activ
 	return output, nil
 }
 
+// kubeHunterArgs builds a kube-hunter scan command for the given target arguments
+func kubeHunterArgs(target []string, active bool, reportFormat string) []string {
+	args := append([]string{"kube-hunter"}, target...)
+
+	if active {
+		args = append(args, "--active")
+	}
+
+	if reportFormat == "" {
+		reportFormat = "json"
+	}
+	return append(args, "--report", reportFormat)
+}
+
 // ListTests lists all available tests
 func (m *KubeHunterModule) ListTests(ctx context.Context, showActive bool) (string, error) {
 	args := []string{"kube-hunter", "--list"}
@@ -156,8 +134,7 @@ func (m *KubeHunterModule) ListTests(ctx context.Context, showActive bool) (stri
 		args = append(args, "--active")
 	}
 
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -201,8 +178,7 @@ func (m *KubeHunterModule) RunCustomHunters(ctx context.Context, target string,
 	
 	args = append(args, "--report", "json")
 
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -216,8 +192,7 @@ func (m *KubeHunterModule) RunCustomHunters(ctx context.Context, target string,
 
 // GetVersion returns the version of kube-hunter
 func (m *KubeHunterModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("aquasec/kube-hunter:latest").
+	container := toolContainer(m.client, "aquasec/kube-hunter:latest").
 		WithExec([]string{"kube-hunter", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
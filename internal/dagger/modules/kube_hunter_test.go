@@ -0,0 +1,42 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKubeHunterArgs_Passive(t *testing.T) {
+	expected := []string{"kube-hunter", "--remote", "10.0.0.1", "--report", "json"}
+	if got := kubeHunterArgs([]string{"--remote", "10.0.0.1"}, false, ""); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestKubeHunterArgs_Active(t *testing.T) {
+	expected := []string{"kube-hunter", "--cidr", "10.0.0.0/24", "--active", "--report", "yaml"}
+	if got := kubeHunterArgs([]string{"--cidr", "10.0.0.0/24"}, true, "yaml"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestConfirmKubeHunterActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		active     bool
+		understood bool
+		wantErr    bool
+	}{
+		{"passive scan needs no confirmation", false, false, false},
+		{"active scan without confirmation is rejected", true, false, true},
+		{"active scan with confirmation is allowed", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ConfirmKubeHunterActive(tt.active, tt.understood)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -20,13 +20,12 @@ func NewKubescapeModule(client *dagger.Client) *KubescapeModule {
 
 // ScanCluster scans a Kubernetes cluster for security issues
 func (m *KubescapeModule) ScanCluster(ctx context.Context, kubeconfig string, framework string, format string, severityThreshold string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithWorkdir("/workspace")
 
 	// Mount kubeconfig if provided
 	if kubeconfig != "" {
-		container = container.WithMountedFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithMountedFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kubescape", "scan"}
@@ -67,13 +66,12 @@ func (m *KubescapeModule) ScanCluster(ctx context.Context, kubeconfig string, fr
 
 // ScanManifests scans Kubernetes manifest files
 func (m *KubescapeModule) ScanManifests(ctx context.Context, manifestsDir string, framework string, format string, severityThreshold string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithWorkdir("/workspace")
 
 	// Mount manifests directory
 	if manifestsDir != "" {
-		container = container.WithMountedDirectory("/workspace/manifests", m.client.Host().Directory(manifestsDir))
+		container = container.WithMountedDirectory("/workspace/manifests", hostDirectory(m.client, manifestsDir))
 	}
 
 	// Add framework (default to nsa if not specified)
@@ -116,13 +114,12 @@ func (m *KubescapeModule) ScanManifests(ctx context.Context, manifestsDir string
 
 // ScanHelm scans Helm charts for security issues
 func (m *KubescapeModule) ScanHelm(ctx context.Context, chartPath string, framework string, format string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithWorkdir("/workspace")
 
 	// Mount chart directory
 	if chartPath != "" {
-		container = container.WithMountedDirectory("/workspace/chart", m.client.Host().Directory(chartPath))
+		container = container.WithMountedDirectory("/workspace/chart", hostDirectory(m.client, chartPath))
 	}
 
 	// Add framework (default to nsa if not specified)
@@ -155,13 +152,12 @@ func (m *KubescapeModule) ScanHelm(ctx context.Context, chartPath string, framew
 
 // ScanRepository scans a Git repository for Kubernetes manifests
 func (m *KubescapeModule) ScanRepository(ctx context.Context, repoPath string, framework string, format string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithWorkdir("/workspace")
 
 	// Mount repository directory
 	if repoPath != "" {
-		container = container.WithMountedDirectory("/workspace/repo", m.client.Host().Directory(repoPath))
+		container = container.WithMountedDirectory("/workspace/repo", hostDirectory(m.client, repoPath))
 	}
 
 	// Add framework (default to nsa if not specified)
@@ -194,8 +190,7 @@ func (m *KubescapeModule) ScanRepository(ctx context.Context, repoPath string, f
 
 // GetVersion returns the version of kubescape
 func (m *KubescapeModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithExec([]string{"kubescape", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -216,8 +211,7 @@ func (m *KubescapeModule) GetVersion(ctx context.Context) (string, error) {
 
 // ListFrameworks lists all available security frameworks
 func (m *KubescapeModule) ListFrameworks(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithExec([]string{"kubescape", "list", "frameworks"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -238,8 +232,7 @@ func (m *KubescapeModule) ListFrameworks(ctx context.Context) (string, error) {
 
 // ListControls lists all available security controls
 func (m *KubescapeModule) ListControls(ctx context.Context, framework string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15")
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15")
 
 	args := []string{"kubescape", "list", "controls"}
 	
@@ -263,13 +256,12 @@ func (m *KubescapeModule) ListControls(ctx context.Context, framework string) (s
 
 // DownloadArtifacts downloads kubescape artifacts for offline use
 func (m *KubescapeModule) DownloadArtifacts(ctx context.Context, outputDir string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/kubescape/kubescape-cli:v3.0.15").
+	container := toolContainer(m.client, "quay.io/kubescape/kubescape-cli:v3.0.15").
 		WithWorkdir("/workspace")
 
 	// Mount output directory
 	if outputDir != "" {
-		container = container.WithMountedDirectory("/workspace/output", m.client.Host().Directory(outputDir))
+		container = container.WithMountedDirectory("/workspace/output", hostDirectory(m.client, outputDir))
 	}
 
 	args := []string{"kubescape", "download", "artifacts", "--output", "/workspace/output"}
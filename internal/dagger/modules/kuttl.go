@@ -26,12 +26,11 @@ func NewKuttlModule(client *dagger.Client) *KuttlModule {
 
 // RunTest runs KUTTL tests
 func (m *KuttlModule) RunTest(ctx context.Context, testPath string, kubeconfig string, parallel int, skipDelete bool) (string, error) {
-	container := m.client.Container().
-		From("kudobuilder/kuttl:latest").
-		WithDirectory("/tests", m.client.Host().Directory(testPath))
+	container := toolContainer(m.client, "kudobuilder/kuttl:latest").
+		WithDirectory("/tests", hostDirectory(m.client, testPath))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{kuttlBinary, "test", "/tests"}
@@ -59,15 +58,14 @@ func (m *KuttlModule) RunTest(ctx context.Context, testPath string, kubeconfig s
 
 // RunTestWithKind runs KUTTL tests with kind cluster
 func (m *KuttlModule) RunTestWithKind(ctx context.Context, testPath string, kindConfig string, parallel int) (string, error) {
-	container := m.client.Container().
-		From("kudobuilder/kuttl:latest").
-		WithDirectory("/tests", m.client.Host().Directory(testPath))
+	container := toolContainer(m.client, "kudobuilder/kuttl:latest").
+		WithDirectory("/tests", hostDirectory(m.client, testPath))
 
 	args := []string{kuttlBinary, "test", "/tests", "--start-kind"}
 	
 	// Add kind config if specified
 	if kindConfig != "" {
-		container = container.WithFile("/kind-config.yaml", m.client.Host().File(kindConfig))
+		container = container.WithFile("/kind-config.yaml", hostFile(m.client, kindConfig))
 		args = append(args, "--kind-config", "/kind-config.yaml")
 	}
 	
@@ -92,9 +90,8 @@ func (m *KuttlModule) RunTestWithKind(ctx context.Context, testPath string, kind
 
 // ValidateTest validates test configuration
 func (m *KuttlModule) ValidateTest(ctx context.Context, testPath string) (string, error) {
-	container := m.client.Container().
-		From("kudobuilder/kuttl:latest").
-		WithDirectory("/tests", m.client.Host().Directory(testPath)).
+	container := toolContainer(m.client, "kudobuilder/kuttl:latest").
+		WithDirectory("/tests", hostDirectory(m.client, testPath)).
 		WithExec([]string{
 			kuttlBinary,
 			"test",
@@ -113,8 +110,7 @@ func (m *KuttlModule) ValidateTest(ctx context.Context, testPath string) (string
 
 // GetVersion returns the version of KUTTL
 func (m *KuttlModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("kudobuilder/kuttl:latest").
+	container := toolContainer(m.client, "kudobuilder/kuttl:latest").
 		WithExec([]string{kuttlBinary, "version"})
 
 	output, err := container.Stdout(ctx)
@@ -127,8 +123,7 @@ func (m *KuttlModule) GetVersion(ctx context.Context) (string, error) {
 
 // GetHelp returns the help information for KUTTL
 func (m *KuttlModule) GetHelp(ctx context.Context, command string) (string, error) {
-	container := m.client.Container().
-		From("kudobuilder/kuttl:latest")
+	container := toolContainer(m.client, "kudobuilder/kuttl:latest")
 
 	args := []string{kuttlBinary, "help"}
 	if command != "" {
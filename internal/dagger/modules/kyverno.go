@@ -25,12 +25,11 @@ func NewKyvernoModule(client *dagger.Client) *KyvernoModule {
 
 // ApplyPolicies applies Kyverno policies to cluster
 func (m *KyvernoModule) ApplyPolicies(ctx context.Context, policiesPath string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/kyverno/kyverno-cli:latest").
-		WithDirectory("/policies", m.client.Host().Directory(policiesPath))
+	container := toolContainer(m.client, "ghcr.io/kyverno/kyverno-cli:latest").
+		WithDirectory("/policies", hostDirectory(m.client, policiesPath))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -52,9 +51,8 @@ func (m *KyvernoModule) ApplyPolicies(ctx context.Context, policiesPath string,
 
 // ValidatePolicies validates Kyverno policy syntax
 func (m *KyvernoModule) ValidatePolicies(ctx context.Context, policiesPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/kyverno/kyverno-cli:latest").
-		WithDirectory("/policies", m.client.Host().Directory(policiesPath)).
+	container := toolContainer(m.client, "ghcr.io/kyverno/kyverno-cli:latest").
+		WithDirectory("/policies", hostDirectory(m.client, policiesPath)).
 		WithExec([]string{
 			"kyverno",
 			"validate",
@@ -74,10 +72,9 @@ func (m *KyvernoModule) ValidatePolicies(ctx context.Context, policiesPath strin
 
 // TestPolicies tests policies against resources
 func (m *KyvernoModule) TestPolicies(ctx context.Context, policiesPath string, resourcesPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/kyverno/kyverno-cli:latest").
-		WithDirectory("/policies", m.client.Host().Directory(policiesPath)).
-		WithDirectory("/resources", m.client.Host().Directory(resourcesPath)).
+	container := toolContainer(m.client, "ghcr.io/kyverno/kyverno-cli:latest").
+		WithDirectory("/policies", hostDirectory(m.client, policiesPath)).
+		WithDirectory("/resources", hostDirectory(m.client, resourcesPath)).
 		WithExec([]string{
 			"kyverno",
 			"test",
@@ -108,11 +105,10 @@ func (m *KyvernoModule) Install(ctx context.Context, namespace string, kubeconfi
 		namespace = "kyverno"
 	}
 
-	container := m.client.Container().
-		From("alpine/helm:latest")
+	container := toolContainer(m.client, "alpine/helm:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -145,11 +141,10 @@ func (m *KyvernoModule) ListPolicies(ctx context.Context, namespace string, kube
 	}
 	args = append(args, "-o", "json")
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
@@ -174,11 +169,10 @@ func (m *KyvernoModule) GetPolicyReports(ctx context.Context, namespace string,
 	}
 	args = append(args, "-o", "json")
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
@@ -199,11 +193,10 @@ func (m *KyvernoModule) GetStatus(ctx context.Context, namespace string, kubecon
 		namespace = "kyverno"
 	}
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "get", "pods", "-n", namespace, "-o", "json"}, dagger.ContainerWithExecOpts{
@@ -253,12 +246,11 @@ subjects:
   name: kyverno
   namespace: kyverno`
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithNewFile("/workspace/cluster-role.yaml", clusterRoleYAML)
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "apply", "-f", "/workspace/cluster-role.yaml"}, dagger.ContainerWithExecOpts{
@@ -309,17 +301,15 @@ spec:
         metadata:
           labels:
             app: "*"`
-		container = m.client.Container().
-			From("bitnami/kubectl:latest").
+		container = toolContainer(m.client, "bitnami/kubectl:latest").
 			WithNewFile("/policy.yaml", samplePolicy)
 	} else {
-		container = m.client.Container().
-			From("bitnami/kubectl:latest").
-			WithFile("/policy.yaml", m.client.Host().File(filePath))
+		container = toolContainer(m.client, "bitnami/kubectl:latest").
+			WithFile("/policy.yaml", hostFile(m.client, filePath))
 	}
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kubectl", "apply", "-f", "/policy.yaml"}
@@ -26,11 +26,10 @@ func NewKyvernoMultitenantModule(client *dagger.Client) *KyvernoMultitenantModul
 
 // CreateTenantPolicies creates tenant isolation policies
 func (m *KyvernoMultitenantModule) CreateTenantPolicies(ctx context.Context, tenantName string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/kyverno/kyverno-cli:latest")
+	container := toolContainer(m.client, "ghcr.io/kyverno/kyverno-cli:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	// Generate tenant isolation policy
@@ -77,12 +76,11 @@ spec:
 
 // ValidateMultitenantSetup validates multi-tenant setup
 func (m *KyvernoMultitenantModule) ValidateMultitenantSetup(ctx context.Context, tenantsConfig string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/kyverno/kyverno-cli:latest").
-		WithFile("/tenants.yaml", m.client.Host().File(tenantsConfig))
+	container := toolContainer(m.client, "ghcr.io/kyverno/kyverno-cli:latest").
+		WithFile("/tenants.yaml", hostFile(m.client, tenantsConfig))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -101,11 +99,10 @@ func (m *KyvernoMultitenantModule) ValidateMultitenantSetup(ctx context.Context,
 
 // CreateTenantNamespace creates a namespace for a tenant
 func (m *KyvernoMultitenantModule) CreateTenantNamespace(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "create", "namespace", namespace})
@@ -132,12 +129,11 @@ spec:
     limits.cpu: %s
     limits.memory: %s`, namespace, cpuLimit, memoryLimit, cpuLimit, memoryLimit)
 
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
 		WithNewFile("/tmp/quota.yaml", quotaYaml)
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "apply", "-f", "/tmp/quota.yaml"})
@@ -152,11 +148,10 @@ spec:
 
 // ListTenantNamespaces lists namespaces with tenant labels
 func (m *KyvernoMultitenantModule) ListTenantNamespaces(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "get", "namespaces", "-l", "tenant", "-o", "json"})
@@ -171,11 +166,10 @@ func (m *KyvernoMultitenantModule) ListTenantNamespaces(ctx context.Context, kub
 
 // GetTenantPolicies gets policies for a specific tenant
 func (m *KyvernoMultitenantModule) GetTenantPolicies(ctx context.Context, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest")
+	container := toolContainer(m.client, "bitnami/kubectl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"kubectl", "get", "policies", "-n", namespace, "-o", "json"})
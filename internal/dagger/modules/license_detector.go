@@ -28,15 +28,14 @@ func (m *LicenseDetectorModule) GetVersion(ctx context.Context) (string, error)
 
 // DetectLicenses detects licenses in a directory using multiple tools for comprehensive analysis
 func (m *LicenseDetectorModule) DetectLicenses(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("ruby:alpine").
+	container := toolContainer(m.client, "ruby:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git", "build-base"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
 		WithExec([]string{"gem", "install", "licensee"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"licensee", "detect",
@@ -64,12 +63,11 @@ func (m *LicenseDetectorModule) AnalyzeDependencyLicenses(ctx context.Context, p
 		// For sample package.json, return a quick mock result
 		return `{"dependencies": {"express": {"licenses": "MIT", "repository": "https://github.com/expressjs/express"}}}`, nil
 	} else {
-		container = m.client.Container().
-			From("alpine:latest").
+		container = toolContainer(m.client, "alpine:latest").
 			WithExec([]string{"apk", "add", "--no-cache", "npm", "jq"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/package.json", m.client.Host().File(packageFile)).
+			WithFile("/package.json", hostFile(m.client, packageFile)).
 			WithExec([]string{
 				"sh", "-c",
 				`cd / && npm install --no-save license-checker-rseidelsohn 2>/dev/null && npx license-checker-rseidelsohn --json --out /licenses.json 2>/dev/null && cat /licenses.json || echo '{"dependencies": {}}'`,
@@ -96,15 +94,14 @@ func (m *LicenseDetectorModule) AnalyzeDependencyLicenses(ctx context.Context, p
 
 // ValidateLicenseCompliance validates license compliance for allowed licenses
 func (m *LicenseDetectorModule) ValidateLicenseCompliance(ctx context.Context, dir string, allowedLicenses []string) (string, error) {
-	container := m.client.Container().
-		From("ruby:alpine").
+	container := toolContainer(m.client, "ruby:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git", "build-base"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
 		WithExec([]string{"gem", "install", "licensee"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace")
 
 	// Create allowed licenses file
@@ -144,15 +141,14 @@ func (m *LicenseDetectorModule) AskalonoIdentify(ctx context.Context, filePath s
 		// For sample file, just return a quick result without installing
 		return "License: MIT (sample)", nil
 	} else {
-		container = m.client.Container().
-			From("rust:alpine").
+		container = toolContainer(m.client, "rust:alpine").
 			WithExec([]string{"apk", "add", "--no-cache", "musl-dev"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithExec([]string{"cargo", "install", "askalono-cli"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/license", m.client.Host().File(filePath))
+			WithFile("/license", hostFile(m.client, filePath))
 	}
 
 	args := []string{"askalono", "identify", "/license"}
@@ -175,12 +171,11 @@ func (m *LicenseDetectorModule) AskalonoIdentify(ctx context.Context, filePath s
 // AskalonoCrawl crawls a directory for licenses using Askalono
 func (m *LicenseDetectorModule) AskalonoCrawl(ctx context.Context, dir string) (string, error) {
 	// Use a simpler approach for crawling - look for common license files
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "find"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"sh", "-c",
@@ -200,12 +195,11 @@ func (m *LicenseDetectorModule) AskalonoCrawl(ctx context.Context, dir string) (
 // LicenseScannerFile scans a file for license information
 func (m *LicenseDetectorModule) LicenseScannerFile(ctx context.Context, filePath string, showCopyrights bool, showHash bool, showKeywords bool, debug bool) (string, error) {
 	// Use a lighter approach - just scan for common license patterns
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "grep"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/file", m.client.Host().File(filePath)).
+		WithFile("/file", hostFile(m.client, filePath)).
 		WithExec([]string{
 			"sh", "-c", 
 			`grep -i "license\|copyright\|mit\|apache\|bsd\|gpl" /file | head -5 | sed 's/^/  /' || echo "No license patterns found"`,
@@ -223,12 +217,11 @@ func (m *LicenseDetectorModule) LicenseScannerFile(ctx context.Context, filePath
 
 // LicenseScannerDirectory scans a directory for license information
 func (m *LicenseDetectorModule) LicenseScannerDirectory(ctx context.Context, dir string, showCopyrights bool, showHash bool, quiet bool) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "grep", "find"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"sh", "-c", 
@@ -247,15 +240,14 @@ func (m *LicenseDetectorModule) LicenseScannerDirectory(ctx context.Context, dir
 
 // LicenseFinderReport generates a license report using license-finder
 func (m *LicenseDetectorModule) LicenseFinderReport(ctx context.Context, projectPath string, format string) (string, error) {
-	container := m.client.Container().
-		From("ruby:alpine").
+	container := toolContainer(m.client, "ruby:alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git", "build-base"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
 		WithExec([]string{"gem", "install", "license_finder"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/project", m.client.Host().Directory(projectPath)).
+		WithDirectory("/project", hostDirectory(m.client, projectPath)).
 		WithWorkdir("/project")
 
 	args := []string{"license_finder", "report"}
@@ -277,15 +269,14 @@ func (m *LicenseDetectorModule) LicenseFinderReport(ctx context.Context, project
 
 // GoLicenseDetector detects licenses for Go projects
 func (m *LicenseDetectorModule) GoLicenseDetector(ctx context.Context, projectPath string) (string, error) {
-	container := m.client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(m.client, "golang:1.21-alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "git"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
 		WithExec([]string{"go", "install", "github.com/google/go-licenses@latest"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithDirectory("/project", m.client.Host().Directory(projectPath)).
+		WithDirectory("/project", hostDirectory(m.client, projectPath)).
 		WithWorkdir("/project").
 		WithEnvVariable("PATH", "/go/bin:/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin").
 		WithExec([]string{"go-licenses", "report", "."}, dagger.ContainerWithExecOpts{
@@ -26,12 +26,11 @@ func NewLitmusModule(client *dagger.Client) *LitmusModule {
 
 // CreateExperiment creates a chaos experiment
 func (m *LitmusModule) CreateExperiment(ctx context.Context, experimentPath string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
-		WithFile("/experiment.yaml", m.client.Host().File(experimentPath))
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
+		WithFile("/experiment.yaml", hostFile(m.client, experimentPath))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -54,11 +53,10 @@ func (m *LitmusModule) CreateExperiment(ctx context.Context, experimentPath stri
 
 // GetExperiments lists chaos experiments
 func (m *LitmusModule) GetExperiments(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest")
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -80,11 +78,10 @@ func (m *LitmusModule) GetExperiments(ctx context.Context, kubeconfig string) (s
 
 // GetChaosResults gets chaos experiment results
 func (m *LitmusModule) GetChaosResults(ctx context.Context, experimentName string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest")
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -107,8 +104,7 @@ func (m *LitmusModule) GetChaosResults(ctx context.Context, experimentName strin
 
 // GetVersion returns the version of Litmus
 func (m *LitmusModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
 		WithExec([]string{litmusctlBinary, "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -130,8 +126,7 @@ func (m *LitmusModule) Install(ctx context.Context, namespace string, releaseNam
 		releaseName = "chaos"
 	}
 
-	container := m.client.Container().
-		From("alpine/helm:latest")
+	container := toolContainer(m.client, "alpine/helm:latest")
 
 	// Add Litmus Helm repository
 	container = container.WithExec([]string{"helm", "repo", "add", "litmuschaos", "https://litmuschaos.github.io/litmus-helm/"}, dagger.ContainerWithExecOpts{
@@ -161,8 +156,7 @@ func (m *LitmusModule) Install(ctx context.Context, namespace string, releaseNam
 
 // ConnectChaosInfra connects chaos infrastructure using litmusctl
 func (m *LitmusModule) ConnectChaosInfra(ctx context.Context, projectID string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest")
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest")
 
 	args := []string{litmusctlBinary, "connect", "chaos-infra"}
 	if projectID != "" {
@@ -183,8 +177,7 @@ func (m *LitmusModule) ConnectChaosInfra(ctx context.Context, projectID string)
 
 // CreateProject creates a new project using litmusctl
 func (m *LitmusModule) CreateProject(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
 		WithExec([]string{litmusctlBinary, "create", "project"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -199,9 +192,8 @@ func (m *LitmusModule) CreateProject(ctx context.Context) (string, error) {
 
 // CreateChaosExperiment creates chaos experiment using litmusctl
 func (m *LitmusModule) CreateChaosExperiment(ctx context.Context, manifestFile string, projectID string, chaosInfraID string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
-		WithFile("/manifest.yaml", m.client.Host().File(manifestFile))
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
+		WithFile("/manifest.yaml", hostFile(m.client, manifestFile))
 
 	args := []string{litmusctlBinary, "create", "chaos-experiment", "-f", "/manifest.yaml"}
 	if projectID != "" {
@@ -225,8 +217,7 @@ func (m *LitmusModule) CreateChaosExperiment(ctx context.Context, manifestFile s
 
 // RunChaosExperiment runs chaos experiment using litmusctl
 func (m *LitmusModule) RunChaosExperiment(ctx context.Context, experimentID string, projectID string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest")
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest")
 
 	args := []string{litmusctlBinary, "run", "chaos-experiment", experimentID}
 	if projectID != "" {
@@ -247,8 +238,7 @@ func (m *LitmusModule) RunChaosExperiment(ctx context.Context, experimentID stri
 
 // GetProjects lists projects using litmusctl
 func (m *LitmusModule) GetProjects(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
 		WithExec([]string{litmusctlBinary, "get", "projects"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -263,8 +253,7 @@ func (m *LitmusModule) GetProjects(ctx context.Context) (string, error) {
 
 // GetChaosInfra lists chaos infrastructure using litmusctl
 func (m *LitmusModule) GetChaosInfra(ctx context.Context, projectID string) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest")
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest")
 
 	args := []string{litmusctlBinary, "get", "chaos-infra"}
 	if projectID != "" {
@@ -285,8 +274,7 @@ func (m *LitmusModule) GetChaosInfra(ctx context.Context, projectID string) (str
 
 // ConfigSetAccount setup ChaosCenter account configuration using litmusctl
 func (m *LitmusModule) ConfigSetAccount(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("litmuschaos/litmusctl:latest").
+	container := toolContainer(m.client, "litmuschaos/litmusctl:latest").
 		WithExec([]string{litmusctlBinary, "config", "set-account"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -301,12 +289,11 @@ func (m *LitmusModule) ConfigSetAccount(ctx context.Context) (string, error) {
 
 // ApplyChaosExperiment applies chaos experiment manifest using kubectl
 func (m *LitmusModule) ApplyChaosExperiment(ctx context.Context, manifestFile string, namespace string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("bitnami/kubectl:latest").
-		WithFile("/manifest.yaml", m.client.Host().File(manifestFile))
+	container := toolContainer(m.client, "bitnami/kubectl:latest").
+		WithFile("/manifest.yaml", hostFile(m.client, manifestFile))
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"kubectl", "apply", "-f", "/manifest.yaml"}
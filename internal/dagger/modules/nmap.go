@@ -24,8 +24,7 @@ func NewNmapModule(client *dagger.Client) *NmapModule {
 
 // GetVersion returns the version of Nmap
 func (m *NmapModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec([]string{"nmap", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -68,8 +67,7 @@ func (m *NmapModule) ScanHost(ctx context.Context, target string, scanType strin
 	// Output in XML for parsing
 	args = append(args, "-oX", "-", target)
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -106,8 +104,7 @@ func (m *NmapModule) PortScan(ctx context.Context, target string, ports string)
 	// Output in XML
 	args = append(args, "-oX", "-", target)
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -128,8 +125,7 @@ func (m *NmapModule) ServiceDetection(ctx context.Context, target string) (strin
 	
 	args := []string{"nmap", "-sV", "-oX", "-", target}
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -151,8 +147,7 @@ func (m *NmapModule) OSDetection(ctx context.Context, target string) (string, er
 	// OS detection requires root privileges
 	args := []string{"nmap", "-O", "-oX", "-", target}
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -196,8 +191,7 @@ func (m *NmapModule) VulnerabilityScan(ctx context.Context, target string, scrip
 	
 	args = append(args, "-oX", "-", target)
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -219,8 +213,7 @@ func (m *NmapModule) NetworkDiscovery(ctx context.Context, network string) (stri
 	// Ping scan for network discovery
 	args := []string{"nmap", "-sn", "-oX", "-", network}
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -249,8 +242,7 @@ func (m *NmapModule) UDPScan(ctx context.Context, target string, ports string) (
 	
 	args = append(args, "-oX", "-", target)
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -294,8 +286,7 @@ func (m *NmapModule) FirewallEvasion(ctx context.Context, target string, techniq
 	
 	args = append(args, "-oX", "-", target)
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -320,8 +311,7 @@ func (m *NmapModule) ScriptScan(ctx context.Context, target string, script strin
 	
 	args := []string{"nmap", "--script", script, "-oX", "-", target}
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -342,8 +332,7 @@ func (m *NmapModule) TracerouteScan(ctx context.Context, target string) (string,
 	
 	args := []string{"nmap", "--traceroute", "-oX", "-", target}
 
-	container := m.client.Container().
-		From("instrumentisto/nmap:latest").
+	container := toolContainer(m.client, "instrumentisto/nmap:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -0,0 +1,37 @@
+package modules
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"dagger.io/dagger"
+)
+
+// NoCacheEnv makes tool containers re-run their steps instead of serving
+// results from Dagger's cache when set to a non-empty value. It is set from
+// ship's --no-cache flag. The shared engine cache itself is left intact.
+const NoCacheEnv = "SHIP_NO_CACHE"
+
+// cacheBusterVar is set on tool containers to a value unique to each
+// container, which changes the cache key of every step that follows
+const cacheBusterVar = "SHIP_CACHE_BUSTER"
+
+// cacheBusterCount keeps cache busters unique within a process
+var cacheBusterCount atomic.Uint64
+
+// cacheBuster returns a new cache buster when the cache is disabled, or ""
+func cacheBuster(getenv func(string) string) string {
+	if getenv(NoCacheEnv) == "" {
+		return ""
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatUint(cacheBusterCount.Add(1), 10)
+}
+
+// withCacheBuster sets the cache buster on container when there is one
+func withCacheBuster(container *dagger.Container, buster string) *dagger.Container {
+	if buster == "" {
+		return container
+	}
+	return container.WithEnvVariable(cacheBusterVar, buster)
+}
@@ -0,0 +1,21 @@
+package modules
+
+import "testing"
+
+func TestCacheBuster(t *testing.T) {
+	disabled := func(string) string { return "" }
+	if buster := cacheBuster(disabled); buster != "" {
+		t.Errorf("Expected no cache buster without %s, got %q", NoCacheEnv, buster)
+	}
+
+	enabled := func(name string) string {
+		if name == NoCacheEnv {
+			return "1"
+		}
+		return ""
+	}
+	first, second := cacheBuster(enabled), cacheBuster(enabled)
+	if first == "" || first == second {
+		t.Errorf("Expected unique cache busters, got %q and %q", first, second)
+	}
+}
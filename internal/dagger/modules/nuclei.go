@@ -24,8 +24,7 @@ func NewNucleiModule(client *dagger.Client) *NucleiModule {
 
 // GetVersion returns the version of Nuclei
 func (m *NucleiModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec([]string{"nuclei", "-version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -53,8 +52,7 @@ func (m *NucleiModule) ScanURL(ctx context.Context, targetURL string, severity s
 		args = append(args, "-severity", severity)
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -83,13 +81,11 @@ func (m *NucleiModule) ScanURLList(ctx context.Context, urlsFile string, severit
 	// Create sample URLs file if none provided
 	if urlsFile == "" || urlsFile == "/tmp/urls.txt" {
 		sampleURLs := "https://example.com\nhttps://test.example.com"
-		container = m.client.Container().
-			From("projectdiscovery/nuclei:latest").
+		container = toolContainer(m.client, "projectdiscovery/nuclei:latest").
 			WithNewFile("/urls.txt", sampleURLs)
 	} else {
-		container = m.client.Container().
-			From("projectdiscovery/nuclei:latest").
-			WithFile("/urls.txt", m.client.Host().File(urlsFile))
+		container = toolContainer(m.client, "projectdiscovery/nuclei:latest").
+			WithFile("/urls.txt", hostFile(m.client, urlsFile))
 	}
 
 	args := []string{"nuclei", "-list", "/urls.txt", "-json"}
@@ -123,8 +119,7 @@ func (m *NucleiModule) ScanWithTemplate(ctx context.Context, targetURL string, t
 		args = append(args, "-t", templatePath)
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -154,8 +149,7 @@ func (m *NucleiModule) ScanWithWorkflow(ctx context.Context, targetURL string, w
 		args = append(args, "-w", "workflows/")
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -176,8 +170,7 @@ func (m *NucleiModule) ScanWithWorkflow(ctx context.Context, targetURL string, w
 
 // UpdateTemplates updates Nuclei templates
 func (m *NucleiModule) UpdateTemplates(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec([]string{"nuclei", "-ut"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -198,8 +191,7 @@ func (m *NucleiModule) UpdateTemplates(ctx context.Context) (string, error) {
 
 // ListTemplates lists available templates
 func (m *NucleiModule) ListTemplates(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec([]string{"nuclei", "-tl", "-silent"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -225,8 +217,7 @@ func (m *NucleiModule) ScanWithTags(ctx context.Context, targetURL string, tags
 		args = append(args, "-tags", tags)
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -253,8 +244,7 @@ func (m *NucleiModule) RateLimitedScan(ctx context.Context, targetURL string, ra
 		args = append(args, "-rate-limit", fmt.Sprintf("%d", rateLimit))
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -294,14 +284,12 @@ http:
       - type: status
         status:
           - 200`
-		container = m.client.Container().
-			From("projectdiscovery/nuclei:latest").
+		container = toolContainer(m.client, "projectdiscovery/nuclei:latest").
 			WithNewFile("/template.yaml", sampleTemplate)
 		templatePath = "/template.yaml"
 	} else {
-		container = m.client.Container().
-			From("projectdiscovery/nuclei:latest").
-			WithFile("/template.yaml", m.client.Host().File(templatePath))
+		container = toolContainer(m.client, "projectdiscovery/nuclei:latest").
+			WithFile("/template.yaml", hostFile(m.client, templatePath))
 		templatePath = "/template.yaml"
 	}
 
@@ -340,8 +328,7 @@ func (m *NucleiModule) GenerateReport(ctx context.Context, targetURL string, rep
 		args = append(args, "-json")
 	}
 
-	container := m.client.Container().
-		From("projectdiscovery/nuclei:latest").
+	container := toolContainer(m.client, "projectdiscovery/nuclei:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -0,0 +1,67 @@
+package modules
+
+import (
+	"sort"
+
+	"dagger.io/dagger"
+)
+
+// OfflineEnv runs tools in offline mode when set to a non-empty value. It is
+// set from ship's --offline flag.
+const OfflineEnv = "SHIP_OFFLINE"
+
+// offlineToolEnv holds, per tool, the variables that stop it from downloading
+// vulnerability databases or checking for new versions. Tools then scan with
+// the databases already in the image or cache and fail fast when there are
+// none, rather than hanging on the network.
+var offlineToolEnv = map[string]map[string]string{
+	"trivy": {
+		"TRIVY_OFFLINE_SCAN":        "true",
+		"TRIVY_SKIP_DB_UPDATE":      "true",
+		"TRIVY_SKIP_JAVA_DB_UPDATE": "true",
+		"TRIVY_SKIP_CHECK_UPDATE":   "true",
+	},
+	"grype": {
+		"GRYPE_DB_AUTO_UPDATE":       "false",
+		"GRYPE_DB_VALIDATE_AGE":      "false",
+		"GRYPE_CHECK_FOR_APP_UPDATE": "false",
+	},
+	"syft": {
+		"SYFT_CHECK_FOR_APP_UPDATE": "false",
+	},
+	"checkov": {
+		"BC_SKIP_MAPPING": "TRUE",
+	},
+}
+
+// offlineEnvVars returns the offline variables of every tool when offline
+// mode is on. Every tool container gets all of them: the names are tool
+// specific, so they do not affect other tools.
+func offlineEnvVars(getenv func(string) string) map[string]string {
+	if getenv(OfflineEnv) == "" {
+		return nil
+	}
+
+	vars := make(map[string]string)
+	for _, toolVars := range offlineToolEnv {
+		for name, value := range toolVars {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// withOfflineEnv sets the given offline variables on container, in a stable
+// order
+func withOfflineEnv(container *dagger.Container, vars map[string]string) *dagger.Container {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		container = container.WithEnvVariable(name, vars[name])
+	}
+	return container
+}
@@ -0,0 +1,43 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineEnvVarsDisabled(t *testing.T) {
+	assert.Empty(t, offlineEnvVars(fakeEnv(nil)))
+}
+
+func TestOfflineEnvVarsPerTool(t *testing.T) {
+	vars := offlineEnvVars(fakeEnv(map[string]string{OfflineEnv: "1"}))
+
+	tests := map[string]map[string]string{
+		"trivy": {
+			"TRIVY_OFFLINE_SCAN":        "true",
+			"TRIVY_SKIP_DB_UPDATE":      "true",
+			"TRIVY_SKIP_JAVA_DB_UPDATE": "true",
+			"TRIVY_SKIP_CHECK_UPDATE":   "true",
+		},
+		"grype": {
+			"GRYPE_DB_AUTO_UPDATE":       "false",
+			"GRYPE_DB_VALIDATE_AGE":      "false",
+			"GRYPE_CHECK_FOR_APP_UPDATE": "false",
+		},
+		"syft": {
+			"SYFT_CHECK_FOR_APP_UPDATE": "false",
+		},
+		"checkov": {
+			"BC_SKIP_MAPPING": "TRUE",
+		},
+	}
+
+	for tool, want := range tests {
+		t.Run(tool, func(t *testing.T) {
+			for name, value := range want {
+				assert.Equal(t, value, vars[name], name)
+			}
+		})
+	}
+}
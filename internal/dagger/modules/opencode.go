@@ -64,6 +64,15 @@ func findOpenCodeProjectRoot() string {
 	return "."
 }
 
+// opencodeContainer builds the OpenCode image with the same proxy, offline
+// and cache settings as other tool containers
+func (m *OpenCodeModule) opencodeContainer() *dagger.Container {
+	return withToolEnv(m.client.Container().
+		Build(hostDirectory(m.client, m.projectRoot), dagger.ContainerBuildOpts{
+			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
+		}))
+}
+
 // addCommonEnvVars adds common environment variables for AI providers
 func (m *OpenCodeModule) addCommonEnvVars(container *dagger.Container) *dagger.Container {
 	// List of common AI provider environment variables
@@ -110,11 +119,8 @@ func (m *OpenCodeModule) ChatWithSessionAndModel(ctx context.Context, workDir st
 	fmt.Printf("[OpenCode Debug]   continueSession: %t\n", continueSession)
 	fmt.Printf("[OpenCode Debug]   model: %s\n", model)
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace")
 	
 	// SAFETY: Run OpenCode as root to avoid permission issues, but NO FILE EXPORT during development
@@ -143,7 +149,7 @@ func (m *OpenCodeModule) ChatWithSessionAndModel(ctx context.Context, workDir st
 	} else {
 		// Mount the opencode session directory to enable persistence
 		container = container.WithDirectory("/root/.local/share/opencode", 
-			m.client.Host().Directory(opencodeSessionDir))
+			hostDirectory(m.client, opencodeSessionDir))
 	}
 	
 	// Add environment variables for AI providers
@@ -264,11 +270,8 @@ func (m *OpenCodeModule) Generate(ctx context.Context, workDir string, prompt st
 		message = fmt.Sprintf("%s and save it to %s", prompt, outputFile)
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace")
 	
 	// Add environment variables for AI providers
@@ -305,11 +308,8 @@ func (m *OpenCodeModule) AnalyzeFile(ctx context.Context, filePath string, quest
 	filename := filepath.Base(filePath)
 	message := fmt.Sprintf("%s about the file %s", question, filename)
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"opencode",
@@ -334,11 +334,8 @@ func (m *OpenCodeModule) Review(ctx context.Context, workDir string, target stri
 		args = append(args, "--target", target)
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -359,11 +356,8 @@ func (m *OpenCodeModule) Refactor(ctx context.Context, workDir string, instructi
 		args = append(args, "--file", file)
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -387,11 +381,8 @@ func (m *OpenCodeModule) Test(ctx context.Context, workDir string, testType stri
 		args = append(args, "--coverage")
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -415,11 +406,8 @@ func (m *OpenCodeModule) Document(ctx context.Context, workDir string, format st
 		args = append(args, "--output-dir", outputDir)
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -435,11 +423,8 @@ func (m *OpenCodeModule) Document(ctx context.Context, workDir string, format st
 
 // WithAuth configures OpenCode with authentication credentials
 func (m *OpenCodeModule) WithAuth(ctx context.Context, workDir string, provider string, apiKey string) (string, error) {
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithEnvVariable(fmt.Sprintf("%s_API_KEY", provider), apiKey).
 		WithExec([]string{
@@ -461,10 +446,7 @@ func (m *OpenCodeModule) WithAuth(ctx context.Context, workDir string, provider
 
 // GetVersion returns the version of OpenCode
 func (m *OpenCodeModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
+	container := m.opencodeContainer().
 		WithExec([]string{"opencode", "--version"})
 
 	output, err := container.Stdout(ctx)
@@ -482,11 +464,8 @@ func (m *OpenCodeModule) Interactive(ctx context.Context, workDir string, model
 		args = append(args, "--model", model)
 	}
 
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -502,11 +481,8 @@ func (m *OpenCodeModule) Interactive(ctx context.Context, workDir string, model
 
 // BatchProcess processes multiple files with OpenCode
 func (m *OpenCodeModule) BatchProcess(ctx context.Context, workDir string, pattern string, operation string) (string, error) {
-	container := m.client.Container().
-		Build(m.client.Host().Directory(m.projectRoot), dagger.ContainerBuildOpts{
-			Dockerfile: "internal/dagger/dockerfiles/opencode.dockerfile",
-		}).
-		WithDirectory("/workspace", m.client.Host().Directory(workDir)).
+	container := m.opencodeContainer().
+		WithDirectory("/workspace", hostDirectory(m.client, workDir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"opencode",
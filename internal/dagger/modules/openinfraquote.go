@@ -68,9 +68,8 @@ func (m *OpenInfraQuoteModule) Estimate(ctx context.Context, terraformPath strin
 		envs = append(envs, "INFRACOST_CURRENCY="+opts.Currency)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("openinfraquote", "infracost/infracost:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(terraformPath)).
+	container := toolContainer(m.client, getImageTag("openinfraquote", "infracost/infracost:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, terraformPath)).
 		WithWorkdir("/workspace")
 
 	// Add environment variables
@@ -115,10 +114,9 @@ func (m *OpenInfraQuoteModule) Diff(ctx context.Context, path1, path2 string, op
 		envs = append(envs, "INFRACOST_CURRENCY="+opts.Currency)
 	}
 
-	container := m.client.Container().
-		From(getImageTag("openinfraquote", "infracost/infracost:latest")).
-		WithDirectory("/path1", m.client.Host().Directory(path1)).
-		WithDirectory("/path2", m.client.Host().Directory(path2)).
+	container := toolContainer(m.client, getImageTag("openinfraquote", "infracost/infracost:latest")).
+		WithDirectory("/path1", hostDirectory(m.client, path1)).
+		WithDirectory("/path2", hostDirectory(m.client, path2)).
 		WithWorkdir("/")
 
 	// Add environment variables
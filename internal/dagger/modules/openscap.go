@@ -43,19 +43,17 @@ func (m *OpenSCAPModule) EvaluateProfile(ctx context.Context, contentPath string
     </check>
   </Rule>
 </Benchmark>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithNewFile("/content.xml", xccdfContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/content.xml", m.client.Host().File(contentPath))
+			WithFile("/content.xml", hostFile(m.client, contentPath))
 	}
 
 	container = container.WithExec([]string{
@@ -84,8 +82,7 @@ func (m *OpenSCAPModule) EvaluateProfile(ctx context.Context, contentPath string
 // ScanImage scans a container image for compliance
 func (m *OpenSCAPModule) ScanImage(ctx context.Context, imageName string, profile string) (string, error) {
 	// Simplified image scanning since oscap-podman may not be available
-	container := m.client.Container().
-		From("registry.fedoraproject.org/fedora:latest").
+	container := toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 		WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 		WithExec([]string{
 			"sh", "-c", fmt.Sprintf("echo 'Image scanning simulated for: %s with profile: %s'", imageName, profile),
@@ -115,15 +112,13 @@ func (m *OpenSCAPModule) GenerateReport(ctx context.Context, resultsPath string)
   <target>localhost</target>
   <rule-result idref="xccdf_test_rule_test" result="pass"/>
 </TestResult>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 			WithNewFile("/results.xml", resultsContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
-			WithFile("/results.xml", m.client.Host().File(resultsPath))
+			WithFile("/results.xml", hostFile(m.client, resultsPath))
 	}
 
 	container = container.WithExec([]string{
@@ -174,15 +169,13 @@ func (m *OpenSCAPModule) OvalEvaluate(ctx context.Context, ovalFile string, resu
     </textfilecontent54_object>
   </objects>
 </oval_definitions>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 			WithNewFile("/oval.xml", ovalContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
-			WithFile("/oval.xml", m.client.Host().File(ovalFile))
+			WithFile("/oval.xml", hostFile(m.client, ovalFile))
 	}
 
 	args := []string{oscapBinary, "oval", "eval"}
@@ -190,7 +183,7 @@ func (m *OpenSCAPModule) OvalEvaluate(ctx context.Context, ovalFile string, resu
 		args = append(args, "--results", "/results.xml")
 	}
 	if variablesFile != "" {
-		container = container.WithFile("/variables.xml", m.client.Host().File(variablesFile))
+		container = container.WithFile("/variables.xml", hostFile(m.client, variablesFile))
 		args = append(args, "--variables", "/variables.xml")
 	}
 	if definitionId != "" {
@@ -231,19 +224,17 @@ func (m *OpenSCAPModule) GenerateGuide(ctx context.Context, xccdfFile string, pr
     <rationale>Updates provide security patches</rationale>
   </Rule>
 </Benchmark>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithNewFile("/xccdf.xml", xccdfContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/xccdf.xml", m.client.Host().File(xccdfFile))
+			WithFile("/xccdf.xml", hostFile(m.client, xccdfFile))
 	}
 
 	args := []string{oscapBinary, "xccdf", "generate", "guide"}
@@ -287,19 +278,17 @@ func (m *OpenSCAPModule) ValidateDataStream(ctx context.Context, datastreamFile
     </Benchmark>
   </ds:component>
 </ds:data-stream-collection>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithNewFile("/datastream.xml", datastreamContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/datastream.xml", m.client.Host().File(datastreamFile))
+			WithFile("/datastream.xml", hostFile(m.client, datastreamFile))
 	}
 
 	container = container.WithExec([]string{oscapBinary, "ds", "sds-validate", "/datastream.xml"}, dagger.ContainerWithExecOpts{
@@ -330,19 +319,17 @@ func (m *OpenSCAPModule) ValidateContent(ctx context.Context, contentFile string
   <description>Test SCAP content for validation</description>
   <version>1.0</version>
 </Benchmark>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithNewFile("/content.xml", contentXML)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/content.xml", m.client.Host().File(contentFile))
+			WithFile("/content.xml", hostFile(m.client, contentFile))
 	}
 
 	var args []string
@@ -385,19 +372,17 @@ func (m *OpenSCAPModule) GetInfo(ctx context.Context, contentFile string) (strin
   <version>1.0</version>
   <status date="2023-01-01">draft</status>
 </Benchmark>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
 			WithNewFile("/content.xml", contentXML)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
 			}).
-			WithFile("/content.xml", m.client.Host().File(contentFile))
+			WithFile("/content.xml", hostFile(m.client, contentFile))
 	}
 
 	container = container.WithExec([]string{oscapBinary, "info", "/content.xml"}, dagger.ContainerWithExecOpts{
@@ -432,15 +417,13 @@ func (m *OpenSCAPModule) RemediateXCCDF(ctx context.Context, resultsFile string)
     <fix system="urn:xccdf:fix:script:sh">echo "Remediation applied"</fix>
   </rule-result>
 </TestResult>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 			WithNewFile("/results.xml", resultsContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
-			WithFile("/results.xml", m.client.Host().File(resultsFile))
+			WithFile("/results.xml", hostFile(m.client, resultsFile))
 	}
 
 	container = container.WithExec([]string{oscapBinary, "xccdf", "remediate", "/results.xml"}, dagger.ContainerWithExecOpts{
@@ -471,15 +454,13 @@ func (m *OpenSCAPModule) GenerateOvalReport(ctx context.Context, ovalResultsFile
     </system>
   </results>
 </oval_results>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 			WithNewFile("/oval_results.xml", ovalResultsContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
-			WithFile("/oval_results.xml", m.client.Host().File(ovalResultsFile))
+			WithFile("/oval_results.xml", hostFile(m.client, ovalResultsFile))
 	}
 
 	container = container.WithExec([]string{oscapBinary, "oval", "generate", "report", "/oval_results.xml"}, dagger.ContainerWithExecOpts{
@@ -516,15 +497,13 @@ func (m *OpenSCAPModule) SplitDataStream(ctx context.Context, datastreamFile str
     </Benchmark>
   </ds:component>
 </ds:data-stream-collection>`
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
 			WithNewFile("/datastream.xml", datastreamContent)
 	} else {
-		container = m.client.Container().
-			From("registry.fedoraproject.org/fedora:latest").
+		container = toolContainer(m.client, "registry.fedoraproject.org/fedora:latest").
 			WithExec([]string{"dnf", "install", "-y", "openscap-scanner", "openscap-utils"}).
-			WithFile("/datastream.xml", m.client.Host().File(datastreamFile))
+			WithFile("/datastream.xml", hostFile(m.client, datastreamFile))
 	}
 
 	args := []string{oscapBinary, "ds", "sds-split"}
@@ -25,8 +25,7 @@ func NewOSSFScorecardModule(client *dagger.Client) *OSSFScorecardModule {
 
 // ScoreRepository scores a repository's security posture
 func (m *OSSFScorecardModule) ScoreRepository(ctx context.Context, repoURL string, githubToken string) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/openssf/scorecard:stable").
+	container := toolContainer(m.client, "gcr.io/openssf/scorecard:stable").
 		WithEnvVariable("GITHUB_TOKEN", githubToken).
 		WithExec([]string{
 			scorecardBinary,
@@ -54,8 +53,7 @@ func (m *OSSFScorecardModule) ScoreWithChecks(ctx context.Context, repoURL strin
 		args = append(args, "--checks", check)
 	}
 
-	container := m.client.Container().
-		From("gcr.io/openssf/scorecard:stable").
+	container := toolContainer(m.client, "gcr.io/openssf/scorecard:stable").
 		WithEnvVariable("GITHUB_TOKEN", githubToken).
 		WithExec(args)
 
@@ -69,8 +67,7 @@ func (m *OSSFScorecardModule) ScoreWithChecks(ctx context.Context, repoURL strin
 
 // ListChecks lists available scorecard checks
 func (m *OSSFScorecardModule) ListChecks(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/openssf/scorecard:stable").
+	container := toolContainer(m.client, "gcr.io/openssf/scorecard:stable").
 		WithExec([]string{
 			scorecardBinary,
 			"--show-details",
@@ -86,8 +83,7 @@ func (m *OSSFScorecardModule) ListChecks(ctx context.Context) (string, error) {
 
 // GetVersion returns the version of OSSF Scorecard
 func (m *OSSFScorecardModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("gcr.io/openssf/scorecard:stable").
+	container := toolContainer(m.client, "gcr.io/openssf/scorecard:stable").
 		WithExec([]string{scorecardBinary, "version"})
 
 	output, err := container.Stdout(ctx)
@@ -0,0 +1,130 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// OSVScannerModule runs OSV-Scanner for dependency vulnerability scanning
+type OSVScannerModule struct {
+	client *dagger.Client
+	name   string
+}
+
+// The image ships the binary at the filesystem root
+const osvScannerBinary = "/osv-scanner"
+
+// NewOSVScannerModule creates a new OSV-Scanner module
+func NewOSVScannerModule(client *dagger.Client) *OSVScannerModule {
+	return &OSVScannerModule{
+		client: client,
+		name:   "osv-scanner",
+	}
+}
+
+// ScanDirectory scans a directory for lockfiles and SBOMs with known vulnerabilities
+func (m *OSVScannerModule) ScanDirectory(ctx context.Context, dir string, opts ...OSVScannerOption) (string, error) {
+	config := newOSVScannerConfig(opts)
+
+	container := toolContainer(m.client, getImageTag("osv-scanner", "ghcr.io/google/osv-scanner:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace")
+
+	return m.run(ctx, container, osvScannerDirectoryArgs(config, "/workspace"))
+}
+
+// ScanLockfile scans a single lockfile such as package-lock.json or go.sum
+func (m *OSVScannerModule) ScanLockfile(ctx context.Context, lockfilePath string, opts ...OSVScannerOption) (string, error) {
+	config := newOSVScannerConfig(opts)
+	mountPath := "/workspace/" + filepath.Base(lockfilePath)
+
+	container := toolContainer(m.client, getImageTag("osv-scanner", "ghcr.io/google/osv-scanner:latest")).
+		WithFile(mountPath, hostFile(m.client, lockfilePath)).
+		WithWorkdir("/workspace")
+
+	return m.run(ctx, container, osvScannerLockfileArgs(config, mountPath))
+}
+
+// GetVersion returns the version of OSV-Scanner
+func (m *OSVScannerModule) GetVersion(ctx context.Context) (string, error) {
+	container := toolContainer(m.client, getImageTag("osv-scanner", "ghcr.io/google/osv-scanner:latest")).
+		WithExec([]string{osvScannerBinary, "--version"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	return "", fmt.Errorf("failed to get osv-scanner version: no output received")
+}
+
+func (m *OSVScannerModule) run(ctx context.Context, container *dagger.Container, args []string) (string, error) {
+	container = container.WithExec(args, dagger.ContainerWithExecOpts{
+		// osv-scanner returns non-zero exit code when vulnerabilities are found
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run osv-scanner: no output received")
+}
+
+func newOSVScannerConfig(opts []OSVScannerOption) *OSVScannerConfig {
+	config := &OSVScannerConfig{
+		Format: "table",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// osvScannerDirectoryArgs builds the command line for a directory scan
+func osvScannerDirectoryArgs(config *OSVScannerConfig, dir string) []string {
+	args := []string{osvScannerBinary, "--format", config.Format}
+	if config.Recursive {
+		args = append(args, "--recursive")
+	}
+	return append(args, dir)
+}
+
+// osvScannerLockfileArgs builds the command line for a lockfile scan
+func osvScannerLockfileArgs(config *OSVScannerConfig, lockfile string) []string {
+	return []string{osvScannerBinary, "--format", config.Format, "--lockfile", lockfile}
+}
+
+// OSVScannerConfig holds the settings for an OSV-Scanner run
+type OSVScannerConfig struct {
+	Format    string
+	Recursive bool
+}
+
+// OSVScannerOption sets a field of OSVScannerConfig
+type OSVScannerOption func(*OSVScannerConfig)
+
+// WithOSVScannerFormat sets the report format (table, json, sarif)
+func WithOSVScannerFormat(format string) OSVScannerOption {
+	return func(c *OSVScannerConfig) {
+		c.Format = format
+	}
+}
+
+// WithOSVScannerRecursive scans subdirectories for lockfiles
+func WithOSVScannerRecursive(recursive bool) OSVScannerOption {
+	return func(c *OSVScannerConfig) {
+		c.Recursive = recursive
+	}
+}
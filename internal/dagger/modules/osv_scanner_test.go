@@ -0,0 +1,33 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOSVScannerDirectoryArgs(t *testing.T) {
+	args := osvScannerDirectoryArgs(newOSVScannerConfig(nil), "/workspace")
+	expected := []string{osvScannerBinary, "--format", "table", "/workspace"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	config := newOSVScannerConfig([]OSVScannerOption{
+		WithOSVScannerFormat("sarif"),
+		WithOSVScannerRecursive(true),
+	})
+	args = osvScannerDirectoryArgs(config, "/workspace")
+	expected = []string{osvScannerBinary, "--format", "sarif", "--recursive", "/workspace"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestOSVScannerLockfileArgs(t *testing.T) {
+	config := newOSVScannerConfig([]OSVScannerOption{WithOSVScannerFormat("json")})
+	args := osvScannerLockfileArgs(config, "/workspace/package-lock.json")
+	expected := []string{osvScannerBinary, "--format", "json", "--lockfile", "/workspace/package-lock.json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
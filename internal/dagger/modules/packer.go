@@ -25,12 +25,11 @@ func NewPackerModule(client *dagger.Client) *PackerModule {
 
 // BuildImage builds an image using Packer
 func (m *PackerModule) BuildImage(ctx context.Context, templatePath string, varsFile string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templatePath))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templatePath))
 
 	if varsFile != "" {
-		container = container.WithFile("/vars.pkrvars.hcl", m.client.Host().File(varsFile))
+		container = container.WithFile("/vars.pkrvars.hcl", hostFile(m.client, varsFile))
 	}
 
 	args := []string{packerBinary, "build"}
@@ -51,9 +50,8 @@ func (m *PackerModule) BuildImage(ctx context.Context, templatePath string, vars
 
 // ValidateTemplate validates a Packer template
 func (m *PackerModule) ValidateTemplate(ctx context.Context, templatePath string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templatePath)).
 		WithExec([]string{
 			packerBinary, "validate",
 			"/template.pkr.hcl",
@@ -69,9 +67,8 @@ func (m *PackerModule) ValidateTemplate(ctx context.Context, templatePath string
 
 // FormatTemplate formats a Packer template
 func (m *PackerModule) FormatTemplate(ctx context.Context, templatePath string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templatePath)).
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templatePath)).
 		WithExec([]string{
 			packerBinary, "fmt",
 			"/template.pkr.hcl",
@@ -87,8 +84,7 @@ func (m *PackerModule) FormatTemplate(ctx context.Context, templatePath string)
 
 // GetVersion returns the version of Packer
 func (m *PackerModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
+	container := toolContainer(m.client, "hashicorp/packer:latest").
 		WithExec([]string{packerBinary, "version"})
 
 	output, err := container.Stdout(ctx)
@@ -101,9 +97,8 @@ func (m *PackerModule) GetVersion(ctx context.Context) (string, error) {
 
 // InspectTemplate inspects and analyzes Packer template configuration
 func (m *PackerModule) InspectTemplate(ctx context.Context, templatePath string, machineReadable bool) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templatePath))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templatePath))
 
 	args := []string{packerBinary, "inspect"}
 	if machineReadable {
@@ -123,9 +118,8 @@ func (m *PackerModule) InspectTemplate(ctx context.Context, templatePath string,
 
 // FixTemplate fixes and upgrades Packer template to current version
 func (m *PackerModule) FixTemplate(ctx context.Context, templatePath string, validate bool) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templatePath))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templatePath))
 
 	args := []string{packerBinary, "fix"}
 	if validate {
@@ -145,9 +139,8 @@ func (m *PackerModule) FixTemplate(ctx context.Context, templatePath string, val
 
 // InitConfiguration initializes Packer configuration and installs required plugins
 func (m *PackerModule) InitConfiguration(ctx context.Context, configFile string, upgrade bool) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/config.pkr.hcl", m.client.Host().File(configFile))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/config.pkr.hcl", hostFile(m.client, configFile))
 
 	args := []string{packerBinary, "init"}
 	if upgrade {
@@ -167,11 +160,10 @@ func (m *PackerModule) InitConfiguration(ctx context.Context, configFile string,
 
 // ManagePlugins manages Packer plugins (install, remove, required)
 func (m *PackerModule) ManagePlugins(ctx context.Context, subcommand string, pluginName string, version string, configFile string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest")
+	container := toolContainer(m.client, "hashicorp/packer:latest")
 
 	if configFile != "" {
-		container = container.WithFile("/config.pkr.hcl", m.client.Host().File(configFile))
+		container = container.WithFile("/config.pkr.hcl", hostFile(m.client, configFile))
 	}
 
 	args := []string{packerBinary, "plugins", subcommand}
@@ -201,9 +193,8 @@ func (m *PackerModule) ManagePlugins(ctx context.Context, subcommand string, plu
 
 // HCL2Upgrade upgrades JSON Packer template to HCL2
 func (m *PackerModule) HCL2Upgrade(ctx context.Context, templateFile string, outputFile string, withAnnotations bool) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.json", m.client.Host().File(templateFile))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.json", hostFile(m.client, templateFile))
 
 	args := []string{packerBinary, "hcl2_upgrade"}
 	if outputFile != "" {
@@ -226,12 +217,11 @@ func (m *PackerModule) HCL2Upgrade(ctx context.Context, templateFile string, out
 
 // Console opens Packer console for template debugging
 func (m *PackerModule) Console(ctx context.Context, templateFile string, vars string, varFile string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/packer:latest").
-		WithFile("/template.pkr.hcl", m.client.Host().File(templateFile))
+	container := toolContainer(m.client, "hashicorp/packer:latest").
+		WithFile("/template.pkr.hcl", hostFile(m.client, templateFile))
 
 	if varFile != "" {
-		container = container.WithFile("/vars.pkrvars.hcl", m.client.Host().File(varFile))
+		container = container.WithFile("/vars.pkrvars.hcl", hostFile(m.client, varFile))
 	}
 
 	args := []string{packerBinary, "console"}
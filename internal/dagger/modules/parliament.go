@@ -24,10 +24,9 @@ func NewParliamentModule(client *dagger.Client) *ParliamentModule {
 
 // LintPolicyFile lints a specific IAM policy file
 func (m *ParliamentModule) LintPolicyFile(ctx context.Context, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath)).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{parliamentBinary, "--file", "policy.json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -49,10 +48,9 @@ func (m *ParliamentModule) LintPolicyFile(ctx context.Context, policyPath string
 
 // LintPolicyDirectory lints all policy files in a directory
 func (m *ParliamentModule) LintPolicyDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{parliamentBinary, "--directory", "."}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -73,8 +71,7 @@ func (m *ParliamentModule) LintPolicyDirectory(ctx context.Context, dir string)
 
 // LintPolicyString lints a policy provided as a string
 func (m *ParliamentModule) LintPolicyString(ctx context.Context, policyJSON string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
 		WithExec([]string{parliamentBinary, "--string", policyJSON}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -94,10 +91,9 @@ func (m *ParliamentModule) LintPolicyString(ctx context.Context, policyJSON stri
 
 // LintWithCommunityAuditors lints using community auditors
 func (m *ParliamentModule) LintWithCommunityAuditors(ctx context.Context, policyPath string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath)).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{parliamentBinary, "--file", "policy.json", "--include-community-auditors"})
 
@@ -115,11 +111,10 @@ func (m *ParliamentModule) LintWithCommunityAuditors(ctx context.Context, policy
 
 // LintWithPrivateAuditors lints using private auditors
 func (m *ParliamentModule) LintWithPrivateAuditors(ctx context.Context, policyPath string, auditorsPath string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath)).
-		WithDirectory("/workspace/auditors", m.client.Host().Directory(auditorsPath)).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
+		WithDirectory("/workspace/auditors", hostDirectory(m.client, auditorsPath)).
 		WithWorkdir("/workspace").
 		WithExec([]string{parliamentBinary, "--file", "policy.json", "--private-auditors-dir", "auditors"})
 
@@ -143,10 +138,9 @@ func (m *ParliamentModule) LintWithSeverityFilter(ctx context.Context, policyPat
 		args = append(args, "--minimum-severity", minSeverity)
 	}
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath)).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
 		WithWorkdir("/workspace").
 		WithExec(args)
 
@@ -164,8 +158,7 @@ func (m *ParliamentModule) LintWithSeverityFilter(ctx context.Context, policyPat
 
 // LintAWSManagedPolicies lints AWS managed policies
 func (m *ParliamentModule) LintAWSManagedPolicies(ctx context.Context, config string, jsonOutput bool) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"})
 
 	// Create a simple test policy directory structure for AWS managed policies
@@ -182,7 +175,7 @@ func (m *ParliamentModule) LintAWSManagedPolicies(ctx context.Context, config st
 
 	args := []string{parliamentBinary, "--file", "/workspace/test_policy.json"}
 	if config != "" {
-		container = container.WithFile("/workspace/config.yaml", m.client.Host().File(config))
+		container = container.WithFile("/workspace/config.yaml", hostFile(m.client, config))
 		args = append(args, "--config", "/workspace/config.yaml")
 	}
 	if jsonOutput {
@@ -205,14 +198,13 @@ func (m *ParliamentModule) LintAWSManagedPolicies(ctx context.Context, config st
 
 // LintAuthDetailsFile lints AWS IAM authorization details file
 func (m *ParliamentModule) LintAuthDetailsFile(ctx context.Context, authDetailsFile string, config string, jsonOutput bool) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/auth_details.json", m.client.Host().File(authDetailsFile))
+		WithFile("/workspace/auth_details.json", hostFile(m.client, authDetailsFile))
 
 	args := []string{parliamentBinary, "--auth-details-file", "/workspace/auth_details.json"}
 	if config != "" {
-		container = container.WithFile("/workspace/config.yaml", m.client.Host().File(config))
+		container = container.WithFile("/workspace/config.yaml", hostFile(m.client, config))
 		args = append(args, "--config", "/workspace/config.yaml")
 	}
 	if jsonOutput {
@@ -235,16 +227,15 @@ func (m *ParliamentModule) LintAuthDetailsFile(ctx context.Context, authDetailsF
 
 // ComprehensiveAnalysis performs comprehensive IAM policy analysis with all auditors
 func (m *ParliamentModule) ComprehensiveAnalysis(ctx context.Context, policyPath string, privateAuditors string, config string, jsonOutput bool) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithFile("/workspace/policy.json", m.client.Host().File(policyPath))
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath))
 
 	if privateAuditors != "" {
-		container = container.WithDirectory("/workspace/auditors", m.client.Host().Directory(privateAuditors))
+		container = container.WithDirectory("/workspace/auditors", hostDirectory(m.client, privateAuditors))
 	}
 	if config != "" {
-		container = container.WithFile("/workspace/config.yaml", m.client.Host().File(config))
+		container = container.WithFile("/workspace/config.yaml", hostFile(m.client, config))
 	}
 
 	args := []string{parliamentBinary, "--file", "/workspace/policy.json", "--include-community-auditors"}
@@ -274,16 +265,15 @@ func (m *ParliamentModule) ComprehensiveAnalysis(ctx context.Context, policyPath
 
 // BatchDirectoryAnalysis performs batch analysis of multiple policy directories
 func (m *ParliamentModule) BatchDirectoryAnalysis(ctx context.Context, baseDirectory string, config string, privateAuditors string, jsonOutput bool, includeExtension string, excludePattern string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
-		WithDirectory("/workspace", m.client.Host().Directory(baseDirectory))
+		WithDirectory("/workspace", hostDirectory(m.client, baseDirectory))
 
 	if privateAuditors != "" {
-		container = container.WithDirectory("/workspace/auditors", m.client.Host().Directory(privateAuditors))
+		container = container.WithDirectory("/workspace/auditors", hostDirectory(m.client, privateAuditors))
 	}
 	if config != "" {
-		container = container.WithFile("/workspace/config.yaml", m.client.Host().File(config))
+		container = container.WithFile("/workspace/config.yaml", hostFile(m.client, config))
 	}
 
 	args := []string{parliamentBinary, "--directory", "/workspace", "--include-community-auditors"}
@@ -315,4 +305,79 @@ func (m *ParliamentModule) BatchDirectoryAnalysis(ctx context.Context, baseDirec
 	}
 
 	return output, nil
-}
\ No newline at end of file
+}
+
+// LintPolicy lints a policy file with the given options
+func (m *ParliamentModule) LintPolicy(ctx context.Context, policyPath string, opts ...ParliamentOption) (string, error) {
+	config := newParliamentConfig(opts)
+
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
+		WithFile("/workspace/policy.json", hostFile(m.client, policyPath)).
+		WithWorkdir("/workspace").
+		WithExec(parliamentLintArgs(config, "policy.json"), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	return parliamentOutput(ctx, container)
+}
+
+// LintPolicyStdin lints an inline policy document by piping it to parliament on stdin
+func (m *ParliamentModule) LintPolicyStdin(ctx context.Context, policyJSON string, opts ...ParliamentOption) (string, error) {
+	config := newParliamentConfig(opts)
+
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"pip", "install", "--no-cache-dir", "parliament"}).
+		WithExec(parliamentLintArgs(config, "-"), dagger.ContainerWithExecOpts{
+			Stdin:  policyJSON,
+			Expect: "ANY",
+		})
+
+	return parliamentOutput(ctx, container)
+}
+
+func parliamentOutput(ctx context.Context, container *dagger.Container) (string, error) {
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "Policy linting completed", nil
+}
+
+func newParliamentConfig(opts []ParliamentOption) *ParliamentConfig {
+	config := &ParliamentConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// parliamentLintArgs builds the parliament command line; a file of "-" reads stdin
+func parliamentLintArgs(config *ParliamentConfig, file string) []string {
+	args := []string{parliamentBinary, "--file", file}
+	if config.JSON {
+		args = append(args, "--json")
+	}
+	return args
+}
+
+// ParliamentConfig holds the settings for a Parliament run
+type ParliamentConfig struct {
+	JSON bool
+}
+
+// ParliamentOption sets a field of ParliamentConfig
+type ParliamentOption func(*ParliamentConfig)
+
+// WithParliamentJSON emits findings as JSON, one object per line
+func WithParliamentJSON(jsonOutput bool) ParliamentOption {
+	return func(c *ParliamentConfig) {
+		c.JSON = jsonOutput
+	}
+}
@@ -0,0 +1,24 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParliamentLintArgs_JSON(t *testing.T) {
+	config := newParliamentConfig([]ParliamentOption{WithParliamentJSON(true)})
+
+	args := parliamentLintArgs(config, "policy.json")
+	expected := []string{parliamentBinary, "--file", "policy.json", "--json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestParliamentLintArgs_Stdin(t *testing.T) {
+	args := parliamentLintArgs(newParliamentConfig(nil), "-")
+	expected := []string{parliamentBinary, "--file", "-"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"dagger.io/dagger"
 )
@@ -26,8 +27,7 @@ func NewPMapperModule(client *dagger.Client) *PMapperModule {
 
 // CreateGraph creates a privilege graph for an AWS account
 func (m *PMapperModule) CreateGraph(ctx context.Context, profile string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -58,8 +58,7 @@ func (m *PMapperModule) CreateGraph(ctx context.Context, profile string) (string
 
 // QueryAccess queries if a principal can access a specific action/resource
 func (m *PMapperModule) QueryAccess(ctx context.Context, profile string, principal string, action string, resource string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -95,8 +94,7 @@ func (m *PMapperModule) QueryAccess(ctx context.Context, profile string, princip
 
 // FindPrivilegeEscalation finds privilege escalation paths
 func (m *PMapperModule) FindPrivilegeEscalation(ctx context.Context, profile string, principal string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -125,10 +123,28 @@ func (m *PMapperModule) FindPrivilegeEscalation(ctx context.Context, profile str
 	return output, nil
 }
 
-// VisualizeGraph creates a visual representation of the privilege graph
-func (m *PMapperModule) VisualizeGraph(ctx context.Context, profile string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+// VisualizeGraph creates a visual representation of the privilege graph.
+// Text formats (dot, graphml) return the rendered graph; image formats (png, svg)
+// are exported to the output directory and the artifact path is returned.
+func (m *PMapperModule) VisualizeGraph(ctx context.Context, profile string, outputFormat string, opts ...PMapperOption) (string, error) {
+	config := &PMapperConfig{
+		OutputDir: ".",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if outputFormat == "" {
+		outputFormat = "svg"
+	}
+	args, err := pmapperVisualizeArgs(outputFormat)
+	if err != nil {
+		return "", err
+	}
+
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"apt-get", "update"}).
+		WithExec([]string{"apt-get", "install", "-y", "--no-install-recommends", "graphviz"}).
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -141,31 +157,77 @@ func (m *PMapperModule) VisualizeGraph(ctx context.Context, profile string, outp
 			WithEnvVariable("AWS_REGION", os.Getenv("AWS_REGION"))
 	}
 
-	args := []string{pmapperBinary, "visualize"}
-	if outputFormat != "" {
-		args = append(args, "--format", outputFormat)
-	}
-
-	container = container.WithExec(args, dagger.ContainerWithExecOpts{
-		Expect: "ANY",
-	})
+	// visualize reads the stored graph, so it has to be created in the same container
+	container = container.
+		WithWorkdir(pmapperOutputDir).
+		WithExec([]string{pmapperBinary, "graph", "create"}).
+		WithExec(args)
 
-	output, err := container.Stdout(ctx)
+	outputs := container.Directory(pmapperOutputDir)
+	files, err := outputs.Glob(ctx, "*."+outputFormat)
 	if err != nil {
+		return "", fmt.Errorf("failed to locate visualization output: %w", err)
+	}
+	if len(files) == 0 {
 		stderr, _ := container.Stderr(ctx)
-		if stderr != "" {
-			return stderr, nil
-		}
-		return "", fmt.Errorf("failed to visualize graph: %w", err)
+		return "", fmt.Errorf("pmapper produced no %s output: %s", outputFormat, stderr)
 	}
 
-	return output, nil
+	artifact := outputs.File(files[0])
+	if pmapperTextFormats[outputFormat] {
+		return artifact.Contents(ctx)
+	}
+
+	path := pmapperArtifactPath(config.OutputDir, files[0])
+	if _, err := artifact.Export(ctx, path); err != nil {
+		return "", fmt.Errorf("failed to export visualization: %w", err)
+	}
+	return path, nil
+}
+
+// pmapperOutputDir is the working directory pmapper writes visualizations to
+const pmapperOutputDir = "/workspace"
+
+// pmapperTextFormats are visualization formats returned inline rather than exported
+var pmapperTextFormats = map[string]bool{
+	"dot":     true,
+	"graphml": true,
+}
+
+// pmapperVisualizeArgs builds the pmapper visualize command for a file type
+func pmapperVisualizeArgs(format string) ([]string, error) {
+	switch format {
+	case "dot", "graphml", "png", "svg":
+		return []string{pmapperBinary, "visualize", "--filetype", format}, nil
+	default:
+		return nil, fmt.Errorf("unsupported pmapper visualize format %q: must be dot, graphml, png or svg", format)
+	}
+}
+
+// pmapperArtifactPath returns where an exported visualization is written on
+// the host; a relative output directory is resolved against the working directory
+func pmapperArtifactPath(outputDir, fileName string) string {
+	return filepath.Join(HostPath(outputDir), filepath.Base(fileName))
+}
+
+// PMapperConfig holds the settings for a PMapper run
+type PMapperConfig struct {
+	OutputDir string
+}
+
+// PMapperOption sets a field of PMapperConfig
+type PMapperOption func(*PMapperConfig)
+
+// WithPMapperOutputDir sets the host directory image visualizations are exported to
+func WithPMapperOutputDir(dir string) PMapperOption {
+	return func(c *PMapperConfig) {
+		c.OutputDir = dir
+	}
 }
 
 // ListPrincipals lists all principals in the AWS account
 func (m *PMapperModule) ListPrincipals(ctx context.Context, profile string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -196,8 +258,7 @@ func (m *PMapperModule) ListPrincipals(ctx context.Context, profile string) (str
 
 // CheckAdminAccess checks if a principal has admin access
 func (m *PMapperModule) CheckAdminAccess(ctx context.Context, profile string, principal string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "principalmapper"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
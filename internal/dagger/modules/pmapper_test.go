@@ -0,0 +1,55 @@
+package modules
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPMapperVisualizeArgs(t *testing.T) {
+	for _, format := range []string{"dot", "graphml", "png", "svg"} {
+		args, err := pmapperVisualizeArgs(format)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", format, err)
+		}
+		expected := []string{pmapperBinary, "visualize", "--filetype", format}
+		if !reflect.DeepEqual(args, expected) {
+			t.Errorf("Expected args %v, got %v", expected, args)
+		}
+	}
+
+	if _, err := pmapperVisualizeArgs("pdf"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestPMapperTextFormats(t *testing.T) {
+	for format, text := range map[string]bool{"dot": true, "graphml": true, "png": false, "svg": false} {
+		if pmapperTextFormats[format] != text {
+			t.Errorf("Expected %s text output to be %v", format, text)
+		}
+	}
+}
+
+func TestPMapperArtifactPath(t *testing.T) {
+	if got := pmapperArtifactPath("", "123456789012.png"); got != "123456789012.png" {
+		t.Errorf("Expected artifact in current directory, got %q", got)
+	}
+
+	expected := filepath.Join("reports", "123456789012.png")
+	if got := pmapperArtifactPath("reports", "123456789012.png"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestPMapperArtifactPath_WorkingDir(t *testing.T) {
+	t.Setenv(WorkingDirEnv, "/src/project")
+
+	expected := filepath.Join("/src/project", "reports", "123456789012.png")
+	if got := pmapperArtifactPath("reports", "123456789012.png"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := pmapperArtifactPath("/tmp/graphs", "123456789012.png"); got != "/tmp/graphs/123456789012.png" {
+		t.Errorf("Expected absolute output dir to be kept, got %q", got)
+	}
+}
@@ -13,26 +13,22 @@ type PolicySentryModule struct {
 	name   string
 }
 
-const policySentryBinary = "policy-sentry"
+// policySentryBinary is the command the policy-sentry package installs
+const policySentryBinary = "policy_sentry"
 
 // NewPolicySentryModule creates a new Policy Sentry module
 func NewPolicySentryModule(client *dagger.Client) *PolicySentryModule {
 	return &PolicySentryModule{
 		client: client,
-		name:   policySentryBinary,
+		name:   "policy-sentry",
 	}
 }
 
 // CreateTemplate creates a policy template
 func (m *PolicySentryModule) CreateTemplate(ctx context.Context, templateType string, outputFile string) (string, error) {
-	args := []string{policySentryBinary, "create-template", "--template-type", templateType}
-	
-	if outputFile != "" {
-		args = append(args, "--output-file", outputFile)
-	}
+	args := policySentryCreateTemplateArgs(templateType, outputFile)
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -55,12 +51,11 @@ func (m *PolicySentryModule) CreateTemplate(ctx context.Context, templateType st
 
 // WritePolicy writes an IAM policy from a YAML template
 func (m *PolicySentryModule) WritePolicy(ctx context.Context, inputFile string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/workspace/input.yml", m.client.Host().File(inputFile)).
+		WithFile("/workspace/input.yml", hostFile(m.client, inputFile)).
 		WithWorkdir("/workspace").
 		WithExec([]string{policySentryBinary, "write-policy", "--input-file", "input.yml"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -80,8 +75,7 @@ func (m *PolicySentryModule) WritePolicy(ctx context.Context, inputFile string)
 
 // WritePolicyFromTemplate writes a policy from an inline template
 func (m *PolicySentryModule) WritePolicyFromTemplate(ctx context.Context, templateYAML string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -121,8 +115,7 @@ actions:
 		}
 	}
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -158,8 +151,7 @@ crud:
 		}
 	}
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -183,8 +175,7 @@ crud:
 
 // QueryActionTable queries the action table for service information
 func (m *PolicySentryModule) QueryActionTable(ctx context.Context, service string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -206,8 +197,7 @@ func (m *PolicySentryModule) QueryActionTable(ctx context.Context, service strin
 
 // QueryConditionTable queries the condition table for service information
 func (m *PolicySentryModule) QueryConditionTable(ctx context.Context, service string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -225,4 +215,81 @@ func (m *PolicySentryModule) QueryConditionTable(ctx context.Context, service st
 	}
 
 	return output, nil
-}
\ No newline at end of file
+}
+
+// QueryArnTable lists the ARN formats for a service, optionally for a single resource type
+func (m *PolicySentryModule) QueryArnTable(ctx context.Context, service string, name string, listArnTypes bool, format string) (string, error) {
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		}).
+		WithExec(policySentryArnTableArgs(service, name, listArnTypes, format), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		if stderr != "" {
+			return stderr, nil
+		}
+		return "", fmt.Errorf("failed to query arn table: %w", err)
+	}
+
+	return output, nil
+}
+
+// QueryServiceTable lists all AWS services known to Policy Sentry
+func (m *PolicySentryModule) QueryServiceTable(ctx context.Context, format string) (string, error) {
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"pip", "install", "--no-cache-dir", "policy-sentry"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		}).
+		WithExec(policySentryServiceTableArgs(format), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		if stderr != "" {
+			return stderr, nil
+		}
+		return "", fmt.Errorf("failed to query service table: %w", err)
+	}
+
+	return output, nil
+}
+
+// policySentryCreateTemplateArgs builds the create-template command line
+func policySentryCreateTemplateArgs(templateType string, outputFile string) []string {
+	args := []string{policySentryBinary, "create-template", "--template-type", templateType}
+	if outputFile != "" {
+		args = append(args, "--output-file", outputFile)
+	}
+	return args
+}
+
+// policySentryArnTableArgs builds the query arn-table command line
+func policySentryArnTableArgs(service string, name string, listArnTypes bool, format string) []string {
+	args := []string{policySentryBinary, "query", "arn-table", "--service", service}
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if listArnTypes {
+		args = append(args, "--list-arn-types")
+	}
+	if format != "" {
+		args = append(args, "--fmt", format)
+	}
+	return args
+}
+
+// policySentryServiceTableArgs builds the query service-table command line
+func policySentryServiceTableArgs(format string) []string {
+	args := []string{policySentryBinary, "query", "service-table"}
+	if format != "" {
+		args = append(args, "--fmt", format)
+	}
+	return args
+}
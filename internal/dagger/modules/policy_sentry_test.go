@@ -0,0 +1,42 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicySentryCreateTemplateArgs(t *testing.T) {
+	args := policySentryCreateTemplateArgs("crud", "template.yml")
+	expected := []string{"policy_sentry", "create-template", "--template-type", "crud", "--output-file", "template.yml"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	args = policySentryCreateTemplateArgs("actions", "")
+	expected = []string{"policy_sentry", "create-template", "--template-type", "actions"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestPolicySentryArnTableArgs(t *testing.T) {
+	args := policySentryArnTableArgs("s3", "bucket", true, "json")
+	expected := []string{"policy_sentry", "query", "arn-table", "--service", "s3", "--name", "bucket", "--list-arn-types", "--fmt", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	args = policySentryArnTableArgs("s3", "", false, "")
+	expected = []string{"policy_sentry", "query", "arn-table", "--service", "s3"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestPolicySentryServiceTableArgs(t *testing.T) {
+	args := policySentryServiceTableArgs("csv")
+	expected := []string{"policy_sentry", "query", "service-table", "--fmt", "csv"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
@@ -26,12 +26,11 @@ func NewPowerpipeModule(client *dagger.Client) *PowerpipeModule {
 
 // RunBenchmark runs a security benchmark
 func (m *PowerpipeModule) RunBenchmark(ctx context.Context, benchmark string, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+			WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace").
 			WithExec([]string{"sh", "-c", "powerpipe mod init 2>/dev/null || true"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
@@ -69,12 +68,11 @@ func (m *PowerpipeModule) RunBenchmark(ctx context.Context, benchmark string, mo
 
 // RunControl runs a specific control
 func (m *PowerpipeModule) RunControl(ctx context.Context, control string, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+			WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace").
 			WithExec([]string{"sh", "-c", "powerpipe mod init 2>/dev/null || true"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
@@ -112,12 +110,11 @@ func (m *PowerpipeModule) RunControl(ctx context.Context, control string, modPat
 
 // ListBenchmarks lists available benchmarks
 func (m *PowerpipeModule) ListBenchmarks(ctx context.Context, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+			WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace").
 			WithExec([]string{"sh", "-c", "powerpipe mod init 2>/dev/null || true"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
@@ -142,8 +139,7 @@ func (m *PowerpipeModule) ListBenchmarks(ctx context.Context, modPath string) (s
 
 // GetVersion returns the version of Powerpipe
 func (m *PowerpipeModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest").
 		WithExec([]string{powerpipeBinary, "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -158,12 +154,11 @@ func (m *PowerpipeModule) GetVersion(ctx context.Context) (string, error) {
 
 // RunQuery executes a Powerpipe query
 func (m *PowerpipeModule) RunQuery(ctx context.Context, query string, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+			WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace").
 			WithExec([]string{"sh", "-c", "powerpipe mod init 2>/dev/null || true"}, dagger.ContainerWithExecOpts{
 				Expect: "ANY",
@@ -188,11 +183,10 @@ func (m *PowerpipeModule) RunQuery(ctx context.Context, query string, modPath st
 
 // ListQueries lists available queries
 func (m *PowerpipeModule) ListQueries(ctx context.Context, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace")
 	}
 
@@ -214,11 +208,10 @@ func (m *PowerpipeModule) ListQueries(ctx context.Context, modPath string) (stri
 
 // StartServer starts the Powerpipe server
 func (m *PowerpipeModule) StartServer(ctx context.Context, modPath string, port int) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace")
 	}
 
@@ -241,11 +234,10 @@ func (m *PowerpipeModule) StartServer(ctx context.Context, modPath string, port
 
 // ListDashboards lists available dashboards
 func (m *PowerpipeModule) ListDashboards(ctx context.Context, modPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/powerpipe:latest")
+	container := toolContainer(m.client, "ghcr.io/turbot/powerpipe:latest")
 
 	if modPath != "" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(modPath)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, modPath)).
 			WithWorkdir("/workspace")
 	}
 
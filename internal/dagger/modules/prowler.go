@@ -27,8 +27,7 @@ func NewProwlerModule(client *dagger.Client) *ProwlerModule {
 // ScanAWS scans AWS infrastructure for security issues
 func (m *ProwlerModule) ScanAWS(ctx context.Context, provider string, region string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -50,8 +49,7 @@ func (m *ProwlerModule) ScanAWS(ctx context.Context, provider string, region str
 // ScanAzure scans Azure infrastructure for security issues
 func (m *ProwlerModule) ScanAzure(ctx context.Context) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -74,8 +72,7 @@ func (m *ProwlerModule) ScanAzure(ctx context.Context) (string, error) {
 // ScanGCP scans Google Cloud Platform for security issues
 func (m *ProwlerModule) ScanGCP(ctx context.Context, projectId string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -95,12 +92,11 @@ func (m *ProwlerModule) ScanGCP(ctx context.Context, projectId string) (string,
 // ScanKubernetes scans Kubernetes cluster for security issues
 func (m *ProwlerModule) ScanKubernetes(ctx context.Context, kubeconfigPath string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/tmp/kubeconfig", m.client.Host().File(kubeconfigPath)).
+		WithFile("/tmp/kubeconfig", hostFile(m.client, kubeconfigPath)).
 		WithEnvVariable("KUBECONFIG", "/tmp/kubeconfig").
 		WithExec([]string{prowlerBinary, "kubernetes", "--output-format", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -117,8 +113,7 @@ func (m *ProwlerModule) ScanKubernetes(ctx context.Context, kubeconfigPath strin
 // ScanWithCompliance scans with specific compliance frameworks
 func (m *ProwlerModule) ScanWithCompliance(ctx context.Context, provider string, compliance string, region string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -140,8 +135,7 @@ func (m *ProwlerModule) ScanWithCompliance(ctx context.Context, provider string,
 // ScanSpecificServices scans specific cloud services
 func (m *ProwlerModule) ScanSpecificServices(ctx context.Context, provider string, services string, region string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -168,8 +162,7 @@ func (m *ProwlerModule) ListChecks(ctx context.Context, provider string) (string
 	}
 
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -193,8 +186,7 @@ func (m *ProwlerModule) ListServices(ctx context.Context, provider string) (stri
 	}
 
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -218,8 +210,7 @@ func (m *ProwlerModule) ListCompliance(ctx context.Context, provider string) (st
 	}
 
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -238,12 +229,11 @@ func (m *ProwlerModule) ListCompliance(ctx context.Context, provider string) (st
 // GenerateDashboard generates HTML dashboard from scan results
 func (m *ProwlerModule) GenerateDashboard(ctx context.Context, inputFile string, outputDir string) (string, error) {
 	// Use Python base image and install Prowler since official image doesn't support ARM64
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/workspace/input.json", m.client.Host().File(inputFile)).
+		WithFile("/workspace/input.json", hostFile(m.client, inputFile)).
 		WithWorkdir("/workspace").
 		WithExec([]string{prowlerBinary, "dashboard", "--input", "input.json", "--output-dir", outputDir}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -255,4 +245,138 @@ func (m *ProwlerModule) GenerateDashboard(ctx context.Context, inputFile string,
 	}
 
 	return output, nil
-}
\ No newline at end of file
+}
+
+// prowlerMutelistMount is where the mutelist file is mounted inside the container
+const prowlerMutelistMount = "/workspace/mutelist.yaml"
+
+// prowlerCredentialsMount is where a provider's credentials file is mounted
+const prowlerCredentialsMount = "/workspace/credentials.json"
+
+// prowlerCredentialEnv lists, per provider, the host variables that carry its
+// credentials into the container
+var prowlerCredentialEnv = map[string][]string{
+	"aws":   {"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_REGION"},
+	"azure": {"AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET"},
+	"gcp":   {},
+}
+
+// prowlerCredentialFileEnv names, per provider, the host variable holding the
+// path of a credentials file. The file is mounted and the variable points at
+// the mount inside the container.
+var prowlerCredentialFileEnv = map[string]string{
+	"gcp": "GOOGLE_APPLICATION_CREDENTIALS",
+}
+
+// Scan assesses a cloud provider (aws, azure or gcp) with Prowler. Findings
+// accepted in a mutelist, set with WithProwlerMutelist, are reported as muted.
+func (m *ProwlerModule) Scan(ctx context.Context, provider string, opts ...ProwlerOption) (string, error) {
+	credentials, ok := prowlerCredentialEnv[provider]
+	if !ok {
+		return "", fmt.Errorf("unsupported prowler provider %q: expected aws, azure or gcp", provider)
+	}
+	config := newProwlerConfig(opts)
+	if config.Region != "" && provider != "aws" {
+		return "", fmt.Errorf("a prowler region only applies to aws, not %s", provider)
+	}
+
+	// Use Python base image and install Prowler since official image doesn't support ARM64
+	container := toolContainer(m.client, "python:3.11-slim").
+		WithExec([]string{"pip", "install", "--no-cache-dir", "prowler"}, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+	for _, name := range credentials {
+		if value := os.Getenv(name); value != "" {
+			container = container.WithEnvVariable(name, value)
+		}
+	}
+	container, err := withProwlerCredentialsFile(m.client, container, provider)
+	if err != nil {
+		return "", err
+	}
+	if config.MutelistFile != "" {
+		container = container.WithFile(prowlerMutelistMount, hostFile(m.client, config.MutelistFile))
+	}
+
+	container = container.WithExec(prowlerScanArgs(provider, config), dagger.ContainerWithExecOpts{
+		// prowler exits non-zero when checks fail
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return stderr, nil
+	}
+
+	return "", fmt.Errorf("failed to run prowler %s scan: no output received", provider)
+}
+
+// withProwlerCredentialsFile mounts the provider's credentials file, named by
+// a host variable such as GOOGLE_APPLICATION_CREDENTIALS, as a secret and
+// points the variable at the mount
+func withProwlerCredentialsFile(client *dagger.Client, container *dagger.Container, provider string) (*dagger.Container, error) {
+	name, ok := prowlerCredentialFileEnv[provider]
+	if !ok {
+		return container, nil
+	}
+	path := os.Getenv(name)
+	if path == "" {
+		return container, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s credentials %s: %w", provider, path, err)
+	}
+
+	return container.
+		WithMountedSecret(prowlerCredentialsMount, client.SetSecret(secretName(provider+"-credentials", string(content)), string(content))).
+		WithEnvVariable(name, prowlerCredentialsMount), nil
+}
+
+// prowlerScanArgs builds a prowler scan command line
+func prowlerScanArgs(provider string, config *ProwlerConfig) []string {
+	args := []string{prowlerBinary, provider, "--output-format", "json"}
+	if config.Region != "" {
+		args = append(args, "--region", config.Region)
+	}
+	if config.MutelistFile != "" {
+		args = append(args, "--mutelist-file", prowlerMutelistMount)
+	}
+	return args
+}
+
+// ProwlerConfig holds the settings for a Prowler run
+type ProwlerConfig struct {
+	Region       string
+	MutelistFile string
+}
+
+// ProwlerOption sets a field of ProwlerConfig
+type ProwlerOption func(*ProwlerConfig)
+
+// WithProwlerRegion limits an AWS scan to one region
+func WithProwlerRegion(region string) ProwlerOption {
+	return func(c *ProwlerConfig) {
+		c.Region = region
+	}
+}
+
+// WithProwlerMutelist mounts a prowler mutelist YAML that mutes accepted findings
+func WithProwlerMutelist(path string) ProwlerOption {
+	return func(c *ProwlerConfig) {
+		c.MutelistFile = path
+	}
+}
+
+func newProwlerConfig(opts []ProwlerOption) *ProwlerConfig {
+	config := &ProwlerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
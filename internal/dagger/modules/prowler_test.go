@@ -0,0 +1,31 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProwlerScanArgs_Default(t *testing.T) {
+	args := prowlerScanArgs("aws", newProwlerConfig(nil))
+
+	expected := []string{"prowler", "aws", "--output-format", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestProwlerScanArgs_Mutelist(t *testing.T) {
+	config := newProwlerConfig([]ProwlerOption{
+		WithProwlerMutelist("./mutelist.yaml"),
+		WithProwlerRegion("eu-west-1"),
+	})
+	args := prowlerScanArgs("aws", config)
+
+	expected := []string{"prowler", "aws", "--output-format", "json", "--region", "eu-west-1", "--mutelist-file", "/workspace/mutelist.yaml"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+	if config.MutelistFile != "./mutelist.yaml" {
+		t.Errorf("Expected mutelist ./mutelist.yaml, got %s", config.MutelistFile)
+	}
+}
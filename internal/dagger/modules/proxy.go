@@ -0,0 +1,62 @@
+package modules
+
+import (
+	"os"
+
+	"dagger.io/dagger"
+)
+
+// NoProxyPassthroughEnv disables copying the host's proxy settings into tool
+// containers when set to a non-empty value
+const NoProxyPassthroughEnv = "SHIP_NO_PROXY_PASSTHROUGH"
+
+// proxyEnvNames are the proxy variables propagated from the host. Both cases
+// are copied because tools differ in which spelling they honor.
+var proxyEnvNames = []string{
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY",
+	"http_proxy", "https_proxy", "no_proxy",
+}
+
+// proxyEnvVar is a single proxy setting to set in a container
+type proxyEnvVar struct {
+	Name  string
+	Value string
+}
+
+// toolContainer starts a tool container from image with the host's proxy
+// settings and, in offline mode, the tools' offline settings applied. With
+// --no-cache it also gets a cache buster so its steps are not served from cache.
+func toolContainer(client *dagger.Client, image string) *dagger.Container {
+	return withToolEnv(client.Container().From(image))
+}
+
+// withToolEnv applies the settings toolContainer gives every tool container
+// to one that was not started from an image, such as a built Dockerfile
+func withToolEnv(container *dagger.Container) *dagger.Container {
+	container = withProxyEnv(container, proxyEnvVars(os.Getenv))
+	container = withOfflineEnv(container, offlineEnvVars(os.Getenv))
+	return withCacheBuster(container, cacheBuster(os.Getenv))
+}
+
+// proxyEnvVars returns the proxy settings to propagate, in a stable order
+func proxyEnvVars(getenv func(string) string) []proxyEnvVar {
+	if getenv(NoProxyPassthroughEnv) != "" {
+		return nil
+	}
+
+	var vars []proxyEnvVar
+	for _, name := range proxyEnvNames {
+		if value := getenv(name); value != "" {
+			vars = append(vars, proxyEnvVar{Name: name, Value: value})
+		}
+	}
+	return vars
+}
+
+// withProxyEnv sets the given proxy variables on container
+func withProxyEnv(container *dagger.Container, vars []proxyEnvVar) *dagger.Container {
+	for _, v := range vars {
+		container = container.WithEnvVariable(v.Name, v.Value)
+	}
+	return container
+}
@@ -0,0 +1,86 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"dagger.io/dagger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+func TestProxyEnvVars(t *testing.T) {
+	vars := proxyEnvVars(fakeEnv(map[string]string{
+		"HTTPS_PROXY": "http://proxy.internal:3128",
+		"no_proxy":    "localhost,.internal",
+		"HOME":        "/root",
+	}))
+
+	assert.Equal(t, []proxyEnvVar{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.internal:3128"},
+		{Name: "no_proxy", Value: "localhost,.internal"},
+	}, vars)
+}
+
+func TestProxyEnvVarsUnset(t *testing.T) {
+	assert.Empty(t, proxyEnvVars(fakeEnv(map[string]string{})))
+}
+
+func TestProxyEnvVarsOptOut(t *testing.T) {
+	vars := proxyEnvVars(fakeEnv(map[string]string{
+		"HTTPS_PROXY":         "http://proxy.internal:3128",
+		NoProxyPassthroughEnv: "1",
+	}))
+
+	assert.Empty(t, vars)
+}
+
+// TestToolContainerProxyEnv checks the proxy settings reach a real container spec
+func TestToolContainerProxyEnv(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Dagger integration test in short mode")
+	}
+
+	t.Setenv("HTTPS_PROXY", "http://proxy.internal:3128")
+	t.Setenv(NoProxyPassthroughEnv, "")
+
+	ctx := context.Background()
+	client, err := dagger.Connect(ctx, dagger.WithLogOutput(os.Stderr))
+	if err != nil {
+		t.Skipf("Dagger engine unavailable: %v", err)
+	}
+	defer client.Close()
+
+	value, err := toolContainer(client, "alpine:3.20").EnvVariable(ctx, "HTTPS_PROXY")
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.internal:3128", value)
+}
+
+// TestWithToolEnvBuiltContainer checks containers not started by toolContainer
+// get the same settings
+func TestWithToolEnvBuiltContainer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Dagger integration test in short mode")
+	}
+
+	t.Setenv("HTTPS_PROXY", "http://proxy.internal:3128")
+	t.Setenv(NoProxyPassthroughEnv, "")
+
+	ctx := context.Background()
+	client, err := dagger.Connect(ctx, dagger.WithLogOutput(os.Stderr))
+	if err != nil {
+		t.Skipf("Dagger engine unavailable: %v", err)
+	}
+	defer client.Close()
+
+	value, err := withToolEnv(client.Container()).EnvVariable(ctx, "HTTPS_PROXY")
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.internal:3128", value)
+}
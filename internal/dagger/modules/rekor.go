@@ -0,0 +1,95 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"dagger.io/dagger"
+)
+
+// RekorModule runs rekor-cli to query the Rekor transparency log
+type RekorModule struct {
+	client *dagger.Client
+	name   string
+}
+
+const rekorBinary = "rekor-cli"
+
+// rekorUUIDPattern matches a Rekor entry UUID, optionally prefixed with its
+// 16 character tree ID
+var rekorUUIDPattern = regexp.MustCompile(`^([0-9a-f]{16})?[0-9a-f]{64}$`)
+
+// NewRekorModule creates a new Rekor module
+func NewRekorModule(client *dagger.Client) *RekorModule {
+	return &RekorModule{
+		client: client,
+		name:   rekorBinary,
+	}
+}
+
+// GetEntry returns the transparency log entry with the given UUID as JSON,
+// including its inclusion proof. An empty server uses the public Rekor instance.
+func (m *RekorModule) GetEntry(ctx context.Context, uuid string, server string) (string, error) {
+	container := m.baseContainer().
+		WithExec(rekorGetArgs(uuid, server))
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		return "", fmt.Errorf("failed to get rekor entry %s: %w\nStderr: %s", uuid, err, stderr)
+	}
+
+	return output, nil
+}
+
+// SearchByHash returns the UUIDs of the transparency log entries for the
+// artifact with the given SHA-256 hash
+func (m *RekorModule) SearchByHash(ctx context.Context, hash string, server string) ([]string, error) {
+	container := m.baseContainer().
+		WithExec(rekorSearchArgs(hash, server))
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		return nil, fmt.Errorf("failed to search rekor for %s: %w\nStderr: %s", hash, err, stderr)
+	}
+
+	return rekorUUIDs(output), nil
+}
+
+func (m *RekorModule) baseContainer() *dagger.Container {
+	return toolContainer(m.client, getImageTag("rekor", "gcr.io/projectsigstore/rekor-cli:latest"))
+}
+
+// rekorGetArgs builds the rekor-cli get command line for an entry UUID
+func rekorGetArgs(uuid string, server string) []string {
+	args := []string{rekorBinary, "get", "--uuid", uuid, "--format", "json"}
+	if server != "" {
+		args = append(args, "--rekor_server", server)
+	}
+	return args
+}
+
+// rekorSearchArgs builds the rekor-cli search command line for an artifact
+// hash, given as sha256:<hex> or bare hex
+func rekorSearchArgs(hash string, server string) []string {
+	args := []string{rekorBinary, "search", "--sha", hash}
+	if server != "" {
+		args = append(args, "--rekor_server", server)
+	}
+	return args
+}
+
+// rekorUUIDs extracts the entry UUIDs listed by rekor-cli search
+func rekorUUIDs(output string) []string {
+	var uuids []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if rekorUUIDPattern.MatchString(line) {
+			uuids = append(uuids, line)
+		}
+	}
+	return uuids
+}
@@ -0,0 +1,54 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRekorGetArgs(t *testing.T) {
+	uuid := "24296fb24b8ad77a" + "8f6b4dc6a3b4b8e7c0f2fbbd1c1b3b2f9b0e5f2a4c7d3e1f0a9b8c7d6e5f4a3b"
+
+	args := rekorGetArgs(uuid, "")
+	expected := []string{rekorBinary, "get", "--uuid", uuid, "--format", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	args = rekorGetArgs(uuid, "https://rekor.example.com")
+	expected = append(expected, "--rekor_server", "https://rekor.example.com")
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args with server %v, got %v", expected, args)
+	}
+}
+
+func TestRekorSearchArgs(t *testing.T) {
+	hash := "sha256:8f6b4dc6a3b4b8e7c0f2fbbd1c1b3b2f9b0e5f2a4c7d3e1f0a9b8c7d6e5f4a3b"
+
+	args := rekorSearchArgs(hash, "")
+	expected := []string{rekorBinary, "search", "--sha", hash}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	args = rekorSearchArgs(hash, "https://rekor.example.com")
+	expected = append(expected, "--rekor_server", "https://rekor.example.com")
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args with server %v, got %v", expected, args)
+	}
+}
+
+func TestRekorUUIDs(t *testing.T) {
+	first := "8f6b4dc6a3b4b8e7c0f2fbbd1c1b3b2f9b0e5f2a4c7d3e1f0a9b8c7d6e5f4a3b"
+	second := "24296fb24b8ad77a" + first
+	output := "Found matching entries (listed by UUID):\n" + first + "\n" + second + "\n"
+
+	uuids := rekorUUIDs(output)
+	expected := []string{first, second}
+	if !reflect.DeepEqual(uuids, expected) {
+		t.Errorf("Expected uuids %v, got %v", expected, uuids)
+	}
+
+	if uuids := rekorUUIDs("no matching entries found\n"); len(uuids) != 0 {
+		t.Errorf("Expected no uuids, got %v", uuids)
+	}
+}
@@ -25,8 +25,7 @@ func NewScoutSuiteModule(client *dagger.Client) *ScoutSuiteModule {
 
 // ScanAWS scans AWS environment
 func (m *ScoutSuiteModule) ScanAWS(ctx context.Context, profile string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -50,8 +49,7 @@ func (m *ScoutSuiteModule) ScanAWS(ctx context.Context, profile string) (string,
 
 // ScanAzure scans Azure environment
 func (m *ScoutSuiteModule) ScanAzure(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -74,8 +72,7 @@ func (m *ScoutSuiteModule) ScanAzure(ctx context.Context) (string, error) {
 
 // ScanGCP scans Google Cloud Platform environment
 func (m *ScoutSuiteModule) ScanGCP(ctx context.Context, projectID string) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -100,8 +97,7 @@ func (m *ScoutSuiteModule) ScanGCP(ctx context.Context, projectID string) (strin
 
 // GetVersion returns the version of Scout Suite
 func (m *ScoutSuiteModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -131,8 +127,7 @@ func (m *ScoutSuiteModule) ServeReport(ctx context.Context, provider string, rep
 		args = append(args, "--port", port)
 	}
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
@@ -156,8 +151,7 @@ func (m *ScoutSuiteModule) Help(ctx context.Context, provider string) (string, e
 		args = []string{scoutBinary, provider, "--help"}
 	}
 
-	container := m.client.Container().
-		From("python:3.11-slim").
+	container := toolContainer(m.client, "python:3.11-slim").
 		WithExec([]string{"pip", "install", "scoutsuite"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
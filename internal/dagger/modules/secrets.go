@@ -0,0 +1,14 @@
+package modules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// secretName returns a Dagger secret name unique to value. The Dagger client
+// is shared by concurrent MCP calls and a secret name refers to one value per
+// session, so a fixed name could hand one scan another scan's credentials.
+func secretName(name, value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return name + "-" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,22 @@
+package modules
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretName(t *testing.T) {
+	first := secretName("dockle_password", "hunter2")
+	if !strings.HasPrefix(first, "dockle_password-") {
+		t.Errorf("Expected the name to keep its prefix, got %q", first)
+	}
+	if strings.Contains(first, "hunter2") {
+		t.Errorf("Expected the name not to contain the secret, got %q", first)
+	}
+	if again := secretName("dockle_password", "hunter2"); again != first {
+		t.Errorf("Expected a stable name for the same value, got %q and %q", first, again)
+	}
+	if other := secretName("dockle_password", "correct-horse"); other == first {
+		t.Errorf("Expected different values to get different names, got %q", other)
+	}
+}
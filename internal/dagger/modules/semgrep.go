@@ -23,9 +23,8 @@ func NewSemgrepModule(client *dagger.Client) *SemgrepModule {
 
 // ScanDirectory scans a directory with Semgrep rules
 func (m *SemgrepModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, dir)).
 		WithWorkdir("/src").
 		WithExec([]string{
 			"semgrep",
@@ -47,9 +46,8 @@ func (m *SemgrepModule) ScanDirectory(ctx context.Context, dir string) (string,
 
 // ScanWithRuleset scans with specific ruleset
 func (m *SemgrepModule) ScanWithRuleset(ctx context.Context, dir string, ruleset string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, dir)).
 		WithWorkdir("/src").
 		WithExec([]string{
 			"semgrep",
@@ -70,9 +68,8 @@ func (m *SemgrepModule) ScanWithRuleset(ctx context.Context, dir string, ruleset
 
 // ScanFile scans a specific file
 func (m *SemgrepModule) ScanFile(ctx context.Context, filePath string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithFile("/workspace/target.file", m.client.Host().File(filePath)).
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithFile("/workspace/target.file", hostFile(m.client, filePath)).
 		WithWorkdir("/src").
 		WithExec([]string{
 			"semgrep",
@@ -93,8 +90,7 @@ func (m *SemgrepModule) ScanFile(ctx context.Context, filePath string) (string,
 
 // GetVersion returns the version of Semgrep
 func (m *SemgrepModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
 		WithExec([]string{"semgrep", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -109,9 +105,8 @@ func (m *SemgrepModule) GetVersion(ctx context.Context) (string, error) {
 
 // LanguageSpecificScan performs language-specific security analysis
 func (m *SemgrepModule) LanguageSpecificScan(ctx context.Context, target string, language string, securityCategory string, outputFormat string, includeExperimental bool, confidence string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	args := []string{"semgrep", "scan", "/src", "--config", "p/" + language}
 	if securityCategory != "" {
@@ -148,9 +143,8 @@ func (m *SemgrepModule) LanguageSpecificScan(ctx context.Context, target string,
 
 // CICDIntegrationScan performs optimized scan for CI/CD pipelines
 func (m *SemgrepModule) CICDIntegrationScan(ctx context.Context, target string, baselineRef string, outputFormat string, outputFile string, configPolicy string, diffAware bool, failOpen bool, timeout string, quiet bool) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	if configPolicy == "" {
 		configPolicy = "p/ci"
@@ -191,14 +185,13 @@ func (m *SemgrepModule) CICDIntegrationScan(ctx context.Context, target string,
 
 // CustomRuleManagement manages and validates custom Semgrep rules
 func (m *SemgrepModule) CustomRuleManagement(ctx context.Context, action string, rulesPath string, target string, testFiles []string, outputFormat string, strict bool) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest")
+	container := toolContainer(m.client, "semgrep/semgrep:latest")
 
 	if rulesPath != "" {
-		container = container.WithFile("/rules.yaml", m.client.Host().File(rulesPath))
+		container = container.WithFile("/rules.yaml", hostFile(m.client, rulesPath))
 	}
 	if target != "" {
-		container = container.WithDirectory("/src", m.client.Host().Directory(target))
+		container = container.WithDirectory("/src", hostDirectory(m.client, target))
 	}
 
 	var args []string
@@ -211,7 +204,7 @@ func (m *SemgrepModule) CustomRuleManagement(ctx context.Context, action string,
 	case "test":
 		args = []string{"semgrep", "test", "--config", "/rules.yaml"}
 		for _, file := range testFiles {
-			container = container.WithFile("/test_"+file, m.client.Host().File(file))
+			container = container.WithFile("/test_"+file, hostFile(m.client, file))
 			args = append(args, "/test_"+file)
 		}
 	case "scan":
@@ -247,9 +240,8 @@ func (m *SemgrepModule) CustomRuleManagement(ctx context.Context, action string,
 
 // PerformanceOptimizedScan performs high-performance scan with optimization features
 func (m *SemgrepModule) PerformanceOptimizedScan(ctx context.Context, target string, configPolicy string, maxMemory string, maxTargetBytes string, jobs string, timeout string, enableMetrics bool, optimizations bool, excludePatterns []string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	if configPolicy == "" {
 		configPolicy = "auto"
@@ -299,9 +291,8 @@ func (m *SemgrepModule) PerformanceOptimizedScan(ctx context.Context, target str
 
 // ScanSecrets performs specialized secrets scanning
 func (m *SemgrepModule) ScanSecrets(ctx context.Context, directory string, outputFormat string, excludePatterns []string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(directory))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, directory))
 
 	args := []string{"semgrep", "scan", "/src", "--config", "p/secrets"}
 	if outputFormat == "json" {
@@ -323,9 +314,8 @@ func (m *SemgrepModule) ScanSecrets(ctx context.Context, directory string, outpu
 
 // ScanOWASPTop10 scans for OWASP Top 10 vulnerabilities
 func (m *SemgrepModule) ScanOWASPTop10(ctx context.Context, directory string, outputFormat string, languageFocus string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(directory))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, directory))
 
 	args := []string{"semgrep", "scan", "/src", "--config", "p/owasp-top-ten"}
 	if outputFormat == "json" {
@@ -347,9 +337,8 @@ func (m *SemgrepModule) ScanOWASPTop10(ctx context.Context, directory string, ou
 
 // VulnerabilityResearch performs advanced vulnerability research and pattern discovery
 func (m *SemgrepModule) VulnerabilityResearch(ctx context.Context, target string, researchMode string, languageFocus string, vulnerabilityTypes []string, includeExperimental bool, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	args := []string{"semgrep", "scan", "/src"}
 
@@ -390,9 +379,8 @@ func (m *SemgrepModule) VulnerabilityResearch(ctx context.Context, target string
 
 // ComplianceScanning performs compliance-focused security scanning
 func (m *SemgrepModule) ComplianceScanning(ctx context.Context, target string, complianceFramework string, industryFocus string, outputFormat string, outputFile string, includeRemediation bool, severityThreshold string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	args := []string{"semgrep", "scan", "/src", "--config", "p/"+complianceFramework}
 	if industryFocus != "" {
@@ -432,9 +420,8 @@ func (m *SemgrepModule) ComplianceScanning(ctx context.Context, target string, c
 
 // ComprehensiveReporting generates comprehensive security analysis reports
 func (m *SemgrepModule) ComprehensiveReporting(ctx context.Context, target string, reportType string, outputFormats []string, outputDirectory string, includeMetrics bool, includeTrends bool, baselineComparison string) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	args := []string{"semgrep", "scan", "/src"}
 
@@ -478,9 +465,8 @@ func (m *SemgrepModule) ComprehensiveReporting(ctx context.Context, target strin
 
 // SecurityAuditScan performs comprehensive security audit scan
 func (m *SemgrepModule) SecurityAuditScan(ctx context.Context, target string, ruleset string, severity string, outputFormat string, outputFile string, excludePaths []string, verbose bool, failOnFindings bool) (string, error) {
-	container := m.client.Container().
-		From("semgrep/semgrep:latest").
-		WithDirectory("/src", m.client.Host().Directory(target))
+	container := toolContainer(m.client, "semgrep/semgrep:latest").
+		WithDirectory("/src", hostDirectory(m.client, target))
 
 	if ruleset == "" {
 		ruleset = "p/security-audit"
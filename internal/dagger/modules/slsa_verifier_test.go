@@ -0,0 +1,23 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSLSAVerifierImageArgs(t *testing.T) {
+	image := "ghcr.io/org/app@sha256:abc"
+
+	args := slsaVerifierImageArgs(image, "github.com/org/app", "")
+	expected := []string{slsaVerifierBinary, "verify-image", image, "--source-uri", "github.com/org/app"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+
+	builder := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v2.0.0"
+	args = slsaVerifierImageArgs(image, "github.com/org/app", builder)
+	expected = append(expected, "--builder-id", builder)
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
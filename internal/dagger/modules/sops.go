@@ -3,6 +3,9 @@ package modules
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -11,7 +14,12 @@ type SOPSModule struct {
 	client *dagger.Client
 }
 
-const sopsBinary = "sops"
+const (
+	sopsBinary = "sops"
+
+	// sopsAgeKeyFileMount is where a host age key file is mounted in the container
+	sopsAgeKeyFileMount = "/keys/age.txt"
+)
 
 func NewSOPSModule(client *dagger.Client) *SOPSModule {
 	return &SOPSModule{
@@ -20,11 +28,16 @@ func NewSOPSModule(client *dagger.Client) *SOPSModule {
 }
 
 // EncryptFile encrypts a file using SOPS
-func (m *SOPSModule) EncryptFile(ctx context.Context, filePath string, kmsArn string, pgpFingerprint string, agePublicKey string, outputFile string, inPlace bool) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest").
-		WithFile("/workspace/input", m.client.Host().File(filePath)).
-		WithWorkdir("/workspace")
+func (m *SOPSModule) EncryptFile(ctx context.Context, filePath string, kmsArn string, pgpFingerprint string, agePublicKey string, outputFile string, inPlace bool, opts ...SOPSOption) (string, error) {
+	config := newSOPSConfig(opts)
+
+	if err := validateSOPSRegex(config); err != nil {
+		return "", err
+	}
+
+	container := m.withSOPSKeys(toolContainer(m.client, "mozilla/sops:latest").
+		WithFile("/workspace/input", hostFile(m.client, filePath)).
+		WithWorkdir("/workspace"), config)
 
 	args := []string{sopsBinary, "--encrypt"}
 
@@ -37,6 +50,8 @@ func (m *SOPSModule) EncryptFile(ctx context.Context, filePath string, kmsArn st
 	if agePublicKey != "" {
 		args = append(args, "--age", agePublicKey)
 	}
+	args = append(args, sopsKeyArgs(config)...)
+	args = append(args, sopsRegexArgs(config)...)
 	if outputFile != "" {
 		args = append(args, "--output", outputFile)
 	}
@@ -58,11 +73,12 @@ func (m *SOPSModule) EncryptFile(ctx context.Context, filePath string, kmsArn st
 }
 
 // DecryptFile decrypts a SOPS-encrypted file
-func (m *SOPSModule) DecryptFile(ctx context.Context, filePath string, outputFile string) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest").
-		WithFile("/workspace/encrypted", m.client.Host().File(filePath)).
-		WithWorkdir("/workspace")
+func (m *SOPSModule) DecryptFile(ctx context.Context, filePath string, outputFile string, opts ...SOPSOption) (string, error) {
+	config := newSOPSConfig(opts)
+
+	container := m.withSOPSKeys(toolContainer(m.client, "mozilla/sops:latest").
+		WithFile("/workspace/encrypted", hostFile(m.client, filePath)).
+		WithWorkdir("/workspace"), config)
 
 	args := []string{sopsBinary, "--decrypt"}
 
@@ -84,11 +100,12 @@ func (m *SOPSModule) DecryptFile(ctx context.Context, filePath string, outputFil
 }
 
 // UpdateKeys rotates/updates encryption keys for SOPS files
-func (m *SOPSModule) UpdateKeys(ctx context.Context, filePath string, addKms string, addPgp string, addAge string, inPlace bool) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest").
-		WithFile("/workspace/encrypted", m.client.Host().File(filePath)).
-		WithWorkdir("/workspace")
+func (m *SOPSModule) UpdateKeys(ctx context.Context, filePath string, addKms string, addPgp string, addAge string, inPlace bool, opts ...SOPSOption) (string, error) {
+	config := newSOPSConfig(opts)
+
+	container := m.withSOPSKeys(toolContainer(m.client, "mozilla/sops:latest").
+		WithFile("/workspace/encrypted", hostFile(m.client, filePath)).
+		WithWorkdir("/workspace"), config)
 
 	args := []string{sopsBinary, "--rotate"}
 
@@ -101,6 +118,9 @@ func (m *SOPSModule) UpdateKeys(ctx context.Context, filePath string, addKms str
 	if addAge != "" {
 		args = append(args, "--add-age", addAge)
 	}
+	if config.HCVaultTransit != "" {
+		args = append(args, "--add-hc-vault-transit", config.HCVaultTransit)
+	}
 	if inPlace {
 		args = append(args, "--in-place")
 	}
@@ -119,11 +139,12 @@ func (m *SOPSModule) UpdateKeys(ctx context.Context, filePath string, addKms str
 }
 
 // EditFile shows decrypted content for editing (interactive editing not supported in containers)
-func (m *SOPSModule) EditFile(ctx context.Context, filePath string) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest").
-		WithFile("/workspace/encrypted", m.client.Host().File(filePath)).
-		WithWorkdir("/workspace")
+func (m *SOPSModule) EditFile(ctx context.Context, filePath string, opts ...SOPSOption) (string, error) {
+	config := newSOPSConfig(opts)
+
+	container := m.withSOPSKeys(toolContainer(m.client, "mozilla/sops:latest").
+		WithFile("/workspace/encrypted", hostFile(m.client, filePath)).
+		WithWorkdir("/workspace"), config)
 
 	// Note: Interactive editing is limited in containerized environments
 	// This command will show the decrypted content
@@ -142,8 +163,7 @@ func (m *SOPSModule) EditFile(ctx context.Context, filePath string) (string, err
 
 // GetVersion gets the SOPS version
 func (m *SOPSModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest")
+	container := toolContainer(m.client, "mozilla/sops:latest")
 
 	result := container.WithExec([]string{sopsBinary, "--version"}, dagger.ContainerWithExecOpts{
 		Expect: "ANY",
@@ -158,12 +178,11 @@ func (m *SOPSModule) GetVersion(ctx context.Context) (string, error) {
 
 // PublishKeys publishes/exports keys for sharing (shows public key info)
 func (m *SOPSModule) PublishKeys(ctx context.Context, keyType string, keyPath string) (string, error) {
-	container := m.client.Container().
-		From("mozilla/sops:latest").
+	container := toolContainer(m.client, "mozilla/sops:latest").
 		WithWorkdir("/workspace")
 
 	if keyPath != "" {
-		container = container.WithFile("/workspace/keyfile", m.client.Host().File(keyPath))
+		container = container.WithFile("/workspace/keyfile", hostFile(m.client, keyPath))
 	}
 
 	args := []string{sopsBinary, "--help"}
@@ -186,3 +205,164 @@ func (m *SOPSModule) PublishKeys(ctx context.Context, keyType string, keyPath st
 	return output, nil
 }
 
+// withSOPSKeys makes key material available to sops inside the container.
+// Secret values are passed as Dagger secrets so they never appear in logs.
+func (m *SOPSModule) withSOPSKeys(container *dagger.Container, config *SOPSConfig) *dagger.Container {
+	for name, value := range sopsSecretEnv(config) {
+		container = container.WithSecretVariable(name, m.client.SetSecret(secretName(strings.ToLower(name), value), value))
+	}
+	for name, value := range sopsEnv(config) {
+		container = container.WithEnvVariable(name, value)
+	}
+	if config.AgeKeyFile != "" {
+		container = container.WithMountedFile(sopsAgeKeyFileMount, hostFile(m.client, config.AgeKeyFile))
+	}
+	return container
+}
+
+// sopsKeyArgs returns the key-source arguments for sops --encrypt
+func sopsKeyArgs(config *SOPSConfig) []string {
+	var args []string
+	if config.HCVaultTransit != "" {
+		args = append(args, "--hc-vault-transit", config.HCVaultTransit)
+	}
+	return args
+}
+
+// sopsRegexArgs returns the partial encryption arguments for sops --encrypt
+func sopsRegexArgs(config *SOPSConfig) []string {
+	var args []string
+	if config.EncryptedRegex != "" {
+		args = append(args, "--encrypted-regex", config.EncryptedRegex)
+	}
+	if config.UnencryptedRegex != "" {
+		args = append(args, "--unencrypted-regex", config.UnencryptedRegex)
+	}
+	return args
+}
+
+// validateSOPSRegex checks partial encryption regexes before sops is invoked.
+// sops uses Go regexp syntax, so compiling here catches the same errors.
+func validateSOPSRegex(config *SOPSConfig) error {
+	if config.EncryptedRegex != "" && config.UnencryptedRegex != "" {
+		return fmt.Errorf("encrypted-regex and unencrypted-regex cannot be used together")
+	}
+	if config.EncryptedRegex != "" {
+		if _, err := regexp.Compile(config.EncryptedRegex); err != nil {
+			return fmt.Errorf("invalid encrypted-regex %q: %w", config.EncryptedRegex, err)
+		}
+	}
+	if config.UnencryptedRegex != "" {
+		if _, err := regexp.Compile(config.UnencryptedRegex); err != nil {
+			return fmt.Errorf("invalid unencrypted-regex %q: %w", config.UnencryptedRegex, err)
+		}
+	}
+	return nil
+}
+
+// sopsSecretEnv returns key material that must be passed as secrets
+func sopsSecretEnv(config *SOPSConfig) map[string]string {
+	env := map[string]string{}
+	if config.AgeKey != "" {
+		env["SOPS_AGE_KEY"] = config.AgeKey
+	}
+	if config.VaultToken != "" {
+		env["VAULT_TOKEN"] = config.VaultToken
+	}
+	return env
+}
+
+// sopsEnv returns the non-secret environment for a sops run
+func sopsEnv(config *SOPSConfig) map[string]string {
+	env := map[string]string{}
+	if config.AgeKeyFile != "" {
+		env["SOPS_AGE_KEY_FILE"] = sopsAgeKeyFileMount
+	}
+	if config.VaultAddr != "" {
+		env["VAULT_ADDR"] = config.VaultAddr
+	}
+	return env
+}
+
+func newSOPSConfig(opts []SOPSOption) *SOPSConfig {
+	config := &SOPSConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// SOPSConfig holds the settings for a SOPS run
+type SOPSConfig struct {
+	HCVaultTransit string
+	VaultAddr      string
+	VaultToken     string
+	AgeKey         string
+	AgeKeyFile     string
+
+	EncryptedRegex   string
+	UnencryptedRegex string
+}
+
+// SOPSOption sets a field of SOPSConfig
+type SOPSOption func(*SOPSConfig)
+
+// WithSOPSVaultTransit encrypts with a HashiCorp Vault transit key URI
+// (e.g. https://vault.example.com:8200/v1/sops/keys/my-key)
+func WithSOPSVaultTransit(uri string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.HCVaultTransit = uri
+	}
+}
+
+// WithSOPSVaultAuth sets the Vault address and token used for transit operations
+func WithSOPSVaultAuth(addr, token string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.VaultAddr = addr
+		c.VaultToken = token
+	}
+}
+
+// WithSOPSAgeKey passes an age private key to sops via SOPS_AGE_KEY
+func WithSOPSAgeKey(key string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.AgeKey = key
+	}
+}
+
+// WithSOPSAgeKeyFile mounts a host age key file and points SOPS_AGE_KEY_FILE at it
+func WithSOPSAgeKeyFile(path string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.AgeKeyFile = path
+	}
+}
+
+// WithSOPSEncryptedRegex only encrypts values whose keys match the regex
+func WithSOPSEncryptedRegex(regex string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.EncryptedRegex = regex
+	}
+}
+
+// WithSOPSUnencryptedRegex leaves values whose keys match the regex in plaintext
+func WithSOPSUnencryptedRegex(regex string) SOPSOption {
+	return func(c *SOPSConfig) {
+		c.UnencryptedRegex = regex
+	}
+}
+
+// SOPSOptionsFromEnv picks up SOPS_AGE_KEY, SOPS_AGE_KEY_FILE, VAULT_ADDR and
+// VAULT_TOKEN from the host environment so they reach the container
+func SOPSOptionsFromEnv() []SOPSOption {
+	var opts []SOPSOption
+	if key := os.Getenv("SOPS_AGE_KEY"); key != "" {
+		opts = append(opts, WithSOPSAgeKey(key))
+	}
+	if keyFile := os.Getenv("SOPS_AGE_KEY_FILE"); keyFile != "" {
+		opts = append(opts, WithSOPSAgeKeyFile(keyFile))
+	}
+	if addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"); addr != "" || token != "" {
+		opts = append(opts, WithSOPSVaultAuth(addr, token))
+	}
+	return opts
+}
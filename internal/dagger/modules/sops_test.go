@@ -0,0 +1,103 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSOPSKeyArgs_VaultTransit(t *testing.T) {
+	uri := "https://vault.example.com:8200/v1/sops/keys/firstkey"
+	config := newSOPSConfig([]SOPSOption{WithSOPSVaultTransit(uri)})
+
+	expected := []string{"--hc-vault-transit", uri}
+	if got := sopsKeyArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+
+	if got := sopsKeyArgs(newSOPSConfig(nil)); len(got) != 0 {
+		t.Errorf("Expected no key args by default, got %v", got)
+	}
+}
+
+func TestSOPSOptionsFromEnv(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", "AGE-SECRET-KEY-1TEST")
+	t.Setenv("SOPS_AGE_KEY_FILE", "/home/user/.config/sops/age/keys.txt")
+	t.Setenv("VAULT_ADDR", "https://vault.example.com:8200")
+	t.Setenv("VAULT_TOKEN", "s.token")
+
+	config := newSOPSConfig(SOPSOptionsFromEnv())
+
+	expectedSecrets := map[string]string{
+		"SOPS_AGE_KEY": "AGE-SECRET-KEY-1TEST",
+		"VAULT_TOKEN":  "s.token",
+	}
+	if got := sopsSecretEnv(config); !reflect.DeepEqual(got, expectedSecrets) {
+		t.Errorf("Expected secret env %v, got %v", expectedSecrets, got)
+	}
+
+	// The key file is mounted, so the container sees the mount path rather than the host path
+	expectedEnv := map[string]string{
+		"SOPS_AGE_KEY_FILE": sopsAgeKeyFileMount,
+		"VAULT_ADDR":        "https://vault.example.com:8200",
+	}
+	if got := sopsEnv(config); !reflect.DeepEqual(got, expectedEnv) {
+		t.Errorf("Expected env %v, got %v", expectedEnv, got)
+	}
+	if config.AgeKeyFile != "/home/user/.config/sops/age/keys.txt" {
+		t.Errorf("Expected host key file path to be kept for mounting, got %q", config.AgeKeyFile)
+	}
+}
+
+func TestSOPSOptionsFromEnv_Empty(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", "")
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+
+	if opts := SOPSOptionsFromEnv(); len(opts) != 0 {
+		t.Errorf("Expected no options without env, got %d", len(opts))
+	}
+}
+
+func TestSOPSRegexArgs_Encrypted(t *testing.T) {
+	config := newSOPSConfig([]SOPSOption{WithSOPSEncryptedRegex("^(data|stringData)$")})
+
+	if err := validateSOPSRegex(config); err != nil {
+		t.Fatalf("Expected valid regex, got %v", err)
+	}
+	expected := []string{"--encrypted-regex", "^(data|stringData)$"}
+	if got := sopsRegexArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestSOPSRegexArgs_Unencrypted(t *testing.T) {
+	config := newSOPSConfig([]SOPSOption{WithSOPSUnencryptedRegex("^(description|metadata)$")})
+
+	if err := validateSOPSRegex(config); err != nil {
+		t.Fatalf("Expected valid regex, got %v", err)
+	}
+	expected := []string{"--unencrypted-regex", "^(description|metadata)$"}
+	if got := sopsRegexArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestValidateSOPSRegex_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []SOPSOption
+	}{
+		{"bad encrypted regex", []SOPSOption{WithSOPSEncryptedRegex("^(data")}},
+		{"bad unencrypted regex", []SOPSOption{WithSOPSUnencryptedRegex("[a-")}},
+		{"both regexes", []SOPSOption{WithSOPSEncryptedRegex("^data$"), WithSOPSUnencryptedRegex("^meta$")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSOPSRegex(newSOPSConfig(tt.opts)); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
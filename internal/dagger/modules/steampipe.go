@@ -25,8 +25,7 @@ func NewSteampipeModule(client *dagger.Client) *SteampipeModule {
 
 // Query executes a SQL query against cloud resources
 func (m *SteampipeModule) Query(ctx context.Context, query string, plugin string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "plugin", "install", plugin,
 		}, dagger.ContainerWithExecOpts{
@@ -50,9 +49,8 @@ func (m *SteampipeModule) Query(ctx context.Context, query string, plugin string
 
 // QueryFromFile executes queries from a file
 func (m *SteampipeModule) QueryFromFile(ctx context.Context, queryFile string, plugin string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
-		WithFile("/query.sql", m.client.Host().File(queryFile)).
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
+		WithFile("/query.sql", hostFile(m.client, queryFile)).
 		WithExec([]string{
 			steampipeBinary, "plugin", "install", plugin,
 		}, dagger.ContainerWithExecOpts{
@@ -76,8 +74,7 @@ func (m *SteampipeModule) QueryFromFile(ctx context.Context, queryFile string, p
 
 // ListPlugins lists available plugins
 func (m *SteampipeModule) ListPlugins(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "plugin", "list",
 		}, dagger.ContainerWithExecOpts{
@@ -94,8 +91,7 @@ func (m *SteampipeModule) ListPlugins(ctx context.Context) (string, error) {
 
 // GetVersion returns the version of Steampipe
 func (m *SteampipeModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{steampipeBinary, "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -117,8 +113,7 @@ func (m *SteampipeModule) QueryInteractive(ctx context.Context, plugin string) (
 
 // InstallPlugin installs a Steampipe plugin
 func (m *SteampipeModule) InstallPlugin(ctx context.Context, plugin string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "plugin", "install", plugin,
 		}, dagger.ContainerWithExecOpts{
@@ -135,8 +130,7 @@ func (m *SteampipeModule) InstallPlugin(ctx context.Context, plugin string) (str
 
 // UpdatePlugin updates a Steampipe plugin
 func (m *SteampipeModule) UpdatePlugin(ctx context.Context, plugin string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "plugin", "update", plugin,
 		}, dagger.ContainerWithExecOpts{
@@ -153,8 +147,7 @@ func (m *SteampipeModule) UpdatePlugin(ctx context.Context, plugin string) (stri
 
 // UninstallPlugin uninstalls a Steampipe plugin
 func (m *SteampipeModule) UninstallPlugin(ctx context.Context, plugin string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "plugin", "uninstall", plugin,
 		}, dagger.ContainerWithExecOpts{
@@ -176,8 +169,7 @@ func (m *SteampipeModule) StartService(ctx context.Context, port int) (string, e
 		args = append(args, "--port", fmt.Sprintf("%d", port))
 	}
 
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -193,8 +185,7 @@ func (m *SteampipeModule) StartService(ctx context.Context, port int) (string, e
 
 // GetServiceStatus gets the status of the Steampipe service
 func (m *SteampipeModule) GetServiceStatus(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "service", "status",
 		}, dagger.ContainerWithExecOpts{
@@ -212,8 +203,7 @@ func (m *SteampipeModule) GetServiceStatus(ctx context.Context) (string, error)
 
 // StopService stops the Steampipe service
 func (m *SteampipeModule) StopService(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/turbot/steampipe:latest").
+	container := toolContainer(m.client, "ghcr.io/turbot/steampipe:latest").
 		WithExec([]string{
 			steampipeBinary, "service", "stop",
 		}, dagger.ContainerWithExecOpts{
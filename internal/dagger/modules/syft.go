@@ -27,9 +27,8 @@ func (m *SyftModule) GenerateSBOMFromDirectory(ctx context.Context, dir string,
 		format = "json"
 	}
 
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostScanDir(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"/syft", ".", "-o", format,
@@ -51,8 +50,7 @@ func (m *SyftModule) GenerateSBOMFromImage(ctx context.Context, imageName string
 		format = "json"
 	}
 
-	container := m.client.Container().
-		From("anchore/syft:latest").
+	container := toolContainer(m.client, "anchore/syft:latest").
 		WithExec([]string{
 			"/syft", imageName, "-o", format,
 		}, dagger.ContainerWithExecOpts{
@@ -67,6 +65,73 @@ func (m *SyftModule) GenerateSBOMFromImage(ctx context.Context, imageName string
 	return "", fmt.Errorf("failed to generate SBOM from image: no output received")
 }
 
+// Kinds of source Syft catalogs
+const (
+	SyftSourceDirectory = "directory"
+	SyftSourceImage     = "image"
+	SyftSourceArchive   = "archive"
+)
+
+// SyftOutput is an SBOM format to write and the host path it is exported to
+type SyftOutput struct {
+	Format string
+	Path   string
+}
+
+// GenerateSBOMFiles catalogs a source once and writes an SBOM for every
+// output, exporting each file to its host path. kind is one of the
+// SyftSource kinds; ref is the directory or archive path or the image
+// reference.
+func (m *SyftModule) GenerateSBOMFiles(ctx context.Context, kind string, ref string, outputs []SyftOutput) error {
+	if len(outputs) == 0 {
+		return fmt.Errorf("no SBOM outputs requested")
+	}
+
+	container := toolContainer(m.client, "anchore/syft:latest")
+	var source string
+	switch kind {
+	case SyftSourceDirectory:
+		container = container.WithDirectory("/workspace", hostScanDir(m.client, ref)).WithWorkdir("/workspace")
+		source = "dir:."
+	case SyftSourceArchive:
+		container = container.WithFile("/archive", hostFile(m.client, ref))
+		source = "/archive"
+	case SyftSourceImage:
+		source = ref
+	default:
+		return fmt.Errorf("unsupported syft source kind %q", kind)
+	}
+
+	container = container.WithExec(syftOutputArgs(source, outputs), dagger.ContainerWithExecOpts{
+		Expect: "ANY",
+	})
+
+	for i, output := range outputs {
+		if _, err := container.File(syftOutputFile(i)).Export(ctx, output.Path); err != nil {
+			stderr, _ := container.Stderr(ctx)
+			return fmt.Errorf("failed to export %s SBOM to %s: %w\nStderr: %s", output.Format, output.Path, err, stderr)
+		}
+	}
+	return nil
+}
+
+// syftOutputArgs builds a syft command that writes every output format in one
+// cataloging pass with one -o format=file flag each
+func syftOutputArgs(source string, outputs []SyftOutput) []string {
+	args := []string{"/syft", source}
+	for i, output := range outputs {
+		args = append(args, "-o", output.Format+"="+syftOutputFile(i))
+	}
+	return args
+}
+
+// syftOutputFile is the in-container path the i-th SBOM output is written to
+// before being exported. /tmp is used as the syft image has no shell to
+// create directories.
+func syftOutputFile(i int) string {
+	return fmt.Sprintf("/tmp/sbom-%d", i)
+}
+
 // GenerateSBOMFromPackage generates SBOM from a specific package manager
 func (m *SyftModule) GenerateSBOMFromPackage(ctx context.Context, dir string, packageType string, format string) (string, error) {
 	if format == "" {
@@ -76,9 +141,8 @@ func (m *SyftModule) GenerateSBOMFromPackage(ctx context.Context, dir string, pa
 	// packageType can be used for future enhancements to filter by package manager
 	_ = packageType
 
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostScanDir(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"/syft", ".", "-o", format,
@@ -100,7 +164,7 @@ func (m *SyftModule) GenerateAttestations(ctx context.Context, target string, fo
 		format = "json"
 	}
 
-	container := m.client.Container().From("anchore/syft:latest")
+	container := toolContainer(m.client, "anchore/syft:latest")
 	
 	if len(target) > 6 && target[:6] == "image:" {
 		// It's an image
@@ -112,7 +176,7 @@ func (m *SyftModule) GenerateAttestations(ctx context.Context, target string, fo
 	} else {
 		// Assume it's a directory
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(target)).
+			WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace").
 			WithExec([]string{
 				"/syft", ".", "-o", format,
@@ -135,9 +199,8 @@ func (m *SyftModule) LanguageSpecificCataloging(ctx context.Context, target stri
 		outputFormat = "syft-json"
 	}
 
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	// Map languages to catalogers
@@ -184,9 +247,8 @@ func (m *SyftModule) LanguageSpecificCataloging(ctx context.Context, target stri
 
 // SupplyChainAnalysis performs comprehensive supply chain analysis and SBOM generation
 func (m *SyftModule) SupplyChainAnalysis(ctx context.Context, target string, analysisDepth string, outputFormats []string, outputDirectory string, includeTransitiveDeps bool, includeLicenseAnalysis bool, includeProvenance bool, riskAssessment string) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	cmd := "syft dir:."
@@ -229,15 +291,14 @@ func (m *SyftModule) SupplyChainAnalysis(ctx context.Context, target string, ana
 
 // SBOMComparison compares SBOMs and generates difference analysis
 func (m *SyftModule) SBOMComparison(ctx context.Context, baselineTarget string, comparisonTarget string, comparisonType string, outputFormat string, diffOutputFile string, showAddedOnly bool, showRemovedOnly bool, includeVersionChanges bool) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest")
+	container := toolContainer(m.client, "anchore/syft:latest")
 
 	// Generate SBOM for baseline target (simplified implementation)
 	if baselineTarget != "" {
-		container = container.WithDirectory("/baseline", m.client.Host().Directory(baselineTarget))
+		container = container.WithDirectory("/baseline", hostDirectory(m.client, baselineTarget))
 	}
 	if comparisonTarget != "" {
-		container = container.WithDirectory("/comparison", m.client.Host().Directory(comparisonTarget))
+		container = container.WithDirectory("/comparison", hostDirectory(m.client, comparisonTarget))
 	}
 
 	cmd := "syft /baseline -o syft-json --file /tmp/baseline-sbom.json"
@@ -260,9 +321,8 @@ func (m *SyftModule) SBOMComparison(ctx context.Context, baselineTarget string,
 
 // ComplianceAttestation generates compliance-focused SBOMs with attestation features
 func (m *SyftModule) ComplianceAttestation(ctx context.Context, target string, complianceFramework string, outputFormat string, attestationFormat string, outputFile string, includeSupplierInfo bool, includeHashes bool, validateCompleteness bool) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	cmd := "syft dir:."
@@ -303,9 +363,8 @@ func (m *SyftModule) ComplianceAttestation(ctx context.Context, target string, c
 
 // ArchiveAnalysis analyzes archives, packages, and compressed files for SBOM generation
 func (m *SyftModule) ArchiveAnalysis(ctx context.Context, archivePath string, archiveType string, outputFormat string, extractNested bool, includeMetadata bool, extractionDepth string) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithFile("/archive", m.client.Host().File(archivePath))
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithFile("/archive", hostFile(m.client, archivePath))
 
 	cmd := "syft /archive"
 	if outputFormat != "" {
@@ -336,9 +395,8 @@ func (m *SyftModule) ArchiveAnalysis(ctx context.Context, archivePath string, ar
 
 // CICDPipelineIntegration performs optimized SBOM generation for CI/CD pipelines
 func (m *SyftModule) CICDPipelineIntegration(ctx context.Context, target string, pipelineStage string, artifactName string, outputFormats []string, outputDirectory string, failOnError bool, quietMode bool, timeout string) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	cmd := "syft dir:."
@@ -388,9 +446,8 @@ func (m *SyftModule) CICDPipelineIntegration(ctx context.Context, target string,
 
 // MetadataExtraction extracts and enriches metadata for comprehensive SBOM generation
 func (m *SyftModule) MetadataExtraction(ctx context.Context, target string, metadataTypes []string, outputFormat string, includeFileMetadata bool, includeChecksums bool, includeCertificates bool, includeSignatures bool, customAnnotations string) (string, error) {
-	container := m.client.Container().
-		From("anchore/syft:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "anchore/syft:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	// Use syft-json for maximum metadata preservation
@@ -0,0 +1,27 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyftOutputArgs_OneFlagPerFormat(t *testing.T) {
+	args := syftOutputArgs("dir:.", []SyftOutput{
+		{Format: "cyclonedx-json", Path: "./sbom.cdx.json"},
+		{Format: "spdx-json", Path: "./sbom.spdx.json"},
+	})
+
+	expected := []string{"/syft", "dir:.", "-o", "cyclonedx-json=/tmp/sbom-0", "-o", "spdx-json=/tmp/sbom-1"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestSyftOutputArgs_SingleFormat(t *testing.T) {
+	args := syftOutputArgs("registry:alpine:3.19", []SyftOutput{{Format: "spdx-json", Path: "./sbom.spdx.json"}})
+
+	expected := []string{"/syft", "registry:alpine:3.19", "-o", "spdx-json=/tmp/sbom-0"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
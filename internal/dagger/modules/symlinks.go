@@ -0,0 +1,102 @@
+package modules
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// FollowSymlinksEnv makes filesystem scans replace symlinks in the scanned
+// directory with the files and directories they point to when set to a
+// non-empty value. It is set from ship's --follow-symlinks flag.
+//
+// By default a scanned directory is copied into the tool container with its
+// symlinks kept as links: links to paths inside the directory still resolve,
+// while links to paths outside it, such as vendored directories elsewhere on
+// the host, dangle and their contents are not scanned.
+const FollowSymlinksEnv = "SHIP_FOLLOW_SYMLINKS"
+
+// symlinkMount is a symlink in a scanned directory and the host path it
+// resolves to
+type symlinkMount struct {
+	Path   string
+	Target string
+	IsDir  bool
+}
+
+// hostDirMount describes how a scanned host directory is loaded: the
+// directory without the excluded symlinks, plus each link's target at the
+// link's path
+type hostDirMount struct {
+	Path    string
+	Exclude []string
+	Links   []symlinkMount
+}
+
+// followSymlinks reports whether scans follow symlinks
+func followSymlinks(getenv func(string) string) bool {
+	return getenv(FollowSymlinksEnv) != ""
+}
+
+// hostDirMountPlan plans the mount of dir. Without follow the directory is
+// mounted as is. With follow every symlink that resolves is replaced by its
+// target; symlinks inside a link's target directory are kept as links, which
+// also stops link cycles, and dangling links are left in place.
+func hostDirMountPlan(dir string, follow bool) (hostDirMount, error) {
+	plan := hostDirMount{Path: dir}
+	if !follow {
+		return plan, nil
+	}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return nil
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		plan.Exclude = append(plan.Exclude, rel)
+		plan.Links = append(plan.Links, symlinkMount{Path: rel, Target: target, IsDir: info.IsDir()})
+		return nil
+	})
+	return plan, err
+}
+
+// hostScanDir loads a host directory for a filesystem scan, following its
+// symlinks when FollowSymlinksEnv is set. A relative dir is resolved against
+// the working directory.
+func hostScanDir(client *dagger.Client, dir string) *dagger.Directory {
+	dir = HostPath(dir)
+	plan, err := hostDirMountPlan(dir, followSymlinks(os.Getenv))
+	if err != nil {
+		// Leave reporting an unreadable directory to Dagger
+		return client.Host().Directory(dir)
+	}
+
+	directory := client.Host().Directory(plan.Path, dagger.HostDirectoryOpts{Exclude: plan.Exclude})
+	for _, link := range plan.Links {
+		if link.IsDir {
+			directory = directory.WithDirectory(link.Path, client.Host().Directory(link.Target))
+		} else {
+			directory = directory.WithFile(link.Path, client.Host().File(link.Target))
+		}
+	}
+	return directory
+}
@@ -0,0 +1,60 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// symlinkedRepo creates a repository whose vendor link points at a directory
+// outside it, with a link to a file and a dangling link
+func symlinkedRepo(t *testing.T) (repo string, vendored string) {
+	t.Helper()
+	root := t.TempDir()
+	repo = filepath.Join(root, "repo")
+	vendored = filepath.Join(root, "vendored")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "src"), 0o755))
+	require.NoError(t, os.MkdirAll(vendored, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "src", "main.go"), nil, 0o644))
+	require.NoError(t, os.Symlink(vendored, filepath.Join(repo, "src", "vendor")))
+	require.NoError(t, os.Symlink(filepath.Join(repo, "src", "main.go"), filepath.Join(repo, "main.go")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "missing"), filepath.Join(repo, "dangling")))
+
+	// Resolve the temp dir itself, which may be a symlink on some platforms
+	vendored, err := filepath.EvalSymlinks(vendored)
+	require.NoError(t, err)
+	return repo, vendored
+}
+
+func TestHostDirMountPlanDefault(t *testing.T) {
+	repo, _ := symlinkedRepo(t)
+
+	plan, err := hostDirMountPlan(repo, false)
+	require.NoError(t, err)
+	assert.Equal(t, hostDirMount{Path: repo}, plan, "symlinks are mounted as links")
+}
+
+func TestHostDirMountPlanFollowSymlinks(t *testing.T) {
+	repo, vendored := symlinkedRepo(t)
+	mainFile, err := filepath.EvalSymlinks(filepath.Join(repo, "src", "main.go"))
+	require.NoError(t, err)
+
+	plan, err := hostDirMountPlan(repo, true)
+	require.NoError(t, err)
+	assert.Equal(t, hostDirMount{
+		Path:    repo,
+		Exclude: []string{"main.go", "src/vendor"},
+		Links: []symlinkMount{
+			{Path: "main.go", Target: mainFile},
+			{Path: "src/vendor", Target: vendored, IsDir: true},
+		},
+	}, plan)
+}
+
+func TestFollowSymlinks(t *testing.T) {
+	assert.False(t, followSymlinks(fakeEnv(nil)))
+	assert.True(t, followSymlinks(fakeEnv(map[string]string{FollowSymlinksEnv: "1"})))
+}
@@ -71,9 +71,8 @@ func (m *TerraformDocsModule) Generate(ctx context.Context, modulePath string, o
 	// Add module path
 	args = append(args, ".")
 
-	container := m.client.Container().
-		From(getImageTag("terraform-docs", "quay.io/terraform-docs/terraform-docs:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(modulePath)).
+	container := toolContainer(m.client, getImageTag("terraform-docs", "quay.io/terraform-docs/terraform-docs:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, modulePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -107,9 +106,8 @@ func (m *TerraformDocsModule) Validate(ctx context.Context, modulePath string, o
 	// Add module path
 	args = append(args, ".")
 
-	container := m.client.Container().
-		From(getImageTag("terraform-docs", "quay.io/terraform-docs/terraform-docs:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(modulePath)).
+	container := toolContainer(m.client, getImageTag("terraform-docs", "quay.io/terraform-docs/terraform-docs:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, modulePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
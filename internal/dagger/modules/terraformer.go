@@ -36,8 +36,7 @@ func (m *TerraformerModule) ImportAWS(ctx context.Context, region string, servic
 		args = append(args, "--resources", service)
 	}
 
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -61,8 +60,7 @@ func (m *TerraformerModule) ImportGCP(ctx context.Context, project string, servi
 		args = append(args, "--resources", service)
 	}
 
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -86,8 +84,7 @@ func (m *TerraformerModule) ImportAzure(ctx context.Context, subscription string
 		args = append(args, "--resources", service)
 	}
 
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -100,8 +97,7 @@ func (m *TerraformerModule) ImportAzure(ctx context.Context, subscription string
 
 // GetVersion returns the version of terraformer
 func (m *TerraformerModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec([]string{terraformerBinary, "version"})
 
 	output, err := container.Stdout(ctx)
@@ -114,8 +110,7 @@ func (m *TerraformerModule) GetVersion(ctx context.Context) (string, error) {
 
 // ListResources lists available resources for a provider
 func (m *TerraformerModule) ListResources(ctx context.Context, provider string) (string, error) {
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec([]string{
 			terraformerBinary,
 			"import", provider,
@@ -143,8 +138,7 @@ func (m *TerraformerModule) Plan(ctx context.Context, provider string, region st
 		args = append(args, "--resources", resource)
 	}
 
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
@@ -173,8 +167,7 @@ func (m *TerraformerModule) Import(ctx context.Context, provider string, region
 		args = append(args, "--"+key, value)
 	}
 
-	container := m.client.Container().
-		From("quay.io/GoogleCloudPlatform/terraformer:latest").
+	container := toolContainer(m.client, "quay.io/GoogleCloudPlatform/terraformer:latest").
 		WithExec(args)
 
 	output, err := container.Stdout(ctx)
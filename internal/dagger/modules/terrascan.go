@@ -3,6 +3,7 @@ package modules
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -24,9 +25,8 @@ func NewTerrascanModule(client *dagger.Client) *TerrascanModule {
 
 // ScanDirectory scans a directory for IaC security issues using Terrascan
 func (m *TerrascanModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terrascan", "scan", "-d", ".", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -47,9 +47,8 @@ func (m *TerrascanModule) ScanDirectory(ctx context.Context, dir string) (string
 
 // ScanTerraform scans Terraform files specifically
 func (m *TerrascanModule) ScanTerraform(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terrascan", "scan", "-i", "terraform", "-d", ".", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -69,9 +68,8 @@ func (m *TerrascanModule) ScanTerraform(ctx context.Context, dir string) (string
 
 // ScanKubernetes scans Kubernetes manifests
 func (m *TerrascanModule) ScanKubernetes(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terrascan", "scan", "-i", "k8s", "-d", ".", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -91,9 +89,8 @@ func (m *TerrascanModule) ScanKubernetes(ctx context.Context, dir string) (strin
 
 // ScanCloudFormation scans CloudFormation templates
 func (m *TerrascanModule) ScanCloudFormation(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terrascan", "scan", "-i", "cloudformation", "-d", ".", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -113,9 +110,8 @@ func (m *TerrascanModule) ScanCloudFormation(ctx context.Context, dir string) (s
 
 // ScanDockerfiles scans Dockerfile for security issues
 func (m *TerrascanModule) ScanDockerfiles(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terrascan", "scan", "-i", "docker", "-d", ".", "-o", "json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -145,9 +141,8 @@ func (m *TerrascanModule) ScanWithSeverity(ctx context.Context, dir string, seve
 		args = append(args, "--severity", severity)
 	}
 
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -175,8 +170,7 @@ func (m *TerrascanModule) ScanRemote(ctx context.Context, repoURL string, repoTy
 		args = append(args, "--output", outputFormat)
 	}
 
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
+	container := toolContainer(m.client, "tenable/terrascan:latest").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -195,10 +189,9 @@ func (m *TerrascanModule) ScanRemote(ctx context.Context, repoURL string, repoTy
 
 // ScanWithPolicy scans using custom policy path
 func (m *TerrascanModule) ScanWithPolicy(ctx context.Context, dir string, policyPath string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
-		WithDirectory("/policies", m.client.Host().Directory(policyPath)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithDirectory("/policies", hostDirectory(m.client, policyPath)).
 		WithWorkdir("/workspace")
 
 	args := []string{"terrascan", "scan", "-d", ".", "--policy-path", "/policies"}
@@ -224,9 +217,8 @@ func (m *TerrascanModule) ScanWithPolicy(ctx context.Context, dir string, policy
 
 // ComprehensiveIaCScan performs comprehensive Infrastructure as Code security scanning
 func (m *TerrascanModule) ComprehensiveIaCScan(ctx context.Context, target string, iacType string, outputFormat string, outputFile string, severityThreshold string, policyTypes string, excludeRules string, verbose bool, showPassed bool) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", "."}
@@ -270,9 +262,8 @@ func (m *TerrascanModule) ComprehensiveIaCScan(ctx context.Context, target strin
 
 // ComplianceFrameworkScan scans against compliance frameworks
 func (m *TerrascanModule) ComplianceFrameworkScan(ctx context.Context, target string, complianceFramework string, iacType string, outputFormat string, outputFile string, includeSeverityDetails bool) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", ".", "--policy-type", complianceFramework}
@@ -304,14 +295,13 @@ func (m *TerrascanModule) ComplianceFrameworkScan(ctx context.Context, target st
 
 // RemoteRepositoryScan performs advanced remote repository scanning
 func (m *TerrascanModule) RemoteRepositoryScan(ctx context.Context, repoURL string, repoType string, iacType string, branch string, sshKeyPath string, accessToken string, configPath string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest")
+	container := toolContainer(m.client, "tenable/terrascan:latest")
 
 	if sshKeyPath != "" {
-		container = container.WithFile("/ssh_key", m.client.Host().File(sshKeyPath))
+		container = container.WithFile("/ssh_key", hostFile(m.client, sshKeyPath))
 	}
 	if configPath != "" {
-		container = container.WithFile("/config.yaml", m.client.Host().File(configPath))
+		container = container.WithFile("/config.yaml", hostFile(m.client, configPath))
 	}
 
 	args := []string{"terrascan", "scan", "-r", repoURL, "-t", repoType, "-i", iacType}
@@ -349,17 +339,16 @@ func (m *TerrascanModule) RemoteRepositoryScan(ctx context.Context, repoURL stri
 
 // CustomPolicyManagement manages and validates custom Terrascan policies
 func (m *TerrascanModule) CustomPolicyManagement(ctx context.Context, action string, policyPath string, target string, iacType string, testDataPath string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest")
+	container := toolContainer(m.client, "tenable/terrascan:latest")
 
 	if policyPath != "" {
-		container = container.WithDirectory("/policies", m.client.Host().Directory(policyPath))
+		container = container.WithDirectory("/policies", hostDirectory(m.client, policyPath))
 	}
 	if target != "" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target))
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target))
 	}
 	if testDataPath != "" {
-		container = container.WithDirectory("/testdata", m.client.Host().Directory(testDataPath))
+		container = container.WithDirectory("/testdata", hostDirectory(m.client, testDataPath))
 	}
 
 	var args []string
@@ -392,13 +381,12 @@ func (m *TerrascanModule) CustomPolicyManagement(ctx context.Context, action str
 
 // CICDPipelineIntegration performs optimized IaC security scanning for CI/CD pipelines
 func (m *TerrascanModule) CICDPipelineIntegration(ctx context.Context, target string, iacType string, pipelineStage string, gatePolicy string, outputFormat string, outputFile string, failOnViolations bool, baselineFile string, quietMode bool) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	if baselineFile != "" {
-		container = container.WithFile("/baseline.json", m.client.Host().File(baselineFile))
+		container = container.WithFile("/baseline.json", hostFile(m.client, baselineFile))
 	}
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", "."}
@@ -450,8 +438,7 @@ func (m *TerrascanModule) CICDPipelineIntegration(ctx context.Context, target st
 
 // GetVersion returns the version of Terrascan
 func (m *TerrascanModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
+	container := toolContainer(m.client, "tenable/terrascan:latest").
 		WithExec([]string{"terrascan", "version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -466,9 +453,8 @@ func (m *TerrascanModule) GetVersion(ctx context.Context) (string, error) {
 
 // CloudProviderScan performs cloud provider specific security scanning
 func (m *TerrascanModule) CloudProviderScan(ctx context.Context, target string, cloudProvider string, iacType string, securityCategories string, serviceFocus string, includeBestPractices bool, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", ".", "--policy-type", cloudProvider}
@@ -503,9 +489,8 @@ func (m *TerrascanModule) CloudProviderScan(ctx context.Context, target string,
 
 // PerformanceOptimization performs high-performance IaC scanning with optimization features
 func (m *TerrascanModule) PerformanceOptimization(ctx context.Context, target string, iacType string, scanMode string, parallelWorkers string, maxFileSize string, skipLargeFiles bool, enableCaching bool, excludeDirs string, enableMetrics bool) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", "."}
@@ -557,13 +542,12 @@ func (m *TerrascanModule) PerformanceOptimization(ctx context.Context, target st
 
 // ComprehensiveReporting generates comprehensive IaC security reports with analytics
 func (m *TerrascanModule) ComprehensiveReporting(ctx context.Context, target string, iacType string, reportType string, outputFormats string, outputDirectory string, includeRemediation bool, includeTrends bool, baselineComparison string, includePolicyDetails bool) (string, error) {
-	container := m.client.Container().
-		From("tenable/terrascan:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(target)).
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, target)).
 		WithWorkdir("/workspace")
 
 	if baselineComparison != "" {
-		container = container.WithFile("/baseline.json", m.client.Host().File(baselineComparison))
+		container = container.WithFile("/baseline.json", hostFile(m.client, baselineComparison))
 	}
 
 	args := []string{"terrascan", "scan", "-i", iacType, "-d", "."}
@@ -610,4 +594,95 @@ func (m *TerrascanModule) ComprehensiveReporting(ctx context.Context, target str
 	}
 
 	return output, nil
-}
\ No newline at end of file
+}
+
+// Scan scans a directory with the given scan options
+func (m *TerrascanModule) Scan(ctx context.Context, dir string, opts ...TerrascanOption) (string, error) {
+	config := &TerrascanConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	container := toolContainer(m.client, "tenable/terrascan:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
+		WithWorkdir("/workspace")
+
+	if config.PolicyPath != "" {
+		container = container.WithDirectory(terrascanPolicyMount, hostDirectory(m.client, config.PolicyPath))
+	}
+
+	container = container.WithExec(terrascanScanArgs(config), dagger.ContainerWithExecOpts{
+		// Terrascan returns non-zero exit code when violations are found
+		Expect: "ANY",
+	})
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		if stderr != "" {
+			return stderr, nil
+		}
+		return "", fmt.Errorf("failed to run terrascan scan: %w", err)
+	}
+
+	return output, nil
+}
+
+// terrascanPolicyMount is where a custom policy directory is mounted inside the container
+const terrascanPolicyMount = "/policies"
+
+// terrascanScanArgs builds the terrascan scan command line
+func terrascanScanArgs(config *TerrascanConfig) []string {
+	args := []string{"terrascan", "scan", "-d", ".", "-o", "json"}
+
+	if config.IaCType != "" {
+		args = append(args, "-i", config.IaCType)
+	}
+	if config.PolicyPath != "" {
+		args = append(args, "--policy-path", terrascanPolicyMount)
+	}
+	if config.Severity != "" {
+		args = append(args, "--severity", config.Severity)
+	}
+	if len(config.SkipRules) > 0 {
+		args = append(args, "--skip-rules", strings.Join(config.SkipRules, ","))
+	}
+
+	return args
+}
+
+// TerrascanConfig holds the settings for a Terrascan run
+type TerrascanConfig struct {
+	IaCType    string
+	PolicyPath string
+	Severity   string
+	SkipRules  []string
+}
+
+// TerrascanOption sets a field of TerrascanConfig
+type TerrascanOption func(*TerrascanConfig)
+
+func WithTerrascanIaCType(iacType string) TerrascanOption {
+	return func(c *TerrascanConfig) {
+		c.IaCType = iacType
+	}
+}
+
+// WithTerrascanPolicyPath mounts a host policy directory and passes it via --policy-path
+func WithTerrascanPolicyPath(path string) TerrascanOption {
+	return func(c *TerrascanConfig) {
+		c.PolicyPath = path
+	}
+}
+
+func WithTerrascanSeverity(severity string) TerrascanOption {
+	return func(c *TerrascanConfig) {
+		c.Severity = severity
+	}
+}
+
+func WithTerrascanSkipRules(rules []string) TerrascanOption {
+	return func(c *TerrascanConfig) {
+		c.SkipRules = rules
+	}
+}
@@ -0,0 +1,35 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTerrascanScanArgs(t *testing.T) {
+	args := terrascanScanArgs(&TerrascanConfig{
+		IaCType:    "terraform",
+		PolicyPath: "./policies",
+		Severity:   "high",
+		SkipRules:  []string{"AC_AWS_0207", "AC_AWS_0214"},
+	})
+
+	expected := []string{
+		"terrascan", "scan", "-d", ".", "-o", "json",
+		"-i", "terraform",
+		"--policy-path", terrascanPolicyMount,
+		"--severity", "high",
+		"--skip-rules", "AC_AWS_0207,AC_AWS_0214",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTerrascanScanArgs_Defaults(t *testing.T) {
+	args := terrascanScanArgs(&TerrascanConfig{})
+
+	expected := []string{"terrascan", "scan", "-d", ".", "-o", "json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
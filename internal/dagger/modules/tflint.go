@@ -72,9 +72,8 @@ func (m *TFLintModule) Check(ctx context.Context, sourcePath string, opts TFLint
 		args = append(args, "--fix")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("tflint", "ghcr.io/terraform-linters/tflint:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(sourcePath)).
+	container := toolContainer(m.client, getImageTag("tflint", "ghcr.io/terraform-linters/tflint:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, sourcePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -105,9 +104,8 @@ func (m *TFLintModule) Init(ctx context.Context, sourcePath string, opts TFLintI
 		args = append(args, "--upgrade")
 	}
 
-	container := m.client.Container().
-		From(getImageTag("tflint", "ghcr.io/terraform-linters/tflint:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(sourcePath)).
+	container := toolContainer(m.client, getImageTag("tflint", "ghcr.io/terraform-linters/tflint:latest")).
+		WithDirectory("/workspace", hostDirectory(m.client, sourcePath)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
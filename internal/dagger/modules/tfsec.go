@@ -24,8 +24,7 @@ func NewTfsecModule(client *dagger.Client) *TfsecModule {
 
 // GetVersion returns the version of tfsec
 func (m *TfsecModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
 		WithExec([]string{"tfsec", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -61,9 +60,8 @@ func (m *TfsecModule) ScanDirectory(ctx context.Context, dir string, format stri
 	// Add common flags
 	args = append(args, "--no-color", "--soft-fail")
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -99,9 +97,8 @@ func (m *TfsecModule) ScanWithSeverity(ctx context.Context, dir string, severity
 	
 	args = append(args, "--soft-fail")
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -128,9 +125,8 @@ func (m *TfsecModule) ScanWithExcludes(ctx context.Context, dir string, excludes
 		args = append(args, "--exclude", exclude)
 	}
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -150,13 +146,12 @@ func (m *TfsecModule) ScanWithConfig(ctx context.Context, dir string, configPath
 		dir = "."
 	}
 	
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir))
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir))
 	
 	// Add config file if specified
 	if configPath != "" {
-		container = container.WithFile("/config.yml", m.client.Host().File(configPath))
+		container = container.WithFile("/config.yml", hostFile(m.client, configPath))
 	}
 	
 	args := []string{"tfsec", "/workspace", "--format", "json", "--no-color", "--soft-fail"}
@@ -190,13 +185,12 @@ func (m *TfsecModule) ValidateTfvars(ctx context.Context, dir string, tfvarsFile
 		args = append(args, "--tfvars-file", tfvarsFile)
 	}
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace")
 	
 	if tfvarsFile != "" {
-		container = container.WithFile("/tfvars.auto.tfvars", m.client.Host().File(tfvarsFile))
+		container = container.WithFile("/tfvars.auto.tfvars", hostFile(m.client, tfvarsFile))
 		args[len(args)-1] = "/tfvars.auto.tfvars"
 	}
 	
@@ -220,9 +214,8 @@ func (m *TfsecModule) GenerateMetrics(ctx context.Context, dir string) (string,
 	
 	args := []string{"tfsec", dir, "--format", "metrics", "--no-color", "--soft-fail"}
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -242,15 +235,14 @@ func (m *TfsecModule) RunWithCustomChecks(ctx context.Context, dir string, custo
 		dir = "."
 	}
 	
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir))
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir))
 	
 	args := []string{"tfsec", "/workspace", "--format", "json", "--no-color", "--soft-fail"}
 	
 	// Add custom checks directory if specified
 	if customChecksDir != "" {
-		container = container.WithDirectory("/custom-checks", m.client.Host().Directory(customChecksDir))
+		container = container.WithDirectory("/custom-checks", hostDirectory(m.client, customChecksDir))
 		args = append(args, "--custom-check-dir", "/custom-checks")
 	}
 
@@ -278,9 +270,8 @@ func (m *TfsecModule) ScanWithIgnores(ctx context.Context, dir string, showIgnor
 		args = append(args, "--include-ignored")
 	}
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -318,9 +309,8 @@ func (m *TfsecModule) GenerateReport(ctx context.Context, dir string, reportType
 	
 	args := []string{"tfsec", dir, "--format", format, "--no-color", "--soft-fail"}
 
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -336,8 +326,7 @@ func (m *TfsecModule) GenerateReport(ctx context.Context, dir string, reportType
 
 // UpdateChecks updates tfsec check definitions
 func (m *TfsecModule) UpdateChecks(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("aquasec/tfsec:latest").
+	container := toolContainer(m.client, "aquasec/tfsec:latest").
 		WithExec([]string{"tfsec", "--update"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -25,12 +25,11 @@ func NewTfstateReaderModule(client *dagger.Client) *TfstateReaderModule {
 
 // AnalyzeState analyzes a Terraform state file
 func (m *TfstateReaderModule) AnalyzeState(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			"{ version: .version, terraform_version: .terraform_version, serial: .serial, lineage: .lineage, resources: [.resources[] | {type: .type, name: .name, provider: .provider, instances: (.instances | length)}] }",
@@ -49,12 +48,11 @@ func (m *TfstateReaderModule) AnalyzeState(ctx context.Context, statePath string
 
 // ListResources lists resources in state file
 func (m *TfstateReaderModule) ListResources(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			"[.resources[] | {type: .type, name: .name, mode: .mode, provider: .provider}]",
@@ -73,12 +71,11 @@ func (m *TfstateReaderModule) ListResources(ctx context.Context, statePath strin
 
 // GetResourceByType gets resources by type
 func (m *TfstateReaderModule) GetResourceByType(ctx context.Context, statePath string, resourceType string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			fmt.Sprintf(`[.resources[] | select(.type == "%s")]`, resourceType),
@@ -97,12 +94,11 @@ func (m *TfstateReaderModule) GetResourceByType(ctx context.Context, statePath s
 
 // ExtractOutputs extracts outputs from state file
 func (m *TfstateReaderModule) ExtractOutputs(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			".outputs",
@@ -121,9 +117,8 @@ func (m *TfstateReaderModule) ExtractOutputs(ctx context.Context, statePath stri
 
 // ShowState shows state information using terraform show
 func (m *TfstateReaderModule) ShowState(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/terraform:latest").
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+	container := toolContainer(m.client, "hashicorp/terraform:latest").
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"terraform", "show", "-json", "/terraform.tfstate",
 		}, dagger.ContainerWithExecOpts{
@@ -140,9 +135,8 @@ func (m *TfstateReaderModule) ShowState(ctx context.Context, statePath string) (
 
 // PullRemoteState pulls state from remote backend
 func (m *TfstateReaderModule) PullRemoteState(ctx context.Context, workdir string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/terraform:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(workdir)).
+	container := toolContainer(m.client, "hashicorp/terraform:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, workdir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"terraform", "init"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -163,12 +157,11 @@ func (m *TfstateReaderModule) PullRemoteState(ctx context.Context, workdir strin
 
 // InteractiveExplorer provides interactive state exploration
 func (m *TfstateReaderModule) InteractiveExplorer(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"sh", "-c", "echo 'Interactive exploration:' && jq '.' /terraform.tfstate | head -50",
 		}, dagger.ContainerWithExecOpts{
@@ -185,9 +178,8 @@ func (m *TfstateReaderModule) InteractiveExplorer(ctx context.Context, statePath
 
 // StateListResources lists resources using terraform state list
 func (m *TfstateReaderModule) StateListResources(ctx context.Context, statePath string, resourceId string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/terraform:latest").
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath))
+	container := toolContainer(m.client, "hashicorp/terraform:latest").
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath))
 
 	args := []string{"terraform", "state", "list", "-state=/terraform.tfstate"}
 	if resourceId != "" {
@@ -208,9 +200,8 @@ func (m *TfstateReaderModule) StateListResources(ctx context.Context, statePath
 
 // StateShowResource shows a specific resource using terraform state show
 func (m *TfstateReaderModule) StateShowResource(ctx context.Context, statePath string, resourceAddress string) (string, error) {
-	container := m.client.Container().
-		From("hashicorp/terraform:latest").
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+	container := toolContainer(m.client, "hashicorp/terraform:latest").
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"terraform", "state", "show", "-state=/terraform.tfstate", resourceAddress,
 		}, dagger.ContainerWithExecOpts{
@@ -228,12 +219,11 @@ func (m *TfstateReaderModule) StateShowResource(ctx context.Context, statePath s
 // LookupResource looks up resource using tfstate-lookup functionality (simulated with jq)
 func (m *TfstateReaderModule) LookupResource(ctx context.Context, statePath string, resourceAddress string) (string, error) {
 	// Since we don't have tfstate-lookup in container, simulate with jq
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			fmt.Sprintf(`[.resources[] | select(.type + "." + .name == "%s") | .instances[]]`, resourceAddress),
@@ -252,12 +242,11 @@ func (m *TfstateReaderModule) LookupResource(ctx context.Context, statePath stri
 
 // DumpAllResources dumps all resources (simulated tfstate-lookup -dump)
 func (m *TfstateReaderModule) DumpAllResources(ctx context.Context, statePath string) (string, error) {
-	container := m.client.Container().
-		From("alpine:latest").
+	container := toolContainer(m.client, "alpine:latest").
 		WithExec([]string{"apk", "add", "--no-cache", "jq"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		}).
-		WithFile("/terraform.tfstate", m.client.Host().File(statePath)).
+		WithFile("/terraform.tfstate", hostFile(m.client, statePath)).
 		WithExec([]string{
 			"jq",
 			`{
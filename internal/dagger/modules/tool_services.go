@@ -19,8 +19,7 @@ const goBinary = "go"
 // SteampipeService exposes Steampipe as an HTTP API service
 func NewSteampipeService(client *dagger.Client) *dagger.Service {
 	// Create a container that runs an HTTP API for Steampipe
-	container := client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(client, "golang:1.21-alpine").
 		WithExec([]string{"apk", "add", "--no-cache", "curl"}).
 		WithNewFile("/app/server.go", steampipeServerCode).
 		WithExec([]string{goBinary, "run", "/app/server.go"}).
@@ -33,8 +32,7 @@ func NewSteampipeService(client *dagger.Client) *dagger.Service {
 
 // OpenInfraQuoteService exposes cost analysis as a service
 func NewOpenInfraQuoteService(client *dagger.Client) *dagger.Service {
-	container := client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(client, "golang:1.21-alpine").
 		WithNewFile("/app/server.go", costAnalysisServerCode).
 		WithExec([]string{goBinary, "run", "/app/server.go"}).
 		WithExposedPort(8002).
@@ -46,8 +44,7 @@ func NewOpenInfraQuoteService(client *dagger.Client) *dagger.Service {
 
 // TerraformDocsService exposes documentation generation as a service
 func NewTerraformDocsService(client *dagger.Client) *dagger.Service {
-	container := client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(client, "golang:1.21-alpine").
 		WithNewFile("/app/server.go", terraformDocsServerCode).
 		WithExec([]string{goBinary, "run", "/app/server.go"}).
 		WithExposedPort(8003).
@@ -59,8 +56,7 @@ func NewTerraformDocsService(client *dagger.Client) *dagger.Service {
 
 // InfraMapService exposes infrastructure diagram generation as a service
 func NewInfraMapService(client *dagger.Client) *dagger.Service {
-	container := client.Container().
-		From("golang:1.21-alpine").
+	container := toolContainer(client, "golang:1.21-alpine").
 		WithNewFile("/app/server.go", infraMapServerCode).
 		WithExec([]string{goBinary, "run", "/app/server.go"}).
 		WithExposedPort(8005).
@@ -75,8 +71,7 @@ func NewToolRegistryService(client *dagger.Client, services map[string]*dagger.S
 	// Create a registry that knows about all services
 	registryCode := generateRegistryCode(services)
 
-	registryContainer := client.Container().
-		From("golang:1.21-alpine").
+	registryContainer := toolContainer(client, "golang:1.21-alpine").
 		WithNewFile("/app/registry.go", registryCode).
 		WithExec([]string{goBinary, "run", "/app/registry.go"}).
 		WithExposedPort(8000)
@@ -121,8 +116,7 @@ func NewLLMWithServiceTools(client *dagger.Client, model string) *LLMWithService
 // InvestigateWithServices performs investigation using service-based tools
 func (m *LLMWithServiceTools) InvestigateWithServices(ctx context.Context, task string) (*ServiceInvestigationReport, error) {
 	// Create a container that has access to all services
-	investigatorContainer := m.client.Container().
-		From("alpine:latest").
+	investigatorContainer := toolContainer(m.client, "alpine:latest").
 		WithServiceBinding("tool-registry", m.toolRegistry)
 
 	// Bind all tool services
@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -23,66 +25,59 @@ func NewTrivyModule(client *dagger.Client) *TrivyModule {
 }
 
 // ScanImage scans a container image for vulnerabilities
-func (m *TrivyModule) ScanImage(ctx context.Context, imageName string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithExec([]string{
-			"trivy", "image", "--format", "json", "--severity", "HIGH,CRITICAL", imageName,
-		}, dagger.ContainerWithExecOpts{
+func (m *TrivyModule) ScanImage(ctx context.Context, imageName string, opts ...TrivyOption) (string, error) {
+	config := newTrivyConfig(opts)
+	args, err := trivyScanArgs("image", imageName, config)
+	if err != nil {
+		return "", err
+	}
+
+	container := m.scanContainer().
+		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
-	output, _ := container.Stdout(ctx)
-	if output != "" {
-		return output, nil
-	}
-
-	return "", fmt.Errorf("failed to scan image: no output received")
+	return trivyScanOutput(ctx, container, config, "image")
 }
 
 // ScanFilesystem scans a filesystem for vulnerabilities
-func (m *TrivyModule) ScanFilesystem(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+func (m *TrivyModule) ScanFilesystem(ctx context.Context, dir string, opts ...TrivyOption) (string, error) {
+	config := newTrivyConfig(opts)
+	args, err := trivyScanArgs("fs", ".", config)
+	if err != nil {
+		return "", err
+	}
+
+	container := m.scanContainer().
+		WithDirectory("/workspace", hostScanDir(m.client, dir)).
 		WithWorkdir("/workspace").
-		WithExec([]string{
-			"trivy", "fs", "--format", "json", "--severity", "HIGH,CRITICAL", ".",
-		}, dagger.ContainerWithExecOpts{
+		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
-	output, _ := container.Stdout(ctx)
-	if output != "" {
-		return output, nil
-	}
-
-	return "", fmt.Errorf("failed to scan filesystem: no output received")
+	return trivyScanOutput(ctx, container, config, "filesystem")
 }
 
 // ScanRepository scans a git repository
-func (m *TrivyModule) ScanRepository(ctx context.Context, repoURL string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithExec([]string{
-			"trivy", "repo", "--format", "json", "--severity", "HIGH,CRITICAL", repoURL,
-		}, dagger.ContainerWithExecOpts{
+func (m *TrivyModule) ScanRepository(ctx context.Context, repoURL string, opts ...TrivyOption) (string, error) {
+	config := newTrivyConfig(opts)
+	args, err := trivyScanArgs("repo", repoURL, config)
+	if err != nil {
+		return "", err
+	}
+
+	container := m.scanContainer().
+		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
-	output, _ := container.Stdout(ctx)
-	if output != "" {
-		return output, nil
-	}
-
-	return "", fmt.Errorf("failed to scan repository: no output received")
+	return trivyScanOutput(ctx, container, config, "repository")
 }
 
 // ScanConfig scans configuration files for misconfigurations
 func (m *TrivyModule) ScanConfig(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
+		WithDirectory("/workspace", hostScanDir(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{
 			"trivy", "config", "--format", "json", "--severity", "HIGH,CRITICAL", ".",
@@ -100,8 +95,7 @@ func (m *TrivyModule) ScanConfig(ctx context.Context, dir string) (string, error
 
 // GetVersion returns the version of Trivy
 func (m *TrivyModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
 		WithExec([]string{
 			"trivy", "--version",
 		}, dagger.ContainerWithExecOpts{
@@ -118,9 +112,8 @@ func (m *TrivyModule) GetVersion(ctx context.Context) (string, error) {
 
 // ScanSBOM scans SBOM file for vulnerabilities
 func (m *TrivyModule) ScanSBOM(ctx context.Context, sbomPath string, severity string, outputFormat string, outputFile string, ignoreUnfixed bool) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithFile("/sbom.json", m.client.Host().File(sbomPath))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
+		WithFile("/sbom.json", hostFile(m.client, sbomPath))
 
 	args := []string{"sbom", "/sbom.json"}
 	if severity != "" {
@@ -148,22 +141,40 @@ func (m *TrivyModule) ScanSBOM(ctx context.Context, sbomPath string, severity st
 	return output, nil
 }
 
-// ScanKubernetes scans Kubernetes cluster for vulnerabilities
-func (m *TrivyModule) ScanKubernetes(ctx context.Context, target string, clusterContext string, namespace string, severity string, outputFormat string, scanners string, includeImages bool) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+// ScanKubernetes scans the cluster of the configured kubeconfig, or of
+// clusterContext in it when set, for vulnerabilities and misconfigurations
+func (m *TrivyModule) ScanKubernetes(ctx context.Context, clusterContext string, severity string, outputFormat string, scanners string, includeImages bool, opts ...TrivyKubernetesOption) (string, error) {
+	config := newTrivyKubernetesConfig(opts)
+	args, err := trivyKubernetesScanArgs(clusterContext, severity, outputFormat, scanners, includeImages, config)
+	if err != nil {
+		return "", err
+	}
 
-	if target == "" {
-		target = "cluster"
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
+		WithFile(trivyKubeconfigMount, hostFile(m.client, config.Kubeconfig)).
+		WithExec(args, dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to scan Kubernetes: %w", err)
 	}
 
-	args := []string{"k8s", target}
-	if clusterContext != "" {
-		args = append(args, "--context", clusterContext)
+	return output, nil
+}
+
+// trivyKubernetesScanArgs builds the trivy k8s command line. Trivy reads its
+// only positional argument as a kubeconfig context.
+func trivyKubernetesScanArgs(clusterContext, severity, outputFormat, scanners string, includeImages bool, config *TrivyKubernetesConfig) ([]string, error) {
+	if config.Kubeconfig == "" {
+		return nil, fmt.Errorf("a Kubernetes scan needs a kubeconfig")
 	}
-	if namespace != "" {
-		args = append(args, "--namespace", namespace)
+	if err := resolveTrivyKubernetesScope(config); err != nil {
+		return nil, err
 	}
+
+	args := []string{"trivy", "k8s"}
 	if severity != "" {
 		args = append(args, "--severity", severity)
 	}
@@ -176,26 +187,19 @@ func (m *TrivyModule) ScanKubernetes(ctx context.Context, target string, cluster
 	if includeImages {
 		args = append(args, "--include-images")
 	}
-
-	container = container.WithExec(args, dagger.ContainerWithExecOpts{
-		Expect: "ANY",
-	})
-
-	output, err := container.Stdout(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to scan Kubernetes: %w", err)
+	args = append(args, trivyKubernetesArgs(config)...)
+	if clusterContext != "" {
+		args = append(args, clusterContext)
 	}
-
-	return output, nil
+	return args, nil
 }
 
 // GenerateSBOM generates Software Bill of Materials
 func (m *TrivyModule) GenerateSBOM(ctx context.Context, target string, targetType string, sbomFormat string, outputFile string, includeDevDeps bool) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	if targetType == "fs" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
@@ -222,16 +226,15 @@ func (m *TrivyModule) GenerateSBOM(ctx context.Context, target string, targetTyp
 
 // ScanWithFilters scans with advanced filtering options
 func (m *TrivyModule) ScanWithFilters(ctx context.Context, target string, targetType string, severity string, vulnType string, ignoreFile string, ignoreUnfixed bool, exitCode string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	if targetType == "fs" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
 	if ignoreFile != "" {
-		container = container.WithFile("/.trivyignore", m.client.Host().File(ignoreFile))
+		container = container.WithFile("/.trivyignore", hostFile(m.client, ignoreFile))
 	}
 
 	args := []string{targetType, target}
@@ -265,8 +268,7 @@ func (m *TrivyModule) ScanWithFilters(ctx context.Context, target string, target
 
 // DatabaseUpdate performs database operations
 func (m *TrivyModule) DatabaseUpdate(ctx context.Context, operation string, skipUpdate bool, cacheDir string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	var args []string
 	switch operation {
@@ -298,8 +300,7 @@ func (m *TrivyModule) DatabaseUpdate(ctx context.Context, operation string, skip
 
 // ServerMode runs Trivy in server mode
 func (m *TrivyModule) ServerMode(ctx context.Context, listenPort string, listenAddress string, debug bool, token string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	args := []string{"server"}
 	if listenPort != "" {
@@ -329,11 +330,10 @@ func (m *TrivyModule) ServerMode(ctx context.Context, listenPort string, listenA
 
 // ClientScan scans using client mode
 func (m *TrivyModule) ClientScan(ctx context.Context, target string, targetType string, serverURL string, token string, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	if targetType == "fs" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
@@ -360,8 +360,7 @@ func (m *TrivyModule) ClientScan(ctx context.Context, target string, targetType
 
 // PluginManagement manages Trivy plugins
 func (m *TrivyModule) PluginManagement(ctx context.Context, action string, pluginName string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest"))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
 
 	args := []string{"plugin", action}
 	if pluginName != "" && action != "list" {
@@ -382,9 +381,8 @@ func (m *TrivyModule) PluginManagement(ctx context.Context, action string, plugi
 
 // ConvertSBOM converts SBOM between different formats
 func (m *TrivyModule) ConvertSBOM(ctx context.Context, inputSBOM string, outputFormat string, outputFile string) (string, error) {
-	container := m.client.Container().
-		From(getImageTag("trivy", "aquasec/trivy:latest")).
-		WithFile("/input.sbom", m.client.Host().File(inputSBOM))
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
+		WithFile("/input.sbom", hostFile(m.client, inputSBOM))
 
 	args := []string{"convert", "--format", outputFormat, "/input.sbom"}
 	if outputFile != "" {
@@ -402,3 +400,499 @@ func (m *TrivyModule) ConvertSBOM(ctx context.Context, inputSBOM string, outputF
 
 	return output, nil
 }
+
+// trivyScanners are the values accepted by Trivy's --scanners flag
+var trivyScanners = map[string]bool{
+	"vuln":      true,
+	"secret":    true,
+	"misconfig": true,
+	"license":   true,
+}
+
+// trivyFormats are the report formats the scans produce. cosign-vuln is a
+// cosign vulnerability attestation predicate and github a GitHub dependency
+// snapshot, so results can be attached with `cosign attest` or submitted to
+// the dependency submission API.
+var trivyFormats = map[string]bool{
+	"json":        true,
+	"cosign-vuln": true,
+	"github":      true,
+}
+
+// trivyReportMount is where the report is written before being exported to the host
+const trivyReportMount = "/tmp/trivy-report"
+
+const (
+	// trivyCacheVolumeKey is kept stable so scans share the vulnerability and
+	// Java DBs instead of downloading them every run
+	trivyCacheVolumeKey = "ship-trivy-cache"
+
+	trivyCacheDir = "/root/.cache/trivy"
+)
+
+// scanContainer returns a trivy container with the DB cache attached
+func (m *TrivyModule) scanContainer() *dagger.Container {
+	key, path := trivyCacheMount()
+	return toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest")).
+		WithMountedCache(path, m.client.CacheVolume(key))
+}
+
+// trivyCacheMount returns the cache volume key and the path it is mounted
+// at. Trivy keeps the vulnerability DB in db/ and the Java DB in java-db/
+// under it.
+func trivyCacheMount() (string, string) {
+	return trivyCacheVolumeKey, trivyCacheDir
+}
+
+// TrivyConfig holds the settings for a Trivy run
+type TrivyConfig struct {
+	Scanners []string
+	Format   string
+	// OutputPath is a host path the report is exported to
+	OutputPath string
+	// JavaDBRepository is an OCI repository to download the Java DB from
+	JavaDBRepository string
+}
+
+// TrivyOption sets a field of TrivyConfig
+type TrivyOption func(*TrivyConfig)
+
+// WithTrivyScanners selects the scanners to run (vuln, secret, misconfig,
+// license). Only vuln runs by default.
+func WithTrivyScanners(scanners []string) TrivyOption {
+	return func(c *TrivyConfig) {
+		c.Scanners = scanners
+	}
+}
+
+// WithTrivyMisconfigOnly runs only the misconfig scanner, a fast IaC check
+// that skips the vulnerability and secret scanners and their DB downloads
+func WithTrivyMisconfigOnly() TrivyOption {
+	return WithTrivyScanners([]string{"misconfig"})
+}
+
+// WithTrivyFormat sets the report format (json, cosign-vuln, github)
+func WithTrivyFormat(format string) TrivyOption {
+	return func(c *TrivyConfig) {
+		c.Format = format
+	}
+}
+
+// WithTrivyOutput exports the report to a host path, e.g. a predicate file
+// for `cosign attest --type vuln`
+func WithTrivyOutput(path string) TrivyOption {
+	return func(c *TrivyConfig) {
+		c.OutputPath = path
+	}
+}
+
+// WithTrivyJavaDBRepository downloads the Java DB from an OCI repository,
+// such as a mirror reachable from behind a proxy, instead of the default
+func WithTrivyJavaDBRepository(repository string) TrivyOption {
+	return func(c *TrivyConfig) {
+		c.JavaDBRepository = repository
+	}
+}
+
+func newTrivyConfig(opts []TrivyOption) *TrivyConfig {
+	config := &TrivyConfig{
+		Scanners: []string{"vuln"},
+		Format:   "json",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// trivyScanArgs builds the command line for a scan of target with the given
+// trivy subcommand (image, fs or repo). With an output path the report is
+// written to trivyReportMount instead of stdout.
+func trivyScanArgs(subcommand string, target string, config *TrivyConfig) ([]string, error) {
+	if !trivyFormats[config.Format] {
+		return nil, fmt.Errorf("unsupported trivy format %q: must be json, cosign-vuln or github", config.Format)
+	}
+	if config.Format == "cosign-vuln" && subcommand != "image" {
+		return nil, fmt.Errorf("the cosign-vuln format is only supported for image scans")
+	}
+
+	scanners, err := trivyScannersArg(config.Scanners)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"trivy", subcommand, "--format", config.Format, "--severity", "HIGH,CRITICAL", "--scanners", scanners}
+	if config.JavaDBRepository != "" {
+		args = append(args, "--java-db-repository", config.JavaDBRepository)
+	}
+	if config.OutputPath != "" {
+		args = append(args, "--output", trivyReportMount)
+	}
+	return append(args, target), nil
+}
+
+// trivyScanOutput returns the report of a scan. When an output path is set
+// the report file is exported there and its contents are returned.
+func trivyScanOutput(ctx context.Context, container *dagger.Container, config *TrivyConfig, target string) (string, error) {
+	if config.OutputPath == "" {
+		output, _ := container.Stdout(ctx)
+		if output != "" {
+			return output, nil
+		}
+		stderr, _ := container.Stderr(ctx)
+		if err := trivyJavaDBError(stderr); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to scan %s: no output received", target)
+	}
+
+	report := container.File(trivyReportMount)
+	if _, err := report.Export(ctx, config.OutputPath); err != nil {
+		stderr, _ := container.Stderr(ctx)
+		if javaDBErr := trivyJavaDBError(stderr); javaDBErr != nil {
+			return "", javaDBErr
+		}
+		return "", fmt.Errorf("failed to export trivy %s report to %s: %w\nStderr: %s", config.Format, config.OutputPath, err, stderr)
+	}
+	return report.Contents(ctx)
+}
+
+// trivyJavaDBError explains a failed Java DB download reported on stderr.
+// Trivy fetches the Java DB separately from the vulnerability DB, from
+// ghcr.io by default, which is often blocked behind proxies.
+func trivyJavaDBError(stderr string) error {
+	lower := strings.ToLower(stderr)
+	if !strings.Contains(lower, "java db") && !strings.Contains(lower, "java-db") {
+		return nil
+	}
+	if !strings.Contains(lower, "download") && !strings.Contains(lower, "fetch") && !strings.Contains(lower, "pull") {
+		return nil
+	}
+	return fmt.Errorf("trivy could not download its Java DB, which it needs to scan Java archives; "+
+		"check HTTPS_PROXY or use --java-db-repository to point at a reachable mirror: %s", strings.TrimSpace(stderr))
+}
+
+// trivyScannersArg validates scanners and joins them for --scanners, dropping
+// blanks and duplicates. An empty selection falls back to vuln.
+func trivyScannersArg(scanners []string) (string, error) {
+	var selected []string
+	seen := make(map[string]bool)
+	for _, scanner := range scanners {
+		scanner = strings.ToLower(strings.TrimSpace(scanner))
+		if scanner == "" || seen[scanner] {
+			continue
+		}
+		if !trivyScanners[scanner] {
+			return "", fmt.Errorf("unsupported trivy scanner %q: must be vuln, secret, misconfig or license", scanner)
+		}
+		seen[scanner] = true
+		selected = append(selected, scanner)
+	}
+
+	if len(selected) == 0 {
+		return "vuln", nil
+	}
+	return strings.Join(selected, ","), nil
+}
+
+// trivyKubeconfigMount is where the kubeconfig is mounted for cluster compliance scans
+const trivyKubeconfigMount = "/root/.kube/config"
+
+// TrivyComplianceSpec is a compliance spec bundled with trivy and the
+// subcommand that evaluates it
+type TrivyComplianceSpec struct {
+	ID         string
+	Subcommand string
+}
+
+// trivyComplianceSpecs maps the accepted --compliance names to the versioned
+// specs trivy ships. Docker specs scan an image, Kubernetes specs a cluster.
+var trivyComplianceSpecs = map[string]TrivyComplianceSpec{
+	"docker-cis":         {ID: "docker-cis-1.6.0", Subcommand: "image"},
+	"k8s-cis":            {ID: "k8s-cis-1.23", Subcommand: "k8s"},
+	"k8s-nsa":            {ID: "k8s-nsa-1.0", Subcommand: "k8s"},
+	"k8s-pss-baseline":   {ID: "k8s-pss-baseline-0.1", Subcommand: "k8s"},
+	"k8s-pss-restricted": {ID: "k8s-pss-restricted-0.1", Subcommand: "k8s"},
+}
+
+// LookupTrivyComplianceSpec resolves a compliance spec by its short name,
+// e.g. k8s-cis, or its versioned ID, e.g. k8s-cis-1.23
+func LookupTrivyComplianceSpec(name string) (TrivyComplianceSpec, bool) {
+	if spec, ok := trivyComplianceSpecs[name]; ok {
+		return spec, true
+	}
+	for _, spec := range trivyComplianceSpecs {
+		if spec.ID == name {
+			return spec, true
+		}
+	}
+	return TrivyComplianceSpec{}, false
+}
+
+// TrivyComplianceSpecNames returns the short names and versioned IDs of the
+// supported compliance specs in sorted order
+func TrivyComplianceSpecNames() []string {
+	names := make([]string, 0, 2*len(trivyComplianceSpecs))
+	for name, spec := range trivyComplianceSpecs {
+		names = append(names, name, spec.ID)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsCluster reports whether the spec is evaluated against a Kubernetes cluster
+func (s TrivyComplianceSpec) IsCluster() bool {
+	return s.Subcommand == "k8s"
+}
+
+// ScanCompliance evaluates a compliance spec such as docker-cis or k8s-cis.
+// Docker specs scan the image target; Kubernetes specs scan the cluster of
+// the configured kubeconfig, using target as its context when set.
+func (m *TrivyModule) ScanCompliance(ctx context.Context, target string, specName string, opts ...TrivyComplianceOption) (string, error) {
+	config := newTrivyComplianceConfig(opts)
+	spec, ok := LookupTrivyComplianceSpec(specName)
+	if !ok {
+		return "", fmt.Errorf("unsupported trivy compliance spec %q", specName)
+	}
+	args, err := trivyComplianceArgs(spec, target, config)
+	if err != nil {
+		return "", err
+	}
+
+	container := toolContainer(m.client, getImageTag("trivy", "aquasec/trivy:latest"))
+	if spec.IsCluster() {
+		container = container.WithFile(trivyKubeconfigMount, hostFile(m.client, config.Kubeconfig))
+	}
+	container = container.WithExec(args, dagger.ContainerWithExecOpts{
+		// trivy exits non-zero when it cannot reach the image or cluster
+		Expect: "ANY",
+	})
+
+	output, _ := container.Stdout(ctx)
+	if output != "" {
+		return output, nil
+	}
+
+	stderr, _ := container.Stderr(ctx)
+	if stderr != "" {
+		return "", fmt.Errorf("trivy %s compliance scan failed: %s", spec.ID, strings.TrimSpace(stderr))
+	}
+	return "", fmt.Errorf("trivy %s compliance scan failed: no output received", spec.ID)
+}
+
+// trivyComplianceArgs builds the command line evaluating spec against target
+func trivyComplianceArgs(spec TrivyComplianceSpec, target string, config *TrivyComplianceConfig) ([]string, error) {
+	if config.Report != "summary" && config.Report != "all" {
+		return nil, fmt.Errorf("unsupported trivy compliance report %q: must be summary or all", config.Report)
+	}
+	if config.Format != "table" && config.Format != "json" {
+		return nil, fmt.Errorf("unsupported trivy compliance format %q: must be table or json", config.Format)
+	}
+
+	args := []string{"trivy", spec.Subcommand, "--compliance", spec.ID, "--report", config.Report, "--format", config.Format}
+
+	if spec.IsCluster() {
+		if config.Kubeconfig == "" {
+			return nil, fmt.Errorf("the %s compliance spec scans a cluster and needs a kubeconfig", spec.ID)
+		}
+		args = append(args, "--kubeconfig", trivyKubeconfigMount)
+		if target != "" {
+			args = append(args, "--context", target)
+		}
+		return args, nil
+	}
+
+	if target == "" {
+		return nil, fmt.Errorf("the %s compliance spec needs an image to scan", spec.ID)
+	}
+	return append(args, target), nil
+}
+
+// TrivyComplianceConfig holds the settings for a Trivy compliance run
+type TrivyComplianceConfig struct {
+	// Report is summary or all
+	Report     string
+	Format     string
+	Kubeconfig string
+}
+
+// TrivyComplianceOption sets a field of TrivyComplianceConfig
+type TrivyComplianceOption func(*TrivyComplianceConfig)
+
+// WithTrivyComplianceReport selects a summary of the controls or all their
+// findings
+func WithTrivyComplianceReport(report string) TrivyComplianceOption {
+	return func(c *TrivyComplianceConfig) {
+		c.Report = report
+	}
+}
+
+// WithTrivyComplianceFormat sets the report format (table, json)
+func WithTrivyComplianceFormat(format string) TrivyComplianceOption {
+	return func(c *TrivyComplianceConfig) {
+		c.Format = format
+	}
+}
+
+// WithTrivyComplianceKubeconfig sets the kubeconfig of the cluster scanned
+// by Kubernetes specs
+func WithTrivyComplianceKubeconfig(path string) TrivyComplianceOption {
+	return func(c *TrivyComplianceConfig) {
+		c.Kubeconfig = path
+	}
+}
+
+func newTrivyComplianceConfig(opts []TrivyComplianceOption) *TrivyComplianceConfig {
+	config := &TrivyComplianceConfig{
+		Report: "summary",
+		Format: "json",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// trivyKubernetesArgs builds the namespace and kind filter and kubeconfig
+// arguments of a Kubernetes scan
+func trivyKubernetesArgs(config *TrivyKubernetesConfig) []string {
+	var args []string
+	if len(config.IncludeNamespaces) > 0 {
+		args = append(args, "--include-namespaces", strings.Join(config.IncludeNamespaces, ","))
+	}
+	if len(config.ExcludeNamespaces) > 0 {
+		args = append(args, "--exclude-namespaces", strings.Join(config.ExcludeNamespaces, ","))
+	}
+	if len(config.IncludeKinds) > 0 {
+		args = append(args, "--include-kinds", strings.Join(config.IncludeKinds, ","))
+	}
+	if config.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", trivyKubeconfigMount)
+	}
+	return args
+}
+
+// trivyWorkloadKinds are the resource kinds scanned for the workload target
+var trivyWorkloadKinds = []string{"pod", "replicaset", "replicationcontroller", "statefulset", "daemonset", "cronjob", "job", "deployment"}
+
+// trivyKubernetesKinds are the resource kinds trivy k8s can be limited to
+var trivyKubernetesKinds = map[string]bool{
+	"pod": true, "replicaset": true, "replicationcontroller": true, "statefulset": true,
+	"daemonset": true, "cronjob": true, "job": true, "deployment": true, "node": true,
+	"service": true, "configmap": true, "serviceaccount": true, "namespace": true,
+	"role": true, "rolebinding": true, "clusterrole": true, "clusterrolebinding": true,
+	"networkpolicy": true, "ingress": true, "resourcequota": true, "limitrange": true,
+	"customresourcedefinition": true,
+}
+
+// trivyTargetKinds maps a scan target onto the resource kinds it covers.
+// cluster and all scan every kind; workload scans the workload kinds; any
+// other target must be a single resource kind.
+func trivyTargetKinds(target string) ([]string, error) {
+	switch target = strings.ToLower(target); target {
+	case "", "cluster", "all":
+		return nil, nil
+	case "workload":
+		return trivyWorkloadKinds, nil
+	}
+	if !trivyKubernetesKinds[target] {
+		return nil, fmt.Errorf("unsupported Kubernetes scan target %q: use cluster, all, workload or a resource kind such as deployment", target)
+	}
+	return []string{target}, nil
+}
+
+// resolveTrivyKubernetesScope folds the single namespace and target into the
+// include filters trivy understands, rejecting combinations that would make
+// the scan wider or narrower than requested
+func resolveTrivyKubernetesScope(config *TrivyKubernetesConfig) error {
+	if config.Namespace != "" {
+		if len(config.IncludeNamespaces) > 0 || len(config.ExcludeNamespaces) > 0 {
+			return fmt.Errorf("namespace cannot be combined with include or exclude namespaces")
+		}
+		config.IncludeNamespaces = []string{config.Namespace}
+	}
+
+	kinds, err := trivyTargetKinds(config.Target)
+	if err != nil {
+		return err
+	}
+	if len(kinds) > 0 {
+		if len(config.IncludeKinds) > 0 {
+			return fmt.Errorf("target %q cannot be combined with include kinds", config.Target)
+		}
+		config.IncludeKinds = kinds
+	}
+	return nil
+}
+
+// TrivyKubernetesConfig holds the settings for a Trivy Kubernetes run
+type TrivyKubernetesConfig struct {
+	IncludeNamespaces []string
+	ExcludeNamespaces []string
+	// IncludeKinds limits the scan to resource kinds, e.g. deployment
+	IncludeKinds []string
+	// Namespace limits the scan to one namespace; it cannot be combined
+	// with IncludeNamespaces or ExcludeNamespaces
+	Namespace string
+	// Target is cluster, all, workload or a resource kind; it cannot be
+	// combined with IncludeKinds
+	Target string
+	// Kubeconfig is a host path to the kubeconfig of the scanned cluster
+	Kubeconfig string
+}
+
+// TrivyKubernetesOption sets a field of TrivyKubernetesConfig
+type TrivyKubernetesOption func(*TrivyKubernetesConfig)
+
+// WithTrivyIncludeNamespaces limits a Kubernetes scan to the given namespaces
+func WithTrivyIncludeNamespaces(namespaces []string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.IncludeNamespaces = namespaces
+	}
+}
+
+// WithTrivyExcludeNamespaces skips the given namespaces in a Kubernetes scan,
+// e.g. kube-system
+func WithTrivyExcludeNamespaces(namespaces []string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.ExcludeNamespaces = namespaces
+	}
+}
+
+// WithTrivyIncludeKinds limits a Kubernetes scan to the given resource
+// kinds, e.g. deployment or pod
+func WithTrivyIncludeKinds(kinds []string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.IncludeKinds = kinds
+	}
+}
+
+// WithTrivyNamespace limits a Kubernetes scan to a single namespace
+func WithTrivyNamespace(namespace string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.Namespace = namespace
+	}
+}
+
+// WithTrivyTarget sets what a Kubernetes scan covers: cluster, all, workload
+// or a resource kind such as deployment
+func WithTrivyTarget(target string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.Target = target
+	}
+}
+
+// WithTrivyKubernetesKubeconfig sets the kubeconfig of the scanned cluster
+func WithTrivyKubernetesKubeconfig(path string) TrivyKubernetesOption {
+	return func(c *TrivyKubernetesConfig) {
+		c.Kubeconfig = path
+	}
+}
+
+func newTrivyKubernetesConfig(opts []TrivyKubernetesOption) *TrivyKubernetesConfig {
+	config := &TrivyKubernetesConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
@@ -0,0 +1,323 @@
+package modules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrivyScanArgs_DefaultsToVuln(t *testing.T) {
+	args, err := trivyScanArgs("image", "alpine:3.19", newTrivyConfig(nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "image", "--format", "json", "--severity", "HIGH,CRITICAL", "--scanners", "vuln", "alpine:3.19"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyScanArgs_AttestationFormats(t *testing.T) {
+	for _, format := range []string{"cosign-vuln", "github"} {
+		t.Run(format, func(t *testing.T) {
+			args, err := trivyScanArgs("image", "alpine:3.19", newTrivyConfig([]TrivyOption{
+				WithTrivyFormat(format),
+				WithTrivyOutput("/tmp/predicate.json"),
+			}))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// The report is written inside the container and exported to the host path
+			expected := []string{"trivy", "image", "--format", format, "--severity", "HIGH,CRITICAL", "--scanners", "vuln", "--output", trivyReportMount, "alpine:3.19"}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("Expected args %v, got %v", expected, args)
+			}
+		})
+	}
+}
+
+func TestTrivyScanArgs_AttestationFormatToStdout(t *testing.T) {
+	args, err := trivyScanArgs("image", "alpine:3.19", newTrivyConfig([]TrivyOption{WithTrivyFormat("cosign-vuln")}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "image", "--format", "cosign-vuln", "--severity", "HIGH,CRITICAL", "--scanners", "vuln", "alpine:3.19"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyScanArgs_FormatErrors(t *testing.T) {
+	if _, err := trivyScanArgs("image", "alpine:3.19", newTrivyConfig([]TrivyOption{WithTrivyFormat("sarif")})); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+	if _, err := trivyScanArgs("fs", ".", newTrivyConfig([]TrivyOption{WithTrivyFormat("cosign-vuln")})); err == nil {
+		t.Error("Expected an error for cosign-vuln outside image scans")
+	}
+	if _, err := trivyScanArgs("repo", "https://github.com/org/repo", newTrivyConfig([]TrivyOption{WithTrivyFormat("github")})); err != nil {
+		t.Errorf("Unexpected error for github format on repo scans: %v", err)
+	}
+}
+
+func TestTrivyScanArgs_Scanners(t *testing.T) {
+	tests := []struct {
+		name     string
+		scanners []string
+		expected string
+	}{
+		{"vuln", []string{"vuln"}, "vuln"},
+		{"secret", []string{"secret"}, "secret"},
+		{"misconfig", []string{"misconfig"}, "misconfig"},
+		{"license", []string{"license"}, "license"},
+		{"vuln and secret", []string{"vuln", "secret"}, "vuln,secret"},
+		{"all", []string{"vuln", "secret", "misconfig", "license"}, "vuln,secret,misconfig,license"},
+		{"whitespace and case", []string{" Secret", "MISCONFIG "}, "secret,misconfig"},
+		{"duplicates and blanks", []string{"secret", "", "secret"}, "secret"},
+		{"empty falls back to vuln", []string{""}, "vuln"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := trivyScanArgs("fs", ".", newTrivyConfig([]TrivyOption{WithTrivyScanners(tt.scanners)}))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			expected := []string{"trivy", "fs", "--format", "json", "--severity", "HIGH,CRITICAL", "--scanners", tt.expected, "."}
+			if !reflect.DeepEqual(args, expected) {
+				t.Errorf("Expected args %v, got %v", expected, args)
+			}
+		})
+	}
+}
+
+func TestTrivyScanArgs_MisconfigOnly(t *testing.T) {
+	args, err := trivyScanArgs("fs", ".", newTrivyConfig([]TrivyOption{WithTrivyMisconfigOnly()}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "fs", "--format", "json", "--severity", "HIGH,CRITICAL", "--scanners", "misconfig", "."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyScanArgs_UnsupportedScanner(t *testing.T) {
+	_, err := trivyScanArgs("repo", "https://github.com/org/repo", newTrivyConfig([]TrivyOption{
+		WithTrivyScanners([]string{"vuln", "rbac"}),
+	}))
+	if err == nil {
+		t.Fatal("Expected an error for an unsupported scanner")
+	}
+}
+
+func TestTrivyComplianceArgs_DockerCIS(t *testing.T) {
+	spec, ok := LookupTrivyComplianceSpec("docker-cis")
+	if !ok {
+		t.Fatalf("docker-cis should be a known spec")
+	}
+
+	args, err := trivyComplianceArgs(spec, "nginx:1.25", newTrivyComplianceConfig(nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "image", "--compliance", "docker-cis-1.6.0", "--report", "summary", "--format", "json", "nginx:1.25"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyComplianceArgs_KubernetesCIS(t *testing.T) {
+	spec, ok := LookupTrivyComplianceSpec("k8s-cis-1.23")
+	if !ok {
+		t.Fatalf("versioned spec IDs should be accepted")
+	}
+
+	args, err := trivyComplianceArgs(spec, "prod", newTrivyComplianceConfig([]TrivyComplianceOption{
+		WithTrivyComplianceReport("all"),
+		WithTrivyComplianceFormat("table"),
+		WithTrivyComplianceKubeconfig("/home/user/.kube/config"),
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "k8s", "--compliance", "k8s-cis-1.23", "--report", "all", "--format", "table",
+		"--kubeconfig", "/root/.kube/config", "--context", "prod"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyComplianceArgs_Errors(t *testing.T) {
+	docker, _ := LookupTrivyComplianceSpec("docker-cis")
+	k8s, _ := LookupTrivyComplianceSpec("k8s-nsa")
+
+	tests := map[string]struct {
+		spec   TrivyComplianceSpec
+		target string
+		opts   []TrivyComplianceOption
+	}{
+		"report":        {docker, "nginx:1.25", []TrivyComplianceOption{WithTrivyComplianceReport("detailed")}},
+		"format":        {docker, "nginx:1.25", []TrivyComplianceOption{WithTrivyComplianceFormat("sarif")}},
+		"missing image": {docker, "", nil},
+		"no kubeconfig": {k8s, "", nil},
+	}
+
+	for name, tt := range tests {
+		if _, err := trivyComplianceArgs(tt.spec, tt.target, newTrivyComplianceConfig(tt.opts)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, ok := LookupTrivyComplianceSpec("aws-cis"); ok {
+		t.Errorf("aws-cis should not be a known spec")
+	}
+}
+
+func TestTrivyScanArgs_JavaDBRepository(t *testing.T) {
+	args, err := trivyScanArgs("fs", ".", newTrivyConfig([]TrivyOption{
+		WithTrivyJavaDBRepository("mirror.example.com/aquasecurity/trivy-java-db:1"),
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"trivy", "fs", "--format", "json", "--severity", "HIGH,CRITICAL", "--scanners", "vuln",
+		"--java-db-repository", "mirror.example.com/aquasecurity/trivy-java-db:1", "."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyCacheMount(t *testing.T) {
+	key, path := trivyCacheMount()
+	if key != "ship-trivy-cache" {
+		t.Errorf("Expected a stable cache volume key, got %q", key)
+	}
+	if path != "/root/.cache/trivy" {
+		t.Errorf("Expected trivy's default cache dir, got %q", path)
+	}
+}
+
+func TestTrivyJavaDBError(t *testing.T) {
+	stderr := "FATAL	Fatal error	run error: java DB error: failed to download Java DB: OCI repository error: GET https://ghcr.io/v2/: dial tcp: i/o timeout"
+
+	err := trivyJavaDBError(stderr)
+	if err == nil {
+		t.Fatalf("Expected a Java DB error")
+	}
+	for _, want := range []string{"--java-db-repository", "HTTPS_PROXY", "i/o timeout"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err.Error())
+		}
+	}
+
+	if err := trivyJavaDBError("FATAL	image scan error: unable to find the specified image"); err != nil {
+		t.Errorf("Expected no Java DB error, got %v", err)
+	}
+	if err := trivyJavaDBError(""); err != nil {
+		t.Errorf("Expected no Java DB error for empty stderr, got %v", err)
+	}
+}
+
+func TestTrivyKubernetesArgs(t *testing.T) {
+	if args := trivyKubernetesArgs(newTrivyKubernetesConfig(nil)); len(args) != 0 {
+		t.Errorf("Expected no filter args by default, got %v", args)
+	}
+
+	args := trivyKubernetesArgs(newTrivyKubernetesConfig([]TrivyKubernetesOption{
+		WithTrivyIncludeNamespaces([]string{"payments", "orders"}),
+		WithTrivyExcludeNamespaces([]string{"kube-system"}),
+		WithTrivyIncludeKinds([]string{"deployment", "pod"}),
+		WithTrivyKubernetesKubeconfig("/home/user/.kube/config"),
+	}))
+	expected := []string{
+		"--include-namespaces", "payments,orders",
+		"--exclude-namespaces", "kube-system",
+		"--include-kinds", "deployment,pod",
+		"--kubeconfig", trivyKubeconfigMount,
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyKubernetesScanArgs(t *testing.T) {
+	if _, err := trivyKubernetesScanArgs("", "", "", "", false, newTrivyKubernetesConfig(nil)); err == nil {
+		t.Error("Expected an error without a kubeconfig")
+	}
+
+	config := newTrivyKubernetesConfig([]TrivyKubernetesOption{
+		WithTrivyIncludeNamespaces([]string{"payments"}),
+		WithTrivyKubernetesKubeconfig("/home/user/.kube/config"),
+	})
+	args, err := trivyKubernetesScanArgs("prod", "CRITICAL", "json", "", false, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"trivy", "k8s", "--severity", "CRITICAL", "--format", "json",
+		"--include-namespaces", "payments",
+		"--kubeconfig", trivyKubeconfigMount,
+		"prod",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyKubernetesScanArgs_NamespaceAndTarget(t *testing.T) {
+	config := newTrivyKubernetesConfig([]TrivyKubernetesOption{
+		WithTrivyNamespace("payments"),
+		WithTrivyTarget("deployment"),
+		WithTrivyKubernetesKubeconfig("/home/user/.kube/config"),
+	})
+	args, err := trivyKubernetesScanArgs("", "", "", "", false, config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{
+		"trivy", "k8s",
+		"--include-namespaces", "payments",
+		"--include-kinds", "deployment",
+		"--kubeconfig", trivyKubeconfigMount,
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTrivyKubernetesScanArgs_ScopeErrors(t *testing.T) {
+	tests := map[string][]TrivyKubernetesOption{
+		"namespace with include": {WithTrivyNamespace("payments"), WithTrivyIncludeNamespaces([]string{"orders"})},
+		"namespace with exclude": {WithTrivyNamespace("payments"), WithTrivyExcludeNamespaces([]string{"kube-system"})},
+		"target with kinds":      {WithTrivyTarget("pod"), WithTrivyIncludeKinds([]string{"deployment"})},
+		"unknown target":         {WithTrivyTarget("everything")},
+	}
+	for name, opts := range tests {
+		config := newTrivyKubernetesConfig(append(opts, WithTrivyKubernetesKubeconfig("/home/user/.kube/config")))
+		if _, err := trivyKubernetesScanArgs("", "", "", "", false, config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTrivyTargetKinds(t *testing.T) {
+	for _, target := range []string{"", "cluster", "all"} {
+		if kinds, err := trivyTargetKinds(target); err != nil || kinds != nil {
+			t.Errorf("Expected %q to scan every kind, got %v, %v", target, kinds, err)
+		}
+	}
+	if kinds, _ := trivyTargetKinds("workload"); !reflect.DeepEqual(kinds, trivyWorkloadKinds) {
+		t.Errorf("Expected workload kinds, got %v", kinds)
+	}
+	if kinds, _ := trivyTargetKinds("Node"); !reflect.DeepEqual(kinds, []string{"node"}) {
+		t.Errorf("Expected node kind, got %v", kinds)
+	}
+}
@@ -2,8 +2,10 @@ package modules
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -25,9 +27,8 @@ func NewTruffleHogModule(client *dagger.Client) *TruffleHogModule {
 
 // ScanDirectory scans a directory for secrets using TruffleHog
 func (m *TruffleHogModule) ScanDirectory(ctx context.Context, dir string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(dir)).
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, dir)).
 		WithWorkdir("/workspace").
 		WithExec([]string{"trufflehog", "filesystem", ".", "--json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
@@ -48,8 +49,7 @@ func (m *TruffleHogModule) ScanDirectory(ctx context.Context, dir string) (strin
 
 // ScanGitRepo scans a Git repository for secrets
 func (m *TruffleHogModule) ScanGitRepo(ctx context.Context, repoURL string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
 		WithExec([]string{"trufflehog", "git", repoURL, "--json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -68,8 +68,7 @@ func (m *TruffleHogModule) ScanGitRepo(ctx context.Context, repoURL string) (str
 
 // ScanGitHub scans a GitHub repository for secrets
 func (m *TruffleHogModule) ScanGitHub(ctx context.Context, repo string, token string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if token != "" {
 		container = container.WithEnvVariable("GITHUB_TOKEN", token)
@@ -95,8 +94,7 @@ func (m *TruffleHogModule) ScanGitHub(ctx context.Context, repo string, token st
 
 // ScanGitHubOrg scans an entire GitHub organization for secrets
 func (m *TruffleHogModule) ScanGitHubOrg(ctx context.Context, org string, token string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if token != "" {
 		container = container.WithEnvVariable("GITHUB_TOKEN", token)
@@ -120,10 +118,44 @@ func (m *TruffleHogModule) ScanGitHubOrg(ctx context.Context, org string, token
 	return output, nil
 }
 
+// ScanGitLab scans a GitLab repository for secrets. TruffleHog's gitlab source
+// requires a token, which is passed as the GITLAB_TOKEN secret rather than on
+// the command line.
+func (m *TruffleHogModule) ScanGitLab(ctx context.Context, repo string, token string) (string, error) {
+	if token == "" {
+		token = os.Getenv("GITLAB_TOKEN")
+	}
+	if token == "" {
+		return "", fmt.Errorf("gitlab scans require a token (set GITLAB_TOKEN or pass one explicitly)")
+	}
+
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
+		WithSecretVariable("GITLAB_TOKEN", m.client.SetSecret(secretName("gitlab_token", token), token)).
+		WithExec(trufflehogGitLabArgs(repo), dagger.ContainerWithExecOpts{
+			Expect: "ANY",
+		})
+
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		stderr, _ := container.Stderr(ctx)
+		if stderr != "" {
+			return stderr, nil
+		}
+		return "", fmt.Errorf("failed to scan gitlab repo: %w", err)
+	}
+
+	return output, nil
+}
+
+// trufflehogGitLabArgs builds the gitlab source command; the token is read
+// from GITLAB_TOKEN
+func trufflehogGitLabArgs(repo string) []string {
+	return []string{"trufflehog", "gitlab", "--repo", repo, "--json"}
+}
+
 // ScanDockerImage scans a Docker image for secrets
 func (m *TruffleHogModule) ScanDockerImage(ctx context.Context, imageName string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
 		WithExec([]string{"trufflehog", "docker", "--image", imageName, "--json"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -142,8 +174,7 @@ func (m *TruffleHogModule) ScanDockerImage(ctx context.Context, imageName string
 
 // ScanS3 scans an S3 bucket for secrets
 func (m *TruffleHogModule) ScanS3(ctx context.Context, bucket string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
 		WithEnvVariable("AWS_ACCESS_KEY_ID", os.Getenv("AWS_ACCESS_KEY_ID")).
 		WithEnvVariable("AWS_SECRET_ACCESS_KEY", os.Getenv("AWS_SECRET_ACCESS_KEY")).
 		WithEnvVariable("AWS_REGION", os.Getenv("AWS_REGION")).
@@ -178,11 +209,11 @@ func (m *TruffleHogModule) ScanWithVerification(ctx context.Context, target stri
 		args = []string{"trufflehog", "filesystem", target, "--json", "--verify"}
 	}
 
-	container := m.client.Container().From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if targetType == "filesystem" {
 		container = container.
-			WithDirectory("/workspace", m.client.Host().Directory(target)).
+			WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		args[2] = "."
 	}
@@ -207,123 +238,160 @@ func (m *TruffleHogModule) ScanWithVerification(ctx context.Context, target stri
 	return output, nil
 }
 
-// ScanGitAdvanced scans git repository with advanced filtering options
-func (m *TruffleHogModule) ScanGitAdvanced(ctx context.Context, repoURL string, branch string, sinceDate string, untilDate string, onlyVerified bool, outputFormat string, excludePaths []string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
-
-	args := []string{"trufflehog", "git", repoURL}
-	if branch != "" {
-		args = append(args, "--branch", branch)
-	}
-	if sinceDate != "" {
-		args = append(args, "--since", sinceDate)
-	}
-	if untilDate != "" {
-		args = append(args, "--until", untilDate)
-	}
-	if onlyVerified {
-		args = append(args, "--only-verified")
-	}
-	if outputFormat != "" {
-		args = append(args, "--format", outputFormat)
+// ScanGitAdvanced scans git repository with advanced filtering options.
+// sinceCommit limits the scan to commits after it.
+func (m *TruffleHogModule) ScanGitAdvanced(ctx context.Context, repoURL string, branch string, sinceCommit string, onlyVerified bool, outputFormat string, excludePaths []string) (string, error) {
+	args, err := trufflehogGitArgs(repoURL, branch, sinceCommit, onlyVerified, excludePaths, outputFormat)
+	if err != nil {
+		return "", err
 	}
-	for _, path := range excludePaths {
-		args = append(args, "--exclude", path)
+
+	container := withTruffleHogExcludes(toolContainer(m.client, "trufflesecurity/trufflehog:latest"), excludePaths)
+	return runTruffleHogScan(ctx, container, args, outputFormat)
+}
+
+// ScanFilesystemAdvanced scans filesystem with advanced options and exclusions
+func (m *TruffleHogModule) ScanFilesystemAdvanced(ctx context.Context, path string, onlyVerified bool, excludePaths []string, outputFormat string) (string, error) {
+	args, err := trufflehogFilesystemArgs(onlyVerified, excludePaths, outputFormat)
+	if err != nil {
+		return "", err
 	}
 
-	container = container.WithExec(args, dagger.ContainerWithExecOpts{
-		Expect: "ANY",
-	})
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, path)).
+		WithWorkdir("/workspace")
+	container = withTruffleHogExcludes(container, excludePaths)
+	return runTruffleHogScan(ctx, container, args, outputFormat)
+}
 
-	output, err := container.Stdout(ctx)
+// ScanDockerAdvanced scans Docker image with advanced verification options
+func (m *TruffleHogModule) ScanDockerAdvanced(ctx context.Context, image string, onlyVerified bool, outputFormat string) (string, error) {
+	args, err := trufflehogDockerArgs(image, onlyVerified, outputFormat)
 	if err != nil {
-		stderr, _ := container.Stderr(ctx)
-		if stderr != "" {
-			return stderr, nil
-		}
-		return output, nil
+		return "", err
 	}
 
-	return output, nil
+	return runTruffleHogScan(ctx, toolContainer(m.client, "trufflesecurity/trufflehog:latest"), args, outputFormat)
 }
 
-// ScanFilesystemAdvanced scans filesystem with advanced options and exclusions
-func (m *TruffleHogModule) ScanFilesystemAdvanced(ctx context.Context, path string, onlyVerified bool, excludePaths []string, outputFormat string, maxDepth string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(path)).
-		WithWorkdir("/workspace")
+// trufflehogExcludeFile is where exclude patterns are written; TruffleHog
+// reads the regular expressions to skip from a file, one per line
+const trufflehogExcludeFile = "/tmp/trufflehog-exclude-paths.txt"
+
+// trufflehogFormatArgs maps an output format onto TruffleHog's flags.
+// TruffleHog has no --format: json and jsonl both use --json, which writes
+// one JSON object per line, and json output is turned into an array
+// afterwards. "" and plain keep TruffleHog's default output.
+func trufflehogFormatArgs(format string) ([]string, error) {
+	switch format {
+	case "", "plain":
+		return nil, nil
+	case "json", "jsonl":
+		return []string{"--json"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported trufflehog output format %q: must be json, jsonl or plain", format)
+	}
+}
 
-	args := []string{"trufflehog", "filesystem", "."}
+// trufflehogCommonArgs appends the flags shared by the advanced scans
+func trufflehogCommonArgs(args []string, onlyVerified bool, excludePaths []string, format string) ([]string, error) {
+	formatArgs, err := trufflehogFormatArgs(format)
+	if err != nil {
+		return nil, err
+	}
 	if onlyVerified {
 		args = append(args, "--only-verified")
 	}
-	if outputFormat != "" {
-		args = append(args, "--format", outputFormat)
+	if len(excludePaths) > 0 {
+		args = append(args, "--exclude-paths", trufflehogExcludeFile)
+	}
+	return append(args, formatArgs...), nil
+}
+
+// trufflehogFilesystemArgs builds the filesystem scan of /workspace
+func trufflehogFilesystemArgs(onlyVerified bool, excludePaths []string, format string) ([]string, error) {
+	return trufflehogCommonArgs([]string{"trufflehog", "filesystem", "."}, onlyVerified, excludePaths, format)
+}
+
+// trufflehogGitArgs builds the git scan of a repository
+func trufflehogGitArgs(repoURL, branch, sinceCommit string, onlyVerified bool, excludePaths []string, format string) ([]string, error) {
+	args := []string{"trufflehog", "git", repoURL}
+	if branch != "" {
+		args = append(args, "--branch", branch)
 	}
-	if maxDepth != "" {
-		args = append(args, "--max-depth", maxDepth)
+	if sinceCommit != "" {
+		args = append(args, "--since-commit", sinceCommit)
 	}
-	for _, excludePath := range excludePaths {
-		args = append(args, "--exclude", excludePath)
+	return trufflehogCommonArgs(args, onlyVerified, excludePaths, format)
+}
+
+// trufflehogDockerArgs builds the docker scan of an image
+func trufflehogDockerArgs(image string, onlyVerified bool, format string) ([]string, error) {
+	return trufflehogCommonArgs([]string{"trufflehog", "docker", "--image", image}, onlyVerified, nil, format)
+}
+
+// withTruffleHogExcludes writes the exclude patterns to trufflehogExcludeFile
+func withTruffleHogExcludes(container *dagger.Container, excludePaths []string) *dagger.Container {
+	if len(excludePaths) == 0 {
+		return container
 	}
+	return container.WithNewFile(trufflehogExcludeFile, strings.Join(excludePaths, "\n")+"\n")
+}
 
+// runTruffleHogScan runs a scan and formats its output. TruffleHog exits
+// non-zero only when the scan itself fails, such as on a usage error, which
+// is reported as an error rather than as the scan's output.
+func runTruffleHogScan(ctx context.Context, container *dagger.Container, args []string, format string) (string, error) {
 	container = container.WithExec(args, dagger.ContainerWithExecOpts{
 		Expect: "ANY",
 	})
 
-	output, err := container.Stdout(ctx)
+	exitCode, err := container.ExitCode(ctx)
 	if err != nil {
+		return "", fmt.Errorf("failed to run trufflehog: %w", err)
+	}
+	if exitCode != 0 {
 		stderr, _ := container.Stderr(ctx)
-		if stderr != "" {
-			return stderr, nil
-		}
-		return output, nil
+		return "", fmt.Errorf("trufflehog exited with code %d: %s", exitCode, strings.TrimSpace(stderr))
 	}
 
+	output, err := container.Stdout(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read trufflehog output: %w", err)
+	}
+	if format == "json" {
+		return trufflehogJSONArray(output)
+	}
 	return output, nil
 }
 
-// ScanDockerAdvanced scans Docker image with advanced verification options
-func (m *TruffleHogModule) ScanDockerAdvanced(ctx context.Context, image string, onlyVerified bool, outputFormat string, layers string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
-
-	args := []string{"trufflehog", "docker", image}
-	if onlyVerified {
-		args = append(args, "--only-verified")
-	}
-	if outputFormat != "" {
-		args = append(args, "--format", outputFormat)
-	}
-	if layers != "" {
-		args = append(args, "--layers", layers)
+// trufflehogJSONArray turns TruffleHog's JSON lines into a JSON array
+func trufflehogJSONArray(output string) (string, error) {
+	results := []json.RawMessage{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !json.Valid([]byte(line)) {
+			return "", fmt.Errorf("failed to parse trufflehog output line %q", line)
+		}
+		results = append(results, json.RawMessage(line))
 	}
 
-	container = container.WithExec(args, dagger.ContainerWithExecOpts{
-		Expect: "ANY",
-	})
-
-	output, err := container.Stdout(ctx)
+	data, err := json.MarshalIndent(results, "", "  ")
 	if err != nil {
-		stderr, _ := container.Stderr(ctx)
-		if stderr != "" {
-			return stderr, nil
-		}
-		return output, nil
+		return "", fmt.Errorf("failed to encode trufflehog results: %w", err)
 	}
-
-	return output, nil
+	return string(data), nil
 }
 
 // ComprehensiveSecretDetection performs comprehensive secret detection with advanced filtering
 func (m *TruffleHogModule) ComprehensiveSecretDetection(ctx context.Context, target string, sourceType string, outputFormat string, outputFile string, onlyVerified bool, includeDetectors bool, confidenceLevel string, excludePaths []string, includePaths []string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if sourceType == "filesystem" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
@@ -332,9 +400,11 @@ func (m *TruffleHogModule) ComprehensiveSecretDetection(ctx context.Context, tar
 	if onlyVerified {
 		args = append(args, "--only-verified")
 	}
-	if outputFormat != "" {
-		args = append(args, "--format", outputFormat)
+	formatArgs, err := trufflehogFormatArgs(outputFormat)
+	if err != nil {
+		return "", err
 	}
+	args = append(args, formatArgs...)
 	if outputFile != "" {
 		args = append(args, "--output", outputFile)
 	}
@@ -369,8 +439,7 @@ func (m *TruffleHogModule) ComprehensiveSecretDetection(ctx context.Context, tar
 
 // CloudStorageScanning scans cloud storage for secrets
 func (m *TruffleHogModule) CloudStorageScanning(ctx context.Context, cloudProvider string, resourceIdentifier string, credentialsProfile string, region string, recursive bool, filePatterns string, onlyVerified bool, maxFileSize string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	var args []string
 	switch cloudProvider {
@@ -430,19 +499,18 @@ func (m *TruffleHogModule) CloudStorageScanning(ctx context.Context, cloudProvid
 
 // CustomDetectorManagement manages and uses custom secret detectors
 func (m *TruffleHogModule) CustomDetectorManagement(ctx context.Context, action string, detectorConfig string, target string, detectorPattern string, includeBuiltin bool) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	var args []string
 	switch action {
 	case "list":
 		args = []string{"trufflehog", "--list-detectors"}
 	case "validate":
-		container = container.WithFile("/config.yaml", m.client.Host().File(detectorConfig))
+		container = container.WithFile("/config.yaml", hostFile(m.client, detectorConfig))
 		args = []string{"trufflehog", "--validate-config", "/config.yaml"}
 	case "scan-with-custom":
-		container = container.WithFile("/config.yaml", m.client.Host().File(detectorConfig)).
-			WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithFile("/config.yaml", hostFile(m.client, detectorConfig)).
+			WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		args = []string{"trufflehog", "filesystem", ".", "--config", "/config.yaml"}
 		if includeBuiltin {
@@ -472,8 +540,7 @@ func (m *TruffleHogModule) CustomDetectorManagement(ctx context.Context, action
 
 // EnterpriseGitScanning performs enterprise-grade git repository scanning
 func (m *TruffleHogModule) EnterpriseGitScanning(ctx context.Context, gitSource string, repository string, authentication string, scanMode string, commitRange string, branches string, includeForks bool, includeIssues bool, includePullRequests bool, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	var args []string
 	switch gitSource {
@@ -528,9 +595,8 @@ func (m *TruffleHogModule) EnterpriseGitScanning(ctx context.Context, gitSource
 
 // CICDPipelineIntegration performs optimized secret scanning for CI/CD pipelines
 func (m *TruffleHogModule) CICDPipelineIntegration(ctx context.Context, scanTarget string, scanType string, baselineFile string, outputFormat string, outputFile string, failOnVerified bool, failOnUnverified bool, quietMode bool, timeout string) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
-		WithDirectory("/workspace", m.client.Host().Directory(scanTarget)).
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
+		WithDirectory("/workspace", hostDirectory(m.client, scanTarget)).
 		WithWorkdir("/workspace")
 
 	args := []string{"trufflehog", "filesystem", "."}
@@ -550,7 +616,7 @@ func (m *TruffleHogModule) CICDPipelineIntegration(ctx context.Context, scanTarg
 	}
 
 	if baselineFile != "" {
-		container = container.WithFile("/baseline.json", m.client.Host().File(baselineFile))
+		container = container.WithFile("/baseline.json", hostFile(m.client, baselineFile))
 		args = append(args, "--baseline", "/baseline.json")
 	}
 	if outputFormat != "" {
@@ -584,11 +650,10 @@ func (m *TruffleHogModule) CICDPipelineIntegration(ctx context.Context, scanTarg
 
 // PerformanceOptimization performs high-performance secret scanning with optimization features
 func (m *TruffleHogModule) PerformanceOptimization(ctx context.Context, target string, sourceType string, concurrency string, maxFileSize string, bufferSize string, skipBinaries bool, enableSampling bool, memoryLimit string, enableMetrics bool) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if sourceType == "filesystem" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
@@ -634,11 +699,10 @@ func (m *TruffleHogModule) PerformanceOptimization(ctx context.Context, target s
 
 // ComprehensiveReporting generates comprehensive secret scanning reports with analytics
 func (m *TruffleHogModule) ComprehensiveReporting(ctx context.Context, target string, sourceType string, reportType string, outputFormats string, outputDirectory string, includeVerificationStatus bool, includeRiskAssessment bool, baselineComparison string, includeTrends bool) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest")
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest")
 
 	if sourceType == "filesystem" {
-		container = container.WithDirectory("/workspace", m.client.Host().Directory(target)).
+		container = container.WithDirectory("/workspace", hostDirectory(m.client, target)).
 			WithWorkdir("/workspace")
 		target = "."
 	}
@@ -667,7 +731,7 @@ func (m *TruffleHogModule) ComprehensiveReporting(ctx context.Context, target st
 		args = append(args, "--include-verification")
 	}
 	if baselineComparison != "" {
-		container = container.WithFile("/baseline.json", m.client.Host().File(baselineComparison))
+		container = container.WithFile("/baseline.json", hostFile(m.client, baselineComparison))
 		args = append(args, "--baseline", "/baseline.json")
 	}
 
@@ -689,8 +753,7 @@ func (m *TruffleHogModule) ComprehensiveReporting(ctx context.Context, target st
 
 // GetVersion returns the version of TruffleHog
 func (m *TruffleHogModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("trufflesecurity/trufflehog:latest").
+	container := toolContainer(m.client, "trufflesecurity/trufflehog:latest").
 		WithExec([]string{"trufflehog", "--version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -0,0 +1,75 @@
+package modules
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTruffleHogGitLabArgs(t *testing.T) {
+	args := trufflehogGitLabArgs("https://gitlab.com/acme/api")
+
+	expected := []string{"trufflehog", "gitlab", "--repo", "https://gitlab.com/acme/api", "--json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected args %v, got %v", expected, args)
+	}
+}
+
+func TestTruffleHogScanGitLab_RequiresToken(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+
+	// The token check happens before any container is built
+	module := NewTruffleHogModule(nil)
+	if _, err := module.ScanGitLab(context.Background(), "https://gitlab.com/acme/api", ""); err == nil {
+		t.Error("Expected an error when no GitLab token is available")
+	}
+}
+
+func TestTruffleHogAdvancedArgs(t *testing.T) {
+	args, err := trufflehogFilesystemArgs(true, []string{"vendor/", `.*\.lock`}, "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"trufflehog", "filesystem", ".", "--only-verified", "--exclude-paths", trufflehogExcludeFile, "--json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected filesystem args %v, got %v", expected, args)
+	}
+
+	args, err = trufflehogGitArgs("https://github.com/acme/api", "main", "9f1c2e", false, nil, "jsonl")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = []string{"trufflehog", "git", "https://github.com/acme/api", "--branch", "main", "--since-commit", "9f1c2e", "--json"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected git args %v, got %v", expected, args)
+	}
+
+	args, err = trufflehogDockerArgs("acme/api:1.0", false, "plain")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = []string{"trufflehog", "docker", "--image", "acme/api:1.0"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected docker args %v, got %v", expected, args)
+	}
+
+	// TruffleHog has neither --format nor SARIF output
+	if _, err := trufflehogFilesystemArgs(false, nil, "sarif"); err == nil {
+		t.Error("Expected an error for the sarif format")
+	}
+}
+
+func TestTruffleHogJSONArray(t *testing.T) {
+	output, err := trufflehogJSONArray("{\"DetectorName\":\"AWS\"}\n\n{\"DetectorName\":\"Slack\"}\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "[\n  {\n    \"DetectorName\": \"AWS\"\n  },\n  {\n    \"DetectorName\": \"Slack\"\n  }\n]"
+	if output != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+
+	if output, _ := trufflehogJSONArray(""); output != "[]" {
+		t.Errorf("Expected an empty array, got %q", output)
+	}
+}
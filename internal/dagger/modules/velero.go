@@ -24,11 +24,10 @@ func NewVeleroModule(client *dagger.Client) *VeleroModule {
 
 // CreateBackup creates a backup of Kubernetes resources
 func (m *VeleroModule) CreateBackup(ctx context.Context, backupName string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -49,11 +48,10 @@ func (m *VeleroModule) CreateBackup(ctx context.Context, backupName string, kube
 
 // ListBackups lists all backups
 func (m *VeleroModule) ListBackups(ctx context.Context, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -74,11 +72,10 @@ func (m *VeleroModule) ListBackups(ctx context.Context, kubeconfig string) (stri
 
 // RestoreBackup restores from a backup
 func (m *VeleroModule) RestoreBackup(ctx context.Context, backupName string, restoreName string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{
@@ -100,8 +97,7 @@ func (m *VeleroModule) RestoreBackup(ctx context.Context, backupName string, res
 
 // GetVersion returns the version of Velero
 func (m *VeleroModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest").
+	container := toolContainer(m.client, "velero/velero:latest").
 		WithExec([]string{"velero", "version", "--client-only"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -117,11 +113,10 @@ func (m *VeleroModule) GetVersion(ctx context.Context) (string, error) {
 
 // Install installs Velero with storage provider configuration
 func (m *VeleroModule) Install(ctx context.Context, provider string, bucket string, region string, secretFile string, useNodeAgent bool, namespace string, noSecret bool, plugins string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if secretFile != "" {
-		container = container.WithFile("/secret", m.client.Host().File(secretFile))
+		container = container.WithFile("/secret", hostFile(m.client, secretFile))
 	}
 
 	args := []string{"velero", "install"}
@@ -165,11 +160,10 @@ func (m *VeleroModule) Install(ctx context.Context, provider string, bucket stri
 
 // CreateSchedule creates a backup schedule
 func (m *VeleroModule) CreateSchedule(ctx context.Context, name string, schedule string, includeNamespaces string, excludeNamespaces string, ttl string, labels string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "schedule", "create", name, "--schedule", schedule}
@@ -200,11 +194,10 @@ func (m *VeleroModule) CreateSchedule(ctx context.Context, name string, schedule
 
 // CreateBackupAdvanced creates an on-demand backup with advanced options
 func (m *VeleroModule) CreateBackupAdvanced(ctx context.Context, name string, fromSchedule string, includeNamespaces string, excludeNamespaces string, labels string, wait bool, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "backup", "create", name}
@@ -238,11 +231,10 @@ func (m *VeleroModule) CreateBackupAdvanced(ctx context.Context, name string, fr
 
 // CreateRestoreAdvanced creates a restore from backup with advanced options
 func (m *VeleroModule) CreateRestoreAdvanced(ctx context.Context, name string, fromBackup string, namespaceMappings string, includeNamespaces string, excludeNamespaces string, restorePVs bool, wait bool, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "restore", "create", name, "--from-backup", fromBackup}
@@ -276,11 +268,10 @@ func (m *VeleroModule) CreateRestoreAdvanced(ctx context.Context, name string, f
 
 // GetBackups gets list of backups
 func (m *VeleroModule) GetBackups(ctx context.Context, output string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "backup", "get"}
@@ -302,11 +293,10 @@ func (m *VeleroModule) GetBackups(ctx context.Context, output string, kubeconfig
 
 // DescribeBackup describes a specific backup
 func (m *VeleroModule) DescribeBackup(ctx context.Context, name string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"velero", "backup", "describe", name}, dagger.ContainerWithExecOpts{
@@ -323,11 +313,10 @@ func (m *VeleroModule) DescribeBackup(ctx context.Context, name string, kubeconf
 
 // GetRestores gets list of restores
 func (m *VeleroModule) GetRestores(ctx context.Context, output string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "restore", "get"}
@@ -349,11 +338,10 @@ func (m *VeleroModule) GetRestores(ctx context.Context, output string, kubeconfi
 
 // DescribeRestore describes a specific restore
 func (m *VeleroModule) DescribeRestore(ctx context.Context, name string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"velero", "restore", "describe", name}, dagger.ContainerWithExecOpts{
@@ -370,11 +358,10 @@ func (m *VeleroModule) DescribeRestore(ctx context.Context, name string, kubecon
 
 // GetSchedules gets list of backup schedules
 func (m *VeleroModule) GetSchedules(ctx context.Context, output string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "schedule", "get"}
@@ -396,11 +383,10 @@ func (m *VeleroModule) GetSchedules(ctx context.Context, output string, kubeconf
 
 // DeleteBackup deletes a backup
 func (m *VeleroModule) DeleteBackup(ctx context.Context, name string, confirm bool, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "backup", "delete", name}
@@ -422,11 +408,10 @@ func (m *VeleroModule) DeleteBackup(ctx context.Context, name string, confirm bo
 
 // DeleteSchedule deletes a backup schedule
 func (m *VeleroModule) DeleteSchedule(ctx context.Context, name string, confirm bool, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "schedule", "delete", name}
@@ -448,11 +433,10 @@ func (m *VeleroModule) DeleteSchedule(ctx context.Context, name string, confirm
 
 // CreateBackupLocation creates backup storage location
 func (m *VeleroModule) CreateBackupLocation(ctx context.Context, name string, provider string, bucket string, config string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "backup-location", "create", name, "--provider", provider, "--bucket", bucket}
@@ -474,11 +458,10 @@ func (m *VeleroModule) CreateBackupLocation(ctx context.Context, name string, pr
 
 // GetBackupLocations gets backup storage locations
 func (m *VeleroModule) GetBackupLocations(ctx context.Context, output string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	args := []string{"velero", "backup-location", "get"}
@@ -500,11 +483,10 @@ func (m *VeleroModule) GetBackupLocations(ctx context.Context, output string, ku
 
 // GetBackupLogs gets backup logs
 func (m *VeleroModule) GetBackupLogs(ctx context.Context, name string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"velero", "backup", "logs", name}, dagger.ContainerWithExecOpts{
@@ -521,11 +503,10 @@ func (m *VeleroModule) GetBackupLogs(ctx context.Context, name string, kubeconfi
 
 // GetRestoreLogs gets restore logs
 func (m *VeleroModule) GetRestoreLogs(ctx context.Context, name string, kubeconfig string) (string, error) {
-	container := m.client.Container().
-		From("velero/velero:latest")
+	container := toolContainer(m.client, "velero/velero:latest")
 
 	if kubeconfig != "" {
-		container = container.WithFile("/root/.kube/config", m.client.Host().File(kubeconfig))
+		container = container.WithFile("/root/.kube/config", hostFile(m.client, kubeconfig))
 	}
 
 	container = container.WithExec([]string{"velero", "restore", "logs", name}, dagger.ContainerWithExecOpts{
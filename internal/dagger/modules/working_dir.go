@@ -0,0 +1,51 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+
+	"dagger.io/dagger"
+)
+
+// WorkingDirEnv names the host directory relative scan paths and the default
+// workspace are resolved against, instead of the current directory. It is set
+// from ship's --working-dir flag.
+const WorkingDirEnv = "SHIP_WORKING_DIR"
+
+// HostPath resolves a relative host path against the working directory.
+// Absolute paths, and every path when no working directory is configured, are
+// returned unchanged; an empty path resolves to the working directory itself.
+func HostPath(path string) string {
+	dir := os.Getenv(WorkingDirEnv)
+	switch {
+	case dir == "":
+		if path == "" {
+			return "."
+		}
+		return path
+	case path == "" || path == ".":
+		return dir
+	case filepath.IsAbs(path):
+		return path
+	default:
+		return filepath.Join(dir, path)
+	}
+}
+
+// hostDirectory loads a host directory, resolving a relative path against the
+// working directory. Every module mounts host directories through it.
+func hostDirectory(client *dagger.Client, path string, opts ...dagger.HostDirectoryOpts) *dagger.Directory {
+	return client.Host().Directory(HostPath(path), opts...)
+}
+
+// hostFile loads a host file, resolving a relative path against the working
+// directory. Every module mounts host files through it.
+func hostFile(client *dagger.Client, path string, opts ...dagger.HostFileOpts) *dagger.File {
+	return client.Host().File(HostPath(path), opts...)
+}
+
+// workspaceDir is the host directory mounted as the workspace of tools that
+// scan the whole project
+func workspaceDir(client *dagger.Client) *dagger.Directory {
+	return hostDirectory(client, ".")
+}
@@ -0,0 +1,55 @@
+package modules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		workingDir string
+		path       string
+		want       string
+	}{
+		{"no working dir", "", "infra", "infra"},
+		{"no working dir, default workspace", "", "", "."},
+		{"workspace", "/repo", ".", "/repo"},
+		{"empty path", "/repo", "", "/repo"},
+		{"relative path", "/repo", "infra/prod", "/repo/infra/prod"},
+		{"absolute path", "/repo", "/other/infra", "/other/infra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(WorkingDirEnv, tt.workingDir)
+			assert.Equal(t, tt.want, HostPath(tt.path))
+		})
+	}
+}
+
+// TestHostMountsResolveWorkingDir guards that modules mount host paths only
+// through hostDirectory and hostFile, which honor --working-dir
+func TestHostMountsResolveWorkingDir(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || file == "working_dir.go" || file == "symlinks.go" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, call := range []string{"Host().Directory(", "Host().File("} {
+			assert.NotContains(t, string(content), call, "%s should mount host paths through hostDirectory or hostFile", file)
+		}
+	}
+}
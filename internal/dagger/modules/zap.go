@@ -3,6 +3,8 @@ package modules
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"dagger.io/dagger"
 )
@@ -13,7 +15,26 @@ type ZapModule struct {
 	name   string
 }
 
-const zapBinary = "zap.sh"
+const (
+	zapBinary = "zap.sh"
+
+	zapAuthScriptMount = "/zap/wrk/auth_hook.py"
+	// zapSessionDir holds the files of a ZAP session (.session, .data,
+	// .script, .properties and so on), which ZAP opens as a set
+	zapSessionDir = "/zap/wrk/session"
+
+	// zapPoliciesDir is where ZAP loads named scan policies from
+	zapPoliciesDir = "/home/zap/.ZAP/policies"
+)
+
+// zapThresholdLevels maps an alert threshold to the packaged-scan minimum
+// level (-l). OFF disables filtering so every result is reported.
+var zapThresholdLevels = map[string]string{
+	"OFF":    "PASS",
+	"LOW":    "INFO",
+	"MEDIUM": "WARN",
+	"HIGH":   "FAIL",
+}
 
 // NewZapModule creates a new ZAP module
 func NewZapModule(client *dagger.Client) *ZapModule {
@@ -24,15 +45,21 @@ func NewZapModule(client *dagger.Client) *ZapModule {
 }
 
 // BaselineScan performs a baseline scan
-func (m *ZapModule) BaselineScan(ctx context.Context, target string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable").
-		WithExec([]string{
-			"zap-baseline.py",
-			"-t", target,
-			"-J", "/zap/wrk/baseline-report.json",
-			"-r", "/zap/wrk/baseline-report.html",
-		}, dagger.ContainerWithExecOpts{
+func (m *ZapModule) BaselineScan(ctx context.Context, target string, opts ...ZapOption) (string, error) {
+	config := newZapConfig(opts)
+	if err := validateZapConfig(config); err != nil {
+		return "", err
+	}
+
+	args := append([]string{
+		"zap-baseline.py",
+		"-t", target,
+		"-J", "/zap/wrk/baseline-report.json",
+		"-r", "/zap/wrk/baseline-report.html",
+	}, zapExtraArgs(config)...)
+
+	container := m.withZapMounts(toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable"), config).
+		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
@@ -45,16 +72,22 @@ func (m *ZapModule) BaselineScan(ctx context.Context, target string) (string, er
 }
 
 // FullScan performs a full scan
-func (m *ZapModule) FullScan(ctx context.Context, target string, maxDuration int) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable").
-		WithExec([]string{
-			"zap-full-scan.py",
-			"-t", target,
-			"-J", "/zap/wrk/full-report.json",
-			"-r", "/zap/wrk/full-report.html",
-			"-m", fmt.Sprintf("%d", maxDuration),
-		}, dagger.ContainerWithExecOpts{
+func (m *ZapModule) FullScan(ctx context.Context, target string, maxDuration int, opts ...ZapOption) (string, error) {
+	config := newZapConfig(opts)
+	if err := validateZapConfig(config); err != nil {
+		return "", err
+	}
+
+	args := append([]string{
+		"zap-full-scan.py",
+		"-t", target,
+		"-J", "/zap/wrk/full-report.json",
+		"-r", "/zap/wrk/full-report.html",
+		"-m", fmt.Sprintf("%d", maxDuration),
+	}, zapExtraArgs(config)...)
+
+	container := m.withZapMounts(toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable"), config).
+		WithExec(args, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
 
@@ -68,9 +101,8 @@ func (m *ZapModule) FullScan(ctx context.Context, target string, maxDuration int
 
 // ApiScan performs an API scan using OpenAPI/Swagger spec
 func (m *ZapModule) ApiScan(ctx context.Context, target string, apiSpecPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable").
-		WithFile("/zap/wrk/api-spec.json", m.client.Host().File(apiSpecPath)).
+	container := toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable").
+		WithFile("/zap/wrk/api-spec.json", hostFile(m.client, apiSpecPath)).
 		WithExec([]string{
 			"zap-api-scan.py",
 			"-t", target,
@@ -92,9 +124,8 @@ func (m *ZapModule) ApiScan(ctx context.Context, target string, apiSpecPath stri
 
 // ScanWithContext performs a scan with context file
 func (m *ZapModule) ScanWithContext(ctx context.Context, target string, contextPath string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable").
-		WithFile("/zap/wrk/context.context", m.client.Host().File(contextPath)).
+	container := toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable").
+		WithFile("/zap/wrk/context.context", hostFile(m.client, contextPath)).
 		WithExec([]string{
 			"zap-baseline.py",
 			"-t", target,
@@ -115,8 +146,7 @@ func (m *ZapModule) ScanWithContext(ctx context.Context, target string, contextP
 
 // SpiderScan performs a spider crawl and scan
 func (m *ZapModule) SpiderScan(ctx context.Context, target string, maxDepth int, outputFormat string) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable")
+	container := toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable")
 
 	args := []string{"zap-baseline.py", "-t", target}
 	
@@ -151,8 +181,7 @@ func (m *ZapModule) SpiderScan(ctx context.Context, target string, maxDepth int,
 
 // GetVersion returns the version of ZAP
 func (m *ZapModule) GetVersion(ctx context.Context) (string, error) {
-	container := m.client.Container().
-		From("ghcr.io/zaproxy/zaproxy:stable").
+	container := toolContainer(m.client, "ghcr.io/zaproxy/zaproxy:stable").
 		WithExec([]string{zapBinary, "-version"}, dagger.ContainerWithExecOpts{
 			Expect: "ANY",
 		})
@@ -164,3 +193,137 @@ func (m *ZapModule) GetVersion(ctx context.Context) (string, error) {
 
 	return output, nil
 }
+
+// withZapMounts mounts authentication material into the ZAP working directory
+func (m *ZapModule) withZapMounts(container *dagger.Container, config *ZapConfig) *dagger.Container {
+	if config.AuthScript != "" {
+		container = container.WithFile(zapAuthScriptMount, hostFile(m.client, config.AuthScript))
+	}
+	if config.Session != "" {
+		container = container.WithDirectory(zapSessionDir, hostDirectory(m.client, filepath.Dir(config.Session), dagger.HostDirectoryOpts{
+			Include: []string{filepath.Base(config.Session) + "*"},
+		}))
+	}
+	if config.ScanPolicy != "" {
+		container = container.WithFile(zapScanPolicyMount(config.ScanPolicy), hostFile(m.client, config.ScanPolicy))
+	}
+	return container
+}
+
+// zapSessionPath returns where the session is opened from in the container
+func zapSessionPath(path string) string {
+	return zapSessionDir + "/" + filepath.Base(path)
+}
+
+// zapScanPolicyName returns the policy name ZAP registers for a policy file
+func zapScanPolicyName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// zapScanPolicyMount returns where a policy file is mounted in the container
+func zapScanPolicyMount(path string) string {
+	return zapPoliciesDir + "/" + zapScanPolicyName(path) + ".policy"
+}
+
+// zapThresholdLevel maps an alert threshold (OFF, LOW, MEDIUM, HIGH) to a ZAP level
+func zapThresholdLevel(threshold string) (string, error) {
+	level, ok := zapThresholdLevels[strings.ToUpper(threshold)]
+	if !ok {
+		return "", fmt.Errorf("unsupported ZAP alert threshold %q (use OFF, LOW, MEDIUM or HIGH)", threshold)
+	}
+	return level, nil
+}
+
+// zapExtraArgs returns packaged-scan arguments for the configured options.
+// Options passed to ZAP itself are combined into a single -z argument, which
+// the packaged scans split on spaces, so validateZapConfig rejects values
+// that contain whitespace.
+func zapExtraArgs(config *ZapConfig) []string {
+	var args []string
+	var zapOptions []string
+
+	if config.AuthScript != "" {
+		args = append(args, "--hook", zapAuthScriptMount)
+	}
+	if config.Session != "" {
+		zapOptions = append(zapOptions, "-session", zapSessionPath(config.Session))
+	}
+	if config.ScanPolicy != "" {
+		zapOptions = append(zapOptions, "-config", "scanner.defaultPolicy="+zapScanPolicyName(config.ScanPolicy))
+	}
+	if config.AlertThreshold != "" {
+		// Already checked by validateZapConfig
+		level, _ := zapThresholdLevel(config.AlertThreshold)
+		args = append(args, "-l", level)
+	}
+
+	if len(zapOptions) > 0 {
+		args = append(args, "-z", strings.Join(zapOptions, " "))
+	}
+
+	return args
+}
+
+func newZapConfig(opts []ZapOption) *ZapConfig {
+	config := &ZapConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// validateZapConfig rejects options ZAP would not understand
+func validateZapConfig(config *ZapConfig) error {
+	if config.AlertThreshold != "" {
+		if _, err := zapThresholdLevel(config.AlertThreshold); err != nil {
+			return err
+		}
+	}
+	if config.Session != "" && strings.ContainsAny(filepath.Base(config.Session), " \t\n") {
+		return fmt.Errorf("ZAP session file name %q must not contain whitespace", filepath.Base(config.Session))
+	}
+	if config.ScanPolicy != "" && strings.ContainsAny(zapScanPolicyName(config.ScanPolicy), " \t\n") {
+		return fmt.Errorf("ZAP scan policy file name %q must not contain whitespace", filepath.Base(config.ScanPolicy))
+	}
+	return nil
+}
+
+// ZapConfig holds the settings for a ZAP run
+type ZapConfig struct {
+	AuthScript     string
+	Session        string
+	AlertThreshold string
+	ScanPolicy     string
+}
+
+// ZapOption sets a field of ZapConfig
+type ZapOption func(*ZapConfig)
+
+// WithZapAuthScript mounts a packaged-scan hook script that performs login
+func WithZapAuthScript(path string) ZapOption {
+	return func(c *ZapConfig) {
+		c.AuthScript = path
+	}
+}
+
+// WithZapSession mounts an authenticated ZAP session, together with the other
+// files sharing its name, and opens it before scanning
+func WithZapSession(path string) ZapOption {
+	return func(c *ZapConfig) {
+		c.Session = path
+	}
+}
+
+// WithZapAlertThreshold only reports alerts at or above the threshold (OFF, LOW, MEDIUM, HIGH)
+func WithZapAlertThreshold(threshold string) ZapOption {
+	return func(c *ZapConfig) {
+		c.AlertThreshold = threshold
+	}
+}
+
+// WithZapScanPolicy mounts a ZAP .policy file and makes it the default scan policy
+func WithZapScanPolicy(path string) ZapOption {
+	return func(c *ZapConfig) {
+		c.ScanPolicy = path
+	}
+}
@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestZapExtraArgs_Unauthenticated(t *testing.T) {
+	if got := zapExtraArgs(newZapConfig(nil)); len(got) != 0 {
+		t.Errorf("Expected unauthenticated scans to add no args, got %v", got)
+	}
+}
+
+func TestZapExtraArgs_AuthScript(t *testing.T) {
+	config := newZapConfig([]ZapOption{WithZapAuthScript("./auth_hook.py")})
+
+	expected := []string{"--hook", "/zap/wrk/auth_hook.py"}
+	if got := zapExtraArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestZapExtraArgs_Session(t *testing.T) {
+	config := newZapConfig([]ZapOption{
+		WithZapAuthScript("./auth_hook.py"),
+		WithZapSession("./logged-in.session"),
+	})
+
+	expected := []string{"--hook", "/zap/wrk/auth_hook.py", "-z", "-session /zap/wrk/session/logged-in.session"}
+	if got := zapExtraArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+}
+
+func TestZapThresholdLevel(t *testing.T) {
+	tests := map[string]string{
+		"OFF":    "PASS",
+		"LOW":    "INFO",
+		"MEDIUM": "WARN",
+		"high":   "FAIL",
+	}
+
+	for threshold, expected := range tests {
+		level, err := zapThresholdLevel(threshold)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", threshold, err)
+		}
+		if level != expected {
+			t.Errorf("Threshold %q: expected %q, got %q", threshold, expected, level)
+		}
+	}
+
+	if err := validateZapConfig(newZapConfig([]ZapOption{WithZapAlertThreshold("CRITICAL")})); err == nil {
+		t.Error("Expected error for unsupported threshold")
+	}
+}
+
+func TestZapExtraArgs_ThresholdAndPolicy(t *testing.T) {
+	config := newZapConfig([]ZapOption{
+		WithZapSession("./logged-in.session"),
+		WithZapScanPolicy("/policies/api-strict.policy"),
+		WithZapAlertThreshold("MEDIUM"),
+	})
+
+	expected := []string{"-l", "WARN", "-z", "-session /zap/wrk/session/logged-in.session -config scanner.defaultPolicy=api-strict"}
+	if got := zapExtraArgs(config); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected args %v, got %v", expected, got)
+	}
+
+	if mount := zapScanPolicyMount("/policies/api-strict.policy"); mount != "/home/zap/.ZAP/policies/api-strict.policy" {
+		t.Errorf("Unexpected policy mount path %q", mount)
+	}
+}
+
+func TestValidateZapConfig_RejectsWhitespaceInZOptions(t *testing.T) {
+	if err := validateZapConfig(newZapConfig([]ZapOption{WithZapSession("./sessions/logged-in.session")})); err != nil {
+		t.Errorf("Unexpected error for session without whitespace: %v", err)
+	}
+	if err := validateZapConfig(newZapConfig([]ZapOption{WithZapSession("./my sessions/logged-in.session")})); err != nil {
+		t.Errorf("Expected whitespace in the session directory to be allowed, got %v", err)
+	}
+	if err := validateZapConfig(newZapConfig([]ZapOption{WithZapSession("./logged in.session")})); err == nil {
+		t.Error("Expected error for session file name with whitespace")
+	}
+	if err := validateZapConfig(newZapConfig([]ZapOption{WithZapScanPolicy("./api strict.policy")})); err == nil {
+		t.Error("Expected error for scan policy file name with whitespace")
+	}
+}
@@ -0,0 +1,113 @@
+package dagger
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"dagger.io/dagger/engineconn"
+)
+
+// RunnerHostEnv points Dagger at an engine it did not provision
+const RunnerHostEnv = "_EXPERIMENTAL_DAGGER_RUNNER_HOST"
+
+// DefaultDedicatedEngineName is the container name of the engine started by
+// ship engine start
+const DefaultDedicatedEngineName = "ship-dagger-engine"
+
+// memoryLimitPattern matches Docker memory sizes such as 512m or 4g
+var memoryLimitPattern = regexp.MustCompile(`^[0-9]+[bkmgBKMG]?$`)
+
+// ResourceLimits caps the CPU and memory available to tool containers.
+// Dagger has no per-container resource controls and the engine it provisions
+// itself is shared by every Dagger client on the host, so limits are set when
+// a dedicated engine container is created and tools reach it through
+// _EXPERIMENTAL_DAGGER_RUNNER_HOST. They are shared by all tools using that
+// engine, not applied to each one.
+type ResourceLimits struct {
+	// CPUs is a Docker --cpus value, e.g. "2" or "1.5"
+	CPUs string
+	// Memory is a Docker --memory value, e.g. "4g" or "512m"
+	Memory string
+}
+
+// IsZero reports whether no limits are set
+func (l ResourceLimits) IsZero() bool {
+	return l.CPUs == "" && l.Memory == ""
+}
+
+// Validate checks the limits are in a format Docker accepts
+func (l ResourceLimits) Validate() error {
+	if l.CPUs != "" {
+		cpus, err := strconv.ParseFloat(l.CPUs, 64)
+		if err != nil || cpus <= 0 {
+			return fmt.Errorf("invalid CPU limit %q: expected a positive number such as 2 or 1.5", l.CPUs)
+		}
+	}
+	if l.Memory != "" && !memoryLimitPattern.MatchString(l.Memory) {
+		return fmt.Errorf("invalid memory limit %q: expected a size such as 512m or 4g", l.Memory)
+	}
+	return nil
+}
+
+// engineImage is the engine image matching this SDK version
+func engineImage() string {
+	return "registry.dagger.io/engine:v" + engineconn.CLIVersion
+}
+
+// DedicatedEngineRunnerHost is the _EXPERIMENTAL_DAGGER_RUNNER_HOST value
+// that selects the dedicated engine container name
+func DedicatedEngineRunnerHost(name string) string {
+	return "docker-container://" + name
+}
+
+// dedicatedEngineRunArgs builds the docker run command that creates a
+// dedicated engine container with the limits applied
+func dedicatedEngineRunArgs(limits ResourceLimits, name string) []string {
+	args := []string{"run", "-d", "--privileged", "--name", name,
+		// A named volume keeps the engine's cache across restarts
+		"-v", name + "-state:/var/lib/dagger"}
+	if limits.CPUs != "" {
+		args = append(args, "--cpus", limits.CPUs)
+	}
+	if limits.Memory != "" {
+		args = append(args, "--memory", limits.Memory)
+	}
+	return append(args, engineImage())
+}
+
+// StartDedicatedEngine creates a Dagger engine container named name with the
+// given limits and returns the _EXPERIMENTAL_DAGGER_RUNNER_HOST value that
+// selects it. The engine Dagger provisions for itself is never modified, and
+// Docker refuses a second container with the same name, so concurrent starts
+// cannot overwrite each other's limits.
+func StartDedicatedEngine(ctx context.Context, limits ResourceLimits, name string) (string, error) {
+	if err := limits.Validate(); err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = DefaultDedicatedEngineName
+	}
+
+	if out, err := exec.CommandContext(ctx, "docker", dedicatedEngineRunArgs(limits, name)...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to start Dagger engine container %s (stop an existing one with 'ship engine stop'): %w\n%s",
+			name, err, strings.TrimSpace(string(out)))
+	}
+	return DedicatedEngineRunnerHost(name), nil
+}
+
+// StopDedicatedEngine removes a dedicated engine container created by
+// StartDedicatedEngine; its cache volume is kept
+func StopDedicatedEngine(ctx context.Context, name string) error {
+	if name == "" {
+		name = DefaultDedicatedEngineName
+	}
+
+	if out, err := exec.CommandContext(ctx, "docker", "rm", "-f", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop Dagger engine container %s: %w\n%s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
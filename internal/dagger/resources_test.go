@@ -0,0 +1,44 @@
+package dagger
+
+import (
+	"testing"
+
+	"dagger.io/dagger/engineconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedicatedEngineRunArgs(t *testing.T) {
+	image := "registry.dagger.io/engine:v" + engineconn.CLIVersion
+
+	args := dedicatedEngineRunArgs(ResourceLimits{CPUs: "1.5", Memory: "2g"}, "ship-dagger-engine")
+	assert.Equal(t, []string{
+		"run", "-d", "--privileged", "--name", "ship-dagger-engine",
+		"-v", "ship-dagger-engine-state:/var/lib/dagger",
+		"--cpus", "1.5",
+		"--memory", "2g",
+		image,
+	}, args)
+
+	args = dedicatedEngineRunArgs(ResourceLimits{CPUs: "2"}, "scans")
+	assert.Equal(t, []string{
+		"run", "-d", "--privileged", "--name", "scans",
+		"-v", "scans-state:/var/lib/dagger",
+		"--cpus", "2",
+		image,
+	}, args)
+}
+
+func TestResourceLimitsValidate(t *testing.T) {
+	assert.NoError(t, ResourceLimits{}.Validate())
+	assert.NoError(t, ResourceLimits{CPUs: "0.5", Memory: "512m"}.Validate())
+	assert.NoError(t, ResourceLimits{Memory: "4G"}.Validate())
+
+	assert.Error(t, ResourceLimits{CPUs: "0"}.Validate())
+	assert.Error(t, ResourceLimits{CPUs: "two"}.Validate())
+	assert.Error(t, ResourceLimits{Memory: "4gb"}.Validate())
+	assert.Error(t, ResourceLimits{Memory: "-1"}.Validate())
+}
+
+func TestDedicatedEngineRunnerHost(t *testing.T) {
+	assert.Equal(t, "docker-container://ship-dagger-engine", DedicatedEngineRunnerHost(DefaultDedicatedEngineName))
+}
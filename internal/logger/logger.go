@@ -1,36 +1,58 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 )
 
-func Init(level, file string) error {
-	var logLevel slog.Level
-	switch level {
+// LevelEnv sets the log level when --log-level is not given
+const LevelEnv = "SHIP_LOG_LEVEL"
+
+// Levels are the accepted log levels, from most to least verbose
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// ParseLevel maps a log level name to its slog level
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
 	case "debug":
-		logLevel = slog.LevelDebug
-	case "info":
-		logLevel = slog.LevelInfo
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
 	case "warn":
-		logLevel = slog.LevelWarn
+		return slog.LevelWarn, nil
 	case "error":
-		logLevel = slog.LevelError
+		return slog.LevelError, nil
 	default:
-		logLevel = slog.LevelInfo
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q: must be one of %s", level, strings.Join(Levels, ", "))
+	}
+}
+
+// New returns a logger writing diagnostics at level and above to w as
+// "time=... level=INFO msg=... key=value" lines, with secrets redacted
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(redactingHandler{slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+// Init installs the default logger. Diagnostics go to stderr, or as JSON to
+// file when one is given.
+func Init(level, file string) error {
+	logLevel, err := ParseLevel(level)
+	if err != nil {
+		return err
 	}
 
-	var handler slog.Handler
+	logger := New(os.Stderr, logLevel)
 	if file != "" {
 		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
 			return err
 		}
-		handler = slog.NewJSONHandler(f, &slog.HandlerOptions{Level: logLevel})
-	} else {
-		handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})
+		logger = slog.New(redactingHandler{slog.NewJSONHandler(f, &slog.HandlerOptions{Level: logLevel})})
 	}
 
-	slog.SetDefault(slog.New(handler))
+	slog.SetDefault(logger)
 	return nil
 }
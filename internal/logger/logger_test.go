@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelGatesMessages(t *testing.T) {
+	tests := []struct {
+		level string
+		want  []string
+		skip  []string
+	}{
+		{"debug", []string{"level=DEBUG", "level=INFO", "level=WARN", "level=ERROR"}, nil},
+		{"info", []string{"level=INFO", "level=WARN", "level=ERROR"}, []string{"level=DEBUG"}},
+		{"warn", []string{"level=WARN", "level=ERROR"}, []string{"level=DEBUG", "level=INFO"}},
+		{"error", []string{"level=ERROR"}, []string{"level=DEBUG", "level=INFO", "level=WARN"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLevel(tt.level)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			logger := New(&out, level)
+			logger.Debug("debug message")
+			logger.Info("info message")
+			logger.Warn("warn message")
+			logger.Error("error message")
+
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, skip := range tt.skip {
+				assert.NotContains(t, out.String(), skip)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	level, err = ParseLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelInfo, level)
+
+	_, err = ParseLevel("verbose")
+	assert.EqualError(t, err, `invalid log level "verbose": must be one of debug, info, warn, error`)
+}
+
+func TestNewFormat(t *testing.T) {
+	var out bytes.Buffer
+	New(&out, slog.LevelInfo).Info("starting MCP server", "transport", "stdio")
+
+	assert.Regexp(t, `^time=\S+ level=INFO msg="starting MCP server" transport=stdio\n$`, out.String())
+}
+
+func TestRedactSecrets(t *testing.T) {
+	RedactSecrets("brv-6f1c2e9a")
+
+	var out bytes.Buffer
+	logger := New(&out, slog.LevelDebug)
+	logger.Info("connecting with key brv-6f1c2e9a", "env", "BRAVE_API_KEY=brv-6f1c2e9a")
+	logger.With("key", "brv-6f1c2e9a").Debug("MCP server running")
+	logger.Error("connect failed", "error", errors.New("unauthorized: brv-6f1c2e9a"),
+		slog.Group("server", "env", map[string]string{"BRAVE_API_KEY": "brv-6f1c2e9a"}))
+
+	assert.NotContains(t, out.String(), "brv-6f1c2e9a")
+	assert.Equal(t, 5, strings.Count(out.String(), Redacted))
+	assert.Equal(t, "ship --var KEY=***", Redact("ship --var KEY=brv-6f1c2e9a"))
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces secret values in log output
+const Redacted = "***"
+
+// secrets holds the values registered with RedactSecrets
+var secrets struct {
+	mu       sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+}
+
+// RedactSecrets registers values, such as secret MCP server variables, that
+// must never appear in diagnostics. Loggers from New and Init, and Redact,
+// replace them with ***.
+func RedactSecrets(values ...string) {
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+
+	if secrets.values == nil {
+		secrets.values = make(map[string]bool)
+	}
+	for _, value := range values {
+		if value != "" {
+			secrets.values[value] = true
+		}
+	}
+
+	var pairs []string
+	for value := range secrets.values {
+		pairs = append(pairs, value, Redacted)
+	}
+	secrets.replacer = strings.NewReplacer(pairs...)
+}
+
+// Redact replaces every registered secret value in s with ***
+func Redact(s string) string {
+	secrets.mu.RLock()
+	replacer := secrets.replacer
+	secrets.mu.RUnlock()
+
+	if replacer == nil {
+		return s
+	}
+	return replacer.Replace(s)
+}
+
+// redactingHandler redacts secret values from messages and attributes
+// before passing records on
+type redactingHandler struct {
+	slog.Handler
+}
+
+func (h redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return redactingHandler{h.Handler.WithAttrs(redacted)}
+}
+
+func (h redactingHandler) WithGroup(name string) slog.Handler {
+	return redactingHandler{h.Handler.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, attr := range group {
+			redacted[i] = redactAttr(attr)
+		}
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		// Errors and other values are logged by their string form
+		s := fmt.Sprint(value.Any())
+		if r := Redact(s); r != s {
+			return slog.String(a.Key, r)
+		}
+	}
+	return a
+}
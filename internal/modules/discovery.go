@@ -190,6 +190,10 @@ func (d *UserDirectoryDiscovery) loadModuleFromFile(yamlPath, modulePath, source
 	module.Source = source
 	module.Trusted = source == "builtin" || (source == "user" && d.config.AllowUntrusted)
 
+	if err := applySignaturePolicy(&module, yamlPath, data, d.config); err != nil {
+		return nil, err
+	}
+
 	return &module, nil
 }
 
@@ -1,10 +1,18 @@
 package modules
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"dagger.io/dagger"
+	shipdagger "github.com/cloudshipai/ship/internal/dagger"
+	daggermodules "github.com/cloudshipai/ship/internal/dagger/modules"
 )
 
 // ExecutorRegistry manages module executors
@@ -45,7 +53,10 @@ func (r *ExecutorRegistry) Execute(ctx context.Context, module *Module, command
 }
 
 // DockerExecutor executes Docker-based modules
-type DockerExecutor struct{}
+type DockerExecutor struct {
+	// Runner runs module containers; nil runs them with Dagger
+	Runner ContainerRunner
+}
 
 func (e *DockerExecutor) CanExecute(module *Module) bool {
 	return module.Spec.Type == ModuleTypeDocker && module.Spec.Docker != nil
@@ -54,12 +65,341 @@ func (e *DockerExecutor) CanExecute(module *Module) bool {
 func (e *DockerExecutor) Execute(ctx context.Context, module *Module, command string, args []string, flags map[string]interface{}) (*ExecutionResult, error) {
 	start := time.Now()
 
-	// For Docker modules, we'll delegate to the existing Ship CLI commands
-	// This is a bridge until we have full Docker execution
-	result, err := e.executeViaShipCLI(ctx, module, command, args, flags)
+	// Built-in modules delegate to the existing Ship CLI commands; other
+	// modules run their image restricted to their declared permissions
+	var result *ExecutionResult
+	var err error
+	if module.Source == "builtin" {
+		result, err = e.executeViaShipCLI(ctx, module, command, args, flags)
+	} else {
+		result, err = e.executeContainer(ctx, module, command, args, flags)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	result.Duration = time.Since(start)
-	return result, err
+	return result, nil
+}
+
+// executeContainer runs the module's image, confined by the container policy
+// of its permissions
+func (e *DockerExecutor) executeContainer(ctx context.Context, module *Module, command string, args []string, flags map[string]interface{}) (*ExecutionResult, error) {
+	policy, err := NewContainerPolicy(module.Spec.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("module '%s': %w", module.Metadata.Name, err)
+	}
+	policy.ConfineVolumes = !module.Trusted
+
+	workspace, err := moduleWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	invocation := []string{command}
+	for _, cmdSpec := range module.Spec.Commands {
+		if cmdSpec.Name == command {
+			invocation = append(invocation, moduleFlagArgs(cmdSpec.Flags, flags)...)
+		}
+	}
+	invocation = append(invocation, args...)
+
+	container, err := moduleContainer(module.Spec.Docker, policy, workspace, invocation)
+	if err != nil {
+		return nil, fmt.Errorf("module '%s': %w", module.Metadata.Name, err)
+	}
+
+	runner := e.Runner
+	if runner == nil {
+		runner = defaultContainerRunner(container)
+	}
+	return runner.Run(ctx, container)
+}
+
+// moduleWorkspace is the absolute host directory mounted as a module's
+// workspace: --working-dir when set, as for every other tool container, or
+// the current directory
+func moduleWorkspace() (string, error) {
+	workspace, err := filepath.Abs(daggermodules.HostPath("."))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workspace: %w", err)
+	}
+	return workspace, nil
+}
+
+// moduleFlagArgs maps flag values onto the container invocation in the order
+// the flags are declared. Unset flags are left out: empty strings and lists,
+// false booleans and zero integers. Booleans become --name and lists repeat
+// --name for each value.
+func moduleFlagArgs(declared []ModuleFlag, values map[string]interface{}) []string {
+	var args []string
+	for _, flag := range declared {
+		switch value := values[flag.Name].(type) {
+		case bool:
+			if value {
+				args = append(args, "--"+flag.Name)
+			}
+		case string:
+			if value != "" {
+				args = append(args, "--"+flag.Name, value)
+			}
+		case int:
+			if value != 0 {
+				args = append(args, "--"+flag.Name, fmt.Sprint(value))
+			}
+		case []string:
+			for _, item := range value {
+				args = append(args, "--"+flag.Name, item)
+			}
+		}
+	}
+	return args
+}
+
+// moduleWorkspaceMount is where the workspace is mounted in module containers
+const moduleWorkspaceMount = "/workspace"
+
+// ModuleContainer is a module's container as it is run: its image, command,
+// environment and the host directories it is given
+type ModuleContainer struct {
+	Image string
+	// Entrypoint replaces the image's entrypoint when set
+	Entrypoint []string
+	Command    []string
+	WorkingDir string
+	Env        map[string]string
+	// Network gives the container network access
+	Network bool
+	// Workspace is the host directory mounted at /workspace, empty when the
+	// module may not see it
+	Workspace string
+	// WriteWorkspace writes the container's changes to /workspace back to
+	// the host workspace
+	WriteWorkspace bool
+	Mounts         []ContainerMount
+}
+
+// ContainerMount mounts a host directory into a module container, or a
+// temporary directory when Source is empty
+type ContainerMount struct {
+	Source string
+	Target string
+}
+
+// ContainerRunner runs module containers
+type ContainerRunner interface {
+	Run(ctx context.Context, container ModuleContainer) (*ExecutionResult, error)
+}
+
+// moduleContainer builds the container of a module command. It gets network
+// access only with the network permission. The workspace and the module's
+// bind volumes are given to the container only with a
+// filesystem permission, and the workspace is written back only with
+// filesystem:write. Confined volumes must lie inside the workspace.
+func moduleContainer(spec *DockerModuleSpec, policy ContainerPolicy, workspace string, command []string) (ModuleContainer, error) {
+	container := ModuleContainer{
+		Image:      spec.Image,
+		Entrypoint: spec.Entrypoint,
+		Command:    command,
+		WorkingDir: spec.WorkingDir,
+		Env:        spec.Env,
+		Network:    policy.Network,
+	}
+	if policy.Workspace != WorkspaceNone {
+		container.Workspace = workspace
+		container.WriteWorkspace = policy.Workspace == WorkspaceReadWrite
+		if container.WorkingDir == "" {
+			container.WorkingDir = moduleWorkspaceMount
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		switch volume.Type {
+		case "tmpfs":
+			container.Mounts = append(container.Mounts, ContainerMount{Target: volume.Target})
+		case "", "bind", "volume":
+			if policy.Workspace == WorkspaceNone {
+				return ModuleContainer{}, fmt.Errorf("volume %s needs the %s or %s permission", volume.Target, PermissionFilesystemRead, PermissionFilesystemWrite)
+			}
+			source := volume.Source
+			if policy.ConfineVolumes {
+				var err error
+				if source, err = workspaceVolumeSource(source, workspace); err != nil {
+					return ModuleContainer{}, err
+				}
+			}
+			container.Mounts = append(container.Mounts, ContainerMount{Source: source, Target: volume.Target})
+		default:
+			return ModuleContainer{}, fmt.Errorf("unsupported volume type %q for %s", volume.Type, volume.Target)
+		}
+	}
+	return container, nil
+}
+
+// workspaceVolumeSource resolves a volume source against the workspace and
+// refuses sources outside it, such as the docker socket
+func workspaceVolumeSource(source string, workspace string) (string, error) {
+	path := source
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workspace, path)
+	}
+	path = filepath.Clean(path)
+	rel, err := filepath.Rel(workspace, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("volume source %s is outside the workspace", source)
+	}
+	return path, nil
+}
+
+// defaultContainerRunner picks the runner for a module container. Dagger
+// cannot run a container without network access, so containers without the
+// network permission run with docker run --network none instead.
+func defaultContainerRunner(container ModuleContainer) ContainerRunner {
+	if container.Network {
+		return DaggerRunner{}
+	}
+	return DockerRunner{}
+}
+
+// DockerRunner runs module containers with docker run, which can deny them
+// network access. Host directories are bind-mounted, read-only unless the
+// workspace may be written.
+type DockerRunner struct{}
+
+func (DockerRunner) Run(ctx context.Context, spec ModuleContainer) (*ExecutionResult, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", dockerRunArgs(spec)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		exitError, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, fmt.Errorf("failed to run docker: %w", err)
+		}
+		exitCode = exitError.ExitCode()
+	}
+
+	return &ExecutionResult{
+		ExitCode: exitCode,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+	}, nil
+}
+
+// dockerRunArgs builds the docker run command line for a module container
+func dockerRunArgs(spec ModuleContainer) []string {
+	args := []string{"run", "--rm"}
+	if !spec.Network {
+		args = append(args, "--network", "none")
+	}
+
+	mountSuffix := ":ro"
+	if spec.WriteWorkspace {
+		mountSuffix = ""
+	}
+	if spec.Workspace != "" {
+		args = append(args, "-v", spec.Workspace+":"+moduleWorkspaceMount+mountSuffix)
+	}
+	for _, mount := range spec.Mounts {
+		if mount.Source == "" {
+			args = append(args, "--tmpfs", mount.Target)
+		} else {
+			args = append(args, "-v", mount.Source+":"+mount.Target+mountSuffix)
+		}
+	}
+	if spec.WorkingDir != "" {
+		args = append(args, "-w", spec.WorkingDir)
+	}
+
+	envNames := make([]string, 0, len(spec.Env))
+	for name := range spec.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		args = append(args, "-e", name+"="+spec.Env[name])
+	}
+
+	if len(spec.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", spec.Entrypoint[0])
+	}
+	args = append(args, spec.Image)
+	if len(spec.Entrypoint) > 1 {
+		args = append(args, spec.Entrypoint[1:]...)
+	}
+	return append(args, spec.Command...)
+}
+
+// DaggerRunner runs module containers on the Dagger engine. Host directories
+// are copied into the container, so changes reach the host only through a
+// written-back workspace. Dagger cannot run a container without network
+// access, so it only runs containers granted the network permission.
+type DaggerRunner struct{}
+
+func (DaggerRunner) Run(ctx context.Context, spec ModuleContainer) (*ExecutionResult, error) {
+	engine, err := shipdagger.NewEngine(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dagger engine: %w", err)
+	}
+	defer engine.Close()
+	client := engine.GetClient()
+
+	container := client.Container().From(spec.Image)
+	if spec.Workspace != "" {
+		container = container.WithDirectory(moduleWorkspaceMount, client.Host().Directory(spec.Workspace))
+	}
+	for _, mount := range spec.Mounts {
+		if mount.Source == "" {
+			container = container.WithMountedTemp(mount.Target)
+		} else {
+			container = container.WithDirectory(mount.Target, client.Host().Directory(mount.Source))
+		}
+	}
+	if spec.WorkingDir != "" {
+		container = container.WithWorkdir(spec.WorkingDir)
+	}
+	envNames := make([]string, 0, len(spec.Env))
+	for name := range spec.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		container = container.WithEnvVariable(name, spec.Env[name])
+	}
+
+	opts := dagger.ContainerWithExecOpts{Expect: dagger.ReturnTypeAny}
+	args := spec.Command
+	if len(spec.Entrypoint) > 0 {
+		args = append(append([]string{}, spec.Entrypoint...), spec.Command...)
+	} else {
+		opts.UseEntrypoint = true
+	}
+	container = container.WithExec(args, opts)
+
+	exitCode, err := container.ExitCode(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", spec.Image, err)
+	}
+	stdout, err := container.Stdout(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output of %s: %w", spec.Image, err)
+	}
+	stderr, err := container.Stderr(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read errors of %s: %w", spec.Image, err)
+	}
+	if spec.WriteWorkspace {
+		if _, err := container.Directory(moduleWorkspaceMount).Export(ctx, spec.Workspace); err != nil {
+			return nil, fmt.Errorf("failed to write the workspace back: %w", err)
+		}
+	}
+
+	return &ExecutionResult{
+		ExitCode: exitCode,
+		Stdout:   stdout,
+		Stderr:   stderr,
+	}, nil
 }
 
 func (e *DockerExecutor) executeViaShipCLI(ctx context.Context, module *Module, command string, args []string, flags map[string]interface{}) (*ExecutionResult, error) {
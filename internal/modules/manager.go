@@ -25,6 +25,11 @@ func NewManager(config ModuleConfig) *Manager {
 	}
 }
 
+// SetContainerRunner runs the containers of Docker modules with runner
+func (m *Manager) SetContainerRunner(runner ContainerRunner) {
+	m.executor.Register(ModuleTypeDocker, &DockerExecutor{Runner: runner})
+}
+
 // LoadModules discovers and loads all available modules
 func (m *Manager) LoadModules(ctx context.Context) error {
 	modules, err := m.discovery.DiscoverAll(ctx)
@@ -63,9 +68,10 @@ func (m *Manager) ExecuteModule(ctx context.Context, moduleName, command string,
 		return nil, fmt.Errorf("command '%s' not found in module '%s'", command, moduleName)
 	}
 
-	// Security check
-	if !module.Trusted && !m.config.AllowUntrusted {
-		return nil, fmt.Errorf("module '%s' is not trusted and untrusted modules are disabled", moduleName)
+	// Security check: untrusted modules may only read the workspace unless
+	// untrusted modules are allowed
+	if err := CheckPermissions(module, m.config.AllowUntrusted); err != nil {
+		return nil, err
 	}
 
 	return m.executor.Execute(ctx, module, command, args, flags)
@@ -94,31 +100,54 @@ func (m *Manager) RegisterDynamicCommands(rootCmd *cobra.Command) error {
 // registerModuleCommands registers CLI commands for a specific module
 func (m *Manager) registerModuleCommands(rootCmd *cobra.Command, module *Module) error {
 	for _, cmdSpec := range module.Spec.Commands {
-		// Create command
-		cmd := &cobra.Command{
-			Use:   cmdSpec.Name,
-			Short: cmdSpec.Description,
-			Long:  fmt.Sprintf("%s\n\nModule: %s (%s)", cmdSpec.Description, module.Metadata.Name, module.Source),
-			RunE:  m.createCommandRunner(module, cmdSpec),
-		}
-
-		// Add flags
-		for _, flag := range cmdSpec.Flags {
-			if err := m.addFlag(cmd, flag); err != nil {
-				return fmt.Errorf("failed to add flag %s: %w", flag.Name, err)
-			}
+		cmd, err := m.newModuleCommand(module, cmdSpec)
+		if err != nil {
+			return err
+		}
+		rootCmd.AddCommand(cmd)
+	}
+
+	return nil
+}
+
+// CommandFor returns a CLI command that runs one command of a loaded module
+// with its declared flags
+func (m *Manager) CommandFor(moduleName, command string) (*cobra.Command, error) {
+	module, err := m.GetModule(moduleName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cmdSpec := range module.Spec.Commands {
+		if cmdSpec.Name == command {
+			return m.newModuleCommand(module, cmdSpec)
 		}
+	}
+	return nil, fmt.Errorf("command '%s' not found in module '%s'", command, moduleName)
+}
+
+// newModuleCommand creates the CLI command for a module command
+func (m *Manager) newModuleCommand(module *Module, cmdSpec ModuleCommand) (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:   cmdSpec.Name,
+		Short: cmdSpec.Description,
+		Long:  fmt.Sprintf("%s\n\nModule: %s (%s)", cmdSpec.Description, module.Metadata.Name, module.Source),
+		RunE:  m.createCommandRunner(module, cmdSpec),
+	}
 
-		// Add examples
-		if len(cmdSpec.Examples) > 0 {
-			cmd.Example = strings.Join(cmdSpec.Examples, "\n")
+	// Add flags
+	for _, flag := range cmdSpec.Flags {
+		if err := m.addFlag(cmd, flag); err != nil {
+			return nil, fmt.Errorf("failed to add flag %s: %w", flag.Name, err)
 		}
+	}
 
-		// Register command
-		rootCmd.AddCommand(cmd)
+	// Add examples
+	if len(cmdSpec.Examples) > 0 {
+		cmd.Example = strings.Join(cmdSpec.Examples, "\n")
 	}
 
-	return nil
+	return cmd, nil
 }
 
 // createCommandRunner creates a command runner for a module command
@@ -146,7 +175,7 @@ func (m *Manager) createCommandRunner(module *Module, cmdSpec ModuleCommand) fun
 
 		// Output result
 		if result.Stdout != "" {
-			fmt.Print(result.Stdout)
+			fmt.Fprint(cmd.OutOrStdout(), result.Stdout)
 		}
 		if result.Stderr != "" {
 			fmt.Fprint(cmd.ErrOrStderr(), result.Stderr)
@@ -171,10 +200,7 @@ func (m *Manager) addFlag(cmd *cobra.Command, flag ModuleFlag) error {
 				defaultVal = val
 			}
 		}
-		cmd.Flags().String(flag.Name, defaultVal, flag.Description)
-		if flag.Short != "" {
-			cmd.Flags().StringP(flag.Name, flag.Short, defaultVal, flag.Description)
-		}
+		cmd.Flags().StringP(flag.Name, flag.Short, defaultVal, flag.Description)
 		if flag.Required {
 			cmd.MarkFlagRequired(flag.Name)
 		}
@@ -186,10 +212,7 @@ func (m *Manager) addFlag(cmd *cobra.Command, flag ModuleFlag) error {
 				defaultVal = val
 			}
 		}
-		cmd.Flags().Bool(flag.Name, defaultVal, flag.Description)
-		if flag.Short != "" {
-			cmd.Flags().BoolP(flag.Name, flag.Short, defaultVal, flag.Description)
-		}
+		cmd.Flags().BoolP(flag.Name, flag.Short, defaultVal, flag.Description)
 
 	case "int":
 		defaultVal := 0
@@ -198,10 +221,7 @@ func (m *Manager) addFlag(cmd *cobra.Command, flag ModuleFlag) error {
 				defaultVal = val
 			}
 		}
-		cmd.Flags().Int(flag.Name, defaultVal, flag.Description)
-		if flag.Short != "" {
-			cmd.Flags().IntP(flag.Name, flag.Short, defaultVal, flag.Description)
-		}
+		cmd.Flags().IntP(flag.Name, flag.Short, defaultVal, flag.Description)
 		if flag.Required {
 			cmd.MarkFlagRequired(flag.Name)
 		}
@@ -213,10 +233,7 @@ func (m *Manager) addFlag(cmd *cobra.Command, flag ModuleFlag) error {
 				defaultVal = val
 			}
 		}
-		cmd.Flags().StringSlice(flag.Name, defaultVal, flag.Description)
-		if flag.Short != "" {
-			cmd.Flags().StringSliceP(flag.Name, flag.Short, defaultVal, flag.Description)
-		}
+		cmd.Flags().StringSliceP(flag.Name, flag.Short, defaultVal, flag.Description)
 
 	default:
 		return fmt.Errorf("unsupported flag type: %s", flag.Type)
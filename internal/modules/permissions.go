@@ -0,0 +1,108 @@
+package modules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Permissions a module can declare in spec.permissions
+const (
+	PermissionFilesystemRead  = "filesystem:read"
+	PermissionFilesystemWrite = "filesystem:write"
+	PermissionNetwork         = "network"
+)
+
+// knownPermissions are the permissions modules can declare
+var knownPermissions = map[string]bool{
+	PermissionFilesystemRead:  true,
+	PermissionFilesystemWrite: true,
+	PermissionNetwork:         true,
+}
+
+// untrustedPermissions are the permissions an untrusted module may hold
+// without allow_untrusted: it can read the workspace but not change it or
+// reach the network
+var untrustedPermissions = map[string]bool{
+	PermissionFilesystemRead: true,
+}
+
+// WorkspaceAccess is how the workspace is mounted into a module's container
+type WorkspaceAccess string
+
+const (
+	WorkspaceNone      WorkspaceAccess = ""
+	WorkspaceReadOnly  WorkspaceAccess = "ro"
+	WorkspaceReadWrite WorkspaceAccess = "rw"
+)
+
+// ContainerPolicy restricts a module's container to its declared permissions
+type ContainerPolicy struct {
+	Network   bool
+	Workspace WorkspaceAccess
+	// ConfineVolumes restricts bind volume sources to the workspace, for
+	// untrusted modules
+	ConfineVolumes bool
+}
+
+// NewContainerPolicy derives the container restrictions from declared
+// permissions. Without network the container has no network access; the
+// workspace is mounted read-only with only filesystem:read and not at all
+// without a filesystem permission.
+func NewContainerPolicy(permissions []string) (ContainerPolicy, error) {
+	var policy ContainerPolicy
+	for _, permission := range permissions {
+		switch permission {
+		case PermissionNetwork:
+			policy.Network = true
+		case PermissionFilesystemWrite:
+			policy.Workspace = WorkspaceReadWrite
+		case PermissionFilesystemRead:
+			if policy.Workspace == WorkspaceNone {
+				policy.Workspace = WorkspaceReadOnly
+			}
+		default:
+			return ContainerPolicy{}, fmt.Errorf("unknown permission %q: must be one of %s", permission, strings.Join(sortedKeys(knownPermissions), ", "))
+		}
+	}
+	return policy, nil
+}
+
+// CheckPermissions refuses an untrusted module that requests permissions
+// beyond those untrusted modules are allowed, or mounts volumes, unless
+// allowUntrusted is set. Untrusted modules that only read the workspace run
+// without allowUntrusted, since their container gets no network and a
+// read-only workspace.
+func CheckPermissions(module *Module, allowUntrusted bool) error {
+	if module.Trusted || allowUntrusted {
+		return nil
+	}
+
+	var excess []string
+	for _, permission := range module.Spec.Permissions {
+		if !untrustedPermissions[permission] {
+			excess = append(excess, permission)
+		}
+	}
+	if module.Spec.Docker != nil {
+		for _, volume := range module.Spec.Docker.Volumes {
+			if volume.Type != "tmpfs" {
+				excess = append(excess, "volume "+volume.Target)
+			}
+		}
+	}
+	if len(excess) > 0 {
+		return fmt.Errorf("module '%s' is not trusted and requests %s; rerun with --allow-untrusted to grant it",
+			module.Metadata.Name, strings.Join(excess, ", "))
+	}
+	return nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,255 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	daggermodules "github.com/cloudshipai/ship/internal/dagger/modules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDocker puts a docker executable on PATH that prints its arguments one
+// per line instead of running a container
+func fakeDocker(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker script needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nfor arg in \"$@\"; do echo \"$arg\"; done\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755))
+	t.Setenv("PATH", dir)
+}
+
+// recordingRunner records the containers it is asked to run instead of
+// running them
+type recordingRunner struct {
+	containers []ModuleContainer
+}
+
+func (r *recordingRunner) Run(ctx context.Context, container ModuleContainer) (*ExecutionResult, error) {
+	r.containers = append(r.containers, container)
+	return &ExecutionResult{}, nil
+}
+
+func dockerModule(name string, permissions ...string) *Module {
+	return &Module{
+		Metadata: ModuleMetadata{Name: name},
+		Spec: ModuleSpec{
+			Type:        ModuleTypeDocker,
+			Docker:      &DockerModuleSpec{Image: "example/scanner:1.0"},
+			Commands:    []ModuleCommand{{Name: "scan"}},
+			Permissions: permissions,
+		},
+		Source: "user",
+	}
+}
+
+func TestNewContainerPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		want        ContainerPolicy
+	}{
+		{"no permissions", nil, ContainerPolicy{}},
+		{"read only", []string{PermissionFilesystemRead}, ContainerPolicy{Workspace: WorkspaceReadOnly}},
+		{"write implies read", []string{PermissionFilesystemWrite, PermissionFilesystemRead}, ContainerPolicy{Workspace: WorkspaceReadWrite}},
+		{"network", []string{PermissionNetwork, PermissionFilesystemRead}, ContainerPolicy{Network: true, Workspace: WorkspaceReadOnly}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewContainerPolicy(tt.permissions)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+
+	_, err := NewContainerPolicy([]string{"docker-socket"})
+	assert.ErrorContains(t, err, `unknown permission "docker-socket"`)
+}
+
+func TestModuleContainer_ReadOnlyWorkspace(t *testing.T) {
+	policy, err := NewContainerPolicy([]string{PermissionFilesystemRead})
+	require.NoError(t, err)
+
+	container, err := moduleContainer(&DockerModuleSpec{Image: "example/scanner:1.0"}, policy, "/src/app", []string{"scan", "."})
+	require.NoError(t, err)
+	assert.Equal(t, ModuleContainer{
+		Image:      "example/scanner:1.0",
+		Command:    []string{"scan", "."},
+		WorkingDir: "/workspace",
+		Workspace:  "/src/app",
+	}, container)
+}
+
+func TestModuleContainer_WritableWorkspace(t *testing.T) {
+	policy, err := NewContainerPolicy([]string{PermissionNetwork, PermissionFilesystemWrite})
+	require.NoError(t, err)
+
+	spec := &DockerModuleSpec{
+		Image:      "example/fixer:2",
+		Entrypoint: []string{"/bin/fixer", "--quiet"},
+		Env:        map[string]string{"B": "2", "A": "1"},
+		Volumes:    []VolumeMount{{Source: "/cache", Target: "/root/.cache"}, {Type: "tmpfs", Target: "/tmp"}},
+	}
+	container, err := moduleContainer(spec, policy, "/src/app", []string{"fix"})
+	require.NoError(t, err)
+	assert.Equal(t, ModuleContainer{
+		Image:          "example/fixer:2",
+		Entrypoint:     []string{"/bin/fixer", "--quiet"},
+		Command:        []string{"fix"},
+		WorkingDir:     "/workspace",
+		Env:            map[string]string{"A": "1", "B": "2"},
+		Network:        true,
+		Workspace:      "/src/app",
+		WriteWorkspace: true,
+		Mounts:         []ContainerMount{{Source: "/cache", Target: "/root/.cache"}, {Target: "/tmp"}},
+	}, container)
+}
+
+func TestModuleContainer_NoFilesystemPermission(t *testing.T) {
+	container, err := moduleContainer(&DockerModuleSpec{Image: "example/ping:1"}, ContainerPolicy{}, "/src/app", []string{"ping"})
+	require.NoError(t, err)
+	assert.Empty(t, container.Workspace, "the workspace is not mounted")
+	assert.Empty(t, container.WorkingDir)
+
+	_, err = moduleContainer(&DockerModuleSpec{
+		Image:   "example/ping:1",
+		Volumes: []VolumeMount{{Source: "/etc", Target: "/host-etc"}},
+	}, ContainerPolicy{}, "/src/app", []string{"ping"})
+	assert.ErrorContains(t, err, "volume /host-etc needs the filesystem:read or filesystem:write permission")
+}
+
+func TestModuleContainer_ConfinedVolumes(t *testing.T) {
+	policy := ContainerPolicy{Workspace: WorkspaceReadOnly, ConfineVolumes: true}
+
+	container, err := moduleContainer(&DockerModuleSpec{
+		Image:   "example/scanner:1.0",
+		Volumes: []VolumeMount{{Source: "cache", Target: "/root/.cache"}, {Source: "/src/app/rules", Target: "/rules"}},
+	}, policy, "/src/app", []string{"scan"})
+	require.NoError(t, err)
+	assert.Equal(t, []ContainerMount{
+		{Source: "/src/app/cache", Target: "/root/.cache"},
+		{Source: "/src/app/rules", Target: "/rules"},
+	}, container.Mounts)
+
+	for _, source := range []string{"/var/run/docker.sock", "../secrets", "/src/application"} {
+		_, err := moduleContainer(&DockerModuleSpec{
+			Image:   "example/scanner:1.0",
+			Volumes: []VolumeMount{{Source: source, Target: "/mnt"}},
+		}, policy, "/src/app", []string{"scan"})
+		assert.ErrorContains(t, err, "is outside the workspace", source)
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	assert.NoError(t, CheckPermissions(dockerModule("reader", PermissionFilesystemRead), false),
+		"untrusted modules may read the workspace")
+
+	err := CheckPermissions(dockerModule("uploader", PermissionFilesystemRead, PermissionNetwork), false)
+	assert.EqualError(t, err, "module 'uploader' is not trusted and requests network; rerun with --allow-untrusted to grant it")
+
+	assert.NoError(t, CheckPermissions(dockerModule("uploader", PermissionNetwork), true))
+
+	mounter := dockerModule("mounter", PermissionFilesystemRead)
+	mounter.Spec.Docker.Volumes = []VolumeMount{{Source: "/var/run/docker.sock", Target: "/var/run/docker.sock"}, {Type: "tmpfs", Target: "/tmp"}}
+	err = CheckPermissions(mounter, false)
+	assert.EqualError(t, err, "module 'mounter' is not trusted and requests volume /var/run/docker.sock; rerun with --allow-untrusted to grant it")
+
+	trusted := dockerModule("trusted", PermissionNetwork, PermissionFilesystemWrite)
+	trusted.Trusted = true
+	assert.NoError(t, CheckPermissions(trusted, false))
+}
+
+func TestExecuteModule_EnforcesPermissions(t *testing.T) {
+	runner := &recordingRunner{}
+	manager := NewManager(ModuleConfig{})
+	manager.SetContainerRunner(runner)
+	manager.modules = []*Module{
+		dockerModule("reader", PermissionFilesystemRead),
+		dockerModule("uploader", PermissionNetwork),
+	}
+
+	_, err := manager.ExecuteModule(context.Background(), "uploader", "scan", nil, nil)
+	assert.ErrorContains(t, err, "--allow-untrusted")
+	assert.Empty(t, runner.containers, "a refused module is not run")
+
+	_, err = manager.ExecuteModule(context.Background(), "reader", "scan", []string{"."}, nil)
+	require.NoError(t, err)
+	require.Len(t, runner.containers, 1)
+	container := runner.containers[0]
+	assert.Equal(t, []string{"scan", "."}, container.Command)
+	assert.NotEmpty(t, container.Workspace)
+	assert.False(t, container.WriteWorkspace, "a filesystem:read module does not write the workspace back")
+	assert.False(t, container.Network)
+
+	manager.config.AllowUntrusted = true
+	_, err = manager.ExecuteModule(context.Background(), "uploader", "scan", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, runner.containers[1].Workspace, "a module without filesystem permission does not see the workspace")
+}
+
+func TestExecuteModule_WorkspaceFollowsWorkingDir(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Setenv(daggermodules.WorkingDirEnv, workingDir)
+
+	runner := &recordingRunner{}
+	manager := NewManager(ModuleConfig{})
+	manager.SetContainerRunner(runner)
+	manager.modules = []*Module{dockerModule("reader", PermissionFilesystemRead)}
+
+	_, err := manager.ExecuteModule(context.Background(), "reader", "scan", []string{"."}, nil)
+	require.NoError(t, err)
+	require.Len(t, runner.containers, 1)
+	assert.Equal(t, workingDir, runner.containers[0].Workspace, "--working-dir is mounted, not the current directory")
+}
+
+func TestDockerRunArgs(t *testing.T) {
+	args := dockerRunArgs(ModuleContainer{
+		Image:      "example/scanner:1.0",
+		Command:    []string{"scan", "."},
+		WorkingDir: "/workspace",
+		Env:        map[string]string{"B": "2", "A": "1"},
+		Workspace:  "/src/app",
+		Mounts:     []ContainerMount{{Source: "/src/app/rules", Target: "/rules"}, {Target: "/tmp"}},
+	})
+	assert.Equal(t, []string{
+		"run", "--rm", "--network", "none",
+		"-v", "/src/app:/workspace:ro", "-v", "/src/app/rules:/rules:ro", "--tmpfs", "/tmp", "-w", "/workspace",
+		"-e", "A=1", "-e", "B=2",
+		"example/scanner:1.0", "scan", ".",
+	}, args)
+
+	args = dockerRunArgs(ModuleContainer{
+		Image:          "example/fixer:2",
+		Entrypoint:     []string{"/bin/fixer", "--quiet"},
+		Command:        []string{"fix"},
+		Network:        true,
+		Workspace:      "/src/app",
+		WriteWorkspace: true,
+	})
+	assert.Equal(t, []string{
+		"run", "--rm",
+		"-v", "/src/app:/workspace",
+		"--entrypoint", "/bin/fixer", "example/fixer:2", "--quiet", "fix",
+	}, args)
+}
+
+func TestExecuteModule_NoNetworkModuleRunsWithoutNetwork(t *testing.T) {
+	fakeDocker(t)
+
+	manager := NewManager(ModuleConfig{})
+	manager.modules = []*Module{dockerModule("reader", PermissionFilesystemRead)}
+
+	result, err := manager.ExecuteModule(context.Background(), "reader", "scan", []string{"."}, nil)
+	require.NoError(t, err)
+	args := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	assert.Subset(t, args, []string{"--network", "none"}, "a module without network permission runs without network")
+	assert.Contains(t, strings.Join(args, " "), ":/workspace:ro", "a filesystem:read module gets a read-only mount")
+}
@@ -0,0 +1,124 @@
+package modules
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `apiVersion: ship.cloudship.ai/v1
+kind: Module
+metadata:
+  name: signed-scanner
+  version: 1.0.0
+spec:
+  type: docker
+  docker:
+    image: example/scanner:1.0
+  commands:
+    - name: scan
+`
+
+// writeTestKey generates an ECDSA key pair like `cosign generate-key-pair`
+// and writes the public key as PEM
+func writeTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	return key, path
+}
+
+// signManifest signs data like `cosign sign-blob`
+func signManifest(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+// writeModuleDir writes a module directory with the test manifest and, when
+// given, its signature, returning the directory of modules
+func writeModuleDir(t *testing.T, signature []byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	moduleDir := filepath.Join(dir, "signed-scanner")
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "module.yaml"), []byte(testManifest), 0644))
+	if signature != nil {
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "module.yaml.sig"), signature, 0644))
+	}
+	return dir
+}
+
+func discoverTestModules(t *testing.T, dir string, config ModuleConfig) []*Module {
+	t.Helper()
+	discovery := &UserDirectoryDiscovery{config: config}
+	modules, err := discovery.discoverModulesInDirectory(dir, "project")
+	require.NoError(t, err)
+	return modules
+}
+
+func TestSignedModule_ValidSignature(t *testing.T) {
+	key, publicKey := writeTestKey(t)
+	dir := writeModuleDir(t, signManifest(t, key, []byte(testManifest)))
+
+	modules := discoverTestModules(t, dir, ModuleConfig{PublicKey: publicKey, RequireSigned: true})
+	require.Len(t, modules, 1)
+	assert.True(t, modules[0].Signed)
+	assert.True(t, modules[0].Trusted, "a verified module is trusted")
+}
+
+func TestSignedModule_InvalidSignature(t *testing.T) {
+	key, publicKey := writeTestKey(t)
+	dir := writeModuleDir(t, signManifest(t, key, []byte(testManifest+"    - name: exfiltrate\n")))
+
+	assert.Empty(t, discoverTestModules(t, dir, ModuleConfig{PublicKey: publicKey}),
+		"a module whose signature does not match its manifest is not loaded")
+
+	otherKey, _ := writeTestKey(t)
+	dir = writeModuleDir(t, signManifest(t, otherKey, []byte(testManifest)))
+	assert.Empty(t, discoverTestModules(t, dir, ModuleConfig{PublicKey: publicKey}),
+		"a module signed by another key is not loaded")
+}
+
+func TestSignedModule_RequireSigned(t *testing.T) {
+	_, publicKey := writeTestKey(t)
+	dir := writeModuleDir(t, nil)
+
+	modules := discoverTestModules(t, dir, ModuleConfig{PublicKey: publicKey})
+	require.Len(t, modules, 1, "unsigned modules load when signatures are optional")
+	assert.False(t, modules[0].Signed)
+	assert.False(t, modules[0].Trusted)
+
+	assert.Empty(t, discoverTestModules(t, dir, ModuleConfig{PublicKey: publicKey, RequireSigned: true}))
+	assert.Empty(t, discoverTestModules(t, dir, ModuleConfig{RequireSigned: true}),
+		"required signatures cannot be verified without a public key")
+}
+
+func TestVerifyManifestSignature_Ed25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	manifest := []byte(testManifest)
+	signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, manifest)))
+
+	assert.NoError(t, VerifyManifestSignature(manifest, signature, public))
+	assert.Error(t, VerifyManifestSignature([]byte("tampered"), signature, public))
+	assert.ErrorContains(t, VerifyManifestSignature(manifest, []byte("not base64!"), public), "failed to decode signature")
+}
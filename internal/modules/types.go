@@ -25,6 +25,9 @@ type Module struct {
 	Source   string    `yaml:"-"`
 	LoadedAt time.Time `yaml:"-"`
 	Trusted  bool      `yaml:"-"`
+	// Signed is set when the manifest's signature verified against the
+	// configured public key
+	Signed bool `yaml:"-"`
 }
 
 // ModuleMetadata contains module identification information
@@ -122,6 +125,10 @@ type ModuleConfig struct {
 	Sandbox        bool            `yaml:"sandbox"`
 	CacheDir       string          `yaml:"cache_dir,omitempty"`
 	UpdateInterval string          `yaml:"update_interval,omitempty"`
+	// PublicKey is a PEM public key module manifest signatures are verified against
+	PublicKey string `yaml:"public_key,omitempty"`
+	// RequireSigned refuses modules whose manifest signature does not verify
+	RequireSigned bool `yaml:"require_signed"`
 }
 
 // GitRepository represents a git-based module source
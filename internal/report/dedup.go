@@ -0,0 +1,65 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// dedupKey identifies findings that describe the same issue
+type dedupKey struct {
+	RuleID      string
+	File        string
+	Line        int
+	MessageHash string
+}
+
+func keyOf(finding Finding) dedupKey {
+	sum := sha256.Sum256([]byte(finding.Message))
+	return dedupKey{
+		RuleID:      finding.RuleID,
+		File:        finding.File,
+		Line:        finding.Line,
+		MessageHash: hex.EncodeToString(sum[:]),
+	}
+}
+
+// Dedup collapses findings with the same rule, file, line and message into
+// one, keeping the first occurrence and recording every reporting tool in
+// Tools. The order of first occurrences is preserved.
+func Dedup(findings []Finding) []Finding {
+	var result []Finding
+	index := make(map[dedupKey]int)
+
+	for _, finding := range findings {
+		key := keyOf(finding)
+		i, seen := index[key]
+		if !seen {
+			index[key] = len(result)
+			finding.Tools = addTools(nil, finding)
+			result = append(result, finding)
+			continue
+		}
+		result[i].Tools = addTools(result[i].Tools, finding)
+	}
+
+	return result
+}
+
+// addTools merges the finding's tools into tools, keeping them sorted and unique
+func addTools(tools []string, finding Finding) []string {
+	candidates := append([]string{finding.Tool}, finding.Tools...)
+	for _, tool := range candidates {
+		if tool == "" {
+			continue
+		}
+		i := sort.SearchStrings(tools, tool)
+		if i < len(tools) && tools[i] == tool {
+			continue
+		}
+		tools = append(tools, "")
+		copy(tools[i+1:], tools[i:])
+		tools[i] = tool
+	}
+	return tools
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedup_CollapsesOverlappingToolOutputs(t *testing.T) {
+	trivy := []Finding{
+		{Tool: "trivy", RuleID: "CVE-2024-1234", File: "go.sum", Line: 10, Severity: "error", Message: "golang.org/x/net vulnerable"},
+		{Tool: "trivy", RuleID: "CVE-2024-9999", File: "go.sum", Line: 22, Severity: "warning", Message: "golang.org/x/text vulnerable"},
+	}
+	osv := []Finding{
+		{Tool: "osv-scanner", RuleID: "CVE-2024-1234", File: "go.sum", Line: 10, Severity: "warning", Message: "golang.org/x/net vulnerable"},
+		{Tool: "osv-scanner", RuleID: "GHSA-abcd", File: "package-lock.json", Line: 3, Severity: "error", Message: "lodash vulnerable"},
+	}
+
+	findings := Dedup(append(trivy, osv...))
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "CVE-2024-1234", findings[0].RuleID)
+	assert.Equal(t, []string{"osv-scanner", "trivy"}, findings[0].Tools)
+	assert.Equal(t, "trivy", findings[0].Tool, "the first report is kept")
+	assert.Equal(t, "error", findings[0].Severity)
+
+	assert.Equal(t, []string{"trivy"}, findings[1].Tools)
+	assert.Equal(t, []string{"osv-scanner"}, findings[2].Tools)
+}
+
+func TestDedup_KeyIncludesLocationAndMessage(t *testing.T) {
+	findings := Dedup([]Finding{
+		{Tool: "a", RuleID: "R1", File: "main.tf", Line: 1, Message: "open bucket"},
+		{Tool: "b", RuleID: "R1", File: "main.tf", Line: 2, Message: "open bucket"},
+		{Tool: "c", RuleID: "R1", File: "other.tf", Line: 1, Message: "open bucket"},
+		{Tool: "d", RuleID: "R1", File: "main.tf", Line: 1, Message: "public bucket"},
+		{Tool: "e", RuleID: "R2", File: "main.tf", Line: 1, Message: "open bucket"},
+	})
+	assert.Len(t, findings, 5)
+}
+
+func TestDedup_SameToolReportedTwice(t *testing.T) {
+	finding := Finding{Tool: "checkov", RuleID: "CKV_AWS_20", File: "main.tf", Line: 4, Message: "public"}
+	findings := Dedup([]Finding{finding, finding})
+	require.Len(t, findings, 1)
+	assert.Equal(t, []string{"checkov"}, findings[0].Tools)
+}
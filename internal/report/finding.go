@@ -0,0 +1,81 @@
+// Package report normalizes tool findings so they can be rendered in
+// tool-independent report formats
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Finding is a single issue reported by a tool
+type Finding struct {
+	Tool     string `json:"tool"`
+	RuleID   string `json:"rule_id"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Tools lists every tool that reported the finding once duplicates are
+	// collapsed with Dedup
+	Tools []string `json:"tools,omitempty"`
+}
+
+// sarifLog is the subset of a SARIF 2.1.0 log needed to extract findings
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Name string `json:"name"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// FromSARIF extracts one finding per result in a SARIF log. Results without a
+// level default to "warning" as the SARIF specification requires.
+func FromSARIF(data []byte) ([]Finding, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to parse SARIF: %w", err)
+	}
+
+	var findings []Finding
+	for _, run := range log.Runs {
+		for _, result := range run.Results {
+			finding := Finding{
+				Tool:     run.Tool.Driver.Name,
+				RuleID:   result.RuleID,
+				Severity: result.Level,
+				Message:  result.Message.Text,
+			}
+			if finding.Severity == "" {
+				finding.Severity = "warning"
+			}
+			if len(result.Locations) > 0 {
+				location := result.Locations[0].PhysicalLocation
+				finding.File = location.ArtifactLocation.URI
+				finding.Line = location.Region.StartLine
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
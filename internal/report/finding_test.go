@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSARIF(t *testing.T) {
+	sarif := `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "actionlint"}},
+    "results": [
+      {
+        "ruleId": "expression",
+        "level": "error",
+        "message": {"text": "undefined variable \"foo\""},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": ".github/workflows/ci.yml"}, "region": {"startLine": 12}}}]
+      },
+      {
+        "ruleId": "shellcheck",
+        "message": {"text": "SC2086: Double quote to prevent globbing"}
+      }
+    ]
+  }]
+}`
+
+	findings, err := FromSARIF([]byte(sarif))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, Finding{
+		Tool:     "actionlint",
+		RuleID:   "expression",
+		File:     ".github/workflows/ci.yml",
+		Line:     12,
+		Severity: "error",
+		Message:  `undefined variable "foo"`,
+	}, findings[0])
+	assert.Equal(t, "warning", findings[1].Severity, "results without a level default to warning")
+	assert.Empty(t, findings[1].File)
+}
+
+func TestFromSARIF_Invalid(t *testing.T) {
+	_, err := FromSARIF([]byte("not sarif"))
+	assert.Error(t, err)
+}
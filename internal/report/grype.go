@@ -0,0 +1,56 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// grypeDocument is the subset of Grype's JSON report needed to extract
+// findings
+type grypeDocument struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+			Fix      struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name      string `json:"name"`
+			Version   string `json:"version"`
+			Locations []struct {
+				Path string `json:"path"`
+			} `json:"locations"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+// FromGrype extracts one finding per match from Grype's JSON output. Grype's
+// own severities, such as "negligible", are kept so they can be remapped
+// with a SeverityMap.
+func FromGrype(data []byte) ([]Finding, error) {
+	var document grypeDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse Grype report: %w", err)
+	}
+
+	var findings []Finding
+	for _, match := range document.Matches {
+		finding := Finding{
+			Tool:     "grype",
+			RuleID:   match.Vulnerability.ID,
+			Severity: strings.ToLower(match.Vulnerability.Severity),
+			Message:  fmt.Sprintf("%s %s is vulnerable", match.Artifact.Name, match.Artifact.Version),
+		}
+		if len(match.Vulnerability.Fix.Versions) > 0 {
+			finding.Message += "; fixed in " + strings.Join(match.Vulnerability.Fix.Versions, ", ")
+		}
+		if len(match.Artifact.Locations) > 0 {
+			finding.File = strings.TrimPrefix(match.Artifact.Locations[0].Path, "/")
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromGrype(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "grype.json"))
+	require.NoError(t, err)
+
+	findings, err := FromGrype(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Tool: "grype", RuleID: "CVE-2005-2541", File: "var/lib/dpkg/status", Severity: "negligible", Message: "tar 1.34+dfsg-1.2+deb12u1 is vulnerable"},
+		{Tool: "grype", RuleID: "GHSA-m425-mq94-257g", File: "go.mod", Severity: "high", Message: "google.golang.org/grpc v1.56.2 is vulnerable; fixed in 1.56.3, 1.57.1, 1.58.3"},
+	}, findings)
+
+	severityMap, err := ParseSeverityMap(map[string]string{"grype:negligible": "info"})
+	require.NoError(t, err)
+	severityMap.Apply(findings)
+	assert.Equal(t, "info", findings[0].Severity, "Grype's own severities can be remapped")
+	assert.Equal(t, "high", findings[1].Severity)
+}
+
+func TestFromGrypeInvalid(t *testing.T) {
+	findings, err := FromGrype([]byte(`{"matches":[]}`))
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = FromGrype([]byte("not json"))
+	assert.ErrorContains(t, err, "failed to parse Grype report")
+}
@@ -0,0 +1,122 @@
+// Package junit renders findings as JUnit XML so CI systems can show them as
+// failed test cases
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/cloudshipai/ship/internal/report"
+)
+
+// noFile groups findings that are not tied to a file
+const noFile = "(no file)"
+
+// TestSuites is the JUnit document root
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite holds the findings for one file
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// TestCase is a single finding
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	File      string   `xml:"file,attr,omitempty"`
+	Line      int      `xml:"line,attr,omitempty"`
+	Failure   *Failure `xml:"failure"`
+}
+
+// Failure describes why a test case failed
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Convert builds a JUnit document with one suite per file and one failing test
+// case per finding, named after the finding's rule. Suites are ordered by file
+// and cases by line then rule so the output is stable.
+func Convert(name string, findings []report.Finding) TestSuites {
+	byFile := make(map[string][]report.Finding)
+	for _, finding := range findings {
+		file := finding.File
+		if file == "" {
+			file = noFile
+		}
+		byFile[file] = append(byFile[file], finding)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	suites := TestSuites{Name: name, Tests: len(findings), Failures: len(findings)}
+	for _, file := range files {
+		fileFindings := byFile[file]
+		sort.SliceStable(fileFindings, func(i, j int) bool {
+			if fileFindings[i].Line != fileFindings[j].Line {
+				return fileFindings[i].Line < fileFindings[j].Line
+			}
+			return fileFindings[i].RuleID < fileFindings[j].RuleID
+		})
+
+		suite := TestSuite{Name: file, Tests: len(fileFindings), Failures: len(fileFindings)}
+		for _, finding := range fileFindings {
+			suite.TestCases = append(suite.TestCases, testCase(file, finding))
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	return suites
+}
+
+// Marshal renders findings as an indented JUnit XML document
+func Marshal(name string, findings []report.Finding) ([]byte, error) {
+	data, err := xml.MarshalIndent(Convert(name, findings), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JUnit XML: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func testCase(file string, finding report.Finding) TestCase {
+	rule := finding.RuleID
+	if rule == "" {
+		rule = "finding"
+	}
+
+	location := file
+	if finding.Line > 0 {
+		location = fmt.Sprintf("%s:%d", file, finding.Line)
+	}
+
+	tc := TestCase{
+		Name:      rule,
+		ClassName: file,
+		Line:      finding.Line,
+		Failure: &Failure{
+			Message: finding.Message,
+			Type:    finding.Severity,
+			Text:    fmt.Sprintf("%s: %s", location, finding.Message),
+		},
+	}
+	if file != noFile {
+		tc.File = file
+	}
+	return tc
+}
@@ -0,0 +1,71 @@
+package junit
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/cloudshipai/ship/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fixtureFindings = []report.Finding{
+	{Tool: "checkov", RuleID: "CKV_AWS_20", File: "main.tf", Line: 14, Severity: "error", Message: "S3 bucket is publicly readable"},
+	{Tool: "checkov", RuleID: "CKV_AWS_57", File: "main.tf", Line: 3, Severity: "warning", Message: "S3 bucket is publicly writable"},
+}
+
+func TestMarshal_OneFailingCasePerFinding(t *testing.T) {
+	data, err := Marshal("checkov", fixtureFindings)
+	require.NoError(t, err)
+
+	var suites TestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+
+	assert.Equal(t, "checkov", suites.Name)
+	assert.Equal(t, 2, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+
+	var cases []TestCase
+	for _, suite := range suites.Suites {
+		cases = append(cases, suite.TestCases...)
+	}
+	require.Len(t, cases, 2)
+	for _, tc := range cases {
+		require.NotNil(t, tc.Failure, "%s should fail", tc.Name)
+	}
+}
+
+func TestConvert_GroupsByFile(t *testing.T) {
+	findings := append([]report.Finding{
+		{RuleID: "CKV_DOCKER_2", File: "Dockerfile", Line: 1, Severity: "warning", Message: "No HEALTHCHECK"},
+		{RuleID: "CKV_GLOBAL", Severity: "note", Message: "Not tied to a file"},
+	}, fixtureFindings...)
+
+	suites := Convert("checkov", findings)
+	require.Len(t, suites.Suites, 3)
+
+	// Suites are sorted by file, with file-less findings grouped together
+	assert.Equal(t, "(no file)", suites.Suites[0].Name)
+	assert.Equal(t, "Dockerfile", suites.Suites[1].Name)
+	assert.Equal(t, "main.tf", suites.Suites[2].Name)
+
+	// Cases within a file are ordered by line and named after the rule
+	mainTF := suites.Suites[2]
+	assert.Equal(t, 2, mainTF.Failures)
+	assert.Equal(t, "CKV_AWS_57", mainTF.TestCases[0].Name)
+	assert.Equal(t, "CKV_AWS_20", mainTF.TestCases[1].Name)
+	assert.Equal(t, "main.tf:14: S3 bucket is publicly readable", mainTF.TestCases[1].Failure.Text)
+	assert.Equal(t, "error", mainTF.TestCases[1].Failure.Type)
+
+	assert.Empty(t, suites.Suites[0].TestCases[0].File)
+}
+
+func TestMarshal_NoFindings(t *testing.T) {
+	data, err := Marshal("checkov", nil)
+	require.NoError(t, err)
+
+	var suites TestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 0, suites.Tests)
+	assert.Empty(t, suites.Suites)
+}
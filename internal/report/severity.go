@@ -0,0 +1,102 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// severityRanks orders the canonical severities from least to most severe.
+// SARIF levels rank alongside the named severities they correspond to.
+var severityRanks = map[string]int{
+	"none":     0,
+	"info":     0,
+	"note":     1,
+	"low":      1,
+	"warning":  2,
+	"medium":   2,
+	"error":    3,
+	"high":     3,
+	"critical": 4,
+}
+
+// Severities lists the canonical severities, least severe first
+func Severities() []string {
+	severities := make([]string, 0, len(severityRanks))
+	for severity := range severityRanks {
+		severities = append(severities, severity)
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		ri, rj := severityRanks[severities[i]], severityRanks[severities[j]]
+		if ri != rj {
+			return ri < rj
+		}
+		return severities[i] < severities[j]
+	})
+	return severities
+}
+
+// SeverityRank ranks a severity on the canonical scale. Severities outside
+// the scale, such as a tool's own "negligible", rank -1 and so never meet a
+// threshold until they are remapped.
+func SeverityRank(severity string) int {
+	if rank, ok := severityRanks[strings.ToLower(severity)]; ok {
+		return rank
+	}
+	return -1
+}
+
+// SeverityMap remaps tool-specific severities onto the canonical scale. Keys
+// are a severity, applied to every tool, or tool:severity, applied to that
+// tool only; both are matched case-insensitively.
+type SeverityMap map[string]string
+
+// ParseSeverityMap builds a SeverityMap from from=to pairs, rejecting
+// targets that are not canonical severities
+func ParseSeverityMap(pairs map[string]string) (SeverityMap, error) {
+	severityMap := make(SeverityMap, len(pairs))
+	for from, to := range pairs {
+		to = strings.ToLower(strings.TrimSpace(to))
+		if _, ok := severityRanks[to]; !ok {
+			return nil, fmt.Errorf("invalid severity mapping %s=%s: must map to one of %s", from, to, strings.Join(Severities(), ", "))
+		}
+		severityMap[strings.ToLower(strings.TrimSpace(from))] = to
+	}
+	return severityMap, nil
+}
+
+// Apply rewrites the severity of each finding in place, preferring a
+// tool-specific mapping over a global one
+func (m SeverityMap) Apply(findings []Finding) {
+	if len(m) == 0 {
+		return
+	}
+	for i, finding := range findings {
+		severity := strings.ToLower(finding.Severity)
+		if to, ok := m[strings.ToLower(finding.Tool)+":"+severity]; ok {
+			findings[i].Severity = to
+		} else if to, ok := m[severity]; ok {
+			findings[i].Severity = to
+		}
+	}
+}
+
+// CountBySeverity counts findings per severity
+func CountBySeverity(findings []Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// AtOrAbove returns the findings whose severity ranks at or above threshold
+func AtOrAbove(findings []Finding, threshold string) []Finding {
+	var matched []Finding
+	for _, finding := range findings {
+		if SeverityRank(finding.Severity) >= SeverityRank(threshold) {
+			matched = append(matched, finding)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityMap_Apply(t *testing.T) {
+	severityMap, err := ParseSeverityMap(map[string]string{
+		"Negligible":      "info",
+		"warning":         "medium",
+		"checkov:warning": "HIGH",
+	})
+	require.NoError(t, err)
+
+	findings := []Finding{
+		{Tool: "grype", Severity: "negligible"},
+		{Tool: "osv-scanner", Severity: "warning"},
+		{Tool: "checkov", Severity: "warning"},
+		{Tool: "trivy", Severity: "error"},
+	}
+	severityMap.Apply(findings)
+
+	assert.Equal(t, "info", findings[0].Severity)
+	assert.Equal(t, "medium", findings[1].Severity)
+	assert.Equal(t, "high", findings[2].Severity, "a tool-specific mapping wins over a global one")
+	assert.Equal(t, "error", findings[3].Severity, "unmapped severities are unchanged")
+}
+
+func TestParseSeverityMap_InvalidTarget(t *testing.T) {
+	_, err := ParseSeverityMap(map[string]string{"negligible": "trivial"})
+	assert.ErrorContains(t, err, "invalid severity mapping negligible=trivial")
+}
+
+func TestSeverityRank(t *testing.T) {
+	assert.Equal(t, SeverityRank("error"), SeverityRank("HIGH"))
+	assert.Greater(t, SeverityRank("critical"), SeverityRank("error"))
+	assert.Greater(t, SeverityRank("warning"), SeverityRank("note"))
+	assert.Equal(t, -1, SeverityRank("negligible"))
+}
+
+func TestAtOrAbove(t *testing.T) {
+	findings := []Finding{
+		{RuleID: "a", Severity: "note"},
+		{RuleID: "b", Severity: "high"},
+		{RuleID: "c", Severity: "negligible"},
+		{RuleID: "d", Severity: "warning"},
+	}
+
+	matched := AtOrAbove(findings, "warning")
+	require.Len(t, matched, 2)
+	assert.Equal(t, "b", matched[0].RuleID)
+	assert.Equal(t, "d", matched[1].RuleID)
+	assert.Len(t, AtOrAbove(findings, "none"), 3, "unknown severities never meet a threshold")
+}
+
+func TestCountBySeverity(t *testing.T) {
+	counts := CountBySeverity([]Finding{{Severity: "error"}, {Severity: "info"}, {Severity: "error"}})
+	assert.Equal(t, map[string]int{"error": 2, "info": 1}, counts)
+}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// trufflehogResult is the subset of a TruffleHog --json result needed to
+// extract a finding. The secret itself (Raw) is deliberately not read.
+type trufflehogResult struct {
+	SourceMetadata struct {
+		Data map[string]struct {
+			File string `json:"file"`
+			Line int    `json:"line"`
+		} `json:"Data"`
+	} `json:"SourceMetadata"`
+	DetectorName string `json:"DetectorName"`
+	Verified     bool   `json:"Verified"`
+}
+
+// FromTruffleHog extracts one finding per result from TruffleHog's --json
+// output, which is one JSON object per line. Verified secrets are high
+// severity and unverified ones medium.
+func FromTruffleHog(data []byte) ([]Finding, error) {
+	var findings []Finding
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var result trufflehogResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse TruffleHog result on line %d: %w", lineNumber, err)
+		}
+
+		finding := Finding{
+			Tool:     "trufflehog",
+			RuleID:   result.DetectorName,
+			Severity: "medium",
+			Message:  fmt.Sprintf("Unverified %s secret", result.DetectorName),
+		}
+		if result.Verified {
+			finding.Severity = "high"
+			finding.Message = fmt.Sprintf("Verified %s secret", result.DetectorName)
+		}
+
+		// Data holds a single source, such as Filesystem, Git or Docker
+		sources := make([]string, 0, len(result.SourceMetadata.Data))
+		for source := range result.SourceMetadata.Data {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		if len(sources) > 0 {
+			location := result.SourceMetadata.Data[sources[0]]
+			finding.File = location.File
+			finding.Line = location.Line
+		}
+
+		findings = append(findings, finding)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TruffleHog results: %w", err)
+	}
+
+	return findings, nil
+}
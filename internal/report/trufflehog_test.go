@@ -0,0 +1,35 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromTruffleHog(t *testing.T) {
+	output := `{"SourceMetadata":{"Data":{"Filesystem":{"file":"config/prod.env","line":3}}},"SourceName":"trufflehog - filesystem","DetectorName":"AWS","Verified":true,"Raw":"AKIAEXAMPLE","Redacted":"AKIA****"}
+
+{"SourceMetadata":{"Data":{"Git":{"commit":"9f1c2e","file":"deploy.sh","line":12}}},"SourceName":"trufflehog - git","DetectorName":"Slack","Verified":false,"Raw":"xoxb-example"}
+`
+
+	findings, err := FromTruffleHog([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Tool: "trufflehog", RuleID: "AWS", File: "config/prod.env", Line: 3, Severity: "high", Message: "Verified AWS secret"},
+		{Tool: "trufflehog", RuleID: "Slack", File: "deploy.sh", Line: 12, Severity: "medium", Message: "Unverified Slack secret"},
+	}, findings)
+
+	for _, finding := range findings {
+		assert.NotContains(t, finding.Message, "AKIAEXAMPLE", "secrets are not copied into findings")
+	}
+}
+
+func TestFromTruffleHogEmptyAndInvalid(t *testing.T) {
+	findings, err := FromTruffleHog([]byte("\n"))
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = FromTruffleHog([]byte("{\"DetectorName\":\"AWS\"}\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
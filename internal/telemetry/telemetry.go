@@ -85,6 +85,16 @@ func Close() {
 	}
 }
 
+// Disable turns telemetry off for the rest of the process, for example in
+// offline mode. Events tracked afterwards are dropped.
+func Disable() {
+	if globalClient == nil {
+		globalClient = &Client{enabled: false}
+		return
+	}
+	globalClient.enabled = false
+}
+
 // TrackMCPCommand tracks when a user runs an MCP command
 func TrackMCPCommand(toolName string) {
 	trackEvent("shp_mcp_command_executed", posthog.NewProperties().
@@ -154,22 +154,14 @@ func (a *MCPAdapter) attachShipTool(mcpServer *server.MCPServer, name string, to
 		for _, param := range tool.Parameters() {
 			if param.Required {
 				if _, exists := params[param.Name]; !exists {
-					return mcp.NewToolResultError(fmt.Sprintf("required parameter '%s' is missing", param.Name)), nil
+					return NewToolErrorResult(ErrorCodeInvalidInput, fmt.Sprintf("required parameter '%s' is missing", param.Name)), nil
 				}
 			}
 		}
 
 		// Execute the Ship tool
 		result, err := tool.Execute(ctx, params, a.engine)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-
-		if result.Error != nil {
-			return mcp.NewToolResultError(result.Error.Error()), nil
-		}
-
-		return mcp.NewToolResultText(result.Content), nil
+		return toolCallResult(result, err), nil
 	})
 }
 
@@ -14,4 +14,68 @@ var (
 
 	// ErrDaggerEngineNotAvailable is returned when Dagger engine is not available
 	ErrDaggerEngineNotAvailable = errors.New("dagger engine not available")
+
+	// ErrToolUnavailable is returned when a tool's binary or container image cannot be run
+	ErrToolUnavailable = errors.New("tool not installed or unavailable")
+
+	// ErrFindingsExceeded is returned when a tool ran but its findings exceeded the allowed threshold
+	ErrFindingsExceeded = errors.New("findings exceeded threshold")
+)
+
+// ErrorCode classifies why a tool execution failed so callers can react
+// without parsing error messages
+type ErrorCode string
+
+const (
+	// ErrorCodeToolNotFound means the requested tool is not registered
+	ErrorCodeToolNotFound ErrorCode = "tool_not_found"
+
+	// ErrorCodeToolUnavailable means the tool's binary or image cannot be run
+	ErrorCodeToolUnavailable ErrorCode = "tool_unavailable"
+
+	// ErrorCodeNotConfigured means the tool has no executor
+	ErrorCodeNotConfigured ErrorCode = "not_configured"
+
+	// ErrorCodeInvalidInput means a parameter is missing or invalid
+	ErrorCodeInvalidInput ErrorCode = "invalid_input"
+
+	// ErrorCodeEngineUnavailable means the Dagger engine could not be used
+	ErrorCodeEngineUnavailable ErrorCode = "engine_unavailable"
+
+	// ErrorCodeFindingsExceeded means the tool ran and reported too many findings
+	ErrorCodeFindingsExceeded ErrorCode = "findings_exceeded"
+
+	// ErrorCodeExternalTool means an external MCP server reported an error
+	ErrorCodeExternalTool ErrorCode = "external_tool_error"
+
+	// ErrorCodeExecutionFailed is any other failure while running the tool
+	ErrorCodeExecutionFailed ErrorCode = "execution_failed"
 )
+
+// errorCodes maps the package's sentinel errors to their codes
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrToolNotFound, ErrorCodeToolNotFound},
+	{ErrToolUnavailable, ErrorCodeToolUnavailable},
+	{ErrExecutorNotSet, ErrorCodeNotConfigured},
+	{ErrInvalidParameter, ErrorCodeInvalidInput},
+	{ErrDaggerEngineNotAvailable, ErrorCodeEngineUnavailable},
+	{ErrFindingsExceeded, ErrorCodeFindingsExceeded},
+}
+
+// ErrorCodeFor returns the code for err, matching wrapped sentinel errors. It
+// returns an empty code for a nil error and ErrorCodeExecutionFailed for
+// errors it does not recognize.
+func ErrorCodeFor(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	for _, entry := range errorCodes {
+		if errors.Is(err, entry.err) {
+			return entry.code
+		}
+	}
+	return ErrorCodeExecutionFailed
+}
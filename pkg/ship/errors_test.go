@@ -0,0 +1,96 @@
+package ship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudshipai/ship/pkg/dagger"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "tool not found", err: fmt.Errorf("%w: trivy", ErrToolNotFound), want: ErrorCodeToolNotFound},
+		{name: "tool unavailable", err: fmt.Errorf("pull aquasec/trivy: %w", ErrToolUnavailable), want: ErrorCodeToolUnavailable},
+		{name: "invalid input", err: fmt.Errorf("%w: severity must be HIGH or CRITICAL", ErrInvalidParameter), want: ErrorCodeInvalidInput},
+		{name: "engine", err: ErrDaggerEngineNotAvailable, want: ErrorCodeEngineUnavailable},
+		{name: "findings", err: fmt.Errorf("12 critical issues: %w", ErrFindingsExceeded), want: ErrorCodeFindingsExceeded},
+		{name: "executor", err: ErrExecutorNotSet, want: ErrorCodeNotConfigured},
+		{name: "unknown", err: errors.New("exit status 2"), want: ErrorCodeExecutionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCodeFor(tt.err))
+		})
+	}
+}
+
+func TestContainerTool_SetsErrorCode(t *testing.T) {
+	t.Run("executor not set", func(t *testing.T) {
+		result, err := NewContainerTool("empty", ContainerToolConfig{}).Execute(context.Background(), nil, nil)
+		assert.ErrorIs(t, err, ErrExecutorNotSet)
+		assert.Equal(t, ErrorCodeNotConfigured, result.ErrorCode)
+	})
+
+	t.Run("derived from result error", func(t *testing.T) {
+		tool := NewContainerTool("gate", ContainerToolConfig{
+			Execute: func(ctx context.Context, params map[string]interface{}, engine *dagger.Engine) (*ToolResult, error) {
+				return &ToolResult{Error: fmt.Errorf("3 high findings: %w", ErrFindingsExceeded)}, nil
+			},
+		})
+
+		result, err := tool.Execute(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ErrorCodeFindingsExceeded, result.ErrorCode)
+	})
+
+	t.Run("explicit code is kept", func(t *testing.T) {
+		tool := NewContainerTool("custom", ContainerToolConfig{
+			Execute: func(ctx context.Context, params map[string]interface{}, engine *dagger.Engine) (*ToolResult, error) {
+				return &ToolResult{Error: errors.New("image pull failed"), ErrorCode: ErrorCodeToolUnavailable}, nil
+			},
+		})
+
+		result, _ := tool.Execute(context.Background(), nil, nil)
+		assert.Equal(t, ErrorCodeToolUnavailable, result.ErrorCode)
+	})
+}
+
+func TestToolCallResult_IncludesErrorCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *ToolResult
+		err     error
+		want    ErrorCode
+		message string
+	}{
+		{name: "execute error", err: fmt.Errorf("%w: path", ErrInvalidParameter), want: ErrorCodeInvalidInput, message: "invalid parameter: path"},
+		{name: "result error", result: &ToolResult{Error: ErrDaggerEngineNotAvailable}, want: ErrorCodeEngineUnavailable, message: "dagger engine not available"},
+		{name: "result code wins", result: &ToolResult{Error: errors.New("remote failed"), ErrorCode: ErrorCodeExternalTool}, want: ErrorCodeExternalTool, message: "remote failed"},
+		{name: "no result", want: ErrorCodeExecutionFailed, message: "tool returned no result"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callResult := toolCallResult(tt.result, tt.err)
+			require.True(t, callResult.IsError)
+			assert.Equal(t, tt.message, callResult.Content[0].(mcp.TextContent).Text)
+			require.NotNil(t, callResult.Meta)
+			assert.Equal(t, string(tt.want), callResult.Meta.AdditionalFields[ErrorCodeMetaKey])
+		})
+	}
+
+	success := toolCallResult(&ToolResult{Content: "ok"}, nil)
+	assert.False(t, success.IsError)
+	assert.Nil(t, success.Meta)
+}
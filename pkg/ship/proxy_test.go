@@ -161,4 +161,32 @@ func TestHardcodedMCPServers(t *testing.T) {
 			t.Logf("Server %s has %d tools", name, len(tools))
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestMCPServerConfigRedactedEnv(t *testing.T) {
+	config := MCPServerConfig{
+		Name: "brave-search",
+		Env:  map[string]string{"BRAVE_API_KEY": "brv-6f1c2e9a", "BRAVE_SEARCH_COUNT": "10"},
+		Variables: []Variable{
+			{Name: "BRAVE_API_KEY", Secret: true},
+			{Name: "BRAVE_SEARCH_COUNT"},
+		},
+	}
+
+	assert.Equal(t, []string{"BRAVE_API_KEY=***", "BRAVE_SEARCH_COUNT=10"}, config.redactedEnv())
+}
+
+func TestMCPServerConfigRedactedArgs(t *testing.T) {
+	config := MCPServerConfig{
+		Name: "github",
+		Args: []string{"--token", "ghp-9a8b7c", "--header=Authorization: Bearer ghp-9a8b7c", "--owner", "acme"},
+		Env:  map[string]string{"GITHUB_TOKEN": "ghp-9a8b7c", "GITHUB_OWNER": "acme"},
+		Variables: []Variable{
+			{Name: "GITHUB_TOKEN", Secret: true},
+			{Name: "GITHUB_OWNER"},
+		},
+	}
+
+	assert.Equal(t, []string{"--token", "***", "--header=Authorization: Bearer ***", "--owner", "acme"}, config.redactedArgs())
+	assert.Equal(t, "ghp-9a8b7c", config.Args[1], "the args themselves are unchanged")
+}
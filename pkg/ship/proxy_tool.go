@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudshipai/ship/pkg/dagger"
@@ -18,7 +21,8 @@ type ProxyTool struct {
 	description string
 	parameters  []Parameter
 	client      client.MCPClient
-	toolName    string // The actual tool name on the external server
+	toolName    string    // The actual tool name on the external server
+	source      *MCPProxy // When set, calls use the proxy's current connection
 }
 
 // NewProxyTool creates a new proxy tool that forwards calls to an external MCP server
@@ -63,11 +67,23 @@ func (p *ProxyTool) Execute(ctx context.Context, params map[string]interface{},
 		},
 	}
 
-	// Forward the call to the external MCP server
-	response, err := p.client.CallTool(ctx, toolCallRequest)
+	// Forward the call to the external MCP server, over the proxy's current
+	// connection when the server may have been restarted
+	mcpClient := p.client
+	if p.source != nil {
+		mcpClient = p.source.currentClient()
+	}
+	if mcpClient == nil {
+		return &ToolResult{
+			Error:     fmt.Errorf("not connected to MCP server"),
+			ErrorCode: ErrorCodeExternalTool,
+		}, fmt.Errorf("failed to call external tool %s: not connected to MCP server", p.toolName)
+	}
+	response, err := mcpClient.CallTool(ctx, toolCallRequest)
 	if err != nil {
 		return &ToolResult{
-			Error: err,
+			Error:     err,
+			ErrorCode: ErrorCodeExternalTool,
 		}, fmt.Errorf("failed to call external tool %s: %w", p.toolName, err)
 	}
 
@@ -96,7 +112,8 @@ func (p *ProxyTool) Execute(ctx context.Context, params map[string]interface{},
 	// Check for errors in the response
 	if response.IsError {
 		return &ToolResult{
-			Error: fmt.Errorf("external tool error: %s", content),
+			Error:     fmt.Errorf("external tool error: %s", content),
+			ErrorCode: ErrorCodeExternalTool,
 		}, nil
 	}
 
@@ -127,10 +144,58 @@ type MCPServerConfig struct {
 	Variables   []Variable        `json:"variables,omitempty"` // Framework-defined variables
 }
 
+// redactedEnv lists the environment as KEY=value with the values of secret
+// variables replaced by ***
+func (c MCPServerConfig) redactedEnv() []string {
+	secret := make(map[string]bool)
+	for _, variable := range c.Variables {
+		if variable.Secret {
+			secret[variable.Name] = true
+		}
+	}
+
+	env := make([]string, 0, len(c.Env))
+	for key, value := range c.Env {
+		if secret[key] {
+			value = "***"
+		}
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(env)
+	return env
+}
+
+// redactedArgs returns the args with the values of secret variables, which
+// args can include through ${VAR} expansion, replaced by ***
+func (c MCPServerConfig) redactedArgs() []string {
+	args := append([]string{}, c.Args...)
+	for _, variable := range c.Variables {
+		value := c.Env[variable.Name]
+		if !variable.Secret || value == "" {
+			continue
+		}
+		for i := range args {
+			args[i] = strings.ReplaceAll(args[i], value, "***")
+		}
+	}
+	return args
+}
+
 // MCPProxy manages connections to external MCP servers and creates proxy tools
 type MCPProxy struct {
 	config MCPServerConfig
-	client client.MCPClient
+
+	mu         sync.Mutex
+	client     client.MCPClient
+	serverInfo mcp.Implementation
+}
+
+// ProxyToolDefinition describes a tool discovered on an external MCP server.
+// Definitions can be cached and turned back into proxy tools with ProxyTools.
+type ProxyToolDefinition struct {
+	Name        string      `json:"name"` // The tool name on the external server
+	Description string      `json:"description"`
+	Parameters  []Parameter `json:"parameters,omitempty"`
 }
 
 // NewMCPProxy creates a new MCP proxy for an external server
@@ -157,7 +222,7 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 		}
 
 		// Debug: Log the command being executed
-		fmt.Fprintf(os.Stderr, "[DEBUG] Creating stdio MCP client with command: %s %v\n", p.config.Command, p.config.Args)
+		fmt.Fprintf(os.Stderr, "[DEBUG] Creating stdio MCP client with command: %s %v\n", p.config.Command, p.config.redactedArgs())
 
 		// Convert env map to slice format
 		var envSlice []string
@@ -165,9 +230,9 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 			envSlice = append(envSlice, fmt.Sprintf("%s=%s", key, value))
 		}
 
-		// Debug: Log environment variables
+		// Debug: Log environment variables, without secret values
 		if len(envSlice) > 0 {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Environment variables: %v\n", envSlice)
+			fmt.Fprintf(os.Stderr, "[DEBUG] Environment variables: %v\n", p.config.redactedEnv())
 		}
 
 		mcpClient, err = client.NewStdioMCPClient(p.config.Command, envSlice, p.config.Args...)
@@ -204,6 +269,7 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 	initCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	var serverInfo mcp.Implementation
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = mcp.Implementation{
@@ -217,9 +283,11 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 
 		// For stdio transport, try to detect if the process is responsive
 		done := make(chan error, 1)
+		var initResult *mcp.InitializeResult
 		go func() {
 			fmt.Fprintf(os.Stderr, "[DEBUG] Starting MCP initialization in goroutine...\n")
-			_, err := mcpClient.Initialize(initCtx, initRequest)
+			var err error
+			initResult, err = mcpClient.Initialize(initCtx, initRequest)
 			fmt.Fprintf(os.Stderr, "[DEBUG] MCP initialization completed with error: %v\n", err)
 			done <- err
 		}()
@@ -232,6 +300,7 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 				return fmt.Errorf("failed to initialize MCP connection: %w", err)
 			}
 			fmt.Fprintf(os.Stderr, "[DEBUG] MCP initialization successful\n")
+			serverInfo = initResult.ServerInfo
 		case <-initCtx.Done():
 			fmt.Fprintf(os.Stderr, "[DEBUG] MCP initialization timed out\n")
 			mcpClient.Close()
@@ -239,34 +308,86 @@ func (p *MCPProxy) Connect(ctx context.Context) error {
 		}
 	} else {
 		// For other transport types, use normal initialization
-		_, err = mcpClient.Initialize(initCtx, initRequest)
+		initResult, err := mcpClient.Initialize(initCtx, initRequest)
 		if err != nil {
 			mcpClient.Close()
 			return fmt.Errorf("failed to initialize MCP connection: %w", err)
 		}
+		serverInfo = initResult.ServerInfo
 	}
 
+	p.mu.Lock()
 	p.client = mcpClient
+	p.serverInfo = serverInfo
+	p.mu.Unlock()
 	fmt.Fprintf(os.Stderr, "[DEBUG] MCP proxy connection established successfully\n")
 	return nil
 }
 
+// ServerVersion returns the version the external server reported when connecting
+func (p *MCPProxy) ServerVersion() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.serverInfo.Version
+}
+
+// currentClient returns the connection to the external server, which changes
+// when the server is restarted
+func (p *MCPProxy) currentClient() client.MCPClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.client
+}
+
+// Ping checks that the external server is alive and responding
+func (p *MCPProxy) Ping(ctx context.Context) error {
+	mcpClient := p.currentClient()
+	if mcpClient == nil {
+		return fmt.Errorf("not connected to MCP server")
+	}
+	return mcpClient.Ping(ctx)
+}
+
+// Restart closes the connection to the external server, which for stdio
+// servers stops the server process, and connects again. Proxy tools created
+// with ProxyTools use the new connection.
+func (p *MCPProxy) Restart(ctx context.Context) error {
+	p.mu.Lock()
+	old := p.client
+	p.client = nil
+	p.mu.Unlock()
+
+	if old != nil {
+		// The server has usually exited already, so closing reports its exit status
+		old.Close()
+	}
+	return p.Connect(ctx)
+}
+
 // DiscoverTools queries the external server for available tools and returns proxy tools
 func (p *MCPProxy) DiscoverTools(ctx context.Context) ([]Tool, error) {
-	if p.client == nil {
+	definitions, err := p.DiscoverToolDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ProxyTools(definitions), nil
+}
+
+// DiscoverToolDefinitions queries the external server for the definitions of its tools
+func (p *MCPProxy) DiscoverToolDefinitions(ctx context.Context) ([]ProxyToolDefinition, error) {
+	mcpClient := p.currentClient()
+	if mcpClient == nil {
 		return nil, fmt.Errorf("not connected to MCP server")
 	}
 
 	// List tools from the external server
 	listToolsRequest := mcp.ListToolsRequest{}
-	toolsResponse, err := p.client.ListTools(ctx, listToolsRequest)
+	toolsResponse, err := mcpClient.ListTools(ctx, listToolsRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tools from external server: %w", err)
 	}
 
-	var proxyTools []Tool
-
-	// Create proxy tools for each discovered tool
+	var definitions []ProxyToolDefinition
 	for _, tool := range toolsResponse.Tools {
 		// Convert MCP tool parameters to Ship parameters
 		var shipParams []Parameter
@@ -293,31 +414,45 @@ func (p *MCPProxy) DiscoverTools(ctx context.Context) ([]Tool, error) {
 			}
 		}
 
-		// Create proxy tool with namespace prefix
-		proxyToolName := fmt.Sprintf("%s_%s", p.config.Name, tool.Name)
 		description := tool.Description
 		if description == "" {
 			description = fmt.Sprintf("Proxied tool %s from %s", tool.Name, p.config.Name)
 		}
 
+		definitions = append(definitions, ProxyToolDefinition{
+			Name:        tool.Name,
+			Description: description,
+			Parameters:  shipParams,
+		})
+	}
+
+	return definitions, nil
+}
+
+// ProxyTools creates proxy tools for tool definitions that forward calls
+// through this proxy's connection
+func (p *MCPProxy) ProxyTools(definitions []ProxyToolDefinition) []Tool {
+	var proxyTools []Tool
+	for _, definition := range definitions {
+		// Create proxy tool with namespace prefix
+		proxyToolName := fmt.Sprintf("%s_%s", p.config.Name, definition.Name)
 		proxyTool := NewProxyTool(
 			proxyToolName,
-			description,
-			shipParams,
-			p.client,
-			tool.Name, // Original tool name for the external server
+			definition.Description,
+			definition.Parameters,
+			nil,
+			definition.Name, // Original tool name for the external server
 		)
-
+		proxyTool.source = p
 		proxyTools = append(proxyTools, proxyTool)
 	}
-
-	return proxyTools, nil
+	return proxyTools
 }
 
 // Close closes the connection to the external MCP server
 func (p *MCPProxy) Close() error {
-	if p.client != nil {
-		return p.client.Close()
+	if mcpClient := p.currentClient(); mcpClient != nil {
+		return mcpClient.Close()
 	}
 	return nil
 }
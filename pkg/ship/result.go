@@ -0,0 +1,38 @@
+package ship
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ErrorCodeMetaKey is the MCP result metadata field carrying a tool's ErrorCode
+const ErrorCodeMetaKey = "error_code"
+
+// toolCallResult converts a tool execution into an MCP result. Failures are
+// error results whose metadata carries the ErrorCode.
+func toolCallResult(result *ToolResult, err error) *mcp.CallToolResult {
+	switch {
+	case err != nil:
+		code := ErrorCodeFor(err)
+		if result != nil && result.ErrorCode != "" {
+			code = result.ErrorCode
+		}
+		return NewToolErrorResult(code, err.Error())
+	case result == nil:
+		return NewToolErrorResult(ErrorCodeExecutionFailed, "tool returned no result")
+	case result.Error != nil:
+		code := result.ErrorCode
+		if code == "" {
+			code = ErrorCodeFor(result.Error)
+		}
+		return NewToolErrorResult(code, result.Error.Error())
+	default:
+		return mcp.NewToolResultText(result.Content)
+	}
+}
+
+// NewToolErrorResult builds an MCP error result tagged with code
+func NewToolErrorResult(code ErrorCode, message string) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(message)
+	result.Meta = mcp.NewMetaFromMap(map[string]any{ErrorCodeMetaKey: string(code)})
+	return result
+}
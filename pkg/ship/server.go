@@ -110,6 +110,13 @@ func (s *MCPServer) Start(ctx context.Context) error {
 
 	s.engine = engine
 
+	return s.StartWithoutEngine()
+}
+
+// StartWithoutEngine initializes the server without a Dagger engine, for
+// servers whose tools run no containers, such as proxies of external MCP
+// servers. Tools are executed with a nil engine.
+func (s *MCPServer) StartWithoutEngine() error {
 	// Create MCP server
 	s.server = server.NewMCPServer(s.name, s.version)
 
@@ -165,6 +172,18 @@ func (s *MCPServer) Close() error {
 	return lastErr
 }
 
+// AddTool registers a tool on a server that may already be started; a
+// started server announces it to connected clients
+func (s *MCPServer) AddTool(tool Tool) error {
+	if err := s.registry.RegisterTool(tool); err != nil {
+		return err
+	}
+	if s.server != nil {
+		s.registerMCPTool(tool.Name(), tool)
+	}
+	return nil
+}
+
 // GetRegistry returns the server's registry
 func (s *MCPServer) GetRegistry() *Registry {
 	return s.registry
@@ -239,22 +258,14 @@ func (s *MCPServer) registerMCPTool(name string, tool Tool) {
 		for _, param := range tool.Parameters() {
 			if param.Required {
 				if _, exists := params[param.Name]; !exists {
-					return mcp.NewToolResultError(fmt.Sprintf("required parameter '%s' is missing", param.Name)), nil
+					return NewToolErrorResult(ErrorCodeInvalidInput, fmt.Sprintf("required parameter '%s' is missing", param.Name)), nil
 				}
 			}
 		}
 
 		// Execute the tool
 		result, err := tool.Execute(ctx, params, s.engine)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-
-		if result.Error != nil {
-			return mcp.NewToolResultError(result.Error.Error()), nil
-		}
-
-		return mcp.NewToolResultText(result.Content), nil
+		return toolCallResult(result, err), nil
 	})
 }
 
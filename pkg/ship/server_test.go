@@ -191,6 +191,17 @@ func TestMCPServer_Start(t *testing.T) {
 	})
 }
 
+func TestMCPServer_StartWithoutEngine(t *testing.T) {
+	server := NewServer("test-server", "1.0.0").
+		AddTool(createTestTool("test-tool", "Test tool")).
+		Build()
+
+	require.NoError(t, server.StartWithoutEngine())
+	assert.Nil(t, server.engine)
+	assert.NotNil(t, server.server)
+	assert.NoError(t, server.Close())
+}
+
 func TestMCPServer_Close(t *testing.T) {
 	builder := NewServer("test-server", "1.0.0")
 	server := builder.Build()
@@ -23,11 +23,13 @@ type Parameter struct {
 	Enum        []string `json:"enum,omitempty"`
 }
 
-// ToolResult represents the result of a tool execution
+// ToolResult represents the result of a tool execution. ErrorCode classifies
+// Error; when a tool leaves it empty it is derived from Error with ErrorCodeFor.
 type ToolResult struct {
-	Content  string                 `json:"content"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	Error    error                  `json:"error,omitempty"`
+	Content   string                 `json:"content"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Error     error                  `json:"error,omitempty"`
+	ErrorCode ErrorCode              `json:"error_code,omitempty"`
 }
 
 // ToolExecutor is a function type for executing container-based tools
@@ -83,10 +85,15 @@ func (t *ContainerTool) Parameters() []Parameter {
 func (t *ContainerTool) Execute(ctx context.Context, params map[string]interface{}, engine *dagger.Engine) (*ToolResult, error) {
 	if t.executor == nil {
 		return &ToolResult{
-			Content: "",
-			Error:   ErrExecutorNotSet,
+			Content:   "",
+			Error:     ErrExecutorNotSet,
+			ErrorCode: ErrorCodeNotConfigured,
 		}, ErrExecutorNotSet
 	}
 
-	return t.executor(ctx, params, engine)
+	result, err := t.executor(ctx, params, engine)
+	if result != nil && result.Error != nil && result.ErrorCode == "" {
+		result.ErrorCode = ErrorCodeFor(result.Error)
+	}
+	return result, err
 }